@@ -3,6 +3,9 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
 
 	"github.com/joho/godotenv"
 	"github.com/pkg/errors"
@@ -64,7 +67,7 @@ func GetApp(log *zerolog.Logger) (auth.ITokenManager, facade.IFacade, storage.IS
 		log.Fatal().Err(err).Msg("Failed to make connection")
 	}
 
-	facadeClient := facade.NewFacade(conn, tokenManager, log)
+	facadeClient := facade.NewFacade(conn, tokenManager, log, cfg.SecuredMasterKey)
 	storageManager := storage.NewStorageManager(facadeClient, tokenManager, log)
 
 	return tokenManager, facadeClient, storageManager, conn
@@ -93,7 +96,7 @@ func LoadConfig(log *zerolog.Logger) *config.Config {
 }
 
 func MakeConnection(cfg config.Config, interceptor *interceptors.AuthInterceptor) (*grpc.ClientConn, error) {
-	creds, err := credentials.NewClientTLSFromFile(cfg.Certificate, "")
+	creds, err := transportCredentials(cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not load TLS keys")
 	}
@@ -110,3 +113,35 @@ func MakeConnection(cfg config.Config, interceptor *interceptors.AuthInterceptor
 
 	return conn, nil
 }
+
+// transportCredentials trusts the server certificate and, when one is configured, presents the client
+// certificate.
+//
+//nolint:ireturn
+func transportCredentials(cfg config.Config) (credentials.TransportCredentials, error) {
+	if cfg.ClientCertificate == "" {
+		//nolint:wrapcheck
+		return credentials.NewClientTLSFromFile(cfg.Certificate, "")
+	}
+
+	pemCerts, err := os.ReadFile(cfg.Certificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read server certificate")
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pemCerts) {
+		return nil, errors.New("no certificates found in the server certificate file")
+	}
+
+	clientCert, err := tls.LoadX509KeyPair(cfg.ClientCertificate, cfg.ClientKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load client certificate")
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		RootCAs:      roots,
+		Certificates: []tls.Certificate{clientCert},
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
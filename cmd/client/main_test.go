@@ -77,6 +77,35 @@ func TestMakeConnection(t *testing.T) {
 	})
 }
 
+func TestMakeConnection_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	ca := testutils.NewTestCA(t)
+	clientCert, clientKey := testutils.WriteKeyPair(t, ca.Issue(t, "ci", "spiffe://example.org/ci"))
+
+	cfg := config.Config{
+		Address:           "localhost:50051",
+		Certificate:       ca.WritePEM(t),
+		ClientCertificate: clientCert,
+		ClientKey:         clientKey,
+	}
+
+	conn, err := MakeConnection(cfg, &interceptors.AuthInterceptor{})
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	// The key does not match the certificate
+	_, cfg.ClientKey = testutils.WriteKeyPair(t, ca.Issue(t, "other", ""))
+
+	_, err = MakeConnection(cfg, &interceptors.AuthInterceptor{})
+	require.ErrorContains(t, err, "could not load client certificate")
+
+	cfg.Certificate = clientKey
+
+	_, err = MakeConnection(cfg, &interceptors.AuthInterceptor{})
+	require.ErrorContains(t, err, "no certificates found")
+}
+
 // Note: Testing GetApp and GetTUI would require extensive mocking of all dependencies.
 // In practice, you might want to test these components separately through their own packages.
 
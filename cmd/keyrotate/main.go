@@ -0,0 +1,89 @@
+// Command keyrotate re-wraps every user encryption key and TOTP secret with the current master key.
+// It then rotates the key of every user whose vault still holds values not bound to their records;
+// the servers re-encrypt those.
+//
+// Rotate with servers online:
+//  1. Make the new key current on every server, e.g. MASTER_KEY="k2:<new>,<old>", and restart them.
+//     With KMS=transit the key is rotated in Transit instead; with KMS=pkcs11 PKCS11_KEY_LABEL changes.
+//  2. Run keyrotate with the same settings.
+//  3. Once it reports no errors, retire the old key.
+//
+// Switching from MASTER_KEY to another provider works the same way: keep MASTER_KEY set while rotating.
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/npavlov/go-password-manager/internal/pkg/logger"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/dbmanager"
+	"github.com/npavlov/go-password-manager/internal/server/keyrotation"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/storage"
+	commonUtils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+const (
+	batchSize  = 500
+	batchPause = 200 * time.Millisecond
+)
+
+var ErrDatabaseNotConnected = errors.New("database is not connected")
+
+func main() {
+	log := logger.NewLogger(zerolog.InfoLevel).Get()
+
+	if err := godotenv.Load("server.env"); err != nil {
+		log.Error().Err(err).Msg("Error loading server.env file")
+	}
+
+	cfg := config.NewConfigBuilder(&log).FromEnv().FromFlags().Build()
+
+	ctx, cancel := commonUtils.WithSignalCancel(context.Background(), &log)
+	defer cancel()
+
+	dbManager := dbmanager.NewDBManager(cfg.Database, &log).Connect(ctx)
+	if dbManager.DB == nil {
+		log.Fatal().Err(ErrDatabaseNotConnected).Msg("Failed to connect to database")
+	}
+	defer dbManager.Close()
+
+	stats, err := rotate(ctx, cfg, dbManager.VerifyConnection(ctx).DB, &log)
+	if err != nil {
+		log.Error().Err(err).Int("scanned", stats.Scanned).Int("rewrapped", stats.Rewrapped).
+			Msg("Master key rotation failed; it can be run again")
+
+		return
+	}
+
+	log.Info().Int("scanned", stats.Scanned).Int("rewrapped", stats.Rewrapped).Int("skipped", stats.Skipped).
+		Int("rebinding", stats.Rebinding).Msg("Master key rotation finished")
+}
+
+func rotate(
+	ctx context.Context,
+	cfg *config.Config,
+	pool dbmanager.PgxPool,
+	log *zerolog.Logger,
+) (keyrotation.Stats, error) {
+	if pool == nil {
+		return keyrotation.Stats{}, ErrDatabaseNotConnected
+	}
+
+	keys, err := kms.New(cfg.KMSOptions())
+	if err != nil {
+		return keyrotation.Stats{}, errors.Wrap(err, "error setting up key provider")
+	}
+
+	log.Info().Str("kms", cfg.KMS).Msg("Rotating user keys")
+
+	rotator := keyrotation.NewRotator(storage.NewDBStorage(pool, log), keys, log, batchSize, batchPause)
+
+	//nolint:wrapcheck
+	return rotator.Run(ctx)
+}
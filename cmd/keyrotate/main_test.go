@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/keyrotation"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	commonUtils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+
+	oldKey, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+	newKey, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	encrypted, err := utils.Encrypt("user-key", oldKey)
+	require.NoError(t, err)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	userID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	mock.ExpectQuery("SELECT id, encryption_key FROM users").
+		WithArgs(pgtype.UUID{Valid: true}, int32(batchSize)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "encryption_key"}).AddRow(userID, encrypted))
+	mock.ExpectExec("UPDATE users").
+		WithArgs(pgxmock.AnyArg(), userID, encrypted).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery("SELECT id, totp_secret").
+		WithArgs(pgtype.UUID{Valid: true}, int32(batchSize)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "totp_secret"}))
+	mock.ExpectQuery("SELECT id, key_version FROM users").
+		WithArgs(pgtype.UUID{Valid: true}, int32(batchSize)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "key_version"}))
+
+	cfg := &config.Config{SecuredMasterKey: commonUtils.NewString("k2:" + newKey + "," + oldKey)}
+	log := zerolog.Nop()
+
+	stats, err := rotate(t.Context(), cfg, mock, &log)
+	require.NoError(t, err)
+	assert.Equal(t, keyrotation.Stats{Scanned: 1, Rewrapped: 1}, stats)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRotate_Errors(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+
+	_, err := rotate(t.Context(), &config.Config{SecuredMasterKey: commonUtils.NewString("")}, nil, &log)
+	require.ErrorIs(t, err, ErrDatabaseNotConnected)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	_, err = rotate(t.Context(), &config.Config{KMS: "unknown"}, mock, &log)
+	require.ErrorIs(t, err, kms.ErrUnknownProvider)
+}
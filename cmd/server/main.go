@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"sync"
+	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
@@ -14,25 +16,43 @@ import (
 
 	"github.com/npavlov/go-password-manager/internal/pkg/logger"
 	"github.com/npavlov/go-password-manager/internal/server/adapter"
+	"github.com/npavlov/go-password-manager/internal/server/apitoken"
+	"github.com/npavlov/go-password-manager/internal/server/auditlog"
 	"github.com/npavlov/go-password-manager/internal/server/buildinfo"
+	"github.com/npavlov/go-password-manager/internal/server/clientcert"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/dbmanager"
+	"github.com/npavlov/go-password-manager/internal/server/emergency"
+	"github.com/npavlov/go-password-manager/internal/server/jwtkeys"
+	"github.com/npavlov/go-password-manager/internal/server/keyrotation"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
 	"github.com/npavlov/go-password-manager/internal/server/redis"
 	"github.com/npavlov/go-password-manager/internal/server/service"
+	"github.com/npavlov/go-password-manager/internal/server/service/audit"
 	"github.com/npavlov/go-password-manager/internal/server/service/auth"
 	"github.com/npavlov/go-password-manager/internal/server/service/card"
 	"github.com/npavlov/go-password-manager/internal/server/service/file"
 	"github.com/npavlov/go-password-manager/internal/server/service/item"
 	"github.com/npavlov/go-password-manager/internal/server/service/meta"
 	"github.com/npavlov/go-password-manager/internal/server/service/note"
+	"github.com/npavlov/go-password-manager/internal/server/service/organization"
 	"github.com/npavlov/go-password-manager/internal/server/service/password"
+	"github.com/npavlov/go-password-manager/internal/server/service/send"
 	"github.com/npavlov/go-password-manager/internal/server/storage"
 	"github.com/npavlov/go-password-manager/internal/utils"
 )
 
+// Re-encryption after a user key rotation runs alongside regular traffic, so it works in small batches.
+const (
+	reencryptBatchSize = 100
+	reencryptPause     = 100 * time.Millisecond
+)
+
+const jwksReadHeaderTimeout = 5 * time.Second
+
 var (
 	ErrDatabaseNotConnected = errors.New("database is not connected")
-	ErrJWTisNotPorvided     = errors.New("JWT token is not provided")
 	ErrMinioNotConnected    = errors.New("MinIO is not connected")
 )
 
@@ -58,9 +78,21 @@ func main() {
 	defer dbManager.Close()
 	setBucket(ctx, cfg, minioClient)
 
+	grpcServer, reencryptor, tokenKeys, promoter, sends := startServer(ctx, cfg, &log, dbManager, minioClient)
+	if err := tokenKeys.Start(ctx); err != nil {
+		cancel()
+		dbManager.Close()
+		log.Fatal().Err(err).Msg("Failed to load token signing keys")
+	}
+
 	wg.Add(1)
-	grpcServer := startServer(ctx, cfg, &log, dbManager, minioClient)
 	grpcServer.Start(ctx, &wg)
+	startJWKS(ctx, cfg, &log, tokenKeys, &wg)
+
+	go reencryptor.Run(ctx)
+	go tokenKeys.Run(ctx)
+	go promoter.Run(ctx)
+	go sends.Run(ctx)
 
 	utils.WaitForShutdown(&wg)
 }
@@ -73,10 +105,20 @@ func loadConfig(log *zerolog.Logger) *config.Config {
 	cfg := config.NewConfigBuilder(log).FromEnv().FromFlags().Build()
 	log.Info().Interface("config", cfg).Msg("Configuration loaded")
 
-	if cfg.JwtSecret == "" {
-		panic(ErrJWTisNotPorvided)
+	if err := cfg.TokenOptions().Validate(); err != nil {
+		panic(errors.Wrap(err, "error in token signing settings"))
 	}
 
+	if err := cfg.ClientCertOptions().Validate(); err != nil {
+		panic(errors.Wrap(err, "error in client certificate settings"))
+	}
+
+	keyProvider, err := kms.New(cfg.KMSOptions())
+	if err != nil {
+		panic(errors.Wrap(err, "error setting up key provider"))
+	}
+	cfg.KeyProvider = keyProvider
+
 	return cfg
 }
 
@@ -86,14 +128,35 @@ func startServer(
 	log *zerolog.Logger,
 	dbM *dbmanager.DBManager,
 	minioClient *minio.Client,
-) *service.GManager {
+) (*service.GManager, *keyrotation.Reencryptor, *jwtkeys.Keys, *emergency.Promoter, *send.Service) {
 	dbStorage, memStorage := setupStorage(ctx, cfg, dbM, log)
 
+	// Access tokens outlive the key that signed them by at most their own lifetime.
+	tokenOptions := cfg.TokenOptions()
+	tokenOptions.Retention = auth.TokenExpiration
+	tokenKeys := jwtkeys.New(dbStorage, cfg.Keys(), tokenOptions, log)
+	apiTokens := apitoken.NewResolver(dbStorage, log)
+
+	clientCerts, err := clientcert.NewResolver(dbStorage, cfg.ClientCertOptions(), log)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load client certificate identities")
+	}
+
+	auditor := auditlog.NewRecorder(dbStorage)
+
 	//nolint:contextcheck
-	grpcManager := service.NewGRPCManager(cfg, log, memStorage)
+	grpcManager := service.NewGRPCManager(cfg, log, memStorage, memStorage, tokenKeys, apiTokens, clientCerts,
+		auditor, dbStorage)
 	grpcServer := grpcManager.GetServer()
 
-	authService := auth.NewAuthService(log, dbStorage, cfg, memStorage)
+	objectStorage := adapter.NewMinioAdapter(minioClient)
+
+	reencryptor := keyrotation.NewReencryptor(dbStorage, objectStorage, cfg.Bucket, cfg.Keys(), log,
+		reencryptBatchSize, reencryptPause)
+
+	logins := ratelimit.NewLoginGuard(memStorage, cfg.LoginLimits(), log)
+
+	authService := auth.NewAuthService(log, dbStorage, cfg, memStorage, reencryptor, logins, objectStorage, tokenKeys)
 	authService.RegisterService(grpcServer)
 
 	passwordService := password.NewPasswordService(log, dbStorage, cfg)
@@ -105,16 +168,68 @@ func startServer(
 	cardService := card.NewCardService(log, dbStorage, cfg)
 	cardService.RegisterService(grpcServer)
 
-	fileService := file.NewFileService(log, dbStorage, cfg, adapter.NewMinioAdapter(minioClient))
+	fileService := file.NewFileService(log, dbStorage, cfg, objectStorage)
 	fileService.RegisterService(grpcServer)
 
-	itemService := item.NewItemService(log, dbStorage, cfg)
+	itemService := item.NewItemService(log, dbStorage, cfg, memStorage)
 	itemService.RegisterService(grpcServer)
 
 	metaService := meta.NewMetadataService(log, dbStorage, cfg)
 	metaService.RegisterService(grpcServer)
 
-	return grpcManager
+	organizationService := organization.NewOrganizationService(log, dbStorage, cfg)
+	organizationService.RegisterService(grpcServer)
+
+	sendService := send.NewSendService(log, dbStorage, cfg, objectStorage)
+	sendService.RegisterService(grpcServer)
+
+	auditService := audit.NewAuditService(log, dbStorage, cfg)
+	auditService.RegisterService(grpcServer)
+
+	promoter := emergency.NewPromoter(dbStorage, cfg.EmergencyCheck, log)
+
+	return grpcManager, reencryptor, tokenKeys, promoter, sendService
+}
+
+// startJWKS serves the public token keys over HTTP, so that other services can verify access tokens.
+func startJWKS(ctx context.Context, cfg *config.Config, log *zerolog.Logger, keys *jwtkeys.Keys, wg *sync.WaitGroup) {
+	if cfg.JWKSAddress == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(jwtkeys.JWKSPath, keys)
+
+	//nolint:exhaustruct
+	server := &http.Server{
+		Addr:              cfg.JWKSAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: jwksReadHeaderTimeout,
+	}
+
+	wg.Add(1)
+
+	go func() {
+		log.Info().Str("address", cfg.JWKSAddress).Msg("starting JWKS server")
+
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal().Err(err).Msg("failed to serve JWKS")
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		defer wg.Done()
+		log.Info().Msg("shutting down JWKS server")
+
+		//nolint:contextcheck
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), jwksReadHeaderTimeout)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("error shutting down JWKS server")
+		}
+	}()
 }
 
 func setupDatabase(ctx context.Context, cfg *config.Config, log *zerolog.Logger) *dbmanager.DBManager {
@@ -127,13 +242,12 @@ func setupDatabase(ctx context.Context, cfg *config.Config, log *zerolog.Logger)
 	return dbManager
 }
 
-//nolint:ireturn
 func setupStorage(
 	ctx context.Context,
 	cfg *config.Config,
 	dbManager *dbmanager.DBManager,
 	log *zerolog.Logger,
-) (*storage.DBStorage, redis.MemStorage) {
+) (*storage.DBStorage, *redis.RStorage) {
 	st := storage.NewDBStorage(dbManager.DB, log)
 	memStorage := redis.NewRStorage(*cfg, log)
 
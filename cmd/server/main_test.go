@@ -44,9 +44,19 @@ func TestLoadConfig_Fail(t *testing.T) {
 	tmpFile.Close()
 
 	t.Setenv("ENV_FILE", tmpFile.Name())
+	t.Setenv("JWT_ALGORITHM", "HS256")
 
 	log := zerolog.Nop()
-	assert.PanicsWithValue(t, ErrJWTisNotPorvided, func() {
+	assert.Panics(t, func() {
+		loadConfig(&log)
+	})
+}
+
+func TestLoadConfig_ClientCertificatesWithoutCA(t *testing.T) {
+	t.Setenv("CLIENT_AUTH", "required")
+
+	log := zerolog.Nop()
+	assert.PanicsWithError(t, "error in client certificate settings: client certificates need a client CA", func() {
 		loadConfig(&log)
 	})
 }
@@ -190,7 +200,11 @@ func TestStartServerSuccess(t *testing.T) {
 	client, err := setupMinIO(cfg)
 	require.NoError(t, err)
 
-	grpcManager := startServer(t.Context(), cfg, &log, dbMgr, client)
+	grpcManager, reencryptor, tokenKeys, promoter, sends := startServer(t.Context(), cfg, &log, dbMgr, client)
 
 	assert.NotNil(t, grpcManager)
+	assert.NotNil(t, reencryptor)
+	assert.NotNil(t, tokenKeys)
+	assert.NotNil(t, promoter)
+	assert.NotNil(t, sends)
 }
@@ -0,0 +1,58 @@
+// Command unlock lifts the temporary lock of an account after too many failed logins and forgets
+// its failed logins, so the user can log in again right away:
+//
+//	unlock -user alice
+//
+// It connects to the Redis of the servers, configured like them in server.env.
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/joho/godotenv"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/npavlov/go-password-manager/internal/pkg/logger"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
+	"github.com/npavlov/go-password-manager/internal/server/redis"
+	commonUtils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+var ErrNoUsername = errors.New("username is required, see -user")
+
+func main() {
+	log := logger.NewLogger(zerolog.InfoLevel).Get()
+
+	if err := godotenv.Load("server.env"); err != nil {
+		log.Error().Err(err).Msg("Error loading server.env file")
+	}
+
+	username := flag.String("user", "", "username to unlock")
+	flag.Parse()
+
+	cfg := config.NewConfigBuilder(&log).FromEnv().Build()
+
+	ctx, cancel := commonUtils.WithSignalCancel(context.Background(), &log)
+	defer cancel()
+
+	memStorage := redis.NewRStorage(*cfg, &log)
+	if err := memStorage.Ping(ctx); err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to Redis")
+	}
+
+	if err := unlock(ctx, ratelimit.NewLoginGuard(memStorage, cfg.LoginLimits(), &log), *username); err != nil {
+		log.Error().Err(err).Msg("Unlocking the account failed")
+	}
+}
+
+func unlock(ctx context.Context, guard *ratelimit.LoginGuard, username string) error {
+	if username == "" {
+		return ErrNoUsername
+	}
+
+	//nolint:wrapcheck
+	return guard.Unlock(ctx, username)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestUnlock(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.Nop()
+	guard := ratelimit.NewLoginGuard(testutils.NewMockRedis(), ratelimit.Limits{
+		MaxFailures:   1,
+		FailureWindow: time.Minute,
+		Lockout:       time.Hour,
+	}, &log)
+
+	require.NoError(t, guard.Check(t.Context(), "alice", ""))
+	require.NoError(t, guard.Failed(t.Context(), "alice"))
+	require.Error(t, guard.Check(t.Context(), "alice", ""))
+
+	require.ErrorIs(t, unlock(t.Context(), guard, ""), ErrNoUsername)
+	require.Error(t, guard.Check(t.Context(), "alice", ""))
+
+	require.NoError(t, unlock(t.Context(), guard, "alice"))
+	require.NoError(t, guard.Check(t.Context(), "alice", ""))
+}
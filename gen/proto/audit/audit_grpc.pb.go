@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/audit/audit.proto
+
+package audit
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AuditService_GetAuditLogV1_FullMethodName    = "/proto.audit.AuditService/GetAuditLogV1"
+	AuditService_ExportAuditLogV1_FullMethodName = "/proto.audit.AuditService/ExportAuditLogV1"
+)
+
+// AuditServiceClient is the client API for AuditService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AuditService reads the audit log, which records every call made to the server. Each event carries the
+// hash of the event before it, so editing or removing events breaks the chain.
+type AuditServiceClient interface {
+	// List the events of the calls the caller made and of the calls on items in the caller's vault, the newest first.
+	GetAuditLogV1(ctx context.Context, in *GetAuditLogV1Request, opts ...grpc.CallOption) (*GetAuditLogV1Response, error)
+	// Export the whole log as JSON Lines, checking the chain on the way. Admins only.
+	ExportAuditLogV1(ctx context.Context, in *ExportAuditLogV1Request, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportAuditLogV1Response], error)
+}
+
+type auditServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuditServiceClient(cc grpc.ClientConnInterface) AuditServiceClient {
+	return &auditServiceClient{cc}
+}
+
+func (c *auditServiceClient) GetAuditLogV1(ctx context.Context, in *GetAuditLogV1Request, opts ...grpc.CallOption) (*GetAuditLogV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAuditLogV1Response)
+	err := c.cc.Invoke(ctx, AuditService_GetAuditLogV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auditServiceClient) ExportAuditLogV1(ctx context.Context, in *ExportAuditLogV1Request, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportAuditLogV1Response], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AuditService_ServiceDesc.Streams[0], AuditService_ExportAuditLogV1_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportAuditLogV1Request, ExportAuditLogV1Response]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuditService_ExportAuditLogV1Client = grpc.ServerStreamingClient[ExportAuditLogV1Response]
+
+// AuditServiceServer is the server API for AuditService service.
+// All implementations must embed UnimplementedAuditServiceServer
+// for forward compatibility.
+//
+// AuditService reads the audit log, which records every call made to the server. Each event carries the
+// hash of the event before it, so editing or removing events breaks the chain.
+type AuditServiceServer interface {
+	// List the events of the calls the caller made and of the calls on items in the caller's vault, the newest first.
+	GetAuditLogV1(context.Context, *GetAuditLogV1Request) (*GetAuditLogV1Response, error)
+	// Export the whole log as JSON Lines, checking the chain on the way. Admins only.
+	ExportAuditLogV1(*ExportAuditLogV1Request, grpc.ServerStreamingServer[ExportAuditLogV1Response]) error
+	mustEmbedUnimplementedAuditServiceServer()
+}
+
+// UnimplementedAuditServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuditServiceServer struct{}
+
+func (UnimplementedAuditServiceServer) GetAuditLogV1(context.Context, *GetAuditLogV1Request) (*GetAuditLogV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLogV1 not implemented")
+}
+func (UnimplementedAuditServiceServer) ExportAuditLogV1(*ExportAuditLogV1Request, grpc.ServerStreamingServer[ExportAuditLogV1Response]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAuditLogV1 not implemented")
+}
+func (UnimplementedAuditServiceServer) mustEmbedUnimplementedAuditServiceServer() {}
+func (UnimplementedAuditServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAuditServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuditServiceServer will
+// result in compilation errors.
+type UnsafeAuditServiceServer interface {
+	mustEmbedUnimplementedAuditServiceServer()
+}
+
+func RegisterAuditServiceServer(s grpc.ServiceRegistrar, srv AuditServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuditServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuditService_ServiceDesc, srv)
+}
+
+func _AuditService_GetAuditLogV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditLogV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditServiceServer).GetAuditLogV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuditService_GetAuditLogV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditServiceServer).GetAuditLogV1(ctx, req.(*GetAuditLogV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuditService_ExportAuditLogV1_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAuditLogV1Request)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuditServiceServer).ExportAuditLogV1(m, &grpc.GenericServerStream[ExportAuditLogV1Request, ExportAuditLogV1Response]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuditService_ExportAuditLogV1Server = grpc.ServerStreamingServer[ExportAuditLogV1Response]
+
+// AuditService_ServiceDesc is the grpc.ServiceDesc for AuditService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuditService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.audit.AuditService",
+	HandlerType: (*AuditServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAuditLogV1",
+			Handler:    _AuditService_GetAuditLogV1_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAuditLogV1",
+			Handler:       _AuditService_ExportAuditLogV1_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/audit/audit.proto",
+}
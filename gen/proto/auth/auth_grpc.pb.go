@@ -19,9 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_RegisterV1_FullMethodName     = "/proto.auth.AuthService/RegisterV1"
-	AuthService_LoginV1_FullMethodName        = "/proto.auth.AuthService/LoginV1"
-	AuthService_RefreshTokenV1_FullMethodName = "/proto.auth.AuthService/RefreshTokenV1"
+	AuthService_RegisterV1_FullMethodName               = "/proto.auth.AuthService/RegisterV1"
+	AuthService_LoginV1_FullMethodName                  = "/proto.auth.AuthService/LoginV1"
+	AuthService_VerifyTOTPV1_FullMethodName             = "/proto.auth.AuthService/VerifyTOTPV1"
+	AuthService_RefreshTokenV1_FullMethodName           = "/proto.auth.AuthService/RefreshTokenV1"
+	AuthService_GetVaultKeyV1_FullMethodName            = "/proto.auth.AuthService/GetVaultKeyV1"
+	AuthService_RotateUserKeyV1_FullMethodName          = "/proto.auth.AuthService/RotateUserKeyV1"
+	AuthService_GetKeyRotationV1_FullMethodName         = "/proto.auth.AuthService/GetKeyRotationV1"
+	AuthService_EnrollTOTPV1_FullMethodName             = "/proto.auth.AuthService/EnrollTOTPV1"
+	AuthService_ConfirmTOTPV1_FullMethodName            = "/proto.auth.AuthService/ConfirmTOTPV1"
+	AuthService_LogoutV1_FullMethodName                 = "/proto.auth.AuthService/LogoutV1"
+	AuthService_ListSessionsV1_FullMethodName           = "/proto.auth.AuthService/ListSessionsV1"
+	AuthService_RevokeSessionV1_FullMethodName          = "/proto.auth.AuthService/RevokeSessionV1"
+	AuthService_RevokeAllSessionsV1_FullMethodName      = "/proto.auth.AuthService/RevokeAllSessionsV1"
+	AuthService_ChangePasswordV1_FullMethodName         = "/proto.auth.AuthService/ChangePasswordV1"
+	AuthService_DeleteAccountV1_FullMethodName          = "/proto.auth.AuthService/DeleteAccountV1"
+	AuthService_CreateAPITokenV1_FullMethodName         = "/proto.auth.AuthService/CreateAPITokenV1"
+	AuthService_ListAPITokensV1_FullMethodName          = "/proto.auth.AuthService/ListAPITokensV1"
+	AuthService_RevokeAPITokenV1_FullMethodName         = "/proto.auth.AuthService/RevokeAPITokenV1"
+	AuthService_AddEmergencyContactV1_FullMethodName    = "/proto.auth.AuthService/AddEmergencyContactV1"
+	AuthService_ListEmergencyAccessV1_FullMethodName    = "/proto.auth.AuthService/ListEmergencyAccessV1"
+	AuthService_RemoveEmergencyAccessV1_FullMethodName  = "/proto.auth.AuthService/RemoveEmergencyAccessV1"
+	AuthService_RequestEmergencyAccessV1_FullMethodName = "/proto.auth.AuthService/RequestEmergencyAccessV1"
+	AuthService_ApproveEmergencyAccessV1_FullMethodName = "/proto.auth.AuthService/ApproveEmergencyAccessV1"
+	AuthService_RejectEmergencyAccessV1_FullMethodName  = "/proto.auth.AuthService/RejectEmergencyAccessV1"
+	AuthService_ListEmergencyItemsV1_FullMethodName     = "/proto.auth.AuthService/ListEmergencyItemsV1"
+	AuthService_TakeOverAccountV1_FullMethodName        = "/proto.auth.AuthService/TakeOverAccountV1"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -33,10 +56,57 @@ const (
 type AuthServiceClient interface {
 	// Register a new user and return tokens and a user key.
 	RegisterV1(ctx context.Context, in *RegisterV1Request, opts ...grpc.CallOption) (*RegisterV1Response, error)
-	// Authenticate a user with username and password, returning tokens.
+	// Authenticate a user with username and password, returning tokens, or a challenge
+	// for accounts with two-factor authentication.
 	LoginV1(ctx context.Context, in *LoginV1Request, opts ...grpc.CallOption) (*LoginV1Response, error)
+	// Answer a login challenge with a TOTP or recovery code, returning tokens.
+	VerifyTOTPV1(ctx context.Context, in *VerifyTOTPV1Request, opts ...grpc.CallOption) (*VerifyTOTPV1Response, error)
 	// Refresh authentication tokens using a valid refresh token.
 	RefreshTokenV1(ctx context.Context, in *RefreshTokenV1Request, opts ...grpc.CallOption) (*RefreshTokenV1Response, error)
+	// Return the wrapped vault key of the calling zero-knowledge account.
+	GetVaultKeyV1(ctx context.Context, in *GetVaultKeyV1Request, opts ...grpc.CallOption) (*GetVaultKeyV1Response, error)
+	// Replace the data key of the calling user and re-encrypt the vault in the background.
+	RotateUserKeyV1(ctx context.Context, in *RotateUserKeyV1Request, opts ...grpc.CallOption) (*RotateUserKeyV1Response, error)
+	// Return the progress of the latest key rotation of the calling user.
+	GetKeyRotationV1(ctx context.Context, in *GetKeyRotationV1Request, opts ...grpc.CallOption) (*GetKeyRotationV1Response, error)
+	// Start enrolling the calling user in two-factor authentication with a new TOTP secret.
+	EnrollTOTPV1(ctx context.Context, in *EnrollTOTPV1Request, opts ...grpc.CallOption) (*EnrollTOTPV1Response, error)
+	// Enable two-factor authentication once a code from the enrolled secret is confirmed.
+	ConfirmTOTPV1(ctx context.Context, in *ConfirmTOTPV1Request, opts ...grpc.CallOption) (*ConfirmTOTPV1Response, error)
+	// Sign out the session of a refresh token, invalidating its tokens.
+	LogoutV1(ctx context.Context, in *LogoutV1Request, opts ...grpc.CallOption) (*LogoutV1Response, error)
+	// List the signed-in sessions of the calling user.
+	ListSessionsV1(ctx context.Context, in *ListSessionsV1Request, opts ...grpc.CallOption) (*ListSessionsV1Response, error)
+	// Sign out one session of the calling user.
+	RevokeSessionV1(ctx context.Context, in *RevokeSessionV1Request, opts ...grpc.CallOption) (*RevokeSessionV1Response, error)
+	// Sign out every session of the calling user, including the current one.
+	RevokeAllSessionsV1(ctx context.Context, in *RevokeAllSessionsV1Request, opts ...grpc.CallOption) (*RevokeAllSessionsV1Response, error)
+	// Change the password of the calling user, signing out every session and starting a new one.
+	ChangePasswordV1(ctx context.Context, in *ChangePasswordV1Request, opts ...grpc.CallOption) (*ChangePasswordV1Response, error)
+	// Delete the calling user with the whole vault and every uploaded file.
+	DeleteAccountV1(ctx context.Context, in *DeleteAccountV1Request, opts ...grpc.CallOption) (*DeleteAccountV1Response, error)
+	// Create a personal access token with a limited scope, for automation.
+	CreateAPITokenV1(ctx context.Context, in *CreateAPITokenV1Request, opts ...grpc.CallOption) (*CreateAPITokenV1Response, error)
+	// List the personal access tokens of the calling user.
+	ListAPITokensV1(ctx context.Context, in *ListAPITokensV1Request, opts ...grpc.CallOption) (*ListAPITokensV1Response, error)
+	// Revoke a personal access token of the calling user.
+	RevokeAPITokenV1(ctx context.Context, in *RevokeAPITokenV1Request, opts ...grpc.CallOption) (*RevokeAPITokenV1Response, error)
+	// Make a user a trusted contact of the calling user, or change the grant of a contact.
+	AddEmergencyContactV1(ctx context.Context, in *AddEmergencyContactV1Request, opts ...grpc.CallOption) (*AddEmergencyContactV1Response, error)
+	// List the emergency access the calling user gave and received.
+	ListEmergencyAccessV1(ctx context.Context, in *ListEmergencyAccessV1Request, opts ...grpc.CallOption) (*ListEmergencyAccessV1Response, error)
+	// Delete emergency access the calling user gave or received.
+	RemoveEmergencyAccessV1(ctx context.Context, in *RemoveEmergencyAccessV1Request, opts ...grpc.CallOption) (*RemoveEmergencyAccessV1Response, error)
+	// Request emergency access as a trusted contact, starting the wait.
+	RequestEmergencyAccessV1(ctx context.Context, in *RequestEmergencyAccessV1Request, opts ...grpc.CallOption) (*RequestEmergencyAccessV1Response, error)
+	// Approve a request for emergency access before the wait is over.
+	ApproveEmergencyAccessV1(ctx context.Context, in *ApproveEmergencyAccessV1Request, opts ...grpc.CallOption) (*ApproveEmergencyAccessV1Response, error)
+	// Reject a request for emergency access, or end access that was approved.
+	RejectEmergencyAccessV1(ctx context.Context, in *RejectEmergencyAccessV1Request, opts ...grpc.CallOption) (*RejectEmergencyAccessV1Response, error)
+	// List the items of the grantor of approved view access.
+	ListEmergencyItemsV1(ctx context.Context, in *ListEmergencyItemsV1Request, opts ...grpc.CallOption) (*ListEmergencyItemsV1Response, error)
+	// Set a new password for the grantor of approved takeover access.
+	TakeOverAccountV1(ctx context.Context, in *TakeOverAccountV1Request, opts ...grpc.CallOption) (*TakeOverAccountV1Response, error)
 }
 
 type authServiceClient struct {
@@ -67,6 +137,16 @@ func (c *authServiceClient) LoginV1(ctx context.Context, in *LoginV1Request, opt
 	return out, nil
 }
 
+func (c *authServiceClient) VerifyTOTPV1(ctx context.Context, in *VerifyTOTPV1Request, opts ...grpc.CallOption) (*VerifyTOTPV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyTOTPV1Response)
+	err := c.cc.Invoke(ctx, AuthService_VerifyTOTPV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) RefreshTokenV1(ctx context.Context, in *RefreshTokenV1Request, opts ...grpc.CallOption) (*RefreshTokenV1Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RefreshTokenV1Response)
@@ -77,6 +157,226 @@ func (c *authServiceClient) RefreshTokenV1(ctx context.Context, in *RefreshToken
 	return out, nil
 }
 
+func (c *authServiceClient) GetVaultKeyV1(ctx context.Context, in *GetVaultKeyV1Request, opts ...grpc.CallOption) (*GetVaultKeyV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVaultKeyV1Response)
+	err := c.cc.Invoke(ctx, AuthService_GetVaultKeyV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RotateUserKeyV1(ctx context.Context, in *RotateUserKeyV1Request, opts ...grpc.CallOption) (*RotateUserKeyV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateUserKeyV1Response)
+	err := c.cc.Invoke(ctx, AuthService_RotateUserKeyV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetKeyRotationV1(ctx context.Context, in *GetKeyRotationV1Request, opts ...grpc.CallOption) (*GetKeyRotationV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyRotationV1Response)
+	err := c.cc.Invoke(ctx, AuthService_GetKeyRotationV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) EnrollTOTPV1(ctx context.Context, in *EnrollTOTPV1Request, opts ...grpc.CallOption) (*EnrollTOTPV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollTOTPV1Response)
+	err := c.cc.Invoke(ctx, AuthService_EnrollTOTPV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ConfirmTOTPV1(ctx context.Context, in *ConfirmTOTPV1Request, opts ...grpc.CallOption) (*ConfirmTOTPV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTOTPV1Response)
+	err := c.cc.Invoke(ctx, AuthService_ConfirmTOTPV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LogoutV1(ctx context.Context, in *LogoutV1Request, opts ...grpc.CallOption) (*LogoutV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutV1Response)
+	err := c.cc.Invoke(ctx, AuthService_LogoutV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListSessionsV1(ctx context.Context, in *ListSessionsV1Request, opts ...grpc.CallOption) (*ListSessionsV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsV1Response)
+	err := c.cc.Invoke(ctx, AuthService_ListSessionsV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeSessionV1(ctx context.Context, in *RevokeSessionV1Request, opts ...grpc.CallOption) (*RevokeSessionV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeSessionV1Response)
+	err := c.cc.Invoke(ctx, AuthService_RevokeSessionV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeAllSessionsV1(ctx context.Context, in *RevokeAllSessionsV1Request, opts ...grpc.CallOption) (*RevokeAllSessionsV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAllSessionsV1Response)
+	err := c.cc.Invoke(ctx, AuthService_RevokeAllSessionsV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ChangePasswordV1(ctx context.Context, in *ChangePasswordV1Request, opts ...grpc.CallOption) (*ChangePasswordV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePasswordV1Response)
+	err := c.cc.Invoke(ctx, AuthService_ChangePasswordV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DeleteAccountV1(ctx context.Context, in *DeleteAccountV1Request, opts ...grpc.CallOption) (*DeleteAccountV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAccountV1Response)
+	err := c.cc.Invoke(ctx, AuthService_DeleteAccountV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CreateAPITokenV1(ctx context.Context, in *CreateAPITokenV1Request, opts ...grpc.CallOption) (*CreateAPITokenV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAPITokenV1Response)
+	err := c.cc.Invoke(ctx, AuthService_CreateAPITokenV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListAPITokensV1(ctx context.Context, in *ListAPITokensV1Request, opts ...grpc.CallOption) (*ListAPITokensV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAPITokensV1Response)
+	err := c.cc.Invoke(ctx, AuthService_ListAPITokensV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeAPITokenV1(ctx context.Context, in *RevokeAPITokenV1Request, opts ...grpc.CallOption) (*RevokeAPITokenV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAPITokenV1Response)
+	err := c.cc.Invoke(ctx, AuthService_RevokeAPITokenV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) AddEmergencyContactV1(ctx context.Context, in *AddEmergencyContactV1Request, opts ...grpc.CallOption) (*AddEmergencyContactV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddEmergencyContactV1Response)
+	err := c.cc.Invoke(ctx, AuthService_AddEmergencyContactV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListEmergencyAccessV1(ctx context.Context, in *ListEmergencyAccessV1Request, opts ...grpc.CallOption) (*ListEmergencyAccessV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyAccessV1Response)
+	err := c.cc.Invoke(ctx, AuthService_ListEmergencyAccessV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RemoveEmergencyAccessV1(ctx context.Context, in *RemoveEmergencyAccessV1Request, opts ...grpc.CallOption) (*RemoveEmergencyAccessV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveEmergencyAccessV1Response)
+	err := c.cc.Invoke(ctx, AuthService_RemoveEmergencyAccessV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RequestEmergencyAccessV1(ctx context.Context, in *RequestEmergencyAccessV1Request, opts ...grpc.CallOption) (*RequestEmergencyAccessV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestEmergencyAccessV1Response)
+	err := c.cc.Invoke(ctx, AuthService_RequestEmergencyAccessV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ApproveEmergencyAccessV1(ctx context.Context, in *ApproveEmergencyAccessV1Request, opts ...grpc.CallOption) (*ApproveEmergencyAccessV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveEmergencyAccessV1Response)
+	err := c.cc.Invoke(ctx, AuthService_ApproveEmergencyAccessV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RejectEmergencyAccessV1(ctx context.Context, in *RejectEmergencyAccessV1Request, opts ...grpc.CallOption) (*RejectEmergencyAccessV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectEmergencyAccessV1Response)
+	err := c.cc.Invoke(ctx, AuthService_RejectEmergencyAccessV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListEmergencyItemsV1(ctx context.Context, in *ListEmergencyItemsV1Request, opts ...grpc.CallOption) (*ListEmergencyItemsV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyItemsV1Response)
+	err := c.cc.Invoke(ctx, AuthService_ListEmergencyItemsV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) TakeOverAccountV1(ctx context.Context, in *TakeOverAccountV1Request, opts ...grpc.CallOption) (*TakeOverAccountV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TakeOverAccountV1Response)
+	err := c.cc.Invoke(ctx, AuthService_TakeOverAccountV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -86,10 +386,57 @@ func (c *authServiceClient) RefreshTokenV1(ctx context.Context, in *RefreshToken
 type AuthServiceServer interface {
 	// Register a new user and return tokens and a user key.
 	RegisterV1(context.Context, *RegisterV1Request) (*RegisterV1Response, error)
-	// Authenticate a user with username and password, returning tokens.
+	// Authenticate a user with username and password, returning tokens, or a challenge
+	// for accounts with two-factor authentication.
 	LoginV1(context.Context, *LoginV1Request) (*LoginV1Response, error)
+	// Answer a login challenge with a TOTP or recovery code, returning tokens.
+	VerifyTOTPV1(context.Context, *VerifyTOTPV1Request) (*VerifyTOTPV1Response, error)
 	// Refresh authentication tokens using a valid refresh token.
 	RefreshTokenV1(context.Context, *RefreshTokenV1Request) (*RefreshTokenV1Response, error)
+	// Return the wrapped vault key of the calling zero-knowledge account.
+	GetVaultKeyV1(context.Context, *GetVaultKeyV1Request) (*GetVaultKeyV1Response, error)
+	// Replace the data key of the calling user and re-encrypt the vault in the background.
+	RotateUserKeyV1(context.Context, *RotateUserKeyV1Request) (*RotateUserKeyV1Response, error)
+	// Return the progress of the latest key rotation of the calling user.
+	GetKeyRotationV1(context.Context, *GetKeyRotationV1Request) (*GetKeyRotationV1Response, error)
+	// Start enrolling the calling user in two-factor authentication with a new TOTP secret.
+	EnrollTOTPV1(context.Context, *EnrollTOTPV1Request) (*EnrollTOTPV1Response, error)
+	// Enable two-factor authentication once a code from the enrolled secret is confirmed.
+	ConfirmTOTPV1(context.Context, *ConfirmTOTPV1Request) (*ConfirmTOTPV1Response, error)
+	// Sign out the session of a refresh token, invalidating its tokens.
+	LogoutV1(context.Context, *LogoutV1Request) (*LogoutV1Response, error)
+	// List the signed-in sessions of the calling user.
+	ListSessionsV1(context.Context, *ListSessionsV1Request) (*ListSessionsV1Response, error)
+	// Sign out one session of the calling user.
+	RevokeSessionV1(context.Context, *RevokeSessionV1Request) (*RevokeSessionV1Response, error)
+	// Sign out every session of the calling user, including the current one.
+	RevokeAllSessionsV1(context.Context, *RevokeAllSessionsV1Request) (*RevokeAllSessionsV1Response, error)
+	// Change the password of the calling user, signing out every session and starting a new one.
+	ChangePasswordV1(context.Context, *ChangePasswordV1Request) (*ChangePasswordV1Response, error)
+	// Delete the calling user with the whole vault and every uploaded file.
+	DeleteAccountV1(context.Context, *DeleteAccountV1Request) (*DeleteAccountV1Response, error)
+	// Create a personal access token with a limited scope, for automation.
+	CreateAPITokenV1(context.Context, *CreateAPITokenV1Request) (*CreateAPITokenV1Response, error)
+	// List the personal access tokens of the calling user.
+	ListAPITokensV1(context.Context, *ListAPITokensV1Request) (*ListAPITokensV1Response, error)
+	// Revoke a personal access token of the calling user.
+	RevokeAPITokenV1(context.Context, *RevokeAPITokenV1Request) (*RevokeAPITokenV1Response, error)
+	// Make a user a trusted contact of the calling user, or change the grant of a contact.
+	AddEmergencyContactV1(context.Context, *AddEmergencyContactV1Request) (*AddEmergencyContactV1Response, error)
+	// List the emergency access the calling user gave and received.
+	ListEmergencyAccessV1(context.Context, *ListEmergencyAccessV1Request) (*ListEmergencyAccessV1Response, error)
+	// Delete emergency access the calling user gave or received.
+	RemoveEmergencyAccessV1(context.Context, *RemoveEmergencyAccessV1Request) (*RemoveEmergencyAccessV1Response, error)
+	// Request emergency access as a trusted contact, starting the wait.
+	RequestEmergencyAccessV1(context.Context, *RequestEmergencyAccessV1Request) (*RequestEmergencyAccessV1Response, error)
+	// Approve a request for emergency access before the wait is over.
+	ApproveEmergencyAccessV1(context.Context, *ApproveEmergencyAccessV1Request) (*ApproveEmergencyAccessV1Response, error)
+	// Reject a request for emergency access, or end access that was approved.
+	RejectEmergencyAccessV1(context.Context, *RejectEmergencyAccessV1Request) (*RejectEmergencyAccessV1Response, error)
+	// List the items of the grantor of approved view access.
+	ListEmergencyItemsV1(context.Context, *ListEmergencyItemsV1Request) (*ListEmergencyItemsV1Response, error)
+	// Set a new password for the grantor of approved takeover access.
+	TakeOverAccountV1(context.Context, *TakeOverAccountV1Request) (*TakeOverAccountV1Response, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -106,9 +453,78 @@ func (UnimplementedAuthServiceServer) RegisterV1(context.Context, *RegisterV1Req
 func (UnimplementedAuthServiceServer) LoginV1(context.Context, *LoginV1Request) (*LoginV1Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoginV1 not implemented")
 }
+func (UnimplementedAuthServiceServer) VerifyTOTPV1(context.Context, *VerifyTOTPV1Request) (*VerifyTOTPV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTOTPV1 not implemented")
+}
 func (UnimplementedAuthServiceServer) RefreshTokenV1(context.Context, *RefreshTokenV1Request) (*RefreshTokenV1Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefreshTokenV1 not implemented")
 }
+func (UnimplementedAuthServiceServer) GetVaultKeyV1(context.Context, *GetVaultKeyV1Request) (*GetVaultKeyV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVaultKeyV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) RotateUserKeyV1(context.Context, *RotateUserKeyV1Request) (*RotateUserKeyV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateUserKeyV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) GetKeyRotationV1(context.Context, *GetKeyRotationV1Request) (*GetKeyRotationV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyRotationV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) EnrollTOTPV1(context.Context, *EnrollTOTPV1Request) (*EnrollTOTPV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTOTPV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) ConfirmTOTPV1(context.Context, *ConfirmTOTPV1Request) (*ConfirmTOTPV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTPV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) LogoutV1(context.Context, *LogoutV1Request) (*LogoutV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) ListSessionsV1(context.Context, *ListSessionsV1Request) (*ListSessionsV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessionsV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeSessionV1(context.Context, *RevokeSessionV1Request) (*RevokeSessionV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSessionV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeAllSessionsV1(context.Context, *RevokeAllSessionsV1Request) (*RevokeAllSessionsV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllSessionsV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) ChangePasswordV1(context.Context, *ChangePasswordV1Request) (*ChangePasswordV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePasswordV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) DeleteAccountV1(context.Context, *DeleteAccountV1Request) (*DeleteAccountV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccountV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) CreateAPITokenV1(context.Context, *CreateAPITokenV1Request) (*CreateAPITokenV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPITokenV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) ListAPITokensV1(context.Context, *ListAPITokensV1Request) (*ListAPITokensV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPITokensV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeAPITokenV1(context.Context, *RevokeAPITokenV1Request) (*RevokeAPITokenV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPITokenV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) AddEmergencyContactV1(context.Context, *AddEmergencyContactV1Request) (*AddEmergencyContactV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddEmergencyContactV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) ListEmergencyAccessV1(context.Context, *ListEmergencyAccessV1Request) (*ListEmergencyAccessV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyAccessV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) RemoveEmergencyAccessV1(context.Context, *RemoveEmergencyAccessV1Request) (*RemoveEmergencyAccessV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveEmergencyAccessV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) RequestEmergencyAccessV1(context.Context, *RequestEmergencyAccessV1Request) (*RequestEmergencyAccessV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmergencyAccessV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) ApproveEmergencyAccessV1(context.Context, *ApproveEmergencyAccessV1Request) (*ApproveEmergencyAccessV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveEmergencyAccessV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) RejectEmergencyAccessV1(context.Context, *RejectEmergencyAccessV1Request) (*RejectEmergencyAccessV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectEmergencyAccessV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) ListEmergencyItemsV1(context.Context, *ListEmergencyItemsV1Request) (*ListEmergencyItemsV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyItemsV1 not implemented")
+}
+func (UnimplementedAuthServiceServer) TakeOverAccountV1(context.Context, *TakeOverAccountV1Request) (*TakeOverAccountV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeOverAccountV1 not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
@@ -166,6 +582,24 @@ func _AuthService_LoginV1_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_VerifyTOTPV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTOTPV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyTOTPV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifyTOTPV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyTOTPV1(ctx, req.(*VerifyTOTPV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_RefreshTokenV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RefreshTokenV1Request)
 	if err := dec(in); err != nil {
@@ -184,24 +618,512 @@ func _AuthService_RefreshTokenV1_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
-// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var AuthService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "proto.auth.AuthService",
-	HandlerType: (*AuthServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "RegisterV1",
-			Handler:    _AuthService_RegisterV1_Handler,
-		},
-		{
-			MethodName: "LoginV1",
-			Handler:    _AuthService_LoginV1_Handler,
-		},
-		{
-			MethodName: "RefreshTokenV1",
-			Handler:    _AuthService_RefreshTokenV1_Handler,
+func _AuthService_GetVaultKeyV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVaultKeyV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetVaultKeyV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetVaultKeyV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetVaultKeyV1(ctx, req.(*GetVaultKeyV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RotateUserKeyV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateUserKeyV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RotateUserKeyV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RotateUserKeyV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RotateUserKeyV1(ctx, req.(*RotateUserKeyV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetKeyRotationV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyRotationV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetKeyRotationV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetKeyRotationV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetKeyRotationV1(ctx, req.(*GetKeyRotationV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_EnrollTOTPV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollTOTPV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).EnrollTOTPV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_EnrollTOTPV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).EnrollTOTPV1(ctx, req.(*EnrollTOTPV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ConfirmTOTPV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTOTPV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ConfirmTOTPV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ConfirmTOTPV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ConfirmTOTPV1(ctx, req.(*ConfirmTOTPV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LogoutV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LogoutV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_LogoutV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LogoutV1(ctx, req.(*LogoutV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListSessionsV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListSessionsV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListSessionsV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListSessionsV1(ctx, req.(*ListSessionsV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeSessionV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeSessionV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeSessionV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeSessionV1(ctx, req.(*RevokeSessionV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeAllSessionsV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAllSessionsV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeAllSessionsV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeAllSessionsV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeAllSessionsV1(ctx, req.(*RevokeAllSessionsV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ChangePasswordV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ChangePasswordV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ChangePasswordV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ChangePasswordV1(ctx, req.(*ChangePasswordV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DeleteAccountV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DeleteAccountV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DeleteAccountV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DeleteAccountV1(ctx, req.(*DeleteAccountV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CreateAPITokenV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPITokenV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateAPITokenV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateAPITokenV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateAPITokenV1(ctx, req.(*CreateAPITokenV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListAPITokensV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPITokensV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListAPITokensV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListAPITokensV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListAPITokensV1(ctx, req.(*ListAPITokensV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeAPITokenV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPITokenV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeAPITokenV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeAPITokenV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeAPITokenV1(ctx, req.(*RevokeAPITokenV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AddEmergencyContactV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddEmergencyContactV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AddEmergencyContactV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_AddEmergencyContactV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AddEmergencyContactV1(ctx, req.(*AddEmergencyContactV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListEmergencyAccessV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyAccessV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListEmergencyAccessV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListEmergencyAccessV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListEmergencyAccessV1(ctx, req.(*ListEmergencyAccessV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RemoveEmergencyAccessV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveEmergencyAccessV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RemoveEmergencyAccessV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RemoveEmergencyAccessV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RemoveEmergencyAccessV1(ctx, req.(*RemoveEmergencyAccessV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RequestEmergencyAccessV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEmergencyAccessV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestEmergencyAccessV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestEmergencyAccessV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestEmergencyAccessV1(ctx, req.(*RequestEmergencyAccessV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ApproveEmergencyAccessV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveEmergencyAccessV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ApproveEmergencyAccessV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ApproveEmergencyAccessV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ApproveEmergencyAccessV1(ctx, req.(*ApproveEmergencyAccessV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RejectEmergencyAccessV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectEmergencyAccessV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RejectEmergencyAccessV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RejectEmergencyAccessV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RejectEmergencyAccessV1(ctx, req.(*RejectEmergencyAccessV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListEmergencyItemsV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyItemsV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListEmergencyItemsV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListEmergencyItemsV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListEmergencyItemsV1(ctx, req.(*ListEmergencyItemsV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_TakeOverAccountV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeOverAccountV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).TakeOverAccountV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_TakeOverAccountV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).TakeOverAccountV1(ctx, req.(*TakeOverAccountV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.auth.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterV1",
+			Handler:    _AuthService_RegisterV1_Handler,
+		},
+		{
+			MethodName: "LoginV1",
+			Handler:    _AuthService_LoginV1_Handler,
+		},
+		{
+			MethodName: "VerifyTOTPV1",
+			Handler:    _AuthService_VerifyTOTPV1_Handler,
+		},
+		{
+			MethodName: "RefreshTokenV1",
+			Handler:    _AuthService_RefreshTokenV1_Handler,
+		},
+		{
+			MethodName: "GetVaultKeyV1",
+			Handler:    _AuthService_GetVaultKeyV1_Handler,
+		},
+		{
+			MethodName: "RotateUserKeyV1",
+			Handler:    _AuthService_RotateUserKeyV1_Handler,
+		},
+		{
+			MethodName: "GetKeyRotationV1",
+			Handler:    _AuthService_GetKeyRotationV1_Handler,
+		},
+		{
+			MethodName: "EnrollTOTPV1",
+			Handler:    _AuthService_EnrollTOTPV1_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTPV1",
+			Handler:    _AuthService_ConfirmTOTPV1_Handler,
+		},
+		{
+			MethodName: "LogoutV1",
+			Handler:    _AuthService_LogoutV1_Handler,
+		},
+		{
+			MethodName: "ListSessionsV1",
+			Handler:    _AuthService_ListSessionsV1_Handler,
+		},
+		{
+			MethodName: "RevokeSessionV1",
+			Handler:    _AuthService_RevokeSessionV1_Handler,
+		},
+		{
+			MethodName: "RevokeAllSessionsV1",
+			Handler:    _AuthService_RevokeAllSessionsV1_Handler,
+		},
+		{
+			MethodName: "ChangePasswordV1",
+			Handler:    _AuthService_ChangePasswordV1_Handler,
+		},
+		{
+			MethodName: "DeleteAccountV1",
+			Handler:    _AuthService_DeleteAccountV1_Handler,
+		},
+		{
+			MethodName: "CreateAPITokenV1",
+			Handler:    _AuthService_CreateAPITokenV1_Handler,
+		},
+		{
+			MethodName: "ListAPITokensV1",
+			Handler:    _AuthService_ListAPITokensV1_Handler,
+		},
+		{
+			MethodName: "RevokeAPITokenV1",
+			Handler:    _AuthService_RevokeAPITokenV1_Handler,
+		},
+		{
+			MethodName: "AddEmergencyContactV1",
+			Handler:    _AuthService_AddEmergencyContactV1_Handler,
+		},
+		{
+			MethodName: "ListEmergencyAccessV1",
+			Handler:    _AuthService_ListEmergencyAccessV1_Handler,
+		},
+		{
+			MethodName: "RemoveEmergencyAccessV1",
+			Handler:    _AuthService_RemoveEmergencyAccessV1_Handler,
+		},
+		{
+			MethodName: "RequestEmergencyAccessV1",
+			Handler:    _AuthService_RequestEmergencyAccessV1_Handler,
+		},
+		{
+			MethodName: "ApproveEmergencyAccessV1",
+			Handler:    _AuthService_ApproveEmergencyAccessV1_Handler,
+		},
+		{
+			MethodName: "RejectEmergencyAccessV1",
+			Handler:    _AuthService_RejectEmergencyAccessV1_Handler,
+		},
+		{
+			MethodName: "ListEmergencyItemsV1",
+			Handler:    _AuthService_ListEmergencyItemsV1_Handler,
+		},
+		{
+			MethodName: "TakeOverAccountV1",
+			Handler:    _AuthService_TakeOverAccountV1_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
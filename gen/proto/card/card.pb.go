@@ -27,7 +27,14 @@ const (
 type StoreCardV1Request struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Card data to be stored.
-	Card          *CardData `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	Card *CardData `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	// Card data sealed by a zero-knowledge client, sent instead of card.
+	SealedCard *SealedCardData `protobuf:"bytes,2,opt,name=sealed_card,json=sealedCard,proto3" json:"sealed_card,omitempty"`
+	// Collection of an organization to store the card in (UUID format); the caller's vault when empty.
+	CollectionId string `protobuf:"bytes,3,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// ID to store the card under (UUID format), chosen by zero-knowledge clients to bind the fields they
+	// seal; a new one when empty.
+	ItemId        string `protobuf:"bytes,4,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -69,6 +76,27 @@ func (x *StoreCardV1Request) GetCard() *CardData {
 	return nil
 }
 
+func (x *StoreCardV1Request) GetSealedCard() *SealedCardData {
+	if x != nil {
+		return x.SealedCard
+	}
+	return nil
+}
+
+func (x *StoreCardV1Request) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *StoreCardV1Request) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
 // Response after storing a card.
 type StoreCardV1Response struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -117,7 +145,11 @@ func (x *StoreCardV1Response) GetCardId() string {
 
 // Request to retrieve all stored cards.
 type GetCardsV1Request struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page number to retrieve (0 or 1 selects the first page, at most 1000000 so the offset fits).
+	Page int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Number of cards per page (0 selects the server default, at most 100).
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -152,11 +184,27 @@ func (*GetCardsV1Request) Descriptor() ([]byte, []int) {
 	return file_proto_card_card_proto_rawDescGZIP(), []int{2}
 }
 
-// Response containing all stored cards.
+func (x *GetCardsV1Request) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetCardsV1Request) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// Response containing a page of stored cards with pagination metadata.
 type GetCardsV1Response struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// List of cards.
-	Cards         []*CardData `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	Cards []*CardEntry `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	// Total number of cards owned by the user.
+	TotalCount    int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -191,13 +239,20 @@ func (*GetCardsV1Response) Descriptor() ([]byte, []int) {
 	return file_proto_card_card_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetCardsV1Response) GetCards() []*CardData {
+func (x *GetCardsV1Response) GetCards() []*CardEntry {
 	if x != nil {
 		return x.Cards
 	}
 	return nil
 }
 
+func (x *GetCardsV1Response) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
 // Request to retrieve a specific card.
 type GetCardV1Request struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -250,7 +305,9 @@ type GetCardV1Response struct {
 	// Card data.
 	Card *CardData `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
 	// Timestamp of the last update.
-	LastUpdate    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+	LastUpdate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+	// Current version of the card, to be sent back as expected_version on update.
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -299,6 +356,13 @@ func (x *GetCardV1Response) GetLastUpdate() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *GetCardV1Response) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // Request to delete a card.
 type DeleteCardV1Request struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -397,7 +461,11 @@ type UpdateCardV1Request struct {
 	// Unique card ID (UUID format).
 	CardId string `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
 	// Updated card data.
-	Data          *CardData `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Data *CardData `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Version the client last saw; 0 overwrites unconditionally.
+	ExpectedVersion int64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// Updated card data sealed by a zero-knowledge client, sent instead of data.
+	SealedData    *SealedCardData `protobuf:"bytes,4,opt,name=sealed_data,json=sealedData,proto3" json:"sealed_data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -446,11 +514,27 @@ func (x *UpdateCardV1Request) GetData() *CardData {
 	return nil
 }
 
+func (x *UpdateCardV1Request) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateCardV1Request) GetSealedData() *SealedCardData {
+	if x != nil {
+		return x.SealedData
+	}
+	return nil
+}
+
 // Response after updating a card.
 type UpdateCardV1Response struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// ID of the updated card.
-	CardId        string `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	CardId string `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	// Version of the card after the update.
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -492,6 +576,13 @@ func (x *UpdateCardV1Response) GetCardId() string {
 	return ""
 }
 
+func (x *UpdateCardV1Response) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // Represents the data structure for a payment card.
 type CardData struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -565,6 +656,199 @@ func (x *CardData) GetCardholderName() string {
 	return ""
 }
 
+// Card fields sealed by a zero-knowledge client. The server stores them as they are,
+// so the format rules of CardData are checked by the client before sealing.
+type SealedCardData struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sealed card number.
+	CardNumber string `protobuf:"bytes,1,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	// Sealed expiry date.
+	ExpiryDate string `protobuf:"bytes,2,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	// Sealed security code.
+	Cvv string `protobuf:"bytes,3,opt,name=cvv,proto3" json:"cvv,omitempty"`
+	// Sealed cardholder name.
+	CardholderName string `protobuf:"bytes,4,opt,name=cardholder_name,json=cardholderName,proto3" json:"cardholder_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SealedCardData) Reset() {
+	*x = SealedCardData{}
+	mi := &file_proto_card_card_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SealedCardData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SealedCardData) ProtoMessage() {}
+
+func (x *SealedCardData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_card_card_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SealedCardData.ProtoReflect.Descriptor instead.
+func (*SealedCardData) Descriptor() ([]byte, []int) {
+	return file_proto_card_card_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SealedCardData) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+func (x *SealedCardData) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+func (x *SealedCardData) GetCvv() string {
+	if x != nil {
+		return x.Cvv
+	}
+	return ""
+}
+
+func (x *SealedCardData) GetCardholderName() string {
+	if x != nil {
+		return x.CardholderName
+	}
+	return ""
+}
+
+// CardEntry is a stored card together with its identifier and update time.
+type CardEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique entry ID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Decrypted card data.
+	Card *CardData `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"`
+	// Timestamp of the last update.
+	LastUpdate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+	// Version of the card, incremented on every update.
+	Version       int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CardEntry) Reset() {
+	*x = CardEntry{}
+	mi := &file_proto_card_card_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardEntry) ProtoMessage() {}
+
+func (x *CardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_card_card_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardEntry.ProtoReflect.Descriptor instead.
+func (*CardEntry) Descriptor() ([]byte, []int) {
+	return file_proto_card_card_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CardEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CardEntry) GetCard() *CardData {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *CardEntry) GetLastUpdate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return nil
+}
+
+func (x *CardEntry) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// CardConflict is attached to an ABORTED status when expected_version is stale.
+type CardConflict struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Card as currently stored on the server.
+	Current       *CardEntry `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CardConflict) Reset() {
+	*x = CardConflict{}
+	mi := &file_proto_card_card_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardConflict) ProtoMessage() {}
+
+func (x *CardConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_card_card_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardConflict.ProtoReflect.Descriptor instead.
+func (*CardConflict) Descriptor() ([]byte, []int) {
+	return file_proto_card_card_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CardConflict) GetCurrent() *CardEntry {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
 var File_proto_card_card_proto protoreflect.FileDescriptor
 
 var file_proto_card_card_proto_rawDesc = string([]byte{
@@ -574,60 +858,137 @@ var file_proto_card_card_proto_rawDesc = string([]byte{
 	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x62, 0x75, 0x66, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
 	0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x3e, 0x0a, 0x12, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x61,
-	0x72, 0x64, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x63, 0x61, 0x72,
-	0x64, 0x22, 0x2e, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31,
+	0x6f, 0x22, 0xdf, 0x03, 0x0a, 0x12, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56,
+	0x31, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x61, 0x72, 0x64, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x63, 0x61,
+	0x72, 0x64, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x72,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x61, 0x72, 0x64, 0x2e, 0x53, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x72, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x72, 0x64, 0x12,
+	0x7c, 0x0a, 0x0d, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x57, 0xba, 0x48, 0x54, 0x72, 0x52, 0x32, 0x50, 0x5e,
+	0x28, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x38, 0x7d, 0x2d,
+	0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x34, 0x7d, 0x2d, 0x5b,
+	0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x34, 0x7d, 0x2d, 0x5b, 0x30,
+	0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x34, 0x7d, 0x2d, 0x5b, 0x30, 0x2d,
+	0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x31, 0x32, 0x7d, 0x29, 0x3f, 0x24, 0x52,
+	0x0c, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x70, 0x0a,
+	0x07, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x57,
+	0xba, 0x48, 0x54, 0x72, 0x52, 0x32, 0x50, 0x5e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66,
+	0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x38, 0x7d, 0x2d, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41,
+	0x2d, 0x46, 0x5d, 0x7b, 0x34, 0x7d, 0x2d, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d,
+	0x46, 0x5d, 0x7b, 0x34, 0x7d, 0x2d, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46,
+	0x5d, 0x7b, 0x34, 0x7d, 0x2d, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d,
+	0x7b, 0x31, 0x32, 0x7d, 0x29, 0x3f, 0x24, 0x52, 0x06, 0x69, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x3a,
+	0x72, 0xba, 0x48, 0x6f, 0x1a, 0x6d, 0x0a, 0x12, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x61,
+	0x72, 0x64, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2b, 0x63, 0x61, 0x72, 0x64,
+	0x20, 0x61, 0x6e, 0x64, 0x20, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x72, 0x64,
+	0x20, 0x61, 0x72, 0x65, 0x20, 0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x6c, 0x79, 0x20, 0x65, 0x78,
+	0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x1a, 0x2a, 0x21, 0x28, 0x68, 0x61, 0x73, 0x28, 0x74,
+	0x68, 0x69, 0x73, 0x2e, 0x63, 0x61, 0x72, 0x64, 0x29, 0x20, 0x26, 0x26, 0x20, 0x68, 0x61, 0x73,
+	0x28, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x72,
+	0x64, 0x29, 0x29, 0x22, 0x2e, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x61, 0x72, 0x64,
+	0x56, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61,
+	0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72,
+	0x64, 0x49, 0x64, 0x22, 0x5c, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x56,
+	0x31, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x0b, 0xba, 0x48, 0x08, 0x1a, 0x06, 0x18, 0xc0, 0x84,
+	0x3d, 0x28, 0x00, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0x09, 0xba, 0x48,
+	0x06, 0x1a, 0x04, 0x18, 0x64, 0x28, 0x00, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x22, 0x62, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x56, 0x31, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x61, 0x72, 0x64, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x63,
+	0x61, 0x72, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x35, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72, 0x64,
+	0x56, 0x31, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x07, 0x63, 0x61, 0x72,
+	0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05, 0x72,
+	0x03, 0xb0, 0x01, 0x01, 0x52, 0x06, 0x63, 0x61, 0x72, 0x64, 0x49, 0x64, 0x22, 0x94, 0x01, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x43, 0x61,
+	0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x12, 0x3b, 0x0a, 0x0b,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x38, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x72,
+	0x64, 0x56, 0x31, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x07, 0x63, 0x61,
+	0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05,
+	0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x06, 0x63, 0x61, 0x72, 0x64, 0x49, 0x64, 0x22, 0x26, 0x0a,
+	0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x02, 0x6f, 0x6b, 0x22, 0xc8, 0x02, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x43, 0x61, 0x72, 0x64, 0x56, 0x31, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a,
+	0x07, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08,
+	0xba, 0x48, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x06, 0x63, 0x61, 0x72, 0x64, 0x49, 0x64,
+	0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x43, 0x61, 0x72, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a, 0x10, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xba, 0x48, 0x04, 0x22, 0x02, 0x28, 0x00, 0x52, 0x0f, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3b,
+	0x0a, 0x0b, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x61, 0x72, 0x64,
+	0x2e, 0x53, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x0a, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x3a, 0x73, 0xba, 0x48, 0x70,
+	0x1a, 0x6e, 0x0a, 0x13, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x2e,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2b, 0x64, 0x61, 0x74, 0x61, 0x20, 0x61, 0x6e,
+	0x64, 0x20, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x20, 0x61, 0x72,
+	0x65, 0x20, 0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x6c, 0x79, 0x20, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x73, 0x69, 0x76, 0x65, 0x1a, 0x2a, 0x21, 0x28, 0x68, 0x61, 0x73, 0x28, 0x74, 0x68, 0x69, 0x73,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x29, 0x20, 0x26, 0x26, 0x20, 0x68, 0x61, 0x73, 0x28, 0x74, 0x68,
+	0x69, 0x73, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x29, 0x29,
+	0x22, 0x49, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x64,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x64, 0x49,
-	0x64, 0x22, 0x13, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x56, 0x31, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x40, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72,
-	0x64, 0x73, 0x56, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x05,
-	0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x44, 0x61, 0x74,
-	0x61, 0x52, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x22, 0x35, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43,
-	0x61, 0x72, 0x64, 0x56, 0x31, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x07,
-	0x63, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba,
-	0x48, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x06, 0x63, 0x61, 0x72, 0x64, 0x49, 0x64, 0x22,
-	0x7a, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x61, 0x72, 0x64, 0x2e,
-	0x43, 0x61, 0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x12, 0x3b,
-	0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x38, 0x0a, 0x13, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x21, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x06, 0x63,
-	0x61, 0x72, 0x64, 0x49, 0x64, 0x22, 0x26, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43,
-	0x61, 0x72, 0x64, 0x56, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x22, 0x62, 0x0a,
-	0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52,
-	0x06, 0x63, 0x61, 0x72, 0x64, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x61,
-	0x72, 0x64, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x22, 0x2f, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56,
-	0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72,
-	0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x64,
-	0x49, 0x64, 0x22, 0xe2, 0x01, 0x0a, 0x08, 0x43, 0x61, 0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12,
-	0x36, 0x0a, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x42, 0x15, 0xba, 0x48, 0x12, 0x72, 0x10, 0x32, 0x0e, 0x5e, 0x5b, 0x30,
-	0x2d, 0x39, 0x5d, 0x7b, 0x31, 0x33, 0x2c, 0x31, 0x39, 0x7d, 0x24, 0x52, 0x0a, 0x63, 0x61, 0x72,
-	0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x43, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72,
-	0x79, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x22, 0xba, 0x48,
-	0x1f, 0x72, 0x1d, 0x32, 0x1b, 0x5e, 0x28, 0x30, 0x5b, 0x31, 0x2d, 0x39, 0x5d, 0x7c, 0x31, 0x5b,
-	0x30, 0x2d, 0x32, 0x5d, 0x29, 0x5c, 0x2f, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x32, 0x7d, 0x24,
-	0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x44, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x03,
-	0x63, 0x76, 0x76, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x13, 0xba, 0x48, 0x10, 0x72, 0x0e,
-	0x32, 0x0c, 0x5e, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x33, 0x2c, 0x34, 0x7d, 0x24, 0x52, 0x03,
-	0x63, 0x76, 0x76, 0x12, 0x32, 0x0a, 0x0f, 0x63, 0x61, 0x72, 0x64, 0x68, 0x6f, 0x6c, 0x64, 0x65,
-	0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x09, 0xba, 0x48,
-	0x06, 0x72, 0x04, 0x10, 0x01, 0x18, 0x64, 0x52, 0x0e, 0x63, 0x61, 0x72, 0x64, 0x68, 0x6f, 0x6c,
-	0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x32, 0x9a, 0x03, 0x0a, 0x0b, 0x43, 0x61, 0x72, 0x64,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xe2, 0x01, 0x0a, 0x08,
+	0x43, 0x61, 0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x61, 0x72, 0x64,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x15, 0xba,
+	0x48, 0x12, 0x72, 0x10, 0x32, 0x0e, 0x5e, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x31, 0x33, 0x2c,
+	0x31, 0x39, 0x7d, 0x24, 0x52, 0x0a, 0x63, 0x61, 0x72, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x43, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x22, 0xba, 0x48, 0x1f, 0x72, 0x1d, 0x32, 0x1b, 0x5e, 0x28,
+	0x30, 0x5b, 0x31, 0x2d, 0x39, 0x5d, 0x7c, 0x31, 0x5b, 0x30, 0x2d, 0x32, 0x5d, 0x29, 0x5c, 0x2f,
+	0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x32, 0x7d, 0x24, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x44, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x03, 0x63, 0x76, 0x76, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x13, 0xba, 0x48, 0x10, 0x72, 0x0e, 0x32, 0x0c, 0x5e, 0x5b, 0x30, 0x2d, 0x39,
+	0x5d, 0x7b, 0x33, 0x2c, 0x34, 0x7d, 0x24, 0x52, 0x03, 0x63, 0x76, 0x76, 0x12, 0x32, 0x0a, 0x0f,
+	0x63, 0x61, 0x72, 0x64, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x09, 0xba, 0x48, 0x06, 0x72, 0x04, 0x10, 0x01, 0x18, 0x64,
+	0x52, 0x0e, 0x63, 0x61, 0x72, 0x64, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x22, 0xc4, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x43, 0x61, 0x72, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x2c, 0x0a, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0b, 0xba, 0x48, 0x08, 0x72, 0x06, 0x3a,
+	0x04, 0x7a, 0x6b, 0x31, 0x3a, 0x52, 0x0a, 0x63, 0x61, 0x72, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x2c, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0b, 0xba, 0x48, 0x08, 0x72, 0x06, 0x3a, 0x04, 0x7a,
+	0x6b, 0x31, 0x3a, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x44, 0x61, 0x74, 0x65, 0x12,
+	0x1d, 0x0a, 0x03, 0x63, 0x76, 0x76, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0b, 0xba, 0x48,
+	0x08, 0x72, 0x06, 0x3a, 0x04, 0x7a, 0x6b, 0x31, 0x3a, 0x52, 0x03, 0x63, 0x76, 0x76, 0x12, 0x37,
+	0x0a, 0x0f, 0x63, 0x61, 0x72, 0x64, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0e, 0xba, 0x48, 0x0b, 0x72, 0x09, 0x18, 0xff,
+	0x01, 0x3a, 0x04, 0x7a, 0x6b, 0x31, 0x3a, 0x52, 0x0e, 0x63, 0x61, 0x72, 0x64, 0x68, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x9c, 0x01, 0x0a, 0x09, 0x43, 0x61, 0x72, 0x64,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x61, 0x72, 0x64,
+	0x2e, 0x43, 0x61, 0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x12,
+	0x3b, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x3f, 0x0a, 0x0c, 0x43, 0x61, 0x72, 0x64, 0x43, 0x6f,
+	0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x61, 0x72, 0x64, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x32, 0x9a, 0x03, 0x0a, 0x0b, 0x43, 0x61, 0x72, 0x64,
 	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x72, 0x65,
 	0x43, 0x61, 0x72, 0x64, 0x56, 0x31, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
 	0x61, 0x72, 0x64, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31, 0x52,
@@ -653,17 +1014,17 @@ var file_proto_card_card_proto_rawDesc = string([]byte{
 	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x61, 0x72, 0x64, 0x2e,
 	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x72, 0x64, 0x56, 0x31, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x95, 0x01, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x9b, 0x01, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x2e, 0x63, 0x61, 0x72, 0x64, 0x42, 0x09, 0x43, 0x61, 0x72, 0x64, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
 	0x2f, 0x6e, 0x70, 0x61, 0x76, 0x6c, 0x6f, 0x76, 0x2f, 0x67, 0x6f, 0x2d, 0x70, 0x61, 0x73, 0x73,
 	0x77, 0x6f, 0x72, 0x64, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e,
-	0x2f, 0x63, 0x61, 0x72, 0x64, 0xa2, 0x02, 0x03, 0x50, 0x43, 0x58, 0xaa, 0x02, 0x0a, 0x50, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x72, 0x64, 0xca, 0x02, 0x0a, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x5c, 0x43, 0x61, 0x72, 0x64, 0xe2, 0x02, 0x16, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x43, 0x61,
-	0x72, 0x64, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02,
-	0x0b, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x3a, 0x3a, 0x43, 0x61, 0x72, 0x64, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x61, 0x72, 0x64, 0xa2, 0x02, 0x03, 0x50, 0x43,
+	0x58, 0xaa, 0x02, 0x0a, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x72, 0x64, 0xca, 0x02,
+	0x0a, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x43, 0x61, 0x72, 0x64, 0xe2, 0x02, 0x16, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x5c, 0x43, 0x61, 0x72, 0x64, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0b, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x3a, 0x3a, 0x43, 0x61,
+	0x72, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -678,7 +1039,7 @@ func file_proto_card_card_proto_rawDescGZIP() []byte {
 	return file_proto_card_card_proto_rawDescData
 }
 
-var file_proto_card_card_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_card_card_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_card_card_proto_goTypes = []any{
 	(*StoreCardV1Request)(nil),    // 0: proto.card.StoreCardV1Request
 	(*StoreCardV1Response)(nil),   // 1: proto.card.StoreCardV1Response
@@ -691,29 +1052,37 @@ var file_proto_card_card_proto_goTypes = []any{
 	(*UpdateCardV1Request)(nil),   // 8: proto.card.UpdateCardV1Request
 	(*UpdateCardV1Response)(nil),  // 9: proto.card.UpdateCardV1Response
 	(*CardData)(nil),              // 10: proto.card.CardData
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*SealedCardData)(nil),        // 11: proto.card.SealedCardData
+	(*CardEntry)(nil),             // 12: proto.card.CardEntry
+	(*CardConflict)(nil),          // 13: proto.card.CardConflict
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
 }
 var file_proto_card_card_proto_depIdxs = []int32{
 	10, // 0: proto.card.StoreCardV1Request.card:type_name -> proto.card.CardData
-	10, // 1: proto.card.GetCardsV1Response.cards:type_name -> proto.card.CardData
-	10, // 2: proto.card.GetCardV1Response.card:type_name -> proto.card.CardData
-	11, // 3: proto.card.GetCardV1Response.last_update:type_name -> google.protobuf.Timestamp
-	10, // 4: proto.card.UpdateCardV1Request.data:type_name -> proto.card.CardData
-	0,  // 5: proto.card.CardService.StoreCardV1:input_type -> proto.card.StoreCardV1Request
-	2,  // 6: proto.card.CardService.GetCardsV1:input_type -> proto.card.GetCardsV1Request
-	4,  // 7: proto.card.CardService.GetCardV1:input_type -> proto.card.GetCardV1Request
-	8,  // 8: proto.card.CardService.UpdateCardV1:input_type -> proto.card.UpdateCardV1Request
-	6,  // 9: proto.card.CardService.DeleteCardV1:input_type -> proto.card.DeleteCardV1Request
-	1,  // 10: proto.card.CardService.StoreCardV1:output_type -> proto.card.StoreCardV1Response
-	3,  // 11: proto.card.CardService.GetCardsV1:output_type -> proto.card.GetCardsV1Response
-	5,  // 12: proto.card.CardService.GetCardV1:output_type -> proto.card.GetCardV1Response
-	9,  // 13: proto.card.CardService.UpdateCardV1:output_type -> proto.card.UpdateCardV1Response
-	7,  // 14: proto.card.CardService.DeleteCardV1:output_type -> proto.card.DeleteCardV1Response
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	11, // 1: proto.card.StoreCardV1Request.sealed_card:type_name -> proto.card.SealedCardData
+	12, // 2: proto.card.GetCardsV1Response.cards:type_name -> proto.card.CardEntry
+	10, // 3: proto.card.GetCardV1Response.card:type_name -> proto.card.CardData
+	14, // 4: proto.card.GetCardV1Response.last_update:type_name -> google.protobuf.Timestamp
+	10, // 5: proto.card.UpdateCardV1Request.data:type_name -> proto.card.CardData
+	11, // 6: proto.card.UpdateCardV1Request.sealed_data:type_name -> proto.card.SealedCardData
+	10, // 7: proto.card.CardEntry.card:type_name -> proto.card.CardData
+	14, // 8: proto.card.CardEntry.last_update:type_name -> google.protobuf.Timestamp
+	12, // 9: proto.card.CardConflict.current:type_name -> proto.card.CardEntry
+	0,  // 10: proto.card.CardService.StoreCardV1:input_type -> proto.card.StoreCardV1Request
+	2,  // 11: proto.card.CardService.GetCardsV1:input_type -> proto.card.GetCardsV1Request
+	4,  // 12: proto.card.CardService.GetCardV1:input_type -> proto.card.GetCardV1Request
+	8,  // 13: proto.card.CardService.UpdateCardV1:input_type -> proto.card.UpdateCardV1Request
+	6,  // 14: proto.card.CardService.DeleteCardV1:input_type -> proto.card.DeleteCardV1Request
+	1,  // 15: proto.card.CardService.StoreCardV1:output_type -> proto.card.StoreCardV1Response
+	3,  // 16: proto.card.CardService.GetCardsV1:output_type -> proto.card.GetCardsV1Response
+	5,  // 17: proto.card.CardService.GetCardV1:output_type -> proto.card.GetCardV1Response
+	9,  // 18: proto.card.CardService.UpdateCardV1:output_type -> proto.card.UpdateCardV1Response
+	7,  // 19: proto.card.CardService.DeleteCardV1:output_type -> proto.card.DeleteCardV1Response
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_proto_card_card_proto_init() }
@@ -727,7 +1096,7 @@ func file_proto_card_card_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_card_card_proto_rawDesc), len(file_proto_card_card_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
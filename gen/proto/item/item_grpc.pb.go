@@ -19,7 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ItemService_GetItemsV1_FullMethodName = "/proto.item.ItemService/GetItemsV1"
+	ItemService_GetItemsV1_FullMethodName         = "/proto.item.ItemService/GetItemsV1"
+	ItemService_HydrateItemsV1_FullMethodName     = "/proto.item.ItemService/HydrateItemsV1"
+	ItemService_GetChangesV1_FullMethodName       = "/proto.item.ItemService/GetChangesV1"
+	ItemService_WatchItemsV1_FullMethodName       = "/proto.item.ItemService/WatchItemsV1"
+	ItemService_ShareItemV1_FullMethodName        = "/proto.item.ItemService/ShareItemV1"
+	ItemService_RevokeShareV1_FullMethodName      = "/proto.item.ItemService/RevokeShareV1"
+	ItemService_ListSharesV1_FullMethodName       = "/proto.item.ItemService/ListSharesV1"
+	ItemService_GetSharedItemV1_FullMethodName    = "/proto.item.ItemService/GetSharedItemV1"
+	ItemService_UpdateSharedItemV1_FullMethodName = "/proto.item.ItemService/UpdateSharedItemV1"
 )
 
 // ItemServiceClient is the client API for ItemService service.
@@ -31,6 +39,22 @@ const (
 type ItemServiceClient interface {
 	// Retrieve a paginated list of all stored items.
 	GetItemsV1(ctx context.Context, in *GetItemsV1Request, opts ...grpc.CallOption) (*GetItemsV1Response, error)
+	// Stream decrypted payloads and metadata for a set of items in one call.
+	HydrateItemsV1(ctx context.Context, in *HydrateItemsV1Request, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HydrateItemsV1Response], error)
+	// Retrieve item inserts, updates and deletions made after a change cursor.
+	GetChangesV1(ctx context.Context, in *GetChangesV1Request, opts ...grpc.CallOption) (*GetChangesV1Response, error)
+	// Stream item changes as they happen, starting after the given change cursor.
+	WatchItemsV1(ctx context.Context, in *WatchItemsV1Request, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchItemsV1Response], error)
+	// Share an item with another user, sealed to their share key, or change the grant of an existing share.
+	ShareItemV1(ctx context.Context, in *ShareItemV1Request, opts ...grpc.CallOption) (*ShareItemV1Response, error)
+	// Revoke the access of a user to a shared item.
+	RevokeShareV1(ctx context.Context, in *RevokeShareV1Request, opts ...grpc.CallOption) (*RevokeShareV1Response, error)
+	// List the shares of the caller's items.
+	ListSharesV1(ctx context.Context, in *ListSharesV1Request, opts ...grpc.CallOption) (*ListSharesV1Response, error)
+	// Retrieve the decrypted payload of an item shared with the caller.
+	GetSharedItemV1(ctx context.Context, in *GetSharedItemV1Request, opts ...grpc.CallOption) (*GetSharedItemV1Response, error)
+	// Change an item shared with the caller with write access.
+	UpdateSharedItemV1(ctx context.Context, in *UpdateSharedItemV1Request, opts ...grpc.CallOption) (*UpdateSharedItemV1Response, error)
 }
 
 type itemServiceClient struct {
@@ -51,6 +75,104 @@ func (c *itemServiceClient) GetItemsV1(ctx context.Context, in *GetItemsV1Reques
 	return out, nil
 }
 
+func (c *itemServiceClient) HydrateItemsV1(ctx context.Context, in *HydrateItemsV1Request, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HydrateItemsV1Response], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ItemService_ServiceDesc.Streams[0], ItemService_HydrateItemsV1_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HydrateItemsV1Request, HydrateItemsV1Response]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ItemService_HydrateItemsV1Client = grpc.ServerStreamingClient[HydrateItemsV1Response]
+
+func (c *itemServiceClient) GetChangesV1(ctx context.Context, in *GetChangesV1Request, opts ...grpc.CallOption) (*GetChangesV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChangesV1Response)
+	err := c.cc.Invoke(ctx, ItemService_GetChangesV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) WatchItemsV1(ctx context.Context, in *WatchItemsV1Request, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchItemsV1Response], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ItemService_ServiceDesc.Streams[1], ItemService_WatchItemsV1_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchItemsV1Request, WatchItemsV1Response]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ItemService_WatchItemsV1Client = grpc.ServerStreamingClient[WatchItemsV1Response]
+
+func (c *itemServiceClient) ShareItemV1(ctx context.Context, in *ShareItemV1Request, opts ...grpc.CallOption) (*ShareItemV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareItemV1Response)
+	err := c.cc.Invoke(ctx, ItemService_ShareItemV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) RevokeShareV1(ctx context.Context, in *RevokeShareV1Request, opts ...grpc.CallOption) (*RevokeShareV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeShareV1Response)
+	err := c.cc.Invoke(ctx, ItemService_RevokeShareV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) ListSharesV1(ctx context.Context, in *ListSharesV1Request, opts ...grpc.CallOption) (*ListSharesV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSharesV1Response)
+	err := c.cc.Invoke(ctx, ItemService_ListSharesV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) GetSharedItemV1(ctx context.Context, in *GetSharedItemV1Request, opts ...grpc.CallOption) (*GetSharedItemV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSharedItemV1Response)
+	err := c.cc.Invoke(ctx, ItemService_GetSharedItemV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) UpdateSharedItemV1(ctx context.Context, in *UpdateSharedItemV1Request, opts ...grpc.CallOption) (*UpdateSharedItemV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSharedItemV1Response)
+	err := c.cc.Invoke(ctx, ItemService_UpdateSharedItemV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ItemServiceServer is the server API for ItemService service.
 // All implementations must embed UnimplementedItemServiceServer
 // for forward compatibility.
@@ -60,6 +182,22 @@ func (c *itemServiceClient) GetItemsV1(ctx context.Context, in *GetItemsV1Reques
 type ItemServiceServer interface {
 	// Retrieve a paginated list of all stored items.
 	GetItemsV1(context.Context, *GetItemsV1Request) (*GetItemsV1Response, error)
+	// Stream decrypted payloads and metadata for a set of items in one call.
+	HydrateItemsV1(*HydrateItemsV1Request, grpc.ServerStreamingServer[HydrateItemsV1Response]) error
+	// Retrieve item inserts, updates and deletions made after a change cursor.
+	GetChangesV1(context.Context, *GetChangesV1Request) (*GetChangesV1Response, error)
+	// Stream item changes as they happen, starting after the given change cursor.
+	WatchItemsV1(*WatchItemsV1Request, grpc.ServerStreamingServer[WatchItemsV1Response]) error
+	// Share an item with another user, sealed to their share key, or change the grant of an existing share.
+	ShareItemV1(context.Context, *ShareItemV1Request) (*ShareItemV1Response, error)
+	// Revoke the access of a user to a shared item.
+	RevokeShareV1(context.Context, *RevokeShareV1Request) (*RevokeShareV1Response, error)
+	// List the shares of the caller's items.
+	ListSharesV1(context.Context, *ListSharesV1Request) (*ListSharesV1Response, error)
+	// Retrieve the decrypted payload of an item shared with the caller.
+	GetSharedItemV1(context.Context, *GetSharedItemV1Request) (*GetSharedItemV1Response, error)
+	// Change an item shared with the caller with write access.
+	UpdateSharedItemV1(context.Context, *UpdateSharedItemV1Request) (*UpdateSharedItemV1Response, error)
 	mustEmbedUnimplementedItemServiceServer()
 }
 
@@ -73,6 +211,30 @@ type UnimplementedItemServiceServer struct{}
 func (UnimplementedItemServiceServer) GetItemsV1(context.Context, *GetItemsV1Request) (*GetItemsV1Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetItemsV1 not implemented")
 }
+func (UnimplementedItemServiceServer) HydrateItemsV1(*HydrateItemsV1Request, grpc.ServerStreamingServer[HydrateItemsV1Response]) error {
+	return status.Errorf(codes.Unimplemented, "method HydrateItemsV1 not implemented")
+}
+func (UnimplementedItemServiceServer) GetChangesV1(context.Context, *GetChangesV1Request) (*GetChangesV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChangesV1 not implemented")
+}
+func (UnimplementedItemServiceServer) WatchItemsV1(*WatchItemsV1Request, grpc.ServerStreamingServer[WatchItemsV1Response]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchItemsV1 not implemented")
+}
+func (UnimplementedItemServiceServer) ShareItemV1(context.Context, *ShareItemV1Request) (*ShareItemV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareItemV1 not implemented")
+}
+func (UnimplementedItemServiceServer) RevokeShareV1(context.Context, *RevokeShareV1Request) (*RevokeShareV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShareV1 not implemented")
+}
+func (UnimplementedItemServiceServer) ListSharesV1(context.Context, *ListSharesV1Request) (*ListSharesV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharesV1 not implemented")
+}
+func (UnimplementedItemServiceServer) GetSharedItemV1(context.Context, *GetSharedItemV1Request) (*GetSharedItemV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedItemV1 not implemented")
+}
+func (UnimplementedItemServiceServer) UpdateSharedItemV1(context.Context, *UpdateSharedItemV1Request) (*UpdateSharedItemV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSharedItemV1 not implemented")
+}
 func (UnimplementedItemServiceServer) mustEmbedUnimplementedItemServiceServer() {}
 func (UnimplementedItemServiceServer) testEmbeddedByValue()                     {}
 
@@ -112,6 +274,136 @@ func _ItemService_GetItemsV1_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ItemService_HydrateItemsV1_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HydrateItemsV1Request)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ItemServiceServer).HydrateItemsV1(m, &grpc.GenericServerStream[HydrateItemsV1Request, HydrateItemsV1Response]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ItemService_HydrateItemsV1Server = grpc.ServerStreamingServer[HydrateItemsV1Response]
+
+func _ItemService_GetChangesV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChangesV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).GetChangesV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_GetChangesV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).GetChangesV1(ctx, req.(*GetChangesV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_WatchItemsV1_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchItemsV1Request)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ItemServiceServer).WatchItemsV1(m, &grpc.GenericServerStream[WatchItemsV1Request, WatchItemsV1Response]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ItemService_WatchItemsV1Server = grpc.ServerStreamingServer[WatchItemsV1Response]
+
+func _ItemService_ShareItemV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareItemV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).ShareItemV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_ShareItemV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).ShareItemV1(ctx, req.(*ShareItemV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_RevokeShareV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).RevokeShareV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_RevokeShareV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).RevokeShareV1(ctx, req.(*RevokeShareV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_ListSharesV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharesV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).ListSharesV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_ListSharesV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).ListSharesV1(ctx, req.(*ListSharesV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_GetSharedItemV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedItemV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).GetSharedItemV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_GetSharedItemV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).GetSharedItemV1(ctx, req.(*GetSharedItemV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_UpdateSharedItemV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSharedItemV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).UpdateSharedItemV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_UpdateSharedItemV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).UpdateSharedItemV1(ctx, req.(*UpdateSharedItemV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ItemService_ServiceDesc is the grpc.ServiceDesc for ItemService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -123,7 +415,42 @@ var ItemService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetItemsV1",
 			Handler:    _ItemService_GetItemsV1_Handler,
 		},
+		{
+			MethodName: "GetChangesV1",
+			Handler:    _ItemService_GetChangesV1_Handler,
+		},
+		{
+			MethodName: "ShareItemV1",
+			Handler:    _ItemService_ShareItemV1_Handler,
+		},
+		{
+			MethodName: "RevokeShareV1",
+			Handler:    _ItemService_RevokeShareV1_Handler,
+		},
+		{
+			MethodName: "ListSharesV1",
+			Handler:    _ItemService_ListSharesV1_Handler,
+		},
+		{
+			MethodName: "GetSharedItemV1",
+			Handler:    _ItemService_GetSharedItemV1_Handler,
+		},
+		{
+			MethodName: "UpdateSharedItemV1",
+			Handler:    _ItemService_UpdateSharedItemV1_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "HydrateItemsV1",
+			Handler:       _ItemService_HydrateItemsV1_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchItemsV1",
+			Handler:       _ItemService_WatchItemsV1_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/item/item.proto",
 }
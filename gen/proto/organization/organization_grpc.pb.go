@@ -0,0 +1,569 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/organization/organization.proto
+
+package organization
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OrganizationService_CreateOrganizationV1_FullMethodName     = "/proto.organization.OrganizationService/CreateOrganizationV1"
+	OrganizationService_ListOrganizationsV1_FullMethodName      = "/proto.organization.OrganizationService/ListOrganizationsV1"
+	OrganizationService_DeleteOrganizationV1_FullMethodName     = "/proto.organization.OrganizationService/DeleteOrganizationV1"
+	OrganizationService_SetMemberV1_FullMethodName              = "/proto.organization.OrganizationService/SetMemberV1"
+	OrganizationService_RemoveMemberV1_FullMethodName           = "/proto.organization.OrganizationService/RemoveMemberV1"
+	OrganizationService_ListMembersV1_FullMethodName            = "/proto.organization.OrganizationService/ListMembersV1"
+	OrganizationService_CreateCollectionV1_FullMethodName       = "/proto.organization.OrganizationService/CreateCollectionV1"
+	OrganizationService_DeleteCollectionV1_FullMethodName       = "/proto.organization.OrganizationService/DeleteCollectionV1"
+	OrganizationService_ListCollectionsV1_FullMethodName        = "/proto.organization.OrganizationService/ListCollectionsV1"
+	OrganizationService_SetCollectionAccessV1_FullMethodName    = "/proto.organization.OrganizationService/SetCollectionAccessV1"
+	OrganizationService_RevokeCollectionAccessV1_FullMethodName = "/proto.organization.OrganizationService/RevokeCollectionAccessV1"
+	OrganizationService_ListCollectionItemsV1_FullMethodName    = "/proto.organization.OrganizationService/ListCollectionItemsV1"
+)
+
+// OrganizationServiceClient is the client API for OrganizationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OrganizationService manages organizations, their members and the collections their items live in.
+// Items are added to a collection by passing its ID to the store RPCs of the item services.
+type OrganizationServiceClient interface {
+	// Create an organization with the caller as its owner.
+	CreateOrganizationV1(ctx context.Context, in *CreateOrganizationV1Request, opts ...grpc.CallOption) (*CreateOrganizationV1Response, error)
+	// List the organizations the caller is a member of.
+	ListOrganizationsV1(ctx context.Context, in *ListOrganizationsV1Request, opts ...grpc.CallOption) (*ListOrganizationsV1Response, error)
+	// Delete an organization that has no collections left.
+	DeleteOrganizationV1(ctx context.Context, in *DeleteOrganizationV1Request, opts ...grpc.CallOption) (*DeleteOrganizationV1Response, error)
+	// Add a user to an organization, or change the role of a member.
+	SetMemberV1(ctx context.Context, in *SetMemberV1Request, opts ...grpc.CallOption) (*SetMemberV1Response, error)
+	// Remove a member from an organization, along with their collection access.
+	RemoveMemberV1(ctx context.Context, in *RemoveMemberV1Request, opts ...grpc.CallOption) (*RemoveMemberV1Response, error)
+	// List the members of an organization.
+	ListMembersV1(ctx context.Context, in *ListMembersV1Request, opts ...grpc.CallOption) (*ListMembersV1Response, error)
+	// Create a collection in an organization.
+	CreateCollectionV1(ctx context.Context, in *CreateCollectionV1Request, opts ...grpc.CallOption) (*CreateCollectionV1Response, error)
+	// Delete an empty collection.
+	DeleteCollectionV1(ctx context.Context, in *DeleteCollectionV1Request, opts ...grpc.CallOption) (*DeleteCollectionV1Response, error)
+	// List the collections of an organization the caller can reach.
+	ListCollectionsV1(ctx context.Context, in *ListCollectionsV1Request, opts ...grpc.CallOption) (*ListCollectionsV1Response, error)
+	// Give a member access to a collection, or change it.
+	SetCollectionAccessV1(ctx context.Context, in *SetCollectionAccessV1Request, opts ...grpc.CallOption) (*SetCollectionAccessV1Response, error)
+	// Take the access to a collection away from a member.
+	RevokeCollectionAccessV1(ctx context.Context, in *RevokeCollectionAccessV1Request, opts ...grpc.CallOption) (*RevokeCollectionAccessV1Response, error)
+	// List the items of a collection.
+	ListCollectionItemsV1(ctx context.Context, in *ListCollectionItemsV1Request, opts ...grpc.CallOption) (*ListCollectionItemsV1Response, error)
+}
+
+type organizationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrganizationServiceClient(cc grpc.ClientConnInterface) OrganizationServiceClient {
+	return &organizationServiceClient{cc}
+}
+
+func (c *organizationServiceClient) CreateOrganizationV1(ctx context.Context, in *CreateOrganizationV1Request, opts ...grpc.CallOption) (*CreateOrganizationV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrganizationV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_CreateOrganizationV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) ListOrganizationsV1(ctx context.Context, in *ListOrganizationsV1Request, opts ...grpc.CallOption) (*ListOrganizationsV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrganizationsV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_ListOrganizationsV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) DeleteOrganizationV1(ctx context.Context, in *DeleteOrganizationV1Request, opts ...grpc.CallOption) (*DeleteOrganizationV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOrganizationV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_DeleteOrganizationV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) SetMemberV1(ctx context.Context, in *SetMemberV1Request, opts ...grpc.CallOption) (*SetMemberV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMemberV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_SetMemberV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) RemoveMemberV1(ctx context.Context, in *RemoveMemberV1Request, opts ...grpc.CallOption) (*RemoveMemberV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveMemberV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_RemoveMemberV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) ListMembersV1(ctx context.Context, in *ListMembersV1Request, opts ...grpc.CallOption) (*ListMembersV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMembersV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_ListMembersV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) CreateCollectionV1(ctx context.Context, in *CreateCollectionV1Request, opts ...grpc.CallOption) (*CreateCollectionV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCollectionV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_CreateCollectionV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) DeleteCollectionV1(ctx context.Context, in *DeleteCollectionV1Request, opts ...grpc.CallOption) (*DeleteCollectionV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCollectionV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_DeleteCollectionV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) ListCollectionsV1(ctx context.Context, in *ListCollectionsV1Request, opts ...grpc.CallOption) (*ListCollectionsV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_ListCollectionsV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) SetCollectionAccessV1(ctx context.Context, in *SetCollectionAccessV1Request, opts ...grpc.CallOption) (*SetCollectionAccessV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCollectionAccessV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_SetCollectionAccessV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) RevokeCollectionAccessV1(ctx context.Context, in *RevokeCollectionAccessV1Request, opts ...grpc.CallOption) (*RevokeCollectionAccessV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeCollectionAccessV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_RevokeCollectionAccessV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) ListCollectionItemsV1(ctx context.Context, in *ListCollectionItemsV1Request, opts ...grpc.CallOption) (*ListCollectionItemsV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionItemsV1Response)
+	err := c.cc.Invoke(ctx, OrganizationService_ListCollectionItemsV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationServiceServer is the server API for OrganizationService service.
+// All implementations must embed UnimplementedOrganizationServiceServer
+// for forward compatibility.
+//
+// OrganizationService manages organizations, their members and the collections their items live in.
+// Items are added to a collection by passing its ID to the store RPCs of the item services.
+type OrganizationServiceServer interface {
+	// Create an organization with the caller as its owner.
+	CreateOrganizationV1(context.Context, *CreateOrganizationV1Request) (*CreateOrganizationV1Response, error)
+	// List the organizations the caller is a member of.
+	ListOrganizationsV1(context.Context, *ListOrganizationsV1Request) (*ListOrganizationsV1Response, error)
+	// Delete an organization that has no collections left.
+	DeleteOrganizationV1(context.Context, *DeleteOrganizationV1Request) (*DeleteOrganizationV1Response, error)
+	// Add a user to an organization, or change the role of a member.
+	SetMemberV1(context.Context, *SetMemberV1Request) (*SetMemberV1Response, error)
+	// Remove a member from an organization, along with their collection access.
+	RemoveMemberV1(context.Context, *RemoveMemberV1Request) (*RemoveMemberV1Response, error)
+	// List the members of an organization.
+	ListMembersV1(context.Context, *ListMembersV1Request) (*ListMembersV1Response, error)
+	// Create a collection in an organization.
+	CreateCollectionV1(context.Context, *CreateCollectionV1Request) (*CreateCollectionV1Response, error)
+	// Delete an empty collection.
+	DeleteCollectionV1(context.Context, *DeleteCollectionV1Request) (*DeleteCollectionV1Response, error)
+	// List the collections of an organization the caller can reach.
+	ListCollectionsV1(context.Context, *ListCollectionsV1Request) (*ListCollectionsV1Response, error)
+	// Give a member access to a collection, or change it.
+	SetCollectionAccessV1(context.Context, *SetCollectionAccessV1Request) (*SetCollectionAccessV1Response, error)
+	// Take the access to a collection away from a member.
+	RevokeCollectionAccessV1(context.Context, *RevokeCollectionAccessV1Request) (*RevokeCollectionAccessV1Response, error)
+	// List the items of a collection.
+	ListCollectionItemsV1(context.Context, *ListCollectionItemsV1Request) (*ListCollectionItemsV1Response, error)
+	mustEmbedUnimplementedOrganizationServiceServer()
+}
+
+// UnimplementedOrganizationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOrganizationServiceServer struct{}
+
+func (UnimplementedOrganizationServiceServer) CreateOrganizationV1(context.Context, *CreateOrganizationV1Request) (*CreateOrganizationV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganizationV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) ListOrganizationsV1(context.Context, *ListOrganizationsV1Request) (*ListOrganizationsV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizationsV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) DeleteOrganizationV1(context.Context, *DeleteOrganizationV1Request) (*DeleteOrganizationV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrganizationV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) SetMemberV1(context.Context, *SetMemberV1Request) (*SetMemberV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMemberV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) RemoveMemberV1(context.Context, *RemoveMemberV1Request) (*RemoveMemberV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMemberV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) ListMembersV1(context.Context, *ListMembersV1Request) (*ListMembersV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMembersV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) CreateCollectionV1(context.Context, *CreateCollectionV1Request) (*CreateCollectionV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollectionV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) DeleteCollectionV1(context.Context, *DeleteCollectionV1Request) (*DeleteCollectionV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollectionV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) ListCollectionsV1(context.Context, *ListCollectionsV1Request) (*ListCollectionsV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollectionsV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) SetCollectionAccessV1(context.Context, *SetCollectionAccessV1Request) (*SetCollectionAccessV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCollectionAccessV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) RevokeCollectionAccessV1(context.Context, *RevokeCollectionAccessV1Request) (*RevokeCollectionAccessV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCollectionAccessV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) ListCollectionItemsV1(context.Context, *ListCollectionItemsV1Request) (*ListCollectionItemsV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollectionItemsV1 not implemented")
+}
+func (UnimplementedOrganizationServiceServer) mustEmbedUnimplementedOrganizationServiceServer() {}
+func (UnimplementedOrganizationServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeOrganizationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrganizationServiceServer will
+// result in compilation errors.
+type UnsafeOrganizationServiceServer interface {
+	mustEmbedUnimplementedOrganizationServiceServer()
+}
+
+func RegisterOrganizationServiceServer(s grpc.ServiceRegistrar, srv OrganizationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOrganizationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OrganizationService_ServiceDesc, srv)
+}
+
+func _OrganizationService_CreateOrganizationV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).CreateOrganizationV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_CreateOrganizationV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).CreateOrganizationV1(ctx, req.(*CreateOrganizationV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_ListOrganizationsV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationsV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).ListOrganizationsV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_ListOrganizationsV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).ListOrganizationsV1(ctx, req.(*ListOrganizationsV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_DeleteOrganizationV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOrganizationV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).DeleteOrganizationV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_DeleteOrganizationV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).DeleteOrganizationV1(ctx, req.(*DeleteOrganizationV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_SetMemberV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMemberV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).SetMemberV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_SetMemberV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).SetMemberV1(ctx, req.(*SetMemberV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_RemoveMemberV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMemberV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).RemoveMemberV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_RemoveMemberV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).RemoveMemberV1(ctx, req.(*RemoveMemberV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_ListMembersV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMembersV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).ListMembersV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_ListMembersV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).ListMembersV1(ctx, req.(*ListMembersV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_CreateCollectionV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).CreateCollectionV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_CreateCollectionV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).CreateCollectionV1(ctx, req.(*CreateCollectionV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_DeleteCollectionV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).DeleteCollectionV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_DeleteCollectionV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).DeleteCollectionV1(ctx, req.(*DeleteCollectionV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_ListCollectionsV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).ListCollectionsV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_ListCollectionsV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).ListCollectionsV1(ctx, req.(*ListCollectionsV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_SetCollectionAccessV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCollectionAccessV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).SetCollectionAccessV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_SetCollectionAccessV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).SetCollectionAccessV1(ctx, req.(*SetCollectionAccessV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_RevokeCollectionAccessV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCollectionAccessV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).RevokeCollectionAccessV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_RevokeCollectionAccessV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).RevokeCollectionAccessV1(ctx, req.(*RevokeCollectionAccessV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_ListCollectionItemsV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionItemsV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).ListCollectionItemsV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_ListCollectionItemsV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).ListCollectionItemsV1(ctx, req.(*ListCollectionItemsV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrganizationService_ServiceDesc is the grpc.ServiceDesc for OrganizationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrganizationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.organization.OrganizationService",
+	HandlerType: (*OrganizationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrganizationV1",
+			Handler:    _OrganizationService_CreateOrganizationV1_Handler,
+		},
+		{
+			MethodName: "ListOrganizationsV1",
+			Handler:    _OrganizationService_ListOrganizationsV1_Handler,
+		},
+		{
+			MethodName: "DeleteOrganizationV1",
+			Handler:    _OrganizationService_DeleteOrganizationV1_Handler,
+		},
+		{
+			MethodName: "SetMemberV1",
+			Handler:    _OrganizationService_SetMemberV1_Handler,
+		},
+		{
+			MethodName: "RemoveMemberV1",
+			Handler:    _OrganizationService_RemoveMemberV1_Handler,
+		},
+		{
+			MethodName: "ListMembersV1",
+			Handler:    _OrganizationService_ListMembersV1_Handler,
+		},
+		{
+			MethodName: "CreateCollectionV1",
+			Handler:    _OrganizationService_CreateCollectionV1_Handler,
+		},
+		{
+			MethodName: "DeleteCollectionV1",
+			Handler:    _OrganizationService_DeleteCollectionV1_Handler,
+		},
+		{
+			MethodName: "ListCollectionsV1",
+			Handler:    _OrganizationService_ListCollectionsV1_Handler,
+		},
+		{
+			MethodName: "SetCollectionAccessV1",
+			Handler:    _OrganizationService_SetCollectionAccessV1_Handler,
+		},
+		{
+			MethodName: "RevokeCollectionAccessV1",
+			Handler:    _OrganizationService_RevokeCollectionAccessV1_Handler,
+		},
+		{
+			MethodName: "ListCollectionItemsV1",
+			Handler:    _OrganizationService_ListCollectionItemsV1_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/organization/organization.proto",
+}
@@ -0,0 +1,285 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/send/send.proto
+
+package send
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SendService_CreateSendV1_FullMethodName     = "/proto.send.SendService/CreateSendV1"
+	SendService_CreateFileSendV1_FullMethodName = "/proto.send.SendService/CreateFileSendV1"
+	SendService_ListSendsV1_FullMethodName      = "/proto.send.SendService/ListSendsV1"
+	SendService_DeleteSendV1_FullMethodName     = "/proto.send.SendService/DeleteSendV1"
+	SendService_AccessSendV1_FullMethodName     = "/proto.send.SendService/AccessSendV1"
+)
+
+// SendServiceClient is the client API for SendService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SendService shares a text or a file with anyone who has the link, for a limited number of views and time.
+// Each send is sealed with a key of its own. The server returns the key once and does not keep it; the
+// link carries it in the URL fragment, which browsers do not send to servers.
+type SendServiceClient interface {
+	// Create a send of a text.
+	CreateSendV1(ctx context.Context, in *CreateSendV1Request, opts ...grpc.CallOption) (*CreateSendV1Response, error)
+	// Create a send of a file using a client-streaming RPC.
+	CreateFileSendV1(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateFileSendV1Request, CreateSendV1Response], error)
+	// List the sends of the caller.
+	ListSendsV1(ctx context.Context, in *ListSendsV1Request, opts ...grpc.CallOption) (*ListSendsV1Response, error)
+	// Delete a send of the caller before it expires.
+	DeleteSendV1(ctx context.Context, in *DeleteSendV1Request, opts ...grpc.CallOption) (*DeleteSendV1Response, error)
+	// Open a send with the key of its link. Needs no login, and counts as one view.
+	AccessSendV1(ctx context.Context, in *AccessSendV1Request, opts ...grpc.CallOption) (*AccessSendV1Response, error)
+}
+
+type sendServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSendServiceClient(cc grpc.ClientConnInterface) SendServiceClient {
+	return &sendServiceClient{cc}
+}
+
+func (c *sendServiceClient) CreateSendV1(ctx context.Context, in *CreateSendV1Request, opts ...grpc.CallOption) (*CreateSendV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSendV1Response)
+	err := c.cc.Invoke(ctx, SendService_CreateSendV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sendServiceClient) CreateFileSendV1(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateFileSendV1Request, CreateSendV1Response], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SendService_ServiceDesc.Streams[0], SendService_CreateFileSendV1_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreateFileSendV1Request, CreateSendV1Response]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SendService_CreateFileSendV1Client = grpc.ClientStreamingClient[CreateFileSendV1Request, CreateSendV1Response]
+
+func (c *sendServiceClient) ListSendsV1(ctx context.Context, in *ListSendsV1Request, opts ...grpc.CallOption) (*ListSendsV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSendsV1Response)
+	err := c.cc.Invoke(ctx, SendService_ListSendsV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sendServiceClient) DeleteSendV1(ctx context.Context, in *DeleteSendV1Request, opts ...grpc.CallOption) (*DeleteSendV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSendV1Response)
+	err := c.cc.Invoke(ctx, SendService_DeleteSendV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sendServiceClient) AccessSendV1(ctx context.Context, in *AccessSendV1Request, opts ...grpc.CallOption) (*AccessSendV1Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessSendV1Response)
+	err := c.cc.Invoke(ctx, SendService_AccessSendV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SendServiceServer is the server API for SendService service.
+// All implementations must embed UnimplementedSendServiceServer
+// for forward compatibility.
+//
+// SendService shares a text or a file with anyone who has the link, for a limited number of views and time.
+// Each send is sealed with a key of its own. The server returns the key once and does not keep it; the
+// link carries it in the URL fragment, which browsers do not send to servers.
+type SendServiceServer interface {
+	// Create a send of a text.
+	CreateSendV1(context.Context, *CreateSendV1Request) (*CreateSendV1Response, error)
+	// Create a send of a file using a client-streaming RPC.
+	CreateFileSendV1(grpc.ClientStreamingServer[CreateFileSendV1Request, CreateSendV1Response]) error
+	// List the sends of the caller.
+	ListSendsV1(context.Context, *ListSendsV1Request) (*ListSendsV1Response, error)
+	// Delete a send of the caller before it expires.
+	DeleteSendV1(context.Context, *DeleteSendV1Request) (*DeleteSendV1Response, error)
+	// Open a send with the key of its link. Needs no login, and counts as one view.
+	AccessSendV1(context.Context, *AccessSendV1Request) (*AccessSendV1Response, error)
+	mustEmbedUnimplementedSendServiceServer()
+}
+
+// UnimplementedSendServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSendServiceServer struct{}
+
+func (UnimplementedSendServiceServer) CreateSendV1(context.Context, *CreateSendV1Request) (*CreateSendV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSendV1 not implemented")
+}
+func (UnimplementedSendServiceServer) CreateFileSendV1(grpc.ClientStreamingServer[CreateFileSendV1Request, CreateSendV1Response]) error {
+	return status.Errorf(codes.Unimplemented, "method CreateFileSendV1 not implemented")
+}
+func (UnimplementedSendServiceServer) ListSendsV1(context.Context, *ListSendsV1Request) (*ListSendsV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSendsV1 not implemented")
+}
+func (UnimplementedSendServiceServer) DeleteSendV1(context.Context, *DeleteSendV1Request) (*DeleteSendV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSendV1 not implemented")
+}
+func (UnimplementedSendServiceServer) AccessSendV1(context.Context, *AccessSendV1Request) (*AccessSendV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccessSendV1 not implemented")
+}
+func (UnimplementedSendServiceServer) mustEmbedUnimplementedSendServiceServer() {}
+func (UnimplementedSendServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeSendServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SendServiceServer will
+// result in compilation errors.
+type UnsafeSendServiceServer interface {
+	mustEmbedUnimplementedSendServiceServer()
+}
+
+func RegisterSendServiceServer(s grpc.ServiceRegistrar, srv SendServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSendServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SendService_ServiceDesc, srv)
+}
+
+func _SendService_CreateSendV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSendV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SendServiceServer).CreateSendV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SendService_CreateSendV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SendServiceServer).CreateSendV1(ctx, req.(*CreateSendV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SendService_CreateFileSendV1_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SendServiceServer).CreateFileSendV1(&grpc.GenericServerStream[CreateFileSendV1Request, CreateSendV1Response]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SendService_CreateFileSendV1Server = grpc.ClientStreamingServer[CreateFileSendV1Request, CreateSendV1Response]
+
+func _SendService_ListSendsV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSendsV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SendServiceServer).ListSendsV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SendService_ListSendsV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SendServiceServer).ListSendsV1(ctx, req.(*ListSendsV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SendService_DeleteSendV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSendV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SendServiceServer).DeleteSendV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SendService_DeleteSendV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SendServiceServer).DeleteSendV1(ctx, req.(*DeleteSendV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SendService_AccessSendV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessSendV1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SendServiceServer).AccessSendV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SendService_AccessSendV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SendServiceServer).AccessSendV1(ctx, req.(*AccessSendV1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SendService_ServiceDesc is the grpc.ServiceDesc for SendService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SendService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.send.SendService",
+	HandlerType: (*SendServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSendV1",
+			Handler:    _SendService_CreateSendV1_Handler,
+		},
+		{
+			MethodName: "ListSendsV1",
+			Handler:    _SendService_ListSendsV1_Handler,
+		},
+		{
+			MethodName: "DeleteSendV1",
+			Handler:    _SendService_DeleteSendV1_Handler,
+		},
+		{
+			MethodName: "AccessSendV1",
+			Handler:    _SendService_AccessSendV1_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CreateFileSendV1",
+			Handler:       _SendService_CreateFileSendV1_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/send/send.proto",
+}
@@ -5,6 +5,7 @@ type ITokenManager interface {
 	SaveTokens(accessToken, refreshToken string) error
 	UpdateTokens(access, refresh string) error
 	IsAuthorized() bool
+	ClearTokens() error
 	HandleAuthFailure()
 	SetAuthFailCallback(callback func())
 	GetAccessToken() string
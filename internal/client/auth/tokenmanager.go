@@ -106,12 +106,18 @@ func (tm *TokenManager) IsAuthorized() bool {
 	return tm.isAuthorized
 }
 
-// HandleAuthFailure clears tokens and notifies the UI.
-func (tm *TokenManager) HandleAuthFailure() {
+// ClearTokens forgets the tokens, e.g. after signing out.
+func (tm *TokenManager) ClearTokens() error {
 	tm.AccessToken = nil
 	tm.RefreshToken = nil
 	tm.isAuthorized = false
-	err := tm.SaveTokens("", "")
+
+	return tm.SaveTokens("", "")
+}
+
+// HandleAuthFailure clears tokens and notifies the UI.
+func (tm *TokenManager) HandleAuthFailure() {
+	err := tm.ClearTokens()
 	if err != nil {
 		return
 	}
@@ -228,6 +228,33 @@ func TestHandleAuthFailure(t *testing.T) {
 	assert.Empty(t, tokens.RefreshToken)
 }
 
+func TestClearTokens(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	cfg := &config.Config{TokenFile: "clear_tokens_test.json"}
+	defer os.Remove(cfg.TokenFile)
+
+	tm := auth.NewTokenManager(&logger, cfg)
+	require.NoError(t, tm.UpdateTokens("valid_access", "valid_refresh"))
+
+	// Signing out is no authentication failure.
+	tm.SetAuthFailCallback(func() {
+		t.Error("unexpected auth failure callback")
+	})
+
+	require.NoError(t, tm.ClearTokens())
+	assert.False(t, tm.IsAuthorized())
+
+	data, err := os.ReadFile(cfg.TokenFile)
+	require.NoError(t, err)
+
+	var tokens auth.DataObject
+	require.NoError(t, json.Unmarshal(data, &tokens))
+	assert.Empty(t, tokens.AccessToken)
+	assert.Empty(t, tokens.RefreshToken)
+}
+
 func TestGetTokens(t *testing.T) {
 	t.Parallel()
 
@@ -12,11 +12,14 @@ import (
 )
 
 type Config struct {
-	Address          string `env:"ADDRESS"     envDefault:":9090"`
-	MasterKey        string `env:"MASTER_KEY"  envDefault:""`
-	Certificate      string `env:"CERTIFICATE" envDefault:""`
-	TokenFile        string `env:"TOKEN_FILE"  envDefault:""`
-	SecuredMasterKey utils.ISecureString
+	Address     string `env:"ADDRESS"     envDefault:":9090"`
+	MasterKey   string `env:"MASTER_KEY"  envDefault:""`
+	Certificate string `env:"CERTIFICATE" envDefault:""`
+	// ClientCertificate and ClientKey authenticate the client to servers that accept client certificates.
+	ClientCertificate string `env:"CLIENT_CERTIFICATE" envDefault:""`
+	ClientKey         string `env:"CLIENT_KEY"         envDefault:""`
+	TokenFile         string `env:"TOKEN_FILE"         envDefault:""`
+	SecuredMasterKey  utils.ISecureString
 }
 
 // Builder defines the builder for the Config struct.
@@ -30,11 +33,13 @@ type Builder struct {
 func NewConfigBuilder(log *zerolog.Logger) *Builder {
 	return &Builder{
 		cfg: &Config{
-			Address:          "",
-			MasterKey:        "",
-			Certificate:      "",
-			TokenFile:        "",
-			SecuredMasterKey: nil,
+			Address:           "",
+			MasterKey:         "",
+			Certificate:       "",
+			ClientCertificate: "",
+			ClientKey:         "",
+			TokenFile:         "",
+			SecuredMasterKey:  nil,
 		},
 		logger: log,
 		mu:     sync.RWMutex{},
@@ -61,6 +66,8 @@ func (b *Builder) FromFlags() *Builder {
 	fs.StringVar(&b.cfg.Address, "a", b.cfg.Address, "address and port to run server")
 	fs.StringVar(&b.cfg.MasterKey, "masterkey", b.cfg.MasterKey, "Master Key for encrypting data")
 	fs.StringVar(&b.cfg.Certificate, "cert", b.cfg.Certificate, "Certificate")
+	fs.StringVar(&b.cfg.ClientCertificate, "client_cert", b.cfg.ClientCertificate, "Client certificate")
+	fs.StringVar(&b.cfg.ClientKey, "client_key", b.cfg.ClientKey, "Private key of the client certificate")
 	fs.StringVar(&b.cfg.TokenFile, "token_file", b.cfg.TokenFile, "File where do we store tokens")
 	_ = fs.Parse(os.Args[1:])
 
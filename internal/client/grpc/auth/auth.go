@@ -2,10 +2,13 @@ package auth
 
 import (
 	"context"
+	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
 	"github.com/npavlov/go-password-manager/internal/client/auth"
@@ -17,24 +20,33 @@ type Client struct {
 	Client       pb.AuthServiceClient
 	TokenManager auth.ITokenManager
 	Log          *zerolog.Logger
+	// DeviceName names the sessions this client starts in the session list.
+	DeviceName string
 }
 
 // NewAuthClient  creates a new gRPC connection.
 func NewAuthClient(conn *grpc.ClientConn, tokenManager auth.ITokenManager, log *zerolog.Logger) *Client {
+	// Without a host name the server falls back to the user agent
+	deviceName, _ := os.Hostname()
+
 	return &Client{
 		conn:         conn,
 		Client:       pb.NewAuthServiceClient(conn),
 		TokenManager: tokenManager,
 		Log:          log,
+		DeviceName:   deviceName,
 	}
 }
 
 // Register sends a register request to the server.
-func (as *Client) Register(username, password, email string) (string, error) {
+// A non-nil vaultKey registers a zero-knowledge account, which gets no user key back.
+func (as *Client) Register(username, password, email string, vaultKey *pb.VaultKey) (string, error) {
 	resp, err := as.Client.RegisterV1(context.Background(), &pb.RegisterV1Request{
-		Username: username,
-		Password: password,
-		Email:    email,
+		Username:   username,
+		Password:   password,
+		Email:      email,
+		VaultKey:   vaultKey,
+		DeviceName: as.DeviceName,
 	})
 	if err != nil {
 		return "", errors.Wrap(err, "failed to register user")
@@ -50,17 +62,216 @@ func (as *Client) Register(username, password, email string) (string, error) {
 	return resp.GetUserKey(), nil
 }
 
-// Login sends a login request to the server.
-func (as *Client) Login(username, password string) error {
+// Login sends a login request to the server and returns the wrapped vault key
+// of zero-knowledge accounts, nil otherwise.
+// Accounts with two-factor authentication get a challenge token instead, which VerifyTOTP completes.
+func (as *Client) Login(username, password string) (*pb.VaultKey, string, error) {
 	resp, err := as.Client.LoginV1(context.Background(), &pb.LoginV1Request{
-		Username: username,
-		Password: password,
+		Username:   username,
+		Password:   password,
+		DeviceName: as.DeviceName,
 	})
 	if err != nil {
 		as.TokenManager.HandleAuthFailure()
 
-		return errors.Wrap(err, "failed to login")
+		return nil, "", errors.Wrap(err, "failed to login")
+	}
+
+	if resp.GetChallengeToken() != "" {
+		return nil, resp.GetChallengeToken(), nil
+	}
+
+	err = as.TokenManager.UpdateTokens(resp.GetToken(), resp.GetRefreshToken())
+	if err != nil {
+		as.Log.Error().Err(err).Msg("failed to update tokens")
+
+		return nil, "", errors.Wrap(err, "failed to update tokens")
+	}
+
+	return resp.GetVaultKey(), "", nil
+}
+
+// VerifyTOTP completes a login with the code of the authenticator app or a recovery code.
+func (as *Client) VerifyTOTP(challenge, code string) (*pb.VaultKey, error) {
+	resp, err := as.Client.VerifyTOTPV1(context.Background(), &pb.VerifyTOTPV1Request{
+		ChallengeToken: challenge,
+		Code:           code,
+		DeviceName:     as.DeviceName,
+	})
+	if err != nil {
+		as.TokenManager.HandleAuthFailure()
+
+		return nil, errors.Wrap(err, "failed to verify code")
+	}
+
+	err = as.TokenManager.UpdateTokens(resp.GetToken(), resp.GetRefreshToken())
+	if err != nil {
+		as.Log.Error().Err(err).Msg("failed to update tokens")
+
+		return nil, errors.Wrap(err, "failed to update tokens")
+	}
+
+	return resp.GetVaultKey(), nil
+}
+
+// EnrollTOTP starts enabling two-factor authentication and returns the secret to add to an authenticator app.
+func (as *Client) EnrollTOTP(ctx context.Context) (*pb.EnrollTOTPV1Response, error) {
+	resp, err := as.Client.EnrollTOTPV1(ctx, &pb.EnrollTOTPV1Request{})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error enrolling totp")
+
+		return nil, errors.Wrap(err, "error enrolling totp")
+	}
+
+	return resp, nil
+}
+
+// ConfirmTOTP enables two-factor authentication with a code from the enrolled secret
+// and returns the recovery codes.
+func (as *Client) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	resp, err := as.Client.ConfirmTOTPV1(ctx, &pb.ConfirmTOTPV1Request{Code: code})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error confirming totp")
+
+		return nil, errors.Wrap(err, "error confirming totp")
+	}
+
+	return resp.GetRecoveryCodes(), nil
+}
+
+// GetVaultKey fetches the wrapped vault key of the current zero-knowledge account.
+func (as *Client) GetVaultKey(ctx context.Context) (*pb.VaultKey, error) {
+	resp, err := as.Client.GetVaultKeyV1(ctx, &pb.GetVaultKeyV1Request{})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error getting vault key")
+
+		return nil, errors.Wrap(err, "error getting vault key")
+	}
+
+	return resp.GetVaultKey(), nil
+}
+
+// Logout signs out the session on the server and forgets the tokens. The tokens are forgotten
+// even if the server cannot be reached.
+func (as *Client) Logout(ctx context.Context) error {
+	_, err := as.Client.LogoutV1(ctx, &pb.LogoutV1Request{RefreshToken: as.TokenManager.GetRefreshToken()})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error logging out")
+	}
+
+	if clearErr := as.TokenManager.ClearTokens(); clearErr != nil {
+		as.Log.Error().Err(clearErr).Msg("failed to clear tokens")
+	}
+
+	return errors.Wrap(err, "error logging out")
+}
+
+// ListSessions returns the sessions of the current user.
+func (as *Client) ListSessions(ctx context.Context) ([]*pb.Session, error) {
+	resp, err := as.Client.ListSessionsV1(ctx, &pb.ListSessionsV1Request{})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error listing sessions")
+
+		return nil, errors.Wrap(err, "error listing sessions")
+	}
+
+	return resp.GetSessions(), nil
+}
+
+// RevokeSession signs out one session of the current user.
+func (as *Client) RevokeSession(ctx context.Context, sessionID string) error {
+	_, err := as.Client.RevokeSessionV1(ctx, &pb.RevokeSessionV1Request{SessionId: sessionID})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error revoking session")
+
+		return errors.Wrap(err, "error revoking session")
+	}
+
+	return nil
+}
+
+// RevokeAllSessions signs out every session of the current user, this one included,
+// and returns how many there were.
+func (as *Client) RevokeAllSessions(ctx context.Context) (int32, error) {
+	resp, err := as.Client.RevokeAllSessionsV1(ctx, &pb.RevokeAllSessionsV1Request{})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error revoking sessions")
+
+		return 0, errors.Wrap(err, "error revoking sessions")
 	}
+
+	if err := as.TokenManager.ClearTokens(); err != nil {
+		as.Log.Error().Err(err).Msg("failed to clear tokens")
+	}
+
+	return resp.GetRevoked(), nil
+}
+
+// CreateAPIToken creates a personal access token for automation and returns its secret,
+// which the server shows only this once.
+func (as *Client) CreateAPIToken(
+	ctx context.Context,
+	name string,
+	scope *pb.APITokenScope,
+	expiresAt time.Time,
+) (string, *pb.APIToken, error) {
+	resp, err := as.Client.CreateAPITokenV1(ctx, &pb.CreateAPITokenV1Request{
+		Name:      name,
+		Scope:     scope,
+		ExpiresAt: timestamppb.New(expiresAt),
+	})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error creating api token")
+
+		return "", nil, errors.Wrap(err, "error creating api token")
+	}
+
+	return resp.GetToken(), resp.GetApiToken(), nil
+}
+
+// ListAPITokens returns the personal access tokens of the current user.
+func (as *Client) ListAPITokens(ctx context.Context) ([]*pb.APIToken, error) {
+	resp, err := as.Client.ListAPITokensV1(ctx, &pb.ListAPITokensV1Request{})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error listing api tokens")
+
+		return nil, errors.Wrap(err, "error listing api tokens")
+	}
+
+	return resp.GetApiTokens(), nil
+}
+
+// RevokeAPIToken revokes one personal access token of the current user.
+func (as *Client) RevokeAPIToken(ctx context.Context, tokenID string) error {
+	_, err := as.Client.RevokeAPITokenV1(ctx, &pb.RevokeAPITokenV1Request{TokenId: tokenID})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error revoking api token")
+
+		return errors.Wrap(err, "error revoking api token")
+	}
+
+	return nil
+}
+
+// ChangePassword changes the password of the current user and keeps the tokens of the new session,
+// since the server signs out all others. A non-nil vaultKey replaces the vault key of a zero-knowledge account.
+func (as *Client) ChangePassword(
+	ctx context.Context,
+	currentPassword, newPassword string,
+	vaultKey *pb.VaultKey,
+) error {
+	resp, err := as.Client.ChangePasswordV1(ctx, &pb.ChangePasswordV1Request{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+		VaultKey:        vaultKey,
+		DeviceName:      as.DeviceName,
+	})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error changing password")
+
+		return errors.Wrap(err, "error changing password")
+	}
+
 	err = as.TokenManager.UpdateTokens(resp.GetToken(), resp.GetRefreshToken())
 	if err != nil {
 		as.Log.Error().Err(err).Msg("failed to update tokens")
@@ -70,3 +281,19 @@ func (as *Client) Login(username, password string) error {
 
 	return nil
 }
+
+// DeleteAccount deletes the current user with the whole vault and forgets the tokens.
+func (as *Client) DeleteAccount(ctx context.Context, password string) error {
+	_, err := as.Client.DeleteAccountV1(ctx, &pb.DeleteAccountV1Request{Password: password})
+	if err != nil {
+		as.Log.Error().Err(err).Msg("error deleting account")
+
+		return errors.Wrap(err, "error deleting account")
+	}
+
+	if err := as.TokenManager.ClearTokens(); err != nil {
+		as.Log.Error().Err(err).Msg("failed to clear tokens")
+	}
+
+	return nil
+}
@@ -4,12 +4,14 @@ package auth_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
 	"github.com/npavlov/go-password-manager/internal/client/grpc/auth"
@@ -47,6 +49,213 @@ func (m *MockAuthServiceClient) LoginV1(ctx context.Context,
 	return args.Get(0).(*pb.LoginV1Response), args.Error(1)
 }
 
+func (m *MockAuthServiceClient) GetVaultKeyV1(ctx context.Context,
+	in *pb.GetVaultKeyV1Request,
+	_ ...grpc.CallOption,
+) (*pb.GetVaultKeyV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.GetVaultKeyV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RotateUserKeyV1(ctx context.Context,
+	in *pb.RotateUserKeyV1Request,
+	_ ...grpc.CallOption,
+) (*pb.RotateUserKeyV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.RotateUserKeyV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) GetKeyRotationV1(ctx context.Context,
+	in *pb.GetKeyRotationV1Request,
+	_ ...grpc.CallOption,
+) (*pb.GetKeyRotationV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.GetKeyRotationV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) VerifyTOTPV1(ctx context.Context,
+	in *pb.VerifyTOTPV1Request,
+	_ ...grpc.CallOption,
+) (*pb.VerifyTOTPV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.VerifyTOTPV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) EnrollTOTPV1(ctx context.Context,
+	in *pb.EnrollTOTPV1Request,
+	_ ...grpc.CallOption,
+) (*pb.EnrollTOTPV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.EnrollTOTPV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ConfirmTOTPV1(ctx context.Context,
+	in *pb.ConfirmTOTPV1Request,
+	_ ...grpc.CallOption,
+) (*pb.ConfirmTOTPV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.ConfirmTOTPV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) LogoutV1(ctx context.Context,
+	in *pb.LogoutV1Request,
+	_ ...grpc.CallOption,
+) (*pb.LogoutV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.LogoutV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListSessionsV1(ctx context.Context,
+	in *pb.ListSessionsV1Request,
+	_ ...grpc.CallOption,
+) (*pb.ListSessionsV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.ListSessionsV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RevokeSessionV1(ctx context.Context,
+	in *pb.RevokeSessionV1Request,
+	_ ...grpc.CallOption,
+) (*pb.RevokeSessionV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.RevokeSessionV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RevokeAllSessionsV1(ctx context.Context,
+	in *pb.RevokeAllSessionsV1Request,
+	_ ...grpc.CallOption,
+) (*pb.RevokeAllSessionsV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.RevokeAllSessionsV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) CreateAPITokenV1(ctx context.Context,
+	in *pb.CreateAPITokenV1Request,
+	_ ...grpc.CallOption,
+) (*pb.CreateAPITokenV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.CreateAPITokenV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListAPITokensV1(ctx context.Context,
+	in *pb.ListAPITokensV1Request,
+	_ ...grpc.CallOption,
+) (*pb.ListAPITokensV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.ListAPITokensV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RevokeAPITokenV1(ctx context.Context,
+	in *pb.RevokeAPITokenV1Request,
+	_ ...grpc.CallOption,
+) (*pb.RevokeAPITokenV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.RevokeAPITokenV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ChangePasswordV1(ctx context.Context,
+	in *pb.ChangePasswordV1Request,
+	_ ...grpc.CallOption,
+) (*pb.ChangePasswordV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.ChangePasswordV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) DeleteAccountV1(ctx context.Context,
+	in *pb.DeleteAccountV1Request,
+	_ ...grpc.CallOption,
+) (*pb.DeleteAccountV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.DeleteAccountV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) AddEmergencyContactV1(ctx context.Context,
+	in *pb.AddEmergencyContactV1Request,
+	_ ...grpc.CallOption,
+) (*pb.AddEmergencyContactV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.AddEmergencyContactV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListEmergencyAccessV1(ctx context.Context,
+	in *pb.ListEmergencyAccessV1Request,
+	_ ...grpc.CallOption,
+) (*pb.ListEmergencyAccessV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.ListEmergencyAccessV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RemoveEmergencyAccessV1(ctx context.Context,
+	in *pb.RemoveEmergencyAccessV1Request,
+	_ ...grpc.CallOption,
+) (*pb.RemoveEmergencyAccessV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.RemoveEmergencyAccessV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RequestEmergencyAccessV1(ctx context.Context,
+	in *pb.RequestEmergencyAccessV1Request,
+	_ ...grpc.CallOption,
+) (*pb.RequestEmergencyAccessV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.RequestEmergencyAccessV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ApproveEmergencyAccessV1(ctx context.Context,
+	in *pb.ApproveEmergencyAccessV1Request,
+	_ ...grpc.CallOption,
+) (*pb.ApproveEmergencyAccessV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.ApproveEmergencyAccessV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RejectEmergencyAccessV1(ctx context.Context,
+	in *pb.RejectEmergencyAccessV1Request,
+	_ ...grpc.CallOption,
+) (*pb.RejectEmergencyAccessV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.RejectEmergencyAccessV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListEmergencyItemsV1(ctx context.Context,
+	in *pb.ListEmergencyItemsV1Request,
+	_ ...grpc.CallOption,
+) (*pb.ListEmergencyItemsV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.ListEmergencyItemsV1Response), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) TakeOverAccountV1(ctx context.Context,
+	in *pb.TakeOverAccountV1Request,
+	_ ...grpc.CallOption,
+) (*pb.TakeOverAccountV1Response, error) {
+	args := m.Called(ctx, in)
+
+	return args.Get(0).(*pb.TakeOverAccountV1Response), args.Error(1)
+}
+
 func TestRegister_Success(t *testing.T) {
 	t.Parallel()
 
@@ -74,7 +283,7 @@ func TestRegister_Success(t *testing.T) {
 
 	mockTokenManager.On("UpdateTokens", accessToken, refreshToken).Return(nil)
 
-	result, err := authClient.Register(username, password, email)
+	result, err := authClient.Register(username, password, email, nil)
 
 	require.NoError(t, err)
 	assert.Equal(t, userKey, result)
@@ -108,9 +317,11 @@ func TestLogin_Success(t *testing.T) {
 
 	mockTokenManager.On("UpdateTokens", accessToken, refreshToken).Return(nil)
 
-	err := authClient.Login(username, password)
+	vaultKey, challenge, err := authClient.Login(username, password)
 
 	require.NoError(t, err)
+	assert.Nil(t, vaultKey)
+	assert.Empty(t, challenge)
 	mockClient.AssertExpectations(t)
 	mockTokenManager.AssertExpectations(t)
 }
@@ -141,7 +352,7 @@ func TestRegister_TokenUpdateFails(t *testing.T) {
 
 	mockTokenManager.On("UpdateTokens", accessToken, refreshToken).Return(assert.AnError)
 
-	result, err := authClient.Register(username, password, email)
+	result, err := authClient.Register(username, password, email, nil)
 
 	require.Error(t, err)
 	assert.Empty(t, result)
@@ -172,11 +383,315 @@ func TestLogin_TokenUpdateFails(t *testing.T) {
 
 	mockTokenManager.On("UpdateTokens", accessToken, refreshToken).Return(assert.AnError)
 
-	err := authClient.Login(username, password)
+	_, _, err := authClient.Login(username, password)
 
 	require.Error(t, err)
 }
 
+func TestLogin_ZeroKnowledge(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockAuthServiceClient)
+	mockTokenManager := new(testutils.MockTokenManager)
+	logger := zerolog.Nop()
+
+	authClient := &auth.Client{
+		Client:       mockClient,
+		TokenManager: mockTokenManager,
+		Log:          &logger,
+	}
+
+	vaultKey := &pb.VaultKey{KdfSalt: "salt", WrappedKey: "wrapped"}
+
+	mockClient.On("LoginV1", mock.Anything, mock.AnythingOfType("*auth.LoginV1Request")).
+		Return(&pb.LoginV1Response{
+			Token:        "access-token",
+			RefreshToken: "refresh-token",
+			VaultKey:     vaultKey,
+		}, nil)
+	mockClient.On("GetVaultKeyV1", mock.Anything, mock.AnythingOfType("*auth.GetVaultKeyV1Request")).
+		Return(&pb.GetVaultKeyV1Response{VaultKey: vaultKey}, nil)
+
+	mockTokenManager.On("UpdateTokens", "access-token", "refresh-token").Return(nil)
+
+	result, _, err := authClient.Login("testuser", "testpass")
+	require.NoError(t, err)
+	assert.Equal(t, "wrapped", result.GetWrappedKey())
+
+	result, err = authClient.GetVaultKey(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "salt", result.GetKdfSalt())
+}
+
+func TestLogin_TOTP(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockAuthServiceClient)
+	mockTokenManager := new(testutils.MockTokenManager)
+	logger := zerolog.Nop()
+
+	authClient := &auth.Client{
+		Client:       mockClient,
+		TokenManager: mockTokenManager,
+		Log:          &logger,
+	}
+
+	mockClient.On("LoginV1", mock.Anything, mock.AnythingOfType("*auth.LoginV1Request")).
+		Return(&pb.LoginV1Response{ChallengeToken: "challenge"}, nil)
+	mockClient.On("VerifyTOTPV1", mock.Anything, &pb.VerifyTOTPV1Request{ChallengeToken: "challenge", Code: "123456"}).
+		Return(&pb.VerifyTOTPV1Response{Token: "access-token", RefreshToken: "refresh-token"}, nil).Once()
+	mockClient.On("VerifyTOTPV1", mock.Anything, mock.AnythingOfType("*auth.VerifyTOTPV1Request")).
+		Return((*pb.VerifyTOTPV1Response)(nil), assert.AnError).Once()
+
+	// Tokens wait for the second factor.
+	vaultKey, challenge, err := authClient.Login("testuser", "testpass")
+	require.NoError(t, err)
+	assert.Nil(t, vaultKey)
+	assert.Equal(t, "challenge", challenge)
+
+	mockTokenManager.On("UpdateTokens", "access-token", "refresh-token").Return(nil)
+
+	_, err = authClient.VerifyTOTP(challenge, "123456")
+	require.NoError(t, err)
+
+	mockTokenManager.On("HandleAuthFailure").Return()
+
+	_, err = authClient.VerifyTOTP(challenge, "123456")
+	require.ErrorContains(t, err, "failed to verify code")
+
+	mockClient.AssertExpectations(t)
+	mockTokenManager.AssertExpectations(t)
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockAuthServiceClient)
+	logger := zerolog.Nop()
+
+	authClient := &auth.Client{
+		Client: mockClient,
+		Log:    &logger,
+	}
+
+	mockClient.On("EnrollTOTPV1", mock.Anything, mock.AnythingOfType("*auth.EnrollTOTPV1Request")).
+		Return(&pb.EnrollTOTPV1Response{Secret: "SECRET"}, nil).Once()
+	mockClient.On("EnrollTOTPV1", mock.Anything, mock.AnythingOfType("*auth.EnrollTOTPV1Request")).
+		Return((*pb.EnrollTOTPV1Response)(nil), assert.AnError).Once()
+	mockClient.On("ConfirmTOTPV1", mock.Anything, &pb.ConfirmTOTPV1Request{Code: "123456"}).
+		Return(&pb.ConfirmTOTPV1Response{RecoveryCodes: []string{"abcde-fghjk"}}, nil).Once()
+	mockClient.On("ConfirmTOTPV1", mock.Anything, mock.AnythingOfType("*auth.ConfirmTOTPV1Request")).
+		Return((*pb.ConfirmTOTPV1Response)(nil), assert.AnError).Once()
+
+	resp, err := authClient.EnrollTOTP(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "SECRET", resp.GetSecret())
+
+	_, err = authClient.EnrollTOTP(t.Context())
+	require.ErrorContains(t, err, "error enrolling totp")
+
+	codes, err := authClient.ConfirmTOTP(t.Context(), "123456")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abcde-fghjk"}, codes)
+
+	_, err = authClient.ConfirmTOTP(t.Context(), "654321")
+	require.ErrorContains(t, err, "error confirming totp")
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockAuthServiceClient)
+	mockTokenManager := new(testutils.MockTokenManager)
+	logger := zerolog.Nop()
+
+	authClient := &auth.Client{
+		Client:       mockClient,
+		TokenManager: mockTokenManager,
+		Log:          &logger,
+	}
+
+	mockTokenManager.On("GetRefreshToken").Return("refresh-token")
+	mockTokenManager.On("ClearTokens").Return(nil).Twice()
+	mockClient.On("LogoutV1", mock.Anything, &pb.LogoutV1Request{RefreshToken: "refresh-token"}).
+		Return(&pb.LogoutV1Response{}, nil).Once()
+	mockClient.On("LogoutV1", mock.Anything, mock.AnythingOfType("*auth.LogoutV1Request")).
+		Return((*pb.LogoutV1Response)(nil), assert.AnError).Once()
+
+	require.NoError(t, authClient.Logout(t.Context()))
+
+	// The local tokens are forgotten even when the server cannot be reached.
+	require.ErrorContains(t, authClient.Logout(t.Context()), "error logging out")
+
+	mockClient.AssertExpectations(t)
+	mockTokenManager.AssertExpectations(t)
+}
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockAuthServiceClient)
+	mockTokenManager := new(testutils.MockTokenManager)
+	logger := zerolog.Nop()
+
+	authClient := &auth.Client{
+		Client:       mockClient,
+		TokenManager: mockTokenManager,
+		Log:          &logger,
+	}
+
+	mockClient.On("ListSessionsV1", mock.Anything, mock.AnythingOfType("*auth.ListSessionsV1Request")).
+		Return(&pb.ListSessionsV1Response{Sessions: []*pb.Session{{Id: "session-1", DeviceName: "laptop"}}}, nil).Once()
+	mockClient.On("ListSessionsV1", mock.Anything, mock.AnythingOfType("*auth.ListSessionsV1Request")).
+		Return((*pb.ListSessionsV1Response)(nil), assert.AnError).Once()
+	mockClient.On("RevokeSessionV1", mock.Anything, &pb.RevokeSessionV1Request{SessionId: "session-1"}).
+		Return(&pb.RevokeSessionV1Response{}, nil).Once()
+	mockClient.On("RevokeSessionV1", mock.Anything, mock.AnythingOfType("*auth.RevokeSessionV1Request")).
+		Return((*pb.RevokeSessionV1Response)(nil), assert.AnError).Once()
+	mockClient.On("RevokeAllSessionsV1", mock.Anything, mock.AnythingOfType("*auth.RevokeAllSessionsV1Request")).
+		Return(&pb.RevokeAllSessionsV1Response{Revoked: 3}, nil).Once()
+	mockClient.On("RevokeAllSessionsV1", mock.Anything, mock.AnythingOfType("*auth.RevokeAllSessionsV1Request")).
+		Return((*pb.RevokeAllSessionsV1Response)(nil), assert.AnError).Once()
+	mockTokenManager.On("ClearTokens").Return(nil).Once()
+
+	sessions, err := authClient.ListSessions(t.Context())
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "laptop", sessions[0].GetDeviceName())
+
+	_, err = authClient.ListSessions(t.Context())
+	require.ErrorContains(t, err, "error listing sessions")
+
+	require.NoError(t, authClient.RevokeSession(t.Context(), "session-1"))
+	require.ErrorContains(t, authClient.RevokeSession(t.Context(), "session-2"), "error revoking session")
+
+	revoked, err := authClient.RevokeAllSessions(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, revoked)
+
+	_, err = authClient.RevokeAllSessions(t.Context())
+	require.ErrorContains(t, err, "error revoking sessions")
+
+	mockClient.AssertExpectations(t)
+	mockTokenManager.AssertExpectations(t)
+}
+
+func TestAPITokens(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockAuthServiceClient)
+	logger := zerolog.Nop()
+
+	authClient := &auth.Client{
+		Client: mockClient,
+		Log:    &logger,
+	}
+
+	scope := &pb.APITokenScope{ReadOnly: true, Tags: map[string]string{"env": "ci"}}
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockClient.On("CreateAPITokenV1", mock.Anything, &pb.CreateAPITokenV1Request{
+		Name:      "deploy",
+		Scope:     scope,
+		ExpiresAt: timestamppb.New(expiresAt),
+	}).Return(&pb.CreateAPITokenV1Response{Token: "pmat_secret", ApiToken: &pb.APIToken{Id: "token-1"}}, nil).Once()
+	mockClient.On("CreateAPITokenV1", mock.Anything, mock.AnythingOfType("*auth.CreateAPITokenV1Request")).
+		Return((*pb.CreateAPITokenV1Response)(nil), assert.AnError).Once()
+	mockClient.On("ListAPITokensV1", mock.Anything, mock.AnythingOfType("*auth.ListAPITokensV1Request")).
+		Return(&pb.ListAPITokensV1Response{ApiTokens: []*pb.APIToken{{Id: "token-1", Name: "deploy"}}}, nil).Once()
+	mockClient.On("ListAPITokensV1", mock.Anything, mock.AnythingOfType("*auth.ListAPITokensV1Request")).
+		Return((*pb.ListAPITokensV1Response)(nil), assert.AnError).Once()
+	mockClient.On("RevokeAPITokenV1", mock.Anything, &pb.RevokeAPITokenV1Request{TokenId: "token-1"}).
+		Return(&pb.RevokeAPITokenV1Response{}, nil).Once()
+	mockClient.On("RevokeAPITokenV1", mock.Anything, mock.AnythingOfType("*auth.RevokeAPITokenV1Request")).
+		Return((*pb.RevokeAPITokenV1Response)(nil), assert.AnError).Once()
+
+	secret, token, err := authClient.CreateAPIToken(t.Context(), "deploy", scope, expiresAt)
+	require.NoError(t, err)
+	assert.Equal(t, "pmat_secret", secret)
+	assert.Equal(t, "token-1", token.GetId())
+
+	_, _, err = authClient.CreateAPIToken(t.Context(), "deploy", scope, expiresAt)
+	require.ErrorContains(t, err, "error creating api token")
+
+	tokens, err := authClient.ListAPITokens(t.Context())
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "deploy", tokens[0].GetName())
+
+	_, err = authClient.ListAPITokens(t.Context())
+	require.ErrorContains(t, err, "error listing api tokens")
+
+	require.NoError(t, authClient.RevokeAPIToken(t.Context(), "token-1"))
+	require.ErrorContains(t, authClient.RevokeAPIToken(t.Context(), "token-2"), "error revoking api token")
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockAuthServiceClient)
+	mockTokenManager := new(testutils.MockTokenManager)
+	logger := zerolog.Nop()
+
+	authClient := &auth.Client{
+		Client:       mockClient,
+		TokenManager: mockTokenManager,
+		Log:          &logger,
+		DeviceName:   "laptop",
+	}
+
+	vaultKey := &pb.VaultKey{KdfSalt: "salt", WrappedKey: "wrapped"}
+
+	mockClient.On("ChangePasswordV1", mock.Anything, &pb.ChangePasswordV1Request{
+		CurrentPassword: "oldPassword",
+		NewPassword:     "newPassword",
+		VaultKey:        vaultKey,
+		DeviceName:      "laptop",
+	}).Return(&pb.ChangePasswordV1Response{Token: "token", RefreshToken: "refresh"}, nil).Once()
+	mockClient.On("ChangePasswordV1", mock.Anything, mock.AnythingOfType("*auth.ChangePasswordV1Request")).
+		Return((*pb.ChangePasswordV1Response)(nil), assert.AnError).Once()
+	mockTokenManager.On("UpdateTokens", "token", "refresh").Return(nil).Once()
+
+	require.NoError(t, authClient.ChangePassword(t.Context(), "oldPassword", "newPassword", vaultKey))
+	require.ErrorContains(t, authClient.ChangePassword(t.Context(), "oldPassword", "newPassword", nil),
+		"error changing password")
+
+	mockClient.AssertExpectations(t)
+	mockTokenManager.AssertExpectations(t)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockAuthServiceClient)
+	mockTokenManager := new(testutils.MockTokenManager)
+	logger := zerolog.Nop()
+
+	authClient := &auth.Client{
+		Client:       mockClient,
+		TokenManager: mockTokenManager,
+		Log:          &logger,
+	}
+
+	mockClient.On("DeleteAccountV1", mock.Anything, &pb.DeleteAccountV1Request{Password: "password"}).
+		Return(&pb.DeleteAccountV1Response{}, nil).Once()
+	mockClient.On("DeleteAccountV1", mock.Anything, mock.AnythingOfType("*auth.DeleteAccountV1Request")).
+		Return((*pb.DeleteAccountV1Response)(nil), assert.AnError).Once()
+	mockTokenManager.On("ClearTokens").Return(nil).Once()
+
+	require.NoError(t, authClient.DeleteAccount(t.Context(), "password"))
+
+	// A failed deletion keeps the session.
+	require.ErrorContains(t, authClient.DeleteAccount(t.Context(), "wrong"), "error deleting account")
+
+	mockClient.AssertExpectations(t)
+	mockTokenManager.AssertExpectations(t)
+}
+
 func TestNewBinaryClient(t *testing.T) {
 	t.Parallel()
 
@@ -34,10 +34,11 @@ func NewBinaryClient(conn *grpc.ClientConn, tokenManager auth.ITokenManager, log
 	}
 }
 
-// UploadFile streams file data to the server.
+// UploadFile streams file data to the server, to store under itemID or under an ID the server picks when it
+// is empty.
 //
 //nolint:cyclop
-func (c *Client) UploadFile(ctx context.Context, filename string, reader io.Reader) (string, error) {
+func (c *Client) UploadFile(ctx context.Context, itemID, filename string, reader io.Reader) (string, error) {
 	stream, err := c.Client.UploadFileV1(ctx)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to start upload stream")
@@ -47,6 +48,7 @@ func (c *Client) UploadFile(ctx context.Context, filename string, reader io.Read
 	err = stream.Send(&pb.UploadFileV1Request{
 		Filename: filename,
 		Data:     make([]byte, 0),
+		ItemId:   itemID,
 	})
 	if err != nil {
 		return "", errors.Wrap(err, "failed to send file metadata")
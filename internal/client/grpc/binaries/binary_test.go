@@ -120,7 +120,7 @@ func TestUploadFile_Success(t *testing.T) {
 		Log:          &logger,
 	}
 
-	fileID, err := client.UploadFile(t.Context(), "hello.txt", reader)
+	fileID, err := client.UploadFile(t.Context(), "", "hello.txt", reader)
 	require.NoError(t, err)
 	assert.Equal(t, "file123", fileID)
 }
@@ -140,7 +140,7 @@ func TestUploadFile_StreamError(t *testing.T) {
 		Log:          &logger,
 	}
 
-	_, err := client.UploadFile(t.Context(), "file.txt", bytes.NewReader([]byte("data")))
+	_, err := client.UploadFile(t.Context(), "", "file.txt", bytes.NewReader([]byte("data")))
 	require.Error(t, err)
 }
 
@@ -2,7 +2,6 @@ package cards
 
 import (
 	"context"
-	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -10,6 +9,7 @@ import (
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/card"
 	"github.com/npavlov/go-password-manager/internal/client/auth"
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
 )
 
 // Client GRPCClient handles communication with the gRPC server.
@@ -31,47 +31,72 @@ func NewCardClient(conn *grpc.ClientConn, tokenManager auth.ITokenManager, log *
 }
 
 // GetCard sends a register request to the server.
-func (as *Client) GetCard(ctx context.Context, id string) (*pb.CardData, time.Time, error) {
+func (as *Client) GetCard(ctx context.Context, id string) (*pb.CardEntry, error) {
 	resp, err := as.Client.GetCardV1(ctx, &pb.GetCardV1Request{
 		CardId: id,
 	})
 	if err != nil {
 		as.Log.Error().Err(err).Msg("error getting password")
 
-		return nil, time.Time{}, errors.Wrap(err, "error getting password")
+		return nil, errors.Wrap(err, "error getting password")
 	}
 
-	return resp.GetCard(), resp.GetLastUpdate().AsTime(), nil
+	return &pb.CardEntry{
+		Id:         id,
+		Card:       resp.GetCard(),
+		LastUpdate: resp.GetLastUpdate(),
+		Version:    resp.GetVersion(),
+	}, nil
 }
 
-func (as *Client) UpdateCard(ctx context.Context, id, cardNum, expDate, cvv, cardHolder string) error {
-	_, err := as.Client.UpdateCardV1(ctx, &pb.UpdateCardV1Request{
-		CardId: id,
-		Data: &pb.CardData{
+// UpdateCard overwrites the card if it is still at expectedVersion (0 skips the check)
+// and returns the new version.
+func (as *Client) UpdateCard(
+	ctx context.Context,
+	id, cardNum, expDate, cvv, cardHolder string,
+	expectedVersion int64,
+) (int64, error) {
+	req := &pb.UpdateCardV1Request{
+		CardId:          id,
+		ExpectedVersion: expectedVersion,
+	}
+	if vault.IsSealed(cardNum) {
+		req.SealedData = sealedCard(cardNum, expDate, cvv, cardHolder)
+	} else {
+		req.Data = &pb.CardData{
 			CardNumber:     cardNum,
 			ExpiryDate:     expDate,
 			Cvv:            cvv,
 			CardholderName: cardHolder,
-		},
-	})
+		}
+	}
+
+	resp, err := as.Client.UpdateCardV1(ctx, req)
 	if err != nil {
 		as.Log.Error().Err(err).Msg("error updating card")
 
-		return errors.Wrap(err, "error updating card")
+		return 0, errors.Wrap(err, "error updating card")
 	}
 
-	return nil
+	return resp.GetVersion(), nil
 }
 
-func (as *Client) StoreCard(ctx context.Context, cardNum, expDate, cvv, cardHolder string) (string, error) {
-	resp, err := as.Client.StoreCardV1(ctx, &pb.StoreCardV1Request{
-		Card: &pb.CardData{
+// StoreCard stores a new card under itemID, or under an ID the server picks when it is empty. Fields
+// sealed by the vault are sent as sealed card data.
+func (as *Client) StoreCard(ctx context.Context, itemID, cardNum, expDate, cvv, cardHolder string) (string, error) {
+	req := &pb.StoreCardV1Request{ItemId: itemID}
+	if vault.IsSealed(cardNum) {
+		req.SealedCard = sealedCard(cardNum, expDate, cvv, cardHolder)
+	} else {
+		req.Card = &pb.CardData{
 			CardNumber:     cardNum,
 			ExpiryDate:     expDate,
 			Cvv:            cvv,
 			CardholderName: cardHolder,
-		},
-	})
+		}
+	}
+
+	resp, err := as.Client.StoreCardV1(ctx, req)
 	if err != nil {
 		as.Log.Error().Err(err).Msg("error storing card")
 
@@ -94,3 +119,13 @@ func (as *Client) DeleteCard(ctx context.Context, id string) (bool, error) {
 
 	return resp.GetOk(), nil
 }
+
+// sealedCard groups card fields sealed by the vault of a zero-knowledge account.
+func sealedCard(cardNum, expDate, cvv, cardHolder string) *pb.SealedCardData {
+	return &pb.SealedCardData{
+		CardNumber:     cardNum,
+		ExpiryDate:     expDate,
+		Cvv:            cvv,
+		CardholderName: cardHolder,
+	}
+}
@@ -120,6 +120,7 @@ func TestGetCard_Success(t *testing.T) {
 		Return(&card.GetCardV1Response{
 			Card:       expectedCard,
 			LastUpdate: timestamppb.New(expectedTime),
+			Version:    2,
 		}, nil)
 
 	client := &cards.Client{
@@ -128,9 +129,11 @@ func TestGetCard_Success(t *testing.T) {
 		Log:          &logger,
 	}
 
-	cardData, _, err := client.GetCard(t.Context(), "card123")
+	entry, err := client.GetCard(t.Context(), "card123")
 	require.NoError(t, err)
-	assert.Equal(t, expectedCard, cardData)
+	assert.Equal(t, "card123", entry.GetId())
+	assert.Equal(t, expectedCard, entry.GetCard())
+	assert.Equal(t, int64(2), entry.GetVersion())
 }
 
 func TestGetCard_Error(t *testing.T) {
@@ -148,7 +151,7 @@ func TestGetCard_Error(t *testing.T) {
 		Log:          &logger,
 	}
 
-	_, _, err := client.GetCard(t.Context(), "card123")
+	_, err := client.GetCard(t.Context(), "card123")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "error getting password")
 }
@@ -167,11 +170,13 @@ func TestUpdateCard_Success(t *testing.T) {
 			Cvv:            "123",
 			CardholderName: "John Doe",
 		},
+		ExpectedVersion: 2,
 	}
 
 	mockClient.On("UpdateCardV1", mock.Anything, updateReq).
 		Return(&card.UpdateCardV1Response{
-			CardId: "card123",
+			CardId:  "card123",
+			Version: 3,
 		}, nil)
 
 	client := &cards.Client{
@@ -180,8 +185,9 @@ func TestUpdateCard_Success(t *testing.T) {
 		Log:          &logger,
 	}
 
-	err := client.UpdateCard(t.Context(), "card123", "4111111111111111", "12/25", "123", "John Doe")
+	version, err := client.UpdateCard(t.Context(), "card123", "4111111111111111", "12/25", "123", "John Doe", 2)
 	require.NoError(t, err)
+	assert.Equal(t, int64(3), version)
 }
 
 func TestUpdateCard_Error(t *testing.T) {
@@ -209,7 +215,7 @@ func TestUpdateCard_Error(t *testing.T) {
 		Log:          &logger,
 	}
 
-	err := client.UpdateCard(t.Context(), "card123", "4111111111111111", "12/25", "123", "John Doe")
+	_, err := client.UpdateCard(t.Context(), "card123", "4111111111111111", "12/25", "123", "John Doe", 0)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "error updating card")
 }
@@ -238,7 +244,37 @@ func TestStoreCard_Success(t *testing.T) {
 		Log:          &logger,
 	}
 
-	cardID, err := client.StoreCard(t.Context(), "4111111111111111", "12/25", "123", "John Doe")
+	cardID, err := client.StoreCard(t.Context(), "", "4111111111111111", "12/25", "123", "John Doe")
+	require.NoError(t, err)
+	assert.Equal(t, "new-card-123", cardID)
+}
+
+func TestStoreCard_Sealed(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockCardServiceClient)
+	logger := zerolog.Nop()
+
+	storeReq := &card.StoreCardV1Request{
+		SealedCard: &card.SealedCardData{
+			CardNumber:     "zk1:number",
+			ExpiryDate:     "zk1:expiry",
+			Cvv:            "zk1:cvv",
+			CardholderName: "zk1:holder",
+		},
+		ItemId: "6f1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+	}
+
+	mockClient.On("StoreCardV1", mock.Anything, storeReq).
+		Return(&card.StoreCardV1Response{CardId: "new-card-123"}, nil)
+
+	client := &cards.Client{
+		Client:       mockClient,
+		TokenManager: new(testutils.MockTokenManager),
+		Log:          &logger,
+	}
+
+	cardID, err := client.StoreCard(t.Context(), "6f1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", "zk1:number", "zk1:expiry", "zk1:cvv", "zk1:holder")
 	require.NoError(t, err)
 	assert.Equal(t, "new-card-123", cardID)
 }
@@ -267,7 +303,7 @@ func TestStoreCard_Error(t *testing.T) {
 		Log:          &logger,
 	}
 
-	_, err := client.StoreCard(t.Context(), "4111111111111111", "12/25", "123", "John Doe")
+	_, err := client.StoreCard(t.Context(), "", "4111111111111111", "12/25", "123", "John Doe")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "error storing card")
 }
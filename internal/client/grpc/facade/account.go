@@ -0,0 +1,70 @@
+package facade
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
+)
+
+var ErrNotZeroKnowledge = errors.New("account has no master password")
+
+// ChangePassword changes the login password; the other devices of the account are signed out.
+// A non-empty newMasterKey also re-wraps the vault key of a zero-knowledge account with it. The vault key
+// itself stays, so this session keeps working, but the next login needs MASTER_KEY set to newMasterKey.
+func (fa *Facade) ChangePassword(ctx context.Context, currentPassword, newPassword, newMasterKey string) error {
+	var vaultKey *pb_auth.VaultKey
+
+	if newMasterKey != "" {
+		rewrapped, err := fa.rewrapVaultKey(ctx, newMasterKey)
+		if err != nil {
+			return err
+		}
+
+		vaultKey = rewrapped
+	}
+
+	err := fa.authClient.ChangePassword(ctx, currentPassword, newPassword, vaultKey)
+
+	return errors.Wrap(err, "error changing password")
+}
+
+// DeleteAccount deletes the account with the whole vault and ends the local session.
+func (fa *Facade) DeleteAccount(ctx context.Context, password string) error {
+	if err := fa.authClient.DeleteAccount(ctx, password); err != nil {
+		return errors.Wrap(err, "error deleting account")
+	}
+
+	fa.forgetSession()
+
+	return nil
+}
+
+// rewrapVaultKey returns the vault key of the account wrapped with newMasterKey instead of the current master key.
+func (fa *Facade) rewrapVaultKey(ctx context.Context, newMasterKey string) (*pb_auth.VaultKey, error) {
+	masterPassword := fa.masterPassword()
+	if masterPassword == "" {
+		return nil, ErrMasterKeyRequired
+	}
+
+	current, err := fa.authClient.GetVaultKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting vault key")
+	}
+
+	if current == nil {
+		return nil, ErrNotZeroKnowledge
+	}
+
+	keys, err := vault.Rewrap(masterPassword, newMasterKey, vault.Keys{
+		Salt:       current.GetKdfSalt(),
+		WrappedKey: current.GetWrappedKey(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error re-wrapping vault key")
+	}
+
+	return &pb_auth.VaultKey{KdfSalt: keys.Salt, WrappedKey: keys.WrappedKey}, nil
+}
@@ -0,0 +1,35 @@
+package facade
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
+)
+
+// CreateAPIToken creates a personal access token for scripts and CI jobs. The returned secret
+// cannot be fetched again.
+func (fa *Facade) CreateAPIToken(
+	ctx context.Context,
+	name string,
+	scope *pb_auth.APITokenScope,
+	expiresAt time.Time,
+) (string, *pb_auth.APIToken, error) {
+	secret, token, err := fa.authClient.CreateAPIToken(ctx, name, scope, expiresAt)
+
+	return secret, token, errors.Wrap(err, "error creating api token")
+}
+
+// ListAPITokens returns the personal access tokens of the account.
+func (fa *Facade) ListAPITokens(ctx context.Context) ([]*pb_auth.APIToken, error) {
+	tokens, err := fa.authClient.ListAPITokens(ctx)
+
+	return tokens, errors.Wrap(err, "error listing api tokens")
+}
+
+// RevokeAPIToken revokes one personal access token of the account.
+func (fa *Facade) RevokeAPIToken(ctx context.Context, tokenID string) error {
+	return errors.Wrap(fa.authClient.RevokeAPIToken(ctx, tokenID), "error revoking api token")
+}
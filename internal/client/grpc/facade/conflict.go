@@ -0,0 +1,47 @@
+package facade
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+)
+
+// PasswordConflictError is returned when a password was changed on the server since it was loaded.
+type PasswordConflictError struct {
+	Current *pb_password.PasswordEntry
+}
+
+func (e *PasswordConflictError) Error() string {
+	return "password was modified on the server"
+}
+
+// CardConflictError is returned when a card was changed on the server since it was loaded.
+type CardConflictError struct {
+	Current *pb_card.CardEntry
+}
+
+func (e *CardConflictError) Error() string {
+	return "card was modified on the server"
+}
+
+// asConflict turns an ABORTED status carrying the server copy of an item into a typed conflict error.
+// Any other error is returned unchanged.
+func asConflict(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Aborted {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		switch conflict := detail.(type) {
+		case *pb_password.PasswordConflict:
+			return &PasswordConflictError{Current: conflict.GetCurrent()}
+		case *pb_card.CardConflict:
+			return &CardConflictError{Current: conflict.GetCurrent()}
+		}
+	}
+
+	return err
+}
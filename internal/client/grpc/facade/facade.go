@@ -3,12 +3,14 @@ package facade
 import (
 	"context"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
 	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
 	pb_file "github.com/npavlov/go-password-manager/gen/proto/file"
 	pb "github.com/npavlov/go-password-manager/gen/proto/item"
@@ -22,22 +24,46 @@ import (
 	"github.com/npavlov/go-password-manager/internal/client/grpc/metainfo"
 	"github.com/npavlov/go-password-manager/internal/client/grpc/notes"
 	"github.com/npavlov/go-password-manager/internal/client/grpc/passwords"
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
+	"github.com/npavlov/go-password-manager/internal/utils"
 )
 
 // AuthClient Client interfaces for all dependencies.
 type AuthClient interface {
-	Login(username, password string) error
-	Register(username, password, email string) (string, error)
+	Login(username, password string) (*pb_auth.VaultKey, string, error)
+	VerifyTOTP(challenge, code string) (*pb_auth.VaultKey, error)
+	Register(username, password, email string, vaultKey *pb_auth.VaultKey) (string, error)
+	GetVaultKey(ctx context.Context) (*pb_auth.VaultKey, error)
+	EnrollTOTP(ctx context.Context) (*pb_auth.EnrollTOTPV1Response, error)
+	ConfirmTOTP(ctx context.Context, code string) ([]string, error)
+	Logout(ctx context.Context) error
+	ListSessions(ctx context.Context) ([]*pb_auth.Session, error)
+	RevokeSession(ctx context.Context, sessionID string) error
+	RevokeAllSessions(ctx context.Context) (int32, error)
+	CreateAPIToken(
+		ctx context.Context, name string, scope *pb_auth.APITokenScope, expiresAt time.Time,
+	) (string, *pb_auth.APIToken, error)
+	ListAPITokens(ctx context.Context) ([]*pb_auth.APIToken, error)
+	RevokeAPIToken(ctx context.Context, tokenID string) error
+	ChangePassword(ctx context.Context, currentPassword, newPassword string, vaultKey *pb_auth.VaultKey) error
+	DeleteAccount(ctx context.Context, password string) error
 }
 
 type ItemsClient interface {
 	GetItems(ctx context.Context, page, pageSize int32) ([]*pb.ItemData, int32, error)
+	HydrateItems(ctx context.Context, itemIDs []string, changedSince time.Time) ([]*pb.HydrateItemsV1Response, error)
+	GetChanges(ctx context.Context, sinceCursor int64, limit int32) (*pb.GetChangesV1Response, error)
+	WatchItems(ctx context.Context, sinceCursor int64, onChange func(*pb.WatchItemsV1Response)) error
+	ShareItem(ctx context.Context, itemID, recipient string, readOnly bool) (*pb.Share, error)
+	RevokeShare(ctx context.Context, itemID, recipient string) error
+	ListShares(ctx context.Context, itemID string) ([]*pb.Share, error)
+	GetSharedItem(ctx context.Context, itemID string) (*pb.HydrateItemsV1Response, error)
 }
 
 type PasswordClient interface {
-	StorePassword(ctx context.Context, login, password string) (string, error)
-	GetPassword(ctx context.Context, id string) (*pb_password.PasswordData, time.Time, error)
-	UpdatePassword(ctx context.Context, id, login, password string) error
+	StorePassword(ctx context.Context, itemID, login, password string) (string, error)
+	GetPassword(ctx context.Context, id string) (*pb_password.PasswordEntry, error)
+	UpdatePassword(ctx context.Context, id, login, password string, expectedVersion int64) (int64, error)
 	DeletePassword(ctx context.Context, id string) (bool, error)
 }
 
@@ -48,20 +74,20 @@ type MetaClient interface {
 }
 
 type NoteClient interface {
-	StoreNote(ctx context.Context, content string) (string, error)
-	GetNote(ctx context.Context, id string) (*pb_note.NoteData, time.Time, error)
+	StoreNote(ctx context.Context, itemID, content string) (string, error)
+	GetNote(ctx context.Context, id string) (*pb_note.NoteEntry, error)
 	DeleteNote(ctx context.Context, id string) (bool, error)
 }
 
 type CardClient interface {
-	StoreCard(ctx context.Context, cardNum, expDate, Cvv, cardHolder string) (string, error)
-	UpdateCard(ctx context.Context, id, cardNum, expDate, Cvv, cardHolder string) error
-	GetCard(ctx context.Context, id string) (*pb_card.CardData, time.Time, error)
+	StoreCard(ctx context.Context, itemID, cardNum, expDate, Cvv, cardHolder string) (string, error)
+	UpdateCard(ctx context.Context, id, cardNum, expDate, Cvv, cardHolder string, expectedVersion int64) (int64, error)
+	GetCard(ctx context.Context, id string) (*pb_card.CardEntry, error)
 	DeleteCard(ctx context.Context, id string) (bool, error)
 }
 
 type BinaryClient interface {
-	UploadFile(ctx context.Context, filename string, reader io.Reader) (string, error)
+	UploadFile(ctx context.Context, itemID, filename string, reader io.Reader) (string, error)
 	DownloadFile(ctx context.Context, fileID string, writer io.Writer) error
 	GetFile(ctx context.Context, fileID string) (*pb_file.FileMeta, error)
 	DeleteFile(ctx context.Context, fileID string) (bool, error)
@@ -76,6 +102,16 @@ type Facade struct {
 	noteClient     NoteClient
 	cardsClient    CardClient
 	binariesClient BinaryClient
+
+	// masterKey unlocks the vault of zero-knowledge accounts; it never leaves the client.
+	masterKey   utils.ISecureString
+	vaultMu     sync.Mutex
+	vault       *vault.Vault
+	vaultLoaded bool
+
+	// challenge is the pending login of an account with two-factor authentication.
+	challengeMu sync.Mutex
+	challenge   string
 }
 
 // Verify Facade implements IFacade.
@@ -90,6 +126,7 @@ type Options struct {
 	NoteClient     NoteClient
 	CardClient     CardClient
 	BinaryClient   BinaryClient
+	MasterKey      utils.ISecureString
 }
 
 // NewFacadeWithOptions creates a new Facade with explicit dependencies.
@@ -102,11 +139,18 @@ func NewFacadeWithOptions(opts Options) *Facade {
 		noteClient:     opts.NoteClient,
 		cardsClient:    opts.CardClient,
 		binariesClient: opts.BinaryClient,
+		masterKey:      opts.MasterKey,
 	}
 }
 
 // NewFacade creates a new Facade with default gRPC implementations.
-func NewFacade(conn *grpc.ClientConn, tokenManager *tokenMgr.TokenManager, log *zerolog.Logger) *Facade {
+// A non-empty masterKey turns new registrations into zero-knowledge accounts.
+func NewFacade(
+	conn *grpc.ClientConn,
+	tokenManager *tokenMgr.TokenManager,
+	log *zerolog.Logger,
+	masterKey utils.ISecureString,
+) *Facade {
 	opts := Options{
 		AuthClient:     auth.NewAuthClient(conn, tokenManager, log),
 		ItemsClient:    items.NewItemsClient(conn, tokenManager, log),
@@ -115,21 +159,59 @@ func NewFacade(conn *grpc.ClientConn, tokenManager *tokenMgr.TokenManager, log *
 		NoteClient:     notes.NewNoteClient(conn, tokenManager, log),
 		CardClient:     cards.NewCardClient(conn, tokenManager, log),
 		BinaryClient:   binary.NewBinaryClient(conn, tokenManager, log),
+		MasterKey:      masterKey,
 	}
 
 	return NewFacadeWithOptions(opts)
 }
 
+// Login signs in and unlocks the vault of zero-knowledge accounts.
+// It returns ErrTOTPRequired when the account needs a code, which VerifyTOTP takes.
 func (fa *Facade) Login(username, password string) error {
-	err := fa.authClient.Login(username, password)
+	vaultKey, challenge, err := fa.authClient.Login(username, password)
+	if err != nil {
+		return errors.Wrap(err, "failed to login")
+	}
+
+	fa.challengeMu.Lock()
+	fa.challenge = challenge
+	fa.challengeMu.Unlock()
 
-	return errors.Wrap(err, "failed to login")
+	if challenge != "" {
+		return ErrTOTPRequired
+	}
+
+	return errors.Wrap(fa.unlockVault(vaultKey), "failed to login")
 }
 
+// Register creates an account. With a master key configured the account is zero-knowledge:
+// the vault key is generated here and the server only receives it wrapped.
 func (fa *Facade) Register(username, password, email string) (string, error) {
-	masterKey, err := fa.authClient.Register(username, password, email)
+	var (
+		newVault *vault.Vault
+		vaultKey *pb_auth.VaultKey
+	)
+
+	if masterPassword := fa.masterPassword(); masterPassword != "" {
+		created, keys, err := vault.New(masterPassword)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to create vault")
+		}
+
+		newVault = created
+		vaultKey = &pb_auth.VaultKey{KdfSalt: keys.Salt, WrappedKey: keys.WrappedKey}
+	}
+
+	userKey, err := fa.authClient.Register(username, password, email, vaultKey)
+	if err != nil {
+		return "", errors.WithMessagef(err, "failed to register user %s", username)
+	}
 
-	return masterKey, errors.WithMessagef(err, "failed to register user %s", username)
+	fa.vaultMu.Lock()
+	fa.vault, fa.vaultLoaded = newVault, true
+	fa.vaultMu.Unlock()
+
+	return userKey, nil
 }
 
 func (fa *Facade) GetItems(ctx context.Context, page, pageSize int32) ([]*pb.ItemData, int32, error) {
@@ -138,22 +220,94 @@ func (fa *Facade) GetItems(ctx context.Context, page, pageSize int32) ([]*pb.Ite
 	return getItems, i, errors.Wrap(err, "error getting items")
 }
 
+func (fa *Facade) HydrateItems(
+	ctx context.Context,
+	itemIDs []string,
+	changedSince time.Time,
+) ([]*pb.HydrateItemsV1Response, error) {
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hydrated, err := fa.itemsClient.HydrateItems(ctx, itemIDs, changedSince)
+	if err != nil {
+		return nil, errors.Wrap(err, "error hydrating items")
+	}
+
+	for _, item := range hydrated {
+		if err := openHydrated(current, item); err != nil {
+			return nil, err
+		}
+	}
+
+	return hydrated, nil
+}
+
+func (fa *Facade) GetChanges(ctx context.Context, sinceCursor int64, limit int32) (*pb.GetChangesV1Response, error) {
+	changes, err := fa.itemsClient.GetChanges(ctx, sinceCursor, limit)
+
+	return changes, errors.Wrap(err, "error getting changes")
+}
+
+func (fa *Facade) WatchItems(
+	ctx context.Context,
+	sinceCursor int64,
+	onChange func(*pb.WatchItemsV1Response),
+) error {
+	err := fa.itemsClient.WatchItems(ctx, sinceCursor, onChange)
+
+	return errors.Wrap(err, "error watching items")
+}
+
 func (fa *Facade) StorePassword(ctx context.Context, login string, password string) (string, error) {
-	passwordID, err := fa.passwordClient.StorePassword(ctx, login, password)
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	itemID := newItemID(current)
+	if err := sealFields(current, itemID, passwordFields(&login, &password)); err != nil {
+		return "", err
+	}
+
+	passwordID, err := fa.passwordClient.StorePassword(ctx, itemID, login, password)
 
 	return passwordID, errors.Wrap(err, "error storing password")
 }
 
-func (fa *Facade) GetPassword(ctx context.Context, id string) (*pb_password.PasswordData, time.Time, error) {
-	password, lastUpdate, err := fa.passwordClient.GetPassword(ctx, id)
+func (fa *Facade) GetPassword(ctx context.Context, id string) (*pb_password.PasswordEntry, error) {
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := fa.passwordClient.GetPassword(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting password")
+	}
 
-	return password, lastUpdate, errors.Wrap(err, "error getting password")
+	return password, openPassword(current, id, password.GetPassword())
 }
 
-func (fa *Facade) UpdatePassword(ctx context.Context, id, login, password string) error {
-	err := fa.passwordClient.UpdatePassword(ctx, id, login, password)
+// UpdatePassword returns a *PasswordConflictError when expectedVersion is stale.
+func (fa *Facade) UpdatePassword(
+	ctx context.Context,
+	id, login, password string,
+	expectedVersion int64,
+) (int64, error) {
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := sealFields(current, id, passwordFields(&login, &password)); err != nil {
+		return 0, err
+	}
 
-	return errors.Wrap(err, "error updating password")
+	version, err := fa.passwordClient.UpdatePassword(ctx, id, login, password, expectedVersion)
+
+	return version, errors.Wrap(openConflict(current, id, asConflict(err)), "error updating password")
 }
 
 func (fa *Facade) DeletePassword(ctx context.Context, id string) (bool, error) {
@@ -163,6 +317,11 @@ func (fa *Facade) DeletePassword(ctx context.Context, id string) (bool, error) {
 }
 
 func (fa *Facade) GetMetainfo(ctx context.Context, id string) (map[string]string, error) {
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	meta, err := fa.metaClient.GetMetainfo(ctx, id)
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting metainfo")
@@ -172,10 +331,20 @@ func (fa *Facade) GetMetainfo(ctx context.Context, id string) (map[string]string
 		return make(map[string]string), nil
 	}
 
-	return meta, nil
+	return meta, openMeta(current, id, meta)
 }
 
 func (fa *Facade) SetMetainfo(ctx context.Context, id string, meta map[string]string) (bool, error) {
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	meta, err = sealMeta(current, id, meta)
+	if err != nil {
+		return false, err
+	}
+
 	result, err := fa.metaClient.SetMetainfo(ctx, id, meta)
 
 	return result, errors.Wrap(err, "error setting metainfo")
@@ -188,15 +357,37 @@ func (fa *Facade) DeleteMetainfo(ctx context.Context, id, key string) (bool, err
 }
 
 func (fa *Facade) StoreNote(ctx context.Context, content string) (string, error) {
-	noteID, err := fa.noteClient.StoreNote(ctx, content)
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	itemID := newItemID(current)
+	if err := sealFields(current, itemID, map[string]*string{vault.FieldNote: &content}); err != nil {
+		return "", err
+	}
+
+	noteID, err := fa.noteClient.StoreNote(ctx, itemID, content)
 
 	return noteID, errors.Wrap(err, "error storing note")
 }
 
-func (fa *Facade) GetNote(ctx context.Context, id string) (*pb_note.NoteData, time.Time, error) {
-	note, lastUpdate, err := fa.noteClient.GetNote(ctx, id)
+func (fa *Facade) GetNote(ctx context.Context, id string) (*pb_note.NoteEntry, error) {
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	return note, lastUpdate, errors.Wrap(err, "error getting note")
+	note, err := fa.noteClient.GetNote(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting note")
+	}
+
+	if note.GetNote() != nil {
+		err = openFields(current, id, map[string]*string{vault.FieldNote: &note.Note.Content})
+	}
+
+	return note, err
 }
 
 func (fa *Facade) DeleteNote(ctx context.Context, id string) (bool, error) {
@@ -206,21 +397,53 @@ func (fa *Facade) DeleteNote(ctx context.Context, id string) (bool, error) {
 }
 
 func (fa *Facade) StoreCard(ctx context.Context, cardNum, expDate, cvv, cardHolder string) (string, error) {
-	cardID, err := fa.cardsClient.StoreCard(ctx, cardNum, expDate, cvv, cardHolder)
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	itemID := newItemID(current)
+	if err := sealFields(current, itemID, cardFields(&cardNum, &expDate, &cvv, &cardHolder)); err != nil {
+		return "", err
+	}
+
+	cardID, err := fa.cardsClient.StoreCard(ctx, itemID, cardNum, expDate, cvv, cardHolder)
 
 	return cardID, errors.Wrap(err, "error storing card")
 }
 
-func (fa *Facade) UpdateCard(ctx context.Context, id, cardNum, expDate, cvv, cardHolder string) error {
-	err := fa.cardsClient.UpdateCard(ctx, id, cardNum, expDate, cvv, cardHolder)
+// UpdateCard returns a *CardConflictError when expectedVersion is stale.
+func (fa *Facade) UpdateCard(
+	ctx context.Context,
+	id, cardNum, expDate, cvv, cardHolder string,
+	expectedVersion int64,
+) (int64, error) {
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return 0, err
+	}
 
-	return errors.Wrap(err, "error updating card")
+	if err := sealFields(current, id, cardFields(&cardNum, &expDate, &cvv, &cardHolder)); err != nil {
+		return 0, err
+	}
+
+	version, err := fa.cardsClient.UpdateCard(ctx, id, cardNum, expDate, cvv, cardHolder, expectedVersion)
+
+	return version, errors.Wrap(openConflict(current, id, asConflict(err)), "error updating card")
 }
 
-func (fa *Facade) GetCard(ctx context.Context, id string) (*pb_card.CardData, time.Time, error) {
-	card, lastUpdate, err := fa.cardsClient.GetCard(ctx, id)
+func (fa *Facade) GetCard(ctx context.Context, id string) (*pb_card.CardEntry, error) {
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	return card, lastUpdate, errors.Wrap(err, "error getting note")
+	card, err := fa.cardsClient.GetCard(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting note")
+	}
+
+	return card, openCard(current, id, card.GetCard())
 }
 
 func (fa *Facade) DeleteCard(ctx context.Context, id string) (bool, error) {
@@ -231,14 +454,30 @@ func (fa *Facade) DeleteCard(ctx context.Context, id string) (bool, error) {
 
 // UploadBinary streams and stores a binary file.
 func (fa *Facade) UploadBinary(ctx context.Context, filename string, reader io.Reader) (string, error) {
-	fileID, err := fa.binariesClient.UploadFile(ctx, filename, reader)
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	itemID := newItemID(current)
+
+	fileID, err := fa.binariesClient.UploadFile(ctx, itemID, filename, sealReader(current, itemID, reader))
 
 	return fileID, errors.Wrap(err, "failed to upload binary")
 }
 
 // DownloadBinary retrieves and writes a binary file.
 func (fa *Facade) DownloadBinary(ctx context.Context, fileID string, writer io.Writer) error {
-	err := fa.binariesClient.DownloadFile(ctx, fileID, writer)
+	current, err := fa.currentVault(ctx)
+	if err != nil {
+		return err
+	}
+
+	writer, finish := openWriter(current, fileID, writer)
+	err = fa.binariesClient.DownloadFile(ctx, fileID, writer)
+	if finishErr := finish(); err == nil {
+		err = finishErr
+	}
 
 	return errors.Wrap(err, "failed to download binary")
 }
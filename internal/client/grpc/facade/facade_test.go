@@ -8,33 +8,125 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
 	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
 	pb_file "github.com/npavlov/go-password-manager/gen/proto/file"
 	pb "github.com/npavlov/go-password-manager/gen/proto/item"
 	pb_note "github.com/npavlov/go-password-manager/gen/proto/note"
 	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
 	"github.com/npavlov/go-password-manager/internal/client/grpc/facade"
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
+	"github.com/npavlov/go-password-manager/internal/utils"
 )
 
 // Mock implementations for all client interfaces
 
 type MockAuthClient struct{ mock.Mock }
 
-func (m *MockAuthClient) Login(username, password string) error {
-	return m.Called(username, password).Error(0)
+func (m *MockAuthClient) Login(username, password string) (*pb_auth.VaultKey, string, error) {
+	args := m.Called(username, password)
+	vaultKey, _ := args.Get(0).(*pb_auth.VaultKey)
+
+	return vaultKey, args.String(1), args.Error(2)
+}
+
+func (m *MockAuthClient) VerifyTOTP(challenge, code string) (*pb_auth.VaultKey, error) {
+	args := m.Called(challenge, code)
+	vaultKey, _ := args.Get(0).(*pb_auth.VaultKey)
+
+	return vaultKey, args.Error(1)
 }
 
-func (m *MockAuthClient) Register(username, password, email string) (string, error) {
-	args := m.Called(username, password, email)
+func (m *MockAuthClient) EnrollTOTP(ctx context.Context) (*pb_auth.EnrollTOTPV1Response, error) {
+	args := m.Called(ctx)
+	resp, _ := args.Get(0).(*pb_auth.EnrollTOTPV1Response)
+
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthClient) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	args := m.Called(ctx, code)
+	codes, _ := args.Get(0).([]string)
+
+	return codes, args.Error(1)
+}
+
+func (m *MockAuthClient) Register(username, password, email string, vaultKey *pb_auth.VaultKey) (string, error) {
+	args := m.Called(username, password, email, vaultKey)
 
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockAuthClient) GetVaultKey(ctx context.Context) (*pb_auth.VaultKey, error) {
+	args := m.Called(ctx)
+	vaultKey, _ := args.Get(0).(*pb_auth.VaultKey)
+
+	return vaultKey, args.Error(1)
+}
+
+func (m *MockAuthClient) Logout(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func (m *MockAuthClient) ListSessions(ctx context.Context) ([]*pb_auth.Session, error) {
+	args := m.Called(ctx)
+	sessions, _ := args.Get(0).([]*pb_auth.Session)
+
+	return sessions, args.Error(1)
+}
+
+func (m *MockAuthClient) RevokeSession(ctx context.Context, sessionID string) error {
+	return m.Called(ctx, sessionID).Error(0)
+}
+
+func (m *MockAuthClient) RevokeAllSessions(ctx context.Context) (int32, error) {
+	args := m.Called(ctx)
+
+	return int32(args.Int(0)), args.Error(1)
+}
+
+func (m *MockAuthClient) CreateAPIToken(ctx context.Context, name string, scope *pb_auth.APITokenScope,
+	expiresAt time.Time,
+) (string, *pb_auth.APIToken, error) {
+	args := m.Called(ctx, name, scope, expiresAt)
+	token, _ := args.Get(1).(*pb_auth.APIToken)
+
+	return args.String(0), token, args.Error(2)
+}
+
+func (m *MockAuthClient) ListAPITokens(ctx context.Context) ([]*pb_auth.APIToken, error) {
+	args := m.Called(ctx)
+	tokens, _ := args.Get(0).([]*pb_auth.APIToken)
+
+	return tokens, args.Error(1)
+}
+
+func (m *MockAuthClient) RevokeAPIToken(ctx context.Context, tokenID string) error {
+	return m.Called(ctx, tokenID).Error(0)
+}
+
+func (m *MockAuthClient) ChangePassword(ctx context.Context, currentPassword, newPassword string,
+	vaultKey *pb_auth.VaultKey,
+) error {
+	args := m.Called(ctx, currentPassword, newPassword, vaultKey)
+
+	return args.Error(0)
+}
+
+func (m *MockAuthClient) DeleteAccount(ctx context.Context, password string) error {
+	args := m.Called(ctx, password)
+
+	return args.Error(0)
+}
+
 type MockItemsClient struct{ mock.Mock }
 
 func (m *MockItemsClient) GetItems(ctx context.Context, page, pageSize int32) ([]*pb.ItemData, int32, error) {
@@ -43,22 +135,92 @@ func (m *MockItemsClient) GetItems(ctx context.Context, page, pageSize int32) ([
 	return args.Get(0).([]*pb.ItemData), args.Get(1).(int32), args.Error(2)
 }
 
+func (m *MockItemsClient) HydrateItems(
+	ctx context.Context,
+	itemIDs []string,
+	changedSince time.Time,
+) ([]*pb.HydrateItemsV1Response, error) {
+	args := m.Called(ctx, itemIDs, changedSince)
+
+	return args.Get(0).([]*pb.HydrateItemsV1Response), args.Error(1)
+}
+
+func (m *MockItemsClient) GetChanges(
+	ctx context.Context,
+	sinceCursor int64,
+	limit int32,
+) (*pb.GetChangesV1Response, error) {
+	args := m.Called(ctx, sinceCursor, limit)
+
+	return args.Get(0).(*pb.GetChangesV1Response), args.Error(1)
+}
+
+func (m *MockItemsClient) WatchItems(
+	ctx context.Context,
+	sinceCursor int64,
+	onChange func(*pb.WatchItemsV1Response),
+) error {
+	args := m.Called(ctx, sinceCursor, onChange)
+
+	return args.Error(0)
+}
+
+func (m *MockItemsClient) ShareItem(
+	ctx context.Context,
+	itemID, recipient string,
+	readOnly bool,
+) (*pb.Share, error) {
+	args := m.Called(ctx, itemID, recipient, readOnly)
+
+	share, _ := args.Get(0).(*pb.Share)
+
+	return share, args.Error(1)
+}
+
+func (m *MockItemsClient) RevokeShare(ctx context.Context, itemID, recipient string) error {
+	args := m.Called(ctx, itemID, recipient)
+
+	return args.Error(0)
+}
+
+func (m *MockItemsClient) ListShares(ctx context.Context, itemID string) ([]*pb.Share, error) {
+	args := m.Called(ctx, itemID)
+
+	shares, _ := args.Get(0).([]*pb.Share)
+
+	return shares, args.Error(1)
+}
+
+func (m *MockItemsClient) GetSharedItem(ctx context.Context, itemID string) (*pb.HydrateItemsV1Response, error) {
+	args := m.Called(ctx, itemID)
+
+	shared, _ := args.Get(0).(*pb.HydrateItemsV1Response)
+
+	return shared, args.Error(1)
+}
+
 type MockPasswordClient struct{ mock.Mock }
 
-func (m *MockPasswordClient) StorePassword(ctx context.Context, login, password string) (string, error) {
-	args := m.Called(ctx, login, password)
+func (m *MockPasswordClient) StorePassword(ctx context.Context, itemID, login, password string) (string, error) {
+	args := m.Called(ctx, itemID, login, password)
 
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockPasswordClient) GetPassword(ctx context.Context, id string) (*pb_password.PasswordData, time.Time, error) {
+func (m *MockPasswordClient) GetPassword(ctx context.Context, id string) (*pb_password.PasswordEntry, error) {
 	args := m.Called(ctx, id)
 
-	return args.Get(0).(*pb_password.PasswordData), args.Get(1).(time.Time), args.Error(2)
+	return args.Get(0).(*pb_password.PasswordEntry), args.Error(1)
 }
 
-func (m *MockPasswordClient) UpdatePassword(ctx context.Context, id, login, password string) error {
-	return m.Called(ctx, id, login, password).Error(0)
+func (m *MockPasswordClient) UpdatePassword(
+	ctx context.Context,
+	id, login, password string,
+	expectedVersion int64,
+) (int64, error) {
+	args := m.Called(ctx, id, login, password, expectedVersion)
+
+	return args.Get(0).(int64), args.Error(1)
 }
 
 func (m *MockPasswordClient) DeletePassword(ctx context.Context, id string) (bool, error) {
@@ -89,16 +251,16 @@ func (m *MockMetaClient) DeleteMetainfo(ctx context.Context, id, key string) (bo
 
 type MockNoteClient struct{ mock.Mock }
 
-func (m *MockNoteClient) StoreNote(ctx context.Context, content string) (string, error) {
-	args := m.Called(ctx, content)
+func (m *MockNoteClient) StoreNote(ctx context.Context, itemID, content string) (string, error) {
+	args := m.Called(ctx, itemID, content)
 
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockNoteClient) GetNote(ctx context.Context, id string) (*pb_note.NoteData, time.Time, error) {
+func (m *MockNoteClient) GetNote(ctx context.Context, id string) (*pb_note.NoteEntry, error) {
 	args := m.Called(ctx, id)
 
-	return args.Get(0).(*pb_note.NoteData), args.Get(1).(time.Time), args.Error(2)
+	return args.Get(0).(*pb_note.NoteEntry), args.Error(1)
 }
 
 func (m *MockNoteClient) DeleteNote(ctx context.Context, id string) (bool, error) {
@@ -109,20 +271,29 @@ func (m *MockNoteClient) DeleteNote(ctx context.Context, id string) (bool, error
 
 type MockCardClient struct{ mock.Mock }
 
-func (m *MockCardClient) StoreCard(ctx context.Context, cardNum, expDate, cvv, cardHolder string) (string, error) {
-	args := m.Called(ctx, cardNum, expDate, cvv, cardHolder)
+func (m *MockCardClient) StoreCard(
+	ctx context.Context,
+	itemID, cardNum, expDate, cvv, cardHolder string,
+) (string, error) {
+	args := m.Called(ctx, itemID, cardNum, expDate, cvv, cardHolder)
 
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockCardClient) UpdateCard(ctx context.Context, id, cardNum, expDate, cvv, cardHolder string) error {
-	return m.Called(ctx, id, cardNum, expDate, cvv, cardHolder).Error(0)
+func (m *MockCardClient) UpdateCard(
+	ctx context.Context,
+	id, cardNum, expDate, cvv, cardHolder string,
+	expectedVersion int64,
+) (int64, error) {
+	args := m.Called(ctx, id, cardNum, expDate, cvv, cardHolder, expectedVersion)
+
+	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockCardClient) GetCard(ctx context.Context, id string) (*pb_card.CardData, time.Time, error) {
+func (m *MockCardClient) GetCard(ctx context.Context, id string) (*pb_card.CardEntry, error) {
 	args := m.Called(ctx, id)
 
-	return args.Get(0).(*pb_card.CardData), args.Get(1).(time.Time), args.Error(2)
+	return args.Get(0).(*pb_card.CardEntry), args.Error(1)
 }
 
 func (m *MockCardClient) DeleteCard(ctx context.Context, id string) (bool, error) {
@@ -133,8 +304,8 @@ func (m *MockCardClient) DeleteCard(ctx context.Context, id string) (bool, error
 
 type MockBinaryClient struct{ mock.Mock }
 
-func (m *MockBinaryClient) UploadFile(ctx context.Context, filename string, reader io.Reader) (string, error) {
-	args := m.Called(ctx, filename, reader)
+func (m *MockBinaryClient) UploadFile(ctx context.Context, itemID, filename string, reader io.Reader) (string, error) {
+	args := m.Called(ctx, itemID, filename, reader)
 
 	return args.String(0), args.Error(1)
 }
@@ -217,9 +388,9 @@ func TestFacade_Login(t *testing.T) {
 
 			fClient, authMock, _, _, _, _, _, _ := setupFacadeTest()
 			if tt.wantErr {
-				authMock.On("Login", tt.username, tt.password).Return(errors.New("error")).Once()
+				authMock.On("Login", tt.username, tt.password).Return(nil, "", errors.New("error")).Once()
 			} else {
-				authMock.On("Login", tt.username, tt.password).Return(nil)
+				authMock.On("Login", tt.username, tt.password).Return(nil, "", nil)
 			}
 
 			err := fClient.Login(tt.username, tt.password)
@@ -235,6 +406,273 @@ func TestFacade_Login(t *testing.T) {
 	}
 }
 
+func TestFacade_LoginTOTP(t *testing.T) {
+	t.Parallel()
+
+	fClient, authMock, _, _, _, _, _, _ := setupFacadeTest()
+
+	require.ErrorIs(t, fClient.VerifyTOTP("123456"), facade.ErrNoLoginChallenge)
+
+	authMock.On("Login", "user", "password").Return(nil, "challenge", nil)
+	authMock.On("VerifyTOTP", "challenge", "000000").Return(nil, errors.New("invalid code")).Once()
+	authMock.On("VerifyTOTP", "challenge", "123456").Return(nil, nil).Once()
+
+	require.ErrorIs(t, fClient.Login("user", "password"), facade.ErrTOTPRequired)
+	require.Error(t, fClient.VerifyTOTP("000000"))
+
+	// A failed code uses up the challenge.
+	require.ErrorIs(t, fClient.VerifyTOTP("123456"), facade.ErrNoLoginChallenge)
+
+	require.ErrorIs(t, fClient.Login("user", "password"), facade.ErrTOTPRequired)
+	require.NoError(t, fClient.VerifyTOTP("123456"))
+
+	authMock.AssertExpectations(t)
+}
+
+func TestFacade_EnrollTOTP(t *testing.T) {
+	t.Parallel()
+
+	fClient, authMock, _, _, _, _, _, _ := setupFacadeTest()
+	ctx := t.Context()
+
+	authMock.On("EnrollTOTP", ctx).Return(&pb_auth.EnrollTOTPV1Response{Secret: "SECRET"}, nil)
+	authMock.On("ConfirmTOTP", ctx, "123456").Return([]string{"abcde-fghjk"}, nil)
+	authMock.On("ConfirmTOTP", ctx, "654321").Return(nil, errors.New("invalid code"))
+
+	resp, err := fClient.EnrollTOTP(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "SECRET", resp.GetSecret())
+
+	codes, err := fClient.ConfirmTOTP(ctx, "123456")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abcde-fghjk"}, codes)
+
+	_, err = fClient.ConfirmTOTP(ctx, "654321")
+	require.ErrorContains(t, err, "error confirming totp")
+}
+
+func TestFacade_Sessions(t *testing.T) {
+	t.Parallel()
+
+	fClient, authMock, _, _, _, _, _, _ := setupFacadeTest()
+	ctx := t.Context()
+
+	authMock.On("ListSessions", ctx).Return([]*pb_auth.Session{{Id: "session-1"}}, nil).Once()
+	authMock.On("ListSessions", ctx).Return(nil, errors.New("unavailable")).Once()
+	authMock.On("RevokeSession", ctx, "session-1").Return(nil)
+	authMock.On("RevokeSession", ctx, "session-2").Return(errors.New("not found"))
+	authMock.On("RevokeAllSessions", ctx).Return(2, nil).Once()
+	authMock.On("RevokeAllSessions", ctx).Return(0, errors.New("unavailable")).Once()
+	authMock.On("Logout", ctx).Return(nil).Once()
+	authMock.On("Logout", ctx).Return(errors.New("unavailable")).Once()
+
+	sessions, err := fClient.ListSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	_, err = fClient.ListSessions(ctx)
+	require.ErrorContains(t, err, "error listing sessions")
+
+	require.NoError(t, fClient.RevokeSession(ctx, "session-1"))
+	require.ErrorContains(t, fClient.RevokeSession(ctx, "session-2"), "error revoking session")
+
+	revoked, err := fClient.RevokeAllSessions(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, revoked)
+
+	_, err = fClient.RevokeAllSessions(ctx)
+	require.ErrorContains(t, err, "error revoking sessions")
+
+	require.NoError(t, fClient.Logout(ctx))
+	require.ErrorContains(t, fClient.Logout(ctx), "error logging out")
+
+	authMock.AssertExpectations(t)
+}
+
+func TestFacade_APITokens(t *testing.T) {
+	t.Parallel()
+
+	fClient, authMock, _, _, _, _, _, _ := setupFacadeTest()
+	ctx := t.Context()
+	scope := &pb_auth.APITokenScope{ReadOnly: true}
+	expiresAt := time.Now().Add(time.Hour)
+
+	authMock.On("CreateAPIToken", ctx, "deploy", scope, expiresAt).
+		Return("pmat_secret", &pb_auth.APIToken{Id: "token-1"}, nil).Once()
+	authMock.On("CreateAPIToken", ctx, "deploy", scope, expiresAt).
+		Return("", nil, errors.New("already exists")).Once()
+	authMock.On("ListAPITokens", ctx).Return([]*pb_auth.APIToken{{Id: "token-1"}}, nil).Once()
+	authMock.On("ListAPITokens", ctx).Return(nil, errors.New("unavailable")).Once()
+	authMock.On("RevokeAPIToken", ctx, "token-1").Return(nil)
+	authMock.On("RevokeAPIToken", ctx, "token-2").Return(errors.New("not found"))
+
+	secret, token, err := fClient.CreateAPIToken(ctx, "deploy", scope, expiresAt)
+	require.NoError(t, err)
+	assert.Equal(t, "pmat_secret", secret)
+	assert.Equal(t, "token-1", token.GetId())
+
+	_, _, err = fClient.CreateAPIToken(ctx, "deploy", scope, expiresAt)
+	require.ErrorContains(t, err, "error creating api token")
+
+	tokens, err := fClient.ListAPITokens(ctx)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+
+	_, err = fClient.ListAPITokens(ctx)
+	require.ErrorContains(t, err, "error listing api tokens")
+
+	require.NoError(t, fClient.RevokeAPIToken(ctx, "token-1"))
+	require.ErrorContains(t, fClient.RevokeAPIToken(ctx, "token-2"), "error revoking api token")
+
+	authMock.AssertExpectations(t)
+}
+
+func TestFacade_Shares(t *testing.T) {
+	t.Parallel()
+
+	fClient, _, itemsMock, _, _, _, _, _ := setupFacadeTest()
+	ctx := t.Context()
+	share := &pb.Share{ItemId: "item-1", Recipient: "bob", ReadOnly: true}
+
+	itemsMock.On("ShareItem", ctx, "item-1", "bob", true).Return(share, nil)
+	itemsMock.On("ShareItem", ctx, "item-1", "eve", true).Return(nil, errors.New("recipient not found"))
+	itemsMock.On("ListShares", ctx, "").Return([]*pb.Share{share}, nil)
+	itemsMock.On("GetSharedItem", ctx, "item-2").
+		Return(&pb.HydrateItemsV1Response{Item: &pb.ItemData{Id: "item-2", Owner: "alice"}}, nil)
+	itemsMock.On("GetSharedItem", ctx, "item-3").Return(nil, errors.New("not found"))
+	itemsMock.On("RevokeShare", ctx, "item-1", "bob").Return(nil)
+	itemsMock.On("RevokeShare", ctx, "item-1", "eve").Return(errors.New("share not found"))
+
+	created, err := fClient.ShareItem(ctx, "item-1", "bob", true)
+	require.NoError(t, err)
+	assert.Equal(t, share, created)
+
+	_, err = fClient.ShareItem(ctx, "item-1", "eve", true)
+	require.ErrorContains(t, err, "error sharing item")
+
+	shares, err := fClient.ListShares(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+
+	shared, err := fClient.GetSharedItem(ctx, "item-2")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", shared.GetItem().GetOwner())
+
+	_, err = fClient.GetSharedItem(ctx, "item-3")
+	require.ErrorContains(t, err, "error getting shared item")
+
+	require.NoError(t, fClient.RevokeShare(ctx, "item-1", "bob"))
+	require.ErrorContains(t, fClient.RevokeShare(ctx, "item-1", "eve"), "error revoking share")
+
+	itemsMock.AssertExpectations(t)
+}
+
+func TestFacade_LogoutLocksVault(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	fClient, authMock, passMock, _ := setupZeroKnowledgeFacade("master password")
+
+	authMock.On("Register", "user", "password", "user@example.com", mock.AnythingOfType("*auth.VaultKey")).
+		Return("", nil).Once()
+	authMock.On("Logout", ctx).Return(nil).Once()
+	authMock.On("GetVaultKey", ctx).Return(nil, errors.New("unauthenticated")).Once()
+
+	_, err := fClient.Register("user", "password", "user@example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, fClient.Logout(ctx))
+
+	// The next account fetches its own vault key.
+	_, err = fClient.GetPassword(ctx, "pass-1")
+	require.ErrorContains(t, err, "error getting vault key")
+
+	authMock.AssertExpectations(t)
+	passMock.AssertExpectations(t)
+}
+
+func TestFacade_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	fClient, authMock, _, _, _, _, _, _ := setupFacadeTest()
+	ctx := t.Context()
+
+	authMock.On("ChangePassword", ctx, "oldPassword", "newPassword", (*pb_auth.VaultKey)(nil)).Return(nil).Once()
+	authMock.On("ChangePassword", ctx, "oldPassword", "newPassword", (*pb_auth.VaultKey)(nil)).
+		Return(errors.New("invalid password")).Once()
+
+	require.NoError(t, fClient.ChangePassword(ctx, "oldPassword", "newPassword", ""))
+	require.ErrorContains(t, fClient.ChangePassword(ctx, "oldPassword", "newPassword", ""), "error changing password")
+
+	// Without a master key there is no vault key to re-wrap.
+	err := fClient.ChangePassword(ctx, "oldPassword", "newPassword", "new master")
+	require.ErrorIs(t, err, facade.ErrMasterKeyRequired)
+
+	authMock.AssertExpectations(t)
+}
+
+func TestFacade_ChangeMasterPassword(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	fClient, authMock, _, _ := setupZeroKnowledgeFacade("master password")
+
+	var registered, rewrapped *pb_auth.VaultKey
+	authMock.On("Register", "user", "password", "user@example.com", mock.AnythingOfType("*auth.VaultKey")).
+		Run(func(args mock.Arguments) { registered, _ = args.Get(3).(*pb_auth.VaultKey) }).
+		Return("", nil).Once()
+	authMock.On("ChangePassword", ctx, "password", "newPassword", mock.AnythingOfType("*auth.VaultKey")).
+		Run(func(args mock.Arguments) { rewrapped, _ = args.Get(3).(*pb_auth.VaultKey) }).
+		Return(nil).Once()
+
+	_, err := fClient.Register("user", "password", "user@example.com")
+	require.NoError(t, err)
+
+	authMock.On("GetVaultKey", ctx).Return(registered, nil).Once()
+	authMock.On("GetVaultKey", ctx).Return(nil, nil).Once()
+
+	require.NoError(t, fClient.ChangePassword(ctx, "password", "newPassword", "new master password"))
+	require.NotNil(t, rewrapped)
+	assert.NotEqual(t, registered.GetWrappedKey(), rewrapped.GetWrappedKey())
+
+	_, err = vault.Unlock("new master password", vault.Keys{
+		Salt:       rewrapped.GetKdfSalt(),
+		WrappedKey: rewrapped.GetWrappedKey(),
+	})
+	require.NoError(t, err)
+
+	// Regular accounts have no vault key.
+	require.ErrorIs(t, fClient.ChangePassword(ctx, "password", "newPassword", "new master"), facade.ErrNotZeroKnowledge)
+
+	authMock.AssertExpectations(t)
+}
+
+func TestFacade_DeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	fClient, authMock, passMock, _ := setupZeroKnowledgeFacade("master password")
+
+	authMock.On("Register", "user", "password", "user@example.com", mock.AnythingOfType("*auth.VaultKey")).
+		Return("", nil).Once()
+	authMock.On("DeleteAccount", ctx, "wrong").Return(errors.New("invalid password")).Once()
+	authMock.On("DeleteAccount", ctx, "password").Return(nil).Once()
+	authMock.On("GetVaultKey", ctx).Return(nil, errors.New("unauthenticated")).Once()
+
+	_, err := fClient.Register("user", "password", "user@example.com")
+	require.NoError(t, err)
+
+	require.ErrorContains(t, fClient.DeleteAccount(ctx, "wrong"), "error deleting account")
+	require.NoError(t, fClient.DeleteAccount(ctx, "password"))
+
+	// The vault of the deleted account is locked.
+	_, err = fClient.GetPassword(ctx, "pass-1")
+	require.ErrorContains(t, err, "error getting vault key")
+
+	authMock.AssertExpectations(t)
+	passMock.AssertExpectations(t)
+}
+
 func TestFacade_Register(t *testing.T) {
 	t.Parallel()
 
@@ -278,7 +716,7 @@ func TestFacade_Register(t *testing.T) {
 
 			fClient, authMock, _, _, _, _, _, _ := setupFacadeTest()
 
-			authMock.On("Register", tt.username, tt.password, tt.email).
+			authMock.On("Register", tt.username, tt.password, tt.email, (*pb_auth.VaultKey)(nil)).
 				Return(tt.mockKey, tt.mockErr).Once()
 
 			key, err := fClient.Register(tt.username, tt.password, tt.email)
@@ -364,6 +802,60 @@ func TestFacade_GetItems(t *testing.T) {
 	}
 }
 
+func TestFacade_HydrateItems(t *testing.T) {
+	t.Parallel()
+
+	since := time.Now().Add(-time.Hour)
+	hydrated := []*pb.HydrateItemsV1Response{
+		{Item: &pb.ItemData{Id: "item1", Type: pb.ItemType_ITEM_TYPE_NOTE}},
+	}
+
+	fClient, _, itemsMock, _, _, _, _, _ := setupFacadeTest()
+	ctx := t.Context()
+
+	itemsMock.On("HydrateItems", ctx, []string(nil), since).Return(hydrated, nil).Once()
+
+	result, err := fClient.HydrateItems(ctx, nil, since)
+	require.NoError(t, err)
+	assert.Equal(t, hydrated, result)
+
+	itemsMock.On("HydrateItems", ctx, []string{"item1"}, time.Time{}).
+		Return([]*pb.HydrateItemsV1Response(nil), errors.New("stream error")).Once()
+
+	_, err = fClient.HydrateItems(ctx, []string{"item1"}, time.Time{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error hydrating items")
+
+	itemsMock.AssertExpectations(t)
+}
+
+func TestFacade_GetChanges(t *testing.T) {
+	t.Parallel()
+
+	changes := &pb.GetChangesV1Response{
+		Changes:    []*pb.ItemChange{{ItemId: "item1", Op: pb.ChangeOp_CHANGE_OP_DELETE, Cursor: 7}},
+		NextCursor: 7,
+	}
+
+	fClient, _, itemsMock, _, _, _, _, _ := setupFacadeTest()
+	ctx := t.Context()
+
+	itemsMock.On("GetChanges", ctx, int64(3), int32(0)).Return(changes, nil).Once()
+
+	result, err := fClient.GetChanges(ctx, 3, 0)
+	require.NoError(t, err)
+	assert.Equal(t, changes, result)
+
+	itemsMock.On("GetChanges", ctx, int64(7), int32(0)).
+		Return((*pb.GetChangesV1Response)(nil), errors.New("rpc error")).Once()
+
+	_, err = fClient.GetChanges(ctx, 7, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error getting changes")
+
+	itemsMock.AssertExpectations(t)
+}
+
 func TestFacade_PasswordOperations(t *testing.T) {
 	t.Parallel()
 
@@ -379,7 +871,7 @@ func TestFacade_PasswordOperations(t *testing.T) {
 		fClient, _, _, passMock, _, _, _, _ := setupFacadeTest()
 		ctx := t.Context()
 
-		passMock.On("StorePassword", ctx, "user1", "pass1").
+		passMock.On("StorePassword", ctx, "", "user1", "pass1").
 			Return("pass-123", nil).Once()
 
 		id, err := fClient.StorePassword(ctx, "user1", "pass1")
@@ -394,15 +886,14 @@ func TestFacade_PasswordOperations(t *testing.T) {
 
 		fClient, _, _, passMock, _, _, _, _ := setupFacadeTest()
 		ctx := t.Context()
-		now := time.Now()
+		entry := &pb_password.PasswordEntry{Id: "pass-123", Password: testPass, Version: 2}
 
 		passMock.On("GetPassword", ctx, "pass-123").
-			Return(testPass, now, nil).Once()
+			Return(entry, nil).Once()
 
-		pass, updated, err := fClient.GetPassword(ctx, "pass-123")
+		pass, err := fClient.GetPassword(ctx, "pass-123")
 		require.NoError(t, err)
-		assert.Equal(t, testPass, pass)
-		assert.Equal(t, now, updated)
+		assert.Equal(t, entry, pass)
 		passMock.AssertExpectations(t)
 	})
 
@@ -413,11 +904,35 @@ func TestFacade_PasswordOperations(t *testing.T) {
 		fClient, _, _, passMock, _, _, _, _ := setupFacadeTest()
 		ctx := t.Context()
 
-		passMock.On("UpdatePassword", ctx, "pass-123", "user1", "newpass").
-			Return(nil).Once()
+		passMock.On("UpdatePassword", ctx, "pass-123", "user1", "newpass", int64(2)).
+			Return(int64(3), nil).Once()
+
+		version, err := fClient.UpdatePassword(ctx, "pass-123", "user1", "newpass", 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), version)
+		passMock.AssertExpectations(t)
+	})
+
+	t.Run("UpdatePassword conflict", func(t *testing.T) {
+		t.Parallel()
+
+		fClient, _, _, passMock, _, _, _, _ := setupFacadeTest()
+		ctx := t.Context()
+		current := &pb_password.PasswordEntry{Id: "pass-123", Password: testPass, Version: 5}
 
-		err := fClient.UpdatePassword(ctx, "pass-123", "user1", "newpass")
+		st, err := status.New(codes.Aborted, "conflict").WithDetails(&pb_password.PasswordConflict{Current: current})
 		require.NoError(t, err)
+
+		passMock.On("UpdatePassword", ctx, "pass-123", "user1", "newpass", int64(2)).
+			Return(int64(0), errors.Wrap(st.Err(), "error updating password")).Once()
+
+		_, err = fClient.UpdatePassword(ctx, "pass-123", "user1", "newpass", 2)
+		require.Error(t, err)
+
+		var conflict *facade.PasswordConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, int64(5), conflict.Current.GetVersion())
+		assert.Equal(t, "user1", conflict.Current.GetPassword().GetLogin())
 		passMock.AssertExpectations(t)
 	})
 
@@ -504,7 +1019,7 @@ func TestFacade_NoteOperations(t *testing.T) {
 		fClient, _, _, _, _, noteMock, _, _ := setupFacadeTest()
 		ctx := t.Context()
 
-		noteMock.On("StoreNote", ctx, "test content").
+		noteMock.On("StoreNote", ctx, "", "test content").
 			Return("note-123", nil).Once()
 
 		id, err := fClient.StoreNote(ctx, "test content")
@@ -519,15 +1034,14 @@ func TestFacade_NoteOperations(t *testing.T) {
 
 		fClient, _, _, _, _, noteMock, _, _ := setupFacadeTest()
 		ctx := t.Context()
-		now := time.Now()
+		entry := &pb_note.NoteEntry{Id: "note-123", Note: testNote, Version: 4}
 
 		noteMock.On("GetNote", ctx, "note-123").
-			Return(testNote, now, nil).Once()
+			Return(entry, nil).Once()
 
-		note, updated, err := fClient.GetNote(ctx, "note-123")
+		note, err := fClient.GetNote(ctx, "note-123")
 		require.NoError(t, err)
-		assert.Equal(t, testNote, note)
-		assert.Equal(t, now, updated)
+		assert.Equal(t, entry, note)
 		noteMock.AssertExpectations(t)
 	})
 
@@ -565,7 +1079,7 @@ func TestFacade_CardOperations(t *testing.T) {
 		fClient, _, _, _, _, _, cardMock, _ := setupFacadeTest()
 		ctx := t.Context()
 
-		cardMock.On("StoreCard", ctx, "4111111111111111", "12/25", "123", "John Doe").
+		cardMock.On("StoreCard", ctx, "", "4111111111111111", "12/25", "123", "John Doe").
 			Return("card-123", nil).Once()
 
 		id, err := fClient.StoreCard(ctx, "4111111111111111", "12/25", "123", "John Doe")
@@ -580,15 +1094,14 @@ func TestFacade_CardOperations(t *testing.T) {
 
 		fClient, _, _, _, _, _, cardMock, _ := setupFacadeTest()
 		ctx := t.Context()
-		now := time.Now()
+		entry := &pb_card.CardEntry{Id: "card-123", Card: testCard, Version: 4}
 
 		cardMock.On("GetCard", ctx, "card-123").
-			Return(testCard, now, nil).Once()
+			Return(entry, nil).Once()
 
-		card, updated, err := fClient.GetCard(ctx, "card-123")
+		card, err := fClient.GetCard(ctx, "card-123")
 		require.NoError(t, err)
-		assert.Equal(t, testCard, card)
-		assert.Equal(t, now, updated)
+		assert.Equal(t, entry, card)
 		cardMock.AssertExpectations(t)
 	})
 
@@ -599,11 +1112,34 @@ func TestFacade_CardOperations(t *testing.T) {
 		fClient, _, _, _, _, _, cardMock, _ := setupFacadeTest()
 		ctx := t.Context()
 
-		cardMock.On("UpdateCard", ctx, "card-123", "4111111111111111", "12/26", "456", "John Doe").
-			Return(nil).Once()
+		cardMock.On("UpdateCard", ctx, "card-123", "4111111111111111", "12/26", "456", "John Doe", int64(4)).
+			Return(int64(5), nil).Once()
+
+		version, err := fClient.UpdateCard(ctx, "card-123", "4111111111111111", "12/26", "456", "John Doe", 4)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), version)
+		cardMock.AssertExpectations(t)
+	})
+
+	t.Run("UpdateCard conflict", func(t *testing.T) {
+		t.Parallel()
+
+		fClient, _, _, _, _, _, cardMock, _ := setupFacadeTest()
+		ctx := t.Context()
+		current := &pb_card.CardEntry{Id: "card-123", Card: testCard, Version: 6}
 
-		err := fClient.UpdateCard(ctx, "card-123", "4111111111111111", "12/26", "456", "John Doe")
+		st, err := status.New(codes.Aborted, "conflict").WithDetails(&pb_card.CardConflict{Current: current})
 		require.NoError(t, err)
+
+		cardMock.On("UpdateCard", ctx, "card-123", "4111111111111111", "12/26", "456", "John Doe", int64(4)).
+			Return(int64(0), st.Err()).Once()
+
+		_, err = fClient.UpdateCard(ctx, "card-123", "4111111111111111", "12/26", "456", "John Doe", 4)
+		require.Error(t, err)
+
+		var conflict *facade.CardConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, int64(6), conflict.Current.GetVersion())
 		cardMock.AssertExpectations(t)
 	})
 
@@ -641,7 +1177,7 @@ func TestFacade_BinaryOperations(t *testing.T) {
 		ctx := t.Context()
 
 		reader := bytes.NewBufferString("test data")
-		binaryMock.On("UploadFile", ctx, "test.txt", reader).
+		binaryMock.On("UploadFile", ctx, "", "test.txt", reader).
 			Return("file-123", nil).Once()
 
 		id, err := fClient.UploadBinary(ctx, "test.txt", reader)
@@ -698,3 +1234,169 @@ func TestFacade_BinaryOperations(t *testing.T) {
 		binaryMock.AssertExpectations(t)
 	})
 }
+
+func TestFacade_WatchItems(t *testing.T) {
+	t.Parallel()
+
+	fClient, _, itemsMock, _, _, _, _, _ := setupFacadeTest()
+	ctx := t.Context()
+
+	itemsMock.On("WatchItems", ctx, int64(5), mock.Anything).Return(nil).Once()
+
+	err := fClient.WatchItems(ctx, 5, func(*pb.WatchItemsV1Response) {})
+	require.NoError(t, err)
+
+	itemsMock.On("WatchItems", ctx, int64(6), mock.Anything).Return(errors.New("stream dropped")).Once()
+
+	err = fClient.WatchItems(ctx, 6, func(*pb.WatchItemsV1Response) {})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error watching items")
+
+	itemsMock.AssertExpectations(t)
+}
+
+func setupZeroKnowledgeFacade(masterKey string) (*facade.Facade, *MockAuthClient, *MockPasswordClient,
+	*MockBinaryClient,
+) {
+	authMock := &MockAuthClient{}
+	passMock := &MockPasswordClient{}
+	binaryMock := &MockBinaryClient{}
+
+	fClient := facade.NewFacadeWithOptions(facade.Options{
+		AuthClient:     authMock,
+		PasswordClient: passMock,
+		BinaryClient:   binaryMock,
+		MasterKey:      utils.NewString(masterKey),
+	})
+
+	return fClient, authMock, passMock, binaryMock
+}
+
+func TestFacade_ZeroKnowledge(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	fClient, authMock, passMock, binaryMock := setupZeroKnowledgeFacade("master password")
+
+	// Registration sends the wrapped vault key and receives no user key.
+	var vaultKey *pb_auth.VaultKey
+	authMock.On("Register", "user", "password", "user@example.com", mock.AnythingOfType("*auth.VaultKey")).
+		Run(func(args mock.Arguments) { vaultKey = args.Get(3).(*pb_auth.VaultKey) }).
+		Return("", nil).Once()
+
+	userKey, err := fClient.Register("user", "password", "user@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, userKey)
+	require.NotNil(t, vaultKey)
+
+	// Fields leave the client sealed to the item ID it chose.
+	var passwordID, sealedLogin, sealedPassword string
+	passMock.On("StorePassword", ctx, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			passwordID, sealedLogin, sealedPassword = args.String(1), args.String(2), args.String(3)
+		}).
+		Return("", nil).Once()
+
+	_, err = fClient.StorePassword(ctx, "login", "secret")
+	require.NoError(t, err)
+	require.NoError(t, uuid.Validate(passwordID))
+	assert.True(t, vault.IsSealed(sealedLogin))
+	assert.True(t, vault.IsSealed(sealedPassword))
+
+	// Files are encrypted on upload and decrypted on download.
+	var (
+		fileID   string
+		uploaded bytes.Buffer
+	)
+	binaryMock.On("UploadFile", ctx, mock.Anything, "file.txt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			fileID = args.String(1)
+			_, _ = io.Copy(&uploaded, args.Get(3).(io.Reader))
+		}).
+		Return("file-1", nil).Once()
+	binaryMock.On("DownloadFile", ctx, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, _ = args.Get(2).(io.Writer).Write(uploaded.Bytes())
+		}).
+		Return(nil).Twice()
+
+	_, err = fClient.UploadBinary(ctx, "file.txt", bytes.NewBufferString("file content"))
+	require.NoError(t, err)
+	assert.NotContains(t, uploaded.String(), "file content")
+
+	var downloaded bytes.Buffer
+	require.NoError(t, fClient.DownloadBinary(ctx, fileID, &downloaded))
+	assert.Equal(t, "file content", downloaded.String())
+
+	// A server handing out the content of another file is noticed
+	require.Error(t, fClient.DownloadBinary(ctx, uuid.NewString(), &bytes.Buffer{}))
+
+	// Another session unlocks the same vault after login.
+	other, otherAuth, otherPass, _ := setupZeroKnowledgeFacade("master password")
+	otherAuth.On("Login", "user", "password").Return(vaultKey, "", nil).Once()
+	otherPass.On("GetPassword", ctx, passwordID).Return(&pb_password.PasswordEntry{
+		Id:       passwordID,
+		Password: &pb_password.PasswordData{Login: sealedLogin, Password: sealedPassword},
+	}, nil).Once()
+
+	require.NoError(t, other.Login("user", "password"))
+
+	entry, err := other.GetPassword(ctx, passwordID)
+	require.NoError(t, err)
+	assert.Equal(t, "login", entry.GetPassword().GetLogin())
+	assert.Equal(t, "secret", entry.GetPassword().GetPassword())
+
+	// Sealed fields moved to another item or swapped with each other do not open
+	swappedID := uuid.NewString()
+	otherPass.On("GetPassword", ctx, swappedID).Return(&pb_password.PasswordEntry{
+		Id:       swappedID,
+		Password: &pb_password.PasswordData{Login: sealedLogin, Password: sealedPassword},
+	}, nil).Once()
+	otherPass.On("GetPassword", ctx, passwordID).Return(&pb_password.PasswordEntry{
+		Id:       passwordID,
+		Password: &pb_password.PasswordData{Login: sealedPassword, Password: sealedLogin},
+	}, nil).Once()
+
+	_, err = other.GetPassword(ctx, swappedID)
+	require.ErrorContains(t, err, "error unsealing value")
+
+	_, err = other.GetPassword(ctx, passwordID)
+	require.ErrorContains(t, err, "error unsealing value")
+
+	// A session resumed from stored tokens fetches the vault key once.
+	resumed, resumedAuth, resumedPass, _ := setupZeroKnowledgeFacade("master password")
+	resumedAuth.On("GetVaultKey", ctx).Return(vaultKey, nil).Once()
+	resumedPass.On("GetPassword", ctx, passwordID).Return(&pb_password.PasswordEntry{
+		Password: &pb_password.PasswordData{Login: sealedLogin, Password: sealedPassword},
+	}, nil).Twice()
+
+	for range 2 {
+		entry, err = resumed.GetPassword(ctx, passwordID)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", entry.GetPassword().GetPassword())
+	}
+
+	resumedAuth.AssertExpectations(t)
+}
+
+func TestFacade_ZeroKnowledgeLoginErrors(t *testing.T) {
+	t.Parallel()
+
+	_, keys, err := vault.New("master password")
+	require.NoError(t, err)
+
+	vaultKey := &pb_auth.VaultKey{KdfSalt: keys.Salt, WrappedKey: keys.WrappedKey}
+
+	wrongKey, authMock, _, _ := setupZeroKnowledgeFacade("wrong password")
+	authMock.On("Login", "user", "password").Return(vaultKey, "", nil).Once()
+
+	err = wrongKey.Login("user", "password")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong master password")
+
+	noKey, authMock, _, _ := setupZeroKnowledgeFacade("")
+	authMock.On("Login", "user", "password").Return(vaultKey, "", nil).Once()
+
+	err = noKey.Login("user", "password")
+	require.ErrorIs(t, err, facade.ErrMasterKeyRequired)
+}
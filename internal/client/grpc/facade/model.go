@@ -5,6 +5,7 @@ import (
 	"io"
 	"time"
 
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
 	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
 	pb_file "github.com/npavlov/go-password-manager/gen/proto/file"
 	pb "github.com/npavlov/go-password-manager/gen/proto/item"
@@ -17,21 +18,38 @@ import (
 //nolint:interfacebloat
 type IFacade interface {
 	Login(username, password string) error
+	VerifyTOTP(code string) error
+	EnrollTOTP(ctx context.Context) (*pb_auth.EnrollTOTPV1Response, error)
+	ConfirmTOTP(ctx context.Context, code string) ([]string, error)
+	Logout(ctx context.Context) error
+	ListSessions(ctx context.Context) ([]*pb_auth.Session, error)
+	RevokeSession(ctx context.Context, sessionID string) error
+	RevokeAllSessions(ctx context.Context) (int32, error)
+	CreateAPIToken(
+		ctx context.Context, name string, scope *pb_auth.APITokenScope, expiresAt time.Time,
+	) (string, *pb_auth.APIToken, error)
+	ListAPITokens(ctx context.Context) ([]*pb_auth.APIToken, error)
+	RevokeAPIToken(ctx context.Context, tokenID string) error
+	ChangePassword(ctx context.Context, currentPassword, newPassword, newMasterKey string) error
+	DeleteAccount(ctx context.Context, password string) error
 	Register(username, password, email string) (string, error)
 	GetItems(ctx context.Context, page, pageSize int32) ([]*pb.ItemData, int32, error)
+	HydrateItems(ctx context.Context, itemIDs []string, changedSince time.Time) ([]*pb.HydrateItemsV1Response, error)
+	GetChanges(ctx context.Context, sinceCursor int64, limit int32) (*pb.GetChangesV1Response, error)
+	WatchItems(ctx context.Context, sinceCursor int64, onChange func(*pb.WatchItemsV1Response)) error
 	StorePassword(ctx context.Context, login string, password string) (string, error)
-	GetPassword(ctx context.Context, id string) (*pb_password.PasswordData, time.Time, error)
-	UpdatePassword(ctx context.Context, id, login, password string) error
+	GetPassword(ctx context.Context, id string) (*pb_password.PasswordEntry, error)
+	UpdatePassword(ctx context.Context, id, login, password string, expectedVersion int64) (int64, error)
 	DeletePassword(ctx context.Context, id string) (bool, error)
 	GetMetainfo(ctx context.Context, id string) (map[string]string, error)
 	SetMetainfo(ctx context.Context, id string, meta map[string]string) (bool, error)
 	DeleteMetainfo(ctx context.Context, id, key string) (bool, error)
 	StoreNote(ctx context.Context, content string) (string, error)
-	GetNote(ctx context.Context, id string) (*pb_note.NoteData, time.Time, error)
+	GetNote(ctx context.Context, id string) (*pb_note.NoteEntry, error)
 	DeleteNote(ctx context.Context, id string) (bool, error)
 	StoreCard(ctx context.Context, cardNum, expDate, Cvv, cardHolder string) (string, error)
-	UpdateCard(ctx context.Context, id, cardNum, expDate, Cvv, cardHolder string) error
-	GetCard(ctx context.Context, id string) (*pb_card.CardData, time.Time, error)
+	UpdateCard(ctx context.Context, id, cardNum, expDate, Cvv, cardHolder string, expectedVersion int64) (int64, error)
+	GetCard(ctx context.Context, id string) (*pb_card.CardEntry, error)
 	DeleteCard(ctx context.Context, id string) (bool, error)
 	UploadBinary(ctx context.Context, filename string, reader io.Reader) (string, error)
 	DownloadBinary(ctx context.Context, fileID string, writer io.Writer) error
@@ -0,0 +1,54 @@
+package facade
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
+)
+
+// Logout signs out of the server and locks the vault. The local session ends even when the
+// server cannot be reached, in which case the error is returned too.
+func (fa *Facade) Logout(ctx context.Context) error {
+	err := fa.authClient.Logout(ctx)
+	fa.forgetSession()
+
+	return errors.Wrap(err, "error logging out")
+}
+
+// ListSessions returns the devices signed in to the account.
+func (fa *Facade) ListSessions(ctx context.Context) ([]*pb_auth.Session, error) {
+	sessions, err := fa.authClient.ListSessions(ctx)
+
+	return sessions, errors.Wrap(err, "error listing sessions")
+}
+
+// RevokeSession signs out one device of the account.
+func (fa *Facade) RevokeSession(ctx context.Context, sessionID string) error {
+	return errors.Wrap(fa.authClient.RevokeSession(ctx, sessionID), "error revoking session")
+}
+
+// RevokeAllSessions signs out every device of the account, this one included,
+// and returns how many were signed out.
+func (fa *Facade) RevokeAllSessions(ctx context.Context) (int32, error) {
+	revoked, err := fa.authClient.RevokeAllSessions(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "error revoking sessions")
+	}
+
+	fa.forgetSession()
+
+	return revoked, nil
+}
+
+// forgetSession drops the unlocked vault and any pending login, so the next account starts clean.
+func (fa *Facade) forgetSession() {
+	fa.vaultMu.Lock()
+	fa.vault, fa.vaultLoaded = nil, false
+	fa.vaultMu.Unlock()
+
+	fa.challengeMu.Lock()
+	fa.challenge = ""
+	fa.challengeMu.Unlock()
+}
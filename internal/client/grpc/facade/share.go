@@ -0,0 +1,36 @@
+package facade
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+)
+
+// ShareItem shares an item with another user, read-only or with write access. Sharing it with the same
+// user again changes the grant.
+func (fa *Facade) ShareItem(ctx context.Context, itemID, recipient string, readOnly bool) (*pb.Share, error) {
+	share, err := fa.itemsClient.ShareItem(ctx, itemID, recipient, readOnly)
+
+	return share, errors.Wrap(err, "error sharing item")
+}
+
+// RevokeShare revokes the access of a user to an item of the account.
+func (fa *Facade) RevokeShare(ctx context.Context, itemID, recipient string) error {
+	return errors.Wrap(fa.itemsClient.RevokeShare(ctx, itemID, recipient), "error revoking share")
+}
+
+// ListShares returns who an item of the account is shared with, or every share when itemID is empty.
+func (fa *Facade) ListShares(ctx context.Context, itemID string) ([]*pb.Share, error) {
+	shares, err := fa.itemsClient.ListShares(ctx, itemID)
+
+	return shares, errors.Wrap(err, "error listing shares")
+}
+
+// GetSharedItem returns an item another user shared with the account.
+func (fa *Facade) GetSharedItem(ctx context.Context, itemID string) (*pb.HydrateItemsV1Response, error) {
+	shared, err := fa.itemsClient.GetSharedItem(ctx, itemID)
+
+	return shared, errors.Wrap(err, "error getting shared item")
+}
@@ -0,0 +1,51 @@
+package facade
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
+)
+
+var (
+	// ErrTOTPRequired is returned by Login for accounts that need a two-factor code to finish signing in.
+	ErrTOTPRequired = errors.New("two-factor code required")
+	// ErrNoLoginChallenge is returned by VerifyTOTP without a login waiting for a code.
+	ErrNoLoginChallenge = errors.New("no login is waiting for a two-factor code")
+)
+
+// VerifyTOTP finishes a login with a code of the authenticator app or a recovery code,
+// and unlocks the vault of zero-knowledge accounts. The server accepts one answer per login,
+// so a wrong code needs the password again.
+func (fa *Facade) VerifyTOTP(code string) error {
+	fa.challengeMu.Lock()
+	challenge := fa.challenge
+	fa.challenge = ""
+	fa.challengeMu.Unlock()
+
+	if challenge == "" {
+		return ErrNoLoginChallenge
+	}
+
+	vaultKey, err := fa.authClient.VerifyTOTP(challenge, code)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify code")
+	}
+
+	return errors.Wrap(fa.unlockVault(vaultKey), "failed to login")
+}
+
+// EnrollTOTP starts enabling two-factor authentication for the signed-in account.
+func (fa *Facade) EnrollTOTP(ctx context.Context) (*pb_auth.EnrollTOTPV1Response, error) {
+	resp, err := fa.authClient.EnrollTOTP(ctx)
+
+	return resp, errors.Wrap(err, "error enrolling totp")
+}
+
+// ConfirmTOTP enables two-factor authentication and returns the recovery codes.
+func (fa *Facade) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	codes, err := fa.authClient.ConfirmTOTP(ctx, code)
+
+	return codes, errors.Wrap(err, "error confirming totp")
+}
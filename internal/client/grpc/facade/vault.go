@@ -0,0 +1,263 @@
+package facade
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
+)
+
+var ErrMasterKeyRequired = errors.New("master key is required to unlock a zero-knowledge account")
+
+// masterPassword returns the configured master key, empty when none is set.
+func (fa *Facade) masterPassword() string {
+	if fa.masterKey == nil {
+		return ""
+	}
+
+	return fa.masterKey.Get()
+}
+
+// unlockVault unwraps the vault key of a zero-knowledge account; a nil key means a regular account.
+func (fa *Facade) unlockVault(vaultKey *pb_auth.VaultKey) error {
+	fa.vaultMu.Lock()
+	defer fa.vaultMu.Unlock()
+
+	fa.vault, fa.vaultLoaded = nil, true
+	if vaultKey == nil {
+		return nil
+	}
+
+	masterPassword := fa.masterPassword()
+	if masterPassword == "" {
+		return ErrMasterKeyRequired
+	}
+
+	unlocked, err := vault.Unlock(masterPassword, vault.Keys{
+		Salt:       vaultKey.GetKdfSalt(),
+		WrappedKey: vaultKey.GetWrappedKey(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to unlock vault")
+	}
+
+	fa.vault = unlocked
+
+	return nil
+}
+
+// currentVault returns the vault of the signed-in account, nil for regular accounts.
+// Sessions resumed from stored tokens fetch the vault key on first use.
+func (fa *Facade) currentVault(ctx context.Context) (*vault.Vault, error) {
+	fa.vaultMu.Lock()
+	loaded, current := fa.vaultLoaded, fa.vault
+	fa.vaultMu.Unlock()
+
+	if loaded || fa.masterPassword() == "" {
+		return current, nil
+	}
+
+	vaultKey, err := fa.authClient.GetVaultKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting vault key")
+	}
+
+	if err := fa.unlockVault(vaultKey); err != nil {
+		return nil, err
+	}
+
+	fa.vaultMu.Lock()
+	defer fa.vaultMu.Unlock()
+
+	return fa.vault, nil
+}
+
+// newItemID chooses the ID of a new item of a zero-knowledge account, so its fields can be sealed to the
+// item before it exists. Regular accounts leave the ID to the server.
+func newItemID(v *vault.Vault) string {
+	if v == nil {
+		return ""
+	}
+
+	return uuid.NewString()
+}
+
+// sealFields seals the values of fields, keyed by field name, in place when the account is zero-knowledge.
+func sealFields(v *vault.Vault, itemID string, fields map[string]*string) error {
+	if v == nil {
+		return nil
+	}
+
+	for field, value := range fields {
+		sealed, err := v.Seal(*value, itemID, field)
+		if err != nil {
+			return errors.Wrap(err, "error sealing value")
+		}
+
+		*value = sealed
+	}
+
+	return nil
+}
+
+// openFields unseals the values of fields in place. Values that were not sealed are left as they are.
+func openFields(v *vault.Vault, itemID string, fields map[string]*string) error {
+	if v == nil {
+		return nil
+	}
+
+	for field, value := range fields {
+		if !vault.IsSealed(*value) {
+			continue
+		}
+
+		opened, err := v.Unseal(*value, itemID, field)
+		if err != nil {
+			return errors.Wrap(err, "error unsealing value")
+		}
+
+		*value = opened
+	}
+
+	return nil
+}
+
+func sealMeta(v *vault.Vault, itemID string, meta map[string]string) (map[string]string, error) {
+	if v == nil {
+		return meta, nil
+	}
+
+	sealed := make(map[string]string, len(meta))
+	for key, value := range meta {
+		if err := sealFields(v, itemID, map[string]*string{vault.MetaField(key): &value}); err != nil {
+			return nil, err
+		}
+
+		sealed[key] = value
+	}
+
+	return sealed, nil
+}
+
+func openMeta(v *vault.Vault, itemID string, meta map[string]string) error {
+	for key, value := range meta {
+		if err := openFields(v, itemID, map[string]*string{vault.MetaField(key): &value}); err != nil {
+			return err
+		}
+
+		meta[key] = value
+	}
+
+	return nil
+}
+
+func passwordFields(login, password *string) map[string]*string {
+	return map[string]*string{vault.FieldLogin: login, vault.FieldPassword: password}
+}
+
+func cardFields(cardNum, expDate, cvv, cardHolder *string) map[string]*string {
+	return map[string]*string{
+		vault.FieldCardNumber:     cardNum,
+		vault.FieldExpiryDate:     expDate,
+		vault.FieldCVV:            cvv,
+		vault.FieldCardholderName: cardHolder,
+	}
+}
+
+func openPassword(v *vault.Vault, itemID string, data *pb_password.PasswordData) error {
+	if data == nil {
+		return nil
+	}
+
+	return openFields(v, itemID, passwordFields(&data.Login, &data.Password))
+}
+
+func openCard(v *vault.Vault, itemID string, data *pb_card.CardData) error {
+	if data == nil {
+		return nil
+	}
+
+	return openFields(v, itemID, cardFields(&data.CardNumber, &data.ExpiryDate, &data.Cvv, &data.CardholderName))
+}
+
+func openHydrated(v *vault.Vault, item *pb.HydrateItemsV1Response) error {
+	itemID := item.GetItem().GetId()
+
+	if err := openMeta(v, itemID, item.GetMetadata()); err != nil {
+		return err
+	}
+
+	if err := openPassword(v, itemID, item.GetPassword()); err != nil {
+		return err
+	}
+
+	if note := item.GetNote(); note != nil {
+		if err := openFields(v, itemID, map[string]*string{vault.FieldNote: &note.Content}); err != nil {
+			return err
+		}
+	}
+
+	return openCard(v, itemID, item.GetCard())
+}
+
+// openConflict unseals the server copy of itemID carried by a conflict error.
+func openConflict(v *vault.Vault, itemID string, err error) error {
+	var passwordConflict *PasswordConflictError
+	if errors.As(err, &passwordConflict) {
+		if openErr := openPassword(v, itemID, passwordConflict.Current.GetPassword()); openErr != nil {
+			return openErr
+		}
+	}
+
+	var cardConflict *CardConflictError
+	if errors.As(err, &cardConflict) {
+		if openErr := openCard(v, itemID, cardConflict.Current.GetCard()); openErr != nil {
+			return openErr
+		}
+	}
+
+	return err
+}
+
+// sealReader encrypts the content of the file fileID on the fly when the account is zero-knowledge.
+func sealReader(v *vault.Vault, fileID string, reader io.Reader) io.Reader {
+	if v == nil {
+		return reader
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.CloseWithError(v.EncryptStream(pipeWriter, reader, fileID))
+	}()
+
+	return pipeReader
+}
+
+// openWriter returns a writer decrypting the content of the file fileID into writer, and a function
+// that must be called once everything is written.
+func openWriter(v *vault.Vault, fileID string, writer io.Writer) (io.Writer, func() error) {
+	if v == nil {
+		return writer, func() error { return nil }
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := v.DecryptStream(writer, pipeReader, fileID)
+		pipeReader.CloseWithError(err)
+		done <- err
+	}()
+
+	return pipeWriter, func() error {
+		_ = pipeWriter.Close()
+
+		return <-done
+	}
+}
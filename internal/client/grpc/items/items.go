@@ -2,10 +2,13 @@ package items
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/item"
 	"github.com/npavlov/go-password-manager/internal/client/auth"
@@ -41,3 +44,121 @@ func (as *Client) GetItems(ctx context.Context, page, pageSize int32) ([]*pb.Ite
 
 	return resp.GetItems(), resp.GetTotalCount(), nil
 }
+
+// HydrateItems streams decrypted items from the server. Items are selected by ID when
+// itemIDs is not empty, otherwise every item updated after changedSince is returned
+// (a zero changedSince returns the whole vault).
+func (as *Client) HydrateItems(
+	ctx context.Context,
+	itemIDs []string,
+	changedSince time.Time,
+) ([]*pb.HydrateItemsV1Response, error) {
+	req := &pb.HydrateItemsV1Request{
+		ItemIds: itemIDs,
+	}
+	if len(itemIDs) == 0 && !changedSince.IsZero() {
+		req.ChangedSince = timestamppb.New(changedSince)
+	}
+
+	stream, err := as.Client.HydrateItemsV1(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start hydrate stream")
+	}
+
+	hydrated := make([]*pb.HydrateItemsV1Response, 0)
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to receive hydrated item")
+		}
+
+		hydrated = append(hydrated, resp)
+	}
+
+	return hydrated, nil
+}
+
+// GetChanges fetches item changes made after sinceCursor. A zero limit lets the server pick the page size.
+func (as *Client) GetChanges(ctx context.Context, sinceCursor int64, limit int32) (*pb.GetChangesV1Response, error) {
+	resp, err := as.Client.GetChangesV1(ctx, &pb.GetChangesV1Request{
+		SinceCursor: sinceCursor,
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetChanges failed, sinceCursor=%d", sinceCursor)
+	}
+
+	return resp, nil
+}
+
+// WatchItems streams item changes made after sinceCursor and calls onChange for every batch.
+// It blocks until the stream ends or ctx is cancelled.
+func (as *Client) WatchItems(
+	ctx context.Context,
+	sinceCursor int64,
+	onChange func(*pb.WatchItemsV1Response),
+) error {
+	stream, err := as.Client.WatchItemsV1(ctx, &pb.WatchItemsV1Request{SinceCursor: sinceCursor})
+	if err != nil {
+		return errors.Wrap(err, "failed to start watch stream")
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to receive item changes")
+		}
+
+		onChange(resp)
+	}
+}
+
+// ShareItem shares an item with another user, or changes the grant of an existing share.
+func (as *Client) ShareItem(ctx context.Context, itemID, recipient string, readOnly bool) (*pb.Share, error) {
+	resp, err := as.Client.ShareItemV1(ctx, &pb.ShareItemV1Request{
+		ItemId:    itemID,
+		Recipient: recipient,
+		ReadOnly:  readOnly,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error sharing item")
+	}
+
+	return resp.GetShare(), nil
+}
+
+// RevokeShare revokes the access of recipient to an item.
+func (as *Client) RevokeShare(ctx context.Context, itemID, recipient string) error {
+	_, err := as.Client.RevokeShareV1(ctx, &pb.RevokeShareV1Request{
+		ItemId:    itemID,
+		Recipient: recipient,
+	})
+
+	return errors.Wrap(err, "error revoking share")
+}
+
+// ListShares returns the shares of an item, or of every item of the user when itemID is empty.
+func (as *Client) ListShares(ctx context.Context, itemID string) ([]*pb.Share, error) {
+	resp, err := as.Client.ListSharesV1(ctx, &pb.ListSharesV1Request{ItemId: itemID})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing shares")
+	}
+
+	return resp.GetShares(), nil
+}
+
+// GetSharedItem returns the decrypted payload and metadata of an item another user shared.
+func (as *Client) GetSharedItem(ctx context.Context, itemID string) (*pb.HydrateItemsV1Response, error) {
+	resp, err := as.Client.GetSharedItemV1(ctx, &pb.GetSharedItemV1Request{ItemId: itemID})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting shared item")
+	}
+
+	return resp.GetItem(), nil
+}
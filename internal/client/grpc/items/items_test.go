@@ -4,13 +4,16 @@ package items_test
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/npavlov/go-password-manager/gen/proto/item"
 	"github.com/npavlov/go-password-manager/internal/client/grpc/items"
@@ -42,6 +45,150 @@ func (m *MockItemServiceClient) GetItemsV1(ctx context.Context,
 	return arg, args.Error(1)
 }
 
+func (m *MockItemServiceClient) HydrateItemsV1(ctx context.Context,
+	in *item.HydrateItemsV1Request,
+	_ ...grpc.CallOption,
+) (grpc.ServerStreamingClient[item.HydrateItemsV1Response], error) {
+	args := m.Called(ctx, in)
+
+	stream, ok := args.Get(0).(grpc.ServerStreamingClient[item.HydrateItemsV1Response])
+	if !ok && args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return stream, args.Error(1)
+}
+
+func (m *MockItemServiceClient) GetChangesV1(ctx context.Context,
+	in *item.GetChangesV1Request,
+	_ ...grpc.CallOption,
+) (*item.GetChangesV1Response, error) {
+	args := m.Called(ctx, in)
+
+	arg, ok := args.Get(0).(*item.GetChangesV1Response)
+	if !ok && args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return arg, args.Error(1)
+}
+
+func (m *MockItemServiceClient) WatchItemsV1(ctx context.Context,
+	in *item.WatchItemsV1Request,
+	_ ...grpc.CallOption,
+) (grpc.ServerStreamingClient[item.WatchItemsV1Response], error) {
+	args := m.Called(ctx, in)
+
+	stream, ok := args.Get(0).(grpc.ServerStreamingClient[item.WatchItemsV1Response])
+	if !ok && args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return stream, args.Error(1)
+}
+
+func (m *MockItemServiceClient) ShareItemV1(ctx context.Context,
+	in *item.ShareItemV1Request,
+	_ ...grpc.CallOption,
+) (*item.ShareItemV1Response, error) {
+	args := m.Called(ctx, in)
+
+	arg, ok := args.Get(0).(*item.ShareItemV1Response)
+	if !ok && args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return arg, args.Error(1)
+}
+
+func (m *MockItemServiceClient) RevokeShareV1(ctx context.Context,
+	in *item.RevokeShareV1Request,
+	_ ...grpc.CallOption,
+) (*item.RevokeShareV1Response, error) {
+	args := m.Called(ctx, in)
+
+	arg, ok := args.Get(0).(*item.RevokeShareV1Response)
+	if !ok && args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return arg, args.Error(1)
+}
+
+func (m *MockItemServiceClient) ListSharesV1(ctx context.Context,
+	in *item.ListSharesV1Request,
+	_ ...grpc.CallOption,
+) (*item.ListSharesV1Response, error) {
+	args := m.Called(ctx, in)
+
+	arg, ok := args.Get(0).(*item.ListSharesV1Response)
+	if !ok && args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return arg, args.Error(1)
+}
+
+func (m *MockItemServiceClient) GetSharedItemV1(ctx context.Context,
+	in *item.GetSharedItemV1Request,
+	_ ...grpc.CallOption,
+) (*item.GetSharedItemV1Response, error) {
+	args := m.Called(ctx, in)
+
+	arg, ok := args.Get(0).(*item.GetSharedItemV1Response)
+	if !ok && args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return arg, args.Error(1)
+}
+
+func (m *MockItemServiceClient) UpdateSharedItemV1(ctx context.Context,
+	in *item.UpdateSharedItemV1Request,
+	_ ...grpc.CallOption,
+) (*item.UpdateSharedItemV1Response, error) {
+	args := m.Called(ctx, in)
+
+	arg, ok := args.Get(0).(*item.UpdateSharedItemV1Response)
+	if !ok && args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return arg, args.Error(1)
+}
+
+type MockWatchStream struct {
+	mock.Mock
+	grpc.ClientStream
+}
+
+func (s *MockWatchStream) Recv() (*item.WatchItemsV1Response, error) {
+	args := s.Called()
+
+	resp, ok := args.Get(0).(*item.WatchItemsV1Response)
+	if !ok && args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return resp, args.Error(1)
+}
+
+type MockHydrateStream struct {
+	mock.Mock
+	grpc.ClientStream
+}
+
+func (s *MockHydrateStream) Recv() (*item.HydrateItemsV1Response, error) {
+	args := s.Called()
+
+	resp, ok := args.Get(0).(*item.HydrateItemsV1Response)
+	if !ok && args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return resp, args.Error(1)
+}
+
 func (m *MockTokenManager) GetToken() (string, error) {
 	args := m.Called()
 
@@ -175,6 +322,91 @@ func TestGetItems_InvalidPageParams(t *testing.T) {
 	require.NoError(t, err) // The client doesn't validate parameters
 }
 
+func TestHydrateItems_ChangedSince(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockItemServiceClient)
+	mockStream := new(MockHydrateStream)
+	logger := zerolog.Nop()
+
+	since := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	first := &item.HydrateItemsV1Response{Item: &item.ItemData{Id: "item1"}}
+	second := &item.HydrateItemsV1Response{Item: &item.ItemData{Id: "item2"}}
+
+	mockClient.On("HydrateItemsV1", mock.Anything, &item.HydrateItemsV1Request{
+		ChangedSince: timestamppb.New(since),
+	}).Return(mockStream, nil)
+	mockStream.On("Recv").Return(first, nil).Once()
+	mockStream.On("Recv").Return(second, nil).Once()
+	mockStream.On("Recv").Return(nil, io.EOF).Once()
+
+	client := &items.Client{
+		Client:       mockClient,
+		TokenManager: new(testutils.MockTokenManager),
+		Log:          &logger,
+	}
+
+	hydrated, err := client.HydrateItems(t.Context(), nil, since)
+	require.NoError(t, err)
+	assert.Equal(t, []*item.HydrateItemsV1Response{first, second}, hydrated)
+	mockClient.AssertExpectations(t)
+	mockStream.AssertExpectations(t)
+}
+
+func TestHydrateItems_ByIDs(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockItemServiceClient)
+	mockStream := new(MockHydrateStream)
+	logger := zerolog.Nop()
+
+	// changedSince is ignored when IDs are given
+	mockClient.On("HydrateItemsV1", mock.Anything, &item.HydrateItemsV1Request{
+		ItemIds: []string{"item1"},
+	}).Return(mockStream, nil)
+	mockStream.On("Recv").Return(nil, io.EOF).Once()
+
+	client := &items.Client{
+		Client:       mockClient,
+		TokenManager: new(testutils.MockTokenManager),
+		Log:          &logger,
+	}
+
+	hydrated, err := client.HydrateItems(t.Context(), []string{"item1"}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, hydrated)
+}
+
+func TestHydrateItems_Errors(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+
+	mockClient := new(MockItemServiceClient)
+	mockClient.On("HydrateItemsV1", mock.Anything, mock.Anything).Return(nil, errors.New("grpc error"))
+
+	client := &items.Client{
+		Client:       mockClient,
+		TokenManager: new(testutils.MockTokenManager),
+		Log:          &logger,
+	}
+
+	_, err := client.HydrateItems(t.Context(), nil, time.Time{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to start hydrate stream")
+
+	mockStream := new(MockHydrateStream)
+	mockStream.On("Recv").Return(nil, errors.New("stream broken"))
+
+	mockClient = new(MockItemServiceClient)
+	mockClient.On("HydrateItemsV1", mock.Anything, mock.Anything).Return(mockStream, nil)
+	client.Client = mockClient
+
+	_, err = client.HydrateItems(t.Context(), nil, time.Time{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to receive hydrated item")
+}
+
 func TestNewItemsClient(t *testing.T) {
 	t.Parallel()
 
@@ -185,3 +417,163 @@ func TestNewItemsClient(t *testing.T) {
 
 	assert.NotNil(t, client)
 }
+
+func TestGetChanges(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+
+	expected := &item.GetChangesV1Response{
+		Changes: []*item.ItemChange{
+			{ItemId: "item1", Type: item.ItemType_ITEM_TYPE_CARD, Op: item.ChangeOp_CHANGE_OP_UPSERT, Cursor: 11},
+		},
+		NextCursor: 11,
+		HasMore:    true,
+	}
+
+	mockClient := new(MockItemServiceClient)
+	mockClient.On("GetChangesV1", mock.Anything, &item.GetChangesV1Request{SinceCursor: 10, Limit: 1}).
+		Return(expected, nil)
+
+	client := &items.Client{
+		Client:       mockClient,
+		TokenManager: new(testutils.MockTokenManager),
+		Log:          &logger,
+	}
+
+	resp, err := client.GetChanges(t.Context(), 10, 1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetChanges_Error(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+
+	mockClient := new(MockItemServiceClient)
+	mockClient.On("GetChangesV1", mock.Anything, mock.Anything).Return(nil, errors.New("grpc error"))
+
+	client := &items.Client{
+		Client:       mockClient,
+		TokenManager: new(testutils.MockTokenManager),
+		Log:          &logger,
+	}
+
+	resp, err := client.GetChanges(t.Context(), 5, 0)
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), "GetChanges failed, sinceCursor=5")
+}
+
+func TestWatchItems(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockItemServiceClient)
+	mockStream := new(MockWatchStream)
+	logger := zerolog.Nop()
+
+	first := &item.WatchItemsV1Response{
+		Changes:    []*item.ItemChange{{ItemId: "item1", Cursor: 4}},
+		NextCursor: 4,
+	}
+	second := &item.WatchItemsV1Response{
+		Changes:    []*item.ItemChange{{ItemId: "item2", Cursor: 6}},
+		NextCursor: 6,
+	}
+
+	mockClient.On("WatchItemsV1", mock.Anything, &item.WatchItemsV1Request{SinceCursor: 3}).Return(mockStream, nil)
+	mockStream.On("Recv").Return(first, nil).Once()
+	mockStream.On("Recv").Return(second, nil).Once()
+	mockStream.On("Recv").Return(nil, io.EOF).Once()
+
+	client := &items.Client{
+		Client:       mockClient,
+		TokenManager: new(testutils.MockTokenManager),
+		Log:          &logger,
+	}
+
+	var received []*item.WatchItemsV1Response
+	err := client.WatchItems(t.Context(), 3, func(resp *item.WatchItemsV1Response) {
+		received = append(received, resp)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*item.WatchItemsV1Response{first, second}, received)
+	mockClient.AssertExpectations(t)
+	mockStream.AssertExpectations(t)
+}
+
+func TestWatchItems_Errors(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+
+	mockClient := new(MockItemServiceClient)
+	mockClient.On("WatchItemsV1", mock.Anything, mock.Anything).Return(nil, errors.New("grpc error"))
+
+	client := &items.Client{
+		Client:       mockClient,
+		TokenManager: new(testutils.MockTokenManager),
+		Log:          &logger,
+	}
+
+	err := client.WatchItems(t.Context(), 0, func(*item.WatchItemsV1Response) {})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to start watch stream")
+
+	mockStream := new(MockWatchStream)
+	mockStream.On("Recv").Return(nil, errors.New("stream dropped"))
+
+	mockClient = new(MockItemServiceClient)
+	mockClient.On("WatchItemsV1", mock.Anything, mock.Anything).Return(mockStream, nil)
+	client.Client = mockClient
+
+	err = client.WatchItems(t.Context(), 0, func(*item.WatchItemsV1Response) {})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to receive item changes")
+}
+
+func TestShares(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	share := &item.Share{ItemId: "item1", Recipient: "bob", ReadOnly: true}
+	shared := &item.HydrateItemsV1Response{Item: &item.ItemData{Id: "item2", Owner: "alice"}}
+
+	mockClient := new(MockItemServiceClient)
+	mockClient.On("ShareItemV1", mock.Anything,
+		&item.ShareItemV1Request{ItemId: "item1", Recipient: "bob", ReadOnly: true}).
+		Return(&item.ShareItemV1Response{Share: share}, nil)
+	mockClient.On("ListSharesV1", mock.Anything, &item.ListSharesV1Request{ItemId: "item1"}).
+		Return(&item.ListSharesV1Response{Shares: []*item.Share{share}}, nil)
+	mockClient.On("GetSharedItemV1", mock.Anything, &item.GetSharedItemV1Request{ItemId: "item2"}).
+		Return(&item.GetSharedItemV1Response{Item: shared}, nil)
+	mockClient.On("RevokeShareV1", mock.Anything, &item.RevokeShareV1Request{ItemId: "item1", Recipient: "bob"}).
+		Return(&item.RevokeShareV1Response{}, nil)
+	mockClient.On("RevokeShareV1", mock.Anything, &item.RevokeShareV1Request{ItemId: "item1", Recipient: "eve"}).
+		Return(nil, errors.New("share not found"))
+
+	client := &items.Client{
+		Client:       mockClient,
+		TokenManager: new(testutils.MockTokenManager),
+		Log:          &logger,
+	}
+
+	created, err := client.ShareItem(t.Context(), "item1", "bob", true)
+	require.NoError(t, err)
+	assert.Equal(t, share, created)
+
+	shares, err := client.ListShares(t.Context(), "item1")
+	require.NoError(t, err)
+	assert.Equal(t, []*item.Share{share}, shares)
+
+	got, err := client.GetSharedItem(t.Context(), "item2")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", got.GetItem().GetOwner())
+
+	require.NoError(t, client.RevokeShare(t.Context(), "item1", "bob"))
+	require.ErrorContains(t, client.RevokeShare(t.Context(), "item1", "eve"), "error revoking share")
+
+	mockClient.AssertExpectations(t)
+}
@@ -2,7 +2,6 @@ package notes
 
 import (
 	"context"
-	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -31,24 +30,31 @@ func NewNoteClient(conn *grpc.ClientConn, tokenManager auth.ITokenManager, log *
 }
 
 // GetNote sends a register request to the server.
-func (as *Client) GetNote(ctx context.Context, id string) (*pb.NoteData, time.Time, error) {
+func (as *Client) GetNote(ctx context.Context, id string) (*pb.NoteEntry, error) {
 	resp, err := as.Client.GetNoteV1(ctx, &pb.GetNoteV1Request{
 		NoteId: id,
 	})
 	if err != nil {
 		as.Log.Error().Err(err).Msg("error getting password")
 
-		return nil, time.Time{}, errors.Wrap(err, "error getting password")
+		return nil, errors.Wrap(err, "error getting password")
 	}
 
-	return resp.GetNote(), resp.GetLastUpdate().AsTime(), nil
+	return &pb.NoteEntry{
+		Id:         id,
+		Note:       resp.GetNote(),
+		LastUpdate: resp.GetLastUpdate(),
+		Version:    resp.GetVersion(),
+	}, nil
 }
 
-func (as *Client) StoreNote(ctx context.Context, content string) (string, error) {
+// StoreNote stores a new note under itemID, or under an ID the server picks when it is empty.
+func (as *Client) StoreNote(ctx context.Context, itemID, content string) (string, error) {
 	resp, err := as.Client.StoreNoteV1(ctx, &pb.StoreNoteV1Request{
 		Note: &pb.NoteData{
 			Content: content,
 		},
+		ItemId: itemID,
 	})
 	if err != nil {
 		as.Log.Error().Err(err).Msg("error storing note")
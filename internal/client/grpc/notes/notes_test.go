@@ -103,6 +103,7 @@ func TestGetNote_Success(t *testing.T) {
 	}).Return(&note.GetNoteV1Response{
 		Note:       expectedNote,
 		LastUpdate: timestamppb.New(expectedTime),
+		Version:    2,
 	}, nil)
 
 	client := &notes.Client{
@@ -111,9 +112,11 @@ func TestGetNote_Success(t *testing.T) {
 		Log:          &logger,
 	}
 
-	noteData, _, err := client.GetNote(t.Context(), "note123")
+	entry, err := client.GetNote(t.Context(), "note123")
 	require.NoError(t, err)
-	assert.Equal(t, expectedNote, noteData)
+	assert.Equal(t, "note123", entry.GetId())
+	assert.Equal(t, expectedNote, entry.GetNote())
+	assert.Equal(t, int64(2), entry.GetVersion())
 }
 
 func TestGetNote_Error(t *testing.T) {
@@ -132,7 +135,7 @@ func TestGetNote_Error(t *testing.T) {
 		Log:          &logger,
 	}
 
-	_, _, err := client.GetNote(t.Context(), "note123")
+	_, err := client.GetNote(t.Context(), "note123")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "error getting password")
 }
@@ -159,7 +162,7 @@ func TestStoreNote_Success(t *testing.T) {
 		Log:          &logger,
 	}
 
-	noteID, err := client.StoreNote(t.Context(), content)
+	noteID, err := client.StoreNote(t.Context(), "", content)
 	require.NoError(t, err)
 	assert.Equal(t, "new-note-123", noteID)
 }
@@ -184,7 +187,7 @@ func TestStoreNote_Error(t *testing.T) {
 		Log:          &logger,
 	}
 
-	_, err := client.StoreNote(t.Context(), content)
+	_, err := client.StoreNote(t.Context(), "", content)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "error storing note")
 }
@@ -2,7 +2,6 @@ package passwords
 
 import (
 	"context"
-	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -31,44 +30,58 @@ func NewPasswordClient(conn *grpc.ClientConn, tokenManager auth.ITokenManager, l
 }
 
 // GetPassword sends a register request to the server.
-func (as *Client) GetPassword(ctx context.Context, id string) (*pb.PasswordData, time.Time, error) {
+func (as *Client) GetPassword(ctx context.Context, id string) (*pb.PasswordEntry, error) {
 	resp, err := as.Client.GetPasswordV1(ctx, &pb.GetPasswordV1Request{
 		PasswordId: id,
 	})
 	if err != nil {
 		as.Log.Error().Err(err).Msg("error getting password")
 
-		return nil, time.Time{}, errors.Wrap(err, "error getting password")
+		return nil, errors.Wrap(err, "error getting password")
 	}
 
-	return resp.GetPassword(), resp.GetLastUpdate().AsTime(), nil
+	return &pb.PasswordEntry{
+		Id:         id,
+		Password:   resp.GetPassword(),
+		LastUpdate: resp.GetLastUpdate(),
+		Version:    resp.GetVersion(),
+	}, nil
 }
 
-func (as *Client) UpdatePassword(ctx context.Context, id, login, password string) error {
-	_, err := as.Client.UpdatePasswordV1(ctx, &pb.UpdatePasswordV1Request{
+// UpdatePassword overwrites the entry if it is still at expectedVersion (0 skips the check)
+// and returns the new version.
+func (as *Client) UpdatePassword(
+	ctx context.Context,
+	id, login, password string,
+	expectedVersion int64,
+) (int64, error) {
+	resp, err := as.Client.UpdatePasswordV1(ctx, &pb.UpdatePasswordV1Request{
 		PasswordId: id,
 		Data: &pb.PasswordData{
 			Login:    login,
 			Password: password,
 			Metadata: make(map[string]string),
 		},
+		ExpectedVersion: expectedVersion,
 	})
 	if err != nil {
 		as.Log.Error().Err(err).Msg("error updating password")
 
-		return errors.Wrap(err, "error updating password")
+		return 0, errors.Wrap(err, "error updating password")
 	}
 
-	return nil
+	return resp.GetVersion(), nil
 }
 
-func (as *Client) StorePassword(ctx context.Context, login, password string) (string, error) {
+// StorePassword stores a new password under itemID, or under an ID the server picks when it is empty.
+func (as *Client) StorePassword(ctx context.Context, itemID, login, password string) (string, error) {
 	resp, err := as.Client.StorePasswordV1(ctx, &pb.StorePasswordV1Request{
 		Password: &pb.PasswordData{
 			Login:    login,
 			Password: password,
 			Metadata: make(map[string]string),
 		},
+		ItemId: itemID,
 	})
 	if err != nil {
 		as.Log.Error().Err(err).Msg("error storing password")
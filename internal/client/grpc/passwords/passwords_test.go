@@ -102,6 +102,7 @@ func TestGetPassword_Success(t *testing.T) {
 	}).Return(&password.GetPasswordV1Response{
 		Password:   expectedPassword,
 		LastUpdate: timestamppb.New(expectedTime),
+		Version:    3,
 	}, nil)
 
 	client := &passwords.Client{
@@ -110,9 +111,11 @@ func TestGetPassword_Success(t *testing.T) {
 		Log:          &logger,
 	}
 
-	passData, _, err := client.GetPassword(t.Context(), "pass123")
+	entry, err := client.GetPassword(t.Context(), "pass123")
 	require.NoError(t, err)
-	assert.Equal(t, expectedPassword, passData)
+	assert.Equal(t, "pass123", entry.GetId())
+	assert.Equal(t, expectedPassword, entry.GetPassword())
+	assert.Equal(t, int64(3), entry.GetVersion())
 }
 
 func TestGetPassword_Error(t *testing.T) {
@@ -131,7 +134,7 @@ func TestGetPassword_Error(t *testing.T) {
 		Log:          &logger,
 	}
 
-	_, _, err := client.GetPassword(t.Context(), "pass123")
+	_, err := client.GetPassword(t.Context(), "pass123")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "error getting password")
 }
@@ -142,8 +145,9 @@ func TestUpdatePassword_Success(t *testing.T) {
 	mockClient := new(MockPasswordServiceClient)
 	logger := zerolog.Nop()
 
-	mockClient.On("UpdatePasswordV1", mock.Anything, mock.Anything).
-		Return(&password.UpdatePasswordV1Response{}, nil)
+	mockClient.On("UpdatePasswordV1", mock.Anything, mock.MatchedBy(func(req *password.UpdatePasswordV1Request) bool {
+		return req.GetExpectedVersion() == 4
+	})).Return(&password.UpdatePasswordV1Response{Version: 5}, nil)
 
 	client := &passwords.Client{
 		Client:       mockClient,
@@ -151,8 +155,9 @@ func TestUpdatePassword_Success(t *testing.T) {
 		Log:          &logger,
 	}
 
-	err := client.UpdatePassword(t.Context(), "pass123", "updateduser", "newpassword456")
+	version, err := client.UpdatePassword(t.Context(), "pass123", "updateduser", "newpassword456", 4)
 	require.NoError(t, err)
+	assert.Equal(t, int64(5), version)
 }
 
 func TestUpdatePassword_Error(t *testing.T) {
@@ -170,7 +175,7 @@ func TestUpdatePassword_Error(t *testing.T) {
 		Log:          &logger,
 	}
 
-	err := client.UpdatePassword(t.Context(), "pass123", "updateduser", "newpassword456")
+	_, err := client.UpdatePassword(t.Context(), "pass123", "updateduser", "newpassword456", 0)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "error updating password")
 }
@@ -192,7 +197,7 @@ func TestStorePassword_Success(t *testing.T) {
 		Log:          &logger,
 	}
 
-	passID, err := client.StorePassword(t.Context(), "newuser", "securepass789")
+	passID, err := client.StorePassword(t.Context(), "", "newuser", "securepass789")
 	require.NoError(t, err)
 	assert.Equal(t, "new-pass-456", passID)
 }
@@ -212,7 +217,7 @@ func TestStorePassword_Error(t *testing.T) {
 		Log:          &logger,
 	}
 
-	_, err := client.StorePassword(t.Context(), "newuser", "securepass789")
+	_, err := client.StorePassword(t.Context(), "", "newuser", "securepass789")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "error storing password")
 }
@@ -54,6 +54,7 @@ func (ai *AuthInterceptor) UnaryInterceptor(
 ) error {
 	if method == pb.AuthService_RegisterV1_FullMethodName ||
 		method == pb.AuthService_LoginV1_FullMethodName ||
+		method == pb.AuthService_VerifyTOTPV1_FullMethodName ||
 		method == pb.AuthService_RefreshTokenV1_FullMethodName {
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
@@ -108,8 +109,9 @@ func (ai *AuthInterceptor) StreamInterceptor(
 		return streamer(ctx, desc, cc, method, opts...)
 	}
 
+	// Without a token the client certificate, if any, authenticates the stream
 	token := ai.tokenManager.GetAccessToken()
-	if token == "" {
+	if token == "" && ai.config.ClientCertificate == "" {
 		return nil, status.Error(codes.Unauthenticated, "no access token")
 	}
 
@@ -245,6 +245,25 @@ func TestStreamInterceptor_NoToken(t *testing.T) {
 	tm.AssertExpectations(t)
 }
 
+func TestStreamInterceptor_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	tm := new(testutils.MockTokenManager)
+	tm.On("GetAccessToken").Return("")
+
+	// The server authenticates the stream by the client certificate.
+	interceptor := interceptors.NewAuthInterceptor(config.Config{ClientCertificate: "client.pem"}, tm)
+	streamer := new(MockStreamer)
+	streamer.On("Stream", mock.Anything, mock.Anything, mock.Anything, "some.method", mock.Anything).
+		Return(nil, nil)
+
+	_, err := interceptor.StreamInterceptor(t.Context(), nil, nil, "some.method", streamer.Stream)
+
+	require.NoError(t, err)
+	streamer.AssertExpectations(t)
+	tm.AssertExpectations(t)
+}
+
 func TestStreamInterceptor_Success(t *testing.T) {
 	t.Parallel()
 
@@ -19,6 +19,7 @@ type StorageItem struct {
 	ID        string            `json:"id"`
 	Type      ItemType          `json:"type"`
 	UpdatedAt time.Time         `json:"updatedAt"`
+	Version   int64             `json:"version"`  // Server version, sent back on update
 	Metadata  map[string]string `json:"metadata"` // Key-value metadata
 }
 
@@ -3,14 +3,11 @@ package storage
 import (
 	"context"
 
-	pb "github.com/npavlov/go-password-manager/gen/proto/item"
 	"github.com/npavlov/go-password-manager/internal/client/model"
 )
 
 //nolint:interfacebloat
 type IStorageManager interface {
-	FetchItems(ctx context.Context) ([]*pb.ItemData, error)
-	ProcessItem(ctx context.Context, item *pb.ItemData) bool
 	ProcessPassword(ctx context.Context, passwordID string, meta map[string]string) error
 	ProcessNote(ctx context.Context, noteID string, meta map[string]string) error
 	ProcessCard(ctx context.Context, cardID string, meta map[string]string) error
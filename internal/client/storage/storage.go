@@ -18,8 +18,8 @@ import (
 )
 
 const (
-	syncTime        = 5 * time.Minute
-	pageLimit int32 = 10
+	syncTime   = 5 * time.Minute
+	watchRetry = 10 * time.Second
 )
 
 // StManager manages client-sIDe storage and background syncing.
@@ -30,11 +30,13 @@ type StManager struct {
 	Cards      map[string]model.CardItem     `json:"cards"`
 	Binaries   map[string]model.BinaryItem   `json:"binaries"`
 	LastSyncAt time.Time
+	Cursor     int64
 	mutex      sync.Mutex
 	stopChan   chan struct{}
 	logger     *zerolog.Logger
 	tokenMgr   auth.ITokenManager
 	Syncing    int32
+	Watching   int32
 }
 
 // NewStorageManager creates a new StorageManager with background sync.
@@ -54,71 +56,8 @@ func NewStorageManager(facade facade.IFacade, tokenMgr auth.ITokenManager, logge
 	return sm
 }
 
-// FetchItems retrieves all items from the server.
-func (sm *StManager) FetchItems(ctx context.Context) ([]*pb.ItemData, error) {
-	sm.mutex.Lock()
-	defer sm.mutex.Unlock()
-
-	total := 0
-	var itr int32 = 1
-	allItems := make([]*pb.ItemData, 0)
-	for {
-		items, count, err := sm.facade.GetItems(ctx, itr, pageLimit)
-		if err != nil {
-			sm.logger.Error().Err(err).Msg("error getting items")
-
-			return nil, errors.Wrap(err, "error getting items")
-		}
-
-		allItems = append(allItems, items...)
-
-		total += int(count)
-
-		// next page
-		itr++
-
-		if count < pageLimit {
-			break
-		}
-	}
-
-	log.Println("Initial fetch complete:", total, "items retrieved.")
-
-	return allItems, nil
-}
-
-func (sm *StManager) ProcessItem(ctx context.Context, item *pb.ItemData) bool {
-	if item.GetUpdatedAt().AsTime().Before(sm.LastSyncAt) {
-		return false
-	}
-
-	itemID := item.GetId()
-
-	meta, err := sm.facade.GetMetainfo(ctx, itemID)
-	if err != nil {
-		sm.logger.Error().Err(err).Msg("error getting metainfo")
-
-		return false
-	}
-
-	switch item.GetType() {
-	case pb.ItemType_ITEM_TYPE_PASSWORD:
-		err = sm.ProcessPassword(ctx, itemID, meta)
-	case pb.ItemType_ITEM_TYPE_NOTE:
-		err = sm.ProcessNote(ctx, itemID, meta)
-	case pb.ItemType_ITEM_TYPE_CARD:
-		err = sm.ProcessCard(ctx, itemID, meta)
-	case pb.ItemType_ITEM_TYPE_BINARY:
-		err = sm.ProcessBinary(ctx, itemID, meta)
-	case pb.ItemType_ITEM_TYPE_UNSPECIFIED:
-		return false
-	}
-
-	return err == nil
-}
-
 func (sm *StManager) ProcessPassword(ctx context.Context, passwordID string, meta map[string]string) error {
-	password, lastUpdate, err := sm.facade.GetPassword(ctx, passwordID)
+	password, err := sm.facade.GetPassword(ctx, passwordID)
 	if err != nil {
 		sm.logger.Error().Err(err).Msg("error getting password")
 
@@ -126,12 +65,13 @@ func (sm *StManager) ProcessPassword(ctx context.Context, passwordID string, met
 	}
 
 	sm.Password[passwordID] = model.PasswordItem{
-		Login:    password.GetLogin(),
-		Password: password.GetPassword(),
+		Login:    password.GetPassword().GetLogin(),
+		Password: password.GetPassword().GetPassword(),
 		StorageItem: model.StorageItem{
 			Type:      model.ItemTypePassword,
 			ID:        passwordID,
-			UpdatedAt: lastUpdate,
+			UpdatedAt: password.GetLastUpdate().AsTime(),
+			Version:   password.GetVersion(),
 			Metadata:  meta,
 		},
 	}
@@ -140,7 +80,7 @@ func (sm *StManager) ProcessPassword(ctx context.Context, passwordID string, met
 }
 
 func (sm *StManager) ProcessNote(ctx context.Context, noteID string, meta map[string]string) error {
-	note, lastUpdate, err := sm.facade.GetNote(ctx, noteID)
+	note, err := sm.facade.GetNote(ctx, noteID)
 	if err != nil {
 		sm.logger.Error().Err(err).Msg("error getting password")
 
@@ -148,11 +88,12 @@ func (sm *StManager) ProcessNote(ctx context.Context, noteID string, meta map[st
 	}
 
 	sm.Notes[noteID] = model.NoteItem{
-		Content: note.GetContent(),
+		Content: note.GetNote().GetContent(),
 		StorageItem: model.StorageItem{
 			Type:      model.ItemTypeNote,
 			ID:        noteID,
-			UpdatedAt: lastUpdate,
+			UpdatedAt: note.GetLastUpdate().AsTime(),
+			Version:   note.GetVersion(),
 			Metadata:  meta,
 		},
 	}
@@ -161,7 +102,7 @@ func (sm *StManager) ProcessNote(ctx context.Context, noteID string, meta map[st
 }
 
 func (sm *StManager) ProcessCard(ctx context.Context, cardID string, meta map[string]string) error {
-	card, lastUpdate, err := sm.facade.GetCard(ctx, cardID)
+	card, err := sm.facade.GetCard(ctx, cardID)
 	if err != nil {
 		sm.logger.Error().Err(err).Msg("error getting card")
 
@@ -169,14 +110,15 @@ func (sm *StManager) ProcessCard(ctx context.Context, cardID string, meta map[st
 	}
 
 	sm.Cards[cardID] = model.CardItem{
-		CardNumber:     card.GetCardNumber(),
-		CVV:            card.GetCvv(),
-		ExpiryDate:     card.GetExpiryDate(),
-		CardholderName: card.GetCardholderName(),
+		CardNumber:     card.GetCard().GetCardNumber(),
+		CVV:            card.GetCard().GetCvv(),
+		ExpiryDate:     card.GetCard().GetExpiryDate(),
+		CardholderName: card.GetCard().GetCardholderName(),
 		StorageItem: model.StorageItem{
 			Type:      model.ItemTypeCard,
 			ID:        cardID,
-			UpdatedAt: lastUpdate,
+			UpdatedAt: card.GetLastUpdate().AsTime(),
+			Version:   card.GetVersion(),
 			Metadata:  meta,
 		},
 	}
@@ -209,7 +151,8 @@ func (sm *StManager) ProcessBinary(ctx context.Context, fileID string, meta map[
 	return nil
 }
 
-// StartBackgroundSync runs a goroutine that checks for updates every minute.
+// StartBackgroundSync keeps the local storage in sync with the server. Changes are pushed
+// over a watch stream; while the stream is down the storage is polled every syncTime instead.
 func (sm *StManager) StartBackgroundSync(ctx context.Context) {
 	ticker := time.NewTicker(syncTime)
 	defer ticker.Stop()
@@ -220,9 +163,18 @@ func (sm *StManager) StartBackgroundSync(ctx context.Context) {
 		sm.logger.Error().Err(err).Msg("error syncing items")
 	}
 
+	watchCtx, cancelWatch := context.WithCancel(ctx)
+	defer cancelWatch()
+
+	go sm.watchChanges(watchCtx)
+
 	for {
 		select {
 		case <-ticker.C:
+			if atomic.LoadInt32(&sm.Watching) == 1 {
+				continue
+			}
+
 			err := sm.SyncItems(ctx)
 			if err != nil {
 				sm.logger.Error().Err(err).Msg("error syncing items")
@@ -235,7 +187,46 @@ func (sm *StManager) StartBackgroundSync(ctx context.Context) {
 	}
 }
 
-// SyncItems checks for updates since the last sync.
+// watchChanges keeps a watch stream open and syncs whenever the server reports changes.
+// A dropped stream is reopened after watchRetry.
+func (sm *StManager) watchChanges(ctx context.Context) {
+	for {
+		sm.mutex.Lock()
+		cursor := sm.Cursor
+		sm.mutex.Unlock()
+
+		err := sm.facade.WatchItems(ctx, cursor, func(changes *pb.WatchItemsV1Response) {
+			atomic.StoreInt32(&sm.Watching, 1)
+
+			if len(changes.GetChanges()) == 0 {
+				return
+			}
+
+			if err := sm.SyncItems(ctx); err != nil {
+				sm.logger.Error().Err(err).Msg("error syncing items")
+			}
+		})
+		atomic.StoreInt32(&sm.Watching, 0)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		sm.logger.Warn().Err(err).Msg("watch stream dropped, falling back to polling")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetry):
+		}
+	}
+}
+
+// SyncItems applies every change recorded on the server after the last known cursor.
+// Upserted items are hydrated page by page and deleted items are dropped from the local maps.
+// The first run starts from cursor 0 and therefore loads the whole vault.
+//
+//nolint:cyclop,funlen
 func (sm *StManager) SyncItems(ctx context.Context) error {
 	if !atomic.CompareAndSwapInt32(&sm.Syncing, 0, 1) {
 		sm.logger.Info().Msg("Sync already in progress, skipping this round.")
@@ -248,33 +239,133 @@ func (sm *StManager) SyncItems(ctx context.Context) error {
 		return errors.New("not authorized")
 	}
 
-	sm.logger.Info().Msg("syncing items...")
-
-	items, err := sm.FetchItems(ctx)
-	if err != nil {
-		return errors.Wrap(err, "error fetching items")
-	}
+	sm.logger.Info().Int64("cursor", sm.Cursor).Msg("syncing items...")
 
-	// Update only items that have changed
 	updatedCount := 0
-	for i, item := range items {
-		sm.logger.Info().Int("Item num", i).Msg("got Processing item")
+	for {
+		changes, err := sm.facade.GetChanges(ctx, sm.Cursor, 0)
+		if err != nil {
+			return errors.Wrap(err, "error getting changes")
+		}
+
+		upserts := make([]string, 0, len(changes.GetChanges()))
+		for _, change := range changes.GetChanges() {
+			if change.GetOp() == pb.ChangeOp_CHANGE_OP_UPSERT {
+				upserts = append(upserts, change.GetItemId())
+			}
+		}
+
+		var hydrated []*pb.HydrateItemsV1Response
+		if len(upserts) > 0 {
+			hydrated, err = sm.facade.HydrateItems(ctx, upserts, time.Time{})
+			if err != nil {
+				return errors.Wrap(err, "error hydrating items")
+			}
+		}
 
-		ok := sm.ProcessItem(ctx, item)
+		sm.mutex.Lock()
 
-		if ok {
-			updatedCount++
+		found := make(map[string]struct{}, len(hydrated))
+		for _, item := range hydrated {
+			if sm.applyHydratedItem(item) {
+				found[item.GetItem().GetId()] = struct{}{}
+				updatedCount++
+			}
+		}
+
+		for _, change := range changes.GetChanges() {
+			_, ok := found[change.GetItemId()]
+			// An upsert that could not be hydrated was deleted in the meantime.
+			if change.GetOp() == pb.ChangeOp_CHANGE_OP_DELETE || !ok {
+				sm.removeItem(change.GetItemId())
+				updatedCount++
+			}
+
+			if changedAt := change.GetChangedAt().AsTime(); changedAt.After(sm.LastSyncAt) {
+				sm.LastSyncAt = changedAt
+			}
+		}
+
+		sm.Cursor = changes.GetNextCursor()
+
+		sm.mutex.Unlock()
+
+		if !changes.GetHasMore() {
+			break
 		}
 	}
 
 	if updatedCount > 0 {
-		sm.LastSyncAt = time.Now()
 		log.Println("Sync complete:", updatedCount, "items updated.")
 	}
 
 	return nil
 }
 
+// removeItem drops an item from whichever local map holds it.
+func (sm *StManager) removeItem(itemID string) {
+	delete(sm.Password, itemID)
+	delete(sm.Notes, itemID)
+	delete(sm.Cards, itemID)
+	delete(sm.Binaries, itemID)
+}
+
+// applyHydratedItem stores a hydrated item in the matching local map.
+func (sm *StManager) applyHydratedItem(hydrated *pb.HydrateItemsV1Response) bool {
+	item := hydrated.GetItem()
+	itemID := item.GetId()
+
+	meta := hydrated.GetMetadata()
+	if meta == nil {
+		meta = make(map[string]string)
+	}
+
+	storageItem := model.StorageItem{
+		ID:        itemID,
+		UpdatedAt: item.GetUpdatedAt().AsTime(),
+		Version:   item.GetVersion(),
+		Metadata:  meta,
+	}
+
+	switch payload := hydrated.GetPayload().(type) {
+	case *pb.HydrateItemsV1Response_Password:
+		storageItem.Type = model.ItemTypePassword
+		sm.Password[itemID] = model.PasswordItem{
+			Login:       payload.Password.GetLogin(),
+			Password:    payload.Password.GetPassword(),
+			StorageItem: storageItem,
+		}
+	case *pb.HydrateItemsV1Response_Note:
+		storageItem.Type = model.ItemTypeNote
+		sm.Notes[itemID] = model.NoteItem{
+			Content:     payload.Note.GetContent(),
+			StorageItem: storageItem,
+		}
+	case *pb.HydrateItemsV1Response_Card:
+		storageItem.Type = model.ItemTypeCard
+		sm.Cards[itemID] = model.CardItem{
+			CardNumber:     payload.Card.GetCardNumber(),
+			CVV:            payload.Card.GetCvv(),
+			ExpiryDate:     payload.Card.GetExpiryDate(),
+			CardholderName: payload.Card.GetCardholderName(),
+			StorageItem:    storageItem,
+		}
+	case *pb.HydrateItemsV1Response_File:
+		storageItem.Type = model.ItemTypeBinary
+		sm.Binaries[itemID] = model.BinaryItem{
+			Filename:    payload.File.GetFileName(),
+			Size:        payload.File.GetFileSize(),
+			StorageItem: storageItem,
+		}
+	default:
+		sm.logger.Warn().Str("itemID", itemID).Msg("hydrated item without payload")
+
+		return false
+	}
+
+	return true
+}
+
 // StopSync stops the background sync goroutine.
 func (sm *StManager) StopSync() {
 	close(sm.stopChan)
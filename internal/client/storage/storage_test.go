@@ -4,6 +4,7 @@ package storage_test
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/npavlov/go-password-manager/gen/proto/item"
 	"github.com/npavlov/go-password-manager/gen/proto/note"
 	"github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/client/model"
 	"github.com/npavlov/go-password-manager/internal/client/storage"
 	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
 )
@@ -40,71 +42,6 @@ func TestNewStorageManager(t *testing.T) {
 	assert.Empty(t, sm.Binaries)
 }
 
-func TestFetchItems_Success(t *testing.T) {
-	t.Parallel()
-
-	logger := zerolog.Nop()
-	// Mock two pages of results
-	itemsPage1 := []*item.ItemData{
-		{Id: "1", Type: item.ItemType_ITEM_TYPE_PASSWORD, UpdatedAt: timestamppb.Now()},
-		{Id: "2", Type: item.ItemType_ITEM_TYPE_NOTE, UpdatedAt: timestamppb.Now()},
-	}
-	facade := new(testutils.MockFacade)
-	facade.GetItemsFunc = func(_ context.Context, _, _ int32) ([]*item.ItemData, int32, error) {
-		return itemsPage1, 0, nil
-	}
-	tm := new(testutils.MockTokenManager)
-
-	facade.On("GetItems", mock.Anything, int32(1), int32(10)).Return(itemsPage1, int32(2), nil)
-
-	sm := storage.NewStorageManager(facade, tm, &logger)
-	items, err := sm.FetchItems(t.Context())
-	require.NoError(t, err)
-	assert.Len(t, items, 2)
-	facade.AssertExpectations(t)
-}
-
-func TestFetchItems_Error(t *testing.T) {
-	t.Parallel()
-
-	logger := zerolog.Nop()
-	facade := new(testutils.MockFacade)
-	tm := new(testutils.MockTokenManager)
-
-	facade.GetItemsFunc = func(_ context.Context, _, _ int32) ([]*item.ItemData, int32, error) {
-		return []*item.ItemData{}, 0, errors.New("error")
-	}
-	facade.On("GetItems", mock.Anything, int32(1), int32(10)).
-		Return([]*item.ItemData{}, int32(0), errors.New("fetch error"))
-
-	sm := storage.NewStorageManager(facade, tm, &logger)
-	_, err := sm.FetchItems(t.Context())
-
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "error getting items")
-	facade.AssertExpectations(t)
-}
-
-func TestProcessItem_NotUpdated(t *testing.T) {
-	t.Parallel()
-
-	logger := zerolog.Nop()
-	f := new(testutils.MockFacade)
-	tm := new(testutils.MockTokenManager)
-
-	sm := storage.NewStorageManager(f, tm, &logger)
-	sm.LastSyncAt = time.Now().Add(time.Hour) // Set future time
-
-	itemData := &item.ItemData{
-		Id:        "1",
-		Type:      item.ItemType_ITEM_TYPE_PASSWORD,
-		UpdatedAt: timestamppb.Now(),
-	}
-
-	processed := sm.ProcessItem(t.Context(), itemData)
-	assert.False(t, processed)
-}
-
 func TestProcessPassword_Success(t *testing.T) {
 	t.Parallel()
 
@@ -114,16 +51,15 @@ func TestProcessPassword_Success(t *testing.T) {
 		Login:    "user",
 		Password: "pass",
 	}
-	facade.GetPasswordFunc = func(_ context.Context, _ string) (*password.PasswordData, time.Time, error) {
-		return passwordData, time.Time{}, nil
+	facade.GetPasswordFunc = func(_ context.Context, id string) (*password.PasswordEntry, error) {
+		return &password.PasswordEntry{Id: id, Password: passwordData, Version: 3}, nil
 	}
 	passwordID := "pass123"
 	tm := new(testutils.MockTokenManager)
 
 	meta := map[string]string{"key": "value"}
-	lastUpdate := time.Now()
 
-	facade.On("GetPassword", mock.Anything, passwordID).Return(passwordData, lastUpdate, nil)
+	facade.On("GetPassword", mock.Anything, passwordID).Return(nil)
 
 	sm := storage.NewStorageManager(facade, tm, &logger)
 	err := sm.ProcessPassword(t.Context(), passwordID, meta)
@@ -132,6 +68,7 @@ func TestProcessPassword_Success(t *testing.T) {
 	assert.Contains(t, sm.Password, passwordID)
 	assert.Equal(t, "user", sm.Password[passwordID].Login)
 	assert.Equal(t, "pass", sm.Password[passwordID].Password)
+	assert.Equal(t, int64(3), sm.Password[passwordID].Version)
 	facade.AssertExpectations(t)
 }
 
@@ -147,11 +84,10 @@ func TestProcessNote_Success(t *testing.T) {
 	noteData := &note.NoteData{
 		Content: "test content",
 	}
-	lastUpdate := time.Now()
-	fClient.GetNoteFunc = func(_ context.Context, _ string) (*note.NoteData, time.Time, error) {
-		return noteData, time.Time{}, nil
+	fClient.GetNoteFunc = func(_ context.Context, id string) (*note.NoteEntry, error) {
+		return &note.NoteEntry{Id: id, Note: noteData, Version: 5}, nil
 	}
-	fClient.On("GetNote", mock.Anything, noteID).Return(noteData, lastUpdate, nil)
+	fClient.On("GetNote", mock.Anything, noteID).Return(nil)
 
 	sm := storage.NewStorageManager(fClient, tm, &logger)
 	err := sm.ProcessNote(t.Context(), noteID, meta)
@@ -159,6 +95,7 @@ func TestProcessNote_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, sm.Notes, noteID)
 	assert.Equal(t, "test content", sm.Notes[noteID].Content)
+	assert.Equal(t, int64(5), sm.Notes[noteID].Version)
 	fClient.AssertExpectations(t)
 }
 
@@ -177,11 +114,10 @@ func TestProcessCard_Success(t *testing.T) {
 		ExpiryDate:     "12/25",
 		CardholderName: "John Doe",
 	}
-	lastUpdate := time.Now()
-	facade.GetCardFunc = func(_ context.Context, _ string) (*card.CardData, time.Time, error) {
-		return cardData, time.Time{}, nil
+	facade.GetCardFunc = func(_ context.Context, id string) (*card.CardEntry, error) {
+		return &card.CardEntry{Id: id, Card: cardData, Version: 2}, nil
 	}
-	facade.On("GetCard", mock.Anything, cardID).Return(cardData, lastUpdate, nil)
+	facade.On("GetCard", mock.Anything, cardID).Return(nil)
 
 	sm := storage.NewStorageManager(facade, tm, &logger)
 	err := sm.ProcessCard(t.Context(), cardID, meta)
@@ -189,6 +125,7 @@ func TestProcessCard_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, sm.Cards, cardID)
 	assert.Equal(t, "4111111111111111", sm.Cards[cardID].CardNumber)
+	assert.Equal(t, int64(2), sm.Cards[cardID].Version)
 	facade.AssertExpectations(t)
 }
 
@@ -260,7 +197,10 @@ func TestStartBackgroundSync(t *testing.T) {
 
 	// Setup mocks for initial sync
 	tm.On("IsAuthorized").Return(true)
-	facade.On("GetItems", mock.Anything, int32(1), int32(10)).Return([]*item.ItemData{}, int32(0), nil)
+	facade.On("GetChanges", mock.Anything, int64(0), int32(0)).Return()
+	facade.GetChangesFunc = func(_ context.Context, _ int64, _ int32) (*item.GetChangesV1Response, error) {
+		return &item.GetChangesV1Response{}, nil
+	}
 
 	sm := storage.NewStorageManager(facade, tm, &logger)
 	ctx, cancel := context.WithCancel(t.Context())
@@ -276,180 +216,237 @@ func TestStartBackgroundSync(t *testing.T) {
 	sm.StopSync()
 }
 
-func TestProcessItem_Success(t *testing.T) {
+func TestStartBackgroundSync_Watch(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	facade := new(testutils.MockFacade)
+	tm := &testutils.MockTokenManager{Authorized: true}
+
+	syncs := make(chan int64, 10)
+	facade.On("GetChanges", mock.Anything, mock.Anything, int32(0)).Return()
+	facade.GetChangesFunc = func(_ context.Context, cursor int64, _ int32) (*item.GetChangesV1Response, error) {
+		syncs <- cursor
+
+		return &item.GetChangesV1Response{NextCursor: 4}, nil
+	}
+
+	watched := make(chan int64, 1)
+	facade.WatchItemsFunc = func(ctx context.Context, cursor int64, onChange func(*item.WatchItemsV1Response)) error {
+		watched <- cursor
+		onChange(&item.WatchItemsV1Response{NextCursor: 4})
+		onChange(&item.WatchItemsV1Response{
+			Changes:    []*item.ItemChange{{ItemId: "item1", Op: item.ChangeOp_CHANGE_OP_DELETE, Cursor: 5}},
+			NextCursor: 5,
+		})
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+
+	sm := storage.NewStorageManager(facade, tm, &logger)
+
+	go sm.StartBackgroundSync(t.Context())
+
+	// The initial sync runs first, then the watch resumes from its cursor.
+	assert.Equal(t, int64(0), <-syncs)
+	assert.Equal(t, int64(4), <-watched)
+
+	// Only the batch carrying changes triggers another sync.
+	assert.Equal(t, int64(4), <-syncs)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&sm.Watching) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Empty(t, syncs)
+
+	sm.StopSync()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&sm.Watching) == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestSyncItems_InitialSync(t *testing.T) {
 	t.Parallel()
 
-	testCases := []struct {
-		name     string
-		itemType item.ItemType
-		mockFunc func(facade *testutils.MockFacade)
-	}{
+	logger := testutils.GetTLogger()
+	facade := new(testutils.MockFacade)
+	tm := new(testutils.MockTokenManager)
+	tm.Authorized = true
+
+	changedAt := time.Now().UTC()
+	ids := []string{"item1", "item2", "item3", "item4"}
+	hydrated := []*item.HydrateItemsV1Response{
 		{
-			name:     "Password",
-			itemType: item.ItemType_ITEM_TYPE_PASSWORD,
-			mockFunc: func(facade *testutils.MockFacade) {
-				facade.On("GetMetainfo", mock.Anything, "item1").Return(map[string]string{}, nil)
-				facade.On("GetPassword", mock.Anything, "item1").Return(&password.PasswordData{}, time.Now(), nil)
+			Item:     &item.ItemData{Id: "item1", Type: item.ItemType_ITEM_TYPE_PASSWORD, Version: 7},
+			Metadata: map[string]string{"site": "example.com"},
+			Payload: &item.HydrateItemsV1Response_Password{
+				Password: &password.PasswordData{Login: "user", Password: "secret"},
 			},
 		},
 		{
-			name:     "Note",
-			itemType: item.ItemType_ITEM_TYPE_NOTE,
-			mockFunc: func(facade *testutils.MockFacade) {
-				facade.On("GetMetainfo", mock.Anything, "item1").Return(map[string]string{}, nil)
-				facade.On("GetNote", mock.Anything, "item1").Return(&note.NoteData{}, time.Now(), nil)
-			},
+			Item:    &item.ItemData{Id: "item2", Type: item.ItemType_ITEM_TYPE_NOTE},
+			Payload: &item.HydrateItemsV1Response_Note{Note: &note.NoteData{Content: "note"}},
 		},
 		{
-			name:     "Card",
-			itemType: item.ItemType_ITEM_TYPE_CARD,
-			mockFunc: func(facade *testutils.MockFacade) {
-				facade.On("GetMetainfo", mock.Anything, "item1").Return(map[string]string{}, nil)
-				facade.On("GetCard", mock.Anything, "item1").Return(&card.CardData{}, time.Now(), nil)
-			},
+			Item: &item.ItemData{Id: "item3", Type: item.ItemType_ITEM_TYPE_CARD},
+			Payload: &item.HydrateItemsV1Response_Card{Card: &card.CardData{
+				CardNumber: "4111111111111111", Cvv: "123", ExpiryDate: "12/30", CardholderName: "John Doe",
+			}},
 		},
 		{
-			name:     "Binary",
-			itemType: item.ItemType_ITEM_TYPE_BINARY,
-			mockFunc: func(facade *testutils.MockFacade) {
-				facade.On("GetMetainfo", mock.Anything, "item1").Return(map[string]string{}, nil)
-				facade.On("GetFile", mock.Anything, "item1").Return(&file.FileMeta{}, nil)
-			},
+			Item:    &item.ItemData{Id: "item4", Type: item.ItemType_ITEM_TYPE_BINARY},
+			Payload: &item.HydrateItemsV1Response_File{File: &file.FileMeta{Id: "item4", FileName: "a.txt", FileSize: 3}},
 		},
 	}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-
-			logger := zerolog.Nop()
-			facade := new(testutils.MockFacade)
-			facade.GetMetainfoFunc = func(_ context.Context, _ string) (map[string]string, error) {
-				return map[string]string{}, nil
-			}
-			facade.GetNoteFunc = func(_ context.Context, _ string) (*note.NoteData, time.Time, error) {
-				return &note.NoteData{}, time.Time{}, nil
-			}
-			facade.GetPasswordFunc = func(_ context.Context, _ string) (*password.PasswordData, time.Time, error) {
-				return &password.PasswordData{}, time.Time{}, nil
-			}
-			facade.GetCardFunc = func(_ context.Context, _ string) (*card.CardData, time.Time, error) {
-				return &card.CardData{}, time.Time{}, nil
-			}
-			facade.GetFileFunc = func(_ context.Context, _ string) (*file.FileMeta, error) {
-				return &file.FileMeta{}, nil
-			}
-			tm := new(testutils.MockTokenManager)
-
-			sm := storage.NewStorageManager(facade, tm, &logger)
-			sm.LastSyncAt = time.Now().Add(-time.Hour) // Set past time
-
-			tc.mockFunc(facade)
-			item := &item.ItemData{
-				Id:        "item1",
-				Type:      tc.itemType,
-				UpdatedAt: timestamppb.Now(),
-			}
-
-			processed := sm.ProcessItem(t.Context(), item)
-			assert.True(t, processed)
-			facade.AssertExpectations(t)
-		})
+	changes := make([]*item.ItemChange, len(ids))
+	for cursor, id := range ids {
+		changes[cursor] = &item.ItemChange{
+			ItemId:    id,
+			Op:        item.ChangeOp_CHANGE_OP_UPSERT,
+			Cursor:    int64(cursor + 1),
+			ChangedAt: timestamppb.New(changedAt),
+		}
+	}
+
+	facade.On("GetChanges", mock.Anything, int64(0), int32(0)).Return()
+	facade.GetChangesFunc = func(_ context.Context, _ int64, _ int32) (*item.GetChangesV1Response, error) {
+		return &item.GetChangesV1Response{Changes: changes, NextCursor: 4}, nil
+	}
+	facade.On("HydrateItems", mock.Anything, ids, time.Time{}).Return()
+	facade.HydrateItemsFunc = func(_ context.Context, _ []string, _ time.Time) ([]*item.HydrateItemsV1Response, error) {
+		return hydrated, nil
 	}
+
+	sm := storage.NewStorageManager(facade, tm, logger)
+	err := sm.SyncItems(t.Context())
+	require.NoError(t, err)
+
+	require.Contains(t, sm.Password, "item1")
+	assert.Equal(t, "secret", sm.Password["item1"].Password)
+	assert.Equal(t, "example.com", sm.Password["item1"].Metadata["site"])
+	assert.Equal(t, int64(7), sm.Password["item1"].Version)
+	assert.Equal(t, "note", sm.Notes["item2"].Content)
+	assert.Equal(t, "4111111111111111", sm.Cards["item3"].CardNumber)
+	assert.Equal(t, "a.txt", sm.Binaries["item4"].Filename)
+	assert.Equal(t, int64(4), sm.Cursor)
+	assert.True(t, changedAt.Equal(sm.LastSyncAt))
+	facade.AssertExpectations(t)
 }
 
-func TestProcessItem_MetaError(t *testing.T) {
+func TestSyncItems_AppliesUpdatesAndDeletes(t *testing.T) {
 	t.Parallel()
 
 	logger := zerolog.Nop()
-	fClient := new(testutils.MockFacade)
+	facade := new(testutils.MockFacade)
 	tm := new(testutils.MockTokenManager)
+	tm.Authorized = true
 
-	sm := storage.NewStorageManager(fClient, tm, &logger)
-	sm.LastSyncAt = time.Now().Add(-time.Hour)
-
-	item := &item.ItemData{
-		Id:        "item1",
-		Type:      item.ItemType_ITEM_TYPE_PASSWORD,
-		UpdatedAt: timestamppb.Now(),
+	facade.On("GetChanges", mock.Anything, int64(10), int32(0)).Return()
+	facade.GetChangesFunc = func(_ context.Context, _ int64, _ int32) (*item.GetChangesV1Response, error) {
+		return &item.GetChangesV1Response{
+			Changes: []*item.ItemChange{
+				{ItemId: "item1", Op: item.ChangeOp_CHANGE_OP_UPSERT, Cursor: 11},
+				{ItemId: "item2", Op: item.ChangeOp_CHANGE_OP_DELETE, Cursor: 12},
+				{ItemId: "item3", Op: item.ChangeOp_CHANGE_OP_UPSERT, Cursor: 13},
+			},
+			NextCursor: 13,
+		}, nil
 	}
-
-	fClient.GetMetainfoFunc = func(_ context.Context, _ string) (map[string]string, error) {
-		return map[string]string{}, nil
+	facade.On("HydrateItems", mock.Anything, []string{"item1", "item3"}, time.Time{}).Return()
+	facade.HydrateItemsFunc = func(_ context.Context, _ []string, _ time.Time) ([]*item.HydrateItemsV1Response, error) {
+		// item3 was removed before it could be hydrated.
+		return []*item.HydrateItemsV1Response{
+			{
+				Item:    &item.ItemData{Id: "item1", Type: item.ItemType_ITEM_TYPE_NOTE},
+				Payload: &item.HydrateItemsV1Response_Note{Note: &note.NoteData{Content: "updated"}},
+			},
+		}, nil
 	}
-	fClient.On("GetMetainfo", mock.Anything, "item1").Return(nil, errors.New("meta error"))
 
-	processed := sm.ProcessItem(t.Context(), item)
-	assert.False(t, processed)
-	fClient.AssertExpectations(t)
+	sm := storage.NewStorageManager(facade, tm, &logger)
+	sm.Cursor = 10
+	sm.Notes["item1"] = model.NoteItem{Content: "stale"}
+	sm.Password["item2"] = model.PasswordItem{Login: "gone"}
+	sm.Cards["item3"] = model.CardItem{CardNumber: "gone"}
+	sm.Notes["item4"] = model.NoteItem{Content: "untouched"}
+
+	err := sm.SyncItems(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, "updated", sm.Notes["item1"].Content)
+	assert.NotContains(t, sm.Password, "item2")
+	assert.NotContains(t, sm.Cards, "item3")
+	assert.Equal(t, "untouched", sm.Notes["item4"].Content)
+	assert.Equal(t, int64(13), sm.Cursor)
+	facade.AssertExpectations(t)
 }
 
-func TestProcessItem_ProcessingError(t *testing.T) {
+func TestSyncItems_FollowsPages(t *testing.T) {
 	t.Parallel()
 
 	logger := zerolog.Nop()
 	facade := new(testutils.MockFacade)
 	tm := new(testutils.MockTokenManager)
+	tm.Authorized = true
 
-	sm := storage.NewStorageManager(facade, tm, &logger)
-	sm.LastSyncAt = time.Now().Add(-time.Hour)
-
-	newItem := &item.ItemData{
-		Id:        "item1",
-		Type:      item.ItemType_ITEM_TYPE_PASSWORD,
-		UpdatedAt: timestamppb.Now(),
+	facade.On("GetChanges", mock.Anything, int64(0), int32(0)).Return()
+	facade.On("GetChanges", mock.Anything, int64(1), int32(0)).Return()
+	facade.GetChangesFunc = func(_ context.Context, cursor int64, _ int32) (*item.GetChangesV1Response, error) {
+		if cursor == 0 {
+			return &item.GetChangesV1Response{
+				Changes:    []*item.ItemChange{{ItemId: "item1", Op: item.ChangeOp_CHANGE_OP_DELETE, Cursor: 1}},
+				NextCursor: 1,
+				HasMore:    true,
+			}, nil
+		}
+
+		return &item.GetChangesV1Response{
+			Changes:    []*item.ItemChange{{ItemId: "item2", Op: item.ChangeOp_CHANGE_OP_DELETE, Cursor: 2}},
+			NextCursor: 2,
+		}, nil
 	}
 
-	facade.GetMetainfoFunc = func(_ context.Context, _ string) (map[string]string, error) {
-		return map[string]string{}, nil
-	}
-	facade.GetPasswordFunc = func(_ context.Context, _ string) (*password.PasswordData, time.Time, error) {
-		return &password.PasswordData{}, time.Time{}, errors.New("password error")
-	}
+	sm := storage.NewStorageManager(facade, tm, &logger)
+	sm.Notes["item1"] = model.NoteItem{}
+	sm.Notes["item2"] = model.NoteItem{}
 
-	facade.On("GetMetainfo", mock.Anything, "item1").Return(map[string]string{}, nil)
-	facade.On("GetPassword", mock.Anything, "item1").Return(nil, time.Time{}, errors.New("password error"))
+	err := sm.SyncItems(t.Context())
+	require.NoError(t, err)
 
-	processed := sm.ProcessItem(t.Context(), newItem)
-	assert.False(t, processed)
+	assert.Empty(t, sm.Notes)
+	assert.Equal(t, int64(2), sm.Cursor)
 	facade.AssertExpectations(t)
 }
 
-func TestSyncItems_Logging(t *testing.T) {
+func TestSyncItems_Errors(t *testing.T) {
 	t.Parallel()
 
-	logger := testutils.GetTLogger()
-	facade := new(testutils.MockFacade)
+	logger := zerolog.Nop()
 	tm := new(testutils.MockTokenManager)
 	tm.Authorized = true
 
-	// Setup test items
-	items := []*item.ItemData{
-		{
-			Id:        "item1",
-			Type:      item.ItemType_ITEM_TYPE_PASSWORD,
-			UpdatedAt: timestamppb.Now(),
-		},
-		{
-			Id:        "item2",
-			Type:      item.ItemType_ITEM_TYPE_NOTE,
-			UpdatedAt: timestamppb.Now(),
-		},
-	}
-
-	// Setup mocks
-	tm.On("IsAuthorized").Return(true)
-	facade.On("GetItems", mock.Anything, int32(1), int32(10)).Return(items, int32(2), 2)
-	facade.On("GetMetainfo", mock.Anything, "item1").Return(map[string]string{}, nil)
-	facade.On("GetPassword", mock.Anything, "item1").Return(&password.PasswordData{}, time.Now(), nil)
-	facade.On("GetMetainfo", mock.Anything, "item2").Return(map[string]string{}, nil)
-	facade.On("GetNote", mock.Anything, "item2").Return(&note.NoteData{}, time.Now(), nil)
-	facade.GetItemsFunc = func(_ context.Context, _, _ int32) ([]*item.ItemData, int32, error) {
-		return items, 2, nil
+	facade := new(testutils.MockFacade)
+	facade.On("GetChanges", mock.Anything, int64(0), int32(0)).Return()
+	facade.GetChangesFunc = func(_ context.Context, _ int64, _ int32) (*item.GetChangesV1Response, error) {
+		return nil, errors.New("rpc error")
 	}
 
-	sm := storage.NewStorageManager(facade, tm, logger)
+	sm := storage.NewStorageManager(facade, tm, &logger)
 	err := sm.SyncItems(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error getting changes")
 
-	require.NoError(t, err)
+	facade.GetChangesFunc = func(_ context.Context, _ int64, _ int32) (*item.GetChangesV1Response, error) {
+		return &item.GetChangesV1Response{
+			Changes:    []*item.ItemChange{{ItemId: "item1", Op: item.ChangeOp_CHANGE_OP_UPSERT, Cursor: 1}},
+			NextCursor: 1,
+		}, nil
+	}
+	facade.On("HydrateItems", mock.Anything, []string{"item1"}, time.Time{}).Return()
+	facade.HydrateItemsFunc = func(_ context.Context, _ []string, _ time.Time) ([]*item.HydrateItemsV1Response, error) {
+		return nil, errors.New("stream error")
+	}
+
+	err = sm.SyncItems(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error hydrating items")
+	// The cursor only advances once a page has been applied.
+	assert.Equal(t, int64(0), sm.Cursor)
 }
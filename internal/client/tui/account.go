@@ -0,0 +1,108 @@
+//nolint:mnd,forcetypeassert
+package tui
+
+import (
+	"context"
+
+	"github.com/rivo/tview"
+)
+
+// ShowAccountMenu offers the changes to the account itself.
+func (t *TUI) ShowAccountMenu() *tview.List {
+	list := tview.NewList()
+
+	list.AddItem("Change password", "Sign out every other device and set a new password", 'c', func() {
+		t.SetRoot(t.ShowChangeMasterPasswordForm(), true)
+	})
+	list.AddItem("Delete account", "Delete the account with everything stored in it", 'd', func() {
+		t.SetRoot(t.ShowDeleteAccountForm(), true)
+	})
+	list.AddItem("⬅ Back", "Return to main menu", 'b', func() {
+		t.SetRoot(t.MainMenu(), true)
+	})
+
+	list.SetTitle("👤 Account").SetBorder(true)
+
+	return list
+}
+
+// ShowChangeMasterPasswordForm asks for the current and the new password. The master key is only
+// filled in to change it for a zero-knowledge account.
+func (t *TUI) ShowChangeMasterPasswordForm() *tview.Form {
+	form := tview.NewForm()
+
+	form.
+		AddPasswordField("Current password", "", 20, '*', nil).
+		AddPasswordField("New password", "", 20, '*', nil).
+		AddPasswordField("New master key (optional)", "", 20, '*', nil).
+		AddButton("Change", func() { t.HandleChangeMasterPassword(form) }).
+		AddButton("Back", func() { t.SetRoot(t.ShowAccountMenu(), true) })
+
+	form.SetTitle("Change password").SetBorder(true)
+
+	return form
+}
+
+// ShowDeleteAccountForm asks for the password before deleting the account.
+func (t *TUI) ShowDeleteAccountForm() *tview.Form {
+	form := tview.NewForm()
+
+	form.
+		AddPasswordField("Password", "", 20, '*', nil).
+		AddButton("Delete", func() {
+			password := form.GetFormItem(0).(*tview.InputField).GetText()
+			t.SetRoot(t.ShowDeleteAccountConfirm(password), true)
+		}).
+		AddButton("Back", func() { t.SetRoot(t.ShowAccountMenu(), true) })
+
+	form.SetTitle("Delete account").SetBorder(true)
+
+	return form
+}
+
+// ShowDeleteAccountConfirm asks once more, since a deleted account cannot be restored.
+func (t *TUI) ShowDeleteAccountConfirm(password string) *tview.Modal {
+	return tview.NewModal().
+		SetText("Delete the account with every password, note, card and file? This cannot be undone.").
+		AddButtons([]string{yesLabel, noLabel}).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			if buttonLabel != yesLabel {
+				t.SetRoot(t.ShowAccountMenu(), true)
+
+				return
+			}
+
+			if err := t.Facade.DeleteAccount(context.Background(), password); err != nil {
+				t.Logger.Error().Err(err).Msg("Failed to delete account")
+				t.SetRoot(t.ShowAccountMenu(), true)
+
+				return
+			}
+
+			t.Logger.Info().Msg("Account deleted")
+			t.SetRoot(t.MainMenu(), true)
+		})
+}
+
+// ---- Handlers ----
+
+func (t *TUI) HandleChangeMasterPassword(form *tview.Form) {
+	currentPassword := form.GetFormItem(0).(*tview.InputField).GetText()
+	newPassword := form.GetFormItem(1).(*tview.InputField).GetText()
+	newMasterKey := form.GetFormItem(2).(*tview.InputField).GetText()
+
+	err := t.Facade.ChangePassword(context.Background(), currentPassword, newPassword, newMasterKey)
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Failed to change password")
+
+		return
+	}
+
+	t.Logger.Info().Msg("Password changed, other devices were signed out")
+
+	if newMasterKey != "" {
+		t.Logger.Warn().Msg("Set MASTER_KEY to the new master key before the next login")
+	}
+
+	t.SetRoot(t.MainMenu(), true)
+}
@@ -0,0 +1,162 @@
+//nolint:err113,forcetypeassert
+package tui_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestShowAccountMenu(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	list := ui.ShowAccountMenu()
+	require.Equal(t, 3, list.GetItemCount())
+
+	title, _ := list.GetItemText(0)
+	assert.Equal(t, "Change password", title)
+
+	title, _ = list.GetItemText(1)
+	assert.Equal(t, "Delete account", title)
+}
+
+func TestHandleChangeMasterPassword(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	var changed []string
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.ChangePasswordFunc = func(_ context.Context, currentPassword, newPassword, newMasterKey string) error {
+		changed = []string{currentPassword, newPassword, newMasterKey}
+
+		return nil
+	}
+	mockFacade.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	mockToken := ui.TokenMgr.(*testutils.MockTokenManager)
+	mockToken.On("IsAuthorized").Return(true)
+
+	form := ui.ShowChangeMasterPasswordForm()
+	form.GetFormItem(0).(*tview.InputField).SetText("oldPass123")
+	form.GetFormItem(1).(*tview.InputField).SetText("newPass456")
+
+	ui.HandleChangeMasterPassword(form)
+
+	assert.Equal(t, []string{"oldPass123", "newPass456", ""}, changed)
+
+	_, ok := root.(*tview.List)
+	assert.True(t, ok)
+}
+
+func TestHandleChangeMasterPassword_Failure(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.ChangePasswordFunc = func(_ context.Context, _, _, _ string) error {
+		return errors.New("invalid password")
+	}
+	mockFacade.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ui.HandleChangeMasterPassword(ui.ShowChangeMasterPasswordForm())
+
+	// The form stays open to try again.
+	assert.Nil(t, root)
+}
+
+func TestShowDeleteAccountConfirm(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	var deleted string
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.DeleteAccountFunc = func(_ context.Context, password string) error {
+		deleted = password
+
+		return nil
+	}
+	mockFacade.On("DeleteAccount", mock.Anything, mock.Anything).Return(nil)
+
+	mockToken := ui.TokenMgr.(*testutils.MockTokenManager)
+	mockToken.On("IsAuthorized").Return(false)
+
+	// Declining keeps the account.
+	pressButton(ui.ShowDeleteAccountConfirm("securePass123"), 1)
+	assert.Empty(t, deleted)
+
+	pressButton(ui.ShowDeleteAccountConfirm("securePass123"), 0)
+	assert.Equal(t, "securePass123", deleted)
+
+	// Deleted, the menu offers the login again.
+	menu, ok := root.(*tview.List)
+	require.True(t, ok)
+
+	title, _ := menu.GetItemText(1)
+	assert.Equal(t, "Login", title)
+}
+
+func TestShowDeleteAccountConfirm_Failure(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.DeleteAccountFunc = func(_ context.Context, _ string) error {
+		return errors.New("invalid password")
+	}
+	mockFacade.On("DeleteAccount", mock.Anything, mock.Anything).Return(nil)
+
+	pressButton(ui.ShowDeleteAccountConfirm("wrongPass123"), 0)
+
+	list, ok := root.(*tview.List)
+	require.True(t, ok)
+
+	title, _ := list.GetItemText(0)
+	assert.Equal(t, "Change password", title)
+}
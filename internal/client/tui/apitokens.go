@@ -0,0 +1,234 @@
+//nolint:mnd,forcetypeassert
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rivo/tview"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_item "github.com/npavlov/go-password-manager/gen/proto/item"
+)
+
+const defaultAPITokenDays = 30
+
+// apiTokenItemTypes names the item types a token can be limited to.
+//
+//nolint:gochecknoglobals
+var apiTokenItemTypes = map[string]pb_item.ItemType{
+	"password": pb_item.ItemType_ITEM_TYPE_PASSWORD,
+	"note":     pb_item.ItemType_ITEM_TYPE_NOTE,
+	"card":     pb_item.ItemType_ITEM_TYPE_CARD,
+	"file":     pb_item.ItemType_ITEM_TYPE_BINARY,
+}
+
+var (
+	errUnknownItemType = errors.New("unknown item type")
+	errInvalidTag      = errors.New("tags must look like key=value")
+	errInvalidDays     = errors.New("expiry must be a positive number of days")
+)
+
+// ShowAPITokenList lists the personal access tokens of the account.
+func (t *TUI) ShowAPITokenList() tview.Primitive {
+	tokens, err := t.Facade.ListAPITokens(context.Background())
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Error listing api tokens")
+
+		return t.MainMenu()
+	}
+
+	list := tview.NewList()
+
+	for _, token := range tokens {
+		tokenCopy := token
+		list.AddItem(token.GetName()+" — "+scopeSummary(token.GetScope()), apiTokenUsage(token), 0, func() {
+			t.SetRoot(t.ShowRevokeAPIToken(tokenCopy), true)
+		})
+	}
+
+	list.AddItem("➕ Create", "Create a token for scripts and CI jobs", 'c', func() {
+		t.SetRoot(t.ShowCreateAPITokenForm(), true)
+	})
+
+	list.AddItem("⬅ Back", "Return to main menu", 'b', func() {
+		t.SetRoot(t.MainMenu(), true)
+	})
+
+	list.SetTitle("🔑 API tokens").SetBorder(true)
+
+	return list
+}
+
+// ShowCreateAPITokenForm asks for the name, scope and lifetime of a new token. Empty item types and tags
+// leave the token unlimited by them.
+func (t *TUI) ShowCreateAPITokenForm() *tview.Form {
+	form := tview.NewForm()
+
+	form.
+		AddInputField("Name", "", 30, nil, nil).
+		AddCheckbox("Read-only", true, nil).
+		AddInputField("Item types (password, note, card, file)", "", 30, nil, nil).
+		AddInputField("Tags (key=value, ...)", "", 30, nil, nil).
+		AddInputField("Expires in days", strconv.Itoa(defaultAPITokenDays), 5, tview.InputFieldInteger, nil).
+		AddButton("Create", func() { t.HandleCreateAPIToken(form) }).
+		AddButton("Back", func() { t.SetRoot(t.ShowAPITokenList(), true) })
+
+	form.SetTitle("Create API token").SetBorder(true)
+
+	return form
+}
+
+// ShowAPITokenSecret shows the secret of a new token once; the server only keeps its hash.
+func (t *TUI) ShowAPITokenSecret(secret string) *tview.Modal {
+	return tview.NewModal().
+		SetText("Copy the token now, it is not shown again:\n\n" + secret).
+		AddButtons([]string{"Done"}).
+		SetDoneFunc(func(_ int, _ string) {
+			t.SetRoot(t.ShowAPITokenList(), true)
+		})
+}
+
+// ShowRevokeAPIToken asks before revoking a token.
+func (t *TUI) ShowRevokeAPIToken(token *pb.APIToken) *tview.Modal {
+	return tview.NewModal().
+		SetText(fmt.Sprintf("Revoke %s?\nScripts using it stop working at once.", token.GetName())).
+		AddButtons([]string{yesLabel, noLabel}).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			if buttonLabel == yesLabel {
+				if err := t.Facade.RevokeAPIToken(context.Background(), token.GetId()); err != nil {
+					t.Logger.Error().Err(err).Msg("Failed to revoke api token")
+
+					return
+				}
+
+				t.Logger.Info().Msg("API token revoked")
+			}
+
+			t.SetRoot(t.ShowAPITokenList(), true)
+		})
+}
+
+// ---- Handlers ----
+
+func (t *TUI) HandleCreateAPIToken(form *tview.Form) {
+	name := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+	readOnly := form.GetFormItem(1).(*tview.Checkbox).IsChecked()
+
+	itemTypes, err := parseItemTypes(form.GetFormItem(2).(*tview.InputField).GetText())
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Invalid item types")
+
+		return
+	}
+
+	tags, err := parseTags(form.GetFormItem(3).(*tview.InputField).GetText())
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Invalid tags")
+
+		return
+	}
+
+	days, err := strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText())
+	if err != nil || days <= 0 {
+		t.Logger.Error().Err(errInvalidDays).Msg("Invalid expiry")
+
+		return
+	}
+
+	scope := &pb.APITokenScope{ReadOnly: readOnly, ItemTypes: itemTypes, Tags: tags}
+	expiresAt := time.Now().AddDate(0, 0, days)
+
+	secret, _, err := t.Facade.CreateAPIToken(context.Background(), name, scope, expiresAt)
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Failed to create api token")
+
+		return
+	}
+
+	t.Logger.Info().Str("name", name).Msg("API token created")
+	t.SetRoot(t.ShowAPITokenSecret(secret), true)
+}
+
+// parseItemTypes reads a comma-separated list of item types.
+func parseItemTypes(input string) ([]pb_item.ItemType, error) {
+	var result []pb_item.ItemType
+
+	for _, name := range strings.Split(input, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		itemType, ok := apiTokenItemTypes[name]
+		if !ok {
+			return nil, errors.Wrap(errUnknownItemType, name)
+		}
+
+		result = append(result, itemType)
+	}
+
+	return result, nil
+}
+
+// parseTags reads a comma-separated list of key=value pairs.
+func parseTags(input string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	for _, pair := range strings.Split(input, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, errors.Wrap(errInvalidTag, pair)
+		}
+
+		result[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return result, nil
+}
+
+// scopeSummary describes what a token may do in a few words.
+func scopeSummary(scope *pb.APITokenScope) string {
+	parts := []string{"read-write"}
+	if scope.GetReadOnly() {
+		parts[0] = "read-only"
+	}
+
+	for _, itemType := range scope.GetItemTypes() {
+		for name, known := range apiTokenItemTypes {
+			if known == itemType {
+				parts = append(parts, name)
+			}
+		}
+	}
+
+	tags := make([]string, 0, len(scope.GetTags()))
+	for key, value := range scope.GetTags() {
+		tags = append(tags, key+"="+value)
+	}
+
+	slices.Sort(tags)
+
+	return strings.Join(append(parts, tags...), ", ")
+}
+
+// apiTokenUsage tells when a token expires and when it was last used.
+func apiTokenUsage(token *pb.APIToken) string {
+	usage := "Expires " + token.GetExpiresAt().AsTime().Local().Format(sessionTimeLayout)
+
+	if token.GetLastUsedAt() == nil {
+		return usage + ", never used"
+	}
+
+	return usage + ", last used " + token.GetLastUsedAt().AsTime().Local().Format(sessionTimeLayout)
+}
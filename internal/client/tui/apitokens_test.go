@@ -0,0 +1,149 @@
+//nolint:err113,forcetypeassert
+package tui_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_item "github.com/npavlov/go-password-manager/gen/proto/item"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestShowAPITokenList(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	var revoked []string
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.ListAPITokensFunc = func(_ context.Context) ([]*pb.APIToken, error) {
+		return []*pb.APIToken{{
+			Id:   "token-1",
+			Name: "deploy",
+			Scope: &pb.APITokenScope{
+				ReadOnly:  true,
+				ItemTypes: []pb_item.ItemType{pb_item.ItemType_ITEM_TYPE_PASSWORD},
+				Tags:      map[string]string{"team": "ops", "env": "ci"},
+			},
+			ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+		}}, nil
+	}
+	mockFacade.RevokeAPITokenFunc = func(_ context.Context, tokenID string) error {
+		revoked = append(revoked, tokenID)
+
+		return nil
+	}
+	mockFacade.On("ListAPITokens", mock.Anything).Return(nil)
+	mockFacade.On("RevokeAPIToken", mock.Anything, mock.Anything).Return(nil)
+
+	list, ok := ui.ShowAPITokenList().(*tview.List)
+	require.True(t, ok)
+	require.Equal(t, 3, list.GetItemCount())
+
+	title, usage := list.GetItemText(0)
+	assert.Equal(t, "deploy — read-only, password, env=ci, team=ops", title)
+	assert.Contains(t, usage, "never used")
+
+	// Selecting a token asks before revoking it.
+	list.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+
+	modal, ok := root.(*tview.Modal)
+	require.True(t, ok)
+
+	pressButton(modal, 0)
+
+	assert.Equal(t, []string{"token-1"}, revoked)
+
+	_, ok = root.(*tview.List)
+	assert.True(t, ok)
+}
+
+func TestHandleCreateAPIToken(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	var created *pb.APITokenScope
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.CreateAPITokenFunc = func(_ context.Context, name string, scope *pb.APITokenScope,
+		expiresAt time.Time,
+	) (string, *pb.APIToken, error) {
+		assert.Equal(t, "deploy", name)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 7), expiresAt, time.Minute)
+
+		created = scope
+
+		return "pmat_secret", &pb.APIToken{Id: "token-1"}, nil
+	}
+	mockFacade.On("CreateAPIToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	form := ui.ShowCreateAPITokenForm()
+	form.GetFormItem(0).(*tview.InputField).SetText("deploy")
+	form.GetFormItem(2).(*tview.InputField).SetText("Password, file")
+	form.GetFormItem(3).(*tview.InputField).SetText("env=ci")
+	form.GetFormItem(4).(*tview.InputField).SetText("7")
+
+	ui.HandleCreateAPIToken(form)
+
+	require.NotNil(t, created)
+	assert.True(t, created.GetReadOnly())
+	assert.Equal(t, []pb_item.ItemType{pb_item.ItemType_ITEM_TYPE_PASSWORD, pb_item.ItemType_ITEM_TYPE_BINARY},
+		created.GetItemTypes())
+	assert.Equal(t, map[string]string{"env": "ci"}, created.GetTags())
+
+	// The secret is shown once.
+	_, ok := root.(*tview.Modal)
+	assert.True(t, ok)
+}
+
+func TestHandleCreateAPIToken_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+
+	for _, field := range []struct {
+		index int
+		value string
+	}{
+		{index: 2, value: "password, folder"},
+		{index: 3, value: "env"},
+		{index: 4, value: "0"},
+	} {
+		form := ui.ShowCreateAPITokenForm()
+		form.GetFormItem(0).(*tview.InputField).SetText("deploy")
+		form.GetFormItem(field.index).(*tview.InputField).SetText(field.value)
+
+		ui.HandleCreateAPIToken(form)
+	}
+
+	mockFacade.AssertNotCalled(t, "CreateAPIToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
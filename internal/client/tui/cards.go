@@ -5,8 +5,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/rivo/tview"
 
+	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
+	"github.com/npavlov/go-password-manager/internal/client/grpc/facade"
 	"github.com/npavlov/go-password-manager/internal/client/model"
 )
 
@@ -140,29 +143,15 @@ func (t *TUI) ShowEditCardForm(card model.CardItem) *tview.Form {
 	form.AddInputField("Card Number", card.CardNumber, 30, nil, nil).
 		AddInputField("Expiry Date", card.ExpiryDate, 10, nil, nil).
 		AddInputField("CVV", card.CVV, 10, nil, nil).
-		AddInputField("Card Holder", "", 10, nil, nil).
+		AddInputField("Card Holder", card.CardholderName, 10, nil, nil).
 		AddButton("Save", func() {
-			cardNum := form.GetFormItem(0).(*tview.InputField).GetText()
-			expiry := form.GetFormItem(1).(*tview.InputField).GetText()
-			cvv := form.GetFormItem(2).(*tview.InputField).GetText()
-			cardHolder := form.GetFormItem(3).(*tview.InputField).GetText()
-
-			err := t.Facade.UpdateCard(context.Background(), card.ID, cardNum, expiry, cvv, cardHolder)
-			if err != nil {
-				t.Logger.Error().Err(err).Msg("Failed to update card")
-
-				return
-			}
-
-			err = t.Storage.ProcessCard(context.Background(), card.ID, card.Metadata)
-			if err != nil {
-				t.Logger.Error().Err(err).Msg("Failed to update local card")
+			edited := card
+			edited.CardNumber = form.GetFormItem(0).(*tview.InputField).GetText()
+			edited.ExpiryDate = form.GetFormItem(1).(*tview.InputField).GetText()
+			edited.CVV = form.GetFormItem(2).(*tview.InputField).GetText()
+			edited.CardholderName = form.GetFormItem(3).(*tview.InputField).GetText()
 
-				return
-			}
-
-			t.Logger.Info().Msg("Card updated successfully")
-			t.App.SetRoot(t.ShowCardDetails(card), true)
+			t.saveCard(card, edited, card.Version)
 		}).
 		AddButton("Cancel", func() {
 			t.SetRoot(t.ShowCardDetails(card), true)
@@ -173,6 +162,66 @@ func (t *TUI) ShowEditCardForm(card model.CardItem) *tview.Form {
 	return form
 }
 
+// saveCard sends the update and falls back to the conflict dialog when the server copy has moved on.
+func (t *TUI) saveCard(card, edited model.CardItem, expectedVersion int64) {
+	_, err := t.Facade.UpdateCard(context.Background(), card.ID,
+		edited.CardNumber, edited.ExpiryDate, edited.CVV, edited.CardholderName, expectedVersion)
+
+	var conflict *facade.CardConflictError
+	if errors.As(err, &conflict) {
+		t.SetRoot(t.ShowCardConflict(card, edited, conflict.Current), true)
+
+		return
+	}
+
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Failed to update card")
+
+		return
+	}
+
+	err = t.Storage.ProcessCard(context.Background(), card.ID, card.Metadata)
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Failed to update local card")
+
+		return
+	}
+
+	t.Logger.Info().Msg("Card updated successfully")
+	t.App.SetRoot(t.ShowCardDetails(card), true)
+}
+
+// ShowCardConflict lets the user resolve a card edit that raced with a change made on another device.
+func (t *TUI) ShowCardConflict(card, edited model.CardItem, current *pb_card.CardEntry) *tview.Modal {
+	server := current.GetCard()
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("This card was changed on another device.\n\n"+
+			"Server: %s %s %s\nYours: %s %s %s",
+			FormatCardNumber(server.GetCardNumber()), server.GetExpiryDate(), server.GetCardholderName(),
+			FormatCardNumber(edited.CardNumber), edited.ExpiryDate, edited.CardholderName)).
+		AddButtons([]string{mergeLabel, overwriteLabel, cancelLabel}).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			switch buttonLabel {
+			case mergeLabel:
+				merged := card
+				merged.CardNumber = mergeField(card.CardNumber, edited.CardNumber, server.GetCardNumber())
+				merged.ExpiryDate = mergeField(card.ExpiryDate, edited.ExpiryDate, server.GetExpiryDate())
+				merged.CVV = mergeField(card.CVV, edited.CVV, server.GetCvv())
+				merged.CardholderName = mergeField(card.CardholderName, edited.CardholderName, server.GetCardholderName())
+				merged.Version = current.GetVersion()
+
+				t.SetRoot(t.ShowEditCardForm(merged), true)
+			case overwriteLabel:
+				t.saveCard(card, edited, current.GetVersion())
+			default:
+				t.SetRoot(t.ShowCardDetails(card), true)
+			}
+		})
+
+	return modal
+}
+
 // ShowRemoveCardForm confirmation before delete.
 func (t *TUI) ShowRemoveCardForm(card model.CardItem) *tview.Modal {
 	confirmation := tview.NewModal().
@@ -10,7 +10,10 @@ import (
 	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
+	"github.com/npavlov/go-password-manager/internal/client/grpc/facade"
 	"github.com/npavlov/go-password-manager/internal/client/model"
 	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
 )
@@ -180,10 +183,10 @@ func TestShowEditCardForm_Success(t *testing.T) {
 
 	// Setup mocks
 	mockFacade := ui.Facade.(*testutils.MockFacade)
-	mockFacade.UpdateCardFunc = func(_ context.Context, _, _, _, _, _ string) error {
-		return nil
+	mockFacade.UpdateCardFunc = func(_ context.Context, _, _, _, _, _ string, _ int64) (int64, error) {
+		return 2, nil
 	}
-	mockFacade.On("UpdateCard", t.Context(), "123", "4444333322221111", "12/26", "456", "Jane Doe").Return(nil)
+	mockFacade.On("UpdateCard", t.Context(), "123", "4444333322221111", "12/26", "456", "Jane Doe", int64(0)).Return(nil)
 
 	mockStorage := ui.Storage.(*testutils.MockStorageManager)
 	mockStorage.ProcessCardFunc = func(_ context.Context, cardID string, _ map[string]string) error {
@@ -256,3 +259,57 @@ func TestShowRemoveCardForm_Cancel(t *testing.T) {
 	modal.SetFocus(1) // Focus "No" button
 	modal.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
 }
+
+func TestShowEditCardForm_ConflictMerge(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+	card := model.CardItem{
+		StorageItem:    model.StorageItem{ID: "123", Version: 1},
+		CardNumber:     "1111222233334444",
+		ExpiryDate:     "12/25",
+		CVV:            "123",
+		CardholderName: "John Doe",
+	}
+
+	var root tview.Primitive
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.UpdateCardFunc = func(_ context.Context, _, _, _, _, _ string, _ int64) (int64, error) {
+		return 0, &facade.CardConflictError{Current: &pb_card.CardEntry{
+			Id: "123",
+			Card: &pb_card.CardData{
+				CardNumber:     "1111222233334444",
+				ExpiryDate:     "01/27",
+				Cvv:            "123",
+				CardholderName: "John Smith",
+			},
+			Version: 3,
+		}}
+	}
+
+	form := ui.ShowEditCardForm(card)
+	form.GetFormItem(2).(*tview.InputField).SetText("999")
+	form.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+
+	modal, ok := root.(*tview.Modal)
+	require.True(t, ok)
+
+	pressModalButton(modal, 0) // Merge
+
+	merged, ok := root.(*tview.Form)
+	require.True(t, ok)
+	assert.Equal(t, "01/27", merged.GetFormItem(1).(*tview.InputField).GetText())
+	assert.Equal(t, "999", merged.GetFormItem(2).(*tview.InputField).GetText())
+	assert.Equal(t, "John Smith", merged.GetFormItem(3).(*tview.InputField).GetText())
+
+	// Cancel on a fresh dialog returns to the card details.
+	pressModalButton(ui.ShowCardConflict(card, card, &pb_card.CardEntry{Card: &pb_card.CardData{}}), 2)
+	_, ok = root.(*tview.TextView)
+	assert.True(t, ok)
+}
@@ -5,8 +5,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/rivo/tview"
 
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/client/grpc/facade"
 	"github.com/npavlov/go-password-manager/internal/client/model"
 )
 
@@ -94,31 +97,19 @@ func (t *TUI) ShowPasswordDetails(pass model.PasswordItem) *tview.Flex {
 
 // ShowChangePasswordForm allows the user to update a password.
 func (t *TUI) ShowChangePasswordForm(pass model.PasswordItem) *tview.Form {
+	return t.changePasswordForm(pass, "")
+}
+
+func (t *TUI) changePasswordForm(pass model.PasswordItem, newPassword string) *tview.Form {
 	form := tview.NewForm()
 
 	form.AddInputField("New login", pass.Login, 30, nil, nil).
-		AddPasswordField("New Password", "", 30, '*', nil).
+		AddPasswordField("New Password", newPassword, 30, '*', nil).
 		AddButton("Save", func() {
 			newLogin := form.GetFormItem(0).(*tview.InputField).GetText()
 			newPassword := form.GetFormItem(1).(*tview.InputField).GetText()
 
-			err := t.Facade.UpdatePassword(context.Background(), pass.ID, newLogin, newPassword)
-			if err != nil {
-				t.Logger.Error().Err(err).Msg("Failed to change password")
-
-				return
-			}
-
-			// Update local storage
-			err = t.Storage.ProcessPassword(context.Background(), pass.ID, pass.Metadata)
-			if err != nil {
-				t.Logger.Error().Err(err).Msg("Failed to update password in storage")
-
-				return
-			}
-
-			t.Logger.Info().Msg("Password changed successfully")
-			t.SetRoot(t.ShowPasswordDetails(pass), true) // Refresh details
+			t.savePassword(pass, newLogin, newPassword, pass.Version)
 		}).
 		AddButton("Cancel", func() { t.SetRoot(t.ShowPasswordDetails(pass), true) })
 
@@ -127,6 +118,66 @@ func (t *TUI) ShowChangePasswordForm(pass model.PasswordItem) *tview.Form {
 	return form
 }
 
+// savePassword sends the update and falls back to the conflict dialog when the server copy has moved on.
+func (t *TUI) savePassword(pass model.PasswordItem, newLogin, newPassword string, expectedVersion int64) {
+	_, err := t.Facade.UpdatePassword(context.Background(), pass.ID, newLogin, newPassword, expectedVersion)
+
+	var conflict *facade.PasswordConflictError
+	if errors.As(err, &conflict) {
+		t.SetRoot(t.ShowPasswordConflict(pass, newLogin, newPassword, conflict.Current), true)
+
+		return
+	}
+
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Failed to change password")
+
+		return
+	}
+
+	// Update local storage
+	err = t.Storage.ProcessPassword(context.Background(), pass.ID, pass.Metadata)
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Failed to update password in storage")
+
+		return
+	}
+
+	t.Logger.Info().Msg("Password changed successfully")
+	t.SetRoot(t.ShowPasswordDetails(pass), true) // Refresh details
+}
+
+// ShowPasswordConflict lets the user resolve an edit that raced with a change made on another device.
+// Merge reopens the form with the server copy plus the fields the user actually changed,
+// Overwrite replaces the server copy with the user's values.
+func (t *TUI) ShowPasswordConflict(
+	pass model.PasswordItem,
+	newLogin, newPassword string,
+	current *pb_password.PasswordEntry,
+) *tview.Modal {
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("This password was changed on another device.\n\n"+
+			"Server login: %s\nYour login: %s", current.GetPassword().GetLogin(), newLogin)).
+		AddButtons([]string{mergeLabel, overwriteLabel, cancelLabel}).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			switch buttonLabel {
+			case mergeLabel:
+				merged := pass
+				merged.Login = mergeField(pass.Login, newLogin, current.GetPassword().GetLogin())
+				merged.Password = current.GetPassword().GetPassword()
+				merged.Version = current.GetVersion()
+
+				t.SetRoot(t.changePasswordForm(merged, mergeField(pass.Password, newPassword, merged.Password)), true)
+			case overwriteLabel:
+				t.savePassword(pass, newLogin, newPassword, current.GetVersion())
+			default:
+				t.SetRoot(t.ShowPasswordDetails(pass), true)
+			}
+		})
+
+	return modal
+}
+
 // ShowAddPasswordForm displays a form to add a new password entry.
 func (t *TUI) ShowAddPasswordForm() *tview.Form {
 	form := tview.NewForm()
@@ -10,7 +10,10 @@ import (
 	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/client/grpc/facade"
 	"github.com/npavlov/go-password-manager/internal/client/model"
 	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
 )
@@ -180,10 +183,10 @@ func TestShowChangePasswordForm_Success(t *testing.T) {
 
 	// Setup mocks
 	mockFacade := ui.Facade.(*testutils.MockFacade)
-	mockFacade.UpdatePasswordFunc = func(_ context.Context, _, _, _ string) error {
-		return nil
+	mockFacade.UpdatePasswordFunc = func(_ context.Context, _, _, _ string, _ int64) (int64, error) {
+		return 2, nil
 	}
-	mockFacade.On("UpdatePassword", t.Context(), "123", "newuser", "newsecret").Return(nil)
+	mockFacade.On("UpdatePassword", t.Context(), "123", "newuser", "newsecret", int64(0)).Return(nil)
 
 	mockStorage := ui.Storage.(*testutils.MockStorageManager)
 	mockStorage.ProcessPasswordFunc = func(_ context.Context, passID string, _ map[string]string) error {
@@ -303,3 +306,110 @@ func TestShowPasswordDetails_Actions(t *testing.T) {
 	actions.SetCurrentItem(3) // Remove Metadata is fourth item
 	actions.InputHandler()(event, nil)
 }
+
+func TestShowChangePasswordForm_Conflict(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+	pass := model.PasswordItem{
+		StorageItem: model.StorageItem{ID: "123", Version: 1},
+		Login:       "testuser",
+		Password:    "oldsecret",
+	}
+
+	var root tview.Primitive
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	var versions []int64
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.UpdatePasswordFunc = func(_ context.Context, _, _, _ string, expectedVersion int64) (int64, error) {
+		versions = append(versions, expectedVersion)
+		if expectedVersion == 1 {
+			return 0, &facade.PasswordConflictError{Current: &pb_password.PasswordEntry{
+				Id:       "123",
+				Password: &pb_password.PasswordData{Login: "serveruser", Password: "serversecret"},
+				Version:  2,
+			}}
+		}
+
+		return expectedVersion + 1, nil
+	}
+
+	mockStorage := ui.Storage.(*testutils.MockStorageManager)
+	mockStorage.ProcessPasswordFunc = func(_ context.Context, _ string, _ map[string]string) error {
+		return nil
+	}
+
+	form := ui.ShowChangePasswordForm(pass)
+	form.GetFormItem(1).(*tview.InputField).SetText("newsecret")
+
+	event := tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+	form.GetButton(0).InputHandler()(event, nil)
+
+	// The stale save opens the conflict dialog.
+	modal, ok := root.(*tview.Modal)
+	require.True(t, ok)
+
+	// Merge keeps the server login the user did not touch and the new password they typed.
+	pressModalButton(modal, 0)
+
+	merged, ok := root.(*tview.Form)
+	require.True(t, ok)
+	assert.Equal(t, "serveruser", merged.GetFormItem(0).(*tview.InputField).GetText())
+	assert.Equal(t, "newsecret", merged.GetFormItem(1).(*tview.InputField).GetText())
+
+	merged.GetButton(0).InputHandler()(event, nil)
+	assert.Equal(t, []int64{1, 2}, versions)
+}
+
+func TestShowPasswordConflict_Overwrite(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+	pass := model.PasswordItem{
+		StorageItem: model.StorageItem{ID: "123", Version: 1},
+		Login:       "testuser",
+	}
+	current := &pb_password.PasswordEntry{
+		Id:       "123",
+		Password: &pb_password.PasswordData{Login: "serveruser", Password: "serversecret"},
+		Version:  4,
+	}
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.UpdatePasswordFunc = func(_ context.Context, id, login, password string, version int64) (int64, error) {
+		assert.Equal(t, "123", id)
+		assert.Equal(t, "mine", login)
+		assert.Equal(t, "minesecret", password)
+		assert.Equal(t, int64(4), version)
+
+		return 5, nil
+	}
+
+	processed := false
+	mockStorage := ui.Storage.(*testutils.MockStorageManager)
+	mockStorage.ProcessPasswordFunc = func(_ context.Context, _ string, _ map[string]string) error {
+		processed = true
+
+		return nil
+	}
+
+	modal := ui.ShowPasswordConflict(pass, "mine", "minesecret", current)
+	pressModalButton(modal, 1) // Overwrite
+
+	assert.True(t, processed)
+}
+
+// pressModalButton focuses the modal the way the application would and presses the button at index.
+func pressModalButton(modal *tview.Modal, index int) {
+	var focus func(p tview.Primitive)
+	focus = func(p tview.Primitive) { p.Focus(focus) }
+
+	modal.SetFocus(index)
+	modal.Focus(focus)
+	modal.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), focus)
+}
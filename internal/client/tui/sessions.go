@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rivo/tview"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+)
+
+const sessionTimeLayout = "2006-01-02 15:04"
+
+// ShowSessionList lists the devices signed in to the account.
+func (t *TUI) ShowSessionList() tview.Primitive {
+	sessions, err := t.Facade.ListSessions(context.Background())
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Error listing sessions")
+
+		return t.MainMenu()
+	}
+
+	list := tview.NewList()
+
+	for _, session := range sessions {
+		sessionCopy := session
+		list.AddItem(sessionTitle(session),
+			"Last used "+session.GetLastUsedAt().AsTime().Local().Format(sessionTimeLayout), 0, func() {
+				t.SetRoot(t.ShowRevokeSession(sessionCopy), true)
+			})
+	}
+
+	list.AddItem("🚫 Revoke all", "Sign out every device, this one included", 'r', func() {
+		t.SetRoot(t.ShowRevokeAllSessions(), true)
+	})
+
+	list.AddItem("⬅ Back", "Return to main menu", 'b', func() {
+		t.SetRoot(t.MainMenu(), true)
+	})
+
+	list.SetTitle("💻 Sessions").SetBorder(true)
+
+	return list
+}
+
+// ShowRevokeSession asks before signing out a device.
+func (t *TUI) ShowRevokeSession(session *pb.Session) *tview.Modal {
+	return tview.NewModal().
+		SetText(fmt.Sprintf("Sign out %s?\nSigned in %s", sessionTitle(session),
+			session.GetCreatedAt().AsTime().Local().Format(sessionTimeLayout))).
+		AddButtons([]string{yesLabel, noLabel}).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			if buttonLabel == yesLabel {
+				if err := t.Facade.RevokeSession(context.Background(), session.GetId()); err != nil {
+					t.Logger.Error().Err(err).Msg("Failed to revoke session")
+
+					return
+				}
+
+				t.Logger.Info().Msg("Session revoked")
+			}
+
+			t.SetRoot(t.ShowSessionList(), true)
+		})
+}
+
+// ShowRevokeAllSessions asks before signing out every device.
+func (t *TUI) ShowRevokeAllSessions() *tview.Modal {
+	return tview.NewModal().
+		SetText("Sign out every device? This one has to log in again too.").
+		AddButtons([]string{yesLabel, noLabel}).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			if buttonLabel != yesLabel {
+				t.SetRoot(t.ShowSessionList(), true)
+
+				return
+			}
+
+			revoked, err := t.Facade.RevokeAllSessions(context.Background())
+			if err != nil {
+				t.Logger.Error().Err(err).Msg("Failed to revoke sessions")
+
+				return
+			}
+
+			t.Logger.Info().Int32("sessions", revoked).Msg("All sessions revoked")
+			t.SetRoot(t.MainMenu(), true)
+		})
+}
+
+// ---- Handlers ----
+
+// HandleLogout signs out on the server too, so the stored refresh token cannot be used any more.
+func (t *TUI) HandleLogout() {
+	if err := t.Facade.Logout(context.Background()); err != nil {
+		t.Logger.Error().Err(err).Msg("Logout failed on the server, signed out locally")
+	}
+
+	t.SetRoot(t.MainMenu(), true)
+}
+
+// sessionTitle names a session by its device and address.
+func sessionTitle(session *pb.Session) string {
+	device := session.GetDeviceName()
+	if device == "" {
+		device = "Unknown device"
+	}
+
+	if session.GetIpAddress() == "" {
+		return device
+	}
+
+	return fmt.Sprintf("%s — %s", device, session.GetIpAddress())
+}
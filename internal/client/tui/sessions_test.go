@@ -0,0 +1,161 @@
+//nolint:err113,forcetypeassert
+package tui_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+// focus passes the focus on down to the innermost primitive, as the application would.
+func focus(p tview.Primitive) {
+	p.Focus(focus)
+}
+
+// pressButton selects a button of the modal.
+func pressButton(modal *tview.Modal, index int) {
+	modal.SetFocus(index)
+	focus(modal)
+	modal.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p tview.Primitive) { focus(p) })
+}
+
+func TestShowSessionList(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	var revoked []string
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.ListSessionsFunc = func(_ context.Context) ([]*pb.Session, error) {
+		return []*pb.Session{{
+			Id:         "session-1",
+			DeviceName: "laptop",
+			IpAddress:  "10.0.0.1",
+			CreatedAt:  timestamppb.New(time.Now().Add(-time.Hour)),
+			LastUsedAt: timestamppb.Now(),
+		}}, nil
+	}
+	mockFacade.RevokeSessionFunc = func(_ context.Context, sessionID string) error {
+		revoked = append(revoked, sessionID)
+
+		return nil
+	}
+	mockFacade.On("ListSessions", mock.Anything).Return(nil)
+	mockFacade.On("RevokeSession", mock.Anything, mock.Anything).Return(nil)
+
+	list, ok := ui.ShowSessionList().(*tview.List)
+	require.True(t, ok)
+	require.Equal(t, 3, list.GetItemCount())
+
+	title, _ := list.GetItemText(0)
+	assert.Equal(t, "laptop — 10.0.0.1", title)
+
+	// Selecting a session asks before revoking it.
+	list.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+
+	modal, ok := root.(*tview.Modal)
+	require.True(t, ok)
+
+	pressButton(modal, 0)
+
+	assert.Equal(t, []string{"session-1"}, revoked)
+
+	_, ok = root.(*tview.List)
+	assert.True(t, ok)
+}
+
+func TestShowSessionList_Failure(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.ListSessionsFunc = func(_ context.Context) ([]*pb.Session, error) {
+		return nil, errors.New("unavailable")
+	}
+	mockFacade.On("ListSessions", mock.Anything).Return(nil)
+
+	mockToken := ui.TokenMgr.(*testutils.MockTokenManager)
+	mockToken.On("IsAuthorized").Return(true)
+
+	_, ok := ui.ShowSessionList().(*tview.List)
+	assert.True(t, ok)
+}
+
+func TestShowRevokeAllSessions(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.RevokeAllFunc = func(_ context.Context) (int32, error) {
+		return 2, nil
+	}
+	mockFacade.On("RevokeAllSessions", mock.Anything).Return(nil)
+
+	modal := ui.ShowRevokeAllSessions()
+	pressButton(modal, 0)
+
+	// Signed out, the menu offers the login again.
+	menu, ok := root.(*tview.List)
+	require.True(t, ok)
+
+	title, _ := menu.GetItemText(1)
+	assert.Equal(t, "Login", title)
+	mockFacade.AssertCalled(t, "RevokeAllSessions", mock.Anything)
+}
+
+func TestHandleLogout(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.LogoutFunc = func(_ context.Context) error {
+		return errors.New("server unavailable")
+	}
+	mockFacade.On("Logout", mock.Anything).Return(nil)
+
+	// A server that cannot be reached still signs out locally.
+	ui.HandleLogout()
+
+	_, ok := root.(*tview.List)
+	require.True(t, ok)
+	mockFacade.AssertCalled(t, "Logout", mock.Anything)
+}
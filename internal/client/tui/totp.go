@@ -0,0 +1,90 @@
+//nolint:mnd,forcetypeassert
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// ShowTOTPForm asks for the second factor of a login.
+func (t *TUI) ShowTOTPForm() *tview.Form {
+	form := tview.NewForm()
+
+	form.
+		AddInputField("Code", "", 20, nil, nil).
+		AddButton("Verify", func() { t.HandleTOTP(form) }).
+		AddButton("Back", func() { t.SetRoot(t.ShowLoginForm(), true) })
+
+	form.SetTitle("Two-factor code or recovery code").SetBorder(true)
+
+	return form
+}
+
+// ShowEnrollTOTP shows a new TOTP secret to add to an authenticator app and asks for a code from it.
+func (t *TUI) ShowEnrollTOTP() tview.Primitive {
+	enrollment, err := t.Facade.EnrollTOTP(context.Background())
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Two-factor enrollment failed")
+
+		return t.MainMenu()
+	}
+
+	textView := tview.NewTextView()
+	textView.SetText(fmt.Sprintf("%s\nSecret: %s", enrollment.GetQrCode(), enrollment.GetSecret()))
+	textView.SetBorder(true).SetTitle("Scan with an authenticator app")
+
+	form := tview.NewForm()
+	form.
+		AddInputField("Code", "", 10, nil, nil).
+		AddButton("Confirm", func() { t.HandleConfirmTOTP(form) }).
+		AddButton("Back", func() { t.SetRoot(t.MainMenu(), true) })
+
+	return tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(textView, 0, 3, false).
+		AddItem(form, 7, 1, true)
+}
+
+// ShowRecoveryCodes lists the recovery codes once; the server only keeps their hashes.
+func (t *TUI) ShowRecoveryCodes(codes []string) *tview.Modal {
+	return tview.NewModal().
+		SetText("Two-factor authentication enabled. Store these recovery codes safely, " +
+			"each one replaces a code once:\n\n" + strings.Join(codes, "\n")).
+		AddButtons([]string{"Done"}).
+		SetDoneFunc(func(_ int, _ string) {
+			t.SetRoot(t.MainMenu(), true)
+		})
+}
+
+// ---- Handlers ----
+
+func (t *TUI) HandleTOTP(form *tview.Form) {
+	code := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+
+	if err := t.Facade.VerifyTOTP(code); err != nil {
+		t.Logger.Error().Err(err).Msg("Two-factor verification failed")
+		// The login challenge is used up, so the password is needed again
+		t.SetRoot(t.ShowLoginForm(), true)
+
+		return
+	}
+
+	t.completeLogin()
+}
+
+func (t *TUI) HandleConfirmTOTP(form *tview.Form) {
+	code := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+
+	codes, err := t.Facade.ConfirmTOTP(context.Background(), code)
+	if err != nil {
+		t.Logger.Error().Err(err).Msg("Two-factor confirmation failed")
+
+		return
+	}
+
+	t.Logger.Info().Msg("Two-factor authentication enabled")
+
+	t.SetRoot(t.ShowRecoveryCodes(codes), true)
+}
@@ -0,0 +1,157 @@
+//nolint:err113,forcetypeassert
+package tui_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	"github.com/npavlov/go-password-manager/internal/client/grpc/facade"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestHandleLogin_TOTPRequired(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.LoginFunc = func(_, _ string) error {
+		return facade.ErrTOTPRequired
+	}
+	mockFacade.On("Login", "user", "pass").Return(nil)
+
+	form := tview.NewForm().
+		AddInputField("Username", "user", 20, nil, nil).
+		AddPasswordField("Password", "pass", 20, '*', nil)
+
+	ui.HandleLogin(form)
+
+	totpForm, ok := root.(*tview.Form)
+	require.True(t, ok)
+	assert.Equal(t, "Two-factor code or recovery code", totpForm.GetTitle())
+}
+
+func TestHandleTOTP(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockStorage := ui.Storage.(*testutils.MockStorageManager)
+	mockToken := ui.TokenMgr.(*testutils.MockTokenManager)
+
+	mockFacade.VerifyTOTPFunc = func(code string) error {
+		if code != "123456" {
+			return errors.New("invalid code")
+		}
+
+		return nil
+	}
+	mockFacade.On("VerifyTOTP", mock.Anything).Return(nil)
+	mockStorage.SyncItemsFunc = func(_ context.Context) error {
+		return nil
+	}
+	mockStorage.On("SyncItems", mock.Anything).Return(nil)
+	mockToken.On("IsAuthorized").Return(true)
+
+	// A wrong code goes back to the password.
+	form := ui.ShowTOTPForm()
+	form.GetFormItem(0).(*tview.InputField).SetText("000000")
+	ui.HandleTOTP(form)
+
+	loginForm, ok := root.(*tview.Form)
+	require.True(t, ok)
+	assert.Equal(t, "Login", loginForm.GetTitle())
+
+	form.GetFormItem(0).(*tview.InputField).SetText(" 123456 ")
+	ui.HandleTOTP(form)
+
+	_, ok = root.(*tview.List)
+	require.True(t, ok)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	var root tview.Primitive
+
+	ui.SetRoot = func(p tview.Primitive, _ bool) *tview.Application {
+		root = p
+
+		return ui.App
+	}
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.EnrollTOTPFunc = func(_ context.Context) (*pb.EnrollTOTPV1Response, error) {
+		return &pb.EnrollTOTPV1Response{Secret: "SECRET", QrCode: "█▀▀▀█"}, nil
+	}
+	mockFacade.ConfirmTOTPFunc = func(_ context.Context, code string) ([]string, error) {
+		if code != "123456" {
+			return nil, errors.New("invalid code")
+		}
+
+		return []string{"abcde-fghjk", "mnpqr-stuvw"}, nil
+	}
+	mockFacade.On("EnrollTOTP", mock.Anything).Return(nil)
+	mockFacade.On("ConfirmTOTP", mock.Anything, mock.Anything).Return(nil)
+
+	flex, ok := ui.ShowEnrollTOTP().(*tview.Flex)
+	require.True(t, ok)
+	assert.Contains(t, flex.GetItem(0).(*tview.TextView).GetText(true), "Secret: SECRET")
+
+	form := flex.GetItem(1).(*tview.Form)
+
+	form.GetFormItem(0).(*tview.InputField).SetText("000000")
+	ui.HandleConfirmTOTP(form)
+	assert.Nil(t, root)
+
+	form.GetFormItem(0).(*tview.InputField).SetText("123456")
+	ui.HandleConfirmTOTP(form)
+
+	_, ok = root.(*tview.Modal)
+	require.True(t, ok)
+}
+
+func TestEnrollTOTP_Failure(t *testing.T) {
+	t.Parallel()
+
+	ui := setupTUI()
+
+	mockFacade := ui.Facade.(*testutils.MockFacade)
+	mockFacade.EnrollTOTPFunc = func(_ context.Context) (*pb.EnrollTOTPV1Response, error) {
+		return nil, errors.New("already enabled")
+	}
+	mockFacade.On("EnrollTOTP", mock.Anything).Return(nil)
+
+	mockToken := ui.TokenMgr.(*testutils.MockTokenManager)
+	mockToken.On("IsAuthorized").Return(true)
+
+	_, ok := ui.ShowEnrollTOTP().(*tview.List)
+	assert.True(t, ok)
+}
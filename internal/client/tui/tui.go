@@ -3,6 +3,7 @@ package tui
 
 import (
 	"context"
+	"errors"
 
 	"github.com/rivo/tview"
 	"github.com/rs/zerolog"
@@ -13,8 +14,11 @@ import (
 )
 
 const (
-	yesLabel = "Yes"
-	noLabel  = "No"
+	yesLabel       = "Yes"
+	noLabel        = "No"
+	mergeLabel     = "Merge"
+	overwriteLabel = "Overwrite"
+	cancelLabel    = "Cancel"
 )
 
 type TUI struct {
@@ -48,8 +52,13 @@ func (t *TUI) GetApp() *tview.Application {
 	return t.SetRoot(t.MainMenu(), true)
 }
 
+// ResetToLoginScreen is called back by the token manager when the tokens stop working.
 func (t *TUI) ResetToLoginScreen() {
-	t.TokenMgr.HandleAuthFailure()
+	// HandleAuthFailure would call back here again
+	if err := t.TokenMgr.ClearTokens(); err != nil {
+		t.Logger.Error().Err(err).Msg("Failed to clear tokens")
+	}
+
 	t.Logger.Warn().Msg("Authentication failed. Redirecting to login screen...")
 	t.SetRoot(t.ShowLoginForm(), true)
 }
@@ -70,7 +79,19 @@ func (t *TUI) MainMenu() *tview.List {
 		menu.AddItem("Binaries", "View and manage binary files", 'b', func() {
 			t.SetRoot(t.ShowBinaryList(), true)
 		})
-		menu.AddItem("Logout", "Sign out", 'q', t.ResetToLoginScreen)
+		menu.AddItem("Two-factor", "Enable two-factor authentication", 't', func() {
+			t.SetRoot(t.ShowEnrollTOTP(), true)
+		})
+		menu.AddItem("Sessions", "View and sign out signed-in devices", 's', func() {
+			t.SetRoot(t.ShowSessionList(), true)
+		})
+		menu.AddItem("API tokens", "Create and revoke tokens for scripts and CI jobs", 'k', func() {
+			t.SetRoot(t.ShowAPITokenList(), true)
+		})
+		menu.AddItem("Account", "Change the password or delete the account", 'a', func() {
+			t.SetRoot(t.ShowAccountMenu(), true)
+		})
+		menu.AddItem("Logout", "Sign out", 'q', t.HandleLogout)
 	} else {
 		menu.AddItem("Register", "Create new account", 'r', func() {
 			t.SetRoot(t.ShowRegisterForm(), true)
@@ -139,12 +160,23 @@ func (t *TUI) HandleLogin(form *tview.Form) {
 	password := form.GetFormItem(1).(*tview.InputField).GetText()
 
 	err := t.Facade.Login(username, password)
+	if errors.Is(err, facade.ErrTOTPRequired) {
+		t.SetRoot(t.ShowTOTPForm(), true)
+
+		return
+	}
+
 	if err != nil {
 		t.Logger.Error().Err(err).Msg("Login failed")
 
 		return
 	}
 
+	t.completeLogin()
+}
+
+// completeLogin syncs the items of the signed-in account and shows the main menu.
+func (t *TUI) completeLogin() {
 	if err := t.Storage.SyncItems(context.Background()); err != nil {
 		t.Logger.Error().Err(err).Msg("SyncItems failed")
 	} else {
@@ -134,7 +134,7 @@ func TestResetToLoginScreen(t *testing.T) {
 	}
 
 	mockToken := ui.TokenMgr.(*testutils.MockTokenManager)
-	mockToken.On("HandleAuthFailure").Return()
+	mockToken.On("ClearTokens").Return(nil)
 
 	ui.ResetToLoginScreen()
 	assert.True(t, called)
@@ -25,3 +25,12 @@ func FormatCardNumber(cardNumber string) string {
 
 	return strings.Join(parts, " ")
 }
+
+// mergeField resolves one field of a conflicting edit: the user's value wins only if they changed it.
+func mergeField(base, mine, theirs string) string {
+	if mine == base {
+		return theirs
+	}
+
+	return mine
+}
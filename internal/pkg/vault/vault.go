@@ -0,0 +1,387 @@
+// Package vault implements client-side encryption for zero-knowledge accounts.
+// The vault key never leaves the client in the clear: the server only keeps it wrapped
+// with a key derived from the master password, and stores every field sealed with it.
+package vault
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// SealedPrefix marks values sealed by a client, so servers can refuse plaintext for zero-knowledge accounts.
+const SealedPrefix = "zk1:"
+
+// Argon2id parameters follow the second recommended option of RFC 9106.
+const (
+	argonTime    = 3
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+	keySize      = 32
+	saltSize     = 16
+)
+
+// Fields a sealed value is bound to, together with the ID of its item. A value copied to another item
+// or field no longer unseals, so a server cannot swap them.
+const (
+	FieldLogin          = "login"
+	FieldPassword       = "password"
+	FieldNote           = "note"
+	FieldCardNumber     = "card_number"
+	FieldExpiryDate     = "expiry_date"
+	FieldCVV            = "cvv"
+	FieldCardholderName = "cardholder_name"
+	FieldFile           = "file"
+)
+
+// Files sealed by EncryptStream start with the header
+//
+//	"GPMZ" | version (1) | nonce prefix (7)
+//
+// followed by chunks of chunkSize plaintext bytes, the last one possibly shorter or empty. Each chunk is
+// sealed with the nonce prefix | chunk counter (4) | final flag (1), and with the header and the binding
+// of the file as additional data, so reordered, dropped or truncated chunks fail to open.
+const (
+	streamMagic           = "GPMZ\x01"
+	streamNoncePrefixSize = 7
+	streamHeaderSize      = len(streamMagic) + streamNoncePrefixSize
+	chunkSize             = 64 * 1024
+)
+
+var (
+	ErrNotSealed = errors.New("value is not sealed by the client")
+	// ErrTruncatedFile is returned when a sealed file ends before its final chunk.
+	ErrTruncatedFile = errors.New("sealed file is truncated")
+	// ErrInvalidFileHeader is returned for a file that was not sealed by EncryptStream.
+	ErrInvalidFileHeader = errors.New("invalid sealed file header")
+	// ErrTooManyChunks is returned when the chunk counter would wrap around.
+	ErrTooManyChunks = errors.New("sealed file has too many chunks")
+)
+
+// Keys is what the server stores for a zero-knowledge account.
+type Keys struct {
+	Salt       string
+	WrappedKey string
+}
+
+// Vault seals and unseals vault fields and files with the vault key.
+type Vault struct {
+	aead cipher.AEAD
+}
+
+// IsSealed reports whether value was produced by Vault.Seal.
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, SealedPrefix)
+}
+
+// MetaField is the field of the metadata value stored under key.
+func MetaField(key string) string {
+	return "meta/" + key
+}
+
+// New generates a random vault key and wraps it with a key derived from masterPassword.
+func New(masterPassword string) (*Vault, Keys, error) {
+	vaultKey := make([]byte, keySize)
+	if _, err := rand.Read(vaultKey); err != nil {
+		return nil, Keys{}, errors.Wrap(err, "failed to generate vault key")
+	}
+
+	keys, err := wrapKey(masterPassword, vaultKey)
+	if err != nil {
+		return nil, Keys{}, err
+	}
+
+	vault, err := fromKey(vaultKey)
+	if err != nil {
+		return nil, Keys{}, err
+	}
+
+	return vault, keys, nil
+}
+
+// Unlock unwraps the vault key of keys with masterPassword.
+func Unlock(masterPassword string, keys Keys) (*Vault, error) {
+	vaultKey, err := unwrapKey(masterPassword, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromKey(vaultKey)
+}
+
+// Rewrap wraps the vault key of keys with newMasterPassword instead of masterPassword. The vault key
+// stays the same, so values sealed before remain readable.
+func Rewrap(masterPassword, newMasterPassword string, keys Keys) (Keys, error) {
+	vaultKey, err := unwrapKey(masterPassword, keys)
+	if err != nil {
+		return Keys{}, err
+	}
+
+	return wrapKey(newMasterPassword, vaultKey)
+}
+
+// Seal encrypts a field of the item itemID.
+func (v *Vault) Seal(plaintext, itemID, field string) (string, error) {
+	sealed, err := seal(v.aead, []byte(plaintext), additionalData(itemID, field))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to seal value")
+	}
+
+	return SealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Unseal decrypts a value Seal produced for the same item and field.
+func (v *Vault) Unseal(value, itemID, field string) (string, error) {
+	if !IsSealed(value) {
+		return "", ErrNotSealed
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, SealedPrefix))
+	if err != nil {
+		return "", errors.Wrap(err, "invalid sealed value")
+	}
+
+	plaintext, err := open(v.aead, data, additionalData(itemID, field))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to unseal value")
+	}
+
+	return string(plaintext), nil
+}
+
+// EncryptStream seals src, the content of the file itemID, into dst.
+func (v *Vault) EncryptStream(dst io.Writer, src io.Reader, itemID string) error {
+	header := make([]byte, streamHeaderSize)
+	copy(header, streamMagic)
+
+	if _, err := rand.Read(header[len(streamMagic):]); err != nil {
+		return errors.Wrap(err, "failed to generate nonce prefix")
+	}
+
+	if _, err := dst.Write(header); err != nil {
+		return errors.Wrap(err, "failed to write header")
+	}
+
+	fileData := append(bytes.Clone(header), additionalData(itemID, FieldFile)...)
+	reader := bufio.NewReader(src)
+	buf := make([]byte, chunkSize)
+
+	for counter := uint32(0); ; counter++ {
+		n, final, err := readChunk(reader, buf)
+		if err != nil {
+			return errors.Wrap(err, "failed to read input")
+		}
+
+		if !final && counter == math.MaxUint32 {
+			return ErrTooManyChunks
+		}
+
+		sealed := v.aead.Seal(nil, streamNonce(header, counter, final), buf[:n], fileData)
+		if _, err := dst.Write(sealed); err != nil {
+			return errors.Wrap(err, "failed to write chunk")
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
+
+// DecryptStream reverses EncryptStream. A chunk is only written once it was authenticated, and
+// decrypting fails unless src ends with the final chunk.
+func (v *Vault) DecryptStream(dst io.Writer, src io.Reader, itemID string) error {
+	header := make([]byte, streamHeaderSize)
+	if _, err := io.ReadFull(src, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return ErrTruncatedFile
+		}
+
+		return errors.Wrap(err, "failed to read header")
+	}
+
+	if string(header[:len(streamMagic)]) != streamMagic {
+		return ErrInvalidFileHeader
+	}
+
+	fileData := append(bytes.Clone(header), additionalData(itemID, FieldFile)...)
+	reader := bufio.NewReader(src)
+	buf := make([]byte, chunkSize+v.aead.Overhead())
+
+	for counter := uint32(0); ; counter++ {
+		n, final, err := readChunk(reader, buf)
+		if err != nil {
+			return errors.Wrap(err, "failed to read input")
+		}
+
+		// Every chunk carries at least its tag, so an empty read means the final chunk is missing.
+		if n == 0 {
+			return ErrTruncatedFile
+		}
+
+		if !final && counter == math.MaxUint32 {
+			return ErrTooManyChunks
+		}
+
+		plaintext, err := v.aead.Open(nil, streamNonce(header, counter, final), buf[:n], fileData)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open chunk %d", counter)
+		}
+
+		if _, err := dst.Write(plaintext); err != nil {
+			return errors.Wrap(err, "failed to write chunk")
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
+
+// readChunk fills buf from reader and reports whether nothing follows the bytes read.
+func readChunk(reader *bufio.Reader, buf []byte) (int, bool, error) {
+	n, err := io.ReadFull(reader, buf)
+
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return n, true, nil
+	case err != nil:
+		return n, false, err
+	}
+
+	// A full chunk is the final one if nothing follows it.
+	if _, err := reader.Peek(1); errors.Is(err, io.EOF) {
+		return n, true, nil
+	} else if err != nil {
+		return n, false, err
+	}
+
+	return n, false, nil
+}
+
+// streamNonce is the nonce prefix of the header, the big-endian chunk counter and the final flag.
+func streamNonce(header []byte, counter uint32, final bool) []byte {
+	nonce := make([]byte, streamNoncePrefixSize+4+1)
+	copy(nonce, header[len(streamMagic):])
+	binary.BigEndian.PutUint32(nonce[streamNoncePrefixSize:], counter)
+
+	if final {
+		nonce[len(nonce)-1] = 1
+	}
+
+	return nonce
+}
+
+// additionalData binds a sealed value to its item and field; item IDs have a fixed length, so the
+// encoding is unambiguous.
+func additionalData(itemID, field string) []byte {
+	return []byte("zk1|" + itemID + "|" + field)
+}
+
+// wrapKey seals vaultKey with a key derived from masterPassword and a new salt.
+func wrapKey(masterPassword string, vaultKey []byte) (Keys, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return Keys{}, errors.Wrap(err, "failed to generate salt")
+	}
+
+	kek, err := newAEAD(deriveKey(masterPassword, salt))
+	if err != nil {
+		return Keys{}, err
+	}
+
+	wrapped, err := seal(kek, vaultKey, nil)
+	if err != nil {
+		return Keys{}, errors.Wrap(err, "failed to wrap vault key")
+	}
+
+	return Keys{
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		WrappedKey: base64.StdEncoding.EncodeToString(wrapped),
+	}, nil
+}
+
+func unwrapKey(masterPassword string, keys Keys) ([]byte, error) {
+	salt, err := base64.StdEncoding.DecodeString(keys.Salt)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid salt")
+	}
+
+	wrapped, err := base64.StdEncoding.DecodeString(keys.WrappedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid wrapped key")
+	}
+
+	kek, err := newAEAD(deriveKey(masterPassword, salt))
+	if err != nil {
+		return nil, err
+	}
+
+	vaultKey, err := open(kek, wrapped, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "wrong master password")
+	}
+
+	return vaultKey, nil
+}
+
+func deriveKey(masterPassword string, salt []byte) []byte {
+	return argon2.IDKey([]byte(masterPassword), salt, argonTime, argonMemory, argonThreads, keySize)
+}
+
+func fromKey(key []byte) (*Vault, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Vault{aead: aead}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GCM")
+	}
+
+	return aead, nil
+}
+
+// seal returns nonce || ciphertext.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt")
+	}
+
+	return plaintext, nil
+}
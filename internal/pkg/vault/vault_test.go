@@ -0,0 +1,205 @@
+package vault_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
+)
+
+func TestNewAndUnlock(t *testing.T) {
+	t.Parallel()
+
+	created, keys, err := vault.New("correct horse battery staple")
+	require.NoError(t, err)
+	assert.NotEmpty(t, keys.Salt)
+	assert.NotEmpty(t, keys.WrappedKey)
+
+	itemID := uuid.NewString()
+	sealed, err := created.Seal("secret", itemID, vault.FieldPassword)
+	require.NoError(t, err)
+	assert.True(t, vault.IsSealed(sealed))
+	assert.NotContains(t, sealed, "secret")
+
+	unlocked, err := vault.Unlock("correct horse battery staple", keys)
+	require.NoError(t, err)
+
+	plaintext, err := unlocked.Unseal(sealed, itemID, vault.FieldPassword)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+}
+
+func TestUnseal_Binding(t *testing.T) {
+	t.Parallel()
+
+	v, _, err := vault.New("master")
+	require.NoError(t, err)
+
+	itemID := uuid.NewString()
+	sealed, err := v.Seal("secret", itemID, vault.FieldPassword)
+	require.NoError(t, err)
+
+	// A server swapping the value into another item or field is noticed
+	_, err = v.Unseal(sealed, uuid.NewString(), vault.FieldPassword)
+	require.ErrorContains(t, err, "failed to unseal value")
+
+	_, err = v.Unseal(sealed, itemID, vault.FieldLogin)
+	require.ErrorContains(t, err, "failed to unseal value")
+
+	_, err = v.Unseal(sealed, itemID, vault.MetaField("password"))
+	require.ErrorContains(t, err, "failed to unseal value")
+}
+
+func TestUnlock_WrongPassword(t *testing.T) {
+	t.Parallel()
+
+	_, keys, err := vault.New("correct horse battery staple")
+	require.NoError(t, err)
+
+	_, err = vault.Unlock("wrong password", keys)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong master password")
+
+	_, err = vault.Unlock("correct horse battery staple", vault.Keys{Salt: "%%%", WrappedKey: keys.WrappedKey})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid salt")
+}
+
+func TestRewrap(t *testing.T) {
+	t.Parallel()
+
+	created, keys, err := vault.New("correct horse battery staple")
+	require.NoError(t, err)
+
+	itemID := uuid.NewString()
+	sealed, err := created.Seal("secret", itemID, vault.FieldNote)
+	require.NoError(t, err)
+
+	_, err = vault.Rewrap("wrong password", "new master password", keys)
+	require.ErrorContains(t, err, "wrong master password")
+
+	rewrapped, err := vault.Rewrap("correct horse battery staple", "new master password", keys)
+	require.NoError(t, err)
+	assert.NotEqual(t, keys.Salt, rewrapped.Salt)
+
+	_, err = vault.Unlock("correct horse battery staple", rewrapped)
+	require.Error(t, err)
+
+	// The vault key is unchanged, so values sealed before still open.
+	unlocked, err := vault.Unlock("new master password", rewrapped)
+	require.NoError(t, err)
+
+	plaintext, err := unlocked.Unseal(sealed, itemID, vault.FieldNote)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+}
+
+func TestUnseal_Errors(t *testing.T) {
+	t.Parallel()
+
+	v, _, err := vault.New("master")
+	require.NoError(t, err)
+
+	itemID := uuid.NewString()
+
+	_, err = v.Unseal("plain value", itemID, vault.FieldNote)
+	require.ErrorIs(t, err, vault.ErrNotSealed)
+
+	_, err = v.Unseal(vault.SealedPrefix+"not base64!", itemID, vault.FieldNote)
+	require.Error(t, err)
+
+	other, _, err := vault.New("master")
+	require.NoError(t, err)
+
+	sealed, err := other.Seal("secret", itemID, vault.FieldNote)
+	require.NoError(t, err)
+
+	_, err = v.Unseal(sealed, itemID, vault.FieldNote)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to unseal value")
+}
+
+func TestStream_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	v, _, err := vault.New("master")
+	require.NoError(t, err)
+
+	const chunkSize = 64 * 1024
+
+	fileID := uuid.NewString()
+
+	for _, size := range []int{0, 10, chunkSize, 2 * chunkSize, 2*chunkSize + 100} {
+		data := []byte(strings.Repeat("x", size))
+
+		var encrypted bytes.Buffer
+		require.NoError(t, v.EncryptStream(&encrypted, bytes.NewReader(data), fileID))
+		if size > 0 {
+			assert.NotContains(t, encrypted.String(), "xxxx")
+		}
+
+		var decrypted bytes.Buffer
+		require.NoError(t, v.DecryptStream(&decrypted, &encrypted, fileID))
+		assert.Equal(t, string(data), decrypted.String(), "size %d", size)
+	}
+}
+
+func TestDecryptStream_Tampered(t *testing.T) {
+	t.Parallel()
+
+	v, _, err := vault.New("master")
+	require.NoError(t, err)
+
+	const (
+		headerSize = 12
+		sealedSize = 64*1024 + 16
+	)
+
+	fileID := uuid.NewString()
+
+	var encrypted bytes.Buffer
+	require.NoError(t, v.EncryptStream(&encrypted, strings.NewReader(strings.Repeat("x", 3*64*1024)), fileID))
+
+	sealed := encrypted.Bytes()
+	header := sealed[:headerSize]
+	chunks := [][]byte{
+		sealed[headerSize : headerSize+sealedSize],
+		sealed[headerSize+sealedSize : headerSize+2*sealedSize],
+		sealed[headerSize+2*sealedSize:],
+	}
+
+	join := func(parts ...[]byte) []byte {
+		return bytes.Join(append([][]byte{header}, parts...), nil)
+	}
+
+	flipped := bytes.Clone(sealed)
+	flipped[len(flipped)-1] ^= 0xff
+
+	tests := []struct {
+		name string
+		data []byte
+		id   string
+	}{
+		{name: "flipped bit", data: flipped, id: fileID},
+		{name: "other file", data: sealed, id: uuid.NewString()},
+		{name: "reordered chunks", data: join(chunks[1], chunks[0], chunks[2]), id: fileID},
+		{name: "dropped chunk", data: join(chunks[0], chunks[2]), id: fileID},
+		{name: "truncated at a chunk", data: join(chunks[0], chunks[1]), id: fileID},
+		{name: "final chunk missing", data: header, id: fileID},
+		{name: "no header", data: chunks[0], id: fileID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v.DecryptStream(&bytes.Buffer{}, bytes.NewReader(tt.data), tt.id)
+			require.Error(t, err)
+		})
+	}
+}
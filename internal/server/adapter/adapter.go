@@ -24,6 +24,14 @@ func (m *MinioAdapter) GetObject(ctx context.Context, bucketName string, objectN
 	return m.client.GetObject(ctx, bucketName, objectName, opts)
 }
 
+func (m *MinioAdapter) ListObjects(
+	ctx context.Context,
+	bucketName string,
+	opts minio.ListObjectsOptions,
+) <-chan minio.ObjectInfo {
+	return m.client.ListObjects(ctx, bucketName, opts)
+}
+
 func (m *MinioAdapter) RemoveObject(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
 	return m.client.RemoveObject(ctx, bucketName, objectName, opts)
 }
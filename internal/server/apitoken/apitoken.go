@@ -0,0 +1,160 @@
+// Package apitoken implements personal access tokens: credentials for automation that reach a part of the vault.
+package apitoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// Prefix tells API tokens apart from access tokens.
+const Prefix = "pmat_"
+
+var ErrInvalidToken = errors.New("invalid api token")
+
+// Store keeps the API tokens, see storage.DBStorage.
+type Store interface {
+	GetAPIToken(ctx context.Context, tokenHash string) (*db.ApiToken, error)
+	TouchAPIToken(ctx context.Context, tokenID pgtype.UUID) error
+	ListScopedItemIDs(ctx context.Context, params db.ListScopedItemIDsParams) ([]pgtype.UUID, error)
+}
+
+// Scope is what an API token may reach.
+type Scope struct {
+	ReadOnly bool
+	// ItemTypes limits the token to items of these types, unless empty.
+	ItemTypes []db.ItemType
+	// Tags limits the token to items carrying all of this metadata, unless empty.
+	Tags map[string]string
+	// items holds the resource IDs the token reaches; nil when it reaches every item.
+	items map[string]struct{}
+}
+
+// Restricted reports whether the scope leaves out some items.
+func (s *Scope) Restricted() bool {
+	return len(s.ItemTypes) > 0 || len(s.Tags) > 0
+}
+
+// AllowsType reports whether the scope reaches items of the type.
+func (s *Scope) AllowsType(itemType db.ItemType) bool {
+	return len(s.ItemTypes) == 0 || slices.Contains(s.ItemTypes, itemType)
+}
+
+// AllowsItem reports whether the scope reaches the item with the resource ID.
+func (s *Scope) AllowsItem(itemID string) bool {
+	if s.items == nil {
+		return true
+	}
+
+	_, ok := s.items[gu.GetIDFromString(itemID).String()]
+
+	return ok
+}
+
+// New returns a new API token and the hash it is stored under.
+func New() (string, string) {
+	token := Prefix + rand.Text()
+
+	return token, Hash(token)
+}
+
+// Hash returns the SHA-256 of an API token, so that the token itself is not stored.
+func Hash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// IsToken reports whether the bearer token is an API token rather than an access token.
+func IsToken(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
+
+// EncodeTags returns the tags as stored in the api_tokens table.
+func EncodeTags(tags map[string]string) []byte {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+
+	//nolint:errchkjson
+	encoded, _ := json.Marshal(tags)
+
+	return encoded
+}
+
+// DecodeTags reads tags stored by EncodeTags.
+func DecodeTags(encoded []byte) map[string]string {
+	tags := map[string]string{}
+	if err := json.Unmarshal(encoded, &tags); err != nil {
+		return map[string]string{}
+	}
+
+	return tags
+}
+
+// Resolver looks up the user and the scope of API tokens.
+type Resolver struct {
+	store  Store
+	logger *zerolog.Logger
+}
+
+func NewResolver(store Store, log *zerolog.Logger) *Resolver {
+	return &Resolver{
+		store:  store,
+		logger: log,
+	}
+}
+
+// Resolve returns the user ID and the scope of an unexpired API token. Restricted scopes list the items
+// they reach on every call, so that retagging an item takes effect at once.
+func (r *Resolver) Resolve(ctx context.Context, token string) (string, *Scope, error) {
+	row, err := r.store.GetAPIToken(ctx, Hash(token))
+	if err != nil {
+		return "", nil, errors.Wrap(ErrInvalidToken, err.Error())
+	}
+
+	if err := r.store.TouchAPIToken(ctx, row.ID); err != nil {
+		r.logger.Error().Err(err).Msg("failed to record api token use")
+	}
+
+	scope := &Scope{
+		ReadOnly:  row.ReadOnly,
+		ItemTypes: make([]db.ItemType, len(row.ItemTypes)),
+		Tags:      DecodeTags(row.Tags),
+		items:     nil,
+	}
+	for cursor, itemType := range row.ItemTypes {
+		scope.ItemTypes[cursor] = db.ItemType(itemType)
+	}
+
+	if !scope.Restricted() {
+		return row.UserID.String(), scope, nil
+	}
+
+	itemIDs, err := r.store.ListScopedItemIDs(ctx, db.ListScopedItemIDsParams{
+		UserID:    row.UserID,
+		ItemTypes: row.ItemTypes,
+		Tags:      row.Tags,
+	})
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error listing items of the scope")
+	}
+
+	scope.items = make(map[string]struct{}, len(itemIDs))
+	for _, itemID := range itemIDs {
+		scope.items[itemID.String()] = struct{}{}
+	}
+
+	return row.UserID.String(), scope, nil
+}
@@ -0,0 +1,108 @@
+//nolint:exhaustruct
+package apitoken_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/apitoken"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	token, hash := apitoken.New()
+	require.True(t, apitoken.IsToken(token))
+	require.True(t, strings.HasPrefix(token, apitoken.Prefix))
+	require.Equal(t, apitoken.Hash(token), hash)
+	require.NotContains(t, hash, token)
+
+	other, _ := apitoken.New()
+	require.NotEqual(t, token, other)
+	require.False(t, apitoken.IsToken("eyJhbGciOiJFZERTQSJ9.e30.sig"))
+}
+
+func TestTags(t *testing.T) {
+	t.Parallel()
+
+	tags := map[string]string{"env": "ci", "team": "ops"}
+	require.Equal(t, tags, apitoken.DecodeTags(apitoken.EncodeTags(tags)))
+	require.JSONEq(t, `{}`, string(apitoken.EncodeTags(nil)))
+	require.Empty(t, apitoken.DecodeTags([]byte("not json")))
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	logger := zerolog.Nop()
+	storage := testutils.NewMockDBStorage(&logger, "")
+	resolver := apitoken.NewResolver(storage, &logger)
+	userID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	password, err := storage.StorePassword(ctx, db.CreatePasswordEntryParams{UserID: userID}, pgtype.UUID{})
+	require.NoError(t, err)
+	note, err := storage.StoreNote(ctx, db.CreateNoteEntryParams{UserID: userID}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	create := func(itemTypes []string, tags map[string]string) string {
+		token, hash := apitoken.New()
+
+		_, err := storage.CreateAPIToken(ctx, db.CreateAPITokenParams{
+			UserID:    userID,
+			Name:      uuid.NewString(),
+			TokenHash: hash,
+			ReadOnly:  true,
+			ItemTypes: itemTypes,
+			Tags:      apitoken.EncodeTags(tags),
+			ExpiresAt: pgtype.Timestamp{Time: time.Now().Add(time.Hour), Valid: true},
+		})
+		require.NoError(t, err)
+
+		return token
+	}
+
+	// A token without limits reaches every item.
+	resolvedID, scope, err := resolver.Resolve(ctx, create(nil, nil))
+	require.NoError(t, err)
+	require.Equal(t, userID.String(), resolvedID)
+	require.True(t, scope.ReadOnly)
+	require.False(t, scope.Restricted())
+	require.True(t, scope.AllowsType(db.ItemTypeCard))
+	require.True(t, scope.AllowsItem(uuid.NewString()))
+
+	_, scope, err = resolver.Resolve(ctx, create([]string{"password"}, nil))
+	require.NoError(t, err)
+	require.True(t, scope.Restricted())
+	require.True(t, scope.AllowsType(db.ItemTypePassword))
+	require.False(t, scope.AllowsType(db.ItemTypeText))
+	require.True(t, scope.AllowsItem(password.ID.String()))
+	require.True(t, scope.AllowsItem(strings.ToUpper(password.ID.String())))
+	require.False(t, scope.AllowsItem(note.ID.String()))
+
+	// Tagging the note brings it into scope straight away.
+	tagged := create(nil, map[string]string{"env": "ci"})
+
+	_, scope, err = resolver.Resolve(ctx, tagged)
+	require.NoError(t, err)
+	require.False(t, scope.AllowsItem(note.ID.String()))
+
+	_, err = storage.AddMeta(ctx, note.ID.String(), "env", "ci")
+	require.NoError(t, err)
+
+	_, scope, err = resolver.Resolve(ctx, tagged)
+	require.NoError(t, err)
+	require.True(t, scope.AllowsItem(note.ID.String()))
+	require.False(t, scope.AllowsItem(password.ID.String()))
+
+	_, _, err = resolver.Resolve(ctx, apitoken.Prefix+"unknown")
+	require.ErrorIs(t, err, apitoken.ErrInvalidToken)
+}
@@ -0,0 +1,136 @@
+// Package auditlog keeps a tamper-evident record of the calls made to the server: every event carries the
+// hash of the event before it, so editing or removing events breaks the chain.
+package auditlog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// maxUserAgent bounds the user agents kept in the log; clients choose them freely.
+const maxUserAgent = 256
+
+var ErrBrokenChain = errors.New("audit chain is broken")
+
+// Storage keeps the audit chain, see storage.DBStorage.
+type Storage interface {
+	AppendAuditEvent(ctx context.Context, build func(last *db.AuditEvent) db.InsertAuditEventParams) error
+}
+
+// Entry is a call to record.
+type Entry struct {
+	// ActorID is the calling user, empty for calls without credentials.
+	ActorID string
+	// ItemID is the vault item the call named, if any.
+	ItemID    string
+	Action    string
+	IP        string
+	UserAgent string
+	Result    string
+}
+
+// Recorder appends entries to the audit chain.
+type Recorder struct {
+	storage Storage
+}
+
+// NewRecorder creates a recorder writing to the storage.
+func NewRecorder(storage Storage) *Recorder {
+	return &Recorder{
+		storage: storage,
+	}
+}
+
+// Record appends an entry after the last event of the chain. The storage serializes appends, so events
+// of several servers never take the same position.
+func (r *Recorder) Record(ctx context.Context, entry Entry) error {
+	userAgent := entry.UserAgent
+	if len(userAgent) > maxUserAgent {
+		userAgent = userAgent[:maxUserAgent]
+	}
+
+	// Postgres refuses text that is not UTF-8, which cutting or clients may leave behind
+	userAgent = strings.ToValidUTF8(userAgent, "")
+
+	err := r.storage.AppendAuditEvent(ctx, func(last *db.AuditEvent) db.InsertAuditEventParams {
+		event := db.AuditEvent{
+			Seq:       1,
+			ActorID:   gu.GetIDFromString(entry.ActorID),
+			ItemID:    gu.GetIDFromString(entry.ItemID),
+			Action:    entry.Action,
+			Ip:        entry.IP,
+			UserAgent: userAgent,
+			Result:    entry.Result,
+			// Postgres keeps microseconds, and timestamps without a zone read back as UTC
+			OccurredAt: pgtype.Timestamp{Time: time.Now().UTC().Truncate(time.Microsecond), Valid: true},
+		}
+
+		if last != nil {
+			event.Seq = last.Seq + 1
+			event.PrevHash = last.Hash
+		}
+
+		event.Hash = Hash(event)
+
+		return db.InsertAuditEventParams(event)
+	})
+
+	return errors.Wrap(err, "error appending audit event")
+}
+
+// Hash returns the hash of an event: the hex SHA-256 of its previous hash, sequence number, actor ID,
+// item ID, action, IP, user agent, result and time in Unix microseconds, each followed by a newline.
+func Hash(event db.AuditEvent) string {
+	digest := sha256.New()
+
+	for _, field := range []string{
+		event.PrevHash,
+		strconv.FormatInt(event.Seq, 10),
+		event.ActorID.String(),
+		event.ItemID.String(),
+		event.Action,
+		event.Ip,
+		event.UserAgent,
+		event.Result,
+		strconv.FormatInt(event.OccurredAt.Time.UnixMicro(), 10),
+	} {
+		digest.Write([]byte(field))
+		digest.Write([]byte{'\n'})
+	}
+
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+// Verifier checks events of the chain in order.
+type Verifier struct {
+	prev *db.AuditEvent
+}
+
+// Check verifies that an event follows the one checked before and that its hash matches. The first
+// event checked is trusted to follow its predecessor unless it starts the chain.
+func (v *Verifier) Check(event db.AuditEvent) error {
+	switch {
+	case v.prev == nil && event.Seq == 1 && event.PrevHash != "":
+		return errors.Wrapf(ErrBrokenChain, "event %d starts the chain but has a previous hash", event.Seq)
+	case v.prev != nil && event.Seq != v.prev.Seq+1:
+		return errors.Wrapf(ErrBrokenChain, "event %d follows event %d", event.Seq, v.prev.Seq)
+	case v.prev != nil && event.PrevHash != v.prev.Hash:
+		return errors.Wrapf(ErrBrokenChain, "event %d does not link to event %d", event.Seq, v.prev.Seq)
+	case Hash(event) != event.Hash:
+		return errors.Wrapf(ErrBrokenChain, "event %d does not match its hash", event.Seq)
+	}
+
+	v.prev = &event
+
+	return nil
+}
@@ -0,0 +1,128 @@
+//nolint:exhaustruct,err113
+package auditlog_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/auditlog"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+// recordChain records count calls and returns the chain.
+func recordChain(t *testing.T, storage *testutils.MockDBStorage, count int) []db.AuditEvent {
+	t.Helper()
+
+	recorder := auditlog.NewRecorder(storage)
+	actorID := uuid.NewString()
+
+	for range count {
+		require.NoError(t, recorder.Record(t.Context(), auditlog.Entry{
+			ActorID:   actorID,
+			ItemID:    uuid.NewString(),
+			Action:    "/proto.password.PasswordService/GetPasswordV1",
+			IP:        "10.0.0.7",
+			UserAgent: "cli/1.0",
+			Result:    "OK",
+		}))
+	}
+
+	events, err := storage.ExportAuditEvents(t.Context(), 0, 100)
+	require.NoError(t, err)
+
+	return events
+}
+
+func verify(events []db.AuditEvent) error {
+	var verifier auditlog.Verifier
+
+	for _, event := range events {
+		if err := verifier.Check(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	storage := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+	events := recordChain(t, storage, 3)
+
+	require.Len(t, events, 3)
+	require.Equal(t, int64(1), events[0].Seq)
+	require.Empty(t, events[0].PrevHash)
+	require.Equal(t, events[0].Hash, events[1].PrevHash)
+	require.Equal(t, events[1].Hash, events[2].PrevHash)
+	require.NoError(t, verify(events))
+
+	// An export may resume in the middle of the chain
+	require.NoError(t, verify(events[1:]))
+}
+
+func TestVerifierDetectsTampering(t *testing.T) {
+	t.Parallel()
+
+	storage := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+	events := recordChain(t, storage, 3)
+
+	edited := append([]db.AuditEvent{}, events...)
+	edited[1].Result = "PermissionDenied"
+	require.ErrorIs(t, verify(edited), auditlog.ErrBrokenChain)
+
+	// Rehashing the edited event does not help, the next one still links to the old hash
+	edited[1].Hash = auditlog.Hash(edited[1])
+	require.ErrorIs(t, verify(edited), auditlog.ErrBrokenChain)
+
+	removed := []db.AuditEvent{events[0], events[2]}
+	require.ErrorIs(t, verify(removed), auditlog.ErrBrokenChain)
+
+	forged := append([]db.AuditEvent{}, events...)
+	forged[0].PrevHash = "forged"
+	forged[0].Hash = auditlog.Hash(forged[0])
+	require.ErrorIs(t, verify(forged), auditlog.ErrBrokenChain)
+}
+
+func TestRecordConcurrently(t *testing.T) {
+	t.Parallel()
+
+	storage := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+	recorder := auditlog.NewRecorder(storage)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, recorder.Record(t.Context(), auditlog.Entry{Action: "/test.Method", Result: "OK"}))
+		}()
+	}
+
+	wg.Wait()
+
+	// Every append lands on its own position of one chain
+	events, err := storage.ExportAuditEvents(t.Context(), 0, 100)
+	require.NoError(t, err)
+	require.Len(t, events, 20)
+	require.NoError(t, verify(events))
+}
+
+func TestRecordStorageError(t *testing.T) {
+	t.Parallel()
+
+	storage := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+	storage.CallError = errors.New("db error")
+
+	err := auditlog.NewRecorder(storage).Record(t.Context(), auditlog.Entry{Action: "/test.Method", Result: "OK"})
+	require.ErrorContains(t, err, "error appending audit event")
+}
@@ -0,0 +1,187 @@
+// Package authz decides what members of an organization may do with the items of its collections.
+//
+// Every organization keeps its items in a vault of its own, so the item services read and write them
+// like personal items once authz resolved the vault the caller works on. Trusted contacts with approved
+// emergency access are resolved to the vault of the grantor the same way.
+package authz
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// Action is what a member does with a collection.
+type Action int
+
+const (
+	// Read lists and reads the items of a collection.
+	Read Action = iota
+	// Write adds, changes and deletes the items of a collection.
+	Write
+	// Manage creates and deletes collections and gives members access to them.
+	Manage
+)
+
+// Storage keeps organizations and their collections, see storage.DBStorage.
+type Storage interface {
+	GetItemAccess(ctx context.Context, itemID, userID pgtype.UUID) (*db.GetItemAccessRow, error)
+	GetCollectionAccess(ctx context.Context, collectionID, userID pgtype.UUID) (*db.GetCollectionAccessRow, error)
+	GetEmergencyItemAccess(ctx context.Context, itemID, granteeID pgtype.UUID) (pgtype.UUID, error)
+	GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User, error)
+}
+
+// Allows reports whether a member with the role may take the action on a collection. The grant is the
+// access the member was given to the collection: unset without one, and true when it is read-only.
+// Owners and admins reach every collection without a grant.
+func Allows(role db.OrgRole, grant pgtype.Bool, action Action) bool {
+	switch role {
+	case db.OrgRoleOwner, db.OrgRoleAdmin:
+		return true
+	case db.OrgRoleMember:
+		return grant.Valid && (action == Read || action == Write && !grant.Bool)
+	case db.OrgRoleReadOnly:
+		return grant.Valid && action == Read
+	default:
+		return false
+	}
+}
+
+// ItemVault returns the vault the caller takes the action on the item in. Items of the caller, as well as
+// items the caller cannot reach through a collection or emergency access, resolve to the caller, so the
+// storage calls filtered by owner treat them as before.
+func ItemVault(ctx context.Context, storage Storage, itemID string, action Action) (pgtype.UUID, error) {
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, errors.Wrap(err, "error getting user id")
+	}
+
+	access, err := storage.GetItemAccess(ctx, gu.GetIDFromString(itemID), userUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return emergencyVault(ctx, storage, itemID, userUUID, action)
+	}
+	if err != nil {
+		return pgtype.UUID{}, errors.Wrap(err, "error getting item access")
+	}
+
+	if !access.Role.Valid || !Allows(access.Role.OrgRole, access.ReadOnly, Read) {
+		return userUUID, nil
+	}
+
+	if !Allows(access.Role.OrgRole, access.ReadOnly, action) {
+		return pgtype.UUID{}, status.Error(codes.PermissionDenied, "the collection of the item is read-only")
+	}
+
+	return access.VaultID, nil
+}
+
+// emergencyVault returns the vault of a user who gave the caller approved view access, for reading an item
+// in it. Anything else resolves to the caller.
+func emergencyVault(
+	ctx context.Context,
+	storage Storage,
+	itemID string,
+	userUUID pgtype.UUID,
+	action Action,
+) (pgtype.UUID, error) {
+	if action != Read {
+		return userUUID, nil
+	}
+
+	grantorUUID, err := storage.GetEmergencyItemAccess(ctx, gu.GetIDFromString(itemID), userUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return userUUID, nil
+	}
+	if err != nil {
+		return pgtype.UUID{}, errors.Wrap(err, "error getting emergency access")
+	}
+
+	return grantorUUID, nil
+}
+
+// ItemKeys returns the vault the caller takes the action on the item in, like ItemVault, with its keys.
+func ItemKeys(
+	ctx context.Context,
+	storage Storage,
+	keys utils.KeyUnwrapper,
+	itemID string,
+	action Action,
+) (pgtype.UUID, utils.UserKeys, error) {
+	vaultUUID, err := ItemVault(ctx, storage, itemID, action)
+	if err != nil {
+		return pgtype.UUID{}, utils.UserKeys{}, err
+	}
+
+	vaultKeys, err := utils.GetUserKey(ctx, storage, vaultUUID, keys)
+	if err != nil {
+		return pgtype.UUID{}, utils.UserKeys{}, errors.Wrap(err, "error getting decryption key")
+	}
+
+	return vaultUUID, vaultKeys, nil
+}
+
+// Collection returns the collection with the access of the caller, if the caller may take the action on it.
+// Collections the caller cannot read are not found.
+func Collection(
+	ctx context.Context,
+	storage Storage,
+	collectionID string,
+	action Action,
+) (*db.GetCollectionAccessRow, error) {
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	access, err := storage.GetCollectionAccess(ctx, gu.GetIDFromString(collectionID), userUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting collection access")
+	}
+
+	if !Allows(access.Role, access.ReadOnly, Read) {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+
+	if !Allows(access.Role, access.ReadOnly, action) {
+		return nil, status.Error(codes.PermissionDenied, "not allowed in the collection")
+	}
+
+	return access, nil
+}
+
+// StoreVault returns the vault and keys a new item is stored with: those of the caller, or those of the
+// organization when the item goes into a collection. The storage puts the item into the collection in the
+// transaction that stores it.
+func StoreVault(
+	ctx context.Context,
+	storage Storage,
+	keys utils.KeyUnwrapper,
+	collectionID string,
+) (pgtype.UUID, utils.UserKeys, error) {
+	if collectionID == "" {
+		return utils.GetDecryptionKey(ctx, storage, keys)
+	}
+
+	collection, err := Collection(ctx, storage, collectionID, Write)
+	if err != nil {
+		return pgtype.UUID{}, utils.UserKeys{}, err
+	}
+
+	vaultKeys, err := utils.GetUserKey(ctx, storage, collection.VaultID, keys)
+	if err != nil {
+		return pgtype.UUID{}, utils.UserKeys{}, errors.Wrap(err, "error getting organization key")
+	}
+
+	return collection.VaultID, vaultKeys, nil
+}
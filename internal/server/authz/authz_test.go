@@ -0,0 +1,47 @@
+//nolint:exhaustruct
+package authz_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/npavlov/go-password-manager/internal/server/authz"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+func TestAllows(t *testing.T) {
+	t.Parallel()
+
+	none := pgtype.Bool{}
+	readOnly := pgtype.Bool{Bool: true, Valid: true}
+	readWrite := pgtype.Bool{Bool: false, Valid: true}
+
+	tests := []struct {
+		name   string
+		role   db.OrgRole
+		grant  pgtype.Bool
+		action authz.Action
+		allows bool
+	}{
+		{name: "owner without a grant", role: db.OrgRoleOwner, grant: none, action: authz.Manage, allows: true},
+		{name: "admin without a grant", role: db.OrgRoleAdmin, grant: none, action: authz.Write, allows: true},
+		{name: "member without a grant", role: db.OrgRoleMember, grant: none, action: authz.Read, allows: false},
+		{name: "member reads", role: db.OrgRoleMember, grant: readOnly, action: authz.Read, allows: true},
+		{name: "member writes read-only", role: db.OrgRoleMember, grant: readOnly, action: authz.Write, allows: false},
+		{name: "member writes", role: db.OrgRoleMember, grant: readWrite, action: authz.Write, allows: true},
+		{name: "member manages", role: db.OrgRoleMember, grant: readWrite, action: authz.Manage, allows: false},
+		{name: "read-only reads", role: db.OrgRoleReadOnly, grant: readWrite, action: authz.Read, allows: true},
+		{name: "read-only writes", role: db.OrgRoleReadOnly, grant: readWrite, action: authz.Write, allows: false},
+		{name: "no role", role: "", grant: readWrite, action: authz.Read, allows: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.allows, authz.Allows(tt.role, tt.grant, tt.action))
+		})
+	}
+}
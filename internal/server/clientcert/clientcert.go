@@ -0,0 +1,189 @@
+// Package clientcert authenticates machine clients by the TLS certificate they present.
+package clientcert
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// Client certificate modes.
+const (
+	// ModeOff asks for no client certificate.
+	ModeOff = "off"
+	// ModeOptional verifies a client certificate when one is presented, so logins keep working.
+	ModeOptional = "optional"
+	// ModeRequired refuses connections without a valid client certificate.
+	ModeRequired = "required"
+)
+
+var (
+	ErrUnknownMode     = errors.New("unknown client certificate mode")
+	ErrNoClientCA      = errors.New("client certificates need a client CA")
+	ErrInvalidCA       = errors.New("no certificates found in the client CA file")
+	ErrNoCertificate   = errors.New("no verified client certificate")
+	ErrUnknownIdentity = errors.New("client certificate identity is not mapped to an account")
+)
+
+// Options are the client certificate settings.
+type Options struct {
+	// Mode is one of ModeOff, ModeOptional or ModeRequired.
+	Mode string
+	// CAFile holds the PEM certificates client certificates are verified against.
+	CAFile string
+	// IdentitiesFile maps certificate identities to usernames, see LoadIdentities.
+	IdentitiesFile string
+}
+
+// Validate checks the options before the server starts.
+func (o Options) Validate() error {
+	switch o.Mode {
+	case "", ModeOff:
+		return nil
+	case ModeOptional, ModeRequired:
+		if o.CAFile == "" {
+			return ErrNoClientCA
+		}
+
+		return nil
+	default:
+		return errors.Wrap(ErrUnknownMode, o.Mode)
+	}
+}
+
+// ServerTLS returns the TLS settings of the server, verifying client certificates against the client CA
+// unless they are turned off.
+func ServerTLS(certFile, keyFile string, options Options) (*tls.Config, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load server certificate")
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if options.Mode == "" || options.Mode == ModeOff {
+		return config, nil
+	}
+
+	pemCerts, err := os.ReadFile(options.CAFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read client CA")
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return nil, ErrInvalidCA
+	}
+
+	config.ClientCAs = pool
+	config.ClientAuth = tls.VerifyClientCertIfGiven
+
+	if options.Mode == ModeRequired {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+// Identity names the client of a certificate: its SPIFFE ID when it has one, its subject otherwise,
+// such as "CN=backup,O=Example".
+func Identity(cert *x509.Certificate) string {
+	for _, uri := range cert.URIs {
+		if uri.Scheme == "spiffe" {
+			return uri.String()
+		}
+	}
+
+	return cert.Subject.String()
+}
+
+// LoadIdentities reads a JSON object mapping certificate identities to usernames. An empty path maps none.
+func LoadIdentities(path string) (map[string]string, error) {
+	identities := make(map[string]string)
+	if path == "" {
+		return identities, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read client identities")
+	}
+
+	if err := json.Unmarshal(data, &identities); err != nil {
+		return nil, errors.Wrap(err, "failed to parse client identities")
+	}
+
+	return identities, nil
+}
+
+// UserStore looks up the accounts certificates are mapped to, see storage.DBStorage.
+type UserStore interface {
+	GetUser(ctx context.Context, username string) (*db.User, error)
+}
+
+// Resolver maps the client certificate of a call to the user it acts as.
+type Resolver struct {
+	store      UserStore
+	identities map[string]string
+	log        *zerolog.Logger
+}
+
+// NewResolver returns a resolver of the identities in options.IdentitiesFile.
+func NewResolver(store UserStore, options Options, log *zerolog.Logger) (*Resolver, error) {
+	identities, err := LoadIdentities(options.IdentitiesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Resolver{
+		store:      store,
+		identities: identities,
+		log:        log,
+	}, nil
+}
+
+// Resolve returns the user ID the verified client certificate of the call is mapped to. It returns
+// ErrNoCertificate when the client presented none.
+func (r *Resolver) Resolve(ctx context.Context) (string, error) {
+	client, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", ErrNoCertificate
+	}
+
+	tlsInfo, ok := client.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", ErrNoCertificate
+	}
+
+	identity := Identity(tlsInfo.State.VerifiedChains[0][0])
+
+	username, ok := r.identities[identity]
+	if !ok {
+		r.log.Warn().Str("identity", identity).Msg("client certificate is not mapped to an account")
+
+		return "", errors.Wrap(ErrUnknownIdentity, identity)
+	}
+
+	user, err := r.store.GetUser(ctx, username)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the account of the client certificate")
+	}
+
+	return user.ID.String(), nil
+}
@@ -0,0 +1,186 @@
+//nolint:exhaustruct
+package clientcert_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/npavlov/go-password-manager/internal/server/clientcert"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, clientcert.Options{}.Validate())
+	require.NoError(t, clientcert.Options{Mode: clientcert.ModeOff}.Validate())
+	require.NoError(t, clientcert.Options{Mode: clientcert.ModeRequired, CAFile: "ca.pem"}.Validate())
+	require.ErrorIs(t, clientcert.Options{Mode: clientcert.ModeOptional}.Validate(), clientcert.ErrNoClientCA)
+	require.ErrorIs(t, clientcert.Options{Mode: "always"}.Validate(), clientcert.ErrUnknownMode)
+}
+
+func TestIdentity(t *testing.T) {
+	t.Parallel()
+
+	ca := testutils.NewTestCA(t)
+
+	withSPIFFE := ca.Issue(t, "backup", "spiffe://example.org/ci/backup")
+	require.Equal(t, "spiffe://example.org/ci/backup", clientcert.Identity(withSPIFFE.Leaf))
+
+	withSubject := ca.Issue(t, "backup", "")
+	require.Equal(t, "CN=backup", clientcert.Identity(withSubject.Leaf))
+}
+
+// handshake connects a client presenting the certificates to a server with the config and returns the
+// error of the server.
+func handshake(t *testing.T, server *tls.Config, roots *x509.CertPool, certificates ...tls.Certificate) error {
+	t.Helper()
+
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+
+	serverDone := make(chan error, 1)
+
+	go func() {
+		serverDone <- tls.Server(serverConn, server).HandshakeContext(t.Context())
+
+		serverConn.Close()
+	}()
+
+	client := tls.Client(clientConn, &tls.Config{
+		RootCAs:      roots,
+		ServerName:   "localhost",
+		Certificates: certificates,
+		MinVersion:   tls.VersionTLS12,
+	})
+
+	// With TLS 1.3 the server checks the client certificate after the client is done, so the client
+	// reads until the server either rejects it or hangs up.
+	if err := client.HandshakeContext(t.Context()); err == nil {
+		_, _ = client.Read(make([]byte, 1))
+	}
+
+	return <-serverDone
+}
+
+func TestServerTLS(t *testing.T) {
+	t.Parallel()
+
+	ca := testutils.NewTestCA(t)
+	certFile, keyFile := testutils.WriteKeyPair(t, ca.Issue(t, "localhost", ""))
+	caFile := ca.WritePEM(t)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+
+	client := ca.Issue(t, "backup", "")
+	stranger := testutils.NewTestCA(t).Issue(t, "backup", "")
+
+	off, err := clientcert.ServerTLS(certFile, keyFile, clientcert.Options{Mode: clientcert.ModeOff})
+	require.NoError(t, err)
+	require.Equal(t, tls.NoClientCert, off.ClientAuth)
+	require.NoError(t, handshake(t, off, roots))
+
+	optional, err := clientcert.ServerTLS(certFile, keyFile,
+		clientcert.Options{Mode: clientcert.ModeOptional, CAFile: caFile})
+	require.NoError(t, err)
+	require.NoError(t, handshake(t, optional, roots))
+	require.NoError(t, handshake(t, optional, roots, client))
+	require.Error(t, handshake(t, optional, roots, stranger))
+
+	required, err := clientcert.ServerTLS(certFile, keyFile,
+		clientcert.Options{Mode: clientcert.ModeRequired, CAFile: caFile})
+	require.NoError(t, err)
+	require.Error(t, handshake(t, required, roots))
+	require.NoError(t, handshake(t, required, roots, client))
+
+	_, err = clientcert.ServerTLS(certFile, keyFile, clientcert.Options{Mode: clientcert.ModeRequired, CAFile: keyFile})
+	require.ErrorIs(t, err, clientcert.ErrInvalidCA)
+
+	_, err = clientcert.ServerTLS(certFile, "missing.pem", clientcert.Options{})
+	require.Error(t, err)
+}
+
+func TestLoadIdentities(t *testing.T) {
+	t.Parallel()
+
+	identities, err := clientcert.LoadIdentities("")
+	require.NoError(t, err)
+	require.Empty(t, identities)
+
+	path := filepath.Join(t.TempDir(), "identities.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"spiffe://example.org/ci": "ci-bot"}`), 0o600))
+
+	identities, err = clientcert.LoadIdentities(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"spiffe://example.org/ci": "ci-bot"}, identities)
+
+	require.NoError(t, os.WriteFile(path, []byte(`["ci-bot"]`), 0o600))
+
+	_, err = clientcert.LoadIdentities(path)
+	require.ErrorContains(t, err, "failed to parse client identities")
+
+	_, err = clientcert.LoadIdentities(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorContains(t, err, "failed to read client identities")
+}
+
+// withPeer returns a context of a call made over TLS by a client with the verified certificate.
+func withPeer(ctx context.Context, cert *x509.Certificate) context.Context {
+	state := tls.ConnectionState{}
+	if cert != nil {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	storage := testutils.NewMockDBStorage(&logger, "")
+
+	user, err := storage.RegisterUser(t.Context(), db.CreateUserParams{Username: "ci-bot"})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "identities.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"spiffe://example.org/ci": "ci-bot",
+		"CN=backup": "deleted-account"
+	}`), 0o600))
+
+	resolver, err := clientcert.NewResolver(storage, clientcert.Options{IdentitiesFile: path}, &logger)
+	require.NoError(t, err)
+
+	ca := testutils.NewTestCA(t)
+
+	userID, err := resolver.Resolve(withPeer(t.Context(), ca.Issue(t, "ci", "spiffe://example.org/ci").Leaf))
+	require.NoError(t, err)
+	require.Equal(t, user.ID.String(), userID)
+
+	_, err = resolver.Resolve(withPeer(t.Context(), ca.Issue(t, "ci", "spiffe://example.org/other").Leaf))
+	require.ErrorIs(t, err, clientcert.ErrUnknownIdentity)
+
+	_, err = resolver.Resolve(withPeer(t.Context(), ca.Issue(t, "backup", "").Leaf))
+	require.ErrorContains(t, err, "failed to get the account of the client certificate")
+
+	_, err = resolver.Resolve(withPeer(t.Context(), nil))
+	require.ErrorIs(t, err, clientcert.ErrNoCertificate)
+
+	_, err = resolver.Resolve(t.Context())
+	require.ErrorIs(t, err, clientcert.ErrNoCertificate)
+
+	_, err = clientcert.NewResolver(storage, clientcert.Options{IdentitiesFile: "missing.json"}, &logger)
+	require.Error(t, err)
+}
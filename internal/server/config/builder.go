@@ -4,26 +4,64 @@ import (
 	"flag"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/rs/zerolog"
 
+	"github.com/npavlov/go-password-manager/internal/server/clientcert"
+	"github.com/npavlov/go-password-manager/internal/server/jwtkeys"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/passhash"
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
 	"github.com/npavlov/go-password-manager/internal/utils"
 )
 
 type Config struct {
-	Address          string `env:"ADDRESS"          envDefault:":9090"`
-	Database         string `env:"DATABASE_DSN"     envDefault:""`
-	JwtSecret        string `env:"JWT_SECRET"       envDefault:""`
-	Certificate      string `env:"CERTIFICATE"      envDefault:""`
-	PrivateKey       string `env:"PRIVATE_KEY"      envDefault:""`
-	MasterKey        string `env:"MASTER_KEY"       envDefault:""`
-	Redis            string `env:"REDIS"            envDefault:"localhost:6379"`
-	Minio            string `env:"MINIO"            envDefault:""`
-	MinioAccessKey   string `env:"MINIO_ACCESS_KEY" envDefault:""`
-	MinioSecretKey   string `env:"MINIO_SECRET_KEY" envDefault:""`
-	Bucket           string `env:"BUCKET"           envDefault:"encrypted-bucket"`
-	SecuredMasterKey utils.ISecureString
+	Address            string        `env:"ADDRESS"              envDefault:":9090"`
+	Database           string        `env:"DATABASE_DSN"         envDefault:""`
+	JwtSecret          string        `env:"JWT_SECRET"           envDefault:""                    json:"-"`
+	JwtAlgorithm       string        `env:"JWT_ALGORITHM"        envDefault:"EdDSA"`
+	JwtIssuer          string        `env:"JWT_ISSUER"           envDefault:"go-password-manager"`
+	JwtAudience        string        `env:"JWT_AUDIENCE"         envDefault:"go-password-manager"`
+	JwtKeyRotation     time.Duration `env:"JWT_KEY_ROTATION"     envDefault:"720h"`
+	JwtKeyRefresh      time.Duration `env:"JWT_KEY_REFRESH"      envDefault:"1m"`
+	JWKSAddress        string        `env:"JWKS_ADDRESS"         envDefault:":9091"`
+	Certificate        string        `env:"CERTIFICATE"          envDefault:""`
+	PrivateKey         string        `env:"PRIVATE_KEY"          envDefault:""`
+	ClientAuth         string        `env:"CLIENT_AUTH"          envDefault:"off"`
+	ClientCA           string        `env:"CLIENT_CA"            envDefault:""`
+	ClientIdentities   string        `env:"CLIENT_IDENTITIES"    envDefault:""`
+	MasterKey          string        `env:"MASTER_KEY"           envDefault:""`
+	Redis              string        `env:"REDIS"                envDefault:"localhost:6379"`
+	Minio              string        `env:"MINIO"                envDefault:""`
+	MinioAccessKey     string        `env:"MINIO_ACCESS_KEY"     envDefault:""`
+	MinioSecretKey     string        `env:"MINIO_SECRET_KEY"     envDefault:""`
+	Bucket             string        `env:"BUCKET"               envDefault:"encrypted-bucket"`
+	KMS                string        `env:"KMS"                  envDefault:"local"`
+	KeystoreFile       string        `env:"KEYSTORE_FILE"        envDefault:""`
+	TransitAddress     string        `env:"TRANSIT_ADDR"         envDefault:""`
+	TransitToken       string        `env:"TRANSIT_TOKEN"        envDefault:""                    json:"-"`
+	TransitMount       string        `env:"TRANSIT_MOUNT"        envDefault:"transit"`
+	TransitKey         string        `env:"TRANSIT_KEY"          envDefault:""`
+	PKCS11Module       string        `env:"PKCS11_MODULE"        envDefault:""`
+	PKCS11Params       string        `env:"PKCS11_PARAMS"        envDefault:""                    json:"-"`
+	PKCS11KeyLabel     string        `env:"PKCS11_KEY_LABEL"     envDefault:""`
+	LoginMaxFailures   int           `env:"LOGIN_MAX_FAILURES"   envDefault:"5"`
+	LoginFailureWindow time.Duration `env:"LOGIN_FAILURE_WINDOW" envDefault:"15m"`
+	LoginBackoff       time.Duration `env:"LOGIN_BACKOFF"        envDefault:"1s"`
+	LoginLockout       time.Duration `env:"LOGIN_LOCKOUT"        envDefault:"15m"`
+	LoginIPAttempts    int           `env:"LOGIN_IP_ATTEMPTS"    envDefault:"20"`
+	LoginIPWindow      time.Duration `env:"LOGIN_IP_WINDOW"      envDefault:"1m"`
+	PasswordMemory     uint32        `env:"PASSWORD_MEMORY"      envDefault:"65536"`
+	PasswordTime       uint32        `env:"PASSWORD_TIME"        envDefault:"3"`
+	PasswordThreads    uint8         `env:"PASSWORD_THREADS"     envDefault:"4"`
+	EmergencyCheck     time.Duration `env:"EMERGENCY_CHECK"      envDefault:"1m"`
+	SendPurge          time.Duration `env:"SEND_PURGE"           envDefault:"10m"`
+	AuditAdmins        []string      `env:"AUDIT_ADMINS"         envDefault:""                    envSeparator:","`
+	SecuredMasterKey   utils.ISecureString
+	// KeyProvider wraps user keys; set from the KMS settings on startup, see Keys.
+	KeyProvider kms.KeyProvider `json:"-"`
 }
 
 // Builder defines the builder for the Config struct.
@@ -37,18 +75,49 @@ type Builder struct {
 func NewConfigBuilder(log *zerolog.Logger) *Builder {
 	return &Builder{
 		cfg: &Config{
-			Address:          "",
-			Database:         "",
-			JwtSecret:        "",
-			Certificate:      "",
-			PrivateKey:       "",
-			Redis:            "",
-			MasterKey:        "",
-			Bucket:           "",
-			Minio:            "",
-			MinioAccessKey:   "",
-			MinioSecretKey:   "",
-			SecuredMasterKey: nil,
+			Address:            "",
+			Database:           "",
+			JwtSecret:          "",
+			JwtAlgorithm:       "",
+			JwtIssuer:          "",
+			JwtAudience:        "",
+			JwtKeyRotation:     0,
+			JwtKeyRefresh:      0,
+			JWKSAddress:        "",
+			Certificate:        "",
+			PrivateKey:         "",
+			ClientAuth:         "",
+			ClientCA:           "",
+			ClientIdentities:   "",
+			Redis:              "",
+			MasterKey:          "",
+			Bucket:             "",
+			Minio:              "",
+			MinioAccessKey:     "",
+			MinioSecretKey:     "",
+			KMS:                "",
+			KeystoreFile:       "",
+			TransitAddress:     "",
+			TransitToken:       "",
+			TransitMount:       "",
+			TransitKey:         "",
+			PKCS11Module:       "",
+			PKCS11Params:       "",
+			PKCS11KeyLabel:     "",
+			LoginMaxFailures:   0,
+			LoginFailureWindow: 0,
+			LoginBackoff:       0,
+			LoginLockout:       0,
+			LoginIPAttempts:    0,
+			LoginIPWindow:      0,
+			PasswordMemory:     0,
+			PasswordTime:       0,
+			PasswordThreads:    0,
+			EmergencyCheck:     0,
+			SendPurge:          0,
+			AuditAdmins:        nil,
+			SecuredMasterKey:   nil,
+			KeyProvider:        nil,
 		},
 		logger: log,
 		mu:     sync.RWMutex{},
@@ -72,15 +141,25 @@ func (b *Builder) FromFlags() *Builder {
 
 	fs.StringVar(&b.cfg.Address, "a", b.cfg.Address, "address and port to run server")
 	fs.StringVar(&b.cfg.Database, "d", b.cfg.Database, "database DSN")
-	fs.StringVar(&b.cfg.JwtSecret, "jwt", b.cfg.JwtSecret, "JWT Secret")
+	fs.StringVar(&b.cfg.JwtSecret, "jwt", b.cfg.JwtSecret, "JWT Secret verifying tokens of earlier versions")
+	fs.StringVar(&b.cfg.JwtAlgorithm, "jwt_alg", b.cfg.JwtAlgorithm, "JWT signing algorithm: EdDSA or ES256")
+	fs.StringVar(&b.cfg.JWKSAddress, "jwks", b.cfg.JWKSAddress, "address and port to serve the JWKS")
 	fs.StringVar(&b.cfg.Certificate, "cert", b.cfg.Certificate, "Certificate")
 	fs.StringVar(&b.cfg.PrivateKey, "privatekey", b.cfg.PrivateKey, "Private Key for http connection")
+	fs.StringVar(&b.cfg.ClientAuth, "client_auth", b.cfg.ClientAuth, "Client certificates: off, optional or required")
+	fs.StringVar(&b.cfg.ClientCA, "client_ca", b.cfg.ClientCA, "CA verifying client certificates")
+	fs.StringVar(&b.cfg.ClientIdentities, "client_identities", b.cfg.ClientIdentities,
+		"JSON file mapping client certificate identities to usernames")
 	fs.StringVar(&b.cfg.Redis, "redis", b.cfg.Redis, "Redis connection string")
 	fs.StringVar(&b.cfg.MasterKey, "masterkey", b.cfg.MasterKey, "Master Key for encrypting data")
 	fs.StringVar(&b.cfg.Minio, "minio", b.cfg.Minio, "Minio address")
 	fs.StringVar(&b.cfg.MinioAccessKey, "minio_access_key", b.cfg.MinioAccessKey, "Minio access key")
 	fs.StringVar(&b.cfg.MinioSecretKey, "minio_secret_key", b.cfg.MinioSecretKey, "Minio secret key")
 	fs.StringVar(&b.cfg.Bucket, "bucket", b.cfg.Bucket, "Bucket name for Minio")
+	fs.StringVar(&b.cfg.KMS, "kms", b.cfg.KMS, "Key provider: local, file, transit or pkcs11")
+	fs.StringVar(&b.cfg.KeystoreFile, "keystore", b.cfg.KeystoreFile, "Keystore file of the file key provider")
+	fs.StringVar(&b.cfg.TransitAddress, "transit_addr", b.cfg.TransitAddress, "Transit server address")
+	fs.StringVar(&b.cfg.TransitKey, "transit_key", b.cfg.TransitKey, "Transit key name")
 	_ = fs.Parse(os.Args[1:])
 
 	return b
@@ -100,3 +179,77 @@ func (b *Builder) Build() *Config {
 
 	return b.cfg
 }
+
+// KMSOptions returns the settings of the key provider.
+func (c *Config) KMSOptions() kms.Options {
+	return kms.Options{
+		Provider:     c.KMS,
+		MasterKey:    c.SecuredMasterKey,
+		KeystoreFile: c.KeystoreFile,
+		Transit: kms.TransitOptions{
+			Address: c.TransitAddress,
+			Token:   c.TransitToken,
+			Mount:   c.TransitMount,
+			KeyName: c.TransitKey,
+			Client:  nil,
+		},
+		PKCS11Module:   c.PKCS11Module,
+		PKCS11Params:   c.PKCS11Params,
+		PKCS11KeyLabel: c.PKCS11KeyLabel,
+	}
+}
+
+// LoginLimits returns the limits on failed logins.
+func (c *Config) LoginLimits() ratelimit.Limits {
+	return ratelimit.Limits{
+		MaxFailures:   c.LoginMaxFailures,
+		FailureWindow: c.LoginFailureWindow,
+		Backoff:       c.LoginBackoff,
+		Lockout:       c.LoginLockout,
+		IPAttempts:    c.LoginIPAttempts,
+		IPWindow:      c.LoginIPWindow,
+	}
+}
+
+// PasswordParams returns the Argon2id parameters of new password hashes.
+func (c *Config) PasswordParams() passhash.Params {
+	return passhash.Params{
+		Memory:     c.PasswordMemory,
+		Time:       c.PasswordTime,
+		Threads:    c.PasswordThreads,
+		SaltLength: 0,
+		KeyLength:  0,
+	}
+}
+
+// TokenOptions returns the settings of the access token signing keys. The retention depends on the
+// token lifetime, which the caller sets.
+func (c *Config) TokenOptions() jwtkeys.Options {
+	return jwtkeys.Options{
+		Algorithm:    c.JwtAlgorithm,
+		Issuer:       c.JwtIssuer,
+		Audience:     c.JwtAudience,
+		Rotation:     c.JwtKeyRotation,
+		Refresh:      c.JwtKeyRefresh,
+		Retention:    0,
+		LegacySecret: c.JwtSecret,
+	}
+}
+
+// ClientCertOptions returns the settings of client certificate authentication.
+func (c *Config) ClientCertOptions() clientcert.Options {
+	return clientcert.Options{
+		Mode:           c.ClientAuth,
+		CAFile:         c.ClientCA,
+		IdentitiesFile: c.ClientIdentities,
+	}
+}
+
+// Keys returns the provider wrapping user keys, the MASTER_KEY keyring when none was set up.
+func (c *Config) Keys() kms.KeyProvider {
+	if c.KeyProvider != nil {
+		return c.KeyProvider
+	}
+
+	return kms.NewLocalProvider(c.SecuredMasterKey)
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/stretchr/testify/assert"
@@ -55,3 +56,63 @@ func TestFromFlags(t *testing.T) {
 	// Verify that flags were correctly parsed into the config
 	assert.Equal(t, "localhost:8091", cfg.Address, "Address should be set by flag")
 }
+
+// TestLoginLimits checks the login limits are read from the environment.
+func TestLoginLimits(t *testing.T) {
+	t.Setenv("LOGIN_MAX_FAILURES", "3")
+	t.Setenv("LOGIN_LOCKOUT", "1h")
+
+	limits := config.NewConfigBuilder(testutils.GetTLogger()).FromEnv().Build().LoginLimits()
+
+	assert.Equal(t, 3, limits.MaxFailures)
+	assert.Equal(t, time.Hour, limits.Lockout)
+	assert.Equal(t, 15*time.Minute, limits.FailureWindow)
+	assert.Equal(t, time.Second, limits.Backoff)
+	assert.Equal(t, 20, limits.IPAttempts)
+	assert.Equal(t, time.Minute, limits.IPWindow)
+}
+
+// TestTokenOptions checks the token signing settings are read from the environment.
+func TestTokenOptions(t *testing.T) {
+	t.Setenv("JWT_ALGORITHM", "ES256")
+	t.Setenv("JWT_SECRET", "old-secret")
+
+	opts := config.NewConfigBuilder(testutils.GetTLogger()).FromEnv().Build().TokenOptions()
+
+	assert.Equal(t, "ES256", opts.Algorithm)
+	assert.Equal(t, "go-password-manager", opts.Issuer)
+	assert.Equal(t, "go-password-manager", opts.Audience)
+	assert.Equal(t, 720*time.Hour, opts.Rotation)
+	assert.Equal(t, time.Minute, opts.Refresh)
+	assert.Equal(t, "old-secret", opts.LegacySecret)
+	require.NoError(t, opts.Validate())
+}
+
+// TestPasswordParams checks the password hashing parameters are read from the environment.
+func TestPasswordParams(t *testing.T) {
+	t.Setenv("PASSWORD_TIME", "4")
+
+	params := config.NewConfigBuilder(testutils.GetTLogger()).FromEnv().Build().PasswordParams()
+
+	assert.Equal(t, uint32(64*1024), params.Memory)
+	assert.Equal(t, uint32(4), params.Time)
+	assert.Equal(t, uint8(4), params.Threads)
+}
+
+// TestClientCertOptions checks client certificates are off unless configured.
+func TestClientCertOptions(t *testing.T) {
+	opts := config.NewConfigBuilder(testutils.GetTLogger()).FromEnv().Build().ClientCertOptions()
+	assert.Equal(t, "off", opts.Mode)
+	require.NoError(t, opts.Validate())
+
+	t.Setenv("CLIENT_AUTH", "required")
+	t.Setenv("CLIENT_CA", "certs/clients.pem")
+	t.Setenv("CLIENT_IDENTITIES", "certs/identities.json")
+
+	opts = config.NewConfigBuilder(testutils.GetTLogger()).FromEnv().Build().ClientCertOptions()
+
+	assert.Equal(t, "required", opts.Mode)
+	assert.Equal(t, "certs/clients.pem", opts.CAFile)
+	assert.Equal(t, "certs/identities.json", opts.IdentitiesFile)
+	require.NoError(t, opts.Validate())
+}
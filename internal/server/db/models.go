@@ -11,6 +11,91 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type EmergencyAccessStatus string
+
+const (
+	EmergencyAccessStatusIdle      EmergencyAccessStatus = "idle"
+	EmergencyAccessStatusRequested EmergencyAccessStatus = "requested"
+	EmergencyAccessStatusApproved  EmergencyAccessStatus = "approved"
+)
+
+func (e *EmergencyAccessStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmergencyAccessStatus(s)
+	case string:
+		*e = EmergencyAccessStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmergencyAccessStatus: %T", src)
+	}
+	return nil
+}
+
+type NullEmergencyAccessStatus struct {
+	EmergencyAccessStatus EmergencyAccessStatus
+	Valid                 bool // Valid is true if EmergencyAccessStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmergencyAccessStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmergencyAccessStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmergencyAccessStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmergencyAccessStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmergencyAccessStatus), nil
+}
+
+type EmergencyAccessType string
+
+const (
+	EmergencyAccessTypeView     EmergencyAccessType = "view"
+	EmergencyAccessTypeTakeover EmergencyAccessType = "takeover"
+)
+
+func (e *EmergencyAccessType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmergencyAccessType(s)
+	case string:
+		*e = EmergencyAccessType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmergencyAccessType: %T", src)
+	}
+	return nil
+}
+
+type NullEmergencyAccessType struct {
+	EmergencyAccessType EmergencyAccessType
+	Valid               bool // Valid is true if EmergencyAccessType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmergencyAccessType) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmergencyAccessType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmergencyAccessType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmergencyAccessType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmergencyAccessType), nil
+}
+
 type ItemType string
 
 const (
@@ -55,14 +140,171 @@ func (ns NullItemType) Value() (driver.Value, error) {
 	return string(ns.ItemType), nil
 }
 
+type KeyRotationStatus string
+
+const (
+	KeyRotationStatusRunning   KeyRotationStatus = "running"
+	KeyRotationStatusCompleted KeyRotationStatus = "completed"
+	KeyRotationStatusFailed    KeyRotationStatus = "failed"
+)
+
+func (e *KeyRotationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = KeyRotationStatus(s)
+	case string:
+		*e = KeyRotationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for KeyRotationStatus: %T", src)
+	}
+	return nil
+}
+
+type NullKeyRotationStatus struct {
+	KeyRotationStatus KeyRotationStatus
+	Valid             bool // Valid is true if KeyRotationStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullKeyRotationStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.KeyRotationStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.KeyRotationStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullKeyRotationStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.KeyRotationStatus), nil
+}
+
+type OrgRole string
+
+const (
+	OrgRoleOwner    OrgRole = "owner"
+	OrgRoleAdmin    OrgRole = "admin"
+	OrgRoleMember   OrgRole = "member"
+	OrgRoleReadOnly OrgRole = "read_only"
+)
+
+func (e *OrgRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OrgRole(s)
+	case string:
+		*e = OrgRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OrgRole: %T", src)
+	}
+	return nil
+}
+
+type NullOrgRole struct {
+	OrgRole OrgRole
+	Valid   bool // Valid is true if OrgRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOrgRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.OrgRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OrgRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOrgRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OrgRole), nil
+}
+
+type SendType string
+
+const (
+	SendTypeText SendType = "text"
+	SendTypeFile SendType = "file"
+)
+
+func (e *SendType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SendType(s)
+	case string:
+		*e = SendType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SendType: %T", src)
+	}
+	return nil
+}
+
+type NullSendType struct {
+	SendType SendType
+	Valid    bool // Valid is true if SendType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSendType) Scan(value interface{}) error {
+	if value == nil {
+		ns.SendType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SendType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSendType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SendType), nil
+}
+
+type ApiToken struct {
+	ID         pgtype.UUID      `db:"id"`
+	UserID     pgtype.UUID      `db:"user_id"`
+	Name       string           `db:"name"`
+	TokenHash  string           `db:"token_hash"`
+	ReadOnly   bool             `db:"read_only"`
+	ItemTypes  []string         `db:"item_types"`
+	Tags       []byte           `db:"tags"`
+	CreatedAt  pgtype.Timestamp `db:"created_at"`
+	ExpiresAt  pgtype.Timestamp `db:"expires_at"`
+	LastUsedAt pgtype.Timestamp `db:"last_used_at"`
+}
+
+type AuditEvent struct {
+	Seq        int64            `db:"seq"`
+	ActorID    pgtype.UUID      `db:"actor_id"`
+	ItemID     pgtype.UUID      `db:"item_id"`
+	Action     string           `db:"action"`
+	Ip         string           `db:"ip"`
+	UserAgent  string           `db:"user_agent"`
+	Result     string           `db:"result"`
+	OccurredAt pgtype.Timestamp `db:"occurred_at"`
+	PrevHash   string           `db:"prev_hash"`
+	Hash       string           `db:"hash"`
+}
+
 type BinaryEntry struct {
-	ID        pgtype.UUID      `db:"id"`
-	UserID    pgtype.UUID      `db:"user_id"`
-	FileName  string           `db:"file_name"`
-	FileSize  int64            `db:"file_size"`
-	FileUrl   string           `db:"file_url"`
-	CreatedAt pgtype.Timestamp `db:"created_at"`
-	UpdatedAt pgtype.Timestamp `db:"updated_at"`
+	ID         pgtype.UUID      `db:"id"`
+	UserID     pgtype.UUID      `db:"user_id"`
+	FileName   string           `db:"file_name"`
+	FileSize   int64            `db:"file_size"`
+	FileUrl    string           `db:"file_url"`
+	CreatedAt  pgtype.Timestamp `db:"created_at"`
+	UpdatedAt  pgtype.Timestamp `db:"updated_at"`
+	Version    int64            `db:"version"`
+	KeyVersion int32            `db:"key_version"`
 }
 
 type Card struct {
@@ -75,6 +317,41 @@ type Card struct {
 	CreatedAt           pgtype.Timestamp `db:"created_at"`
 	UpdatedAt           pgtype.Timestamp `db:"updated_at"`
 	HashedCardNumber    pgtype.Text      `db:"hashed_card_number"`
+	Version             int64            `db:"version"`
+	KeyVersion          int32            `db:"key_version"`
+}
+
+type Collection struct {
+	ID        pgtype.UUID      `db:"id"`
+	OrgID     pgtype.UUID      `db:"org_id"`
+	Name      string           `db:"name"`
+	CreatedAt pgtype.Timestamp `db:"created_at"`
+}
+
+type CollectionItem struct {
+	ItemID       pgtype.UUID      `db:"item_id"`
+	CollectionID pgtype.UUID      `db:"collection_id"`
+	CreatedAt    pgtype.Timestamp `db:"created_at"`
+}
+
+type CollectionMember struct {
+	CollectionID pgtype.UUID      `db:"collection_id"`
+	OrgID        pgtype.UUID      `db:"org_id"`
+	UserID       pgtype.UUID      `db:"user_id"`
+	ReadOnly     bool             `db:"read_only"`
+	CreatedAt    pgtype.Timestamp `db:"created_at"`
+}
+
+type EmergencyAccess struct {
+	ID          pgtype.UUID           `db:"id"`
+	GrantorID   pgtype.UUID           `db:"grantor_id"`
+	GranteeID   pgtype.UUID           `db:"grantee_id"`
+	AccessType  EmergencyAccessType   `db:"access_type"`
+	WaitDays    int32                 `db:"wait_days"`
+	Status      EmergencyAccessStatus `db:"status"`
+	RequestedAt pgtype.Timestamp      `db:"requested_at"`
+	ApprovedAt  pgtype.Timestamp      `db:"approved_at"`
+	CreatedAt   pgtype.Timestamp      `db:"created_at"`
 }
 
 type Item struct {
@@ -85,6 +362,29 @@ type Item struct {
 	CreatedAt  pgtype.Timestamp `db:"created_at"`
 }
 
+type ItemChange struct {
+	UserID    pgtype.UUID      `db:"user_id"`
+	ItemID    pgtype.UUID      `db:"item_id"`
+	Type      ItemType         `db:"type"`
+	Seq       int64            `db:"seq"`
+	Deleted   bool             `db:"deleted"`
+	ChangedAt pgtype.Timestamp `db:"changed_at"`
+}
+
+type KeyRotation struct {
+	ID          pgtype.UUID       `db:"id"`
+	UserID      pgtype.UUID       `db:"user_id"`
+	FromVersion int32             `db:"from_version"`
+	ToVersion   int32             `db:"to_version"`
+	Status      KeyRotationStatus `db:"status"`
+	TotalItems  int64             `db:"total_items"`
+	DoneItems   int64             `db:"done_items"`
+	Error       pgtype.Text       `db:"error"`
+	StartedAt   pgtype.Timestamp  `db:"started_at"`
+	FinishedAt  pgtype.Timestamp  `db:"finished_at"`
+	BindsValues bool              `db:"binds_values"`
+}
+
 type Metainfo struct {
 	ID        pgtype.UUID      `db:"id"`
 	ItemID    pgtype.UUID      `db:"item_id"`
@@ -100,29 +400,119 @@ type Note struct {
 	EncryptedContent string           `db:"encrypted_content"`
 	CreatedAt        pgtype.Timestamp `db:"created_at"`
 	UpdatedAt        pgtype.Timestamp `db:"updated_at"`
+	Version          int64            `db:"version"`
+	KeyVersion       int32            `db:"key_version"`
+}
+
+type OrgMember struct {
+	OrgID     pgtype.UUID      `db:"org_id"`
+	UserID    pgtype.UUID      `db:"user_id"`
+	Role      OrgRole          `db:"role"`
+	CreatedAt pgtype.Timestamp `db:"created_at"`
+}
+
+type Organization struct {
+	ID        pgtype.UUID      `db:"id"`
+	Name      string           `db:"name"`
+	VaultID   pgtype.UUID      `db:"vault_id"`
+	CreatedAt pgtype.Timestamp `db:"created_at"`
 }
 
 type Password struct {
+	ID         pgtype.UUID      `db:"id"`
+	UserID     pgtype.UUID      `db:"user_id"`
+	Login      string           `db:"login"`
+	Password   string           `db:"password"`
+	CreatedAt  pgtype.Timestamp `db:"created_at"`
+	UpdatedAt  pgtype.Timestamp `db:"updated_at"`
+	Version    int64            `db:"version"`
+	KeyVersion int32            `db:"key_version"`
+}
+
+type RecoveryCode struct {
 	ID        pgtype.UUID      `db:"id"`
 	UserID    pgtype.UUID      `db:"user_id"`
-	Login     string           `db:"login"`
-	Password  string           `db:"password"`
+	CodeHash  string           `db:"code_hash"`
+	UsedAt    pgtype.Timestamp `db:"used_at"`
 	CreatedAt pgtype.Timestamp `db:"created_at"`
-	UpdatedAt pgtype.Timestamp `db:"updated_at"`
 }
 
 type RefreshToken struct {
 	ID        pgtype.UUID      `db:"id"`
 	UserID    pgtype.UUID      `db:"user_id"`
-	Token     string           `db:"token"`
 	ExpiresAt pgtype.Timestamp `db:"expires_at"`
 	CreatedAt pgtype.Timestamp `db:"created_at"`
+	SessionID pgtype.UUID      `db:"session_id"`
+	TokenHash string           `db:"token_hash"`
+	UsedAt    pgtype.Timestamp `db:"used_at"`
+}
+
+type Send struct {
+	ID         pgtype.UUID      `db:"id"`
+	UserID     pgtype.UUID      `db:"user_id"`
+	SendType   SendType         `db:"send_type"`
+	Content    string           `db:"content"`
+	ObjectName pgtype.Text      `db:"object_name"`
+	FileSize   int64            `db:"file_size"`
+	Password   pgtype.Text      `db:"password"`
+	MaxViews   int32            `db:"max_views"`
+	Views      int32            `db:"views"`
+	ExpiresAt  pgtype.Timestamp `db:"expires_at"`
+	CreatedAt  pgtype.Timestamp `db:"created_at"`
+}
+
+type Session struct {
+	ID         pgtype.UUID      `db:"id"`
+	UserID     pgtype.UUID      `db:"user_id"`
+	DeviceName string           `db:"device_name"`
+	IpAddress  string           `db:"ip_address"`
+	CreatedAt  pgtype.Timestamp `db:"created_at"`
+	LastUsedAt pgtype.Timestamp `db:"last_used_at"`
+	ExpiresAt  pgtype.Timestamp `db:"expires_at"`
+}
+
+type Share struct {
+	ID          pgtype.UUID      `db:"id"`
+	ItemID      pgtype.UUID      `db:"item_id"`
+	OwnerID     pgtype.UUID      `db:"owner_id"`
+	RecipientID pgtype.UUID      `db:"recipient_id"`
+	ReadOnly    bool             `db:"read_only"`
+	Payload     string           `db:"payload"`
+	ItemSeq     int64            `db:"item_seq"`
+	CreatedAt   pgtype.Timestamp `db:"created_at"`
+}
+
+type ShareKey struct {
+	UserID     pgtype.UUID      `db:"user_id"`
+	PublicKey  string           `db:"public_key"`
+	PrivateKey string           `db:"private_key"`
+	KeyVersion int32            `db:"key_version"`
+	CreatedAt  pgtype.Timestamp `db:"created_at"`
+}
+
+type SigningKey struct {
+	ID          string           `db:"id"`
+	Algorithm   string           `db:"algorithm"`
+	PrivateKey  string           `db:"private_key"`
+	PublicKey   string           `db:"public_key"`
+	CreatedAt   pgtype.Timestamp `db:"created_at"`
+	ActivatesAt pgtype.Timestamp `db:"activates_at"`
 }
 
 type User struct {
-	ID            pgtype.UUID `db:"id"`
-	Username      string      `db:"username"`
-	Email         string      `db:"email"`
-	Password      string      `db:"password"`
-	EncryptionKey string      `db:"encryption_key"`
+	ID                    pgtype.UUID `db:"id"`
+	Username              string      `db:"username"`
+	Email                 string      `db:"email"`
+	Password              string      `db:"password"`
+	EncryptionKey         string      `db:"encryption_key"`
+	ChangeSeq             int64       `db:"change_seq"`
+	KdfSalt               pgtype.Text `db:"kdf_salt"`
+	WrappedVaultKey       pgtype.Text `db:"wrapped_vault_key"`
+	KeyVersion            int32       `db:"key_version"`
+	PreviousEncryptionKey pgtype.Text `db:"previous_encryption_key"`
+	LegacyCiphertexts     bool        `db:"legacy_ciphertexts"`
+	TotpSecret            pgtype.Text `db:"totp_secret"`
+	TotpEnabled           bool        `db:"totp_enabled"`
+	TotpLastStep          int64       `db:"totp_last_step"`
+	OrganizationVault     bool        `db:"organization_vault"`
 }
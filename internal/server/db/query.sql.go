@@ -11,6 +11,21 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const AddCollectionItem = `-- name: AddCollectionItem :exec
+INSERT INTO collection_items (item_id, collection_id)
+VALUES ($1, $2)
+`
+
+type AddCollectionItemParams struct {
+	ItemID       pgtype.UUID `db:"item_id"`
+	CollectionID pgtype.UUID `db:"collection_id"`
+}
+
+func (q *Queries) AddCollectionItem(ctx context.Context, arg AddCollectionItemParams) error {
+	_, err := q.db.Exec(ctx, AddCollectionItem, arg.ItemID, arg.CollectionID)
+	return err
+}
+
 const AddMetaInfo = `-- name: AddMetaInfo :one
 INSERT INTO metainfo (item_id, key, value)
 VALUES ($1, $2, $3)
@@ -38,19 +53,241 @@ func (q *Queries) AddMetaInfo(ctx context.Context, arg AddMetaInfoParams) (Metai
 	return i, err
 }
 
-const CreateNoteEntry = `-- name: CreateNoteEntry :one
-INSERT INTO notes (user_id, encrypted_content)
+const ApproveEmergencyAccess = `-- name: ApproveEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'approved', approved_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND grantor_id = $2 AND status = 'requested'
+`
+
+type ApproveEmergencyAccessParams struct {
+	ID        pgtype.UUID `db:"id"`
+	GrantorID pgtype.UUID `db:"grantor_id"`
+}
+
+func (q *Queries) ApproveEmergencyAccess(ctx context.Context, arg ApproveEmergencyAccessParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ApproveEmergencyAccess, arg.ID, arg.GrantorID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const ChangeUserPassword = `-- name: ChangeUserPassword :execrows
+UPDATE users
+SET password = $1, kdf_salt = $2, wrapped_vault_key = $3
+WHERE id = $4 AND password = $5
+`
+
+type ChangeUserPasswordParams struct {
+	NewPassword     string      `db:"new_password"`
+	KdfSalt         pgtype.Text `db:"kdf_salt"`
+	WrappedVaultKey pgtype.Text `db:"wrapped_vault_key"`
+	ID              pgtype.UUID `db:"id"`
+	OldPassword     string      `db:"old_password"`
+}
+
+func (q *Queries) ChangeUserPassword(ctx context.Context, arg ChangeUserPasswordParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ChangeUserPassword,
+		arg.NewPassword,
+		arg.KdfSalt,
+		arg.WrappedVaultKey,
+		arg.ID,
+		arg.OldPassword,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const CountBinaryEntriesByUserID = `-- name: CountBinaryEntriesByUserID :one
+SELECT COUNT(*) FROM binary_entries WHERE user_id = $1
+`
+
+func (q *Queries) CountBinaryEntriesByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, CountBinaryEntriesByUserID, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountCardsByUserID = `-- name: CountCardsByUserID :one
+SELECT COUNT(*) FROM cards WHERE user_id = $1
+`
+
+func (q *Queries) CountCardsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, CountCardsByUserID, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountCollections = `-- name: CountCollections :one
+SELECT COUNT(*) FROM collections WHERE org_id = $1
+`
+
+func (q *Queries) CountCollections(ctx context.Context, orgID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, CountCollections, orgID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountNotesByUserID = `-- name: CountNotesByUserID :one
+SELECT COUNT(*) FROM notes WHERE user_id = $1
+`
+
+func (q *Queries) CountNotesByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, CountNotesByUserID, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountOrgOwners = `-- name: CountOrgOwners :one
+SELECT COUNT(*) FROM org_members
+WHERE org_id = $1 AND role = 'owner'
+`
+
+func (q *Queries) CountOrgOwners(ctx context.Context, orgID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, CountOrgOwners, orgID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountPasswordEntriesByUserID = `-- name: CountPasswordEntriesByUserID :one
+SELECT COUNT(*) FROM passwords WHERE user_id = $1
+`
+
+func (q *Queries) CountPasswordEntriesByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, CountPasswordEntriesByUserID, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountSoleOwnerships = `-- name: CountSoleOwnerships :one
+SELECT COUNT(*) FROM org_members m
+WHERE m.user_id = $1 AND m.role = 'owner'
+  AND NOT EXISTS (
+    SELECT 1 FROM org_members o
+    WHERE o.org_id = m.org_id AND o.role = 'owner' AND o.user_id <> m.user_id
+)
+`
+
+func (q *Queries) CountSoleOwnerships(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, CountSoleOwnerships, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountStaleItems = `-- name: CountStaleItems :one
+SELECT ((SELECT COUNT(*) FROM passwords p WHERE p.user_id = $1 AND p.key_version < $2)
+    + (SELECT COUNT(*) FROM notes n WHERE n.user_id = $1 AND n.key_version < $2)
+    + (SELECT COUNT(*) FROM cards c WHERE c.user_id = $1 AND c.key_version < $2)
+    + (SELECT COUNT(*) FROM binary_entries b WHERE b.user_id = $1 AND b.key_version < $2)
+    + (SELECT COUNT(*) FROM share_keys k WHERE k.user_id = $1 AND k.key_version < $2))::bigint AS remaining
+`
+
+type CountStaleItemsParams struct {
+	UserID     pgtype.UUID `db:"user_id"`
+	KeyVersion int32       `db:"key_version"`
+}
+
+func (q *Queries) CountStaleItems(ctx context.Context, arg CountStaleItemsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, CountStaleItems, arg.UserID, arg.KeyVersion)
+	var remaining int64
+	err := row.Scan(&remaining)
+	return remaining, err
+}
+
+const CreateAPIToken = `-- name: CreateAPIToken :one
+INSERT INTO api_tokens (user_id, name, token_hash, read_only, item_types, tags, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, name, token_hash, read_only, item_types, tags, created_at, expires_at, last_used_at
+`
+
+type CreateAPITokenParams struct {
+	UserID    pgtype.UUID      `db:"user_id"`
+	Name      string           `db:"name"`
+	TokenHash string           `db:"token_hash"`
+	ReadOnly  bool             `db:"read_only"`
+	ItemTypes []string         `db:"item_types"`
+	Tags      []byte           `db:"tags"`
+	ExpiresAt pgtype.Timestamp `db:"expires_at"`
+}
+
+func (q *Queries) CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) (ApiToken, error) {
+	row := q.db.QueryRow(ctx, CreateAPIToken,
+		arg.UserID,
+		arg.Name,
+		arg.TokenHash,
+		arg.ReadOnly,
+		arg.ItemTypes,
+		arg.Tags,
+		arg.ExpiresAt,
+	)
+	var i ApiToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.TokenHash,
+		&i.ReadOnly,
+		&i.ItemTypes,
+		&i.Tags,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+	)
+	return i, err
+}
+
+const CreateCollection = `-- name: CreateCollection :one
+INSERT INTO collections (org_id, name)
 VALUES ($1, $2)
-    RETURNING id, user_id, encrypted_content, created_at, updated_at
+RETURNING id, org_id, name, created_at
+`
+
+type CreateCollectionParams struct {
+	OrgID pgtype.UUID `db:"org_id"`
+	Name  string      `db:"name"`
+}
+
+func (q *Queries) CreateCollection(ctx context.Context, arg CreateCollectionParams) (Collection, error) {
+	row := q.db.QueryRow(ctx, CreateCollection, arg.OrgID, arg.Name)
+	var i Collection
+	err := row.Scan(
+		&i.ID,
+		&i.OrgID,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const CreateNoteEntry = `-- name: CreateNoteEntry :one
+INSERT INTO notes (id, user_id, encrypted_content, key_version)
+VALUES ($1, $2, $3, $4)
+    RETURNING id, user_id, encrypted_content, created_at, updated_at, version, key_version
 `
 
 type CreateNoteEntryParams struct {
+	ID               pgtype.UUID `db:"id"`
 	UserID           pgtype.UUID `db:"user_id"`
 	EncryptedContent string      `db:"encrypted_content"`
+	KeyVersion       int32       `db:"key_version"`
 }
 
 func (q *Queries) CreateNoteEntry(ctx context.Context, arg CreateNoteEntryParams) (Note, error) {
-	row := q.db.QueryRow(ctx, CreateNoteEntry, arg.UserID, arg.EncryptedContent)
+	row := q.db.QueryRow(ctx, CreateNoteEntry,
+		arg.ID,
+		arg.UserID,
+		arg.EncryptedContent,
+		arg.KeyVersion,
+	)
 	var i Note
 	err := row.Scan(
 		&i.ID,
@@ -58,24 +295,77 @@ func (q *Queries) CreateNoteEntry(ctx context.Context, arg CreateNoteEntryParams
 		&i.EncryptedContent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.KeyVersion,
+	)
+	return i, err
+}
+
+const CreateOrganization = `-- name: CreateOrganization :one
+WITH vault AS (
+    INSERT INTO users (username, email, password, encryption_key, organization_vault)
+    SELECT 'org/' || vault_name, 'org/' || vault_name, '', $1, true
+    FROM (SELECT gen_random_uuid()::text AS vault_name) AS names
+    RETURNING users.id
+), org AS (
+    INSERT INTO organizations (name, vault_id)
+    SELECT $2, vault.id FROM vault
+    RETURNING id, name, vault_id, created_at
+), owner AS (
+    INSERT INTO org_members (org_id, user_id, role)
+    SELECT org.id, $3, 'owner' FROM org
+)
+SELECT id, name, vault_id, created_at FROM org
+`
+
+type CreateOrganizationParams struct {
+	EncryptionKey string      `db:"encryption_key"`
+	Name          string      `db:"name"`
+	OwnerID       pgtype.UUID `db:"owner_id"`
+}
+
+type CreateOrganizationRow struct {
+	ID        pgtype.UUID      `db:"id"`
+	Name      string           `db:"name"`
+	VaultID   pgtype.UUID      `db:"vault_id"`
+	CreatedAt pgtype.Timestamp `db:"created_at"`
+}
+
+// The vault of an organization is a users row without a password, so it cannot sign in
+func (q *Queries) CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (CreateOrganizationRow, error) {
+	row := q.db.QueryRow(ctx, CreateOrganization, arg.EncryptionKey, arg.Name, arg.OwnerID)
+	var i CreateOrganizationRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.VaultID,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
 const CreatePasswordEntry = `-- name: CreatePasswordEntry :one
-INSERT INTO passwords (user_id, login, password)
-VALUES ($1, $2, $3)
-RETURNING id, user_id, login, password, created_at, updated_at
+INSERT INTO passwords (id, user_id, login, password, key_version)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, login, password, created_at, updated_at, version, key_version
 `
 
 type CreatePasswordEntryParams struct {
-	UserID   pgtype.UUID `db:"user_id"`
-	Login    string      `db:"login"`
-	Password string      `db:"password"`
+	ID         pgtype.UUID `db:"id"`
+	UserID     pgtype.UUID `db:"user_id"`
+	Login      string      `db:"login"`
+	Password   string      `db:"password"`
+	KeyVersion int32       `db:"key_version"`
 }
 
 func (q *Queries) CreatePasswordEntry(ctx context.Context, arg CreatePasswordEntryParams) (Password, error) {
-	row := q.db.QueryRow(ctx, CreatePasswordEntry, arg.UserID, arg.Login, arg.Password)
+	row := q.db.QueryRow(ctx, CreatePasswordEntry,
+		arg.ID,
+		arg.UserID,
+		arg.Login,
+		arg.Password,
+		arg.KeyVersion,
+	)
 	var i Password
 	err := row.Scan(
 		&i.ID,
@@ -84,37 +374,181 @@ func (q *Queries) CreatePasswordEntry(ctx context.Context, arg CreatePasswordEnt
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.KeyVersion,
 	)
 	return i, err
 }
 
 const CreateRefreshToken = `-- name: CreateRefreshToken :exec
-INSERT INTO refresh_tokens (user_id, token, expires_at)
-VALUES ($1, $2, $3)
+INSERT INTO refresh_tokens (user_id, token_hash, expires_at, session_id)
+VALUES ($1, $2, $3, $4)
 `
 
 type CreateRefreshTokenParams struct {
 	UserID    pgtype.UUID      `db:"user_id"`
-	Token     string           `db:"token"`
+	TokenHash string           `db:"token_hash"`
 	ExpiresAt pgtype.Timestamp `db:"expires_at"`
+	SessionID pgtype.UUID      `db:"session_id"`
 }
 
 func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
-	_, err := q.db.Exec(ctx, CreateRefreshToken, arg.UserID, arg.Token, arg.ExpiresAt)
+	_, err := q.db.Exec(ctx, CreateRefreshToken,
+		arg.UserID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+		arg.SessionID,
+	)
 	return err
 }
 
-const CreateUser = `-- name: CreateUser :one
-INSERT INTO users (username, password, encryption_key, email)
+const CreateSend = `-- name: CreateSend :one
+INSERT INTO sends (id, user_id, send_type, content, object_name, file_size, password, max_views, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, user_id, send_type, content, object_name, file_size, password, max_views, views, expires_at, created_at
+`
+
+type CreateSendParams struct {
+	ID         pgtype.UUID      `db:"id"`
+	UserID     pgtype.UUID      `db:"user_id"`
+	SendType   SendType         `db:"send_type"`
+	Content    string           `db:"content"`
+	ObjectName pgtype.Text      `db:"object_name"`
+	FileSize   int64            `db:"file_size"`
+	Password   pgtype.Text      `db:"password"`
+	MaxViews   int32            `db:"max_views"`
+	ExpiresAt  pgtype.Timestamp `db:"expires_at"`
+}
+
+func (q *Queries) CreateSend(ctx context.Context, arg CreateSendParams) (Send, error) {
+	row := q.db.QueryRow(ctx, CreateSend,
+		arg.ID,
+		arg.UserID,
+		arg.SendType,
+		arg.Content,
+		arg.ObjectName,
+		arg.FileSize,
+		arg.Password,
+		arg.MaxViews,
+		arg.ExpiresAt,
+	)
+	var i Send
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SendType,
+		&i.Content,
+		&i.ObjectName,
+		&i.FileSize,
+		&i.Password,
+		&i.MaxViews,
+		&i.Views,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const CreateSession = `-- name: CreateSession :one
+INSERT INTO sessions (user_id, device_name, ip_address, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, device_name, ip_address, created_at, last_used_at, expires_at
+`
+
+type CreateSessionParams struct {
+	UserID     pgtype.UUID      `db:"user_id"`
+	DeviceName string           `db:"device_name"`
+	IpAddress  string           `db:"ip_address"`
+	ExpiresAt  pgtype.Timestamp `db:"expires_at"`
+}
+
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
+	row := q.db.QueryRow(ctx, CreateSession,
+		arg.UserID,
+		arg.DeviceName,
+		arg.IpAddress,
+		arg.ExpiresAt,
+	)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.DeviceName,
+		&i.IpAddress,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const CreateShareKey = `-- name: CreateShareKey :exec
+INSERT INTO share_keys (user_id, public_key, private_key, key_version)
 VALUES ($1, $2, $3, $4)
-    RETURNING id, username, email, password, encryption_key
+ON CONFLICT (user_id) DO NOTHING
+`
+
+type CreateShareKeyParams struct {
+	UserID     pgtype.UUID `db:"user_id"`
+	PublicKey  string      `db:"public_key"`
+	PrivateKey string      `db:"private_key"`
+	KeyVersion int32       `db:"key_version"`
+}
+
+// Concurrent shares with a new recipient may both create a key; the first one is kept
+func (q *Queries) CreateShareKey(ctx context.Context, arg CreateShareKeyParams) error {
+	_, err := q.db.Exec(ctx, CreateShareKey,
+		arg.UserID,
+		arg.PublicKey,
+		arg.PrivateKey,
+		arg.KeyVersion,
+	)
+	return err
+}
+
+const CreateSigningKey = `-- name: CreateSigningKey :execrows
+INSERT INTO signing_keys (id, algorithm, private_key, public_key, activates_at)
+SELECT $1::text, $2::text, $3::text, $4::text, $5::timestamp
+WHERE NOT EXISTS (SELECT 1 FROM signing_keys WHERE activates_at > $6::timestamp)
+`
+
+type CreateSigningKeyParams struct {
+	ID          string           `db:"id"`
+	Algorithm   string           `db:"algorithm"`
+	PrivateKey  string           `db:"private_key"`
+	PublicKey   string           `db:"public_key"`
+	ActivatesAt pgtype.Timestamp `db:"activates_at"`
+	Latest      pgtype.Timestamp `db:"latest"`
+}
+
+func (q *Queries) CreateSigningKey(ctx context.Context, arg CreateSigningKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, CreateSigningKey,
+		arg.ID,
+		arg.Algorithm,
+		arg.PrivateKey,
+		arg.PublicKey,
+		arg.ActivatesAt,
+		arg.Latest,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const CreateUser = `-- name: CreateUser :one
+INSERT INTO users (username, password, encryption_key, email, kdf_salt, wrapped_vault_key)
+VALUES ($1, $2, $3, $4, $5, $6)
+    RETURNING id, username, email, password, encryption_key, change_seq, kdf_salt, wrapped_vault_key, key_version, previous_encryption_key, legacy_ciphertexts, totp_secret, totp_enabled, totp_last_step, organization_vault
 `
 
 type CreateUserParams struct {
-	Username      string `db:"username"`
-	Password      string `db:"password"`
-	EncryptionKey string `db:"encryption_key"`
-	Email         string `db:"email"`
+	Username        string      `db:"username"`
+	Password        string      `db:"password"`
+	EncryptionKey   string      `db:"encryption_key"`
+	Email           string      `db:"email"`
+	KdfSalt         pgtype.Text `db:"kdf_salt"`
+	WrappedVaultKey pgtype.Text `db:"wrapped_vault_key"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -123,6 +557,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.Password,
 		arg.EncryptionKey,
 		arg.Email,
+		arg.KdfSalt,
+		arg.WrappedVaultKey,
 	)
 	var i User
 	err := row.Scan(
@@ -131,10 +567,38 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.Password,
 		&i.EncryptionKey,
+		&i.ChangeSeq,
+		&i.KdfSalt,
+		&i.WrappedVaultKey,
+		&i.KeyVersion,
+		&i.PreviousEncryptionKey,
+		&i.LegacyCiphertexts,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.TotpLastStep,
+		&i.OrganizationVault,
 	)
 	return i, err
 }
 
+const DeleteAPIToken = `-- name: DeleteAPIToken :execrows
+DELETE FROM api_tokens
+WHERE id = $1 AND user_id = $2
+`
+
+type DeleteAPITokenParams struct {
+	ID     pgtype.UUID `db:"id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+func (q *Queries) DeleteAPIToken(ctx context.Context, arg DeleteAPITokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteAPIToken, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const DeleteBinaryEntry = `-- name: DeleteBinaryEntry :exec
 DELETE FROM binary_entries WHERE id = $1 and user_id = $2
 `
@@ -163,69 +627,335 @@ func (q *Queries) DeleteCard(ctx context.Context, arg DeleteCardParams) error {
 	return err
 }
 
-const DeleteMetaInfo = `-- name: DeleteMetaInfo :exec
-DELETE FROM metainfo
-WHERE item_id = $1 AND key = $2
+const DeleteCollection = `-- name: DeleteCollection :execrows
+DELETE FROM collections WHERE id = $1
 `
 
-type DeleteMetaInfoParams struct {
-	ItemID pgtype.UUID `db:"item_id"`
-	Key    string      `db:"key"`
-}
-
-func (q *Queries) DeleteMetaInfo(ctx context.Context, arg DeleteMetaInfoParams) error {
-	_, err := q.db.Exec(ctx, DeleteMetaInfo, arg.ItemID, arg.Key)
-	return err
+func (q *Queries) DeleteCollection(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteCollection, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const DeleteNoteEntry = `-- name: DeleteNoteEntry :exec
-DELETE FROM notes WHERE id = $1 and user_id = $2
+const DeleteCollectionMember = `-- name: DeleteCollectionMember :execrows
+DELETE FROM collection_members
+WHERE collection_id = $1 AND user_id = $2
 `
 
-type DeleteNoteEntryParams struct {
-	ID     pgtype.UUID `db:"id"`
-	UserID pgtype.UUID `db:"user_id"`
+type DeleteCollectionMemberParams struct {
+	CollectionID pgtype.UUID `db:"collection_id"`
+	UserID       pgtype.UUID `db:"user_id"`
 }
 
-func (q *Queries) DeleteNoteEntry(ctx context.Context, arg DeleteNoteEntryParams) error {
-	_, err := q.db.Exec(ctx, DeleteNoteEntry, arg.ID, arg.UserID)
-	return err
+func (q *Queries) DeleteCollectionMember(ctx context.Context, arg DeleteCollectionMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteCollectionMember, arg.CollectionID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const DeletePasswordEntry = `-- name: DeletePasswordEntry :exec
-DELETE FROM passwords
-WHERE id = $1 and user_id = $2
+const DeleteEmergencyAccess = `-- name: DeleteEmergencyAccess :execrows
+DELETE FROM emergency_access
+WHERE id = $1 AND (grantor_id = $2 OR grantee_id = $2)
 `
 
-type DeletePasswordEntryParams struct {
+type DeleteEmergencyAccessParams struct {
 	ID     pgtype.UUID `db:"id"`
 	UserID pgtype.UUID `db:"user_id"`
 }
 
-func (q *Queries) DeletePasswordEntry(ctx context.Context, arg DeletePasswordEntryParams) error {
-	_, err := q.db.Exec(ctx, DeletePasswordEntry, arg.ID, arg.UserID)
-	return err
-}
-
-const DeleteRefreshToken = `-- name: DeleteRefreshToken :exec
-DELETE FROM refresh_tokens
-WHERE token = $1
-`
-
-func (q *Queries) DeleteRefreshToken(ctx context.Context, token string) error {
-	_, err := q.db.Exec(ctx, DeleteRefreshToken, token)
-	return err
+func (q *Queries) DeleteEmergencyAccess(ctx context.Context, arg DeleteEmergencyAccessParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteEmergencyAccess, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const DeleteUserRefreshTokens = `-- name: DeleteUserRefreshTokens :exec
-DELETE FROM refresh_tokens
-WHERE user_id = $1
+const DeleteExpiredSends = `-- name: DeleteExpiredSends :many
+DELETE FROM sends
+WHERE expires_at <= $1::timestamp OR views >= max_views
+RETURNING id, user_id, send_type, content, object_name, file_size, password, max_views, views, expires_at, created_at
 `
 
-func (q *Queries) DeleteUserRefreshTokens(ctx context.Context, userID pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, DeleteUserRefreshTokens, userID)
-	return err
-}
+func (q *Queries) DeleteExpiredSends(ctx context.Context, now pgtype.Timestamp) ([]Send, error) {
+	rows, err := q.db.Query(ctx, DeleteExpiredSends, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Send
+	for rows.Next() {
+		var i Send
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.SendType,
+			&i.Content,
+			&i.ObjectName,
+			&i.FileSize,
+			&i.Password,
+			&i.MaxViews,
+			&i.Views,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const DeleteMetaInfo = `-- name: DeleteMetaInfo :exec
+DELETE FROM metainfo
+WHERE item_id = $1 AND key = $2
+`
+
+type DeleteMetaInfoParams struct {
+	ItemID pgtype.UUID `db:"item_id"`
+	Key    string      `db:"key"`
+}
+
+func (q *Queries) DeleteMetaInfo(ctx context.Context, arg DeleteMetaInfoParams) error {
+	_, err := q.db.Exec(ctx, DeleteMetaInfo, arg.ItemID, arg.Key)
+	return err
+}
+
+const DeleteNoteEntry = `-- name: DeleteNoteEntry :exec
+DELETE FROM notes WHERE id = $1 and user_id = $2
+`
+
+type DeleteNoteEntryParams struct {
+	ID     pgtype.UUID `db:"id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+func (q *Queries) DeleteNoteEntry(ctx context.Context, arg DeleteNoteEntryParams) error {
+	_, err := q.db.Exec(ctx, DeleteNoteEntry, arg.ID, arg.UserID)
+	return err
+}
+
+const DeleteOrgMember = `-- name: DeleteOrgMember :execrows
+DELETE FROM org_members
+WHERE org_id = $1 AND user_id = $2
+`
+
+type DeleteOrgMemberParams struct {
+	OrgID  pgtype.UUID `db:"org_id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+func (q *Queries) DeleteOrgMember(ctx context.Context, arg DeleteOrgMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteOrgMember, arg.OrgID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const DeleteOrganization = `-- name: DeleteOrganization :execrows
+DELETE FROM users
+WHERE organization_vault AND id = (SELECT organizations.vault_id FROM organizations WHERE organizations.id = $1)
+`
+
+// Deleting the vault deletes the organization with it
+func (q *Queries) DeleteOrganization(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteOrganization, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const DeletePasswordEntry = `-- name: DeletePasswordEntry :exec
+DELETE FROM passwords
+WHERE id = $1 and user_id = $2
+`
+
+type DeletePasswordEntryParams struct {
+	ID     pgtype.UUID `db:"id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+func (q *Queries) DeletePasswordEntry(ctx context.Context, arg DeletePasswordEntryParams) error {
+	_, err := q.db.Exec(ctx, DeletePasswordEntry, arg.ID, arg.UserID)
+	return err
+}
+
+const DeleteRefreshToken = `-- name: DeleteRefreshToken :exec
+DELETE FROM refresh_tokens
+WHERE token_hash = $1
+`
+
+func (q *Queries) DeleteRefreshToken(ctx context.Context, tokenHash string) error {
+	_, err := q.db.Exec(ctx, DeleteRefreshToken, tokenHash)
+	return err
+}
+
+const DeleteSend = `-- name: DeleteSend :one
+DELETE FROM sends
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, send_type, content, object_name, file_size, password, max_views, views, expires_at, created_at
+`
+
+type DeleteSendParams struct {
+	ID     pgtype.UUID `db:"id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+func (q *Queries) DeleteSend(ctx context.Context, arg DeleteSendParams) (Send, error) {
+	row := q.db.QueryRow(ctx, DeleteSend, arg.ID, arg.UserID)
+	var i Send
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SendType,
+		&i.Content,
+		&i.ObjectName,
+		&i.FileSize,
+		&i.Password,
+		&i.MaxViews,
+		&i.Views,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const DeleteSession = `-- name: DeleteSession :execrows
+DELETE FROM sessions
+WHERE id = $1 AND user_id = $2
+`
+
+type DeleteSessionParams struct {
+	ID     pgtype.UUID `db:"id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+func (q *Queries) DeleteSession(ctx context.Context, arg DeleteSessionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteSession, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const DeleteShare = `-- name: DeleteShare :execrows
+DELETE FROM shares
+WHERE item_id = $1 AND owner_id = $2
+  AND recipient_id = (SELECT users.id FROM users WHERE users.username = $3)
+`
+
+type DeleteShareParams struct {
+	ItemID    pgtype.UUID `db:"item_id"`
+	OwnerID   pgtype.UUID `db:"owner_id"`
+	Recipient string      `db:"recipient"`
+}
+
+func (q *Queries) DeleteShare(ctx context.Context, arg DeleteShareParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteShare, arg.ItemID, arg.OwnerID, arg.Recipient)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const DeleteSigningKey = `-- name: DeleteSigningKey :exec
+DELETE FROM signing_keys
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSigningKey(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, DeleteSigningKey, id)
+	return err
+}
+
+const DeleteUser = `-- name: DeleteUser :execrows
+DELETE FROM users
+WHERE id = $1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const DeleteUserRefreshTokens = `-- name: DeleteUserRefreshTokens :exec
+DELETE FROM refresh_tokens
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserRefreshTokens(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, DeleteUserRefreshTokens, userID)
+	return err
+}
+
+const DeleteUserSessions = `-- name: DeleteUserSessions :many
+DELETE FROM sessions
+WHERE user_id = $1
+RETURNING id
+`
+
+func (q *Queries) DeleteUserSessions(ctx context.Context, userID pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, DeleteUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const EnableTOTP = `-- name: EnableTOTP :execrows
+WITH enabled AS (
+    UPDATE users
+    SET totp_enabled = true, totp_last_step = $2
+    WHERE users.id = $3 AND NOT users.totp_enabled AND users.totp_secret = $4
+    RETURNING users.id
+)
+INSERT INTO recovery_codes (user_id, code_hash)
+SELECT enabled.id, code_hash FROM enabled, unnest($1::text[]) AS code_hash
+`
+
+type EnableTOTPParams struct {
+	CodeHashes []string    `db:"code_hashes"`
+	Step       int64       `db:"step"`
+	UserID     pgtype.UUID `db:"user_id"`
+	Secret     pgtype.Text `db:"secret"`
+}
+
+func (q *Queries) EnableTOTP(ctx context.Context, arg EnableTOTPParams) (int64, error) {
+	result, err := q.db.Exec(ctx, EnableTOTP,
+		arg.CodeHashes,
+		arg.Step,
+		arg.UserID,
+		arg.Secret,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
 
 const ExpireRefreshTokens = `-- name: ExpireRefreshTokens :exec
 DELETE FROM refresh_tokens
@@ -237,27 +967,1971 @@ func (q *Queries) ExpireRefreshTokens(ctx context.Context) error {
 	return err
 }
 
-const GetBinaryEntriesByUserID = `-- name: GetBinaryEntriesByUserID :many
-SELECT id, user_id, file_name, file_size, file_url, created_at, updated_at FROM binary_entries WHERE user_id = $1
+const ExportAuditEvents = `-- name: ExportAuditEvents :many
+SELECT seq, actor_id, item_id, action, ip, user_agent, result, occurred_at, prev_hash, hash FROM audit_events
+WHERE seq > $1
+ORDER BY seq
+LIMIT $2
+`
+
+type ExportAuditEventsParams struct {
+	AfterSeq  int64 `db:"after_seq"`
+	BatchSize int32 `db:"batch_size"`
+}
+
+func (q *Queries) ExportAuditEvents(ctx context.Context, arg ExportAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, ExportAuditEvents, arg.AfterSeq, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.Seq,
+			&i.ActorID,
+			&i.ItemID,
+			&i.Action,
+			&i.Ip,
+			&i.UserAgent,
+			&i.Result,
+			&i.OccurredAt,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const FailKeyRotation = `-- name: FailKeyRotation :exec
+UPDATE key_rotations
+SET status = 'failed', error = $1, finished_at = CURRENT_TIMESTAMP
+WHERE id = $2
+`
+
+type FailKeyRotationParams struct {
+	Error pgtype.Text `db:"error"`
+	ID    pgtype.UUID `db:"id"`
+}
+
+func (q *Queries) FailKeyRotation(ctx context.Context, arg FailKeyRotationParams) error {
+	_, err := q.db.Exec(ctx, FailKeyRotation, arg.Error, arg.ID)
+	return err
+}
+
+const FinishKeyRotation = `-- name: FinishKeyRotation :one
+WITH cleared AS (
+    UPDATE users
+    SET previous_encryption_key = NULL,
+        legacy_ciphertexts = users.legacy_ciphertexts
+            AND NOT (SELECT r.binds_values FROM key_rotations r WHERE r.id = $1)
+    WHERE users.id = $2 AND users.key_version = $3
+    RETURNING users.id
+)
+UPDATE key_rotations
+SET status = 'completed', done_items = total_items, finished_at = CURRENT_TIMESTAMP
+WHERE key_rotations.id = $1 AND key_rotations.user_id IN (SELECT cleared.id FROM cleared)
+    RETURNING id, user_id, from_version, to_version, status, total_items, done_items, error, started_at, finished_at, binds_values
+`
+
+type FinishKeyRotationParams struct {
+	ID        pgtype.UUID `db:"id"`
+	UserID    pgtype.UUID `db:"user_id"`
+	ToVersion int32       `db:"to_version"`
+}
+
+func (q *Queries) FinishKeyRotation(ctx context.Context, arg FinishKeyRotationParams) (KeyRotation, error) {
+	row := q.db.QueryRow(ctx, FinishKeyRotation, arg.ID, arg.UserID, arg.ToVersion)
+	var i KeyRotation
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FromVersion,
+		&i.ToVersion,
+		&i.Status,
+		&i.TotalItems,
+		&i.DoneItems,
+		&i.Error,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.BindsValues,
+	)
+	return i, err
+}
+
+const GetAPIToken = `-- name: GetAPIToken :one
+SELECT id, user_id, name, token_hash, read_only, item_types, tags, created_at, expires_at, last_used_at
+FROM api_tokens
+WHERE token_hash = $1 AND expires_at > NOW()
+`
+
+func (q *Queries) GetAPIToken(ctx context.Context, tokenHash string) (ApiToken, error) {
+	row := q.db.QueryRow(ctx, GetAPIToken, tokenHash)
+	var i ApiToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.TokenHash,
+		&i.ReadOnly,
+		&i.ItemTypes,
+		&i.Tags,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+	)
+	return i, err
+}
+
+const GetBinaryEntriesByIDs = `-- name: GetBinaryEntriesByIDs :many
+SELECT id, user_id, file_name, file_size, file_url, created_at, updated_at, version, key_version FROM binary_entries
+WHERE user_id = $1 AND id = ANY($2::uuid[])
+`
+
+type GetBinaryEntriesByIDsParams struct {
+	UserID pgtype.UUID   `db:"user_id"`
+	Ids    []pgtype.UUID `db:"ids"`
+}
+
+func (q *Queries) GetBinaryEntriesByIDs(ctx context.Context, arg GetBinaryEntriesByIDsParams) ([]BinaryEntry, error) {
+	rows, err := q.db.Query(ctx, GetBinaryEntriesByIDs, arg.UserID, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BinaryEntry
+	for rows.Next() {
+		var i BinaryEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.FileName,
+			&i.FileSize,
+			&i.FileUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.KeyVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetBinaryEntriesByUserID = `-- name: GetBinaryEntriesByUserID :many
+SELECT id, user_id, file_name, file_size, file_url, created_at, updated_at, version, key_version FROM binary_entries
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+    LIMIT $2 OFFSET $3
+`
+
+type GetBinaryEntriesByUserIDParams struct {
+	UserID pgtype.UUID `db:"user_id"`
+	Limit  int32       `db:"limit"`
+	Offset int32       `db:"offset"`
+}
+
+func (q *Queries) GetBinaryEntriesByUserID(ctx context.Context, arg GetBinaryEntriesByUserIDParams) ([]BinaryEntry, error) {
+	rows, err := q.db.Query(ctx, GetBinaryEntriesByUserID, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BinaryEntry
+	for rows.Next() {
+		var i BinaryEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.FileName,
+			&i.FileSize,
+			&i.FileUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.KeyVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetBinaryEntryByID = `-- name: GetBinaryEntryByID :one
+SELECT binary_entries.id, binary_entries.user_id, binary_entries.file_name, binary_entries.file_size, binary_entries.file_url, binary_entries.created_at, binary_entries.updated_at, binary_entries.version, binary_entries.key_version
+FROM binary_entries
+WHERE binary_entries.id = $1 and binary_entries.user_id = $2
+`
+
+type GetBinaryEntryByIDParams struct {
+	ID     pgtype.UUID `db:"id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+func (q *Queries) GetBinaryEntryByID(ctx context.Context, arg GetBinaryEntryByIDParams) (BinaryEntry, error) {
+	row := q.db.QueryRow(ctx, GetBinaryEntryByID, arg.ID, arg.UserID)
+	var i BinaryEntry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FileName,
+		&i.FileSize,
+		&i.FileUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.KeyVersion,
+	)
+	return i, err
+}
+
+const GetCardByID = `-- name: GetCardByID :one
+SELECT cards.id, cards.user_id, cards.encrypted_card_number, cards.encrypted_expiry_date, cards.encrypted_cvv, cards.cardholder_name, cards.created_at, cards.updated_at, cards.hashed_card_number, cards.version, cards.key_version
+FROM cards
+WHERE cards.id = $1 and cards.user_id = $2
+`
+
+type GetCardByIDParams struct {
+	ID     pgtype.UUID `db:"id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+func (q *Queries) GetCardByID(ctx context.Context, arg GetCardByIDParams) (Card, error) {
+	row := q.db.QueryRow(ctx, GetCardByID, arg.ID, arg.UserID)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.EncryptedCardNumber,
+		&i.EncryptedExpiryDate,
+		&i.EncryptedCvv,
+		&i.CardholderName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.HashedCardNumber,
+		&i.Version,
+		&i.KeyVersion,
+	)
+	return i, err
+}
+
+const GetCardsByIDs = `-- name: GetCardsByIDs :many
+SELECT id, user_id, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name, created_at, updated_at, hashed_card_number, version, key_version FROM cards
+WHERE user_id = $1 AND id = ANY($2::uuid[])
+`
+
+type GetCardsByIDsParams struct {
+	UserID pgtype.UUID   `db:"user_id"`
+	Ids    []pgtype.UUID `db:"ids"`
+}
+
+func (q *Queries) GetCardsByIDs(ctx context.Context, arg GetCardsByIDsParams) ([]Card, error) {
+	rows, err := q.db.Query(ctx, GetCardsByIDs, arg.UserID, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.EncryptedCardNumber,
+			&i.EncryptedExpiryDate,
+			&i.EncryptedCvv,
+			&i.CardholderName,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.HashedCardNumber,
+			&i.Version,
+			&i.KeyVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetCardsByUserID = `-- name: GetCardsByUserID :many
+SELECT id, user_id, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name, created_at, updated_at, hashed_card_number, version, key_version FROM cards
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+    LIMIT $2 OFFSET $3
+`
+
+type GetCardsByUserIDParams struct {
+	UserID pgtype.UUID `db:"user_id"`
+	Limit  int32       `db:"limit"`
+	Offset int32       `db:"offset"`
+}
+
+func (q *Queries) GetCardsByUserID(ctx context.Context, arg GetCardsByUserIDParams) ([]Card, error) {
+	rows, err := q.db.Query(ctx, GetCardsByUserID, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.EncryptedCardNumber,
+			&i.EncryptedExpiryDate,
+			&i.EncryptedCvv,
+			&i.CardholderName,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.HashedCardNumber,
+			&i.Version,
+			&i.KeyVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetCollectionAccess = `-- name: GetCollectionAccess :one
+SELECT c.id, c.org_id, o.vault_id, m.role, g.read_only
+FROM collections c
+         JOIN organizations o ON o.id = c.org_id
+         JOIN org_members m ON m.org_id = c.org_id AND m.user_id = $1
+         LEFT JOIN collection_members g ON g.collection_id = c.id AND g.user_id = $1
+WHERE c.id = $2
+`
+
+type GetCollectionAccessParams struct {
+	UserID       pgtype.UUID `db:"user_id"`
+	CollectionID pgtype.UUID `db:"collection_id"`
+}
+
+type GetCollectionAccessRow struct {
+	ID       pgtype.UUID `db:"id"`
+	OrgID    pgtype.UUID `db:"org_id"`
+	VaultID  pgtype.UUID `db:"vault_id"`
+	Role     OrgRole     `db:"role"`
+	ReadOnly pgtype.Bool `db:"read_only"`
+}
+
+func (q *Queries) GetCollectionAccess(ctx context.Context, arg GetCollectionAccessParams) (GetCollectionAccessRow, error) {
+	row := q.db.QueryRow(ctx, GetCollectionAccess, arg.UserID, arg.CollectionID)
+	var i GetCollectionAccessRow
+	err := row.Scan(
+		&i.ID,
+		&i.OrgID,
+		&i.VaultID,
+		&i.Role,
+		&i.ReadOnly,
+	)
+	return i, err
+}
+
+const GetEmergencyAccess = `-- name: GetEmergencyAccess :one
+SELECT e.id, e.grantor_id, e.grantee_id, e.access_type, e.wait_days, e.status, e.requested_at, e.approved_at, e.created_at, g.username AS grantor, t.username AS grantee
+FROM emergency_access e
+         JOIN users g ON g.id = e.grantor_id
+         JOIN users t ON t.id = e.grantee_id
+WHERE e.id = $1 AND (e.grantor_id = $2 OR e.grantee_id = $2)
+`
+
+type GetEmergencyAccessParams struct {
+	ID     pgtype.UUID `db:"id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+type GetEmergencyAccessRow struct {
+	ID          pgtype.UUID           `db:"id"`
+	GrantorID   pgtype.UUID           `db:"grantor_id"`
+	GranteeID   pgtype.UUID           `db:"grantee_id"`
+	AccessType  EmergencyAccessType   `db:"access_type"`
+	WaitDays    int32                 `db:"wait_days"`
+	Status      EmergencyAccessStatus `db:"status"`
+	RequestedAt pgtype.Timestamp      `db:"requested_at"`
+	ApprovedAt  pgtype.Timestamp      `db:"approved_at"`
+	CreatedAt   pgtype.Timestamp      `db:"created_at"`
+	Grantor     string                `db:"grantor"`
+	Grantee     string                `db:"grantee"`
+}
+
+func (q *Queries) GetEmergencyAccess(ctx context.Context, arg GetEmergencyAccessParams) (GetEmergencyAccessRow, error) {
+	row := q.db.QueryRow(ctx, GetEmergencyAccess, arg.ID, arg.UserID)
+	var i GetEmergencyAccessRow
+	err := row.Scan(
+		&i.ID,
+		&i.GrantorID,
+		&i.GranteeID,
+		&i.AccessType,
+		&i.WaitDays,
+		&i.Status,
+		&i.RequestedAt,
+		&i.ApprovedAt,
+		&i.CreatedAt,
+		&i.Grantor,
+		&i.Grantee,
+	)
+	return i, err
+}
+
+const GetEmergencyItemAccess = `-- name: GetEmergencyItemAccess :one
+SELECT e.grantor_id
+FROM emergency_access e
+         JOIN items i ON i.user_id = e.grantor_id
+WHERE i.id_resource = $1 AND e.grantee_id = $2
+  AND e.status = 'approved' AND e.access_type = 'view'
+`
+
+type GetEmergencyItemAccessParams struct {
+	ItemID    pgtype.UUID `db:"item_id"`
+	GranteeID pgtype.UUID `db:"grantee_id"`
+}
+
+func (q *Queries) GetEmergencyItemAccess(ctx context.Context, arg GetEmergencyItemAccessParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, GetEmergencyItemAccess, arg.ItemID, arg.GranteeID)
+	var grantor_id pgtype.UUID
+	err := row.Scan(&grantor_id)
+	return grantor_id, err
+}
+
+const GetItemAccess = `-- name: GetItemAccess :one
+SELECT i.user_id AS vault_id, ci.collection_id, m.role, g.read_only
+FROM items i
+         JOIN collection_items ci ON ci.item_id = i.id_resource
+         JOIN collections c ON c.id = ci.collection_id
+         JOIN organizations o ON o.id = c.org_id AND o.vault_id = i.user_id
+         LEFT JOIN org_members m ON m.org_id = c.org_id AND m.user_id = $1
+         LEFT JOIN collection_members g ON g.collection_id = c.id AND g.user_id = $1
+WHERE i.id_resource = $2
+`
+
+type GetItemAccessParams struct {
+	UserID pgtype.UUID `db:"user_id"`
+	ItemID pgtype.UUID `db:"item_id"`
+}
+
+type GetItemAccessRow struct {
+	VaultID      pgtype.UUID `db:"vault_id"`
+	CollectionID pgtype.UUID `db:"collection_id"`
+	Role         NullOrgRole `db:"role"`
+	ReadOnly     pgtype.Bool `db:"read_only"`
+}
+
+// Only items kept in the vault of the organization resolve to it
+func (q *Queries) GetItemAccess(ctx context.Context, arg GetItemAccessParams) (GetItemAccessRow, error) {
+	row := q.db.QueryRow(ctx, GetItemAccess, arg.UserID, arg.ItemID)
+	var i GetItemAccessRow
+	err := row.Scan(
+		&i.VaultID,
+		&i.CollectionID,
+		&i.Role,
+		&i.ReadOnly,
+	)
+	return i, err
+}
+
+const GetItemChangeSeq = `-- name: GetItemChangeSeq :one
+SELECT seq FROM item_changes
+WHERE user_id = $1 AND item_id = $2 AND NOT deleted
+`
+
+type GetItemChangeSeqParams struct {
+	UserID pgtype.UUID `db:"user_id"`
+	ItemID pgtype.UUID `db:"item_id"`
+}
+
+func (q *Queries) GetItemChangeSeq(ctx context.Context, arg GetItemChangeSeqParams) (int64, error) {
+	row := q.db.QueryRow(ctx, GetItemChangeSeq, arg.UserID, arg.ItemID)
+	var seq int64
+	err := row.Scan(&seq)
+	return seq, err
+}
+
+const GetItemChangesSince = `-- name: GetItemChangesSince :many
+SELECT item_id, type, seq, deleted, changed_at
+FROM item_changes
+WHERE user_id = $1 AND seq > $2::bigint
+ORDER BY seq
+    LIMIT $3
+`
+
+type GetItemChangesSinceParams struct {
+	UserID     pgtype.UUID `db:"user_id"`
+	SinceSeq   int64       `db:"since_seq"`
+	MaxChanges int32       `db:"max_changes"`
+}
+
+type GetItemChangesSinceRow struct {
+	ItemID    pgtype.UUID      `db:"item_id"`
+	Type      ItemType         `db:"type"`
+	Seq       int64            `db:"seq"`
+	Deleted   bool             `db:"deleted"`
+	ChangedAt pgtype.Timestamp `db:"changed_at"`
+}
+
+func (q *Queries) GetItemChangesSince(ctx context.Context, arg GetItemChangesSinceParams) ([]GetItemChangesSinceRow, error) {
+	rows, err := q.db.Query(ctx, GetItemChangesSince, arg.UserID, arg.SinceSeq, arg.MaxChanges)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemChangesSinceRow
+	for rows.Next() {
+		var i GetItemChangesSinceRow
+		if err := rows.Scan(
+			&i.ItemID,
+			&i.Type,
+			&i.Seq,
+			&i.Deleted,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetItemsByResourceIDs = `-- name: GetItemsByResourceIDs :many
+SELECT
+    i.id,
+    i.type,
+    i.id_resource,
+    i.created_at,
+    COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) AS updated_at
+FROM items i
+         LEFT JOIN passwords p ON i.type = 'password' AND i.id_resource = p.id
+         LEFT JOIN notes n ON i.type = 'text' AND i.id_resource = n.id
+         LEFT JOIN cards c ON i.type = 'card' AND i.id_resource = c.id
+         LEFT JOIN binary_entries b ON i.type = 'binary' AND i.id_resource = b.id
+WHERE i.user_id = $1 AND i.id_resource = ANY($2::uuid[])
+ORDER BY i.created_at, i.id
+`
+
+type GetItemsByResourceIDsParams struct {
+	UserID      pgtype.UUID   `db:"user_id"`
+	ResourceIds []pgtype.UUID `db:"resource_ids"`
+}
+
+type GetItemsByResourceIDsRow struct {
+	ID         pgtype.UUID      `db:"id"`
+	Type       ItemType         `db:"type"`
+	IDResource pgtype.UUID      `db:"id_resource"`
+	CreatedAt  pgtype.Timestamp `db:"created_at"`
+	UpdatedAt  pgtype.Timestamp `db:"updated_at"`
+}
+
+func (q *Queries) GetItemsByResourceIDs(ctx context.Context, arg GetItemsByResourceIDsParams) ([]GetItemsByResourceIDsRow, error) {
+	rows, err := q.db.Query(ctx, GetItemsByResourceIDs, arg.UserID, arg.ResourceIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemsByResourceIDsRow
+	for rows.Next() {
+		var i GetItemsByResourceIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.IDResource,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetItemsByUserID = `-- name: GetItemsByUserID :many
+SELECT
+    i.id,
+    i.type,
+    i.id_resource,
+    i.created_at,
+    COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) AS updated_at,
+    COALESCE(o.username, '')::text AS owner,
+    COALESCE(s.read_only, FALSE)::boolean AS read_only
+FROM items i
+         LEFT JOIN passwords p ON i.type = 'password' AND i.id_resource = p.id
+         LEFT JOIN notes n ON i.type = 'text' AND i.id_resource = n.id
+         LEFT JOIN cards c ON i.type = 'card' AND i.id_resource = c.id
+         LEFT JOIN binary_entries b ON i.type = 'binary' AND i.id_resource = b.id
+         LEFT JOIN shares s ON s.item_id = i.id_resource AND s.recipient_id = $1
+         LEFT JOIN users o ON s.id IS NOT NULL AND o.id = i.user_id
+WHERE i.user_id = $1 OR s.id IS NOT NULL
+ORDER BY i.created_at DESC
+    LIMIT $3 OFFSET $2
+`
+
+type GetItemsByUserIDParams struct {
+	UserID pgtype.UUID `db:"user_id"`
+	Offset int32       `db:"offset"`
+	Limit  int32       `db:"limit"`
+}
+
+type GetItemsByUserIDRow struct {
+	ID         pgtype.UUID      `db:"id"`
+	Type       ItemType         `db:"type"`
+	IDResource pgtype.UUID      `db:"id_resource"`
+	CreatedAt  pgtype.Timestamp `db:"created_at"`
+	UpdatedAt  pgtype.Timestamp `db:"updated_at"`
+	Owner      string           `db:"owner"`
+	ReadOnly   bool             `db:"read_only"`
+}
+
+func (q *Queries) GetItemsByUserID(ctx context.Context, arg GetItemsByUserIDParams) ([]GetItemsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, GetItemsByUserID, arg.UserID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemsByUserIDRow
+	for rows.Next() {
+		var i GetItemsByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.IDResource,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Owner,
+			&i.ReadOnly,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetItemsChangedSince = `-- name: GetItemsChangedSince :many
+SELECT
+    i.id,
+    i.type,
+    i.id_resource,
+    i.created_at,
+    COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) AS updated_at
+FROM items i
+         LEFT JOIN passwords p ON i.type = 'password' AND i.id_resource = p.id
+         LEFT JOIN notes n ON i.type = 'text' AND i.id_resource = n.id
+         LEFT JOIN cards c ON i.type = 'card' AND i.id_resource = c.id
+         LEFT JOIN binary_entries b ON i.type = 'binary' AND i.id_resource = b.id
+WHERE i.user_id = $1
+  AND COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) > $2::timestamp
+ORDER BY COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at), i.id
+`
+
+type GetItemsChangedSinceParams struct {
+	UserID       pgtype.UUID      `db:"user_id"`
+	ChangedSince pgtype.Timestamp `db:"changed_since"`
+}
+
+type GetItemsChangedSinceRow struct {
+	ID         pgtype.UUID      `db:"id"`
+	Type       ItemType         `db:"type"`
+	IDResource pgtype.UUID      `db:"id_resource"`
+	CreatedAt  pgtype.Timestamp `db:"created_at"`
+	UpdatedAt  pgtype.Timestamp `db:"updated_at"`
+}
+
+func (q *Queries) GetItemsChangedSince(ctx context.Context, arg GetItemsChangedSinceParams) ([]GetItemsChangedSinceRow, error) {
+	rows, err := q.db.Query(ctx, GetItemsChangedSince, arg.UserID, arg.ChangedSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemsChangedSinceRow
+	for rows.Next() {
+		var i GetItemsChangedSinceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.IDResource,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetLastAuditEvent = `-- name: GetLastAuditEvent :one
+SELECT seq, actor_id, item_id, action, ip, user_agent, result, occurred_at, prev_hash, hash FROM audit_events
+ORDER BY seq DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastAuditEvent(ctx context.Context) (AuditEvent, error) {
+	row := q.db.QueryRow(ctx, GetLastAuditEvent)
+	var i AuditEvent
+	err := row.Scan(
+		&i.Seq,
+		&i.ActorID,
+		&i.ItemID,
+		&i.Action,
+		&i.Ip,
+		&i.UserAgent,
+		&i.Result,
+		&i.OccurredAt,
+		&i.PrevHash,
+		&i.Hash,
+	)
+	return i, err
+}
+
+const GetLatestKeyRotation = `-- name: GetLatestKeyRotation :one
+SELECT id, user_id, from_version, to_version, status, total_items, done_items, error, started_at, finished_at, binds_values FROM key_rotations
+WHERE user_id = $1
+ORDER BY started_at DESC
+    LIMIT 1
+`
+
+func (q *Queries) GetLatestKeyRotation(ctx context.Context, userID pgtype.UUID) (KeyRotation, error) {
+	row := q.db.QueryRow(ctx, GetLatestKeyRotation, userID)
+	var i KeyRotation
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FromVersion,
+		&i.ToVersion,
+		&i.Status,
+		&i.TotalItems,
+		&i.DoneItems,
+		&i.Error,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.BindsValues,
+	)
+	return i, err
+}
+
+const GetMembership = `-- name: GetMembership :one
+SELECT o.id, o.name, o.vault_id, o.created_at, m.role
+FROM organizations o
+         JOIN org_members m ON m.org_id = o.id
+WHERE o.id = $1 AND m.user_id = $2
+`
+
+type GetMembershipParams struct {
+	OrgID  pgtype.UUID `db:"org_id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+type GetMembershipRow struct {
+	ID        pgtype.UUID      `db:"id"`
+	Name      string           `db:"name"`
+	VaultID   pgtype.UUID      `db:"vault_id"`
+	CreatedAt pgtype.Timestamp `db:"created_at"`
+	Role      OrgRole          `db:"role"`
+}
+
+func (q *Queries) GetMembership(ctx context.Context, arg GetMembershipParams) (GetMembershipRow, error) {
+	row := q.db.QueryRow(ctx, GetMembership, arg.OrgID, arg.UserID)
+	var i GetMembershipRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.VaultID,
+		&i.CreatedAt,
+		&i.Role,
+	)
+	return i, err
+}
+
+const GetMetaInfoByItemID = `-- name: GetMetaInfoByItemID :many
+SELECT key, value FROM metainfo WHERE item_id = $1
+`
+
+type GetMetaInfoByItemIDRow struct {
+	Key   string `db:"key"`
+	Value string `db:"value"`
+}
+
+func (q *Queries) GetMetaInfoByItemID(ctx context.Context, itemID pgtype.UUID) ([]GetMetaInfoByItemIDRow, error) {
+	rows, err := q.db.Query(ctx, GetMetaInfoByItemID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMetaInfoByItemIDRow
+	for rows.Next() {
+		var i GetMetaInfoByItemIDRow
+		if err := rows.Scan(&i.Key, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetMetaInfoByItemIDs = `-- name: GetMetaInfoByItemIDs :many
+SELECT item_id, key, value FROM metainfo WHERE item_id = ANY($1::uuid[])
+`
+
+type GetMetaInfoByItemIDsRow struct {
+	ItemID pgtype.UUID `db:"item_id"`
+	Key    string      `db:"key"`
+	Value  string      `db:"value"`
+}
+
+func (q *Queries) GetMetaInfoByItemIDs(ctx context.Context, itemIds []pgtype.UUID) ([]GetMetaInfoByItemIDsRow, error) {
+	rows, err := q.db.Query(ctx, GetMetaInfoByItemIDs, itemIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMetaInfoByItemIDsRow
+	for rows.Next() {
+		var i GetMetaInfoByItemIDsRow
+		if err := rows.Scan(&i.ItemID, &i.Key, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetNoteByID = `-- name: GetNoteByID :one
+SELECT notes.id, notes.user_id, notes.encrypted_content, notes.created_at, notes.updated_at, notes.version, notes.key_version
+FROM notes
+WHERE notes.id = $1 and notes.user_id = $2
+`
+
+type GetNoteByIDParams struct {
+	ID     pgtype.UUID `db:"id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+func (q *Queries) GetNoteByID(ctx context.Context, arg GetNoteByIDParams) (Note, error) {
+	row := q.db.QueryRow(ctx, GetNoteByID, arg.ID, arg.UserID)
+	var i Note
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.EncryptedContent,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.KeyVersion,
+	)
+	return i, err
+}
+
+const GetNotesByIDs = `-- name: GetNotesByIDs :many
+SELECT id, user_id, encrypted_content, created_at, updated_at, version, key_version FROM notes
+WHERE user_id = $1 AND id = ANY($2::uuid[])
+`
+
+type GetNotesByIDsParams struct {
+	UserID pgtype.UUID   `db:"user_id"`
+	Ids    []pgtype.UUID `db:"ids"`
+}
+
+func (q *Queries) GetNotesByIDs(ctx context.Context, arg GetNotesByIDsParams) ([]Note, error) {
+	rows, err := q.db.Query(ctx, GetNotesByIDs, arg.UserID, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.EncryptedContent,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.KeyVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetNotesByUserID = `-- name: GetNotesByUserID :many
+SELECT id, user_id, encrypted_content, created_at, updated_at, version, key_version FROM notes
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+    LIMIT $2 OFFSET $3
+`
+
+type GetNotesByUserIDParams struct {
+	UserID pgtype.UUID `db:"user_id"`
+	Limit  int32       `db:"limit"`
+	Offset int32       `db:"offset"`
+}
+
+func (q *Queries) GetNotesByUserID(ctx context.Context, arg GetNotesByUserIDParams) ([]Note, error) {
+	rows, err := q.db.Query(ctx, GetNotesByUserID, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.EncryptedContent,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.KeyVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetPasswordEntriesByIDs = `-- name: GetPasswordEntriesByIDs :many
+SELECT id, user_id, login, password, created_at, updated_at, version, key_version FROM passwords
+WHERE user_id = $1 AND id = ANY($2::uuid[])
+`
+
+type GetPasswordEntriesByIDsParams struct {
+	UserID pgtype.UUID   `db:"user_id"`
+	Ids    []pgtype.UUID `db:"ids"`
+}
+
+func (q *Queries) GetPasswordEntriesByIDs(ctx context.Context, arg GetPasswordEntriesByIDsParams) ([]Password, error) {
+	rows, err := q.db.Query(ctx, GetPasswordEntriesByIDs, arg.UserID, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Password
+	for rows.Next() {
+		var i Password
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Login,
+			&i.Password,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.KeyVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetPasswordEntriesByUserID = `-- name: GetPasswordEntriesByUserID :many
+SELECT id, user_id, login, password, created_at, updated_at, version, key_version FROM passwords
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+    LIMIT $2 OFFSET $3
+`
+
+type GetPasswordEntriesByUserIDParams struct {
+	UserID pgtype.UUID `db:"user_id"`
+	Limit  int32       `db:"limit"`
+	Offset int32       `db:"offset"`
+}
+
+func (q *Queries) GetPasswordEntriesByUserID(ctx context.Context, arg GetPasswordEntriesByUserIDParams) ([]Password, error) {
+	rows, err := q.db.Query(ctx, GetPasswordEntriesByUserID, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Password
+	for rows.Next() {
+		var i Password
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Login,
+			&i.Password,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.KeyVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetPasswordEntryByID = `-- name: GetPasswordEntryByID :one
+SELECT passwords.id, passwords.user_id, passwords.login, passwords.password, passwords.created_at, passwords.updated_at, passwords.version, passwords.key_version
+FROM passwords
+WHERE passwords.id = $1 and passwords.user_id = $2
+`
+
+type GetPasswordEntryByIDParams struct {
+	ID     pgtype.UUID `db:"id"`
+	UserID pgtype.UUID `db:"user_id"`
+}
+
+func (q *Queries) GetPasswordEntryByID(ctx context.Context, arg GetPasswordEntryByIDParams) (Password, error) {
+	row := q.db.QueryRow(ctx, GetPasswordEntryByID, arg.ID, arg.UserID)
+	var i Password
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Login,
+		&i.Password,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.KeyVersion,
+	)
+	return i, err
+}
+
+const GetRefreshToken = `-- name: GetRefreshToken :one
+SELECT id, user_id, token_hash, expires_at, session_id, used_at
+FROM refresh_tokens
+WHERE token_hash = $1
+`
+
+type GetRefreshTokenRow struct {
+	ID        pgtype.UUID      `db:"id"`
+	UserID    pgtype.UUID      `db:"user_id"`
+	TokenHash string           `db:"token_hash"`
+	ExpiresAt pgtype.Timestamp `db:"expires_at"`
+	SessionID pgtype.UUID      `db:"session_id"`
+	UsedAt    pgtype.Timestamp `db:"used_at"`
+}
+
+func (q *Queries) GetRefreshToken(ctx context.Context, tokenHash string) (GetRefreshTokenRow, error) {
+	row := q.db.QueryRow(ctx, GetRefreshToken, tokenHash)
+	var i GetRefreshTokenRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.SessionID,
+		&i.UsedAt,
+	)
+	return i, err
+}
+
+const GetSend = `-- name: GetSend :one
+SELECT id, user_id, send_type, content, object_name, file_size, password, max_views, views, expires_at, created_at FROM sends
+WHERE id = $1
+`
+
+func (q *Queries) GetSend(ctx context.Context, id pgtype.UUID) (Send, error) {
+	row := q.db.QueryRow(ctx, GetSend, id)
+	var i Send
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SendType,
+		&i.Content,
+		&i.ObjectName,
+		&i.FileSize,
+		&i.Password,
+		&i.MaxViews,
+		&i.Views,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const GetShare = `-- name: GetShare :one
+SELECT id, item_id, owner_id, recipient_id, read_only, payload, item_seq, created_at FROM shares
+WHERE item_id = $1 AND recipient_id = $2
+`
+
+type GetShareParams struct {
+	ItemID      pgtype.UUID `db:"item_id"`
+	RecipientID pgtype.UUID `db:"recipient_id"`
+}
+
+func (q *Queries) GetShare(ctx context.Context, arg GetShareParams) (Share, error) {
+	row := q.db.QueryRow(ctx, GetShare, arg.ItemID, arg.RecipientID)
+	var i Share
+	err := row.Scan(
+		&i.ID,
+		&i.ItemID,
+		&i.OwnerID,
+		&i.RecipientID,
+		&i.ReadOnly,
+		&i.Payload,
+		&i.ItemSeq,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const GetShareKey = `-- name: GetShareKey :one
+SELECT user_id, public_key, private_key, key_version, created_at FROM share_keys
+WHERE user_id = $1
+`
+
+func (q *Queries) GetShareKey(ctx context.Context, userID pgtype.UUID) (ShareKey, error) {
+	row := q.db.QueryRow(ctx, GetShareKey, userID)
+	var i ShareKey
+	err := row.Scan(
+		&i.UserID,
+		&i.PublicKey,
+		&i.PrivateKey,
+		&i.KeyVersion,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const GetTotalItemCountByUserID = `-- name: GetTotalItemCountByUserID :one
+SELECT COUNT(*) FROM items WHERE user_id = $1
+`
+
+func (q *Queries) GetTotalItemCountByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, GetTotalItemCountByUserID, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const GetUserByID = `-- name: GetUserByID :one
+SELECT id, username, email, password, encryption_key, change_seq, kdf_salt, wrapped_vault_key, key_version, previous_encryption_key, legacy_ciphertexts, totp_secret, totp_enabled, totp_last_step, organization_vault FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, GetUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.EncryptionKey,
+		&i.ChangeSeq,
+		&i.KdfSalt,
+		&i.WrappedVaultKey,
+		&i.KeyVersion,
+		&i.PreviousEncryptionKey,
+		&i.LegacyCiphertexts,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.TotpLastStep,
+		&i.OrganizationVault,
+	)
+	return i, err
+}
+
+const GetUserByUsername = `-- name: GetUserByUsername :one
+SELECT id, username, email, password, encryption_key, change_seq, kdf_salt, wrapped_vault_key, key_version, previous_encryption_key, legacy_ciphertexts, totp_secret, totp_enabled, totp_last_step, organization_vault FROM users
+WHERE username = $1
+`
+
+func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, GetUserByUsername, username)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.EncryptionKey,
+		&i.ChangeSeq,
+		&i.KdfSalt,
+		&i.WrappedVaultKey,
+		&i.KeyVersion,
+		&i.PreviousEncryptionKey,
+		&i.LegacyCiphertexts,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.TotpLastStep,
+		&i.OrganizationVault,
+	)
+	return i, err
+}
+
+const InsertAuditEvent = `-- name: InsertAuditEvent :exec
+INSERT INTO audit_events (seq, actor_id, item_id, action, ip, user_agent, result, occurred_at, prev_hash, hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+type InsertAuditEventParams struct {
+	Seq        int64            `db:"seq"`
+	ActorID    pgtype.UUID      `db:"actor_id"`
+	ItemID     pgtype.UUID      `db:"item_id"`
+	Action     string           `db:"action"`
+	Ip         string           `db:"ip"`
+	UserAgent  string           `db:"user_agent"`
+	Result     string           `db:"result"`
+	OccurredAt pgtype.Timestamp `db:"occurred_at"`
+	PrevHash   string           `db:"prev_hash"`
+	Hash       string           `db:"hash"`
+}
+
+func (q *Queries) InsertAuditEvent(ctx context.Context, arg InsertAuditEventParams) error {
+	_, err := q.db.Exec(ctx, InsertAuditEvent,
+		arg.Seq,
+		arg.ActorID,
+		arg.ItemID,
+		arg.Action,
+		arg.Ip,
+		arg.UserAgent,
+		arg.Result,
+		arg.OccurredAt,
+		arg.PrevHash,
+		arg.Hash,
+	)
+	return err
+}
+
+const ItemExists = `-- name: ItemExists :one
+SELECT EXISTS (SELECT 1 FROM items WHERE id_resource = $1)
+`
+
+func (q *Queries) ItemExists(ctx context.Context, idResource pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, ItemExists, idResource)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const ListAPITokens = `-- name: ListAPITokens :many
+SELECT id, user_id, name, token_hash, read_only, item_types, tags, created_at, expires_at, last_used_at
+FROM api_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListAPITokens(ctx context.Context, userID pgtype.UUID) ([]ApiToken, error) {
+	rows, err := q.db.Query(ctx, ListAPITokens, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiToken
+	for rows.Next() {
+		var i ApiToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.TokenHash,
+			&i.ReadOnly,
+			&i.ItemTypes,
+			&i.Tags,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListAuditEvents = `-- name: ListAuditEvents :many
+SELECT seq, actor_id, item_id, action, ip, user_agent, result, occurred_at, prev_hash, hash FROM audit_events
+WHERE (actor_id = $1 OR item_id IN (SELECT id_resource FROM items WHERE user_id = $1))
+  AND ($2::uuid IS NULL OR item_id = $2::uuid)
+  AND ($3::text = '' OR action = $3::text)
+  AND ($4::timestamp IS NULL OR occurred_at >= $4::timestamp)
+  AND ($5::timestamp IS NULL OR occurred_at < $5::timestamp)
+ORDER BY seq DESC
+LIMIT $7 OFFSET $6
+`
+
+type ListAuditEventsParams struct {
+	UserID pgtype.UUID      `db:"user_id"`
+	ItemID pgtype.UUID      `db:"item_id"`
+	Action string           `db:"action"`
+	Since  pgtype.Timestamp `db:"since"`
+	Until  pgtype.Timestamp `db:"until"`
+	Offset int32            `db:"offset"`
+	Limit  int32            `db:"limit"`
+}
+
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, ListAuditEvents,
+		arg.UserID,
+		arg.ItemID,
+		arg.Action,
+		arg.Since,
+		arg.Until,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.Seq,
+			&i.ActorID,
+			&i.ItemID,
+			&i.Action,
+			&i.Ip,
+			&i.UserAgent,
+			&i.Result,
+			&i.OccurredAt,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListCollectionItems = `-- name: ListCollectionItems :many
+SELECT
+    i.id,
+    i.type,
+    i.id_resource,
+    i.created_at,
+    COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) AS updated_at
+FROM collection_items ci
+         JOIN items i ON i.id_resource = ci.item_id
+         LEFT JOIN passwords p ON i.type = 'password' AND i.id_resource = p.id
+         LEFT JOIN notes n ON i.type = 'text' AND i.id_resource = n.id
+         LEFT JOIN cards c ON i.type = 'card' AND i.id_resource = c.id
+         LEFT JOIN binary_entries b ON i.type = 'binary' AND i.id_resource = b.id
+WHERE ci.collection_id = $1 AND COALESCE(p.id, n.id, c.id, b.id) IS NOT NULL
+ORDER BY i.created_at DESC, i.id
+`
+
+type ListCollectionItemsRow struct {
+	ID         pgtype.UUID      `db:"id"`
+	Type       ItemType         `db:"type"`
+	IDResource pgtype.UUID      `db:"id_resource"`
+	CreatedAt  pgtype.Timestamp `db:"created_at"`
+	UpdatedAt  pgtype.Timestamp `db:"updated_at"`
+}
+
+// Items rows outlive their records, so only the items that still have one are listed
+func (q *Queries) ListCollectionItems(ctx context.Context, collectionID pgtype.UUID) ([]ListCollectionItemsRow, error) {
+	rows, err := q.db.Query(ctx, ListCollectionItems, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCollectionItemsRow
+	for rows.Next() {
+		var i ListCollectionItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.IDResource,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListCollections = `-- name: ListCollections :many
+SELECT c.id, c.org_id, c.name, c.created_at, g.read_only
+FROM collections c
+         LEFT JOIN collection_members g ON g.collection_id = c.id AND g.user_id = $1
+WHERE c.org_id = $2 AND ($3::boolean OR g.user_id IS NOT NULL)
+ORDER BY c.name
+`
+
+type ListCollectionsParams struct {
+	UserID         pgtype.UUID `db:"user_id"`
+	OrgID          pgtype.UUID `db:"org_id"`
+	AllCollections bool        `db:"all_collections"`
+}
+
+type ListCollectionsRow struct {
+	ID        pgtype.UUID      `db:"id"`
+	OrgID     pgtype.UUID      `db:"org_id"`
+	Name      string           `db:"name"`
+	CreatedAt pgtype.Timestamp `db:"created_at"`
+	ReadOnly  pgtype.Bool      `db:"read_only"`
+}
+
+func (q *Queries) ListCollections(ctx context.Context, arg ListCollectionsParams) ([]ListCollectionsRow, error) {
+	rows, err := q.db.Query(ctx, ListCollections, arg.UserID, arg.OrgID, arg.AllCollections)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCollectionsRow
+	for rows.Next() {
+		var i ListCollectionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrgID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.ReadOnly,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListEmergencyAccess = `-- name: ListEmergencyAccess :many
+SELECT e.id, e.grantor_id, e.grantee_id, e.access_type, e.wait_days, e.status, e.requested_at, e.approved_at, e.created_at, g.username AS grantor, t.username AS grantee
+FROM emergency_access e
+         JOIN users g ON g.id = e.grantor_id
+         JOIN users t ON t.id = e.grantee_id
+WHERE e.grantor_id = $1 OR e.grantee_id = $1
+ORDER BY e.created_at DESC, e.id
+`
+
+type ListEmergencyAccessRow struct {
+	ID          pgtype.UUID           `db:"id"`
+	GrantorID   pgtype.UUID           `db:"grantor_id"`
+	GranteeID   pgtype.UUID           `db:"grantee_id"`
+	AccessType  EmergencyAccessType   `db:"access_type"`
+	WaitDays    int32                 `db:"wait_days"`
+	Status      EmergencyAccessStatus `db:"status"`
+	RequestedAt pgtype.Timestamp      `db:"requested_at"`
+	ApprovedAt  pgtype.Timestamp      `db:"approved_at"`
+	CreatedAt   pgtype.Timestamp      `db:"created_at"`
+	Grantor     string                `db:"grantor"`
+	Grantee     string                `db:"grantee"`
+}
+
+func (q *Queries) ListEmergencyAccess(ctx context.Context, userID pgtype.UUID) ([]ListEmergencyAccessRow, error) {
+	rows, err := q.db.Query(ctx, ListEmergencyAccess, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmergencyAccessRow
+	for rows.Next() {
+		var i ListEmergencyAccessRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GrantorID,
+			&i.GranteeID,
+			&i.AccessType,
+			&i.WaitDays,
+			&i.Status,
+			&i.RequestedAt,
+			&i.ApprovedAt,
+			&i.CreatedAt,
+			&i.Grantor,
+			&i.Grantee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListLegacyCiphertextUsers = `-- name: ListLegacyCiphertextUsers :many
+SELECT id, key_version FROM users
+WHERE id > $1 AND legacy_ciphertexts AND wrapped_vault_key IS NULL AND previous_encryption_key IS NULL
+ORDER BY id
+LIMIT $2
+`
+
+type ListLegacyCiphertextUsersParams struct {
+	AfterID   pgtype.UUID `db:"after_id"`
+	BatchSize int32       `db:"batch_size"`
+}
+
+type ListLegacyCiphertextUsersRow struct {
+	ID         pgtype.UUID `db:"id"`
+	KeyVersion int32       `db:"key_version"`
+}
+
+func (q *Queries) ListLegacyCiphertextUsers(ctx context.Context, arg ListLegacyCiphertextUsersParams) ([]ListLegacyCiphertextUsersRow, error) {
+	rows, err := q.db.Query(ctx, ListLegacyCiphertextUsers, arg.AfterID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLegacyCiphertextUsersRow
+	for rows.Next() {
+		var i ListLegacyCiphertextUsersRow
+		if err := rows.Scan(&i.ID, &i.KeyVersion); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListOrgMembers = `-- name: ListOrgMembers :many
+SELECT u.username, m.role, m.created_at
+FROM org_members m
+         JOIN users u ON u.id = m.user_id
+WHERE m.org_id = $1
+ORDER BY u.username
+`
+
+type ListOrgMembersRow struct {
+	Username  string           `db:"username"`
+	Role      OrgRole          `db:"role"`
+	CreatedAt pgtype.Timestamp `db:"created_at"`
+}
+
+func (q *Queries) ListOrgMembers(ctx context.Context, orgID pgtype.UUID) ([]ListOrgMembersRow, error) {
+	rows, err := q.db.Query(ctx, ListOrgMembers, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrgMembersRow
+	for rows.Next() {
+		var i ListOrgMembersRow
+		if err := rows.Scan(&i.Username, &i.Role, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListOrganizations = `-- name: ListOrganizations :many
+SELECT o.id, o.name, o.created_at, m.role
+FROM organizations o
+         JOIN org_members m ON m.org_id = o.id
+WHERE m.user_id = $1
+ORDER BY o.name, o.id
+`
+
+type ListOrganizationsRow struct {
+	ID        pgtype.UUID      `db:"id"`
+	Name      string           `db:"name"`
+	CreatedAt pgtype.Timestamp `db:"created_at"`
+	Role      OrgRole          `db:"role"`
+}
+
+func (q *Queries) ListOrganizations(ctx context.Context, userID pgtype.UUID) ([]ListOrganizationsRow, error) {
+	rows, err := q.db.Query(ctx, ListOrganizations, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrganizationsRow
+	for rows.Next() {
+		var i ListOrganizationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListRunningKeyRotations = `-- name: ListRunningKeyRotations :many
+SELECT id, user_id, from_version, to_version, status, total_items, done_items, error, started_at, finished_at, binds_values FROM key_rotations
+WHERE status = 'running'
+ORDER BY started_at
+`
+
+func (q *Queries) ListRunningKeyRotations(ctx context.Context) ([]KeyRotation, error) {
+	rows, err := q.db.Query(ctx, ListRunningKeyRotations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []KeyRotation
+	for rows.Next() {
+		var i KeyRotation
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.FromVersion,
+			&i.ToVersion,
+			&i.Status,
+			&i.TotalItems,
+			&i.DoneItems,
+			&i.Error,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.BindsValues,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListScopedItemIDs = `-- name: ListScopedItemIDs :many
+SELECT i.id_resource
+FROM items i
+WHERE i.user_id = $1
+  AND (cardinality($2::text[]) = 0 OR i.type::text = ANY($2::text[]))
+  AND NOT EXISTS (
+    SELECT 1 FROM jsonb_each_text($3::jsonb) AS tag
+    WHERE NOT EXISTS (
+        SELECT 1 FROM metainfo m
+        WHERE m.item_id = i.id_resource AND m.key = tag.key AND m.value = tag.value
+    )
+)
+`
+
+type ListScopedItemIDsParams struct {
+	UserID    pgtype.UUID `db:"user_id"`
+	ItemTypes []string    `db:"item_types"`
+	Tags      []byte      `db:"tags"`
+}
+
+func (q *Queries) ListScopedItemIDs(ctx context.Context, arg ListScopedItemIDsParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, ListScopedItemIDs, arg.UserID, arg.ItemTypes, arg.Tags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id_resource pgtype.UUID
+		if err := rows.Scan(&id_resource); err != nil {
+			return nil, err
+		}
+		items = append(items, id_resource)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListSends = `-- name: ListSends :many
+SELECT id, user_id, send_type, content, object_name, file_size, password, max_views, views, expires_at, created_at FROM sends
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+`
+
+func (q *Queries) ListSends(ctx context.Context, userID pgtype.UUID) ([]Send, error) {
+	rows, err := q.db.Query(ctx, ListSends, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Send
+	for rows.Next() {
+		var i Send
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.SendType,
+			&i.Content,
+			&i.ObjectName,
+			&i.FileSize,
+			&i.Password,
+			&i.MaxViews,
+			&i.Views,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListSessions = `-- name: ListSessions :many
+SELECT id, user_id, device_name, ip_address, created_at, last_used_at, expires_at
+FROM sessions
+WHERE user_id = $1 AND expires_at > NOW()
+ORDER BY last_used_at DESC
+`
+
+func (q *Queries) ListSessions(ctx context.Context, userID pgtype.UUID) ([]Session, error) {
+	rows, err := q.db.Query(ctx, ListSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.DeviceName,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListShares = `-- name: ListShares :many
+SELECT s.id, s.item_id, s.read_only, s.created_at, u.username AS recipient
+FROM shares s
+         JOIN users u ON u.id = s.recipient_id
+WHERE s.owner_id = $1 AND (NOT $2::boolean OR s.item_id = $3)
+ORDER BY s.created_at DESC, s.id
+`
+
+type ListSharesParams struct {
+	OwnerID pgtype.UUID `db:"owner_id"`
+	ByItem  bool        `db:"by_item"`
+	ItemID  pgtype.UUID `db:"item_id"`
+}
+
+type ListSharesRow struct {
+	ID        pgtype.UUID      `db:"id"`
+	ItemID    pgtype.UUID      `db:"item_id"`
+	ReadOnly  bool             `db:"read_only"`
+	CreatedAt pgtype.Timestamp `db:"created_at"`
+	Recipient string           `db:"recipient"`
+}
+
+func (q *Queries) ListShares(ctx context.Context, arg ListSharesParams) ([]ListSharesRow, error) {
+	rows, err := q.db.Query(ctx, ListShares, arg.OwnerID, arg.ByItem, arg.ItemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSharesRow
+	for rows.Next() {
+		var i ListSharesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ItemID,
+			&i.ReadOnly,
+			&i.CreatedAt,
+			&i.Recipient,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListSigningKeys = `-- name: ListSigningKeys :many
+SELECT id, algorithm, private_key, public_key, created_at, activates_at FROM signing_keys
+ORDER BY activates_at DESC
+`
+
+func (q *Queries) ListSigningKeys(ctx context.Context) ([]SigningKey, error) {
+	rows, err := q.db.Query(ctx, ListSigningKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SigningKey
+	for rows.Next() {
+		var i SigningKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.Algorithm,
+			&i.PrivateKey,
+			&i.PublicKey,
+			&i.CreatedAt,
+			&i.ActivatesAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListStaleBinaryEntries = `-- name: ListStaleBinaryEntries :many
+SELECT id, user_id, file_name, file_size, file_url, created_at, updated_at, version, key_version FROM binary_entries
+WHERE user_id = $1 AND key_version < $2
+ORDER BY id
+    LIMIT $3
+`
+
+type ListStaleBinaryEntriesParams struct {
+	UserID     pgtype.UUID `db:"user_id"`
+	KeyVersion int32       `db:"key_version"`
+	BatchSize  int32       `db:"batch_size"`
+}
+
+func (q *Queries) ListStaleBinaryEntries(ctx context.Context, arg ListStaleBinaryEntriesParams) ([]BinaryEntry, error) {
+	rows, err := q.db.Query(ctx, ListStaleBinaryEntries, arg.UserID, arg.KeyVersion, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BinaryEntry
+	for rows.Next() {
+		var i BinaryEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.FileName,
+			&i.FileSize,
+			&i.FileUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.KeyVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListStaleCards = `-- name: ListStaleCards :many
+SELECT id, user_id, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name, created_at, updated_at, hashed_card_number, version, key_version FROM cards
+WHERE user_id = $1 AND key_version < $2
+ORDER BY id
+    LIMIT $3
 `
 
-func (q *Queries) GetBinaryEntriesByUserID(ctx context.Context, userID pgtype.UUID) ([]BinaryEntry, error) {
-	rows, err := q.db.Query(ctx, GetBinaryEntriesByUserID, userID)
+type ListStaleCardsParams struct {
+	UserID     pgtype.UUID `db:"user_id"`
+	KeyVersion int32       `db:"key_version"`
+	BatchSize  int32       `db:"batch_size"`
+}
+
+func (q *Queries) ListStaleCards(ctx context.Context, arg ListStaleCardsParams) ([]Card, error) {
+	rows, err := q.db.Query(ctx, ListStaleCards, arg.UserID, arg.KeyVersion, arg.BatchSize)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []BinaryEntry
+	var items []Card
 	for rows.Next() {
-		var i BinaryEntry
+		var i Card
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
-			&i.FileName,
-			&i.FileSize,
-			&i.FileUrl,
+			&i.EncryptedCardNumber,
+			&i.EncryptedExpiryDate,
+			&i.EncryptedCvv,
+			&i.CardholderName,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.HashedCardNumber,
+			&i.Version,
+			&i.KeyVersion,
 		); err != nil {
 			return nil, err
 		}
@@ -269,83 +2943,78 @@ func (q *Queries) GetBinaryEntriesByUserID(ctx context.Context, userID pgtype.UU
 	return items, nil
 }
 
-const GetBinaryEntryByID = `-- name: GetBinaryEntryByID :one
-SELECT binary_entries.id, binary_entries.user_id, binary_entries.file_name, binary_entries.file_size, binary_entries.file_url, binary_entries.created_at, binary_entries.updated_at
-FROM binary_entries
-WHERE binary_entries.id = $1 and binary_entries.user_id = $2
+const ListStaleNotes = `-- name: ListStaleNotes :many
+SELECT id, user_id, encrypted_content, created_at, updated_at, version, key_version FROM notes
+WHERE user_id = $1 AND key_version < $2
+ORDER BY id
+    LIMIT $3
 `
 
-type GetBinaryEntryByIDParams struct {
-	ID     pgtype.UUID `db:"id"`
-	UserID pgtype.UUID `db:"user_id"`
+type ListStaleNotesParams struct {
+	UserID     pgtype.UUID `db:"user_id"`
+	KeyVersion int32       `db:"key_version"`
+	BatchSize  int32       `db:"batch_size"`
 }
 
-func (q *Queries) GetBinaryEntryByID(ctx context.Context, arg GetBinaryEntryByIDParams) (BinaryEntry, error) {
-	row := q.db.QueryRow(ctx, GetBinaryEntryByID, arg.ID, arg.UserID)
-	var i BinaryEntry
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.FileName,
-		&i.FileSize,
-		&i.FileUrl,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) ListStaleNotes(ctx context.Context, arg ListStaleNotesParams) ([]Note, error) {
+	rows, err := q.db.Query(ctx, ListStaleNotes, arg.UserID, arg.KeyVersion, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.EncryptedContent,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.KeyVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const GetCardByID = `-- name: GetCardByID :one
-SELECT cards.id, cards.user_id, cards.encrypted_card_number, cards.encrypted_expiry_date, cards.encrypted_cvv, cards.cardholder_name, cards.created_at, cards.updated_at, cards.hashed_card_number
-FROM cards
-WHERE cards.id = $1 and cards.user_id = $2
+const ListStalePasswords = `-- name: ListStalePasswords :many
+SELECT id, user_id, login, password, created_at, updated_at, version, key_version FROM passwords
+WHERE user_id = $1 AND key_version < $2
+ORDER BY id
+    LIMIT $3
 `
 
-type GetCardByIDParams struct {
-	ID     pgtype.UUID `db:"id"`
-	UserID pgtype.UUID `db:"user_id"`
-}
-
-func (q *Queries) GetCardByID(ctx context.Context, arg GetCardByIDParams) (Card, error) {
-	row := q.db.QueryRow(ctx, GetCardByID, arg.ID, arg.UserID)
-	var i Card
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.EncryptedCardNumber,
-		&i.EncryptedExpiryDate,
-		&i.EncryptedCvv,
-		&i.CardholderName,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.HashedCardNumber,
-	)
-	return i, err
+type ListStalePasswordsParams struct {
+	UserID     pgtype.UUID `db:"user_id"`
+	KeyVersion int32       `db:"key_version"`
+	BatchSize  int32       `db:"batch_size"`
 }
 
-const GetCardsByUserID = `-- name: GetCardsByUserID :many
-SELECT id, user_id, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name, created_at, updated_at, hashed_card_number FROM cards WHERE user_id = $1
-`
-
-func (q *Queries) GetCardsByUserID(ctx context.Context, userID pgtype.UUID) ([]Card, error) {
-	rows, err := q.db.Query(ctx, GetCardsByUserID, userID)
+func (q *Queries) ListStalePasswords(ctx context.Context, arg ListStalePasswordsParams) ([]Password, error) {
+	rows, err := q.db.Query(ctx, ListStalePasswords, arg.UserID, arg.KeyVersion, arg.BatchSize)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Card
+	var items []Password
 	for rows.Next() {
-		var i Card
+		var i Password
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
-			&i.EncryptedCardNumber,
-			&i.EncryptedExpiryDate,
-			&i.EncryptedCvv,
-			&i.CardholderName,
+			&i.Login,
+			&i.Password,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.HashedCardNumber,
+			&i.Version,
+			&i.KeyVersion,
 		); err != nil {
 			return nil, err
 		}
@@ -357,7 +3026,81 @@ func (q *Queries) GetCardsByUserID(ctx context.Context, userID pgtype.UUID) ([]C
 	return items, nil
 }
 
-const GetItemsByUserID = `-- name: GetItemsByUserID :many
+const ListTOTPSecretsAfter = `-- name: ListTOTPSecretsAfter :many
+SELECT id, totp_secret::text AS totp_secret FROM users
+WHERE id > $1 AND totp_secret IS NOT NULL
+ORDER BY id
+LIMIT $2
+`
+
+type ListTOTPSecretsAfterParams struct {
+	AfterID   pgtype.UUID `db:"after_id"`
+	BatchSize int32       `db:"batch_size"`
+}
+
+type ListTOTPSecretsAfterRow struct {
+	ID         pgtype.UUID `db:"id"`
+	TotpSecret string      `db:"totp_secret"`
+}
+
+func (q *Queries) ListTOTPSecretsAfter(ctx context.Context, arg ListTOTPSecretsAfterParams) ([]ListTOTPSecretsAfterRow, error) {
+	rows, err := q.db.Query(ctx, ListTOTPSecretsAfter, arg.AfterID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTOTPSecretsAfterRow
+	for rows.Next() {
+		var i ListTOTPSecretsAfterRow
+		if err := rows.Scan(&i.ID, &i.TotpSecret); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUserKeysAfter = `-- name: ListUserKeysAfter :many
+SELECT id, encryption_key FROM users
+WHERE id > $1 AND wrapped_vault_key IS NULL
+ORDER BY id
+LIMIT $2
+`
+
+type ListUserKeysAfterParams struct {
+	AfterID   pgtype.UUID `db:"after_id"`
+	BatchSize int32       `db:"batch_size"`
+}
+
+type ListUserKeysAfterRow struct {
+	ID            pgtype.UUID `db:"id"`
+	EncryptionKey string      `db:"encryption_key"`
+}
+
+func (q *Queries) ListUserKeysAfter(ctx context.Context, arg ListUserKeysAfterParams) ([]ListUserKeysAfterRow, error) {
+	rows, err := q.db.Query(ctx, ListUserKeysAfter, arg.AfterID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserKeysAfterRow
+	for rows.Next() {
+		var i ListUserKeysAfterRow
+		if err := rows.Scan(&i.ID, &i.EncryptionKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListVaultItems = `-- name: ListVaultItems :many
 SELECT
     i.id,
     i.type,
@@ -369,18 +3112,11 @@ FROM items i
          LEFT JOIN notes n ON i.type = 'text' AND i.id_resource = n.id
          LEFT JOIN cards c ON i.type = 'card' AND i.id_resource = c.id
          LEFT JOIN binary_entries b ON i.type = 'binary' AND i.id_resource = b.id
-WHERE i.user_id = $1
-ORDER BY i.created_at DESC
-    LIMIT $2 OFFSET $3
+WHERE i.user_id = $1 AND COALESCE(p.id, n.id, c.id, b.id) IS NOT NULL
+ORDER BY i.created_at DESC, i.id
 `
 
-type GetItemsByUserIDParams struct {
-	UserID pgtype.UUID `db:"user_id"`
-	Limit  int32       `db:"limit"`
-	Offset int32       `db:"offset"`
-}
-
-type GetItemsByUserIDRow struct {
+type ListVaultItemsRow struct {
 	ID         pgtype.UUID      `db:"id"`
 	Type       ItemType         `db:"type"`
 	IDResource pgtype.UUID      `db:"id_resource"`
@@ -388,15 +3124,15 @@ type GetItemsByUserIDRow struct {
 	UpdatedAt  pgtype.Timestamp `db:"updated_at"`
 }
 
-func (q *Queries) GetItemsByUserID(ctx context.Context, arg GetItemsByUserIDParams) ([]GetItemsByUserIDRow, error) {
-	rows, err := q.db.Query(ctx, GetItemsByUserID, arg.UserID, arg.Limit, arg.Offset)
+func (q *Queries) ListVaultItems(ctx context.Context, userID pgtype.UUID) ([]ListVaultItemsRow, error) {
+	rows, err := q.db.Query(ctx, ListVaultItems, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetItemsByUserIDRow
+	var items []ListVaultItemsRow
 	for rows.Next() {
-		var i GetItemsByUserIDRow
+		var i ListVaultItemsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Type,
@@ -414,238 +3150,416 @@ func (q *Queries) GetItemsByUserID(ctx context.Context, arg GetItemsByUserIDPara
 	return items, nil
 }
 
-const GetMetaInfoByItemID = `-- name: GetMetaInfoByItemID :many
-SELECT key, value FROM metainfo WHERE item_id = $1
+const LockAuditChain = `-- name: LockAuditChain :exec
+SELECT pg_advisory_xact_lock(hashtext('audit_events'))
 `
 
-type GetMetaInfoByItemIDRow struct {
-	Key   string `db:"key"`
-	Value string `db:"value"`
+// Held until the transaction ends, so appends read the end of the chain and insert after it one at a time
+func (q *Queries) LockAuditChain(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, LockAuditChain)
+	return err
 }
 
-func (q *Queries) GetMetaInfoByItemID(ctx context.Context, itemID pgtype.UUID) ([]GetMetaInfoByItemIDRow, error) {
-	rows, err := q.db.Query(ctx, GetMetaInfoByItemID, itemID)
+const PromoteEmergencyAccess = `-- name: PromoteEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'approved', approved_at = $1::timestamp
+WHERE status = 'requested' AND requested_at + make_interval(days => wait_days) <= $1::timestamp
+`
+
+func (q *Queries) PromoteEmergencyAccess(ctx context.Context, now pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, PromoteEmergencyAccess, now)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	var items []GetMetaInfoByItemIDRow
-	for rows.Next() {
-		var i GetMetaInfoByItemIDRow
-		if err := rows.Scan(&i.Key, &i.Value); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
+	return result.RowsAffected(), nil
+}
+
+const ReencryptBinaryEntry = `-- name: ReencryptBinaryEntry :execrows
+UPDATE binary_entries
+SET file_url = $1, key_version = $2
+WHERE id = $3 AND version = $4 AND key_version = $5
+`
+
+type ReencryptBinaryEntryParams struct {
+	FileUrl       string      `db:"file_url"`
+	KeyVersion    int32       `db:"key_version"`
+	ID            pgtype.UUID `db:"id"`
+	Version       int64       `db:"version"`
+	OldKeyVersion int32       `db:"old_key_version"`
+}
+
+func (q *Queries) ReencryptBinaryEntry(ctx context.Context, arg ReencryptBinaryEntryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ReencryptBinaryEntry,
+		arg.FileUrl,
+		arg.KeyVersion,
+		arg.ID,
+		arg.Version,
+		arg.OldKeyVersion,
+	)
+	if err != nil {
+		return 0, err
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	return result.RowsAffected(), nil
+}
+
+const ReencryptCard = `-- name: ReencryptCard :execrows
+UPDATE cards
+SET encrypted_card_number = $1, encrypted_expiry_date = $2,
+    encrypted_cvv = $3, key_version = $4
+WHERE id = $5 AND version = $6 AND key_version = $7
+`
+
+type ReencryptCardParams struct {
+	EncryptedCardNumber string      `db:"encrypted_card_number"`
+	EncryptedExpiryDate string      `db:"encrypted_expiry_date"`
+	EncryptedCvv        string      `db:"encrypted_cvv"`
+	KeyVersion          int32       `db:"key_version"`
+	ID                  pgtype.UUID `db:"id"`
+	Version             int64       `db:"version"`
+	OldKeyVersion       int32       `db:"old_key_version"`
+}
+
+func (q *Queries) ReencryptCard(ctx context.Context, arg ReencryptCardParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ReencryptCard,
+		arg.EncryptedCardNumber,
+		arg.EncryptedExpiryDate,
+		arg.EncryptedCvv,
+		arg.KeyVersion,
+		arg.ID,
+		arg.Version,
+		arg.OldKeyVersion,
+	)
+	if err != nil {
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
-const GetNoteByID = `-- name: GetNoteByID :one
-SELECT notes.id, notes.user_id, notes.encrypted_content, notes.created_at, notes.updated_at
-FROM notes
-WHERE notes.id = $1 and notes.user_id = $2
+const ReencryptNote = `-- name: ReencryptNote :execrows
+UPDATE notes
+SET encrypted_content = $1, key_version = $2
+WHERE id = $3 AND version = $4 AND key_version = $5
 `
 
-type GetNoteByIDParams struct {
-	ID     pgtype.UUID `db:"id"`
-	UserID pgtype.UUID `db:"user_id"`
+type ReencryptNoteParams struct {
+	EncryptedContent string      `db:"encrypted_content"`
+	KeyVersion       int32       `db:"key_version"`
+	ID               pgtype.UUID `db:"id"`
+	Version          int64       `db:"version"`
+	OldKeyVersion    int32       `db:"old_key_version"`
 }
 
-func (q *Queries) GetNoteByID(ctx context.Context, arg GetNoteByIDParams) (Note, error) {
-	row := q.db.QueryRow(ctx, GetNoteByID, arg.ID, arg.UserID)
-	var i Note
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.EncryptedContent,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+func (q *Queries) ReencryptNote(ctx context.Context, arg ReencryptNoteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ReencryptNote,
+		arg.EncryptedContent,
+		arg.KeyVersion,
+		arg.ID,
+		arg.Version,
+		arg.OldKeyVersion,
 	)
-	return i, err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const GetNotesByUserID = `-- name: GetNotesByUserID :many
-SELECT id, user_id, encrypted_content, created_at, updated_at FROM notes
-WHERE user_id = $1
+const ReencryptPassword = `-- name: ReencryptPassword :execrows
+UPDATE passwords
+SET password = $1, key_version = $2
+WHERE id = $3 AND version = $4 AND key_version = $5
 `
 
-func (q *Queries) GetNotesByUserID(ctx context.Context, userID pgtype.UUID) ([]Note, error) {
-	rows, err := q.db.Query(ctx, GetNotesByUserID, userID)
+type ReencryptPasswordParams struct {
+	Password      string      `db:"password"`
+	KeyVersion    int32       `db:"key_version"`
+	ID            pgtype.UUID `db:"id"`
+	Version       int64       `db:"version"`
+	OldKeyVersion int32       `db:"old_key_version"`
+}
+
+func (q *Queries) ReencryptPassword(ctx context.Context, arg ReencryptPasswordParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ReencryptPassword,
+		arg.Password,
+		arg.KeyVersion,
+		arg.ID,
+		arg.Version,
+		arg.OldKeyVersion,
+	)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	var items []Note
-	for rows.Next() {
-		var i Note
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.EncryptedContent,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
+	return result.RowsAffected(), nil
+}
+
+const ReencryptShareKey = `-- name: ReencryptShareKey :execrows
+UPDATE share_keys
+SET private_key = $1, key_version = $2
+WHERE user_id = $3 AND key_version = $4
+`
+
+type ReencryptShareKeyParams struct {
+	PrivateKey    string      `db:"private_key"`
+	KeyVersion    int32       `db:"key_version"`
+	UserID        pgtype.UUID `db:"user_id"`
+	OldKeyVersion int32       `db:"old_key_version"`
+}
+
+func (q *Queries) ReencryptShareKey(ctx context.Context, arg ReencryptShareKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ReencryptShareKey,
+		arg.PrivateKey,
+		arg.KeyVersion,
+		arg.UserID,
+		arg.OldKeyVersion,
+	)
+	if err != nil {
+		return 0, err
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	return result.RowsAffected(), nil
+}
+
+const RefreshShare = `-- name: RefreshShare :execrows
+UPDATE shares
+SET payload = $1, item_seq = $2
+WHERE id = $3 AND item_seq = $4
+`
+
+type RefreshShareParams struct {
+	Payload    string      `db:"payload"`
+	ItemSeq    int64       `db:"item_seq"`
+	ID         pgtype.UUID `db:"id"`
+	OldItemSeq int64       `db:"old_item_seq"`
+}
+
+func (q *Queries) RefreshShare(ctx context.Context, arg RefreshShareParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RefreshShare,
+		arg.Payload,
+		arg.ItemSeq,
+		arg.ID,
+		arg.OldItemSeq,
+	)
+	if err != nil {
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
-const GetPasswordEntriesByUserID = `-- name: GetPasswordEntriesByUserID :many
-SELECT id, user_id, login, password, created_at, updated_at FROM passwords
-WHERE user_id = $1
+const RehashUserPassword = `-- name: RehashUserPassword :execrows
+UPDATE users
+SET password = $1
+WHERE id = $2 AND password = $3
 `
 
-func (q *Queries) GetPasswordEntriesByUserID(ctx context.Context, userID pgtype.UUID) ([]Password, error) {
-	rows, err := q.db.Query(ctx, GetPasswordEntriesByUserID, userID)
+type RehashUserPasswordParams struct {
+	NewPassword string      `db:"new_password"`
+	ID          pgtype.UUID `db:"id"`
+	OldPassword string      `db:"old_password"`
+}
+
+func (q *Queries) RehashUserPassword(ctx context.Context, arg RehashUserPasswordParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RehashUserPassword, arg.NewPassword, arg.ID, arg.OldPassword)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Password
-	for rows.Next() {
-		var i Password
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.Login,
-			&i.Password,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
+		return 0, err
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	return result.RowsAffected(), nil
+}
+
+const RejectEmergencyAccess = `-- name: RejectEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'idle', requested_at = NULL, approved_at = NULL
+WHERE id = $1 AND grantor_id = $2 AND status <> 'idle'
+`
+
+type RejectEmergencyAccessParams struct {
+	ID        pgtype.UUID `db:"id"`
+	GrantorID pgtype.UUID `db:"grantor_id"`
+}
+
+// Rejecting also ends access that was approved already
+func (q *Queries) RejectEmergencyAccess(ctx context.Context, arg RejectEmergencyAccessParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RejectEmergencyAccess, arg.ID, arg.GrantorID)
+	if err != nil {
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
-const GetPasswordEntryByID = `-- name: GetPasswordEntryByID :one
-SELECT passwords.id, passwords.user_id, passwords.login, passwords.password, passwords.created_at, passwords.updated_at
-FROM passwords
-WHERE passwords.id = $1 and passwords.user_id = $2
+const RemoveSend = `-- name: RemoveSend :exec
+DELETE FROM sends
+WHERE id = $1
 `
 
-type GetPasswordEntryByIDParams struct {
-	ID     pgtype.UUID `db:"id"`
-	UserID pgtype.UUID `db:"user_id"`
+func (q *Queries) RemoveSend(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, RemoveSend, id)
+	return err
 }
 
-func (q *Queries) GetPasswordEntryByID(ctx context.Context, arg GetPasswordEntryByIDParams) (Password, error) {
-	row := q.db.QueryRow(ctx, GetPasswordEntryByID, arg.ID, arg.UserID)
-	var i Password
+const RequestEmergencyAccess = `-- name: RequestEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'requested', requested_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND grantee_id = $2 AND status = 'idle'
+`
+
+type RequestEmergencyAccessParams struct {
+	ID        pgtype.UUID `db:"id"`
+	GranteeID pgtype.UUID `db:"grantee_id"`
+}
+
+func (q *Queries) RequestEmergencyAccess(ctx context.Context, arg RequestEmergencyAccessParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RequestEmergencyAccess, arg.ID, arg.GranteeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const RetryKeyRotation = `-- name: RetryKeyRotation :one
+UPDATE key_rotations
+SET status = 'running', error = NULL, finished_at = NULL
+WHERE id = $1 AND status = 'failed'
+    RETURNING id, user_id, from_version, to_version, status, total_items, done_items, error, started_at, finished_at, binds_values
+`
+
+func (q *Queries) RetryKeyRotation(ctx context.Context, id pgtype.UUID) (KeyRotation, error) {
+	row := q.db.QueryRow(ctx, RetryKeyRotation, id)
+	var i KeyRotation
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
-		&i.Login,
-		&i.Password,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.FromVersion,
+		&i.ToVersion,
+		&i.Status,
+		&i.TotalItems,
+		&i.DoneItems,
+		&i.Error,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.BindsValues,
 	)
 	return i, err
 }
 
-const GetRefreshToken = `-- name: GetRefreshToken :one
-SELECT id, user_id, token, expires_at
-FROM refresh_tokens
-WHERE token = $1
+const RewrapTOTPSecret = `-- name: RewrapTOTPSecret :execrows
+UPDATE users
+SET totp_secret = $1
+WHERE id = $2 AND totp_secret = $3
 `
 
-type GetRefreshTokenRow struct {
-	ID        pgtype.UUID      `db:"id"`
-	UserID    pgtype.UUID      `db:"user_id"`
-	Token     string           `db:"token"`
-	ExpiresAt pgtype.Timestamp `db:"expires_at"`
+type RewrapTOTPSecretParams struct {
+	NewSecret pgtype.Text `db:"new_secret"`
+	ID        pgtype.UUID `db:"id"`
+	OldSecret pgtype.Text `db:"old_secret"`
 }
 
-func (q *Queries) GetRefreshToken(ctx context.Context, token string) (GetRefreshTokenRow, error) {
-	row := q.db.QueryRow(ctx, GetRefreshToken, token)
-	var i GetRefreshTokenRow
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Token,
-		&i.ExpiresAt,
-	)
-	return i, err
+func (q *Queries) RewrapTOTPSecret(ctx context.Context, arg RewrapTOTPSecretParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RewrapTOTPSecret, arg.NewSecret, arg.ID, arg.OldSecret)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const GetTotalItemCountByUserID = `-- name: GetTotalItemCountByUserID :one
-SELECT COUNT(*) FROM items WHERE user_id = $1
+const RewrapUserKey = `-- name: RewrapUserKey :execrows
+UPDATE users
+SET encryption_key = $1
+WHERE id = $2 AND encryption_key = $3
 `
 
-func (q *Queries) GetTotalItemCountByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
-	row := q.db.QueryRow(ctx, GetTotalItemCountByUserID, userID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+type RewrapUserKeyParams struct {
+	NewKey string      `db:"new_key"`
+	ID     pgtype.UUID `db:"id"`
+	OldKey string      `db:"old_key"`
 }
 
-const GetUserByID = `-- name: GetUserByID :one
-SELECT id, username, email, password, encryption_key FROM users
-WHERE id = $1
+func (q *Queries) RewrapUserKey(ctx context.Context, arg RewrapUserKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, RewrapUserKey, arg.NewKey, arg.ID, arg.OldKey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const SetTOTPSecret = `-- name: SetTOTPSecret :execrows
+UPDATE users
+SET totp_secret = $1
+WHERE id = $2 AND NOT totp_enabled
 `
 
-func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error) {
-	row := q.db.QueryRow(ctx, GetUserByID, id)
-	var i User
-	err := row.Scan(
-		&i.ID,
-		&i.Username,
-		&i.Email,
-		&i.Password,
-		&i.EncryptionKey,
-	)
-	return i, err
+type SetTOTPSecretParams struct {
+	Secret pgtype.Text `db:"secret"`
+	ID     pgtype.UUID `db:"id"`
 }
 
-const GetUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, email, password, encryption_key FROM users
-WHERE username = $1
+func (q *Queries) SetTOTPSecret(ctx context.Context, arg SetTOTPSecretParams) (int64, error) {
+	result, err := q.db.Exec(ctx, SetTOTPSecret, arg.Secret, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const StartKeyRotation = `-- name: StartKeyRotation :one
+WITH rotated AS (
+    UPDATE users
+    SET previous_encryption_key = encryption_key, encryption_key = $1, key_version = key_version + 1
+    WHERE users.id = $2 AND users.key_version = $3
+      AND users.previous_encryption_key IS NULL AND users.wrapped_vault_key IS NULL
+    RETURNING users.id, users.key_version
+)
+INSERT INTO key_rotations (user_id, from_version, to_version, total_items)
+SELECT rotated.id, rotated.key_version - 1, rotated.key_version,
+       (SELECT COUNT(*) FROM items WHERE items.user_id = rotated.id)
+FROM rotated
+    RETURNING id, user_id, from_version, to_version, status, total_items, done_items, error, started_at, finished_at, binds_values
 `
 
-func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User, error) {
-	row := q.db.QueryRow(ctx, GetUserByUsername, username)
-	var i User
+type StartKeyRotationParams struct {
+	NewKey      string      `db:"new_key"`
+	UserID      pgtype.UUID `db:"user_id"`
+	FromVersion int32       `db:"from_version"`
+}
+
+func (q *Queries) StartKeyRotation(ctx context.Context, arg StartKeyRotationParams) (KeyRotation, error) {
+	row := q.db.QueryRow(ctx, StartKeyRotation, arg.NewKey, arg.UserID, arg.FromVersion)
+	var i KeyRotation
 	err := row.Scan(
 		&i.ID,
-		&i.Username,
-		&i.Email,
-		&i.Password,
-		&i.EncryptionKey,
+		&i.UserID,
+		&i.FromVersion,
+		&i.ToVersion,
+		&i.Status,
+		&i.TotalItems,
+		&i.DoneItems,
+		&i.Error,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.BindsValues,
 	)
 	return i, err
 }
 
 const StoreBinaryEntry = `-- name: StoreBinaryEntry :one
-INSERT INTO binary_entries (user_id, file_name, file_url, file_size)
-VALUES ($1, $2, $3, $4)
-    RETURNING id, user_id, file_name, file_size, file_url, created_at, updated_at
+INSERT INTO binary_entries (id, user_id, file_name, file_url, file_size, key_version)
+VALUES ($1, $2, $3, $4, $5, $6)
+    RETURNING id, user_id, file_name, file_size, file_url, created_at, updated_at, version, key_version
 `
 
 type StoreBinaryEntryParams struct {
-	UserID   pgtype.UUID `db:"user_id"`
-	FileName string      `db:"file_name"`
-	FileUrl  string      `db:"file_url"`
-	FileSize int64       `db:"file_size"`
+	ID         pgtype.UUID `db:"id"`
+	UserID     pgtype.UUID `db:"user_id"`
+	FileName   string      `db:"file_name"`
+	FileUrl    string      `db:"file_url"`
+	FileSize   int64       `db:"file_size"`
+	KeyVersion int32       `db:"key_version"`
 }
 
 func (q *Queries) StoreBinaryEntry(ctx context.Context, arg StoreBinaryEntryParams) (BinaryEntry, error) {
 	row := q.db.QueryRow(ctx, StoreBinaryEntry,
+		arg.ID,
 		arg.UserID,
 		arg.FileName,
 		arg.FileUrl,
 		arg.FileSize,
+		arg.KeyVersion,
 	)
 	var i BinaryEntry
 	err := row.Scan(
@@ -656,33 +3570,39 @@ func (q *Queries) StoreBinaryEntry(ctx context.Context, arg StoreBinaryEntryPara
 		&i.FileUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.KeyVersion,
 	)
 	return i, err
 }
 
 const StoreCard = `-- name: StoreCard :one
-INSERT INTO cards (user_id, hashed_card_number, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name)
-VALUES ($1, $2, $3, $4, $5, $6)
-    RETURNING id, user_id, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name, created_at, updated_at, hashed_card_number
+INSERT INTO cards (id, user_id, hashed_card_number, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name, key_version)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    RETURNING id, user_id, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name, created_at, updated_at, hashed_card_number, version, key_version
 `
 
 type StoreCardParams struct {
+	ID                  pgtype.UUID `db:"id"`
 	UserID              pgtype.UUID `db:"user_id"`
 	HashedCardNumber    pgtype.Text `db:"hashed_card_number"`
 	EncryptedCardNumber string      `db:"encrypted_card_number"`
 	EncryptedExpiryDate string      `db:"encrypted_expiry_date"`
 	EncryptedCvv        string      `db:"encrypted_cvv"`
 	CardholderName      string      `db:"cardholder_name"`
+	KeyVersion          int32       `db:"key_version"`
 }
 
 func (q *Queries) StoreCard(ctx context.Context, arg StoreCardParams) (Card, error) {
 	row := q.db.QueryRow(ctx, StoreCard,
+		arg.ID,
 		arg.UserID,
 		arg.HashedCardNumber,
 		arg.EncryptedCardNumber,
 		arg.EncryptedExpiryDate,
 		arg.EncryptedCvv,
 		arg.CardholderName,
+		arg.KeyVersion,
 	)
 	var i Card
 	err := row.Scan(
@@ -695,15 +3615,84 @@ func (q *Queries) StoreCard(ctx context.Context, arg StoreCardParams) (Card, err
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.HashedCardNumber,
+		&i.Version,
+		&i.KeyVersion,
 	)
 	return i, err
 }
 
+const TakeOverAccount = `-- name: TakeOverAccount :execrows
+WITH grant_used AS (
+    UPDATE emergency_access
+    SET status = 'idle', requested_at = NULL, approved_at = NULL
+    WHERE emergency_access.id = $2 AND grantee_id = $3
+      AND status = 'approved' AND access_type = 'takeover'
+    RETURNING grantor_id
+), codes AS (
+    DELETE FROM recovery_codes WHERE user_id IN (SELECT grantor_id FROM grant_used)
+)
+UPDATE users
+SET password = $1, totp_secret = NULL, totp_enabled = false, totp_last_step = 0
+FROM grant_used
+WHERE users.id = grant_used.grantor_id
+`
+
+type TakeOverAccountParams struct {
+	NewPassword string      `db:"new_password"`
+	ID          pgtype.UUID `db:"id"`
+	GranteeID   pgtype.UUID `db:"grantee_id"`
+}
+
+// The grantee sets a new password; the second factor goes too, or the grantee could not sign in with it.
+// The grant returns to idle, so taking over again takes another request.
+func (q *Queries) TakeOverAccount(ctx context.Context, arg TakeOverAccountParams) (int64, error) {
+	result, err := q.db.Exec(ctx, TakeOverAccount, arg.NewPassword, arg.ID, arg.GranteeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const TouchAPIToken = `-- name: TouchAPIToken :exec
+UPDATE api_tokens
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+`
+
+// Tokens are used in bursts, so the last use is recorded once a minute at most
+func (q *Queries) TouchAPIToken(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, TouchAPIToken, id)
+	return err
+}
+
+const TouchSession = `-- name: TouchSession :execrows
+UPDATE sessions
+SET last_used_at = NOW(), ip_address = $1, expires_at = $2
+WHERE id = $3
+`
+
+type TouchSessionParams struct {
+	IpAddress string           `db:"ip_address"`
+	ExpiresAt pgtype.Timestamp `db:"expires_at"`
+	ID        pgtype.UUID      `db:"id"`
+}
+
+func (q *Queries) TouchSession(ctx context.Context, arg TouchSessionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, TouchSession, arg.IpAddress, arg.ExpiresAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const UpdateCard = `-- name: UpdateCard :one
 UPDATE cards
-SET encrypted_card_number = $1, encrypted_expiry_date = $2, encrypted_cvv = $3, cardholder_name = $4, hashed_card_number = $5
-WHERE id = $6
-    RETURNING id, user_id, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name, created_at, updated_at, hashed_card_number
+SET encrypted_card_number = $1, encrypted_expiry_date = $2,
+    encrypted_cvv = $3, cardholder_name = $4, hashed_card_number = $5,
+    key_version = $6, version = version + 1
+WHERE id = $7 AND user_id = $8
+  AND ($9::bigint = 0 OR version = $9::bigint)
+    RETURNING id, user_id, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name, created_at, updated_at, hashed_card_number, version, key_version
 `
 
 type UpdateCardParams struct {
@@ -712,7 +3701,10 @@ type UpdateCardParams struct {
 	EncryptedCvv        string      `db:"encrypted_cvv"`
 	CardholderName      string      `db:"cardholder_name"`
 	HashedCardNumber    pgtype.Text `db:"hashed_card_number"`
+	KeyVersion          int32       `db:"key_version"`
 	ID                  pgtype.UUID `db:"id"`
+	UserID              pgtype.UUID `db:"user_id"`
+	ExpectedVersion     int64       `db:"expected_version"`
 }
 
 func (q *Queries) UpdateCard(ctx context.Context, arg UpdateCardParams) (Card, error) {
@@ -722,7 +3714,10 @@ func (q *Queries) UpdateCard(ctx context.Context, arg UpdateCardParams) (Card, e
 		arg.EncryptedCvv,
 		arg.CardholderName,
 		arg.HashedCardNumber,
+		arg.KeyVersion,
 		arg.ID,
+		arg.UserID,
+		arg.ExpectedVersion,
 	)
 	var i Card
 	err := row.Scan(
@@ -735,25 +3730,54 @@ func (q *Queries) UpdateCard(ctx context.Context, arg UpdateCardParams) (Card, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.HashedCardNumber,
+		&i.Version,
+		&i.KeyVersion,
 	)
 	return i, err
 }
 
+const UpdateKeyRotationProgress = `-- name: UpdateKeyRotationProgress :exec
+UPDATE key_rotations
+SET done_items = GREATEST(total_items - $1::bigint, 0)
+WHERE id = $2
+`
+
+type UpdateKeyRotationProgressParams struct {
+	Remaining int64       `db:"remaining"`
+	ID        pgtype.UUID `db:"id"`
+}
+
+func (q *Queries) UpdateKeyRotationProgress(ctx context.Context, arg UpdateKeyRotationProgressParams) error {
+	_, err := q.db.Exec(ctx, UpdateKeyRotationProgress, arg.Remaining, arg.ID)
+	return err
+}
+
 const UpdatePasswordEntry = `-- name: UpdatePasswordEntry :one
 UPDATE passwords
-SET login = $1, password = $2
-WHERE id = $3
-    RETURNING id, user_id, login, password, created_at, updated_at
+SET login = $1, password = $2, key_version = $3, version = version + 1
+WHERE id = $4 AND user_id = $5
+  AND ($6::bigint = 0 OR version = $6::bigint)
+    RETURNING id, user_id, login, password, created_at, updated_at, version, key_version
 `
 
 type UpdatePasswordEntryParams struct {
-	Login    string      `db:"login"`
-	Password string      `db:"password"`
-	ID       pgtype.UUID `db:"id"`
+	Login           string      `db:"login"`
+	Password        string      `db:"password"`
+	KeyVersion      int32       `db:"key_version"`
+	ID              pgtype.UUID `db:"id"`
+	UserID          pgtype.UUID `db:"user_id"`
+	ExpectedVersion int64       `db:"expected_version"`
 }
 
 func (q *Queries) UpdatePasswordEntry(ctx context.Context, arg UpdatePasswordEntryParams) (Password, error) {
-	row := q.db.QueryRow(ctx, UpdatePasswordEntry, arg.Login, arg.Password, arg.ID)
+	row := q.db.QueryRow(ctx, UpdatePasswordEntry,
+		arg.Login,
+		arg.Password,
+		arg.KeyVersion,
+		arg.ID,
+		arg.UserID,
+		arg.ExpectedVersion,
+	)
 	var i Password
 	err := row.Scan(
 		&i.ID,
@@ -762,6 +3786,223 @@ func (q *Queries) UpdatePasswordEntry(ctx context.Context, arg UpdatePasswordEnt
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.KeyVersion,
+	)
+	return i, err
+}
+
+const UpsertCollectionMember = `-- name: UpsertCollectionMember :execrows
+INSERT INTO collection_members (collection_id, org_id, user_id, read_only)
+SELECT c.id, c.org_id, $1, $2 FROM collections c
+WHERE c.id = $3
+ON CONFLICT (collection_id, user_id) DO UPDATE SET read_only = EXCLUDED.read_only
+`
+
+type UpsertCollectionMemberParams struct {
+	UserID       pgtype.UUID `db:"user_id"`
+	ReadOnly     bool        `db:"read_only"`
+	CollectionID pgtype.UUID `db:"collection_id"`
+}
+
+func (q *Queries) UpsertCollectionMember(ctx context.Context, arg UpsertCollectionMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, UpsertCollectionMember, arg.UserID, arg.ReadOnly, arg.CollectionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const UpsertEmergencyAccess = `-- name: UpsertEmergencyAccess :one
+INSERT INTO emergency_access (grantor_id, grantee_id, access_type, wait_days)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (grantor_id, grantee_id) DO UPDATE
+    SET access_type = EXCLUDED.access_type, wait_days = EXCLUDED.wait_days,
+        status = 'idle', requested_at = NULL, approved_at = NULL
+RETURNING id, grantor_id, grantee_id, access_type, wait_days, status, requested_at, approved_at, created_at
+`
+
+type UpsertEmergencyAccessParams struct {
+	GrantorID  pgtype.UUID         `db:"grantor_id"`
+	GranteeID  pgtype.UUID         `db:"grantee_id"`
+	AccessType EmergencyAccessType `db:"access_type"`
+	WaitDays   int32               `db:"wait_days"`
+}
+
+// Changing the grant of a contact drops a pending or approved request
+func (q *Queries) UpsertEmergencyAccess(ctx context.Context, arg UpsertEmergencyAccessParams) (EmergencyAccess, error) {
+	row := q.db.QueryRow(ctx, UpsertEmergencyAccess,
+		arg.GrantorID,
+		arg.GranteeID,
+		arg.AccessType,
+		arg.WaitDays,
+	)
+	var i EmergencyAccess
+	err := row.Scan(
+		&i.ID,
+		&i.GrantorID,
+		&i.GranteeID,
+		&i.AccessType,
+		&i.WaitDays,
+		&i.Status,
+		&i.RequestedAt,
+		&i.ApprovedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const UpsertOrgMember = `-- name: UpsertOrgMember :one
+INSERT INTO org_members (org_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (org_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING org_id, user_id, role, created_at
+`
+
+type UpsertOrgMemberParams struct {
+	OrgID  pgtype.UUID `db:"org_id"`
+	UserID pgtype.UUID `db:"user_id"`
+	Role   OrgRole     `db:"role"`
+}
+
+func (q *Queries) UpsertOrgMember(ctx context.Context, arg UpsertOrgMemberParams) (OrgMember, error) {
+	row := q.db.QueryRow(ctx, UpsertOrgMember, arg.OrgID, arg.UserID, arg.Role)
+	var i OrgMember
+	err := row.Scan(
+		&i.OrgID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const UpsertShare = `-- name: UpsertShare :one
+INSERT INTO shares (item_id, owner_id, recipient_id, read_only, payload, item_seq)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (item_id, recipient_id) DO UPDATE
+    SET read_only = EXCLUDED.read_only, payload = EXCLUDED.payload, item_seq = EXCLUDED.item_seq
+WHERE shares.owner_id = EXCLUDED.owner_id
+RETURNING id, item_id, owner_id, recipient_id, read_only, payload, item_seq, created_at
+`
+
+type UpsertShareParams struct {
+	ItemID      pgtype.UUID `db:"item_id"`
+	OwnerID     pgtype.UUID `db:"owner_id"`
+	RecipientID pgtype.UUID `db:"recipient_id"`
+	ReadOnly    bool        `db:"read_only"`
+	Payload     string      `db:"payload"`
+	ItemSeq     int64       `db:"item_seq"`
+}
+
+func (q *Queries) UpsertShare(ctx context.Context, arg UpsertShareParams) (Share, error) {
+	row := q.db.QueryRow(ctx, UpsertShare,
+		arg.ItemID,
+		arg.OwnerID,
+		arg.RecipientID,
+		arg.ReadOnly,
+		arg.Payload,
+		arg.ItemSeq,
+	)
+	var i Share
+	err := row.Scan(
+		&i.ID,
+		&i.ItemID,
+		&i.OwnerID,
+		&i.RecipientID,
+		&i.ReadOnly,
+		&i.Payload,
+		&i.ItemSeq,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const UseRecoveryCode = `-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes
+SET used_at = NOW()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+`
+
+type UseRecoveryCodeParams struct {
+	UserID   pgtype.UUID `db:"user_id"`
+	CodeHash string      `db:"code_hash"`
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, UseRecoveryCode, arg.UserID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const UseRefreshToken = `-- name: UseRefreshToken :execrows
+UPDATE refresh_tokens
+SET used_at = NOW(), session_id = $1
+WHERE token_hash = $2 AND used_at IS NULL
+`
+
+type UseRefreshTokenParams struct {
+	SessionID pgtype.UUID `db:"session_id"`
+	TokenHash string      `db:"token_hash"`
+}
+
+func (q *Queries) UseRefreshToken(ctx context.Context, arg UseRefreshTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, UseRefreshToken, arg.SessionID, arg.TokenHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const UseTOTPStep = `-- name: UseTOTPStep :execrows
+UPDATE users
+SET totp_last_step = $1
+WHERE id = $2 AND totp_enabled AND totp_last_step < $1
+`
+
+type UseTOTPStepParams struct {
+	Step int64       `db:"step"`
+	ID   pgtype.UUID `db:"id"`
+}
+
+func (q *Queries) UseTOTPStep(ctx context.Context, arg UseTOTPStepParams) (int64, error) {
+	result, err := q.db.Exec(ctx, UseTOTPStep, arg.Step, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const ViewSend = `-- name: ViewSend :one
+UPDATE sends
+SET views = views + 1
+WHERE id = $1 AND views < max_views AND expires_at > $2::timestamp
+RETURNING id, user_id, send_type, content, object_name, file_size, password, max_views, views, expires_at, created_at
+`
+
+type ViewSendParams struct {
+	ID  pgtype.UUID      `db:"id"`
+	Now pgtype.Timestamp `db:"now"`
+}
+
+// Counting the view and checking the limits in one statement lets every view through once
+func (q *Queries) ViewSend(ctx context.Context, arg ViewSendParams) (Send, error) {
+	row := q.db.QueryRow(ctx, ViewSend, arg.ID, arg.Now)
+	var i Send
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SendType,
+		&i.Content,
+		&i.ObjectName,
+		&i.FileSize,
+		&i.Password,
+		&i.MaxViews,
+		&i.Views,
+		&i.ExpiresAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,61 @@
+// Package emergency approves the requests for emergency access whose wait is over.
+package emergency
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Storage keeps the emergency access grants, see storage.DBStorage.
+type Storage interface {
+	PromoteEmergencyAccess(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Promoter approves requests for emergency access that the grantor left unanswered for the wait of the
+// grant. Requests wait in days, so checking every interval approves them late by at most the interval.
+type Promoter struct {
+	storage  Storage
+	interval time.Duration
+	logger   *zerolog.Logger
+}
+
+// NewPromoter creates a promoter that checks for due requests every interval.
+func NewPromoter(storage Storage, interval time.Duration, log *zerolog.Logger) *Promoter {
+	return &Promoter{
+		storage:  storage,
+		interval: interval,
+		logger:   log,
+	}
+}
+
+// Run promotes due requests every interval until the context is cancelled.
+func (p *Promoter) Run(ctx context.Context) {
+	for {
+		if _, err := p.Promote(ctx, time.Now()); err != nil {
+			p.logger.Error().Err(err).Msg("error promoting emergency access")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.interval):
+		}
+	}
+}
+
+// Promote approves every request whose wait is over at now. It returns how many it approved.
+func (p *Promoter) Promote(ctx context.Context, now time.Time) (int64, error) {
+	promoted, err := p.storage.PromoteEmergencyAccess(ctx, now)
+	if err != nil {
+		return 0, errors.Wrap(err, "error approving emergency access")
+	}
+
+	if promoted > 0 {
+		p.logger.Info().Int64("grants", promoted).Msg("emergency access approved after the wait")
+	}
+
+	return promoted, nil
+}
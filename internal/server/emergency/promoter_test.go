@@ -0,0 +1,110 @@
+//nolint:exhaustruct
+package emergency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/emergency"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+// requestAccess makes the grantee a trusted contact of a new grantor, and requests access.
+func requestAccess(t *testing.T, storage *testutils.MockDBStorage, granteeID pgtype.UUID, waitDays int32) {
+	t.Helper()
+
+	grantorID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	storage.AddTestUser(db.User{ID: grantorID, Username: uuid.NewString()})
+
+	access, err := storage.UpsertEmergencyAccess(t.Context(), db.UpsertEmergencyAccessParams{
+		GrantorID:  grantorID,
+		GranteeID:  granteeID,
+		AccessType: db.EmergencyAccessTypeView,
+		WaitDays:   waitDays,
+	})
+	require.NoError(t, err)
+
+	requested, err := storage.RequestEmergencyAccess(t.Context(), access.ID, granteeID)
+	require.NoError(t, err)
+	require.True(t, requested)
+}
+
+func TestPromoter_Promote(t *testing.T) {
+	t.Parallel()
+
+	storage := testutils.SetupMockUserStorage("")
+	granteeID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	storage.AddTestUser(db.User{ID: granteeID, Username: "grantee"})
+
+	requestAccess(t, storage, granteeID, 1)
+	requestAccess(t, storage, granteeID, 7)
+
+	promoter := emergency.NewPromoter(storage, time.Minute, testutils.GetTLogger())
+
+	promoted, err := promoter.Promote(t.Context(), time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, promoted)
+
+	promoted, err = promoter.Promote(t.Context(), time.Now().Add(25*time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, promoted)
+
+	// Approved requests are not approved again
+	promoted, err = promoter.Promote(t.Context(), time.Now().AddDate(0, 0, 8))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, promoted)
+
+	grants, err := storage.ListEmergencyAccess(t.Context(), granteeID)
+	require.NoError(t, err)
+
+	for _, grant := range grants {
+		assert.Equal(t, db.EmergencyAccessStatusApproved, grant.Status)
+		assert.True(t, grant.ApprovedAt.Valid)
+	}
+}
+
+func TestPromoter_Error(t *testing.T) {
+	t.Parallel()
+
+	storage := testutils.SetupMockUserStorage("")
+	storage.CallError = errors.New("db error")
+
+	promoter := emergency.NewPromoter(storage, time.Minute, testutils.GetTLogger())
+
+	_, err := promoter.Promote(t.Context(), time.Now())
+	require.ErrorContains(t, err, "error approving emergency access")
+}
+
+func TestPromoter_Run(t *testing.T) {
+	t.Parallel()
+
+	storage := testutils.SetupMockUserStorage("")
+	granteeID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	storage.AddTestUser(db.User{ID: granteeID, Username: "grantee"})
+
+	promoter := emergency.NewPromoter(storage, time.Millisecond, testutils.GetTLogger())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+
+	go func() {
+		promoter.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("promoter did not stop")
+	}
+}
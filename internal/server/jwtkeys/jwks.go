@@ -0,0 +1,89 @@
+package jwtkeys
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// JWKSPath is where ServeHTTP is mounted, the usual well-known location.
+const JWKSPath = "/.well-known/jwks.json"
+
+// coordinateSize is the length of a P-256 coordinate.
+const coordinateSize = 32
+
+// JWK is the public half of a signing key, see RFC 7517 and RFC 8037.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y,omitempty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+}
+
+// JWKSet is the document served at JWKSPath.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys that verify tokens, including keys that do not sign yet.
+func (k *Keys) JWKS() JWKSet {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	set := JWKSet{Keys: make([]JWK, 0, len(k.keys))}
+
+	for _, key := range k.keys {
+		jwk := JWK{KeyID: key.id, Algorithm: key.algorithm, Use: "sig"}
+
+		switch public := key.private.Public().(type) {
+		case ed25519.PublicKey:
+			jwk.KeyType, jwk.Curve = "OKP", "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		case *ecdsa.PublicKey:
+			point, err := public.ECDH()
+			if err != nil {
+				continue
+			}
+
+			// Uncompressed point: 0x04, X, Y
+			raw := point.Bytes()
+			jwk.KeyType, jwk.Curve = "EC", "P-256"
+			jwk.X = base64.RawURLEncoding.EncodeToString(raw[1 : 1+coordinateSize])
+			jwk.Y = base64.RawURLEncoding.EncodeToString(raw[1+coordinateSize:])
+		default:
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+// ServeHTTP serves the JWKS. Clients may cache it for a refresh interval, since new keys are published
+// two intervals before they sign.
+func (k *Keys) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := json.Marshal(k.JWKS())
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/jwk-set+json")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(k.opts.Refresh.Seconds())))
+	_, _ = w.Write(body)
+}
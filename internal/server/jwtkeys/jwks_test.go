@@ -0,0 +1,78 @@
+package jwtkeys_test
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/jwtkeys"
+)
+
+func TestServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	keys, _, _ := newKeys(t, testOptions(jwtkeys.AlgEdDSA))
+
+	token, err := keys.Sign(userID, time.Now(), time.Hour)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	keys.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, jwtkeys.JWKSPath, nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/jwk-set+json", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "public, max-age=60", recorder.Header().Get("Cache-Control"))
+
+	var set jwtkeys.JWKSet
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &set))
+	require.Len(t, set.Keys, 1)
+
+	jwk := set.Keys[0]
+	assert.Equal(t, "OKP", jwk.KeyType)
+	assert.Equal(t, "Ed25519", jwk.Curve)
+	assert.Equal(t, "EdDSA", jwk.Algorithm)
+	assert.Equal(t, "sig", jwk.Use)
+
+	// The published key checks the signature on its own.
+	public, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(public, []byte(parts[0]+"."+parts[1]), signature))
+
+	recorder = httptest.NewRecorder()
+	keys.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, jwtkeys.JWKSPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestJWKS_ES256(t *testing.T) {
+	t.Parallel()
+
+	keys, _, _ := newKeys(t, testOptions(jwtkeys.AlgES256))
+
+	set := keys.JWKS()
+	require.Len(t, set.Keys, 1)
+
+	jwk := set.Keys[0]
+	assert.Equal(t, "EC", jwk.KeyType)
+	assert.Equal(t, "P-256", jwk.Curve)
+
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	require.NoError(t, err)
+	y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	require.NoError(t, err)
+
+	_, err = ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...))
+	require.NoError(t, err)
+}
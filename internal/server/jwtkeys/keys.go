@@ -0,0 +1,325 @@
+// Package jwtkeys signs access tokens with rotating asymmetric keys. The keys are kept in the database, so
+// every server replica signs with the same one, and their public halves are published as a JWKS.
+package jwtkeys
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// Signing algorithms, named as in the alg header.
+const (
+	AlgEdDSA = "EdDSA"
+	AlgES256 = "ES256"
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("unknown signing algorithm")
+	ErrNoSigningKey     = errors.New("no active signing key")
+	ErrInvalidOptions   = errors.New("invalid token options")
+)
+
+// Store keeps the signing keys, see storage.DBStorage.
+type Store interface {
+	ListSigningKeys(ctx context.Context) ([]db.SigningKey, error)
+	CreateSigningKey(ctx context.Context, params db.CreateSigningKeyParams) (bool, error)
+	DeleteSigningKey(ctx context.Context, keyID string) error
+}
+
+// KeyWrapper protects the private keys at rest, see kms.KeyProvider.
+type KeyWrapper interface {
+	Wrap(ctx context.Context, key string) (string, error)
+	Unwrap(ctx context.Context, wrapped string) (string, error)
+}
+
+// Options configures signing and rotation.
+type Options struct {
+	Algorithm string
+	Issuer    string
+	Audience  string
+	// Rotation is how long a key signs before the next one takes over.
+	Rotation time.Duration
+	// Refresh is how often keys are reloaded and rotation is checked. A new key is published two refreshes
+	// before it signs, so every replica and JWKS client knows it by then.
+	Refresh time.Duration
+	// Retention is how long a replaced key still verifies: the longest lifetime of a token it signed.
+	Retention time.Duration
+	// LegacySecret verifies HS256 tokens issued before asymmetric keys; it never signs.
+	LegacySecret string
+}
+
+// Validate checks the algorithm and that a key signs for longer than it takes to publish the next one.
+func (o Options) Validate() error {
+	if o.Algorithm != AlgEdDSA && o.Algorithm != AlgES256 {
+		return errors.Wrapf(ErrUnknownAlgorithm, "%q", o.Algorithm)
+	}
+
+	if o.Issuer == "" || o.Audience == "" {
+		return errors.Wrap(ErrInvalidOptions, "issuer and audience are required")
+	}
+
+	if o.Refresh <= 0 || o.Rotation <= o.publishAhead() {
+		return errors.Wrap(ErrInvalidOptions, "rotation must be longer than two refresh intervals")
+	}
+
+	return nil
+}
+
+func (o Options) publishAhead() time.Duration {
+	return 2 * o.Refresh //nolint:mnd
+}
+
+type signingKey struct {
+	id          string
+	algorithm   string
+	private     crypto.Signer
+	activatesAt time.Time
+}
+
+// Keys signs and verifies access tokens with the keys of the store.
+type Keys struct {
+	store   Store
+	wrapper KeyWrapper
+	opts    Options
+	logger  *zerolog.Logger
+
+	mu   sync.RWMutex
+	keys []signingKey // the latest to activate first
+}
+
+// New creates the key set; Start loads it.
+func New(store Store, wrapper KeyWrapper, opts Options, log *zerolog.Logger) *Keys {
+	return &Keys{
+		store:   store,
+		wrapper: wrapper,
+		opts:    opts,
+		logger:  log,
+		mu:      sync.RWMutex{},
+		keys:    nil,
+	}
+}
+
+// Start creates the first key of a new deployment, which signs right away, and loads the keys.
+func (k *Keys) Start(ctx context.Context) error {
+	if _, err := k.Rotate(ctx, time.Now()); err != nil {
+		return err
+	}
+
+	return k.Load(ctx)
+}
+
+// Run rotates and reloads the keys every refresh interval until ctx is done.
+func (k *Keys) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(k.opts.Refresh):
+		}
+
+		if _, err := k.Rotate(ctx, time.Now()); err != nil {
+			k.logger.Error().Err(err).Msg("error rotating signing keys")
+		}
+
+		if err := k.Load(ctx); err != nil {
+			k.logger.Error().Err(err).Msg("error loading signing keys")
+		}
+	}
+}
+
+// Rotate adds the next key once the active one has signed for the rotation period, less the time it takes
+// to publish, or when the algorithm changed, unless the next key is published already. Keys replaced for
+// longer than the retention are deleted. It reports whether a key was added.
+func (k *Keys) Rotate(ctx context.Context, now time.Time) (bool, error) {
+	keys, err := k.store.ListSigningKeys(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "error listing signing keys")
+	}
+
+	if err := k.prune(ctx, keys, now); err != nil {
+		return false, err
+	}
+
+	// The first key has nobody to publish it to yet
+	var latest time.Time
+
+	activatesAt := now
+
+	if len(keys) > 0 {
+		newest := keys[0]
+		latest = newest.ActivatesAt.Time
+
+		// A published key that does not sign yet is the rotation in progress
+		if latest.After(now) {
+			return false, nil
+		}
+
+		if newest.Algorithm == k.opts.Algorithm && now.Before(latest.Add(k.opts.Rotation-k.opts.publishAhead())) {
+			return false, nil
+		}
+
+		activatesAt = now.Add(k.opts.publishAhead())
+	}
+
+	params, err := k.newKey(ctx, activatesAt)
+	if err != nil {
+		return false, err
+	}
+
+	params.Latest = pgtype.Timestamp{Time: latest, Valid: true}
+
+	created, err := k.store.CreateSigningKey(ctx, params)
+	if err != nil {
+		return false, errors.Wrap(err, "error storing signing key")
+	}
+
+	if created {
+		k.logger.Info().Str("kid", params.ID).Time("activates_at", activatesAt).Msg("signing key created")
+	}
+
+	return created, nil
+}
+
+// Load reads the keys from the store. Keys that cannot be decrypted are skipped, so one broken key
+// does not stop the others.
+func (k *Keys) Load(ctx context.Context) error {
+	rows, err := k.store.ListSigningKeys(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error listing signing keys")
+	}
+
+	keys := make([]signingKey, 0, len(rows))
+
+	for _, row := range rows {
+		key, err := k.parseKey(ctx, row)
+		if err != nil {
+			k.logger.Error().Err(err).Str("kid", row.ID).Msg("skipping signing key")
+
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.mu.Unlock()
+
+	return nil
+}
+
+// prune deletes the keys whose successor has been signing for longer than the retention.
+func (k *Keys) prune(ctx context.Context, keys []db.SigningKey, now time.Time) error {
+	for i := 1; i < len(keys); i++ {
+		if now.Sub(keys[i-1].ActivatesAt.Time) <= k.opts.Retention {
+			continue
+		}
+
+		if err := k.store.DeleteSigningKey(ctx, keys[i].ID); err != nil {
+			return errors.Wrap(err, "error deleting signing key")
+		}
+
+		k.logger.Info().Str("kid", keys[i].ID).Msg("signing key retired")
+	}
+
+	return nil
+}
+
+func (k *Keys) newKey(ctx context.Context, activatesAt time.Time) (db.CreateSigningKeyParams, error) {
+	var (
+		private crypto.Signer
+		err     error
+	)
+
+	switch k.opts.Algorithm {
+	case AlgEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	case AlgES256:
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		err = errors.Wrapf(ErrUnknownAlgorithm, "%q", k.opts.Algorithm)
+	}
+
+	if err != nil {
+		return db.CreateSigningKeyParams{}, errors.Wrap(err, "error generating signing key")
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return db.CreateSigningKeyParams{}, errors.Wrap(err, "error encoding signing key")
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return db.CreateSigningKeyParams{}, errors.Wrap(err, "error encoding public key")
+	}
+
+	wrapped, err := k.wrapper.Wrap(ctx, base64.StdEncoding.EncodeToString(privateDER))
+	if err != nil {
+		return db.CreateSigningKeyParams{}, errors.Wrap(err, "error wrapping signing key")
+	}
+
+	return db.CreateSigningKeyParams{
+		ID:          rand.Text(),
+		Algorithm:   k.opts.Algorithm,
+		PrivateKey:  wrapped,
+		PublicKey:   base64.StdEncoding.EncodeToString(publicDER),
+		ActivatesAt: pgtype.Timestamp{Time: activatesAt, Valid: true},
+		Latest:      pgtype.Timestamp{},
+	}, nil
+}
+
+func (k *Keys) parseKey(ctx context.Context, row db.SigningKey) (signingKey, error) {
+	encoded, err := k.wrapper.Unwrap(ctx, row.PrivateKey)
+	if err != nil {
+		return signingKey{}, errors.Wrap(err, "error unwrapping signing key")
+	}
+
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return signingKey{}, errors.Wrap(err, "error decoding signing key")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return signingKey{}, errors.Wrap(err, "error parsing signing key")
+	}
+
+	var private crypto.Signer
+
+	switch parsed := parsed.(type) {
+	case ed25519.PrivateKey:
+		if row.Algorithm == AlgEdDSA {
+			private = parsed
+		}
+	case *ecdsa.PrivateKey:
+		if row.Algorithm == AlgES256 && parsed.Curve == elliptic.P256() {
+			private = parsed
+		}
+	}
+
+	if private == nil {
+		return signingKey{}, errors.Wrapf(ErrUnknownAlgorithm, "key does not match %q", row.Algorithm)
+	}
+
+	return signingKey{
+		id:          row.ID,
+		algorithm:   row.Algorithm,
+		private:     private,
+		activatesAt: row.ActivatesAt.Time,
+	}, nil
+}
@@ -0,0 +1,353 @@
+//nolint:exhaustruct
+package jwtkeys_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/jwtkeys"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	secure "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+const userID = "7f9c24e5-2b1a-4c3d-9e8f-0a1b2c3d4e5f"
+
+func testOptions(algorithm string) jwtkeys.Options {
+	return jwtkeys.Options{
+		Algorithm: algorithm,
+		Issuer:    "test-issuer",
+		Audience:  "test-audience",
+		Rotation:  24 * time.Hour,
+		Refresh:   time.Minute,
+		Retention: time.Hour,
+	}
+}
+
+func newWrapper(t *testing.T) *kms.LocalProvider {
+	t.Helper()
+
+	masterKey, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	return kms.NewLocalProvider(secure.NewString(masterKey))
+}
+
+func newKeys(t *testing.T, opts jwtkeys.Options) (*jwtkeys.Keys, *testutils.MockDBStorage, *kms.LocalProvider) {
+	t.Helper()
+
+	store := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+	wrapper := newWrapper(t)
+	keys := jwtkeys.New(store, wrapper, opts, testutils.GetTLogger())
+	require.NoError(t, keys.Start(t.Context()))
+
+	return keys, store, wrapper
+}
+
+func TestSignVerify(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range []string{jwtkeys.AlgEdDSA, jwtkeys.AlgES256} {
+		t.Run(algorithm, func(t *testing.T) {
+			t.Parallel()
+
+			keys, _, _ := newKeys(t, testOptions(algorithm))
+			now := time.Now()
+
+			token, err := keys.Sign(userID, now, time.Hour)
+			require.NoError(t, err)
+
+			verified, err := keys.Verify(token)
+			require.NoError(t, err)
+			assert.Equal(t, userID, verified)
+
+			claims := &jwt.RegisteredClaims{}
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, claims)
+			require.NoError(t, err)
+
+			assert.Equal(t, algorithm, parsed.Method.Alg())
+			assert.Equal(t, keys.JWKS().Keys[0].KeyID, parsed.Header["kid"])
+			assert.Equal(t, "test-issuer", claims.Issuer)
+			assert.Equal(t, jwt.ClaimStrings{"test-audience"}, claims.Audience)
+			assert.Equal(t, userID, claims.Subject)
+			assert.Equal(t, now.Unix(), claims.IssuedAt.Unix())
+			assert.Equal(t, now.Add(time.Hour).Unix(), claims.ExpiresAt.Unix())
+
+			// Tokens issued in the same second still differ.
+			again, err := keys.Sign(userID, now, time.Hour)
+			require.NoError(t, err)
+			assert.NotEqual(t, token, again)
+		})
+	}
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	t.Parallel()
+
+	opts := testOptions(jwtkeys.AlgEdDSA)
+	keys, store, wrapper := newKeys(t, opts)
+
+	token, err := keys.Sign(userID, time.Now(), time.Hour)
+	require.NoError(t, err)
+
+	// Another issuer or audience sharing the keys does not accept it.
+	otherIssuer := opts
+	otherIssuer.Issuer = "other-issuer"
+	other := jwtkeys.New(store, wrapper, otherIssuer, testutils.GetTLogger())
+	require.NoError(t, other.Load(t.Context()))
+	_, err = other.Verify(token)
+	require.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+
+	otherAudience := opts
+	otherAudience.Audience = "other-audience"
+	other = jwtkeys.New(store, wrapper, otherAudience, testutils.GetTLogger())
+	require.NoError(t, other.Load(t.Context()))
+	_, err = other.Verify(token)
+	require.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+
+	expired, err := keys.Sign(userID, time.Now(), -time.Minute)
+	require.NoError(t, err)
+	_, err = keys.Verify(expired)
+	require.ErrorIs(t, err, jwt.ErrTokenExpired)
+
+	// Keys of another deployment are unknown.
+	foreign, _, _ := newKeys(t, opts)
+	foreignToken, err := foreign.Sign(userID, time.Now(), time.Hour)
+	require.NoError(t, err)
+	_, err = keys.Verify(foreignToken)
+	require.ErrorIs(t, err, jwtkeys.ErrUnknownKey)
+
+	_, err = keys.Verify("not-a-token")
+	require.Error(t, err)
+}
+
+func TestVerify_AlgorithmMismatch(t *testing.T) {
+	t.Parallel()
+
+	keys, _, _ := newKeys(t, testOptions(jwtkeys.AlgEdDSA))
+
+	attacker, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.RegisteredClaims{
+		Issuer:    "test-issuer",
+		Subject:   userID,
+		Audience:  jwt.ClaimStrings{"test-audience"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	forged.Header["kid"] = keys.JWKS().Keys[0].KeyID
+
+	token, err := forged.SignedString(attacker)
+	require.NoError(t, err)
+
+	_, err = keys.Verify(token)
+	require.ErrorIs(t, err, jwtkeys.ErrAlgorithmMismatch)
+}
+
+func TestVerify_Legacy(t *testing.T) {
+	t.Parallel()
+
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("old-secret"))
+	require.NoError(t, err)
+
+	keys, _, _ := newKeys(t, testOptions(jwtkeys.AlgEdDSA))
+	_, err = keys.Verify(legacy)
+	require.ErrorIs(t, err, jwtkeys.ErrUnknownKey)
+
+	opts := testOptions(jwtkeys.AlgEdDSA)
+	opts.LegacySecret = "old-secret"
+	keys, _, _ = newKeys(t, opts)
+
+	verified, err := keys.Verify(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, userID, verified)
+
+	opts.LegacySecret = "another-secret"
+	keys, _, _ = newKeys(t, opts)
+	_, err = keys.Verify(legacy)
+	require.ErrorIs(t, err, jwt.ErrSignatureInvalid)
+}
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+
+	opts := testOptions(jwtkeys.AlgEdDSA)
+	keys, _, _ := newKeys(t, opts)
+	start := time.Now()
+	first := keys.JWKS().Keys[0].KeyID
+
+	oldToken, err := keys.Sign(userID, start, time.Hour)
+	require.NoError(t, err)
+
+	rotated, err := keys.Rotate(t.Context(), start.Add(opts.Rotation/2))
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	// The next key is published two refreshes before it signs.
+	publishedAt := start.Add(opts.Rotation - 2*opts.Refresh)
+	rotated, err = keys.Rotate(t.Context(), publishedAt)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+
+	rotated, err = keys.Rotate(t.Context(), publishedAt)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	require.NoError(t, keys.Load(t.Context()))
+	require.Len(t, keys.JWKS().Keys, 2)
+
+	second := keys.JWKS().Keys[0].KeyID
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, first, signedBy(t, keys, publishedAt))
+	assert.Equal(t, second, signedBy(t, keys, publishedAt.Add(2*opts.Refresh)))
+
+	// The first key verifies until its tokens have expired.
+	_, err = keys.Verify(oldToken)
+	require.NoError(t, err)
+
+	_, err = keys.Rotate(t.Context(), publishedAt.Add(2*opts.Refresh+opts.Retention))
+	require.NoError(t, err)
+	require.NoError(t, keys.Load(t.Context()))
+	require.Len(t, keys.JWKS().Keys, 2)
+
+	_, err = keys.Rotate(t.Context(), publishedAt.Add(2*opts.Refresh+opts.Retention+time.Second))
+	require.NoError(t, err)
+	require.NoError(t, keys.Load(t.Context()))
+	require.Len(t, keys.JWKS().Keys, 1)
+	assert.Equal(t, second, keys.JWKS().Keys[0].KeyID)
+
+	_, err = keys.Verify(oldToken)
+	require.ErrorIs(t, err, jwtkeys.ErrUnknownKey)
+}
+
+func TestRotate_AlgorithmChange(t *testing.T) {
+	t.Parallel()
+
+	keys, store, wrapper := newKeys(t, testOptions(jwtkeys.AlgEdDSA))
+
+	ecKeys := jwtkeys.New(store, wrapper, testOptions(jwtkeys.AlgES256), testutils.GetTLogger())
+
+	rotated, err := ecKeys.Rotate(t.Context(), time.Now())
+	require.NoError(t, err)
+	assert.True(t, rotated)
+
+	// The EdDSA side waits for the published key instead of adding its own.
+	rotated, err = keys.Rotate(t.Context(), time.Now())
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	require.NoError(t, ecKeys.Load(t.Context()))
+
+	set := ecKeys.JWKS()
+	require.Len(t, set.Keys, 2)
+	assert.Equal(t, "EC", set.Keys[0].KeyType)
+	assert.Equal(t, "OKP", set.Keys[1].KeyType)
+
+	// Until the new key activates, tokens are still signed with EdDSA and verify on both sides.
+	token, err := ecKeys.Sign(userID, time.Now(), time.Hour)
+	require.NoError(t, err)
+
+	_, err = keys.Verify(token)
+	require.NoError(t, err)
+}
+
+func TestStart_Errors(t *testing.T) {
+	t.Parallel()
+
+	store := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+	store.CallError = errors.New("db error")
+
+	keys := jwtkeys.New(store, newWrapper(t), testOptions(jwtkeys.AlgEdDSA), testutils.GetTLogger())
+	require.ErrorContains(t, keys.Start(t.Context()), "db error")
+
+	_, err := keys.Sign(userID, time.Now(), time.Hour)
+	require.ErrorIs(t, err, jwtkeys.ErrNoSigningKey)
+}
+
+func TestLoad_SkipsBrokenKeys(t *testing.T) {
+	t.Parallel()
+
+	opts := testOptions(jwtkeys.AlgEdDSA)
+	_, store, _ := newKeys(t, opts)
+
+	// Wrapped with another master key, the key cannot be read.
+	keys := jwtkeys.New(store, newWrapper(t), opts, testutils.GetTLogger())
+	require.NoError(t, keys.Load(t.Context()))
+	assert.Empty(t, keys.JWKS().Keys)
+
+	_, err := keys.Sign(userID, time.Now(), time.Hour)
+	require.ErrorIs(t, err, jwtkeys.ErrNoSigningKey)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	opts := testOptions(jwtkeys.AlgEdDSA)
+	opts.Refresh = 10 * time.Millisecond
+
+	keys, store, wrapper := newKeys(t, opts)
+
+	// A key added by another replica is picked up by the next refresh.
+	other := jwtkeys.New(store, wrapper, testOptions(jwtkeys.AlgES256), testutils.GetTLogger())
+	_, err := other.Rotate(t.Context(), time.Now())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+
+	go func() {
+		keys.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(keys.JWKS().Keys) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestOptions_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, testOptions(jwtkeys.AlgEdDSA).Validate())
+	require.NoError(t, testOptions(jwtkeys.AlgES256).Validate())
+	require.ErrorIs(t, testOptions("HS256").Validate(), jwtkeys.ErrUnknownAlgorithm)
+
+	opts := testOptions(jwtkeys.AlgEdDSA)
+	opts.Audience = ""
+	require.ErrorIs(t, opts.Validate(), jwtkeys.ErrInvalidOptions)
+
+	opts = testOptions(jwtkeys.AlgEdDSA)
+	opts.Rotation = 2 * opts.Refresh
+	require.ErrorIs(t, opts.Validate(), jwtkeys.ErrInvalidOptions)
+}
+
+// signedBy returns the kid of a token signed at the time.
+func signedBy(t *testing.T, keys *jwtkeys.Keys, at time.Time) string {
+	t.Helper()
+
+	token, err := keys.Sign(userID, at, time.Hour)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+
+	kid, _ := parsed.Header["kid"].(string)
+
+	return kid
+}
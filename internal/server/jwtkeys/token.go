@@ -0,0 +1,134 @@
+package jwtkeys
+
+import (
+	"crypto/rand"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+const legacyAlgorithm = "HS256"
+
+var (
+	ErrUnknownKey        = errors.New("unknown signing key")
+	ErrAlgorithmMismatch = errors.New("token algorithm does not match its key")
+)
+
+// Sign issues an access token for the user with the key active at issuedAt. A random ID keeps tokens
+// issued in the same second apart, since every session stores its own.
+func (k *Keys) Sign(userID string, issuedAt time.Time, lifetime time.Duration) (string, error) {
+	key, ok := k.activeKey(issuedAt)
+	if !ok {
+		return "", ErrNoSigningKey
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.algorithm), jwt.RegisteredClaims{
+		Issuer:    k.opts.Issuer,
+		Subject:   userID,
+		Audience:  jwt.ClaimStrings{k.opts.Audience},
+		ExpiresAt: jwt.NewNumericDate(issuedAt.Add(lifetime)),
+		NotBefore: nil,
+		IssuedAt:  jwt.NewNumericDate(issuedAt),
+		ID:        rand.Text(),
+	})
+	token.Header["kid"] = key.id
+
+	signed, err := token.SignedString(key.private)
+	if err != nil {
+		return "", errors.Wrap(err, "error signing token")
+	}
+
+	return signed, nil
+}
+
+// Verify checks the signature, issuer, audience and lifetime of an access token and returns its user ID.
+func (k *Keys) Verify(tokenString string) (string, error) {
+	unverified, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing token")
+	}
+
+	if unverified.Method.Alg() == legacyAlgorithm {
+		return k.verifyLegacy(tokenString)
+	}
+
+	claims := &jwt.RegisteredClaims{}
+
+	_, err = jwt.ParseWithClaims(tokenString, claims, k.publicKey,
+		jwt.WithValidMethods([]string{AlgEdDSA, AlgES256}),
+		jwt.WithIssuer(k.opts.Issuer),
+		jwt.WithAudience(k.opts.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "error validating token")
+	}
+
+	if claims.Subject == "" {
+		return "", errors.New("token has no subject")
+	}
+
+	return claims.Subject, nil
+}
+
+// verifyLegacy accepts the HS256 tokens of JWT_SECRET, which carry the user in a user_id claim.
+func (k *Keys) verifyLegacy(tokenString string) (string, error) {
+	if k.opts.LegacySecret == "" {
+		return "", errors.Wrap(ErrUnknownKey, "legacy tokens are not accepted")
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(_ *jwt.Token) (interface{}, error) {
+		return []byte(k.opts.LegacySecret), nil
+	}, jwt.WithValidMethods([]string{legacyAlgorithm}), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", errors.Wrap(err, "error validating legacy token")
+	}
+
+	userID, ok := claims["user_id"].(string)
+	if !ok || userID == "" {
+		return "", errors.New("legacy token has no user id")
+	}
+
+	return userID, nil
+}
+
+// publicKey finds the key of the kid header. Its algorithm has to match the alg header, so a token cannot
+// pick how its signature is checked.
+func (k *Keys) publicKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	for _, key := range k.keys {
+		if key.id != kid {
+			continue
+		}
+
+		if key.algorithm != token.Method.Alg() {
+			return nil, ErrAlgorithmMismatch
+		}
+
+		return key.private.Public(), nil
+	}
+
+	return nil, errors.Wrapf(ErrUnknownKey, "kid %q", kid)
+}
+
+// activeKey returns the latest key activated by at.
+func (k *Keys) activeKey(at time.Time) (signingKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	for _, key := range k.keys {
+		if !key.activatesAt.After(at) {
+			return key, true
+		}
+	}
+
+	return signingKey{}, false
+}
@@ -0,0 +1,440 @@
+package keyrotation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	serviceUtils "github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+const (
+	// chunkSize matches the block size of utils.Encryptor, which older files are read with.
+	chunkSize = 1024
+	// pollInterval is how often running rotations are looked for without a Notify, e.g. for rotations
+	// the keyrotate command or another server started.
+	pollInterval = time.Minute
+)
+
+// ReencryptStorage is what the re-encryption worker reads and updates.
+type ReencryptStorage interface {
+	GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User, error)
+	ListRunningKeyRotations(ctx context.Context) ([]db.KeyRotation, error)
+	FailKeyRotation(ctx context.Context, rotationID pgtype.UUID, reason string) error
+	UpdateKeyRotationProgress(ctx context.Context, rotationID pgtype.UUID, remaining int64) error
+	FinishKeyRotation(ctx context.Context, rotation db.KeyRotation) (*db.KeyRotation, error)
+	CountStaleItems(ctx context.Context, userID pgtype.UUID, keyVersion int32) (int64, error)
+	ListStalePasswords(ctx context.Context, userID pgtype.UUID, keyVersion, batchSize int32) ([]db.Password, error)
+	ReencryptPassword(ctx context.Context, params db.ReencryptPasswordParams) (bool, error)
+	ListStaleNotes(ctx context.Context, userID pgtype.UUID, keyVersion, batchSize int32) ([]db.Note, error)
+	ReencryptNote(ctx context.Context, params db.ReencryptNoteParams) (bool, error)
+	ListStaleCards(ctx context.Context, userID pgtype.UUID, keyVersion, batchSize int32) ([]db.Card, error)
+	ReencryptCard(ctx context.Context, params db.ReencryptCardParams) (bool, error)
+	ListStaleBinaries(ctx context.Context, userID pgtype.UUID, keyVersion, batchSize int32) ([]db.BinaryEntry, error)
+	ReencryptBinary(ctx context.Context, params db.ReencryptBinaryEntryParams) (bool, error)
+	GetShareKey(ctx context.Context, userID pgtype.UUID) (*db.ShareKey, error)
+	ReencryptShareKey(ctx context.Context, params db.ReencryptShareKeyParams) (bool, error)
+}
+
+// ObjectStorage holds the encrypted file contents, see file.S3Storage.
+type ObjectStorage interface {
+	PutObject(ctx context.Context,
+		bucketName string,
+		objectName string,
+		reader io.Reader,
+		objectSize int64,
+		opts minio.PutObjectOptions,
+	) (info minio.UploadInfo, err error)
+	GetObject(ctx context.Context, bucketName string, objName string, opts minio.GetObjectOptions) (io.ReadCloser, error)
+	RemoveObject(ctx context.Context, bucketName string, objName string, opts minio.RemoveObjectOptions) error
+}
+
+// Reencryptor moves the vault of a user to the new key after RotateUserKeyV1. Rows are rewritten one by one
+// and only if they did not change since they were read, so users keep working while it runs; reads pick
+// the key by the version each row records.
+type Reencryptor struct {
+	storage   ReencryptStorage
+	objects   ObjectStorage
+	bucket    string
+	keys      serviceUtils.KeyUnwrapper
+	logger    *zerolog.Logger
+	batchSize int32
+	pause     time.Duration
+	wake      chan struct{}
+}
+
+// NewReencryptor creates a worker that pauses between batches to limit the load on the database.
+func NewReencryptor(
+	storage ReencryptStorage,
+	objects ObjectStorage,
+	bucket string,
+	keys serviceUtils.KeyUnwrapper,
+	log *zerolog.Logger,
+	batchSize int32,
+	pause time.Duration,
+) *Reencryptor {
+	return &Reencryptor{
+		storage:   storage,
+		objects:   objects,
+		bucket:    bucket,
+		keys:      keys,
+		logger:    log,
+		batchSize: batchSize,
+		pause:     pause,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Notify wakes the worker up to pick up a rotation that was just started.
+func (r *Reencryptor) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run works through running rotations until ctx is done. Rotations interrupted by a shutdown
+// stay running and are resumed on the next start or poll.
+func (r *Reencryptor) Run(ctx context.Context) {
+	for {
+		r.runPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wake:
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (r *Reencryptor) runPending(ctx context.Context) {
+	rotations, err := r.storage.ListRunningKeyRotations(ctx)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("error listing key rotations")
+
+		return
+	}
+
+	for _, rotation := range rotations {
+		err := r.Reencrypt(ctx, rotation)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			r.logger.Error().Err(err).Str("rotation_id", rotation.ID.String()).Msg("key rotation failed")
+
+			if err := r.storage.FailKeyRotation(ctx, rotation.ID, err.Error()); err != nil {
+				r.logger.Error().Err(err).Msg("error recording failed key rotation")
+			}
+		}
+	}
+}
+
+// Reencrypt re-encrypts every row and file of the rotation's user with the new key, then drops the previous key.
+func (r *Reencryptor) Reencrypt(ctx context.Context, rotation db.KeyRotation) error {
+	userKeys, err := serviceUtils.GetUserKey(ctx, r.storage, rotation.UserID, r.keys)
+	if err != nil {
+		return errors.Wrap(err, "error getting user keys")
+	}
+
+	if userKeys.Version != rotation.ToVersion {
+		return errors.Errorf("user key is at version %d, rotation expects %d", userKeys.Version, rotation.ToVersion)
+	}
+
+	for {
+		remaining, err := r.storage.CountStaleItems(ctx, rotation.UserID, rotation.ToVersion)
+		if err != nil {
+			return errors.Wrap(err, "error counting items to re-encrypt")
+		}
+
+		if err := r.storage.UpdateKeyRotationProgress(ctx, rotation.ID, remaining); err != nil {
+			return errors.Wrap(err, "error updating progress")
+		}
+
+		if remaining == 0 {
+			break
+		}
+
+		if err := r.reencryptBatch(ctx, rotation, userKeys); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "re-encryption interrupted")
+		case <-time.After(r.pause):
+		}
+	}
+
+	if _, err := r.storage.FinishKeyRotation(ctx, rotation); err != nil {
+		return errors.Wrap(err, "error finishing key rotation")
+	}
+
+	r.logger.Info().Str("rotation_id", rotation.ID.String()).Msg("key rotation completed")
+
+	return nil
+}
+
+// reencryptBatch rewrites up to one batch of every item type. Rows that changed in the meantime are left
+// for the next batch, which reads them again.
+//
+//nolint:cyclop,funlen
+func (r *Reencryptor) reencryptBatch(
+	ctx context.Context,
+	rotation db.KeyRotation,
+	userKeys serviceUtils.UserKeys,
+) error {
+	passwords, err := r.storage.ListStalePasswords(ctx, rotation.UserID, rotation.ToVersion, r.batchSize)
+	if err != nil {
+		return errors.Wrap(err, "error listing passwords")
+	}
+
+	for _, password := range passwords {
+		sealed, err := reseal(userKeys, password.KeyVersion, password.UserID, password.ID,
+			field{serviceUtils.FieldPassword, password.Password})
+		if err != nil {
+			return errors.Wrapf(err, "error re-encrypting password %s", password.ID.String())
+		}
+
+		if _, err := r.storage.ReencryptPassword(ctx, db.ReencryptPasswordParams{
+			Password:      sealed[0],
+			KeyVersion:    userKeys.Version,
+			ID:            password.ID,
+			Version:       password.Version,
+			OldKeyVersion: password.KeyVersion,
+		}); err != nil {
+			return errors.Wrap(err, "error storing password")
+		}
+	}
+
+	notes, err := r.storage.ListStaleNotes(ctx, rotation.UserID, rotation.ToVersion, r.batchSize)
+	if err != nil {
+		return errors.Wrap(err, "error listing notes")
+	}
+
+	for _, note := range notes {
+		sealed, err := reseal(userKeys, note.KeyVersion, note.UserID, note.ID,
+			field{serviceUtils.FieldNote, note.EncryptedContent})
+		if err != nil {
+			return errors.Wrapf(err, "error re-encrypting note %s", note.ID.String())
+		}
+
+		if _, err := r.storage.ReencryptNote(ctx, db.ReencryptNoteParams{
+			EncryptedContent: sealed[0],
+			KeyVersion:       userKeys.Version,
+			ID:               note.ID,
+			Version:          note.Version,
+			OldKeyVersion:    note.KeyVersion,
+		}); err != nil {
+			return errors.Wrap(err, "error storing note")
+		}
+	}
+
+	cards, err := r.storage.ListStaleCards(ctx, rotation.UserID, rotation.ToVersion, r.batchSize)
+	if err != nil {
+		return errors.Wrap(err, "error listing cards")
+	}
+
+	for _, card := range cards {
+		sealed, err := reseal(userKeys, card.KeyVersion, card.UserID, card.ID,
+			field{serviceUtils.FieldCardNumber, card.EncryptedCardNumber},
+			field{serviceUtils.FieldExpiryDate, card.EncryptedExpiryDate},
+			field{serviceUtils.FieldCVV, card.EncryptedCvv})
+		if err != nil {
+			return errors.Wrapf(err, "error re-encrypting card %s", card.ID.String())
+		}
+
+		if _, err := r.storage.ReencryptCard(ctx, db.ReencryptCardParams{
+			EncryptedCardNumber: sealed[0],
+			EncryptedExpiryDate: sealed[1],
+			EncryptedCvv:        sealed[2],
+			KeyVersion:          userKeys.Version,
+			ID:                  card.ID,
+			Version:             card.Version,
+			OldKeyVersion:       card.KeyVersion,
+		}); err != nil {
+			return errors.Wrap(err, "error storing card")
+		}
+	}
+
+	binaries, err := r.storage.ListStaleBinaries(ctx, rotation.UserID, rotation.ToVersion, r.batchSize)
+	if err != nil {
+		return errors.Wrap(err, "error listing files")
+	}
+
+	for _, binary := range binaries {
+		if err := r.reencryptBinary(ctx, binary, userKeys); err != nil {
+			return errors.Wrapf(err, "error re-encrypting file %s", binary.ID.String())
+		}
+	}
+
+	return r.reencryptShareKey(ctx, rotation, userKeys)
+}
+
+// reencryptShareKey rewraps the private share key of the user, if it has one that is not at the new version yet.
+func (r *Reencryptor) reencryptShareKey(
+	ctx context.Context,
+	rotation db.KeyRotation,
+	userKeys serviceUtils.UserKeys,
+) error {
+	key, err := r.storage.GetShareKey(ctx, rotation.UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "error getting share key")
+	}
+
+	if key.KeyVersion >= rotation.ToVersion {
+		return nil
+	}
+
+	sealed, err := reseal(userKeys, key.KeyVersion, key.UserID, key.UserID,
+		field{serviceUtils.FieldShareKey, key.PrivateKey})
+	if err != nil {
+		return errors.Wrap(err, "error re-encrypting share key")
+	}
+
+	if _, err := r.storage.ReencryptShareKey(ctx, db.ReencryptShareKeyParams{
+		PrivateKey:    sealed[0],
+		KeyVersion:    userKeys.Version,
+		UserID:        key.UserID,
+		OldKeyVersion: key.KeyVersion,
+	}); err != nil {
+		return errors.Wrap(err, "error storing share key")
+	}
+
+	return nil
+}
+
+// reencryptBinary streams the object through a decryptor with the old key and a StreamEncryptor with the
+// new one into a new object, switches the entry over and removes whichever object is no longer referenced.
+func (r *Reencryptor) reencryptBinary(
+	ctx context.Context,
+	binary db.BinaryEntry,
+	userKeys serviceUtils.UserKeys,
+) error {
+	//nolint:exhaustruct
+	reader, err := r.objects.GetObject(ctx, r.bucket, binary.FileUrl, minio.GetObjectOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error fetching file")
+	}
+	defer reader.Close()
+
+	binding := serviceUtils.Bind(binary.UserID, binary.ID, serviceUtils.FieldFile)
+
+	decryptor, err := userKeys.OpenReader(reader, binary.KeyVersion, binding)
+	if err != nil {
+		return errors.Wrap(err, "error creating decryptor")
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	defer pipeReader.Close()
+
+	go func() {
+		_ = pipeWriter.CloseWithError(resealFile(pipeWriter, decryptor, userKeys, binding))
+	}()
+
+	// Workers of several servers may re-encrypt the same file; each removes only its own object if it loses.
+	objectName := fmt.Sprintf("%s-%s.v%d-%s", binary.UserID.String(), binary.ID.String(), userKeys.Version,
+		uuid.NewString())
+
+	_, err = r.objects.PutObject(ctx, r.bucket, objectName, pipeReader, -1,
+		//nolint:exhaustruct
+		minio.PutObjectOptions{ContentType: "application/octet-stream"},
+	)
+	if err != nil {
+		return errors.Wrap(err, "error uploading re-encrypted file")
+	}
+
+	switched, err := r.storage.ReencryptBinary(ctx, db.ReencryptBinaryEntryParams{
+		FileUrl:       objectName,
+		KeyVersion:    userKeys.Version,
+		ID:            binary.ID,
+		Version:       binary.Version,
+		OldKeyVersion: binary.KeyVersion,
+	})
+	if err != nil {
+		r.removeObject(ctx, objectName)
+
+		return errors.Wrap(err, "error storing file")
+	}
+
+	if switched {
+		r.removeObject(ctx, binary.FileUrl)
+	} else {
+		r.removeObject(ctx, objectName)
+	}
+
+	return nil
+}
+
+func (r *Reencryptor) removeObject(ctx context.Context, objectName string) {
+	//nolint:exhaustruct
+	err := r.objects.RemoveObject(ctx, r.bucket, objectName, minio.RemoveObjectOptions{ForceDelete: true})
+	if err != nil {
+		r.logger.Error().Err(err).Str("object", objectName).Msg("error removing object")
+	}
+}
+
+// field is a stored value of an item with the name it is bound to.
+type field struct {
+	name  string
+	value string
+}
+
+// reseal opens fields written with the given key version and seals them with the current key,
+// bound to their item. Values written before ciphertexts were bound come out bound.
+func reseal(
+	userKeys serviceUtils.UserKeys,
+	version int32,
+	userID, itemID pgtype.UUID,
+	fields ...field,
+) ([]string, error) {
+	sealed := make([]string, len(fields))
+
+	for i, stored := range fields {
+		binding := serviceUtils.Bind(userID, itemID, stored.name)
+
+		plain, err := userKeys.Open(stored.value, version, binding)
+		if err != nil {
+			return nil, err
+		}
+
+		sealed[i], err = userKeys.Seal(plain, binding)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sealed, nil
+}
+
+// resealFile writes the decrypted file to writer encrypted with the current key, in the stream format.
+func resealFile(writer io.Writer, decryptor io.Reader, userKeys serviceUtils.UserKeys,
+	binding serviceUtils.Binding,
+) error {
+	encryptor, err := userKeys.SealWriter(writer, binding)
+	if err != nil {
+		return errors.Wrap(err, "error creating encryptor")
+	}
+
+	// Files written before the stream format are decrypted a block per Read.
+	if _, err := io.CopyBuffer(encryptor, decryptor, make([]byte, chunkSize)); err != nil {
+		return errors.Wrap(err, "error copying file")
+	}
+
+	return errors.Wrap(encryptor.Close(), "error finishing file")
+}
@@ -0,0 +1,306 @@
+//nolint:exhaustruct
+package keyrotation_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/keyrotation"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	secure "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+const bucket = "test-bucket"
+
+// memObjects is an in-memory object store.
+type memObjects struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *memObjects) PutObject(_ context.Context, _, objectName string, reader io.Reader, _ int64,
+	_ minio.PutObjectOptions,
+) (minio.UploadInfo, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.objects[objectName] = data
+
+	return minio.UploadInfo{Key: objectName, Size: int64(len(data))}, nil
+}
+
+func (m *memObjects) GetObject(_ context.Context, _, objectName string,
+	_ minio.GetObjectOptions,
+) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.objects[objectName]
+	if !ok {
+		return nil, errors.New("object not found")
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memObjects) RemoveObject(_ context.Context, _, objectName string, _ minio.RemoveObjectOptions) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.objects, objectName)
+
+	return nil
+}
+
+type vault struct {
+	storage  *testutils.MockDBStorage
+	objects  *memObjects
+	keys     kms.KeyProvider
+	userID   pgtype.UUID
+	oldKey   string
+	newKey   string
+	content  string
+	rotation *db.KeyRotation
+}
+
+// newVault stores one item of every type under the old user key, as written before values were bound to
+// their records, and starts a rotation to a new key.
+func newVault(t *testing.T) *vault {
+	t.Helper()
+
+	ctx := t.Context()
+	masterKey := newMasterKey(t)
+
+	v := &vault{
+		storage: testutils.SetupMockUserStorage(masterKey),
+		objects: &memObjects{objects: make(map[string][]byte)},
+		keys:    kms.NewLocalProvider(secure.NewString(masterKey)),
+		userID:  pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		oldKey:  newMasterKey(t),
+		newKey:  newMasterKey(t),
+		// Several encrypted blocks with a short last one.
+		content: strings.Repeat("file content ", 300),
+	}
+
+	wrappedOld, err := v.keys.Wrap(ctx, v.oldKey)
+	require.NoError(t, err)
+	v.storage.AddTestUser(db.User{
+		ID: v.userID, Username: "rotating", EncryptionKey: wrappedOld, KeyVersion: 1, LegacyCiphertexts: true,
+	})
+
+	_, err = v.storage.StorePassword(ctx, db.CreatePasswordEntryParams{
+		UserID: v.userID, Login: "login", Password: v.seal(t, "password"), KeyVersion: 1,
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	_, err = v.storage.StoreNote(ctx, db.CreateNoteEntryParams{
+		UserID: v.userID, EncryptedContent: v.seal(t, "note"), KeyVersion: 1,
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	_, err = v.storage.StoreCard(ctx, db.StoreCardParams{
+		UserID:              v.userID,
+		EncryptedCardNumber: v.seal(t, "4111111111111111"),
+		EncryptedExpiryDate: v.seal(t, "12/30"),
+		EncryptedCvv:        v.seal(t, "123"),
+		KeyVersion:          1,
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	writer, err := utils.NewEncryptor(&buf, v.oldKey)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte(v.content))
+	require.NoError(t, err)
+	v.objects.objects["file-v1"] = buf.Bytes()
+
+	_, err = v.storage.StoreBinary(ctx, db.StoreBinaryEntryParams{
+		UserID: v.userID, FileName: "file.txt", FileUrl: "file-v1", FileSize: int64(len(v.content)), KeyVersion: 1,
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	wrappedNew, err := v.keys.Wrap(ctx, v.newKey)
+	require.NoError(t, err)
+
+	v.rotation, err = v.storage.StartKeyRotation(ctx, db.StartKeyRotationParams{
+		NewKey: wrappedNew, UserID: v.userID, FromVersion: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), v.rotation.TotalItems)
+
+	return v
+}
+
+func (v *vault) seal(t *testing.T, value string) string {
+	t.Helper()
+
+	sealed, err := utils.Encrypt(value, v.oldKey)
+	require.NoError(t, err)
+
+	return sealed
+}
+
+// newKeys are the user keys once the rotation completed; they only read values bound to their records.
+func (v *vault) newKeys() utils.UserKeys {
+	return utils.UserKeys{Current: v.newKey, Version: 2}
+}
+
+func (v *vault) open(t *testing.T, stored string, itemID pgtype.UUID, field string) string {
+	t.Helper()
+
+	opened, err := v.newKeys().Open(stored, 2, utils.Bind(v.userID, itemID, field))
+	require.NoError(t, err)
+
+	return opened
+}
+
+func (v *vault) reencryptor(batchSize int32) *keyrotation.Reencryptor {
+	return keyrotation.NewReencryptor(v.storage, v.objects, bucket, v.keys, testutils.GetTLogger(), batchSize, 0)
+}
+
+func TestReencryptor_Reencrypt(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	v := newVault(t)
+
+	// A batch size of one makes it loop.
+	require.NoError(t, v.reencryptor(1).Reencrypt(ctx, *v.rotation))
+
+	rotation, err := v.storage.GetLatestKeyRotation(ctx, v.userID)
+	require.NoError(t, err)
+	assert.Equal(t, db.KeyRotationStatusCompleted, rotation.Status)
+	assert.Equal(t, rotation.TotalItems, rotation.DoneItems)
+
+	user, err := v.storage.GetUserByID(ctx, v.userID)
+	require.NoError(t, err)
+	assert.False(t, user.PreviousEncryptionKey.Valid)
+	assert.False(t, user.LegacyCiphertexts)
+
+	passwords, err := v.storage.GetPasswords(ctx, db.GetPasswordEntriesByUserIDParams{UserID: v.userID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, passwords, 1)
+	assert.Equal(t, int32(2), passwords[0].KeyVersion)
+	assert.Equal(t, int64(1), passwords[0].Version)
+	assert.Equal(t, "password", v.open(t, passwords[0].Password, passwords[0].ID, utils.FieldPassword))
+
+	notes, err := v.storage.GetNotes(ctx, db.GetNotesByUserIDParams{UserID: v.userID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "note", v.open(t, notes[0].EncryptedContent, notes[0].ID, utils.FieldNote))
+
+	cards, err := v.storage.GetCards(ctx, db.GetCardsByUserIDParams{UserID: v.userID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	assert.Equal(t, "4111111111111111", v.open(t, cards[0].EncryptedCardNumber, cards[0].ID, utils.FieldCardNumber))
+	assert.Equal(t, "12/30", v.open(t, cards[0].EncryptedExpiryDate, cards[0].ID, utils.FieldExpiryDate))
+	assert.Equal(t, "123", v.open(t, cards[0].EncryptedCvv, cards[0].ID, utils.FieldCVV))
+
+	binaries, err := v.storage.GetBinaries(ctx, db.GetBinaryEntriesByUserIDParams{UserID: v.userID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, binaries, 1)
+	assert.Equal(t, int32(2), binaries[0].KeyVersion)
+	assert.NotContains(t, v.objects.objects, "file-v1")
+
+	object, err := v.objects.GetObject(ctx, bucket, binaries[0].FileUrl, minio.GetObjectOptions{})
+	require.NoError(t, err)
+	reader, err := v.newKeys().OpenReader(object, 2, utils.Bind(v.userID, binaries[0].ID, utils.FieldFile))
+	require.NoError(t, err)
+
+	// The Decryptor is read block by block, like the file service does.
+	var content bytes.Buffer
+	_, err = io.CopyBuffer(struct{ io.Writer }{&content}, struct{ io.Reader }{reader}, make([]byte, 1024))
+	require.NoError(t, err)
+	assert.Equal(t, v.content, content.String())
+}
+
+func TestReencryptor_ReencryptShareKey(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	v := newVault(t)
+
+	_, privateKey, err := utils.GenerateShareKey()
+	require.NoError(t, err)
+
+	binding := utils.Bind(v.userID, v.userID, utils.FieldShareKey)
+	sealed, err := utils.UserKeys{Current: v.oldKey, Version: 1}.Seal(privateKey, binding)
+	require.NoError(t, err)
+	require.NoError(t, v.storage.CreateShareKey(ctx, db.CreateShareKeyParams{
+		UserID: v.userID, PublicKey: "public", PrivateKey: sealed, KeyVersion: 1,
+	}))
+
+	require.NoError(t, v.reencryptor(10).Reencrypt(ctx, *v.rotation))
+
+	key, err := v.storage.GetShareKey(ctx, v.userID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), key.KeyVersion)
+	assert.Equal(t, privateKey, v.open(t, key.PrivateKey, v.userID, utils.FieldShareKey))
+}
+
+func TestReencryptor_Run(t *testing.T) {
+	t.Parallel()
+
+	v := newVault(t)
+	reencryptor := v.reencryptor(10)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	go reencryptor.Run(ctx)
+	reencryptor.Notify()
+
+	require.Eventually(t, func() bool {
+		rotation, err := v.storage.GetLatestKeyRotation(ctx, v.userID)
+
+		return err == nil && rotation.Status == db.KeyRotationStatusCompleted
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReencryptor_RunFailsRotation(t *testing.T) {
+	t.Parallel()
+
+	v := newVault(t)
+	delete(v.objects.objects, "file-v1")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	go v.reencryptor(10).Run(ctx)
+
+	require.Eventually(t, func() bool {
+		rotation, err := v.storage.GetLatestKeyRotation(ctx, v.userID)
+
+		return err == nil && rotation.Status == db.KeyRotationStatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Everything but the missing file was moved, and the previous key is kept for it.
+	rotation, err := v.storage.GetLatestKeyRotation(ctx, v.userID)
+	require.NoError(t, err)
+	assert.Contains(t, rotation.Error.String, "error fetching file")
+
+	user, err := v.storage.GetUserByID(ctx, v.userID)
+	require.NoError(t, err)
+	assert.True(t, user.PreviousEncryptionKey.Valid)
+	assert.True(t, user.LegacyCiphertexts)
+}
@@ -0,0 +1,269 @@
+// Package keyrotation re-wraps user keys and TOTP secrets with the current master key and moves vaults
+// to new user keys.
+package keyrotation
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	serviceUtils "github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+type Storage interface {
+	ListUserKeys(ctx context.Context, afterID pgtype.UUID, batchSize int32) ([]db.ListUserKeysAfterRow, error)
+	RewrapUserKey(ctx context.Context, userID pgtype.UUID, oldKey, newKey string) (bool, error)
+	ListTOTPSecrets(ctx context.Context, afterID pgtype.UUID, batchSize int32) ([]db.ListTOTPSecretsAfterRow, error)
+	RewrapTOTPSecret(ctx context.Context, userID pgtype.UUID, oldSecret, newSecret string) (bool, error)
+	ListLegacyUsers(ctx context.Context,
+		afterID pgtype.UUID,
+		batchSize int32,
+	) ([]db.ListLegacyCiphertextUsersRow, error)
+	StartKeyRotation(ctx context.Context, params db.StartKeyRotationParams) (*db.KeyRotation, error)
+}
+
+// Stats summarises a rotation run. Scanned, Rewrapped and Skipped count user keys and TOTP secrets.
+type Stats struct {
+	Scanned   int
+	Rewrapped int
+	// Skipped counts keys that changed between reading and rewrapping them.
+	Skipped int
+	// Rebinding counts user key rotations started to bind values written before ciphertexts were bound.
+	Rebinding int
+}
+
+// Rotator walks all users in batches. It only touches one row per statement, so servers configured
+// with the same key provider keep serving while it runs, and a stopped run can simply be started again.
+type Rotator struct {
+	storage   Storage
+	keys      kms.KeyProvider
+	logger    *zerolog.Logger
+	batchSize int32
+	pause     time.Duration
+}
+
+// NewRotator creates a rotator that pauses between batches to limit the load on the database.
+func NewRotator(
+	storage Storage,
+	keys kms.KeyProvider,
+	log *zerolog.Logger,
+	batchSize int32,
+	pause time.Duration,
+) *Rotator {
+	return &Rotator{
+		storage:   storage,
+		keys:      keys,
+		logger:    log,
+		batchSize: batchSize,
+		pause:     pause,
+	}
+}
+
+// Run rewraps every user key and TOTP secret that is not wrapped with the current master key yet, then starts
+// a user key rotation for every vault that still holds values not bound to their records. The servers
+// re-encrypt those.
+func (r *Rotator) Run(ctx context.Context) (Stats, error) {
+	var stats Stats
+
+	if err := r.rewrapAll(ctx, &stats); err != nil {
+		return stats, err
+	}
+
+	if err := r.rewrapTOTPAll(ctx, &stats); err != nil {
+		return stats, err
+	}
+
+	if err := r.rebindAll(ctx, &stats); err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+func (r *Rotator) rewrapAll(ctx context.Context, stats *Stats) error {
+	// The zero UUID sorts before every user ID.
+	afterID := pgtype.UUID{Valid: true}
+
+	for {
+		rows, err := r.storage.ListUserKeys(ctx, afterID, r.batchSize)
+		if err != nil {
+			return errors.Wrap(err, "error listing user keys")
+		}
+
+		for _, row := range rows {
+			if err := r.rewrap(ctx, row, stats); err != nil {
+				return err
+			}
+		}
+
+		r.logger.Info().Int("scanned", stats.Scanned).Int("rewrapped", stats.Rewrapped).Msg("batch rotated")
+
+		if len(rows) < int(r.batchSize) {
+			return nil
+		}
+
+		afterID = rows[len(rows)-1].ID
+
+		if err := r.wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Rotator) rewrapTOTPAll(ctx context.Context, stats *Stats) error {
+	afterID := pgtype.UUID{Valid: true}
+
+	for {
+		rows, err := r.storage.ListTOTPSecrets(ctx, afterID, r.batchSize)
+		if err != nil {
+			return errors.Wrap(err, "error listing totp secrets")
+		}
+
+		for _, row := range rows {
+			if err := r.rewrapTOTP(ctx, row, stats); err != nil {
+				return err
+			}
+		}
+
+		r.logger.Info().Int("scanned", stats.Scanned).Int("rewrapped", stats.Rewrapped).Msg("batch rotated")
+
+		if len(rows) < int(r.batchSize) {
+			return nil
+		}
+
+		afterID = rows[len(rows)-1].ID
+
+		if err := r.wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Rotator) rebindAll(ctx context.Context, stats *Stats) error {
+	afterID := pgtype.UUID{Valid: true}
+
+	for {
+		rows, err := r.storage.ListLegacyUsers(ctx, afterID, r.batchSize)
+		if err != nil {
+			return errors.Wrap(err, "error listing users with unbound values")
+		}
+
+		for _, row := range rows {
+			if err := r.rebind(ctx, row, stats); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < int(r.batchSize) {
+			return nil
+		}
+
+		afterID = rows[len(rows)-1].ID
+
+		if err := r.wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Rotator) wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "rotation interrupted")
+	case <-time.After(r.pause):
+		return nil
+	}
+}
+
+func (r *Rotator) rewrap(ctx context.Context, row db.ListUserKeysAfterRow, stats *Stats) error {
+	stats.Scanned++
+
+	newKey, changed, err := r.keys.Rewrap(ctx, row.EncryptionKey)
+	if err != nil {
+		r.logger.Error().Err(err).Str("user_id", row.ID.String()).Msg("error rewrapping user key")
+
+		return errors.Wrapf(err, "error rewrapping key of user %s", row.ID.String())
+	}
+
+	if !changed {
+		return nil
+	}
+
+	ok, err := r.storage.RewrapUserKey(ctx, row.ID, row.EncryptionKey, newKey)
+	if err != nil {
+		return errors.Wrap(err, "error storing user key")
+	}
+
+	if ok {
+		stats.Rewrapped++
+	} else {
+		stats.Skipped++
+	}
+
+	return nil
+}
+
+func (r *Rotator) rewrapTOTP(ctx context.Context, row db.ListTOTPSecretsAfterRow, stats *Stats) error {
+	stats.Scanned++
+
+	newSecret, changed, err := r.keys.Rewrap(ctx, row.TotpSecret)
+	if err != nil {
+		r.logger.Error().Err(err).Str("user_id", row.ID.String()).Msg("error rewrapping totp secret")
+
+		return errors.Wrapf(err, "error rewrapping totp secret of user %s", row.ID.String())
+	}
+
+	if !changed {
+		return nil
+	}
+
+	ok, err := r.storage.RewrapTOTPSecret(ctx, row.ID, row.TotpSecret, newSecret)
+	if err != nil {
+		return errors.Wrap(err, "error storing totp secret")
+	}
+
+	if ok {
+		stats.Rewrapped++
+	} else {
+		stats.Skipped++
+	}
+
+	return nil
+}
+
+// rebind gives the user a new data key, so the re-encryption rewrites every value bound to its record.
+func (r *Rotator) rebind(ctx context.Context, row db.ListLegacyCiphertextUsersRow, stats *Stats) error {
+	newKey, err := serviceUtils.GenerateRandomKey()
+	if err != nil {
+		return errors.Wrap(err, "error generating random key")
+	}
+
+	wrappedKey, err := r.keys.Wrap(ctx, newKey)
+	if err != nil {
+		return errors.Wrap(err, "error encrypting key")
+	}
+
+	_, err = r.storage.StartKeyRotation(ctx, db.StartKeyRotationParams{
+		NewKey:      wrappedKey,
+		UserID:      row.ID,
+		FromVersion: row.KeyVersion,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The user rotated their key since the row was read; that rotation binds the values.
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "error starting key rotation of user %s", row.ID.String())
+	}
+
+	stats.Rebinding++
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package keyrotation_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/keyrotation"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	secure "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+func newMasterKey(t *testing.T) string {
+	t.Helper()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	return key
+}
+
+func TestRotator_Run(t *testing.T) {
+	t.Parallel()
+
+	oldKey, newKey := newMasterKey(t), newMasterKey(t)
+	storage := testutils.SetupMockUserStorage(oldKey)
+
+	// Users created before the keyring: bare ciphertexts under the old key.
+	userKeys := make(map[pgtype.UUID]string)
+	for range 5 {
+		userKey := newMasterKey(t)
+		encrypted, err := utils.Encrypt(userKey, oldKey)
+		require.NoError(t, err)
+
+		user := db.User{
+			ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			Username:      uuid.NewString(),
+			EncryptionKey: encrypted,
+		}
+		storage.AddTestUser(user)
+		userKeys[user.ID] = userKey
+	}
+	storage.AddZeroKnowledgeUser(t.Context())
+
+	// Zero-knowledge accounts have no user key, but their TOTP secret is wrapped all the same.
+	wrappedSecret, err := utils.Encrypt("TOTPSECRET", oldKey)
+	require.NoError(t, err)
+
+	totpUser := db.User{
+		ID:              pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username:        uuid.NewString(),
+		WrappedVaultKey: pgtype.Text{String: "d3JhcHBlZA==", Valid: true},
+		TotpSecret:      pgtype.Text{String: wrappedSecret, Valid: true},
+		TotpEnabled:     true,
+	}
+	storage.AddTestUser(totpUser)
+
+	keys := kms.NewLocalProvider(secure.NewString("k2:" + newKey + "," + oldKey))
+	rotator := keyrotation.NewRotator(storage, keys, testutils.GetTLogger(), 2, 0)
+
+	stats, err := rotator.Run(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, keyrotation.Stats{Scanned: 6, Rewrapped: 6}, stats)
+
+	// Every key is readable with the new master key alone.
+	newOnly, err := utils.ParseKeyring("k2:" + newKey)
+	require.NoError(t, err)
+
+	for userID, userKey := range userKeys {
+		user, err := storage.GetUserByID(t.Context(), userID)
+		require.NoError(t, err)
+
+		decrypted, err := newOnly.Decrypt(user.EncryptionKey)
+		require.NoError(t, err)
+		assert.Equal(t, userKey, decrypted)
+	}
+
+	user, err := storage.GetUserByID(t.Context(), totpUser.ID)
+	require.NoError(t, err)
+
+	secret, err := newOnly.Decrypt(user.TotpSecret.String)
+	require.NoError(t, err)
+	assert.Equal(t, "TOTPSECRET", secret)
+
+	// A second run has nothing left to do.
+	stats, err = rotator.Run(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, keyrotation.Stats{Scanned: 6}, stats)
+}
+
+func TestRotator_Errors(t *testing.T) {
+	t.Parallel()
+
+	oldKey, newKey := newMasterKey(t), newMasterKey(t)
+	storage := testutils.SetupMockUserStorage(oldKey)
+
+	encrypted, err := utils.Encrypt("user-key", oldKey)
+	require.NoError(t, err)
+	storage.AddTestUser(db.User{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, EncryptionKey: encrypted})
+
+	// The old key is missing from the keyring, so the user key cannot be read.
+	keys := kms.NewLocalProvider(secure.NewString("k2:" + newKey))
+
+	_, err = keyrotation.NewRotator(storage, keys, testutils.GetTLogger(), 10, 0).Run(t.Context())
+	require.ErrorContains(t, err, "error rewrapping key of user")
+
+	storage.CallError = errors.New("db down")
+
+	_, err = keyrotation.NewRotator(storage, keys, testutils.GetTLogger(), 10, 0).Run(t.Context())
+	require.ErrorContains(t, err, "error listing user keys")
+}
+
+func TestRotator_Rebind(t *testing.T) {
+	t.Parallel()
+
+	masterKey := newMasterKey(t)
+	storage := testutils.SetupMockUserStorage(masterKey)
+	keys := kms.NewLocalProvider(secure.NewString(masterKey))
+
+	wrapped, err := keys.Wrap(t.Context(), newMasterKey(t))
+	require.NoError(t, err)
+
+	legacyID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	storage.AddTestUser(db.User{
+		ID: legacyID, Username: "legacy", EncryptionKey: wrapped, KeyVersion: 1, LegacyCiphertexts: true,
+	})
+	storage.AddTestUser(db.User{
+		ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Username: "bound", EncryptionKey: wrapped, KeyVersion: 1,
+	})
+
+	rotator := keyrotation.NewRotator(storage, keys, testutils.GetTLogger(), 10, 0)
+
+	stats, err := rotator.Run(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, keyrotation.Stats{Scanned: 2, Rebinding: 1}, stats)
+
+	rotation, err := storage.GetLatestKeyRotation(t.Context(), legacyID)
+	require.NoError(t, err)
+	assert.Equal(t, db.KeyRotationStatusRunning, rotation.Status)
+	assert.Equal(t, int32(2), rotation.ToVersion)
+
+	// The running rotation binds the values, so a second run does not start another one.
+	stats, err = rotator.Run(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, keyrotation.Stats{Scanned: 2}, stats)
+}
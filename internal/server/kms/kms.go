@@ -0,0 +1,152 @@
+// Package kms wraps and unwraps user encryption keys with a master key the services never handle directly.
+package kms
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// Provider names accepted by New.
+const (
+	ProviderLocal   = "local"
+	ProviderFile    = "file"
+	ProviderTransit = "transit"
+	ProviderPKCS11  = "pkcs11"
+)
+
+var ErrUnknownProvider = errors.New("unknown key provider")
+
+// KeyProvider protects user keys with a master key (envelope encryption).
+type KeyProvider interface {
+	// Wrap encrypts a user key with the current master key.
+	Wrap(ctx context.Context, userKey string) (string, error)
+	// Unwrap decrypts a user key wrapped by this provider, with any of its master key versions.
+	Unwrap(ctx context.Context, wrapped string) (string, error)
+	// Rewrap moves a wrapped key to the current master key. It reports false, and returns
+	// wrapped unchanged, when the key already is there.
+	Rewrap(ctx context.Context, wrapped string) (string, bool, error)
+	// Owns reports whether wrapped has the format this provider produces.
+	Owns(wrapped string) bool
+}
+
+// Options selects and configures a key provider.
+type Options struct {
+	Provider  string
+	MasterKey utils.ISecureString
+
+	KeystoreFile string
+
+	Transit TransitOptions
+
+	PKCS11Module   string
+	PKCS11Params   string
+	PKCS11KeyLabel string
+}
+
+// New creates the configured provider. When another provider replaces a configured MASTER_KEY,
+// keys wrapped by the MASTER_KEY keyring stay readable and are moved over by a rotation.
+func New(opts Options) (KeyProvider, error) {
+	var (
+		provider KeyProvider
+		err      error
+	)
+
+	switch opts.Provider {
+	case ProviderLocal, "":
+		return NewLocalProvider(opts.MasterKey), nil
+	case ProviderFile:
+		keystore, err := NewFileProvider(opts.KeystoreFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if !hasKey(opts.MasterKey) {
+			return keystore, nil
+		}
+
+		// Both are keyrings with the same ciphertext format, so they are merged rather than chained.
+		return keystore.AddFallback(opts.MasterKey), nil
+	case ProviderTransit:
+		provider, err = NewTransitProvider(opts.Transit)
+	case ProviderPKCS11:
+		provider, err = NewPKCS11Provider(opts.PKCS11Module, opts.PKCS11Params, opts.PKCS11KeyLabel)
+	default:
+		return nil, errors.Wrapf(ErrUnknownProvider, "%q", opts.Provider)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hasKey(opts.MasterKey) {
+		return NewChain(provider, NewLocalProvider(opts.MasterKey)), nil
+	}
+
+	return provider, nil
+}
+
+func hasKey(masterKey utils.ISecureString) bool {
+	return masterKey != nil && masterKey.Get() != ""
+}
+
+// Chain wraps with its primary provider and unwraps with whichever provider owns the key.
+type Chain struct {
+	primary   KeyProvider
+	fallbacks []KeyProvider
+}
+
+// NewChain creates a provider that reads keys of the fallbacks and rewraps them with primary.
+func NewChain(primary KeyProvider, fallbacks ...KeyProvider) *Chain {
+	return &Chain{primary: primary, fallbacks: fallbacks}
+}
+
+func (c *Chain) Wrap(ctx context.Context, userKey string) (string, error) {
+	//nolint:wrapcheck
+	return c.primary.Wrap(ctx, userKey)
+}
+
+func (c *Chain) Unwrap(ctx context.Context, wrapped string) (string, error) {
+	//nolint:wrapcheck
+	return c.owner(wrapped).Unwrap(ctx, wrapped)
+}
+
+func (c *Chain) Rewrap(ctx context.Context, wrapped string) (string, bool, error) {
+	owner := c.owner(wrapped)
+	if owner == c.primary {
+		//nolint:wrapcheck
+		return owner.Rewrap(ctx, wrapped)
+	}
+
+	userKey, err := owner.Unwrap(ctx, wrapped)
+	if err != nil {
+		return "", false, errors.Wrap(err, "error unwrapping key of previous provider")
+	}
+
+	rewrapped, err := c.primary.Wrap(ctx, userKey)
+	if err != nil {
+		return "", false, errors.Wrap(err, "error wrapping key")
+	}
+
+	return rewrapped, true, nil
+}
+
+func (c *Chain) Owns(wrapped string) bool {
+	return c.owner(wrapped).Owns(wrapped)
+}
+
+func (c *Chain) owner(wrapped string) KeyProvider {
+	if c.primary.Owns(wrapped) {
+		return c.primary
+	}
+
+	for _, fallback := range c.fallbacks {
+		if fallback.Owns(wrapped) {
+			return fallback
+		}
+	}
+
+	return c.primary
+}
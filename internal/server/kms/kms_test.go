@@ -0,0 +1,150 @@
+package kms_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	secure "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+func newKey(t *testing.T) string {
+	t.Helper()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	return key
+}
+
+func TestLocalProvider(t *testing.T) {
+	t.Parallel()
+
+	provider := kms.NewLocalProvider(secure.NewString("k1:" + newKey(t)))
+
+	wrapped, err := provider.Wrap(t.Context(), "user-key")
+	require.NoError(t, err)
+	assert.True(t, provider.Owns(wrapped))
+
+	userKey, err := provider.Unwrap(t.Context(), wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "user-key", userKey)
+
+	_, err = kms.NewLocalProvider(secure.NewString("")).Wrap(t.Context(), "user-key")
+	require.ErrorIs(t, err, utils.ErrEmptyKeyring)
+
+	_, err = kms.NewLocalProvider(nil).Unwrap(t.Context(), wrapped)
+	require.ErrorIs(t, err, utils.ErrEmptyKeyring)
+}
+
+func TestFileProvider(t *testing.T) {
+	t.Parallel()
+
+	oldKey, newKeyValue := newKey(t), newKey(t)
+	path := filepath.Join(t.TempDir(), "keystore")
+	content := "# active key first\nk2:" + newKeyValue + "\n\nk1:" + oldKey + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	provider, err := kms.NewFileProvider(path)
+	require.NoError(t, err)
+
+	legacy, err := kms.NewLocalProvider(secure.NewString("k1:"+oldKey)).Wrap(t.Context(), "user-key")
+	require.NoError(t, err)
+
+	rewrapped, changed, err := provider.Rewrap(t.Context(), legacy)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	userKey, err := provider.Unwrap(t.Context(), rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "user-key", userKey)
+
+	_, err = kms.NewFileProvider(filepath.Join(t.TempDir(), "missing"))
+	require.ErrorContains(t, err, "error opening keystore")
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(empty, []byte("# nothing\n"), 0o600))
+
+	_, err = kms.NewFileProvider(empty)
+	require.ErrorIs(t, err, utils.ErrEmptyKeyring)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	masterKey := secure.NewString(newKey(t))
+
+	provider, err := kms.New(kms.Options{Provider: kms.ProviderLocal, MasterKey: masterKey})
+	require.NoError(t, err)
+	assert.IsType(t, &kms.LocalProvider{}, provider)
+
+	_, err = kms.New(kms.Options{Provider: "cloud"})
+	require.ErrorIs(t, err, kms.ErrUnknownProvider)
+
+	_, err = kms.New(kms.Options{Provider: kms.ProviderTransit})
+	require.Error(t, err)
+
+	// A key wrapped by MASTER_KEY stays readable after moving to the keystore, and is moved over on rewrap.
+	wrapped, err := provider.Wrap(t.Context(), "user-key")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keystore")
+	require.NoError(t, os.WriteFile(path, []byte("k2:"+newKey(t)), 0o600))
+
+	keystore, err := kms.New(kms.Options{Provider: kms.ProviderFile, KeystoreFile: path, MasterKey: masterKey})
+	require.NoError(t, err)
+
+	userKey, err := keystore.Unwrap(t.Context(), wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "user-key", userKey)
+
+	_, changed, err := keystore.Rewrap(t.Context(), wrapped)
+	require.NoError(t, err)
+	assert.True(t, changed)
+}
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	_, server := newFakeTransit(t)
+	masterKey := secure.NewString(newKey(t))
+	local := kms.NewLocalProvider(masterKey)
+
+	legacy, err := local.Wrap(t.Context(), "user-key")
+	require.NoError(t, err)
+
+	chain, err := kms.New(kms.Options{
+		Provider:  kms.ProviderTransit,
+		MasterKey: masterKey,
+		Transit:   kms.TransitOptions{Address: server.URL, Token: transitToken, KeyName: "users"},
+	})
+	require.NoError(t, err)
+
+	// New keys go to Transit, MASTER_KEY keys are still read and get moved.
+	wrapped, err := chain.Wrap(t.Context(), "other-key")
+	require.NoError(t, err)
+	assert.True(t, chain.Owns(wrapped))
+	assert.False(t, local.Owns(wrapped))
+
+	userKey, err := chain.Unwrap(t.Context(), legacy)
+	require.NoError(t, err)
+	assert.Equal(t, "user-key", userKey)
+
+	rewrapped, changed, err := chain.Rewrap(t.Context(), legacy)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.False(t, local.Owns(rewrapped))
+
+	userKey, err = chain.Unwrap(t.Context(), rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "user-key", userKey)
+
+	_, changed, err = chain.Rewrap(t.Context(), rewrapped)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
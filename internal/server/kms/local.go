@@ -0,0 +1,109 @@
+package kms
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	serviceUtils "github.com/npavlov/go-password-manager/internal/server/service/utils"
+	"github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// LocalProvider wraps user keys with the keyring of the MASTER_KEY setting, see utils.Keyring.
+// The keyring is parsed on every call, so the keys stay in their SecureString between calls.
+type LocalProvider struct {
+	masterKey utils.ISecureString
+}
+
+// NewLocalProvider creates a provider backed by a keyring specification.
+func NewLocalProvider(masterKey utils.ISecureString) *LocalProvider {
+	return &LocalProvider{masterKey: masterKey}
+}
+
+// NewFileProvider loads a keyring from a local keystore file: one "id:base64key" entry per line,
+// the active key first. Blank lines and lines starting with # are ignored.
+func NewFileProvider(path string) (*LocalProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening keystore")
+	}
+	defer file.Close()
+
+	var entries []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entries = append(entries, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "error reading keystore")
+	}
+
+	spec := strings.Join(entries, ",")
+	if _, err := serviceUtils.ParseKeyring(spec); err != nil {
+		return nil, errors.Wrapf(err, "invalid keystore %s", path)
+	}
+
+	return NewLocalProvider(utils.NewString(spec)), nil
+}
+
+func (p *LocalProvider) Wrap(_ context.Context, userKey string) (string, error) {
+	keyring, err := p.keyring()
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:wrapcheck
+	return keyring.Encrypt(userKey)
+}
+
+func (p *LocalProvider) Unwrap(_ context.Context, wrapped string) (string, error) {
+	keyring, err := p.keyring()
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:wrapcheck
+	return keyring.Decrypt(wrapped)
+}
+
+func (p *LocalProvider) Rewrap(_ context.Context, wrapped string) (string, bool, error) {
+	keyring, err := p.keyring()
+	if err != nil {
+		return "", false, err
+	}
+
+	//nolint:wrapcheck
+	return keyring.Rewrap(wrapped)
+}
+
+// Owns reports true for keyring ciphertexts: base64, optionally prefixed with "id$", never containing a colon.
+func (p *LocalProvider) Owns(wrapped string) bool {
+	return !strings.Contains(wrapped, ":")
+}
+
+// AddFallback appends the keys of another keyring, so keys they wrapped stay readable and get rewrapped.
+func (p *LocalProvider) AddFallback(masterKey utils.ISecureString) *LocalProvider {
+	return NewLocalProvider(utils.NewString(p.masterKey.Get() + "," + masterKey.Get()))
+}
+
+func (p *LocalProvider) keyring() (*serviceUtils.Keyring, error) {
+	if p.masterKey == nil {
+		return nil, serviceUtils.ErrEmptyKeyring
+	}
+
+	keyring, err := serviceUtils.ParseKeyring(p.masterKey.Get())
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing master keyring")
+	}
+
+	return keyring, nil
+}
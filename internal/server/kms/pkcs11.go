@@ -0,0 +1,132 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const pkcs11Prefix = "pkcs11:"
+
+var (
+	ErrUnknownModule = errors.New("pkcs11 module is not registered")
+
+	modulesMu sync.RWMutex
+	//nolint:gochecknoglobals
+	modules = make(map[string]ModuleOpener)
+)
+
+// Module is the part of a PKCS#11 token the provider relies on, C_WrapKey and C_UnwrapKey
+// with a wrapping key found by its label.
+type Module interface {
+	WrapKey(ctx context.Context, label string, key []byte) ([]byte, error)
+	UnwrapKey(ctx context.Context, label string, wrapped []byte) ([]byte, error)
+}
+
+// ModuleOpener opens a session on a token; params carry module specific settings such as slot and PIN.
+type ModuleOpener func(params string) (Module, error)
+
+// RegisterModule makes a module available under name. Bindings to a vendor library need cgo,
+// so they live in their own package and register themselves from init.
+func RegisterModule(name string, open ModuleOpener) {
+	modulesMu.Lock()
+	defer modulesMu.Unlock()
+
+	modules[name] = open
+}
+
+// PKCS11Provider wraps user keys inside a hardware token. Keys are stored as "pkcs11:<label>:<base64>",
+// so a new wrapping key is rolled out by changing the label and running a rotation.
+type PKCS11Provider struct {
+	module Module
+	label  string
+}
+
+// NewPKCS11Provider opens the registered module name and wraps with the key labelled label.
+func NewPKCS11Provider(name, params, label string) (*PKCS11Provider, error) {
+	if label == "" || strings.Contains(label, ":") {
+		return nil, errors.Errorf("invalid pkcs11 key label %q", label)
+	}
+
+	modulesMu.RLock()
+	open, ok := modules[name]
+	modulesMu.RUnlock()
+
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownModule, "%q", name)
+	}
+
+	module, err := open(params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening pkcs11 module %q", name)
+	}
+
+	return &PKCS11Provider{module: module, label: label}, nil
+}
+
+func (p *PKCS11Provider) Wrap(ctx context.Context, userKey string) (string, error) {
+	wrapped, err := p.module.WrapKey(ctx, p.label, []byte(userKey))
+	if err != nil {
+		return "", errors.Wrap(err, "error wrapping key")
+	}
+
+	return pkcs11Prefix + p.label + ":" + base64.StdEncoding.EncodeToString(wrapped), nil
+}
+
+func (p *PKCS11Provider) Unwrap(ctx context.Context, wrapped string) (string, error) {
+	label, data, err := parsePKCS11(wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	userKey, err := p.module.UnwrapKey(ctx, label, data)
+	if err != nil {
+		return "", errors.Wrap(err, "error unwrapping key")
+	}
+
+	return string(userKey), nil
+}
+
+func (p *PKCS11Provider) Rewrap(ctx context.Context, wrapped string) (string, bool, error) {
+	label, _, err := parsePKCS11(wrapped)
+	if err != nil {
+		return "", false, err
+	}
+
+	if label == p.label {
+		return wrapped, false, nil
+	}
+
+	userKey, err := p.Unwrap(ctx, wrapped)
+	if err != nil {
+		return "", false, err
+	}
+
+	rewrapped, err := p.Wrap(ctx, userKey)
+	if err != nil {
+		return "", false, err
+	}
+
+	return rewrapped, true, nil
+}
+
+func (p *PKCS11Provider) Owns(wrapped string) bool {
+	return strings.HasPrefix(wrapped, pkcs11Prefix)
+}
+
+func parsePKCS11(wrapped string) (string, []byte, error) {
+	label, encoded, ok := strings.Cut(strings.TrimPrefix(wrapped, pkcs11Prefix), ":")
+	if !strings.HasPrefix(wrapped, pkcs11Prefix) || !ok {
+		return "", nil, errors.New("invalid pkcs11 wrapped key")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "invalid pkcs11 wrapped key")
+	}
+
+	return label, data, nil
+}
@@ -0,0 +1,106 @@
+package kms_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+// softToken is a software stand-in for a token holding labelled wrapping keys.
+type softToken struct {
+	keys map[string]string
+}
+
+func (s *softToken) WrapKey(_ context.Context, label string, key []byte) ([]byte, error) {
+	wrapped, err := utils.Encrypt(string(key), s.keys[label])
+
+	return []byte(wrapped), err
+}
+
+func (s *softToken) UnwrapKey(_ context.Context, label string, wrapped []byte) ([]byte, error) {
+	key, err := utils.Decrypt(string(wrapped), s.keys[label])
+
+	return []byte(key), err
+}
+
+func registerSoftToken(t *testing.T, name string, labels ...string) {
+	t.Helper()
+
+	token := &softToken{keys: make(map[string]string)}
+	for _, label := range labels {
+		key, err := utils.GenerateRandomKey()
+		require.NoError(t, err)
+		token.keys[label] = key
+	}
+
+	kms.RegisterModule(name, func(params string) (kms.Module, error) {
+		if params != "slot=0" {
+			return nil, errors.New("no such slot")
+		}
+
+		return token, nil
+	})
+}
+
+func TestPKCS11Provider(t *testing.T) {
+	t.Parallel()
+
+	registerSoftToken(t, "soft-rotate", "key-1", "key-2")
+
+	first, err := kms.NewPKCS11Provider("soft-rotate", "slot=0", "key-1")
+	require.NoError(t, err)
+
+	wrapped, err := first.Wrap(t.Context(), "user-key")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(wrapped, "pkcs11:key-1:"))
+	assert.True(t, first.Owns(wrapped))
+
+	userKey, err := first.Unwrap(t.Context(), wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "user-key", userKey)
+
+	_, changed, err := first.Rewrap(t.Context(), wrapped)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Rolling out a new wrapping key is a label change.
+	second, err := kms.NewPKCS11Provider("soft-rotate", "slot=0", "key-2")
+	require.NoError(t, err)
+
+	rewrapped, changed, err := second.Rewrap(t.Context(), wrapped)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, strings.HasPrefix(rewrapped, "pkcs11:key-2:"))
+
+	userKey, err = second.Unwrap(t.Context(), rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "user-key", userKey)
+}
+
+func TestPKCS11Provider_Errors(t *testing.T) {
+	t.Parallel()
+
+	registerSoftToken(t, "soft-errors", "key-1")
+
+	_, err := kms.NewPKCS11Provider("missing", "", "key-1")
+	require.ErrorIs(t, err, kms.ErrUnknownModule)
+
+	_, err = kms.NewPKCS11Provider("soft-errors", "slot=9", "key-1")
+	require.ErrorContains(t, err, "no such slot")
+
+	_, err = kms.NewPKCS11Provider("soft-errors", "slot=0", "bad:label")
+	require.ErrorContains(t, err, "invalid pkcs11 key label")
+
+	provider, err := kms.NewPKCS11Provider("soft-errors", "slot=0", "key-1")
+	require.NoError(t, err)
+
+	_, err = provider.Unwrap(t.Context(), "pkcs11:key-1")
+	require.ErrorContains(t, err, "invalid pkcs11 wrapped key")
+}
@@ -0,0 +1,152 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	transitPrefix  = "vault:v"
+	transitTimeout = 10 * time.Second
+)
+
+var ErrTransit = errors.New("transit request failed")
+
+// TransitOptions configures a HashiCorp Vault Transit compatible secrets engine.
+type TransitOptions struct {
+	// Address of the server, e.g. https://vault.example.com:8200.
+	Address string
+	Token   string
+	// Mount is the path the engine is mounted at, "transit" by default.
+	Mount   string
+	KeyName string
+	// Client overrides the default HTTP client.
+	Client *http.Client
+}
+
+// TransitProvider wraps user keys with the encrypt, decrypt and rewrap endpoints of a Transit engine.
+// The master key never leaves it; rotating it is done there, and Rewrap moves keys to its latest version.
+type TransitProvider struct {
+	opts TransitOptions
+}
+
+type transitRequest struct {
+	Plaintext  string `json:"plaintext,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+}
+
+type transitResponse struct {
+	Data   transitRequest `json:"data"`
+	Errors []string       `json:"errors"`
+}
+
+// NewTransitProvider creates a Transit provider.
+func NewTransitProvider(opts TransitOptions) (*TransitProvider, error) {
+	if opts.Address == "" || opts.KeyName == "" {
+		return nil, errors.New("transit address and key name are required")
+	}
+
+	if opts.Mount == "" {
+		opts.Mount = "transit"
+	}
+
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: transitTimeout}
+	}
+
+	return &TransitProvider{opts: opts}, nil
+}
+
+func (p *TransitProvider) Wrap(ctx context.Context, userKey string) (string, error) {
+	resp, err := p.call(ctx, "encrypt", transitRequest{
+		Plaintext: base64.StdEncoding.EncodeToString([]byte(userKey)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Ciphertext, nil
+}
+
+func (p *TransitProvider) Unwrap(ctx context.Context, wrapped string) (string, error) {
+	resp, err := p.call(ctx, "decrypt", transitRequest{Ciphertext: wrapped})
+	if err != nil {
+		return "", err
+	}
+
+	userKey, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid transit plaintext")
+	}
+
+	return string(userKey), nil
+}
+
+func (p *TransitProvider) Rewrap(ctx context.Context, wrapped string) (string, bool, error) {
+	resp, err := p.call(ctx, "rewrap", transitRequest{Ciphertext: wrapped})
+	if err != nil {
+		return "", false, err
+	}
+
+	// Transit re-encrypts even when the version does not change; keep the stored value then.
+	if transitVersion(resp.Ciphertext) == transitVersion(wrapped) {
+		return wrapped, false, nil
+	}
+
+	return resp.Ciphertext, true, nil
+}
+
+// Owns reports true for Transit ciphertexts, "vault:v<version>:<base64>".
+func (p *TransitProvider) Owns(wrapped string) bool {
+	return strings.HasPrefix(wrapped, transitPrefix)
+}
+
+func (p *TransitProvider) call(ctx context.Context, operation string, req transitRequest) (transitRequest, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return transitRequest{}, errors.Wrap(err, "error encoding transit request")
+	}
+
+	url := strings.TrimRight(p.opts.Address, "/") + "/v1/" + p.opts.Mount + "/" + operation + "/" + p.opts.KeyName
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return transitRequest{}, errors.Wrap(err, "error creating transit request")
+	}
+	httpReq.Header.Set("X-Vault-Token", p.opts.Token)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := p.opts.Client.Do(httpReq)
+	if err != nil {
+		return transitRequest{}, errors.Wrap(err, "error calling transit")
+	}
+	defer httpResp.Body.Close()
+
+	var resp transitResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return transitRequest{}, errors.Wrapf(ErrTransit, "%s: status %d", operation, httpResp.StatusCode)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return transitRequest{}, errors.Wrapf(ErrTransit, "%s: status %d: %s",
+			operation, httpResp.StatusCode, strings.Join(resp.Errors, "; "))
+	}
+
+	return resp.Data, nil
+}
+
+// transitVersion returns the "vault:vN" part of a ciphertext.
+func transitVersion(ciphertext string) string {
+	if i := strings.LastIndex(ciphertext, ":"); i > 0 {
+		return ciphertext[:i]
+	}
+
+	return ciphertext
+}
@@ -0,0 +1,199 @@
+package kms_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+const transitToken = "test-token"
+
+// fakeTransit is a local stand-in for the Transit secrets engine, with one versioned key.
+type fakeTransit struct {
+	mu       sync.Mutex
+	versions []string
+}
+
+func newFakeTransit(t *testing.T) (*fakeTransit, *httptest.Server) {
+	t.Helper()
+
+	transit := &fakeTransit{}
+	transit.rotate(t)
+
+	server := httptest.NewServer(http.HandlerFunc(transit.serveHTTP))
+	t.Cleanup(server.Close)
+
+	return transit, server
+}
+
+func (f *fakeTransit) rotate(t *testing.T) {
+	t.Helper()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.versions = append(f.versions, key)
+}
+
+func (f *fakeTransit) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != transitToken {
+		writeTransit(w, http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+
+		return
+	}
+
+	var req struct {
+		Plaintext  string `json:"plaintext"`
+		Ciphertext string `json:"ciphertext"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeTransit(w, http.StatusBadRequest, map[string]any{"errors": []string{err.Error()}})
+
+		return
+	}
+
+	switch r.URL.Path {
+	case "/v1/transit/encrypt/users":
+		f.respond(w, "ciphertext", f.encrypt(req.Plaintext))
+	case "/v1/transit/decrypt/users":
+		plaintext, ok := f.decrypt(req.Ciphertext)
+		if !ok {
+			writeTransit(w, http.StatusBadRequest, map[string]any{"errors": []string{"cipher: message authentication failed"}})
+
+			return
+		}
+		f.respond(w, "plaintext", plaintext)
+	case "/v1/transit/rewrap/users":
+		plaintext, ok := f.decrypt(req.Ciphertext)
+		if !ok {
+			writeTransit(w, http.StatusBadRequest, map[string]any{"errors": []string{"invalid ciphertext"}})
+
+			return
+		}
+		f.respond(w, "ciphertext", f.encrypt(plaintext))
+	default:
+		writeTransit(w, http.StatusNotFound, map[string]any{"errors": []string{"no handler for route"}})
+	}
+}
+
+func (f *fakeTransit) encrypt(plaintext string) string {
+	version := len(f.versions)
+	ciphertext, _ := utils.Encrypt(plaintext, f.versions[version-1])
+
+	return "vault:v" + strconv.Itoa(version) + ":" + ciphertext
+}
+
+func (f *fakeTransit) decrypt(ciphertext string) (string, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(ciphertext, "vault:v"), ":", 2)
+	if len(parts) != 2 {
+		return "", false
+	}
+
+	version, err := strconv.Atoi(parts[0])
+	if err != nil || version < 1 || version > len(f.versions) {
+		return "", false
+	}
+
+	plaintext, err := utils.Decrypt(parts[1], f.versions[version-1])
+
+	return plaintext, err == nil
+}
+
+func (f *fakeTransit) respond(w http.ResponseWriter, field, value string) {
+	writeTransit(w, http.StatusOK, map[string]any{"data": map[string]string{field: value}})
+}
+
+func writeTransit(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newTransitProvider(t *testing.T, address, token string) *kms.TransitProvider {
+	t.Helper()
+
+	provider, err := kms.NewTransitProvider(kms.TransitOptions{Address: address, Token: token, KeyName: "users"})
+	require.NoError(t, err)
+
+	return provider
+}
+
+func TestTransitProvider_WrapUnwrap(t *testing.T) {
+	t.Parallel()
+
+	_, server := newFakeTransit(t)
+	provider := newTransitProvider(t, server.URL, transitToken)
+
+	wrapped, err := provider.Wrap(t.Context(), "user-key")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(wrapped, "vault:v1:"))
+	assert.True(t, provider.Owns(wrapped))
+	assert.False(t, provider.Owns("k1$abc"))
+
+	userKey, err := provider.Unwrap(t.Context(), wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "user-key", userKey)
+}
+
+func TestTransitProvider_Rewrap(t *testing.T) {
+	t.Parallel()
+
+	transit, server := newFakeTransit(t)
+	provider := newTransitProvider(t, server.URL, transitToken)
+
+	wrapped, err := provider.Wrap(t.Context(), "user-key")
+	require.NoError(t, err)
+
+	same, changed, err := provider.Rewrap(t.Context(), wrapped)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, wrapped, same)
+
+	transit.rotate(t)
+
+	rewrapped, changed, err := provider.Rewrap(t.Context(), wrapped)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, strings.HasPrefix(rewrapped, "vault:v2:"))
+
+	userKey, err := provider.Unwrap(t.Context(), rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "user-key", userKey)
+}
+
+func TestTransitProvider_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := kms.NewTransitProvider(kms.TransitOptions{Address: "http://localhost"})
+	require.Error(t, err)
+
+	_, server := newFakeTransit(t)
+
+	_, err = newTransitProvider(t, server.URL, "wrong-token").Wrap(t.Context(), "user-key")
+	require.ErrorIs(t, err, kms.ErrTransit)
+	assert.Contains(t, err.Error(), "permission denied")
+
+	_, err = newTransitProvider(t, server.URL, transitToken).Unwrap(t.Context(), "vault:v9:abc")
+	require.ErrorIs(t, err, kms.ErrTransit)
+
+	server.Close()
+
+	_, err = newTransitProvider(t, server.URL, transitToken).Wrap(t.Context(), "user-key")
+	require.ErrorContains(t, err, "error calling transit")
+}
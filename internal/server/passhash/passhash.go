@@ -0,0 +1,172 @@
+// Package passhash hashes login passwords into self-describing PHC strings, so the algorithm and its
+// parameters can change while older hashes still verify.
+package passhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Defaults for zero Params fields, following the second recommendation of RFC 9106.
+const (
+	DefaultMemory     = 64 * 1024 // KiB
+	DefaultTime       = 3
+	DefaultThreads    = 4
+	DefaultSaltLength = 16
+	DefaultKeyLength  = 32
+)
+
+const argon2idPrefix = "$argon2id$"
+
+var (
+	ErrMismatch      = errors.New("password does not match")
+	ErrUnknownFormat = errors.New("unknown password hash format")
+)
+
+// Hasher hashes and verifies login passwords.
+type Hasher interface {
+	// Hash returns the encoded hash of password with a new salt.
+	Hash(password string) (string, error)
+	// Verify checks password against an encoded hash and returns ErrMismatch when it is wrong.
+	Verify(password, encoded string) error
+	// NeedsRehash reports whether encoded was made by another algorithm or with other parameters
+	// than Hash uses now.
+	NeedsRehash(encoded string) bool
+}
+
+// Params tunes Argon2id. Memory is in KiB.
+type Params struct {
+	Memory     uint32
+	Time       uint32
+	Threads    uint8
+	SaltLength uint32
+	KeyLength  uint32
+}
+
+// Argon2id hashes passwords with Argon2id. It still verifies bcrypt hashes, which NeedsRehash reports
+// for replacement.
+type Argon2id struct {
+	params Params
+}
+
+// NewArgon2id creates the hasher; zero params fall back to the defaults.
+func NewArgon2id(params Params) *Argon2id {
+	if params.Memory == 0 {
+		params.Memory = DefaultMemory
+	}
+
+	if params.Time == 0 {
+		params.Time = DefaultTime
+	}
+
+	if params.Threads == 0 {
+		params.Threads = DefaultThreads
+	}
+
+	if params.SaltLength == 0 {
+		params.SaltLength = DefaultSaltLength
+	}
+
+	if params.KeyLength == 0 {
+		params.KeyLength = DefaultKeyLength
+	}
+
+	return &Argon2id{params: params}
+}
+
+// Hash returns password hashed as $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>.
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, a.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(err, "error generating salt")
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.params.Time, a.params.Memory, a.params.Threads, a.params.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		a.params.Memory, a.params.Time, a.params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify checks password against an Argon2id or a bcrypt hash.
+func (a *Argon2id) Verify(password, encoded string) error {
+	if isBcrypt(encoded) {
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrMismatch
+		}
+
+		return errors.Wrap(err, "error verifying bcrypt hash")
+	}
+
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // the key length comes from a hash this package wrote
+	computed := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(computed, key) != 1 {
+		return ErrMismatch
+	}
+
+	return nil
+}
+
+// NeedsRehash reports true for bcrypt hashes and for Argon2id hashes with other parameters.
+func (a *Argon2id) NeedsRehash(encoded string) bool {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+
+	//nolint:gosec // lengths of decoded base64 strings
+	params.SaltLength, params.KeyLength = uint32(len(salt)), uint32(len(key))
+
+	return params != a.params
+}
+
+func isBcrypt(encoded string) bool {
+	_, err := bcrypt.Cost([]byte(encoded))
+
+	return err == nil
+}
+
+func decodeArgon2id(encoded string) (Params, []byte, []byte, error) {
+	var params Params
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(encoded, "$")
+	if !strings.HasPrefix(encoded, argon2idPrefix) || len(parts) != 6 {
+		return params, nil, nil, ErrUnknownFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errors.Wrap(ErrUnknownFormat, "unsupported argon2 version")
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads)
+	if err != nil || params.Time == 0 || params.Threads == 0 {
+		return params, nil, nil, errors.Wrap(ErrUnknownFormat, "invalid argon2 parameters")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errors.Wrap(ErrUnknownFormat, "invalid salt")
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errors.Wrap(ErrUnknownFormat, "invalid key")
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,97 @@
+package passhash_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/npavlov/go-password-manager/internal/server/passhash"
+)
+
+// cheap keeps the tests fast.
+//
+//nolint:gochecknoglobals
+var cheap = passhash.Params{Memory: 1024, Time: 1, Threads: 1}
+
+func TestArgon2id(t *testing.T) {
+	t.Parallel()
+
+	hasher := passhash.NewArgon2id(cheap)
+
+	encoded, err := hasher.Hash("securePass123!")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	require.NoError(t, hasher.Verify("securePass123!", encoded))
+	require.ErrorIs(t, hasher.Verify("securePass124!", encoded), passhash.ErrMismatch)
+	assert.False(t, hasher.NeedsRehash(encoded))
+
+	// Salts differ, so equal passwords get different hashes.
+	again, err := hasher.Hash("securePass123!")
+	require.NoError(t, err)
+	assert.NotEqual(t, encoded, again)
+}
+
+func TestArgon2id_LongPasswords(t *testing.T) {
+	t.Parallel()
+
+	hasher := passhash.NewArgon2id(cheap)
+	long := strings.Repeat("a", 100)
+
+	encoded, err := hasher.Hash(long + "1")
+	require.NoError(t, err)
+
+	// Unlike bcrypt, bytes past the 72nd count.
+	require.ErrorIs(t, hasher.Verify(long+"2", encoded), passhash.ErrMismatch)
+}
+
+func TestArgon2id_Bcrypt(t *testing.T) {
+	t.Parallel()
+
+	hasher := passhash.NewArgon2id(cheap)
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("securePass123!"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	require.NoError(t, hasher.Verify("securePass123!", string(legacy)))
+	require.ErrorIs(t, hasher.Verify("wrong", string(legacy)), passhash.ErrMismatch)
+	assert.True(t, hasher.NeedsRehash(string(legacy)))
+}
+
+func TestArgon2id_OutdatedParams(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := passhash.NewArgon2id(cheap).Hash("securePass123!")
+	require.NoError(t, err)
+
+	stronger := passhash.NewArgon2id(passhash.Params{Memory: 2048, Time: 1, Threads: 1})
+
+	// Old parameters still verify, but ask for a new hash.
+	require.NoError(t, stronger.Verify("securePass123!", encoded))
+	assert.True(t, stronger.NeedsRehash(encoded))
+
+	longerKey := passhash.NewArgon2id(passhash.Params{Memory: 1024, Time: 1, Threads: 1, KeyLength: 64})
+	assert.True(t, longerKey.NeedsRehash(encoded))
+}
+
+func TestArgon2id_InvalidHash(t *testing.T) {
+	t.Parallel()
+
+	hasher := passhash.NewArgon2id(cheap)
+
+	for _, encoded := range []string{
+		"",
+		"plain",
+		"$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$",
+	} {
+		require.ErrorIs(t, hasher.Verify("securePass123!", encoded), passhash.ErrUnknownFormat, encoded)
+		assert.True(t, hasher.NeedsRehash(encoded), encoded)
+	}
+}
@@ -0,0 +1,189 @@
+// Package ratelimit slows down password guessing against the login.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Key prefixes of the login state in Redis.
+const (
+	failuresPrefix = "login-failures:"
+	lockPrefix     = "login-lock:"
+	addressPrefix  = "login-address:"
+)
+
+// Store keeps the login attempts shared by every server replica, see redis.RStorage.
+type Store interface {
+	Del(ctx context.Context, keys ...string) error
+	Record(ctx context.Context, key string, window time.Duration) error
+	Recent(ctx context.Context, key string, window time.Duration) ([]time.Time, error)
+	Admit(ctx context.Context, key string, limit int, window time.Duration) ([]time.Time, error)
+}
+
+// Limits configures a LoginGuard. A zero MaxFailures or IPAttempts turns that limit off.
+type Limits struct {
+	// MaxFailures failed logins of a username within FailureWindow lock it for Lockout.
+	MaxFailures   int
+	FailureWindow time.Duration
+	// Backoff is the wait after the first failure; it doubles with every further one.
+	Backoff time.Duration
+	Lockout time.Duration
+	// IPAttempts logins are allowed from one address within IPWindow.
+	IPAttempts int
+	IPWindow   time.Duration
+}
+
+// BlockedError rejects a login until RetryAfter has passed.
+type BlockedError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", e.Reason, e.RetryAfter.Round(time.Second))
+}
+
+// LoginGuard tracks login attempts per username and per client address.
+type LoginGuard struct {
+	store  Store
+	limits Limits
+	logger *zerolog.Logger
+}
+
+func NewLoginGuard(store Store, limits Limits, logger *zerolog.Logger) *LoginGuard {
+	return &LoginGuard{
+		store:  store,
+		limits: limits,
+		logger: logger,
+	}
+}
+
+// Check returns a *BlockedError while the username is locked or backing off, or when the username or
+// the address made too many attempts. Allowed attempts are counted before the password is verified, so
+// parallel attempts cannot all pass before the first failure is counted.
+func (g *LoginGuard) Check(ctx context.Context, username, ip string) error {
+	if g.limits.MaxFailures > 0 {
+		if err := g.checkUsername(ctx, username); err != nil {
+			return err
+		}
+	}
+
+	if g.limits.IPAttempts <= 0 || ip == "" {
+		return nil
+	}
+
+	return g.admit(ctx, addressPrefix+ip, g.limits.IPAttempts, g.limits.IPWindow, "too many login attempts")
+}
+
+// Failed marks the attempt counted by Check as failed. The next attempt has to wait for the backoff, and
+// MaxFailures failures within the window lock the username.
+func (g *LoginGuard) Failed(ctx context.Context, username string) error {
+	if g.limits.MaxFailures <= 0 {
+		return nil
+	}
+
+	key := failuresPrefix + username
+
+	failures, err := g.store.Recent(ctx, key, g.limits.FailureWindow)
+	if err != nil {
+		return errors.Wrap(err, "error counting failed logins")
+	}
+
+	if len(failures) < g.limits.MaxFailures {
+		return nil
+	}
+
+	g.logger.Warn().Str("username", username).Int("failures", len(failures)).Msg("account locked")
+
+	if err := g.store.Record(ctx, lockPrefix+username, g.limits.Lockout); err != nil {
+		return errors.Wrap(err, "error locking account")
+	}
+
+	// Counting starts over once the lock is lifted
+	return errors.Wrap(g.store.Del(ctx, key), "error resetting failed logins")
+}
+
+// Succeeded forgets the attempts and failed logins of username.
+func (g *LoginGuard) Succeeded(ctx context.Context, username string) error {
+	if g.limits.MaxFailures <= 0 {
+		return nil
+	}
+
+	return errors.Wrap(g.store.Del(ctx, failuresPrefix+username), "error resetting failed logins")
+}
+
+// Unlock lifts the lock of username and forgets its failed logins.
+func (g *LoginGuard) Unlock(ctx context.Context, username string) error {
+	if err := g.store.Del(ctx, lockPrefix+username, failuresPrefix+username); err != nil {
+		return errors.Wrap(err, "error unlocking account")
+	}
+
+	g.logger.Info().Str("username", username).Msg("account unlocked")
+
+	return nil
+}
+
+// checkUsername returns a *BlockedError while username is locked, waiting after its last failure or out of
+// attempts, and counts the attempt otherwise.
+func (g *LoginGuard) checkUsername(ctx context.Context, username string) error {
+	locks, err := g.store.Recent(ctx, lockPrefix+username, g.limits.Lockout)
+	if err != nil {
+		return errors.Wrap(err, "error checking account lock")
+	}
+
+	if len(locks) > 0 {
+		until := locks[len(locks)-1].Add(g.limits.Lockout)
+
+		return &BlockedError{Reason: "account temporarily locked", RetryAfter: time.Until(until)}
+	}
+
+	failures, err := g.store.Recent(ctx, failuresPrefix+username, g.limits.FailureWindow)
+	if err != nil {
+		return errors.Wrap(err, "error counting failed logins")
+	}
+
+	if len(failures) > 0 {
+		until := failures[len(failures)-1].Add(g.backoff(len(failures)))
+		if retryAfter := time.Until(until); retryAfter > 0 {
+			return &BlockedError{Reason: "too many failed logins", RetryAfter: retryAfter}
+		}
+	}
+
+	return g.admit(ctx, failuresPrefix+username, g.limits.MaxFailures, g.limits.FailureWindow, "too many failed logins")
+}
+
+// admit counts an attempt under key, or returns a *BlockedError when limit attempts were made within window.
+func (g *LoginGuard) admit(ctx context.Context, key string, limit int, window time.Duration, reason string) error {
+	attempts, err := g.store.Admit(ctx, key, limit, window)
+	if err != nil {
+		return errors.Wrap(err, "error counting login attempt")
+	}
+
+	if len(attempts) < limit {
+		return nil
+	}
+
+	// The caller may try again once enough of its attempts left the window
+	oldest := attempts[len(attempts)-limit]
+
+	return &BlockedError{Reason: reason, RetryAfter: time.Until(oldest.Add(window))}
+}
+
+// backoff is the wait after the given number of failures, at most the lockout.
+func (g *LoginGuard) backoff(failures int) time.Duration {
+	delay := g.limits.Backoff
+	for range failures - 1 {
+		if delay >= g.limits.Lockout {
+			break
+		}
+
+		delay *= 2
+	}
+
+	return min(delay, g.limits.Lockout)
+}
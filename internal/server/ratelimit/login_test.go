@@ -0,0 +1,215 @@
+//nolint:err113
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func newGuard(limits ratelimit.Limits) *ratelimit.LoginGuard {
+	logger := zerolog.Nop()
+
+	return ratelimit.NewLoginGuard(testutils.NewMockRedis(), limits, &logger)
+}
+
+// blocked returns the *BlockedError of err.
+func blocked(t *testing.T, err error) *ratelimit.BlockedError {
+	t.Helper()
+
+	var blockedErr *ratelimit.BlockedError
+	require.ErrorAs(t, err, &blockedErr)
+
+	return blockedErr
+}
+
+// fail makes a login attempt of username that fails.
+func fail(t *testing.T, guard *ratelimit.LoginGuard, username string) {
+	t.Helper()
+
+	require.NoError(t, guard.Check(t.Context(), username, ""))
+	require.NoError(t, guard.Failed(t.Context(), username))
+}
+
+func TestLoginGuard_Backoff(t *testing.T) {
+	t.Parallel()
+
+	guard := newGuard(ratelimit.Limits{
+		MaxFailures:   10,
+		FailureWindow: time.Minute,
+		Backoff:       50 * time.Millisecond,
+		Lockout:       150 * time.Millisecond,
+	})
+
+	fail(t, guard, "alice")
+
+	retryAfter := blocked(t, guard.Check(t.Context(), "alice", "")).RetryAfter
+	assert.Greater(t, retryAfter, time.Duration(0))
+	assert.LessOrEqual(t, retryAfter, 50*time.Millisecond)
+
+	// Other usernames are not affected.
+	require.NoError(t, guard.Check(t.Context(), "bob", ""))
+
+	// The wait doubles with every failure, up to the lockout. Blocked attempts are not counted.
+	time.Sleep(retryAfter)
+	fail(t, guard, "alice")
+
+	retryAfter = blocked(t, guard.Check(t.Context(), "alice", "")).RetryAfter
+	assert.Greater(t, retryAfter, 50*time.Millisecond)
+
+	time.Sleep(retryAfter)
+	fail(t, guard, "alice")
+	assert.LessOrEqual(t, blocked(t, guard.Check(t.Context(), "alice", "")).RetryAfter, 150*time.Millisecond)
+
+	time.Sleep(150 * time.Millisecond)
+	require.NoError(t, guard.Check(t.Context(), "alice", ""))
+
+	// A successful login forgets the attempts.
+	require.NoError(t, guard.Succeeded(t.Context(), "alice"))
+	require.NoError(t, guard.Check(t.Context(), "alice", ""))
+}
+
+func TestLoginGuard_Lockout(t *testing.T) {
+	t.Parallel()
+
+	guard := newGuard(ratelimit.Limits{
+		MaxFailures:   3,
+		FailureWindow: time.Minute,
+		Lockout:       time.Hour,
+	})
+
+	for range 3 {
+		fail(t, guard, "alice")
+	}
+
+	blockedErr := blocked(t, guard.Check(t.Context(), "alice", ""))
+	assert.InDelta(t, time.Hour.Seconds(), blockedErr.RetryAfter.Seconds(), 1)
+	assert.Contains(t, blockedErr.Error(), "account temporarily locked, retry in 1h0m0s")
+
+	// Succeeding with another way in does not lift the lock.
+	require.NoError(t, guard.Succeeded(t.Context(), "alice"))
+	blocked(t, guard.Check(t.Context(), "alice", ""))
+
+	require.NoError(t, guard.Unlock(t.Context(), "alice"))
+
+	// Counting starts over after the lock.
+	fail(t, guard, "alice")
+	require.NoError(t, guard.Check(t.Context(), "alice", ""))
+}
+
+func TestLoginGuard_ConcurrentAttempts(t *testing.T) {
+	t.Parallel()
+
+	guard := newGuard(ratelimit.Limits{
+		MaxFailures:   3,
+		FailureWindow: time.Minute,
+		Lockout:       time.Hour,
+		IPAttempts:    5,
+		IPWindow:      time.Minute,
+	})
+
+	// A burst of parallel guesses gets no more attempts than the limits, even before any of them failed.
+	var allowed atomic.Int32
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := guard.Check(t.Context(), "alice", "10.0.0.1")
+			if err == nil {
+				allowed.Add(1)
+
+				return
+			}
+
+			var blockedErr *ratelimit.BlockedError
+			assert.ErrorAs(t, err, &blockedErr)
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(3), allowed.Load())
+
+	// The address has attempts left for other usernames, but not beyond its own limit.
+	require.NoError(t, guard.Check(t.Context(), "bob", "10.0.0.1"))
+	require.NoError(t, guard.Check(t.Context(), "carol", "10.0.0.1"))
+	assert.Equal(t, "too many login attempts", blocked(t, guard.Check(t.Context(), "dave", "10.0.0.1")).Reason)
+}
+
+func TestLoginGuard_Address(t *testing.T) {
+	t.Parallel()
+
+	guard := newGuard(ratelimit.Limits{IPAttempts: 2, IPWindow: time.Minute})
+
+	require.NoError(t, guard.Check(t.Context(), "alice", "10.0.0.1"))
+	require.NoError(t, guard.Check(t.Context(), "bob", "10.0.0.1"))
+
+	blockedErr := blocked(t, guard.Check(t.Context(), "carol", "10.0.0.1"))
+	assert.InDelta(t, time.Minute.Seconds(), blockedErr.RetryAfter.Seconds(), 1)
+
+	require.NoError(t, guard.Check(t.Context(), "alice", "10.0.0.2"))
+	require.NoError(t, guard.Check(t.Context(), "alice", ""))
+}
+
+func TestLoginGuard_Disabled(t *testing.T) {
+	t.Parallel()
+
+	guard := newGuard(ratelimit.Limits{})
+
+	for range 10 {
+		fail(t, guard, "alice")
+		require.NoError(t, guard.Check(t.Context(), "alice", "10.0.0.1"))
+	}
+
+	require.NoError(t, guard.Succeeded(t.Context(), "alice"))
+}
+
+// failingStore is a Store without a connection to Redis.
+type failingStore struct{}
+
+func (failingStore) Del(_ context.Context, _ ...string) error {
+	return errors.New("connection refused")
+}
+
+func (failingStore) Record(_ context.Context, _ string, _ time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingStore) Recent(_ context.Context, _ string, _ time.Duration) ([]time.Time, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (failingStore) Admit(_ context.Context, _ string, _ int, _ time.Duration) ([]time.Time, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestLoginGuard_StoreErrors(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	guard := ratelimit.NewLoginGuard(failingStore{}, ratelimit.Limits{
+		MaxFailures:   3,
+		FailureWindow: time.Minute,
+		Lockout:       time.Hour,
+		IPAttempts:    2,
+		IPWindow:      time.Minute,
+	}, &logger)
+
+	require.ErrorContains(t, guard.Check(t.Context(), "alice", "10.0.0.1"), "error checking account lock")
+	require.ErrorContains(t, guard.Failed(t.Context(), "alice"), "error counting failed logins")
+	require.ErrorContains(t, guard.Succeeded(t.Context(), "alice"), "error resetting failed logins")
+	require.ErrorContains(t, guard.Unlock(t.Context(), "alice"), "error unlocking account")
+}
@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// PubSub fans messages out to every server replica subscribed to a channel.
+type PubSub interface {
+	Publish(ctx context.Context, channel, message string) error
+	Subscribe(ctx context.Context, channel string) (<-chan string, error)
+}
+
+// ItemChangedMessage is the payload published on ItemChangesChannel; watchers re-read the change log themselves.
+const ItemChangedMessage = "changed"
+
+// ItemChangesChannel is the channel announcing changes to the vault of a user.
+func ItemChangesChannel(userID string) string {
+	return "item-changes:" + userID
+}
+
+func (rst *RStorage) Publish(ctx context.Context, channel, message string) error {
+	err := rst.Client.Publish(ctx, channel, message).Err()
+	if err != nil {
+		rst.Logger.Error().Err(err).Str("channel", channel).Msg("Failed to publish to Redis")
+
+		return errors.Wrap(err, "failed to publish")
+	}
+
+	return nil
+}
+
+// Subscribe listens on channel until ctx is cancelled. The subscription is confirmed
+// before returning, so no message published afterwards is missed. The returned channel
+// is closed once ctx is done.
+func (rst *RStorage) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	sub := rst.Client.Subscribe(ctx, channel)
+
+	if _, err := sub.Receive(ctx); err != nil {
+		_ = sub.Close()
+		rst.Logger.Error().Err(err).Str("channel", channel).Msg("Failed to subscribe to Redis")
+
+		return nil, errors.Wrap(err, "failed to subscribe")
+	}
+
+	messages := make(chan string)
+
+	go func() {
+		defer close(messages)
+		defer func() { _ = sub.Close() }()
+
+		incoming := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-incoming:
+				if !ok {
+					return
+				}
+
+				select {
+				case messages <- msg.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
@@ -14,6 +14,10 @@ import (
 type MemStorage interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value string, expiration time.Duration) error
+	// GetDel returns the value of key and deletes it, so only one caller gets it.
+	GetDel(ctx context.Context, key string) (string, error)
+	// Del deletes the keys; missing keys are ignored.
+	Del(ctx context.Context, keys ...string) error
 }
 
 type RStorage struct {
@@ -87,3 +91,25 @@ func (rst *RStorage) Set(ctx context.Context, key string, value string, expirati
 
 	return nil
 }
+
+func (rst *RStorage) GetDel(ctx context.Context, key string) (string, error) {
+	result, err := rst.Client.GetDel(ctx, key).Result()
+	if err != nil {
+		rst.Logger.Error().Err(err).Str("key", key).Msg("Failed to get and delete value from Redis")
+
+		return "", errors.Wrap(err, "failed to get and delete value")
+	}
+
+	return result, nil
+}
+
+func (rst *RStorage) Del(ctx context.Context, keys ...string) error {
+	err := rst.Client.Del(ctx, keys...).Err()
+	if err != nil {
+		rst.Logger.Error().Err(err).Strs("keys", keys).Msg("Failed to delete values from Redis")
+
+		return errors.Wrap(err, "failed to delete values")
+	}
+
+	return nil
+}
@@ -2,7 +2,10 @@
 package redis_test
 
 import (
+	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -104,6 +107,60 @@ func TestGet_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to get value")
 }
 
+func TestGetDel(t *testing.T) {
+	t.Parallel()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	err = mr.Set("test-key", "test-value")
+	require.NoError(t, err)
+
+	logger := zerolog.New(nil)
+	cfg := config.Config{
+		Redis: mr.Addr(),
+	}
+
+	storage := redis.NewRStorage(cfg, &logger)
+	value, err := storage.GetDel(t.Context(), "test-key")
+	require.NoError(t, err)
+	assert.Equal(t, "test-value", value)
+	assert.False(t, mr.Exists("test-key"))
+
+	// The value is only returned once.
+	_, err = storage.GetDel(t.Context(), "test-key")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get and delete value")
+}
+
+func TestDel(t *testing.T) {
+	t.Parallel()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	require.NoError(t, mr.Set("first", "1"))
+	require.NoError(t, mr.Set("second", "2"))
+
+	logger := zerolog.New(nil)
+	cfg := config.Config{
+		Redis: mr.Addr(),
+	}
+
+	storage := redis.NewRStorage(cfg, &logger)
+	require.NoError(t, storage.Del(t.Context(), "first", "second", "missing"))
+	assert.False(t, mr.Exists("first"))
+	assert.False(t, mr.Exists("second"))
+
+	mr.Close()
+
+	err = storage.Del(t.Context(), "first")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to delete values")
+}
+
 func TestSet_Success(t *testing.T) {
 	t.Parallel()
 
@@ -185,3 +242,142 @@ func TestSet_ZeroExpiration(t *testing.T) {
 	ttl := mr.TTL(testKey)
 	assert.Equal(t, time.Duration(0), ttl)
 }
+
+func TestPublishSubscribe(t *testing.T) {
+	t.Parallel()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	logger := zerolog.New(nil)
+	storage := redis.NewRStorage(config.Config{Redis: mr.Addr()}, &logger)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	channel := redis.ItemChangesChannel("user-1")
+
+	messages, err := storage.Subscribe(ctx, channel)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.Publish(t.Context(), channel, "changed"))
+
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "changed", msg)
+	case <-time.After(time.Second):
+		t.Fatal("message was not delivered")
+	}
+
+	cancel()
+
+	// The channel is closed once the subscriber goes away.
+	for range messages {
+	}
+}
+
+func TestPublish_Failure(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.New(nil)
+	storage := redis.NewRStorage(config.Config{Redis: "invalid-address:6379"}, &logger)
+
+	err := storage.Publish(t.Context(), redis.ItemChangesChannel("user-1"), "changed")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to publish")
+
+	_, err = storage.Subscribe(t.Context(), redis.ItemChangesChannel("user-1"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to subscribe")
+}
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	logger := zerolog.New(nil)
+	storage := redis.NewRStorage(config.Config{Redis: mr.Addr()}, &logger)
+
+	events, err := storage.Recent(t.Context(), "attempts", time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	before := time.Now().Add(-time.Millisecond)
+
+	for range 3 {
+		require.NoError(t, storage.Record(t.Context(), "attempts", time.Minute))
+	}
+
+	events, err = storage.Recent(t.Context(), "attempts", time.Minute)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.True(t, events[0].After(before))
+	assert.False(t, events[2].Before(events[0]))
+
+	// The key expires with the window.
+	assert.Equal(t, time.Minute, mr.TTL("attempts"))
+
+	events, err = storage.Recent(t.Context(), "attempts", time.Nanosecond)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestWindow_Admit(t *testing.T) {
+	t.Parallel()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	logger := zerolog.New(nil)
+	storage := redis.NewRStorage(config.Config{Redis: mr.Addr()}, &logger)
+
+	// Parallel callers are admitted up to the limit, each seeing the events before its own.
+	var admitted atomic.Int32
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			events, err := storage.Admit(t.Context(), "attempts", 3, time.Minute)
+			assert.NoError(t, err)
+
+			if len(events) < 3 {
+				admitted.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(3), admitted.Load())
+
+	events, err := storage.Recent(t.Context(), "attempts", time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, time.Minute, mr.TTL("attempts"))
+
+	// Events leaving the window make room again.
+	events, err = storage.Admit(t.Context(), "attempts", 3, time.Nanosecond)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestWindow_Failure(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.New(nil)
+	storage := redis.NewRStorage(config.Config{Redis: "invalid-address:6379"}, &logger)
+
+	require.ErrorContains(t, storage.Record(t.Context(), "attempts", time.Minute), "failed to record event")
+
+	_, err := storage.Recent(t.Context(), "attempts", time.Minute)
+	require.ErrorContains(t, err, "failed to get events")
+
+	_, err = storage.Admit(t.Context(), "attempts", 3, time.Minute)
+	require.ErrorContains(t, err, "failed to admit event")
+}
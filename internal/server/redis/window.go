@@ -0,0 +1,98 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// Window counts events in sliding time windows shared by every server replica.
+type Window interface {
+	// Record adds an event at the current time under key and drops the events older than window.
+	Record(ctx context.Context, key string, window time.Duration) error
+	// Recent returns the times of the events under key within window, oldest first.
+	Recent(ctx context.Context, key string, window time.Duration) ([]time.Time, error)
+	// Admit records an event under key unless limit events are already within window, in one atomic step,
+	// and returns the events within window before it. The event was recorded when fewer than limit are returned.
+	Admit(ctx context.Context, key string, limit int, window time.Duration) ([]time.Time, error)
+}
+
+// admitScript trims the window, returns its events as member/score pairs and adds the new event while
+// the window has room. Scripts run atomically, so parallel callers never see the same count.
+var admitScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+local events = redis.call('ZRANGE', KEYS[1], 0, -1, 'WITHSCORES')
+if #events / 2 < tonumber(ARGV[3]) then
+	redis.call('ZADD', KEYS[1], ARGV[2], ARGV[5])
+	redis.call('PEXPIRE', KEYS[1], ARGV[4])
+end
+return events
+`)
+
+// Record keeps the events in a sorted set scored by their time in microseconds.
+func (rst *RStorage) Record(ctx context.Context, key string, window time.Duration) error {
+	now := time.Now()
+
+	pipe := rst.Client.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(now.Add(-window).UnixMicro(), 10))
+	// Members are random, so events of the same microsecond are all kept
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.UnixMicro()), Member: rand.Text()})
+	pipe.PExpire(ctx, key, window)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		rst.Logger.Error().Err(err).Str("key", key).Msg("Failed to record event in Redis")
+
+		return errors.Wrap(err, "failed to record event")
+	}
+
+	return nil
+}
+
+func (rst *RStorage) Recent(ctx context.Context, key string, window time.Duration) ([]time.Time, error) {
+	//nolint:exhaustruct
+	events, err := rst.Client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(time.Now().Add(-window).UnixMicro(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		rst.Logger.Error().Err(err).Str("key", key).Msg("Failed to get events from Redis")
+
+		return nil, errors.Wrap(err, "failed to get events")
+	}
+
+	times := make([]time.Time, len(events))
+	for i, event := range events {
+		times[i] = time.UnixMicro(int64(event.Score))
+	}
+
+	return times, nil
+}
+
+func (rst *RStorage) Admit(ctx context.Context, key string, limit int, window time.Duration) ([]time.Time, error) {
+	now := time.Now()
+
+	events, err := admitScript.Run(ctx, rst.Client, []string{key},
+		now.Add(-window).UnixMicro(), now.UnixMicro(), limit, window.Milliseconds(), rand.Text()).StringSlice()
+	if err != nil {
+		rst.Logger.Error().Err(err).Str("key", key).Msg("Failed to admit event in Redis")
+
+		return nil, errors.Wrap(err, "failed to admit event")
+	}
+
+	// Members and scores alternate
+	times := make([]time.Time, 0, len(events)/2)
+	for i := 1; i < len(events); i += 2 {
+		score, err := strconv.ParseFloat(events[i], 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid event time")
+		}
+
+		times = append(times, time.UnixMicro(int64(score)))
+	}
+
+	return times, nil
+}
@@ -0,0 +1,223 @@
+//nolint:wrapcheck,exhaustruct
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/audit"
+	"github.com/npavlov/go-password-manager/internal/server/auditlog"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// exportBatchSize is the number of events read and sent at a time by ExportAuditLogV1.
+const exportBatchSize = 500
+
+type Storage interface {
+	GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User, error)
+	ListAuditEvents(ctx context.Context, params db.ListAuditEventsParams) ([]db.AuditEvent, error)
+	ExportAuditEvents(ctx context.Context, afterSeq int64, batchSize int32) ([]db.AuditEvent, error)
+}
+
+type Service struct {
+	pb.UnimplementedAuditServiceServer
+	validator protovalidate.Validator
+	logger    *zerolog.Logger
+	storage   Storage
+	cfg       *config.Config
+}
+
+func NewAuditService(log *zerolog.Logger, storage Storage, cfg *config.Config) *Service {
+	validator, err := protovalidate.New()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create validator")
+	}
+
+	return &Service{
+		logger:    log,
+		validator: validator,
+		storage:   storage,
+		cfg:       cfg,
+	}
+}
+
+func (as *Service) RegisterService(grpcServer *grpc.Server) {
+	pb.RegisterAuditServiceServer(grpcServer, as)
+}
+
+// GetAuditLogV1 lists the events of the calls the caller made, and of the calls others made on items in the
+// caller's vault, the newest first.
+func (as *Service) GetAuditLogV1(ctx context.Context, req *pb.GetAuditLogV1Request) (*pb.GetAuditLogV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	limit, offset := utils.Paginate(req.GetPage(), req.GetPageSize())
+
+	params := db.ListAuditEventsParams{
+		UserID: userUUID,
+		ItemID: gu.GetIDFromString(req.GetItemId()),
+		Action: req.GetAction(),
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if req.GetSince() != nil {
+		params.Since = pgtype.Timestamp{Time: req.GetSince().AsTime(), Valid: true}
+	}
+
+	if req.GetUntil() != nil {
+		params.Until = pgtype.Timestamp{Time: req.GetUntil().AsTime(), Valid: true}
+	}
+
+	events, err := as.storage.ListAuditEvents(ctx, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing audit events")
+	}
+
+	resp := &pb.GetAuditLogV1Response{}
+
+	for _, event := range events {
+		resp.Events = append(resp.Events, &pb.AuditEvent{
+			Seq:        event.Seq,
+			ActorId:    event.ActorID.String(),
+			ItemId:     event.ItemID.String(),
+			Action:     event.Action,
+			Ip:         event.Ip,
+			UserAgent:  event.UserAgent,
+			Result:     event.Result,
+			OccurredAt: timestamppb.New(event.OccurredAt.Time),
+			PrevHash:   event.PrevHash,
+			Hash:       event.Hash,
+		})
+	}
+
+	return resp, nil
+}
+
+// exportedEvent is a line of the export.
+type exportedEvent struct {
+	Seq        int64     `json:"seq"`
+	ActorID    string    `json:"actor_id"`
+	ItemID     string    `json:"item_id"`
+	Action     string    `json:"action"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	Result     string    `json:"result"`
+	OccurredAt time.Time `json:"occurred_at"`
+	PrevHash   string    `json:"prev_hash"`
+	Hash       string    `json:"hash"`
+}
+
+// ExportAuditLogV1 streams the whole log as JSON Lines to the users named in AUDIT_ADMINS. It checks the
+// chain on the way and stops with DataLoss at the first event that does not fit, after sending the
+// events before it.
+func (as *Service) ExportAuditLogV1(
+	req *pb.ExportAuditLogV1Request,
+	stream grpc.ServerStreamingServer[pb.ExportAuditLogV1Response],
+) error {
+	ctx := stream.Context()
+
+	if err := as.validator.Validate(req); err != nil {
+		return errors.Wrap(err, "error validating input")
+	}
+
+	if err := as.checkAdmin(ctx); err != nil {
+		return err
+	}
+
+	var verifier auditlog.Verifier
+
+	for afterSeq := req.GetAfterSeq(); ; {
+		events, err := as.storage.ExportAuditEvents(ctx, afterSeq, exportBatchSize)
+		if err != nil {
+			return errors.Wrap(err, "error exporting audit events")
+		}
+
+		var chunk bytes.Buffer
+
+		encoder := json.NewEncoder(&chunk)
+
+		for _, event := range events {
+			if err := verifier.Check(event); err != nil {
+				as.logger.Error().Err(err).Msg("audit chain is broken")
+
+				if chunk.Len() > 0 {
+					_ = stream.Send(&pb.ExportAuditLogV1Response{Data: chunk.Bytes()})
+				}
+
+				return status.Error(codes.DataLoss, err.Error())
+			}
+
+			if err := encoder.Encode(exportedEvent{
+				Seq:        event.Seq,
+				ActorID:    event.ActorID.String(),
+				ItemID:     event.ItemID.String(),
+				Action:     event.Action,
+				IP:         event.Ip,
+				UserAgent:  event.UserAgent,
+				Result:     event.Result,
+				OccurredAt: event.OccurredAt.Time,
+				PrevHash:   event.PrevHash,
+				Hash:       event.Hash,
+			}); err != nil {
+				return errors.Wrap(err, "error encoding audit event")
+			}
+		}
+
+		if len(events) > 0 {
+			if err := stream.Send(&pb.ExportAuditLogV1Response{Data: chunk.Bytes()}); err != nil {
+				return errors.Wrap(err, "error sending audit events")
+			}
+
+			afterSeq = events[len(events)-1].Seq
+		}
+
+		if len(events) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+// checkAdmin lets through the users named in AUDIT_ADMINS.
+func (as *Service) checkAdmin(ctx context.Context) error {
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return errors.Wrap(err, "error getting user id")
+	}
+
+	user, err := as.storage.GetUserByID(ctx, userUUID)
+	if err != nil {
+		return errors.Wrap(err, "error getting user")
+	}
+
+	if !slices.Contains(as.cfg.AuditAdmins, user.Username) {
+		return status.Error(codes.PermissionDenied, "only audit admins export the audit log")
+	}
+
+	return nil
+}
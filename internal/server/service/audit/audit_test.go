@@ -0,0 +1,238 @@
+//nolint:wrapcheck,exhaustruct
+package audit_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/audit"
+	"github.com/npavlov/go-password-manager/internal/server/auditlog"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/audit"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+const getPassword = "/proto.password.PasswordService/GetPasswordV1"
+
+type auditFixture struct {
+	service  *audit.Service
+	storage  *testutils.MockDBStorage
+	recorder *auditlog.Recorder
+}
+
+func newAuditFixture(t *testing.T) *auditFixture {
+	t.Helper()
+
+	storage := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+	cfg := &config.Config{AuditAdmins: []string{"auditor"}}
+
+	return &auditFixture{
+		service:  audit.NewAuditService(testutils.GetTLogger(), storage, cfg),
+		storage:  storage,
+		recorder: auditlog.NewRecorder(storage),
+	}
+}
+
+// user adds a user and returns ctx carrying its ID.
+func (f *auditFixture) user(t *testing.T, username string) (context.Context, string) {
+	t.Helper()
+
+	user := db.User{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Username: username}
+	f.storage.AddTestUser(user)
+
+	return testutils.InjectUserToContext(t.Context(), user.ID.String()), user.ID.String()
+}
+
+func (f *auditFixture) record(t *testing.T, actorID, itemID, action string) {
+	t.Helper()
+
+	require.NoError(t, f.recorder.Record(t.Context(), auditlog.Entry{
+		ActorID: actorID,
+		ItemID:  itemID,
+		Action:  action,
+		IP:      "10.0.0.7",
+		Result:  "OK",
+	}))
+}
+
+// exportStream collects the chunks of an export.
+type exportStream struct {
+	grpc.ServerStream
+	//nolint:containedctx
+	ctx  context.Context
+	data bytes.Buffer
+}
+
+func (s *exportStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportStream) Send(resp *pb.ExportAuditLogV1Response) error {
+	s.data.Write(resp.GetData())
+
+	return nil
+}
+
+// lines decodes the exported events.
+func (s *exportStream) lines(t *testing.T) []map[string]any {
+	t.Helper()
+
+	var lines []map[string]any
+
+	scanner := bufio.NewScanner(&s.data)
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestGetAuditLog(t *testing.T) {
+	t.Parallel()
+
+	f := newAuditFixture(t)
+	aliceCtx, alice := f.user(t, "alice")
+	_, bob := f.user(t, "bob")
+	itemID := uuid.NewString()
+
+	f.record(t, alice, itemID, getPassword)
+	f.record(t, bob, itemID, getPassword)
+	f.record(t, alice, "", "/proto.item.ItemService/GetItemsV1")
+	f.record(t, alice, uuid.NewString(), getPassword)
+
+	resp, err := f.service.GetAuditLogV1(aliceCtx, &pb.GetAuditLogV1Request{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEvents(), 3)
+	require.Equal(t, int64(4), resp.GetEvents()[0].GetSeq())
+	require.Equal(t, alice, resp.GetEvents()[0].GetActorId())
+	require.NotEmpty(t, resp.GetEvents()[0].GetHash())
+
+	resp, err = f.service.GetAuditLogV1(aliceCtx, &pb.GetAuditLogV1Request{ItemId: itemID})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEvents(), 1)
+	require.Equal(t, int64(1), resp.GetEvents()[0].GetSeq())
+
+	resp, err = f.service.GetAuditLogV1(aliceCtx, &pb.GetAuditLogV1Request{Action: getPassword, PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEvents(), 1)
+	require.Equal(t, int64(4), resp.GetEvents()[0].GetSeq())
+
+	resp, err = f.service.GetAuditLogV1(aliceCtx, &pb.GetAuditLogV1Request{
+		Since: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetEvents())
+
+	resp, err = f.service.GetAuditLogV1(aliceCtx, &pb.GetAuditLogV1Request{
+		Until: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEvents(), 3)
+
+	_, err = f.service.GetAuditLogV1(aliceCtx, &pb.GetAuditLogV1Request{ItemId: "not-a-uuid"})
+	require.Error(t, err)
+}
+
+func TestGetAuditLog_ItemsOfCaller(t *testing.T) {
+	t.Parallel()
+
+	f := newAuditFixture(t)
+	aliceCtx, alice := f.user(t, "alice")
+	_, bob := f.user(t, "bob")
+
+	note, err := f.storage.StoreNote(t.Context(), db.CreateNoteEntryParams{
+		UserID: pgtype.UUID{Bytes: uuid.MustParse(alice), Valid: true},
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	// Another member acts on the item of alice, and on an item alice does not own
+	f.record(t, bob, note.ID.String(), getPassword)
+	f.record(t, bob, uuid.NewString(), getPassword)
+
+	resp, err := f.service.GetAuditLogV1(aliceCtx, &pb.GetAuditLogV1Request{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEvents(), 1)
+	require.Equal(t, bob, resp.GetEvents()[0].GetActorId())
+	require.Equal(t, note.ID.String(), resp.GetEvents()[0].GetItemId())
+
+	resp, err = f.service.GetAuditLogV1(aliceCtx, &pb.GetAuditLogV1Request{ItemId: note.ID.String()})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEvents(), 1)
+}
+
+func TestExportAuditLog(t *testing.T) {
+	t.Parallel()
+
+	f := newAuditFixture(t)
+	aliceCtx, alice := f.user(t, "alice")
+	auditorCtx, _ := f.user(t, "auditor")
+
+	for range 3 {
+		f.record(t, alice, uuid.NewString(), getPassword)
+	}
+
+	// Only admins export
+	err := f.service.ExportAuditLogV1(&pb.ExportAuditLogV1Request{}, &exportStream{ctx: aliceCtx})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	stream := &exportStream{ctx: auditorCtx}
+	require.NoError(t, f.service.ExportAuditLogV1(&pb.ExportAuditLogV1Request{}, stream))
+
+	lines := stream.lines(t)
+	require.Len(t, lines, 3)
+	require.InDelta(t, 1, lines[0]["seq"], 0)
+	require.Equal(t, alice, lines[0]["actor_id"])
+	require.Equal(t, getPassword, lines[0]["action"])
+	require.Equal(t, lines[0]["hash"], lines[1]["prev_hash"])
+
+	// Resuming skips what was exported already
+	stream = &exportStream{ctx: auditorCtx}
+	require.NoError(t, f.service.ExportAuditLogV1(&pb.ExportAuditLogV1Request{AfterSeq: 2}, stream))
+	require.Len(t, stream.lines(t), 1)
+}
+
+func TestExportAuditLogDetectsTampering(t *testing.T) {
+	t.Parallel()
+
+	f := newAuditFixture(t)
+	_, alice := f.user(t, "alice")
+	auditorCtx, _ := f.user(t, "auditor")
+
+	for range 3 {
+		f.record(t, alice, uuid.NewString(), getPassword)
+	}
+
+	// Someone with access to the database rewrites a call and its hash
+	events, err := f.storage.ExportAuditEvents(t.Context(), 1, 1)
+	require.NoError(t, err)
+
+	rewritten := events[0]
+	rewritten.Action = "/proto.item.ItemService/GetItemsV1"
+	rewritten.Hash = auditlog.Hash(rewritten)
+	f.storage.SetAuditEvent(rewritten)
+
+	stream := &exportStream{ctx: auditorCtx}
+	err = f.service.ExportAuditLogV1(&pb.ExportAuditLogV1Request{}, stream)
+	require.Equal(t, codes.DataLoss, status.Code(err))
+
+	// The events before the break are exported
+	lines := stream.lines(t)
+	require.Len(t, lines, 2)
+	require.InDelta(t, 2, lines[1]["seq"], 0)
+}
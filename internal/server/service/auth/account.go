@@ -0,0 +1,185 @@
+//nolint:exhaustruct
+package auth
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+// ChangePasswordV1 replaces the password of the calling user. Every session is signed out, as the old
+// password may be how someone else got in, and the caller continues in a new one. Zero-knowledge accounts
+// can re-wrap their vault key with a new master password in the same call.
+func (as *Service) ChangePasswordV1(
+	ctx context.Context,
+	req *pb.ChangePasswordV1Request,
+) (*pb.ChangePasswordV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	user, err := as.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.confirmPassword(ctx, user, req.GetCurrentPassword()); err != nil {
+		return nil, err
+	}
+
+	params := db.ChangeUserPasswordParams{
+		ID:              user.ID,
+		OldPassword:     user.Password,
+		KdfSalt:         user.KdfSalt,
+		WrappedVaultKey: user.WrappedVaultKey,
+	}
+
+	if vaultKey := req.GetVaultKey(); vaultKey != nil {
+		if !user.WrappedVaultKey.Valid {
+			return nil, status.Error(codes.FailedPrecondition, "account has no vault key")
+		}
+
+		params.KdfSalt = pgtype.Text{String: vaultKey.GetKdfSalt(), Valid: true}
+		params.WrappedVaultKey = pgtype.Text{String: vaultKey.GetWrappedKey(), Valid: true}
+	}
+
+	params.NewPassword, err = as.hasher.Hash(req.GetNewPassword())
+	if err != nil {
+		return nil, errors.Wrap(err, "error hashing password")
+	}
+
+	changed, err := as.Storage.ChangePassword(ctx, params)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("failed to change password")
+
+		return nil, errors.Wrap(err, "error changing password")
+	}
+
+	if !changed {
+		return nil, status.Error(codes.Aborted, "password changed during the request, try again")
+	}
+
+	as.logger.Info().Str("user_id", user.ID.String()).Msg("password changed")
+
+	if _, err := as.revokeAllSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	token, refreshToken, err := as.startSession(ctx, user.ID, req.GetDeviceName())
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error generating token")
+
+		return nil, errors.Wrap(err, "error generating token")
+	}
+
+	return &pb.ChangePasswordV1Response{Token: token, RefreshToken: refreshToken}, nil
+}
+
+// DeleteAccountV1 deletes the calling user once the password confirms it. The files go first, so a failed
+// call can be repeated; deleting the user then takes the vault, sessions and tokens with it.
+func (as *Service) DeleteAccountV1(
+	ctx context.Context,
+	req *pb.DeleteAccountV1Request,
+) (*pb.DeleteAccountV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	user, err := as.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.confirmPassword(ctx, user, req.GetPassword()); err != nil {
+		return nil, err
+	}
+
+	// Organizations are deleted by their owners, so the last owner hands them over or deletes them first
+	owned, err := as.Storage.CountSoleOwnerships(ctx, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error counting owned organizations")
+	}
+
+	if owned > 0 {
+		return nil, status.Error(codes.FailedPrecondition, "the account is the last owner of an organization")
+	}
+
+	if err := as.deleteFiles(ctx, user.ID); err != nil {
+		as.logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("failed to delete files")
+
+		return nil, err
+	}
+
+	// The access tokens live in Redis, so they are deleted while the sessions still list them
+	if _, err := as.revokeAllSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	deleted, err := as.Storage.DeleteUser(ctx, user.ID)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("failed to delete user")
+
+		return nil, errors.Wrap(err, "error deleting user")
+	}
+
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+
+	as.logger.Info().Str("user_id", user.ID.String()).Msg("account deleted")
+
+	return &pb.DeleteAccountV1Response{}, nil
+}
+
+// confirmPassword checks the password of the calling user before a change to the account. Wrong passwords
+// count as failed logins, so a stolen access token does not help guessing the password.
+func (as *Service) confirmPassword(ctx context.Context, user *db.User, password string) error {
+	if err := as.checkLogin(ctx, user.Username); err != nil {
+		return err
+	}
+
+	if err := as.hasher.Verify(password, user.Password); err != nil {
+		as.loginFailed(ctx, user.Username)
+
+		return status.Error(codes.PermissionDenied, "invalid password")
+	}
+
+	// The attempt was counted by checkLogin; the right password releases it like a login does
+	as.loginSucceeded(ctx, user.Username)
+
+	return nil
+}
+
+// deleteFiles removes every object under the prefix of the user from the bucket, including uploads
+// that failed before their file was recorded.
+func (as *Service) deleteFiles(ctx context.Context, userID pgtype.UUID) error {
+	// Cancelling stops the listing when a removal fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := as.objects.ListObjects(ctx, as.cfg.Bucket, minio.ListObjectsOptions{
+		Prefix:    utils.ObjectPrefix(userID),
+		Recursive: true,
+	})
+
+	for object := range objects {
+		if object.Err != nil {
+			return errors.Wrap(object.Err, "error listing files")
+		}
+
+		err := as.objects.RemoveObject(ctx, as.cfg.Bucket, object.Key, minio.RemoveObjectOptions{})
+		if err != nil {
+			return errors.Wrap(err, "error deleting file")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,300 @@
+//nolint:exhaustruct
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
+	"github.com/npavlov/go-password-manager/internal/server/service/auth"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	generalutils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// memObjects is an in-memory bucket.
+type memObjects struct {
+	mu        sync.Mutex
+	objects   map[string]bool
+	removeErr error
+}
+
+func newMemObjects() *memObjects {
+	return &memObjects{objects: make(map[string]bool)}
+}
+
+func (m *memObjects) ListObjects(_ context.Context, _ string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	objects := make(chan minio.ObjectInfo, len(m.objects))
+	for key := range m.objects {
+		if strings.HasPrefix(key, opts.Prefix) {
+			objects <- minio.ObjectInfo{Key: key}
+		}
+	}
+
+	close(objects)
+
+	return objects
+}
+
+func (m *memObjects) RemoveObject(_ context.Context, _, objectName string, _ minio.RemoveObjectOptions) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.removeErr != nil {
+		return m.removeErr
+	}
+
+	delete(m.objects, objectName)
+
+	return nil
+}
+
+func (m *memObjects) has(objectName string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.objects[objectName]
+}
+
+// newAccountService is newSessionService that also returns its bucket.
+func newAccountService(t *testing.T) (*auth.Service, *testutils.MockRedis, *memObjects) {
+	t.Helper()
+
+	masterKey, _ := utils.GenerateRandomKey()
+
+	logger := testutils.GetTLogger()
+	mockRedis := testutils.NewMockRedis()
+	objects := newMemObjects()
+
+	cfg := &config.Config{
+		SecuredMasterKey: generalutils.NewString(masterKey),
+		PasswordMemory:   1024,
+		PasswordTime:     1,
+		PasswordThreads:  1,
+		Bucket:           "test-bucket",
+	}
+
+	logins := ratelimit.NewLoginGuard(mockRedis, ratelimit.Limits{
+		MaxFailures:   3,
+		FailureWindow: time.Minute,
+		Lockout:       time.Hour,
+	}, logger)
+
+	service := auth.NewAuthService(logger, testutils.NewMockDBStorage(logger, masterKey), cfg, mockRedis,
+		rotationNotifier{}, logins, objects, testutils.NewTokenKeys(t))
+
+	return service, mockRedis, objects
+}
+
+func register(t *testing.T, service *auth.Service, username string, vaultKey *pb.VaultKey) *pb.RegisterV1Response {
+	t.Helper()
+
+	resp, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: username,
+		Password: "securePass123!",
+		Email:    username + "@example.com",
+		VaultKey: vaultKey,
+	})
+	require.NoError(t, err)
+
+	return resp
+}
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+
+	service, redis, _ := newAccountService(t)
+	registered := register(t, service, "changer", nil)
+	ctx := authorized(t, registered.GetToken())
+
+	_, err := service.ChangePasswordV1(ctx, &pb.ChangePasswordV1Request{
+		CurrentPassword: "wrongPass123!",
+		NewPassword:     "newSecurePass456!",
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Only zero-knowledge accounts have a vault key to replace.
+	_, err = service.ChangePasswordV1(ctx, &pb.ChangePasswordV1Request{
+		CurrentPassword: "securePass123!",
+		NewPassword:     "newSecurePass456!",
+		VaultKey:        &pb.VaultKey{KdfSalt: "c2FsdA==", WrappedKey: "d3JhcHBlZA=="},
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	resp, err := service.ChangePasswordV1(ctx, &pb.ChangePasswordV1Request{
+		CurrentPassword: "securePass123!",
+		NewPassword:     "newSecurePass456!",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.GetToken())
+	require.NotEmpty(t, resp.GetRefreshToken())
+
+	// Every earlier session is signed out.
+	require.False(t, accessTokenValid(t, redis, registered.GetToken()))
+	require.True(t, accessTokenValid(t, redis, resp.GetToken()))
+
+	_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: registered.GetRefreshToken()})
+	require.Error(t, err)
+
+	_, err = service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "changer", Password: "securePass123!"})
+	require.ErrorContains(t, err, "invalid password")
+
+	_, err = service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "changer", Password: "newSecurePass456!"})
+	require.NoError(t, err)
+}
+
+func TestChangePassword_ZeroKnowledge(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newAccountService(t)
+	registered := register(t, service, "zkchanger", &pb.VaultKey{KdfSalt: "c2FsdA==", WrappedKey: "d3JhcHBlZA=="})
+
+	// Without a vault key the master password stays.
+	resp, err := service.ChangePasswordV1(authorized(t, registered.GetToken()), &pb.ChangePasswordV1Request{
+		CurrentPassword: "securePass123!",
+		NewPassword:     "newSecurePass456!",
+	})
+	require.NoError(t, err)
+
+	login, err := service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "zkchanger", Password: "newSecurePass456!"})
+	require.NoError(t, err)
+	require.Equal(t, "d3JhcHBlZA==", login.GetVaultKey().GetWrappedKey())
+
+	newKey := &pb.VaultKey{KdfSalt: "bmV3LXNhbHQ=", WrappedKey: "bmV3LXdyYXBwZWQ="}
+
+	_, err = service.ChangePasswordV1(authorized(t, resp.GetToken()), &pb.ChangePasswordV1Request{
+		CurrentPassword: "newSecurePass456!",
+		NewPassword:     "newSecurePass789!",
+		VaultKey:        newKey,
+	})
+	require.NoError(t, err)
+
+	login, err = service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "zkchanger", Password: "newSecurePass789!"})
+	require.NoError(t, err)
+	require.Equal(t, newKey.GetKdfSalt(), login.GetVaultKey().GetKdfSalt())
+	require.Equal(t, newKey.GetWrappedKey(), login.GetVaultKey().GetWrappedKey())
+}
+
+func TestDeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	service, redis, objects := newAccountService(t)
+	registered := register(t, service, "leaving", nil)
+	other := register(t, service, "staying", nil)
+
+	userID, err := tokenSubject(registered.GetToken())
+	require.NoError(t, err)
+	otherID, err := tokenSubject(other.GetToken())
+	require.NoError(t, err)
+
+	objects.objects[userID+"-report.pdf"] = true
+	objects.objects[userID+"-photo.png"] = true
+	objects.objects[otherID+"-report.pdf"] = true
+
+	ctx := authorized(t, registered.GetToken())
+
+	_, err = service.DeleteAccountV1(ctx, &pb.DeleteAccountV1Request{Password: "wrongPass123!"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.True(t, objects.has(userID+"-report.pdf"))
+
+	_, err = service.DeleteAccountV1(ctx, &pb.DeleteAccountV1Request{Password: "securePass123!"})
+	require.NoError(t, err)
+
+	require.False(t, objects.has(userID+"-report.pdf"))
+	require.False(t, objects.has(userID+"-photo.png"))
+	require.True(t, objects.has(otherID+"-report.pdf"))
+
+	require.False(t, accessTokenValid(t, redis, registered.GetToken()))
+
+	_, err = service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "leaving", Password: "securePass123!"})
+	require.ErrorContains(t, err, "error getting user")
+
+	_, err = service.DeleteAccountV1(ctx, &pb.DeleteAccountV1Request{Password: "securePass123!"})
+	require.ErrorContains(t, err, "error getting user")
+
+	_, err = service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "staying", Password: "securePass123!"})
+	require.NoError(t, err)
+}
+
+func TestDeleteAccount_FileError(t *testing.T) {
+	t.Parallel()
+
+	service, _, objects := newAccountService(t)
+	registered := register(t, service, "stuck", nil)
+
+	userID, err := tokenSubject(registered.GetToken())
+	require.NoError(t, err)
+
+	objects.objects[userID+"-report.pdf"] = true
+	objects.removeErr = errors.New("bucket unavailable")
+
+	_, err = service.DeleteAccountV1(authorized(t, registered.GetToken()), &pb.DeleteAccountV1Request{
+		Password: "securePass123!",
+	})
+	require.ErrorContains(t, err, "error deleting file")
+
+	// The account stays, so the deletion can be repeated.
+	_, err = service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "stuck", Password: "securePass123!"})
+	require.NoError(t, err)
+}
+
+func TestDeleteAccount_LastOrganizationOwner(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newAccountService(t)
+	registered := register(t, service, "founder", nil)
+
+	userID, err := tokenSubject(registered.GetToken())
+	require.NoError(t, err)
+
+	storage, ok := service.Storage.(*testutils.MockDBStorage)
+	require.True(t, ok)
+
+	_, err = storage.CreateOrganization(t.Context(), db.CreateOrganizationParams{
+		Name: "Acme", OwnerID: generalutils.GetIDFromString(userID),
+	})
+	require.NoError(t, err)
+
+	_, err = service.DeleteAccountV1(authorized(t, registered.GetToken()), &pb.DeleteAccountV1Request{
+		Password: "securePass123!",
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "founder", Password: "securePass123!"})
+	require.NoError(t, err)
+}
+
+func TestConfirmPassword_CountsFailures(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newAccountService(t)
+	registered := register(t, service, "stolen", nil)
+	ctx := authorized(t, registered.GetToken())
+
+	for range 3 {
+		_, err := service.DeleteAccountV1(ctx, &pb.DeleteAccountV1Request{Password: "wrongPass123!"})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+
+	// An access token does not allow guessing the password any faster than the login.
+	_, err := service.ChangePasswordV1(ctx, &pb.ChangePasswordV1Request{
+		CurrentPassword: "securePass123!",
+		NewPassword:     "newSecurePass456!",
+	})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
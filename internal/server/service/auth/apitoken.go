@@ -0,0 +1,161 @@
+//nolint:exhaustruct
+package auth
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_item "github.com/npavlov/go-password-manager/gen/proto/item"
+	"github.com/npavlov/go-password-manager/internal/server/apitoken"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// CreateAPITokenV1 creates a personal access token of the calling user. Its secret is returned once and
+// only its hash is stored.
+func (as *Service) CreateAPITokenV1(
+	ctx context.Context,
+	req *pb.CreateAPITokenV1Request,
+) (*pb.CreateAPITokenV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	existing, err := as.Storage.ListAPITokens(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing api tokens")
+	}
+
+	for _, token := range existing {
+		if token.Name == req.GetName() {
+			return nil, status.Error(codes.AlreadyExists, "an api token with this name exists")
+		}
+	}
+
+	itemTypes := make([]string, len(req.GetScope().GetItemTypes()))
+	for cursor, itemType := range req.GetScope().GetItemTypes() {
+		itemTypes[cursor] = string(utils.FromProtoItemType(itemType))
+	}
+
+	secret, hash := apitoken.New()
+
+	token, err := as.Storage.CreateAPIToken(ctx, db.CreateAPITokenParams{
+		UserID:    userUUID,
+		Name:      req.GetName(),
+		TokenHash: hash,
+		ReadOnly:  req.GetScope().GetReadOnly(),
+		ItemTypes: itemTypes,
+		Tags:      apitoken.EncodeTags(req.GetScope().GetTags()),
+		ExpiresAt: pgtype.Timestamp{Time: req.GetExpiresAt().AsTime(), Valid: true},
+	})
+	if err != nil {
+		as.logger.Error().Err(err).Msg("failed to create api token")
+
+		return nil, errors.Wrap(err, "error creating api token")
+	}
+
+	as.logger.Info().Str("user_id", userUUID.String()).Str("name", token.Name).Msg("api token created")
+
+	return &pb.CreateAPITokenV1Response{
+		Token:    secret,
+		ApiToken: toProtoAPIToken(*token),
+	}, nil
+}
+
+// ListAPITokensV1 lists the personal access tokens of the calling user.
+func (as *Service) ListAPITokensV1(
+	ctx context.Context,
+	req *pb.ListAPITokensV1Request,
+) (*pb.ListAPITokensV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	tokens, err := as.Storage.ListAPITokens(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing api tokens")
+	}
+
+	result := make([]*pb.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, toProtoAPIToken(token))
+	}
+
+	return &pb.ListAPITokensV1Response{ApiTokens: result}, nil
+}
+
+// RevokeAPITokenV1 deletes a personal access token of the calling user, rejecting it from the next call on.
+func (as *Service) RevokeAPITokenV1(
+	ctx context.Context,
+	req *pb.RevokeAPITokenV1Request,
+) (*pb.RevokeAPITokenV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	revoked, err := as.Storage.DeleteAPIToken(ctx, userUUID, gu.GetIDFromString(req.GetTokenId()))
+	if err != nil {
+		return nil, errors.Wrap(err, "error revoking api token")
+	}
+
+	if !revoked {
+		return nil, status.Error(codes.NotFound, "api token not found")
+	}
+
+	as.logger.Info().Str("user_id", userUUID.String()).Str("token_id", req.GetTokenId()).Msg("api token revoked")
+
+	return &pb.RevokeAPITokenV1Response{}, nil
+}
+
+func toProtoAPIToken(token db.ApiToken) *pb.APIToken {
+	itemTypes := make([]pb_item.ItemType, len(token.ItemTypes))
+	for cursor, itemType := range token.ItemTypes {
+		itemTypes[cursor] = utils.ToProtoItemType(db.ItemType(itemType))
+	}
+
+	result := &pb.APIToken{
+		Id:   token.ID.String(),
+		Name: token.Name,
+		Scope: &pb.APITokenScope{
+			ReadOnly:  token.ReadOnly,
+			ItemTypes: itemTypes,
+			Tags:      apitoken.DecodeTags(token.Tags),
+		},
+		CreatedAt: timestamppb.New(token.CreatedAt.Time),
+		ExpiresAt: timestamppb.New(token.ExpiresAt.Time),
+	}
+
+	if token.LastUsedAt.Valid {
+		result.LastUsedAt = timestamppb.New(token.LastUsedAt.Time)
+	}
+
+	return result
+}
@@ -0,0 +1,107 @@
+//nolint:exhaustruct
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_item "github.com/npavlov/go-password-manager/gen/proto/item"
+	"github.com/npavlov/go-password-manager/internal/server/apitoken"
+)
+
+func TestAPITokens(t *testing.T) {
+	t.Parallel()
+
+	service, _ := newSessionService(t)
+
+	registered, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "automation",
+		Password: "securePass123!",
+		Email:    "automation@example.com",
+	})
+	require.NoError(t, err)
+
+	ctx := authorized(t, registered.GetToken())
+	expiresAt := timestamppb.New(time.Now().Add(24 * time.Hour))
+
+	created, err := service.CreateAPITokenV1(ctx, &pb.CreateAPITokenV1Request{
+		Name: "deploy",
+		Scope: &pb.APITokenScope{
+			ReadOnly:  true,
+			ItemTypes: []pb_item.ItemType{pb_item.ItemType_ITEM_TYPE_PASSWORD, pb_item.ItemType_ITEM_TYPE_NOTE},
+			Tags:      map[string]string{"env": "ci"},
+		},
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(created.GetToken(), apitoken.Prefix))
+	require.Equal(t, "deploy", created.GetApiToken().GetName())
+
+	_, err = service.CreateAPITokenV1(ctx, &pb.CreateAPITokenV1Request{
+		Name:      "deploy",
+		Scope:     &pb.APITokenScope{},
+		ExpiresAt: expiresAt,
+	})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	listed, err := service.ListAPITokensV1(ctx, &pb.ListAPITokensV1Request{})
+	require.NoError(t, err)
+	require.Len(t, listed.GetApiTokens(), 1)
+
+	token := listed.GetApiTokens()[0]
+	require.True(t, token.GetScope().GetReadOnly())
+	require.Equal(t, []pb_item.ItemType{pb_item.ItemType_ITEM_TYPE_PASSWORD, pb_item.ItemType_ITEM_TYPE_NOTE},
+		token.GetScope().GetItemTypes())
+	require.Equal(t, map[string]string{"env": "ci"}, token.GetScope().GetTags())
+	require.Equal(t, expiresAt.AsTime().Unix(), token.GetExpiresAt().AsTime().Unix())
+	require.Nil(t, token.GetLastUsedAt())
+
+	_, err = service.RevokeAPITokenV1(ctx, &pb.RevokeAPITokenV1Request{TokenId: token.GetId()})
+	require.NoError(t, err)
+
+	_, err = service.RevokeAPITokenV1(ctx, &pb.RevokeAPITokenV1Request{TokenId: token.GetId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	listed, err = service.ListAPITokensV1(ctx, &pb.ListAPITokensV1Request{})
+	require.NoError(t, err)
+	require.Empty(t, listed.GetApiTokens())
+}
+
+func TestCreateAPIToken_Validation(t *testing.T) {
+	t.Parallel()
+
+	service, _ := newSessionService(t)
+
+	registered, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "automation",
+		Password: "securePass123!",
+		Email:    "automation@example.com",
+	})
+	require.NoError(t, err)
+
+	ctx := authorized(t, registered.GetToken())
+
+	// Already expired
+	_, err = service.CreateAPITokenV1(ctx, &pb.CreateAPITokenV1Request{
+		Name:      "deploy",
+		Scope:     &pb.APITokenScope{},
+		ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute)),
+	})
+	require.ErrorContains(t, err, "error validating input")
+
+	_, err = service.CreateAPITokenV1(ctx, &pb.CreateAPITokenV1Request{
+		Name: "deploy",
+		Scope: &pb.APITokenScope{
+			ItemTypes: []pb_item.ItemType{pb_item.ItemType_ITEM_TYPE_UNSPECIFIED},
+		},
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.ErrorContains(t, err, "error validating input")
+}
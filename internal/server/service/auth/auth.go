@@ -7,14 +7,16 @@ import (
 
 	"github.com/bufbuild/protovalidate-go"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/minio/minio-go/v7"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
-	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/passhash"
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
 	"github.com/npavlov/go-password-manager/internal/server/redis"
 	"github.com/npavlov/go-password-manager/internal/server/service/utils"
 )
@@ -28,9 +30,57 @@ type Storage interface {
 	GetUser(ctx context.Context, username string) (*db.User, error)
 	GetUserByID(ctx context.Context, userID pgtype.UUID) (*db.User, error)
 	RegisterUser(ctx context.Context, createUser db.CreateUserParams) (*db.User, error)
-	GetToken(ctx context.Context, token string) (db.GetRefreshTokenRow, error)
-	StoreToken(ctx context.Context, userID pgtype.UUID, refreshToken string, expiresAt time.Time) error
+	GetToken(ctx context.Context, tokenHash string) (db.GetRefreshTokenRow, error)
+	StoreToken(ctx context.Context, userID, sessionID pgtype.UUID, tokenHash string, expiresAt time.Time) error
+	UseToken(ctx context.Context, tokenHash string, sessionID pgtype.UUID) (bool, error)
+	DeleteToken(ctx context.Context, tokenHash string) error
+	DeleteUserTokens(ctx context.Context, userID pgtype.UUID) error
+	CreateSession(ctx context.Context, params db.CreateSessionParams) (*db.Session, error)
+	TouchSession(ctx context.Context, sessionID pgtype.UUID, ipAddress string, expiresAt time.Time) (bool, error)
+	ListSessions(ctx context.Context, userID pgtype.UUID) ([]db.Session, error)
+	DeleteSession(ctx context.Context, userID, sessionID pgtype.UUID) (bool, error)
+	DeleteUserSessions(ctx context.Context, userID pgtype.UUID) ([]pgtype.UUID, error)
+	StartKeyRotation(ctx context.Context, params db.StartKeyRotationParams) (*db.KeyRotation, error)
+	GetLatestKeyRotation(ctx context.Context, userID pgtype.UUID) (*db.KeyRotation, error)
+	RetryKeyRotation(ctx context.Context, rotationID pgtype.UUID) (*db.KeyRotation, error)
+	SetTOTPSecret(ctx context.Context, userID pgtype.UUID, secret string) (bool, error)
+	EnableTOTP(ctx context.Context, userID pgtype.UUID, secret string, step int64, codeHashes []string) (bool, error)
+	UseTOTPStep(ctx context.Context, userID pgtype.UUID, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID pgtype.UUID, codeHash string) (bool, error)
+	ChangePassword(ctx context.Context, params db.ChangeUserPasswordParams) (bool, error)
+	RehashPassword(ctx context.Context, params db.RehashUserPasswordParams) (bool, error)
+	DeleteUser(ctx context.Context, userID pgtype.UUID) (bool, error)
+	CreateAPIToken(ctx context.Context, params db.CreateAPITokenParams) (*db.ApiToken, error)
+	ListAPITokens(ctx context.Context, userID pgtype.UUID) ([]db.ApiToken, error)
+	DeleteAPIToken(ctx context.Context, userID, tokenID pgtype.UUID) (bool, error)
+	CountSoleOwnerships(ctx context.Context, userID pgtype.UUID) (int64, error)
+	UpsertEmergencyAccess(ctx context.Context, params db.UpsertEmergencyAccessParams) (*db.EmergencyAccess, error)
+	ListEmergencyAccess(ctx context.Context, userID pgtype.UUID) ([]db.ListEmergencyAccessRow, error)
+	GetEmergencyAccess(ctx context.Context, accessID, userID pgtype.UUID) (*db.GetEmergencyAccessRow, error)
+	DeleteEmergencyAccess(ctx context.Context, accessID, userID pgtype.UUID) (bool, error)
+	RequestEmergencyAccess(ctx context.Context, accessID, granteeID pgtype.UUID) (bool, error)
+	ApproveEmergencyAccess(ctx context.Context, accessID, grantorID pgtype.UUID) (bool, error)
+	RejectEmergencyAccess(ctx context.Context, accessID, grantorID pgtype.UUID) (bool, error)
+	ListVaultItems(ctx context.Context, userID pgtype.UUID) ([]db.ListVaultItemsRow, error)
+	TakeOverAccount(ctx context.Context, params db.TakeOverAccountParams) (bool, error)
 }
+
+// ObjectStorage holds the uploaded files, see adapter.MinioAdapter.
+type ObjectStorage interface {
+	ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo
+	RemoveObject(ctx context.Context, bucketName string, objName string, opts minio.RemoveObjectOptions) error
+}
+
+// TokenSigner issues access tokens, see jwtkeys.Keys.
+type TokenSigner interface {
+	Sign(userID string, issuedAt time.Time, lifetime time.Duration) (string, error)
+}
+
+// KeyRotationNotifier wakes the worker that re-encrypts vaults, see keyrotation.Reencryptor.
+type KeyRotationNotifier interface {
+	Notify()
+}
+
 type Service struct {
 	pb.UnimplementedAuthServiceServer
 	validator  protovalidate.Validator
@@ -38,9 +88,23 @@ type Service struct {
 	Storage    Storage
 	cfg        *config.Config
 	memStorage redis.MemStorage
+	rotations  KeyRotationNotifier
+	logins     *ratelimit.LoginGuard
+	objects    ObjectStorage
+	hasher     passhash.Hasher
+	tokens     TokenSigner
 }
 
-func NewAuthService(log *zerolog.Logger, storage Storage, cfg *config.Config, memStorage redis.MemStorage) *Service {
+func NewAuthService(
+	log *zerolog.Logger,
+	storage Storage,
+	cfg *config.Config,
+	memStorage redis.MemStorage,
+	rotations KeyRotationNotifier,
+	logins *ratelimit.LoginGuard,
+	objects ObjectStorage,
+	tokens TokenSigner,
+) *Service {
 	validator, err := protovalidate.New()
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create validator")
@@ -52,6 +116,11 @@ func NewAuthService(log *zerolog.Logger, storage Storage, cfg *config.Config, me
 		Storage:    storage,
 		cfg:        cfg,
 		memStorage: memStorage,
+		rotations:  rotations,
+		logins:     logins,
+		objects:    objects,
+		hasher:     passhash.NewArgon2id(cfg.PasswordParams()),
+		tokens:     tokens,
 	}
 }
 
@@ -66,28 +135,36 @@ func (as *Service) RegisterV1(ctx context.Context, req *pb.RegisterV1Request) (*
 	}
 
 	// Hash the password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.GetPassword()), bcrypt.DefaultCost)
+	hashedPassword, err := as.hasher.Hash(req.GetPassword())
 	if err != nil {
 		return nil, errors.Wrap(err, "error hashing password")
 	}
 
-	// Generate a unique encryption key for this user
-	userKey, err := utils.GenerateRandomKey()
-	if err != nil {
-		return nil, errors.Wrap(err, "error generating random key")
+	createUser := db.CreateUserParams{
+		Username: req.GetUsername(),
+		Password: hashedPassword,
+		Email:    req.GetEmail(),
 	}
-	// Encrypt the user key using the master key
-	encryptedKey, err := utils.Encrypt(userKey, as.cfg.SecuredMasterKey.Get())
-	if err != nil {
-		return nil, errors.Wrap(err, "error encrypting password")
+
+	var userKey string
+	if vaultKey := req.GetVaultKey(); vaultKey != nil {
+		// Zero-knowledge account: the server keeps the wrapped vault key but no key of its own
+		createUser.KdfSalt = pgtype.Text{String: vaultKey.GetKdfSalt(), Valid: true}
+		createUser.WrappedVaultKey = pgtype.Text{String: vaultKey.GetWrappedKey(), Valid: true}
+	} else {
+		// Generate a unique encryption key for this user
+		userKey, err = utils.GenerateRandomKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "error generating random key")
+		}
+		// Wrap the user key with the master key
+		createUser.EncryptionKey, err = as.cfg.Keys().Wrap(ctx, userKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "error encrypting password")
+		}
 	}
 
-	user, err := as.Storage.RegisterUser(ctx, db.CreateUserParams{
-		Username:      req.GetUsername(),
-		Password:      string(hashedPassword),
-		EncryptionKey: encryptedKey,
-		Email:         req.GetEmail(),
-	})
+	user, err := as.Storage.RegisterUser(ctx, createUser)
 	if err != nil {
 		as.logger.Error().Err(err).Msg("failed to register user")
 
@@ -96,7 +173,7 @@ func (as *Service) RegisterV1(ctx context.Context, req *pb.RegisterV1Request) (*
 
 	as.logger.Info().Interface("user", user).Msg("user created")
 
-	token, refreshToken, err := as.tokenGeneration(ctx, user.ID)
+	token, refreshToken, err := as.startSession(ctx, user.ID, req.GetDeviceName())
 	if err != nil {
 		as.logger.Error().Err(err).Msg("error generating token")
 
@@ -106,35 +183,122 @@ func (as *Service) RegisterV1(ctx context.Context, req *pb.RegisterV1Request) (*
 	return &pb.RegisterV1Response{Token: token, RefreshToken: refreshToken, UserKey: userKey}, nil
 }
 
-// LoginV1 user and return JWT token.
+// LoginV1 user and return JWT token. Accounts with two-factor authentication get a challenge
+// token instead, which VerifyTOTPV1 exchanges for the tokens.
 func (as *Service) LoginV1(ctx context.Context, req *pb.LoginV1Request) (*pb.LoginV1Response, error) {
 	if err := as.validator.Validate(req); err != nil {
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
+	if err := as.checkLogin(ctx, req.GetUsername()); err != nil {
+		return nil, err
+	}
+
 	user, err := as.Storage.GetUser(ctx, req.GetUsername())
 	if err != nil {
 		as.logger.Error().Err(err).Msg("failed to get user")
+		// Unknown usernames back off like known ones, so the limits do not tell them apart
+		as.loginFailed(ctx, req.GetUsername())
 
 		return nil, errors.Wrap(err, "error getting user")
 	}
 
 	// Compare password
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.GetPassword()))
+	err = as.hasher.Verify(req.GetPassword(), user.Password)
 	if err != nil {
 		as.logger.Error().Err(err).Msg("invalid password")
+		as.loginFailed(ctx, user.Username)
 
 		return nil, errors.Wrap(err, "invalid password")
 	}
 
-	token, refreshToken, err := as.tokenGeneration(ctx, user.ID)
+	as.rehashPassword(ctx, user, req.GetPassword())
+
+	if user.TotpEnabled {
+		challenge, err := as.startTOTPChallenge(ctx, user.ID)
+		if err != nil {
+			as.logger.Error().Err(err).Msg("error starting login challenge")
+
+			return nil, errors.Wrap(err, "error starting login challenge")
+		}
+
+		return &pb.LoginV1Response{ChallengeToken: challenge}, nil
+	}
+
+	token, refreshToken, err := as.startSession(ctx, user.ID, req.GetDeviceName())
 	if err != nil {
 		as.logger.Error().Err(err).Msg("error generating token")
 
 		return nil, errors.Wrap(err, "error generating token")
 	}
 
-	return &pb.LoginV1Response{Token: token, RefreshToken: refreshToken}, nil
+	as.loginSucceeded(ctx, user.Username)
+
+	return &pb.LoginV1Response{Token: token, RefreshToken: refreshToken, VaultKey: vaultKey(user)}, nil
+}
+
+// rehashPassword replaces a bcrypt hash, or one with outdated parameters, once the login proved the
+// password. Failing only leaves the old hash for the next login, so errors are logged.
+func (as *Service) rehashPassword(ctx context.Context, user *db.User, password string) {
+	if !as.hasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPassword, err := as.hasher.Hash(password)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("failed to rehash password")
+
+		return
+	}
+
+	// A password changed meanwhile keeps its newer hash
+	if _, err := as.Storage.RehashPassword(ctx, db.RehashUserPasswordParams{
+		NewPassword: hashedPassword,
+		ID:          user.ID,
+		OldPassword: user.Password,
+	}); err != nil {
+		as.logger.Error().Err(err).Msg("failed to store rehashed password")
+
+		return
+	}
+
+	as.logger.Info().Str("user_id", user.ID.String()).Msg("password rehashed")
+}
+
+// GetVaultKeyV1 returns the wrapped vault key of a zero-knowledge account, so a client resuming
+// a session can unlock the vault without logging in again.
+func (as *Service) GetVaultKeyV1(ctx context.Context, req *pb.GetVaultKeyV1Request) (*pb.GetVaultKeyV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	user, err := as.Storage.GetUserByID(ctx, userUUID)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("failed to get user")
+
+		return nil, errors.Wrap(err, "error getting user")
+	}
+
+	return &pb.GetVaultKeyV1Response{VaultKey: vaultKey(user)}, nil
+}
+
+// vaultKey returns the wrapped vault key of a zero-knowledge account, nil for other accounts.
+func vaultKey(user *db.User) *pb.VaultKey {
+	if !user.WrappedVaultKey.Valid {
+		return nil
+	}
+
+	return &pb.VaultKey{
+		KdfSalt:    user.KdfSalt.String,
+		WrappedKey: user.WrappedVaultKey.String,
+	}
 }
 
 func (as *Service) RefreshTokenV1(ctx context.Context,
@@ -145,13 +309,20 @@ func (as *Service) RefreshTokenV1(ctx context.Context,
 	}
 
 	// Get refresh token from DB
-	tokenRow, err := as.Storage.GetToken(ctx, req.GetRefreshToken())
+	tokenHash := utils.HashRefreshToken(req.GetRefreshToken())
+
+	tokenRow, err := as.Storage.GetToken(ctx, tokenHash)
 	if err != nil {
 		as.logger.Error().Err(err).Msg("failed to get refresh token")
 
 		return nil, errors.Wrap(err, "invalid refresh token")
 	}
 
+	// A refresh token is exchanged once, so seeing it again means someone else holds a copy
+	if tokenRow.UsedAt.Valid {
+		return nil, as.revokeReusedToken(ctx, tokenRow)
+	}
+
 	// Check if refresh token is expired
 	if tokenRow.ExpiresAt.Time.Before(time.Now()) {
 		as.logger.Error().Msg("refresh token expired")
@@ -159,8 +330,17 @@ func (as *Service) RefreshTokenV1(ctx context.Context,
 		return nil, errors.New("refresh token expired")
 	}
 
+	sessionID, err := as.resumeSession(ctx, tokenRow)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.useRefreshToken(ctx, tokenHash, tokenRow, sessionID); err != nil {
+		return nil, err
+	}
+
 	// Generate a new access token
-	newToken, newRefreshToken, err := as.tokenGeneration(ctx, tokenRow.UserID)
+	newToken, newRefreshToken, err := as.tokenGeneration(ctx, tokenRow.UserID, sessionID)
 	if err != nil {
 		as.logger.Error().Err(err).Msg("error generating token")
 
@@ -175,12 +355,13 @@ func (as *Service) RefreshTokenV1(ctx context.Context,
 	}, nil
 }
 
-func (as *Service) tokenGeneration(ctx context.Context, userID pgtype.UUID) (string, string, error) {
+// tokenGeneration issues an access and a refresh token for a session. The previous access token of the
+// session stops working, so a session holds one at a time. The refresh token is random rather than signed,
+// since the server looks it up by its hash anyway.
+func (as *Service) tokenGeneration(ctx context.Context, userID, sessionID pgtype.UUID) (string, string, error) {
 	userIDStr := userID.String()
 
-	tokenExp := time.Now().Add(TokenExpiration).Unix()
-
-	token, err := utils.GenerateJWT(userIDStr, as.cfg.JwtSecret, tokenExp)
+	token, err := as.tokens.Sign(userIDStr, time.Now(), TokenExpiration)
 	if err != nil {
 		return "", "", errors.Wrap(err, "error generating token")
 	}
@@ -192,14 +373,14 @@ func (as *Service) tokenGeneration(ctx context.Context, userID pgtype.UUID) (str
 		return "", "", errors.Wrap(err, "error setting token")
 	}
 
-	refreshTokenExp := time.Now().Add(RefreshTokenExpiration)
-
-	refreshToken, err := utils.GenerateJWT(userIDStr, as.cfg.JwtSecret, refreshTokenExp.Unix())
-	if err != nil {
-		return "", "", errors.Wrap(err, "error generating refresh token")
+	if err := as.replaceSessionToken(ctx, sessionID, token); err != nil {
+		return "", "", err
 	}
 
-	err = as.Storage.StoreToken(ctx, userID, refreshToken, refreshTokenExp)
+	refreshTokenExp := time.Now().Add(RefreshTokenExpiration)
+	refreshToken := utils.NewRefreshToken()
+
+	err = as.Storage.StoreToken(ctx, userID, sessionID, utils.HashRefreshToken(refreshToken), refreshTokenExp)
 	if err != nil {
 		return "", "", errors.Wrap(err, "error storing token")
 	}
@@ -2,21 +2,42 @@
 package auth_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
 	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/passhash"
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
 	"github.com/npavlov/go-password-manager/internal/server/service/auth"
 	"github.com/npavlov/go-password-manager/internal/server/service/utils"
 	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
 	generalutils "github.com/npavlov/go-password-manager/internal/utils"
 )
 
+// rotationNotifier stands in for the re-encryption worker.
+type rotationNotifier struct{}
+
+func (rotationNotifier) Notify() {}
+
+// tokenSubject reads the user ID from an access token the service issued.
+func tokenSubject(token string) (string, error) {
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing token")
+	}
+
+	return parsed.Claims.GetSubject()
+}
+
 func newTestService(t *testing.T) *auth.Service {
 	t.Helper()
 
@@ -27,11 +48,17 @@ func newTestService(t *testing.T) *auth.Service {
 	mockRedis := testutils.NewMockRedis()
 
 	cfg := &config.Config{
-		JwtSecret:        "test-jwt-secret",
 		SecuredMasterKey: generalutils.NewString(masterKey),
+		// Cheap hashes keep the tests fast
+		PasswordMemory:  1024,
+		PasswordTime:    1,
+		PasswordThreads: 1,
 	}
 
-	return auth.NewAuthService(logger, mockStorage, cfg, mockRedis)
+	logins := ratelimit.NewLoginGuard(mockRedis, cfg.LoginLimits(), logger)
+
+	return auth.NewAuthService(logger, mockStorage, cfg, mockRedis, rotationNotifier{}, logins, newMemObjects(),
+		testutils.NewTokenKeys(t))
 }
 
 func TestRegisterLoginFlow(t *testing.T) {
@@ -63,6 +90,65 @@ func TestRegisterLoginFlow(t *testing.T) {
 	require.NotEmpty(t, loginResp.GetRefreshToken())
 }
 
+func TestRegisterLoginFlow_ZeroKnowledge(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	service := newTestService(t)
+
+	vaultKey := &pb.VaultKey{KdfSalt: "c2FsdA==", WrappedKey: "d3JhcHBlZA=="}
+
+	resp, err := service.RegisterV1(ctx, &pb.RegisterV1Request{
+		Username: "zkuser",
+		Password: "securePass123!",
+		Email:    "zk@example.com",
+		VaultKey: vaultKey,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.GetToken())
+	require.Empty(t, resp.GetUserKey(), "the server must not hold a key to a zero-knowledge vault")
+
+	loginResp, err := service.LoginV1(ctx, &pb.LoginV1Request{
+		Username: "zkuser",
+		Password: "securePass123!",
+	})
+	require.NoError(t, err)
+	require.Equal(t, vaultKey.GetKdfSalt(), loginResp.GetVaultKey().GetKdfSalt())
+	require.Equal(t, vaultKey.GetWrappedKey(), loginResp.GetVaultKey().GetWrappedKey())
+
+	userID, err := tokenSubject(loginResp.GetToken())
+	require.NoError(t, err)
+
+	keyResp, err := service.GetVaultKeyV1(testutils.InjectUserToContext(ctx, userID), &pb.GetVaultKeyV1Request{})
+	require.NoError(t, err)
+	require.Equal(t, vaultKey.GetWrappedKey(), keyResp.GetVaultKey().GetWrappedKey())
+}
+
+func TestGetVaultKey_RegularAccount(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	service := newTestService(t)
+
+	resp, err := service.RegisterV1(ctx, &pb.RegisterV1Request{
+		Username: "regular",
+		Password: "securePass123!",
+		Email:    "regular@example.com",
+	})
+	require.NoError(t, err)
+
+	userID, err := tokenSubject(resp.GetToken())
+	require.NoError(t, err)
+
+	keyResp, err := service.GetVaultKeyV1(testutils.InjectUserToContext(ctx, userID), &pb.GetVaultKeyV1Request{})
+	require.NoError(t, err)
+	require.Nil(t, keyResp.GetVaultKey())
+
+	_, err = service.GetVaultKeyV1(ctx, &pb.GetVaultKeyV1Request{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error getting user id")
+}
+
 func TestRegisterDuplicateUsername(t *testing.T) {
 	t.Parallel()
 
@@ -106,6 +192,43 @@ func TestLoginInvalidPassword(t *testing.T) {
 	require.Contains(t, err.Error(), "invalid password")
 }
 
+func TestLogin_RehashesPassword(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t)
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("securePass123!"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	outdated, err := passhash.NewArgon2id(passhash.Params{Memory: 512, Time: 1, Threads: 1}).Hash("securePass123!")
+	require.NoError(t, err)
+
+	for username, hash := range map[string]string{"bcryptuser": string(legacy), "outdateduser": outdated} {
+		_, err := service.Storage.RegisterUser(t.Context(), db.CreateUserParams{
+			Username: username,
+			Password: hash,
+			Email:    username + "@example.com",
+		})
+		require.NoError(t, err)
+
+		resp, err := service.LoginV1(t.Context(), &pb.LoginV1Request{Username: username, Password: "securePass123!"})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.GetToken())
+
+		user, err := service.Storage.GetUser(t.Context(), username)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(user.Password, "$argon2id$v=19$m=1024,t=1,p=1$"), user.Password)
+
+		// The new hash verifies, and is kept by the next login.
+		_, err = service.LoginV1(t.Context(), &pb.LoginV1Request{Username: username, Password: "securePass123!"})
+		require.NoError(t, err)
+
+		again, err := service.Storage.GetUser(t.Context(), username)
+		require.NoError(t, err)
+		require.Equal(t, user.Password, again.Password)
+	}
+}
+
 func TestRefreshTokenFlow(t *testing.T) {
 	t.Parallel()
 
@@ -125,6 +248,13 @@ func TestRefreshTokenFlow(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, refreshResp.GetToken())
 	require.NotEmpty(t, refreshResp.GetRefreshToken())
+
+	// Only the hash of a refresh token is stored.
+	_, err = serviceStorage(service).GetToken(ctx, refreshResp.GetRefreshToken())
+	require.Error(t, err)
+
+	_, err = serviceStorage(service).GetToken(ctx, utils.HashRefreshToken(refreshResp.GetRefreshToken()))
+	require.NoError(t, err)
 }
 
 func TestExpiredRefreshToken(t *testing.T) {
@@ -138,7 +268,7 @@ func TestExpiredRefreshToken(t *testing.T) {
 	expiredTime := time.Now().Add(-1 * time.Hour)
 
 	token := "expiredToken"
-	err := mockStorage.StoreToken(ctx, userID, token, expiredTime)
+	err := mockStorage.StoreToken(ctx, userID, pgtype.UUID{}, utils.HashRefreshToken(token), expiredTime)
 	require.NoError(t, err)
 
 	_, err = service.RefreshTokenV1(ctx, &pb.RefreshTokenV1Request{
@@ -0,0 +1,403 @@
+//nolint:exhaustruct
+package auth
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_item "github.com/npavlov/go-password-manager/gen/proto/item"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// AddEmergencyContactV1 makes a user a trusted contact of the caller. Zero-knowledge accounts cannot give
+// emergency access: the server holds no key to their vault, and a new password would not open it.
+func (as *Service) AddEmergencyContactV1(
+	ctx context.Context,
+	req *pb.AddEmergencyContactV1Request,
+) (*pb.AddEmergencyContactV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	user, err := as.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.WrappedVaultKey.Valid {
+		return nil, status.Error(codes.FailedPrecondition, "zero-knowledge accounts cannot give emergency access")
+	}
+
+	contact, err := as.Storage.GetUser(ctx, req.GetUsername())
+	if err != nil || contact.OrganizationVault {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	if contact.ID == user.ID {
+		return nil, status.Error(codes.InvalidArgument, "users cannot be their own trusted contact")
+	}
+
+	access, err := as.Storage.UpsertEmergencyAccess(ctx, db.UpsertEmergencyAccessParams{
+		GrantorID:  user.ID,
+		GranteeID:  contact.ID,
+		AccessType: fromProtoEmergencyAccessType(req.GetType()),
+		WaitDays:   req.GetWaitDays(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error storing emergency access")
+	}
+
+	as.logger.Info().Str("user_id", user.ID.String()).Str("access_id", access.ID.String()).
+		Msg("emergency contact added")
+
+	return &pb.AddEmergencyContactV1Response{
+		EmergencyAccess: toProtoEmergencyAccess(db.GetEmergencyAccessRow{
+			ID:          access.ID,
+			GrantorID:   access.GrantorID,
+			GranteeID:   access.GranteeID,
+			AccessType:  access.AccessType,
+			WaitDays:    access.WaitDays,
+			Status:      access.Status,
+			RequestedAt: access.RequestedAt,
+			ApprovedAt:  access.ApprovedAt,
+			CreatedAt:   access.CreatedAt,
+			Grantor:     user.Username,
+			Grantee:     contact.Username,
+		}),
+	}, nil
+}
+
+// ListEmergencyAccessV1 lists the emergency access the caller gave and received.
+func (as *Service) ListEmergencyAccessV1(
+	ctx context.Context,
+	req *pb.ListEmergencyAccessV1Request,
+) (*pb.ListEmergencyAccessV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	grants, err := as.Storage.ListEmergencyAccess(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing emergency access")
+	}
+
+	resp := &pb.ListEmergencyAccessV1Response{}
+
+	for _, grant := range grants {
+		access := toProtoEmergencyAccess(db.GetEmergencyAccessRow(grant))
+		if grant.GrantorID == userUUID {
+			resp.TrustedContacts = append(resp.TrustedContacts, access)
+		} else {
+			resp.Granted = append(resp.Granted, access)
+		}
+	}
+
+	return resp, nil
+}
+
+// RemoveEmergencyAccessV1 deletes emergency access. Grantors remove their contacts, and contacts step down.
+func (as *Service) RemoveEmergencyAccessV1(
+	ctx context.Context,
+	req *pb.RemoveEmergencyAccessV1Request,
+) (*pb.RemoveEmergencyAccessV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	deleted, err := as.Storage.DeleteEmergencyAccess(ctx, gu.GetIDFromString(req.GetAccessId()), userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error deleting emergency access")
+	}
+
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "emergency access not found")
+	}
+
+	return &pb.RemoveEmergencyAccessV1Response{}, nil
+}
+
+// RequestEmergencyAccessV1 requests access as a trusted contact. The request is approved once it went
+// unanswered for the wait of the grant, see emergency.Promoter.
+func (as *Service) RequestEmergencyAccessV1(
+	ctx context.Context,
+	req *pb.RequestEmergencyAccessV1Request,
+) (*pb.RequestEmergencyAccessV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, access, err := as.emergencyAccess(ctx, req.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	if access.GranteeID != userUUID {
+		return nil, status.Error(codes.PermissionDenied, "only the trusted contact requests emergency access")
+	}
+
+	requested, err := as.Storage.RequestEmergencyAccess(ctx, access.ID, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting emergency access")
+	}
+
+	if !requested {
+		return nil, status.Error(codes.FailedPrecondition, "emergency access was requested already")
+	}
+
+	as.logger.Info().Str("user_id", access.GrantorID.String()).Str("access_id", access.ID.String()).
+		Msg("emergency access requested")
+
+	_, access, err = as.emergencyAccess(ctx, req.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RequestEmergencyAccessV1Response{EmergencyAccess: toProtoEmergencyAccess(*access)}, nil
+}
+
+// ApproveEmergencyAccessV1 lets the grantor approve a request without waiting.
+func (as *Service) ApproveEmergencyAccessV1(
+	ctx context.Context,
+	req *pb.ApproveEmergencyAccessV1Request,
+) (*pb.ApproveEmergencyAccessV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, access, err := as.emergencyAccess(ctx, req.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	if access.GrantorID != userUUID {
+		return nil, status.Error(codes.PermissionDenied, "only the grantor approves emergency access")
+	}
+
+	approved, err := as.Storage.ApproveEmergencyAccess(ctx, access.ID, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error approving emergency access")
+	}
+
+	if !approved {
+		return nil, status.Error(codes.FailedPrecondition, "emergency access is not requested")
+	}
+
+	return &pb.ApproveEmergencyAccessV1Response{}, nil
+}
+
+// RejectEmergencyAccessV1 lets the grantor reject a request, or end access that was approved. The contact
+// stays trusted and may request again.
+func (as *Service) RejectEmergencyAccessV1(
+	ctx context.Context,
+	req *pb.RejectEmergencyAccessV1Request,
+) (*pb.RejectEmergencyAccessV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, access, err := as.emergencyAccess(ctx, req.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	if access.GrantorID != userUUID {
+		return nil, status.Error(codes.PermissionDenied, "only the grantor rejects emergency access")
+	}
+
+	rejected, err := as.Storage.RejectEmergencyAccess(ctx, access.ID, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error rejecting emergency access")
+	}
+
+	if !rejected {
+		return nil, status.Error(codes.FailedPrecondition, "emergency access is not requested")
+	}
+
+	as.logger.Info().Str("user_id", userUUID.String()).Str("access_id", access.ID.String()).
+		Msg("emergency access rejected")
+
+	return &pb.RejectEmergencyAccessV1Response{}, nil
+}
+
+// ListEmergencyItemsV1 lists the items of the grantor of approved view access. The contact reads them with
+// the RPCs of their type, which resolve the vault of the grantor.
+func (as *Service) ListEmergencyItemsV1(
+	ctx context.Context,
+	req *pb.ListEmergencyItemsV1Request,
+) (*pb.ListEmergencyItemsV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, access, err := as.emergencyAccess(ctx, req.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	if access.GranteeID != userUUID || access.AccessType != db.EmergencyAccessTypeView ||
+		access.Status != db.EmergencyAccessStatusApproved {
+		return nil, status.Error(codes.PermissionDenied, "view access is not approved")
+	}
+
+	rows, err := as.Storage.ListVaultItems(ctx, access.GrantorID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing items")
+	}
+
+	items := make([]*pb_item.ItemData, len(rows))
+	for cursor, row := range rows {
+		items[cursor] = &pb_item.ItemData{
+			Id:        row.IDResource.String(),
+			Type:      utils.ToProtoItemType(row.Type),
+			CreatedAt: timestamppb.New(row.CreatedAt.Time),
+			UpdatedAt: timestamppb.New(row.UpdatedAt.Time),
+			Owner:     access.Grantor,
+			ReadOnly:  true,
+		}
+	}
+
+	return &pb.ListEmergencyItemsV1Response{Items: items}, nil
+}
+
+// TakeOverAccountV1 sets a new password for the grantor of approved takeover access and signs the grantor
+// out everywhere. The second factor is turned off, and the grant returns to idle.
+func (as *Service) TakeOverAccountV1(
+	ctx context.Context,
+	req *pb.TakeOverAccountV1Request,
+) (*pb.TakeOverAccountV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, access, err := as.emergencyAccess(ctx, req.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := as.hasher.Hash(req.GetNewPassword())
+	if err != nil {
+		return nil, errors.Wrap(err, "error hashing password")
+	}
+
+	taken, err := as.Storage.TakeOverAccount(ctx, db.TakeOverAccountParams{
+		NewPassword: hash,
+		ID:          access.ID,
+		GranteeID:   userUUID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error taking over account")
+	}
+
+	if !taken {
+		return nil, status.Error(codes.PermissionDenied, "takeover access is not approved")
+	}
+
+	as.logger.Info().Str("user_id", access.GrantorID.String()).Str("access_id", access.ID.String()).
+		Msg("account taken over")
+
+	if _, err := as.signOut(ctx, access.GrantorID); err != nil {
+		return nil, err
+	}
+
+	return &pb.TakeOverAccountV1Response{}, nil
+}
+
+// emergencyAccess returns the caller with a grant they gave or received. Other grants are not found.
+func (as *Service) emergencyAccess(
+	ctx context.Context,
+	accessID string,
+) (pgtype.UUID, *db.GetEmergencyAccessRow, error) {
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return pgtype.UUID{}, nil, errors.Wrap(err, "error getting user id")
+	}
+
+	access, err := as.Storage.GetEmergencyAccess(ctx, gu.GetIDFromString(accessID), userUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, nil, status.Error(codes.NotFound, "emergency access not found")
+	}
+	if err != nil {
+		return pgtype.UUID{}, nil, errors.Wrap(err, "error getting emergency access")
+	}
+
+	return userUUID, access, nil
+}
+
+func toProtoEmergencyAccess(access db.GetEmergencyAccessRow) *pb.EmergencyAccess {
+	result := &pb.EmergencyAccess{
+		Id:        access.ID.String(),
+		Grantor:   access.Grantor,
+		Grantee:   access.Grantee,
+		Type:      toProtoEmergencyAccessType(access.AccessType),
+		WaitDays:  access.WaitDays,
+		Status:    toProtoEmergencyAccessStatus(access.Status),
+		CreatedAt: timestamppb.New(access.CreatedAt.Time),
+	}
+
+	if access.RequestedAt.Valid {
+		result.RequestedAt = timestamppb.New(access.RequestedAt.Time)
+	}
+
+	if access.ApprovedAt.Valid {
+		result.ApprovedAt = timestamppb.New(access.ApprovedAt.Time)
+	}
+
+	return result
+}
+
+func toProtoEmergencyAccessType(accessType db.EmergencyAccessType) pb.EmergencyAccessType {
+	switch accessType {
+	case db.EmergencyAccessTypeView:
+		return pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_VIEW
+	case db.EmergencyAccessTypeTakeover:
+		return pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_TAKEOVER
+	default:
+		return pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_UNSPECIFIED
+	}
+}
+
+func fromProtoEmergencyAccessType(accessType pb.EmergencyAccessType) db.EmergencyAccessType {
+	if accessType == pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_TAKEOVER {
+		return db.EmergencyAccessTypeTakeover
+	}
+
+	return db.EmergencyAccessTypeView
+}
+
+func toProtoEmergencyAccessStatus(accessStatus db.EmergencyAccessStatus) pb.EmergencyAccessStatus {
+	switch accessStatus {
+	case db.EmergencyAccessStatusIdle:
+		return pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_IDLE
+	case db.EmergencyAccessStatusRequested:
+		return pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_REQUESTED
+	case db.EmergencyAccessStatusApproved:
+		return pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_APPROVED
+	default:
+		return pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_UNSPECIFIED
+	}
+}
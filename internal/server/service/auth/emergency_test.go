@@ -0,0 +1,362 @@
+//nolint:exhaustruct
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
+	"github.com/npavlov/go-password-manager/internal/server/service/auth"
+	"github.com/npavlov/go-password-manager/internal/server/service/password"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	generalutils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+type emergencyFixture struct {
+	service   *auth.Service
+	passwords *password.Service
+	storage   *testutils.MockDBStorage
+	redis     *testutils.MockRedis
+	grantor   *pb.RegisterV1Response
+	contexts  map[string]context.Context
+}
+
+// setupEmergency registers alice, who gives emergency access, and bob and carol.
+func setupEmergency(t *testing.T) *emergencyFixture {
+	t.Helper()
+
+	masterKey, _ := utils.GenerateRandomKey()
+
+	logger := testutils.GetTLogger()
+	storage := testutils.NewMockDBStorage(logger, masterKey)
+	redis := testutils.NewMockRedis()
+
+	cfg := &config.Config{
+		SecuredMasterKey: generalutils.NewString(masterKey),
+		PasswordMemory:   1024,
+		PasswordTime:     1,
+		PasswordThreads:  1,
+	}
+
+	logins := ratelimit.NewLoginGuard(redis, cfg.LoginLimits(), logger)
+
+	f := &emergencyFixture{
+		service: auth.NewAuthService(logger, storage, cfg, redis, rotationNotifier{}, logins, newMemObjects(),
+			testutils.NewTokenKeys(t)),
+		passwords: password.NewPasswordService(logger, storage, cfg),
+		storage:   storage,
+		redis:     redis,
+		contexts:  make(map[string]context.Context),
+	}
+
+	for _, username := range []string{"alice", "bob", "carol"} {
+		resp := register(t, f.service, username, nil)
+		if username == "alice" {
+			f.grantor = resp
+		}
+
+		f.contexts[username] = authorized(t, resp.GetToken())
+	}
+
+	return f
+}
+
+// contact makes bob a trusted contact of alice and returns the ID of the grant.
+func (f *emergencyFixture) contact(t *testing.T, accessType pb.EmergencyAccessType) string {
+	t.Helper()
+
+	resp, err := f.service.AddEmergencyContactV1(f.contexts["alice"], &pb.AddEmergencyContactV1Request{
+		Username: "bob",
+		Type:     accessType,
+		WaitDays: 3,
+	})
+	require.NoError(t, err)
+
+	return resp.GetEmergencyAccess().GetId()
+}
+
+func TestEmergencyAccess_Flow(t *testing.T) {
+	t.Parallel()
+
+	f := setupEmergency(t)
+	accessID := f.contact(t, pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_VIEW)
+
+	contacts, err := f.service.ListEmergencyAccessV1(f.contexts["alice"], &pb.ListEmergencyAccessV1Request{})
+	require.NoError(t, err)
+	require.Len(t, contacts.GetTrustedContacts(), 1)
+	assert.Empty(t, contacts.GetGranted())
+	assert.Equal(t, "bob", contacts.GetTrustedContacts()[0].GetGrantee())
+
+	granted, err := f.service.ListEmergencyAccessV1(f.contexts["bob"], &pb.ListEmergencyAccessV1Request{})
+	require.NoError(t, err)
+	require.Len(t, granted.GetGranted(), 1)
+	assert.Equal(t, "alice", granted.GetGranted()[0].GetGrantor())
+
+	// Only the grantee requests, and carol does not see the grant at all
+	_, err = f.service.RequestEmergencyAccessV1(f.contexts["alice"], &pb.RequestEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = f.service.RequestEmergencyAccessV1(f.contexts["carol"], &pb.RequestEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	requested, err := f.service.RequestEmergencyAccessV1(f.contexts["bob"], &pb.RequestEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_REQUESTED,
+		requested.GetEmergencyAccess().GetStatus())
+	assert.NotNil(t, requested.GetEmergencyAccess().GetRequestedAt())
+
+	_, err = f.service.RequestEmergencyAccessV1(f.contexts["bob"], &pb.RequestEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = f.service.ListEmergencyItemsV1(f.contexts["bob"], &pb.ListEmergencyItemsV1Request{AccessId: accessID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The grantor rejects the request, and the contact asks again
+	_, err = f.service.RejectEmergencyAccessV1(f.contexts["bob"], &pb.RejectEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = f.service.RejectEmergencyAccessV1(f.contexts["alice"], &pb.RejectEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+
+	_, err = f.service.ApproveEmergencyAccessV1(f.contexts["alice"], &pb.ApproveEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = f.service.RequestEmergencyAccessV1(f.contexts["bob"], &pb.RequestEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+
+	_, err = f.service.ApproveEmergencyAccessV1(f.contexts["alice"], &pb.ApproveEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+
+	_, err = f.service.RemoveEmergencyAccessV1(f.contexts["carol"], &pb.RemoveEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The contact steps down
+	_, err = f.service.RemoveEmergencyAccessV1(f.contexts["bob"], &pb.RemoveEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+
+	contacts, err = f.service.ListEmergencyAccessV1(f.contexts["alice"], &pb.ListEmergencyAccessV1Request{})
+	require.NoError(t, err)
+	assert.Empty(t, contacts.GetTrustedContacts())
+}
+
+func TestEmergencyAccess_View(t *testing.T) {
+	t.Parallel()
+
+	f := setupEmergency(t)
+	accessID := f.contact(t, pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_VIEW)
+
+	stored, err := f.passwords.StorePasswordV1(f.contexts["alice"], &pb_password.StorePasswordV1Request{
+		Password: &pb_password.PasswordData{Login: "alice", Password: "secret"},
+	})
+	require.NoError(t, err)
+
+	passwordID := stored.GetPasswordId()
+
+	_, err = f.passwords.GetPasswordV1(f.contexts["bob"], &pb_password.GetPasswordV1Request{PasswordId: passwordID})
+	require.Error(t, err)
+
+	_, err = f.service.RequestEmergencyAccessV1(f.contexts["bob"], &pb.RequestEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+
+	// Nobody answers the request, so it is approved once the wait is over
+	promoted, err := f.storage.PromoteEmergencyAccess(t.Context(), time.Now().AddDate(0, 0, 2))
+	require.NoError(t, err)
+	assert.Zero(t, promoted)
+
+	promoted, err = f.storage.PromoteEmergencyAccess(t.Context(), time.Now().AddDate(0, 0, 3))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, promoted)
+
+	items, err := f.service.ListEmergencyItemsV1(f.contexts["bob"], &pb.ListEmergencyItemsV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+	require.Len(t, items.GetItems(), 1)
+	assert.Equal(t, passwordID, items.GetItems()[0].GetId())
+	assert.Equal(t, "alice", items.GetItems()[0].GetOwner())
+	assert.True(t, items.GetItems()[0].GetReadOnly())
+
+	resp, err := f.passwords.GetPasswordV1(f.contexts["bob"], &pb_password.GetPasswordV1Request{PasswordId: passwordID})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", resp.GetPassword().GetPassword())
+
+	// View access does not extend to changes
+	_, err = f.passwords.DeletePasswordV1(f.contexts["bob"], &pb_password.DeletePasswordV1Request{
+		PasswordId: passwordID,
+	})
+	require.Error(t, err)
+
+	_, err = f.service.TakeOverAccountV1(f.contexts["bob"], &pb.TakeOverAccountV1Request{
+		AccessId: accessID, NewPassword: "takenOver123!",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Rejecting ends access that was approved
+	_, err = f.service.RejectEmergencyAccessV1(f.contexts["alice"], &pb.RejectEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+
+	_, err = f.passwords.GetPasswordV1(f.contexts["bob"], &pb_password.GetPasswordV1Request{PasswordId: passwordID})
+	require.Error(t, err)
+}
+
+func TestEmergencyAccess_TakeOver(t *testing.T) {
+	t.Parallel()
+
+	f := setupEmergency(t)
+	accessID := f.contact(t, pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_TAKEOVER)
+
+	_, err := f.service.TakeOverAccountV1(f.contexts["bob"], &pb.TakeOverAccountV1Request{
+		AccessId: accessID, NewPassword: "takenOver123!",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = f.service.RequestEmergencyAccessV1(f.contexts["bob"], &pb.RequestEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+
+	_, err = f.service.ApproveEmergencyAccessV1(f.contexts["alice"], &pb.ApproveEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+
+	_, err = f.service.ListEmergencyItemsV1(f.contexts["bob"], &pb.ListEmergencyItemsV1Request{AccessId: accessID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = f.service.TakeOverAccountV1(f.contexts["bob"], &pb.TakeOverAccountV1Request{
+		AccessId: accessID, NewPassword: "takenOver123!",
+	})
+	require.NoError(t, err)
+
+	// The grantor is signed out and the old password is gone, but the contact keeps their session
+	require.False(t, accessTokenValid(t, f.redis, f.grantor.GetToken()))
+
+	_, err = f.service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{
+		RefreshToken: f.grantor.GetRefreshToken(),
+	})
+	require.Error(t, err)
+
+	_, err = f.service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "alice", Password: "securePass123!"})
+	require.Error(t, err)
+
+	login, err := f.service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "alice", Password: "takenOver123!"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, login.GetToken())
+
+	_, err = f.service.ListEmergencyAccessV1(f.contexts["bob"], &pb.ListEmergencyAccessV1Request{})
+	require.NoError(t, err)
+
+	// The grant returns to idle, so taking over again takes another request
+	_, err = f.service.TakeOverAccountV1(f.contexts["bob"], &pb.TakeOverAccountV1Request{
+		AccessId: accessID, NewPassword: "takenAgain123!",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestEmergencyAccess_TakeOverTurnsOffTOTP(t *testing.T) {
+	t.Parallel()
+
+	f := setupEmergency(t)
+	daveCtx, _, _ := enrollTOTP(t, f.service, "dave")
+	f.contexts["dave"] = daveCtx
+
+	resp, err := f.service.AddEmergencyContactV1(f.contexts["dave"], &pb.AddEmergencyContactV1Request{
+		Username: "bob",
+		Type:     pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_TAKEOVER,
+		WaitDays: 1,
+	})
+	require.NoError(t, err)
+
+	accessID := resp.GetEmergencyAccess().GetId()
+
+	_, err = f.service.RequestEmergencyAccessV1(f.contexts["bob"], &pb.RequestEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+
+	_, err = f.service.ApproveEmergencyAccessV1(f.contexts["dave"], &pb.ApproveEmergencyAccessV1Request{
+		AccessId: accessID,
+	})
+	require.NoError(t, err)
+
+	_, err = f.service.TakeOverAccountV1(f.contexts["bob"], &pb.TakeOverAccountV1Request{
+		AccessId: accessID, NewPassword: "takenOver123!",
+	})
+	require.NoError(t, err)
+
+	login, err := f.service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "dave", Password: "takenOver123!"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, login.GetToken(), "the second factor must be off")
+	assert.Empty(t, login.GetChallengeToken())
+}
+
+func TestAddEmergencyContact_Errors(t *testing.T) {
+	t.Parallel()
+
+	f := setupEmergency(t)
+
+	_, err := f.service.AddEmergencyContactV1(f.contexts["alice"], &pb.AddEmergencyContactV1Request{
+		Username: "nobody", Type: pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_VIEW, WaitDays: 1,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = f.service.AddEmergencyContactV1(f.contexts["alice"], &pb.AddEmergencyContactV1Request{
+		Username: "alice", Type: pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_VIEW, WaitDays: 1,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = f.service.AddEmergencyContactV1(f.contexts["alice"], &pb.AddEmergencyContactV1Request{
+		Username: "bob", Type: pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_VIEW, WaitDays: 91,
+	})
+	require.ErrorContains(t, err, "error validating input")
+
+	_, err = f.service.AddEmergencyContactV1(f.contexts["alice"], &pb.AddEmergencyContactV1Request{
+		Username: "bob", WaitDays: 1,
+	})
+	require.ErrorContains(t, err, "error validating input")
+
+	zk := register(t, f.service, "zkgrantor", &pb.VaultKey{
+		KdfSalt: "c2FsdA==", WrappedKey: "d3JhcHBlZA==",
+	})
+
+	_, err = f.service.AddEmergencyContactV1(authorized(t, zk.GetToken()), &pb.AddEmergencyContactV1Request{
+		Username: "bob", Type: pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_VIEW, WaitDays: 1,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
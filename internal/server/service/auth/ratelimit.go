@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+// RetryAfterHeader is the trailer telling a rejected client how many seconds to wait before logging in again.
+const RetryAfterHeader = "retry-after"
+
+// checkLogin rejects a login with ResourceExhausted while the username or the calling address is blocked.
+func (as *Service) checkLogin(ctx context.Context, username string) error {
+	err := as.logins.Check(ctx, username, utils.ClientIP(ctx))
+
+	var blocked *ratelimit.BlockedError
+	if !errors.As(err, &blocked) {
+		return errors.Wrap(err, "error checking login limits")
+	}
+
+	retryAfter := strconv.FormatInt(int64(math.Ceil(blocked.RetryAfter.Seconds())), 10)
+
+	// Calls made outside a server, as in tests, have no trailer to set
+	if err := grpc.SetTrailer(ctx, metadata.Pairs(RetryAfterHeader, retryAfter)); err != nil {
+		as.logger.Debug().Err(err).Msg("failed to set retry-after trailer")
+	}
+
+	as.logger.Warn().Str("username", username).Str("ip", utils.ClientIP(ctx)).Msg(blocked.Reason)
+
+	return status.Error(codes.ResourceExhausted, blocked.Error())
+}
+
+// loginFailed counts a failed login. Errors are only logged, the login has failed either way.
+func (as *Service) loginFailed(ctx context.Context, username string) {
+	if err := as.logins.Failed(ctx, username); err != nil {
+		as.logger.Error().Err(err).Msg("failed to count failed login")
+	}
+}
+
+// loginSucceeded forgets the failed logins of username.
+func (as *Service) loginSucceeded(ctx context.Context, username string) {
+	if err := as.logins.Succeeded(ctx, username); err != nil {
+		as.logger.Error().Err(err).Msg("failed to reset failed logins")
+	}
+}
@@ -0,0 +1,190 @@
+//nolint:exhaustruct
+package auth_test
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
+	"github.com/npavlov/go-password-manager/internal/server/service/auth"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	generalutils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// trailerStream records the trailer a handler sets.
+type trailerStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *trailerStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+
+	return nil
+}
+
+func newLimitedService(t *testing.T, limits ratelimit.Limits) *auth.Service {
+	t.Helper()
+
+	masterKey, _ := utils.GenerateRandomKey()
+
+	logger := testutils.GetTLogger()
+	mockRedis := testutils.NewMockRedis()
+
+	cfg := &config.Config{
+		SecuredMasterKey: generalutils.NewString(masterKey),
+		PasswordMemory:   1024,
+		PasswordTime:     1,
+		PasswordThreads:  1,
+	}
+
+	return auth.NewAuthService(logger, testutils.NewMockDBStorage(logger, masterKey), cfg, mockRedis,
+		rotationNotifier{}, ratelimit.NewLoginGuard(mockRedis, limits, logger), newMemObjects(),
+		testutils.NewTokenKeys(t))
+}
+
+// login logs in from the address and returns the error with the retry-after trailer.
+func login(service *auth.Service, ip, username, password string) (string, error) {
+	stream := &trailerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 4242}})
+
+	_, err := service.LoginV1(ctx, &pb.LoginV1Request{Username: username, Password: password})
+
+	return firstValue(stream.trailer, auth.RetryAfterHeader), err
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func TestLogin_Lockout(t *testing.T) {
+	t.Parallel()
+
+	service := newLimitedService(t, ratelimit.Limits{
+		MaxFailures:   3,
+		FailureWindow: time.Minute,
+		Lockout:       time.Hour,
+	})
+
+	_, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "guessed",
+		Password: "securePass123!",
+		Email:    "guessed@example.com",
+	})
+	require.NoError(t, err)
+
+	for range 3 {
+		_, err = login(service, "10.0.0.1", "guessed", "wrongPass123!")
+		require.ErrorContains(t, err, "invalid password")
+	}
+
+	// The right password does not help while the account is locked.
+	retryAfter, err := login(service, "10.0.0.2", "guessed", "securePass123!")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, strconv.Itoa(int(time.Hour.Seconds())), retryAfter)
+
+	// Unknown usernames lock the same way.
+	for range 3 {
+		_, err = login(service, "10.0.0.1", "nobody", "wrongPass123!")
+		require.ErrorContains(t, err, "error getting user")
+	}
+
+	_, err = login(service, "10.0.0.1", "nobody", "wrongPass123!")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestLogin_Backoff(t *testing.T) {
+	t.Parallel()
+
+	service := newLimitedService(t, ratelimit.Limits{
+		MaxFailures:   10,
+		FailureWindow: time.Minute,
+		Backoff:       100 * time.Millisecond,
+		Lockout:       time.Hour,
+	})
+
+	_, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "hasty",
+		Password: "securePass123!",
+		Email:    "hasty@example.com",
+	})
+	require.NoError(t, err)
+
+	_, err = login(service, "10.0.0.1", "hasty", "wrongPass123!")
+	require.ErrorContains(t, err, "invalid password")
+
+	retryAfter, err := login(service, "10.0.0.1", "hasty", "securePass123!")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, "1", retryAfter)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = login(service, "10.0.0.1", "hasty", "securePass123!")
+	require.NoError(t, err)
+
+	// The successful login forgot the failure.
+	_, err = login(service, "10.0.0.1", "hasty", "wrongPass123!")
+	require.ErrorContains(t, err, "invalid password")
+
+	_, err = login(service, "10.0.0.1", "hasty", "securePass123!")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestLogin_AddressLimit(t *testing.T) {
+	t.Parallel()
+
+	service := newLimitedService(t, ratelimit.Limits{IPAttempts: 2, IPWindow: time.Minute})
+
+	for range 2 {
+		_, err := login(service, "10.0.0.1", "sprayed", "wrongPass123!")
+		require.ErrorContains(t, err, "error getting user")
+	}
+
+	retryAfter, err := login(service, "10.0.0.1", "another", "wrongPass123!")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, "60", retryAfter)
+
+	_, err = login(service, "10.0.0.2", "another", "wrongPass123!")
+	require.ErrorContains(t, err, "error getting user")
+}
+
+func TestVerifyTOTP_CountsFailures(t *testing.T) {
+	t.Parallel()
+
+	service := newLimitedService(t, ratelimit.Limits{
+		MaxFailures:   2,
+		FailureWindow: time.Minute,
+		Lockout:       time.Hour,
+	})
+	enrollTOTP(t, service, "secondguess")
+
+	for range 2 {
+		_, err := service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{
+			ChallengeToken: loginChallenge(t, service, "secondguess"),
+			Code:           "000000",
+		})
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+
+	// Guessing the second factor locks the account like guessing the password.
+	_, err := login(service, "10.0.0.1", "secondguess", "securePass123!")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
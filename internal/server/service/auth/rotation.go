@@ -0,0 +1,168 @@
+//nolint:exhaustruct
+package auth
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+// RotateUserKeyV1 gives the calling user a new data key. Items are re-encrypted in the background and
+// stay readable meanwhile; calling it again after a failure resumes the failed rotation.
+func (as *Service) RotateUserKeyV1(ctx context.Context,
+	req *pb.RotateUserKeyV1Request,
+) (*pb.RotateUserKeyV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	user, err := as.Storage.GetUserByID(ctx, userUUID)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("failed to get user")
+
+		return nil, errors.Wrap(err, "error getting user")
+	}
+
+	if user.WrappedVaultKey.Valid {
+		return nil, status.Error(codes.FailedPrecondition, "zero-knowledge accounts rotate their vault key on the client")
+	}
+
+	var rotation *db.KeyRotation
+	if user.PreviousEncryptionKey.Valid {
+		rotation, err = as.resumeKeyRotation(ctx, user)
+	} else {
+		rotation, err = as.startKeyRotation(ctx, user)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	as.rotations.Notify()
+
+	as.logger.Info().Str("user_id", user.ID.String()).Int32("key_version", rotation.ToVersion).
+		Msg("user key rotation started")
+
+	return &pb.RotateUserKeyV1Response{Rotation: toProtoRotation(rotation)}, nil
+}
+
+func (as *Service) startKeyRotation(ctx context.Context, user *db.User) (*db.KeyRotation, error) {
+	newKey, err := utils.GenerateRandomKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating random key")
+	}
+
+	wrappedKey, err := as.cfg.Keys().Wrap(ctx, newKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "error encrypting key")
+	}
+
+	rotation, err := as.Storage.StartKeyRotation(ctx, db.StartKeyRotationParams{
+		NewKey:      wrappedKey,
+		UserID:      user.ID,
+		FromVersion: user.KeyVersion,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Another request rotated the key since the user was read.
+		return nil, status.Error(codes.FailedPrecondition, "a key rotation is already running")
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "error starting key rotation")
+	}
+
+	return rotation, nil
+}
+
+// resumeKeyRotation restarts the unfinished rotation of a user whose previous key is still in use.
+func (as *Service) resumeKeyRotation(ctx context.Context, user *db.User) (*db.KeyRotation, error) {
+	latest, err := as.Storage.GetLatestKeyRotation(ctx, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting key rotation")
+	}
+
+	if latest.Status != db.KeyRotationStatusFailed {
+		return nil, status.Error(codes.FailedPrecondition, "a key rotation is already running")
+	}
+
+	rotation, err := as.Storage.RetryKeyRotation(ctx, latest.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.FailedPrecondition, "a key rotation is already running")
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "error resuming key rotation")
+	}
+
+	return rotation, nil
+}
+
+// GetKeyRotationV1 reports the progress of the latest key rotation of the calling user.
+func (as *Service) GetKeyRotationV1(ctx context.Context,
+	req *pb.GetKeyRotationV1Request,
+) (*pb.GetKeyRotationV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	rotation, err := as.Storage.GetLatestKeyRotation(ctx, userUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "the key was never rotated")
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting key rotation")
+	}
+
+	return &pb.GetKeyRotationV1Response{Rotation: toProtoRotation(rotation)}, nil
+}
+
+func toProtoRotation(rotation *db.KeyRotation) *pb.KeyRotation {
+	result := &pb.KeyRotation{
+		Id:          rotation.ID.String(),
+		FromVersion: rotation.FromVersion,
+		ToVersion:   rotation.ToVersion,
+		Status:      pb.KeyRotationStatus_KEY_ROTATION_STATUS_UNSPECIFIED,
+		TotalItems:  rotation.TotalItems,
+		DoneItems:   rotation.DoneItems,
+		Error:       rotation.Error.String,
+		StartedAt:   timestamppb.New(rotation.StartedAt.Time),
+	}
+
+	switch rotation.Status {
+	case db.KeyRotationStatusRunning:
+		result.Status = pb.KeyRotationStatus_KEY_ROTATION_STATUS_RUNNING
+	case db.KeyRotationStatusCompleted:
+		result.Status = pb.KeyRotationStatus_KEY_ROTATION_STATUS_COMPLETED
+	case db.KeyRotationStatusFailed:
+		result.Status = pb.KeyRotationStatus_KEY_ROTATION_STATUS_FAILED
+	}
+
+	if rotation.FinishedAt.Valid {
+		result.FinishedAt = timestamppb.New(rotation.FinishedAt.Time)
+	}
+
+	return result
+}
@@ -0,0 +1,86 @@
+//nolint:exhaustruct
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	generalutils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+func TestRotateUserKey(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t)
+
+	resp, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "rotating",
+		Password: "securePass123!",
+		Email:    "rotating@example.com",
+	})
+	require.NoError(t, err)
+
+	userID, err := tokenSubject(resp.GetToken())
+	require.NoError(t, err)
+
+	ctx := testutils.InjectUserToContext(t.Context(), userID)
+
+	_, err = service.GetKeyRotationV1(ctx, &pb.GetKeyRotationV1Request{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	rotateResp, err := service.RotateUserKeyV1(ctx, &pb.RotateUserKeyV1Request{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), rotateResp.GetRotation().GetFromVersion())
+	require.Equal(t, int32(2), rotateResp.GetRotation().GetToVersion())
+	require.Equal(t, pb.KeyRotationStatus_KEY_ROTATION_STATUS_RUNNING, rotateResp.GetRotation().GetStatus())
+
+	storage := serviceStorage(service)
+	user, err := storage.GetUserByID(ctx, generalutils.GetIDFromString(userID))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), user.KeyVersion)
+	require.True(t, user.PreviousEncryptionKey.Valid)
+
+	// Only one rotation runs at a time.
+	_, err = service.RotateUserKeyV1(ctx, &pb.RotateUserKeyV1Request{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// A failed rotation is resumed rather than replaced.
+	rotationID := generalutils.GetIDFromString(rotateResp.GetRotation().GetId())
+	require.NoError(t, storage.FailKeyRotation(ctx, rotationID, "boom"))
+
+	getResp, err := service.GetKeyRotationV1(ctx, &pb.GetKeyRotationV1Request{})
+	require.NoError(t, err)
+	require.Equal(t, pb.KeyRotationStatus_KEY_ROTATION_STATUS_FAILED, getResp.GetRotation().GetStatus())
+	require.Equal(t, "boom", getResp.GetRotation().GetError())
+	require.NotNil(t, getResp.GetRotation().GetFinishedAt())
+
+	rotateResp, err = service.RotateUserKeyV1(ctx, &pb.RotateUserKeyV1Request{})
+	require.NoError(t, err)
+	require.Equal(t, rotationID.String(), rotateResp.GetRotation().GetId())
+	require.Equal(t, pb.KeyRotationStatus_KEY_ROTATION_STATUS_RUNNING, rotateResp.GetRotation().GetStatus())
+}
+
+func TestRotateUserKey_ZeroKnowledge(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t)
+	ctx := serviceStorage(service).AddZeroKnowledgeUser(t.Context())
+
+	_, err := service.RotateUserKeyV1(ctx, &pb.RotateUserKeyV1Request{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestRotateUserKey_NoUser(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t)
+
+	_, err := service.RotateUserKeyV1(t.Context(), &pb.RotateUserKeyV1Request{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error getting user id")
+}
@@ -0,0 +1,335 @@
+//nolint:exhaustruct
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// sessionTokenPrefix keys the current access token of a session in Redis, so revoking the session
+// can delete it.
+const sessionTokenPrefix = "session-token:"
+
+// LogoutV1 signs out the session of the refresh token and invalidates the access token of the call.
+func (as *Service) LogoutV1(ctx context.Context, req *pb.LogoutV1Request) (*pb.LogoutV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	tokenHash := utils.HashRefreshToken(req.GetRefreshToken())
+
+	tokenRow, err := as.Storage.GetToken(ctx, tokenHash)
+	if err != nil || tokenRow.UserID != userUUID {
+		return nil, status.Error(codes.NotFound, "session not found")
+	}
+
+	if tokenRow.SessionID.Valid {
+		if _, err := as.revokeSession(ctx, userUUID, tokenRow.SessionID); err != nil {
+			return nil, err
+		}
+	} else if err := as.Storage.DeleteToken(ctx, tokenHash); err != nil {
+		as.logger.Error().Err(err).Msg("failed to delete refresh token")
+
+		return nil, errors.Wrap(err, "error deleting refresh token")
+	}
+
+	// The access token may predate the session, so it is deleted on its own too
+	if err := as.memStorage.Del(ctx, utils.AccessToken(ctx)); err != nil {
+		return nil, errors.Wrap(err, "error deleting access token")
+	}
+
+	as.logger.Info().Str("user_id", userUUID.String()).Msg("user logged out")
+
+	return &pb.LogoutV1Response{}, nil
+}
+
+// ListSessionsV1 lists the sessions of the calling user that can still refresh their tokens.
+func (as *Service) ListSessionsV1(
+	ctx context.Context,
+	req *pb.ListSessionsV1Request,
+) (*pb.ListSessionsV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	sessions, err := as.Storage.ListSessions(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing sessions")
+	}
+
+	result := make([]*pb.Session, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, &pb.Session{
+			Id:         session.ID.String(),
+			DeviceName: session.DeviceName,
+			IpAddress:  session.IpAddress,
+			CreatedAt:  timestamppb.New(session.CreatedAt.Time),
+			LastUsedAt: timestamppb.New(session.LastUsedAt.Time),
+		})
+	}
+
+	return &pb.ListSessionsV1Response{Sessions: result}, nil
+}
+
+// RevokeSessionV1 signs out one session of the calling user.
+func (as *Service) RevokeSessionV1(
+	ctx context.Context,
+	req *pb.RevokeSessionV1Request,
+) (*pb.RevokeSessionV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	revoked, err := as.revokeSession(ctx, userUUID, gu.GetIDFromString(req.GetSessionId()))
+	if err != nil {
+		return nil, err
+	}
+
+	if !revoked {
+		return nil, status.Error(codes.NotFound, "session not found")
+	}
+
+	return &pb.RevokeSessionV1Response{}, nil
+}
+
+// RevokeAllSessionsV1 signs out every session of the calling user, including the calling one.
+func (as *Service) RevokeAllSessionsV1(
+	ctx context.Context,
+	req *pb.RevokeAllSessionsV1Request,
+) (*pb.RevokeAllSessionsV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	revoked, err := as.revokeAllSessions(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:gosec
+	return &pb.RevokeAllSessionsV1Response{Revoked: int32(revoked)}, nil
+}
+
+// startSession records a login from the calling device and issues its tokens.
+func (as *Service) startSession(ctx context.Context, userID pgtype.UUID, deviceName string) (string, string, error) {
+	if deviceName == "" {
+		deviceName = utils.UserAgent(ctx)
+	}
+
+	session, err := as.Storage.CreateSession(ctx, db.CreateSessionParams{
+		UserID:     userID,
+		DeviceName: deviceName,
+		IpAddress:  utils.ClientIP(ctx),
+		ExpiresAt:  pgtype.Timestamp{Time: time.Now().Add(RefreshTokenExpiration), Valid: true},
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "error creating session")
+	}
+
+	return as.tokenGeneration(ctx, userID, session.ID)
+}
+
+// resumeSession marks the session of a refresh token as used and returns it. Tokens issued before
+// sessions existed get a new session.
+func (as *Service) resumeSession(ctx context.Context, tokenRow db.GetRefreshTokenRow) (pgtype.UUID, error) {
+	expiresAt := time.Now().Add(RefreshTokenExpiration)
+
+	if !tokenRow.SessionID.Valid {
+		session, err := as.Storage.CreateSession(ctx, db.CreateSessionParams{
+			UserID:     tokenRow.UserID,
+			DeviceName: utils.UserAgent(ctx),
+			IpAddress:  utils.ClientIP(ctx),
+			ExpiresAt:  pgtype.Timestamp{Time: expiresAt, Valid: true},
+		})
+		if err != nil {
+			return pgtype.UUID{}, errors.Wrap(err, "error creating session")
+		}
+
+		return session.ID, nil
+	}
+
+	touched, err := as.Storage.TouchSession(ctx, tokenRow.SessionID, utils.ClientIP(ctx), expiresAt)
+	if err != nil {
+		return pgtype.UUID{}, errors.Wrap(err, "error updating session")
+	}
+
+	if !touched {
+		return pgtype.UUID{}, status.Error(codes.Unauthenticated, "session revoked")
+	}
+
+	return tokenRow.SessionID, nil
+}
+
+// useRefreshToken marks the refresh token as exchanged and adds it to the session, which makes the
+// session its token family. Losing the race to another refresh of the same token counts as reuse.
+func (as *Service) useRefreshToken(
+	ctx context.Context,
+	tokenHash string,
+	tokenRow db.GetRefreshTokenRow,
+	sessionID pgtype.UUID,
+) error {
+	used, err := as.Storage.UseToken(ctx, tokenHash, sessionID)
+	if err != nil {
+		return errors.Wrap(err, "error using refresh token")
+	}
+
+	if used {
+		return nil
+	}
+
+	// Tokens issued before sessions got a session of their own above, which the winner did not join
+	if sessionID != tokenRow.SessionID {
+		if _, err := as.revokeSession(ctx, tokenRow.UserID, sessionID); err != nil {
+			return err
+		}
+	}
+
+	if tokenRow, err = as.Storage.GetToken(ctx, tokenHash); err != nil {
+		return status.Error(codes.Unauthenticated, "refresh token reused")
+	}
+
+	return as.revokeReusedToken(ctx, tokenRow)
+}
+
+// revokeReusedToken signs out the session an exchanged refresh token belongs to, since either the
+// client presenting it or whoever refreshed first holds a stolen copy. It returns the error for the caller.
+func (as *Service) revokeReusedToken(ctx context.Context, tokenRow db.GetRefreshTokenRow) error {
+	as.logger.Warn().
+		Str("user_id", tokenRow.UserID.String()).
+		Str("session_id", tokenRow.SessionID.String()).
+		Msg("refresh token reused, revoking its session")
+
+	if tokenRow.SessionID.Valid {
+		if _, err := as.revokeSession(ctx, tokenRow.UserID, tokenRow.SessionID); err != nil {
+			return err
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "refresh token reused")
+}
+
+// revokeSession deletes a session of the user with its refresh tokens and access token.
+// It reports false when the user has no such session.
+func (as *Service) revokeSession(ctx context.Context, userID, sessionID pgtype.UUID) (bool, error) {
+	revoked, err := as.Storage.DeleteSession(ctx, userID, sessionID)
+	if err != nil {
+		return false, errors.Wrap(err, "error deleting session")
+	}
+
+	if !revoked {
+		return false, nil
+	}
+
+	if err := as.deleteSessionTokens(ctx, sessionID); err != nil {
+		return false, err
+	}
+
+	as.logger.Info().Str("user_id", userID.String()).Str("session_id", sessionID.String()).Msg("session revoked")
+
+	return true, nil
+}
+
+// revokeAllSessions deletes every session and refresh token of the user along with their access tokens,
+// including the one of the call. It returns the number of sessions deleted.
+func (as *Service) revokeAllSessions(ctx context.Context, userID pgtype.UUID) (int, error) {
+	sessions, err := as.signOut(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := as.memStorage.Del(ctx, utils.AccessToken(ctx)); err != nil {
+		return 0, errors.Wrap(err, "error deleting access token")
+	}
+
+	return sessions, nil
+}
+
+// signOut deletes every session and refresh token of the user along with their access tokens. The caller
+// may be someone else, whose session stays.
+func (as *Service) signOut(ctx context.Context, userID pgtype.UUID) (int, error) {
+	sessionIDs, err := as.Storage.DeleteUserSessions(ctx, userID)
+	if err != nil {
+		return 0, errors.Wrap(err, "error deleting sessions")
+	}
+
+	// Refresh tokens issued before sessions existed belong to none
+	if err := as.Storage.DeleteUserTokens(ctx, userID); err != nil {
+		return 0, errors.Wrap(err, "error deleting refresh tokens")
+	}
+
+	if err := as.deleteSessionTokens(ctx, sessionIDs...); err != nil {
+		return 0, err
+	}
+
+	as.logger.Info().Str("user_id", userID.String()).Int("sessions", len(sessionIDs)).Msg("all sessions revoked")
+
+	return len(sessionIDs), nil
+}
+
+// replaceSessionToken makes token the access token of the session and deletes the previous one.
+func (as *Service) replaceSessionToken(ctx context.Context, sessionID pgtype.UUID, token string) error {
+	if err := as.deleteSessionTokens(ctx, sessionID); err != nil {
+		return err
+	}
+
+	err := as.memStorage.Set(ctx, sessionTokenPrefix+sessionID.String(), token, TokenExpiration)
+
+	return errors.Wrap(err, "error setting session token")
+}
+
+// deleteSessionTokens deletes the current access tokens of the sessions.
+func (as *Service) deleteSessionTokens(ctx context.Context, sessionIDs ...pgtype.UUID) error {
+	tokens := make([]string, 0, len(sessionIDs))
+
+	for _, sessionID := range sessionIDs {
+		// A missing key means the access token has expired already
+		if token, err := as.memStorage.GetDel(ctx, sessionTokenPrefix+sessionID.String()); err == nil {
+			tokens = append(tokens, token)
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	return errors.Wrap(as.memStorage.Del(ctx, tokens...), "error deleting access tokens")
+}
@@ -0,0 +1,390 @@
+//nolint:exhaustruct
+package auth_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/ratelimit"
+	"github.com/npavlov/go-password-manager/internal/server/service/auth"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	generalutils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// fromDevice returns a context of a call from the IP address.
+func fromDevice(ctx context.Context, ip string) context.Context {
+	return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}})
+}
+
+// authorized returns a context of a call made with the access token, as TokenInterceptor passes it on.
+func authorized(t *testing.T, token string) context.Context {
+	t.Helper()
+
+	userID, err := tokenSubject(token)
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(t.Context(), metadata.Pairs("authorization", token))
+
+	return testutils.InjectUserToContext(ctx, userID)
+}
+
+// accessTokenValid reports whether the access token is still accepted.
+func accessTokenValid(t *testing.T, redis *testutils.MockRedis, token string) bool {
+	t.Helper()
+
+	_, err := redis.Get(t.Context(), token)
+
+	return err == nil
+}
+
+// newSessionService is newTestService that also returns its Redis, which holds the access tokens.
+func newSessionService(t *testing.T) (*auth.Service, *testutils.MockRedis) {
+	t.Helper()
+
+	masterKey, _ := utils.GenerateRandomKey()
+
+	logger := testutils.GetTLogger()
+	mockStorage := testutils.NewMockDBStorage(logger, masterKey)
+	mockRedis := testutils.NewMockRedis()
+
+	cfg := &config.Config{
+		SecuredMasterKey: generalutils.NewString(masterKey),
+		PasswordMemory:   1024,
+		PasswordTime:     1,
+		PasswordThreads:  1,
+	}
+
+	logins := ratelimit.NewLoginGuard(mockRedis, cfg.LoginLimits(), logger)
+
+	service := auth.NewAuthService(logger, mockStorage, cfg, mockRedis, rotationNotifier{}, logins, newMemObjects(),
+		testutils.NewTokenKeys(t))
+
+	return service, mockRedis
+}
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+
+	service, redis := newSessionService(t)
+
+	laptop, err := service.RegisterV1(fromDevice(t.Context(), "10.0.0.1"), &pb.RegisterV1Request{
+		Username:   "sessionuser",
+		Password:   "securePass123!",
+		Email:      "session@example.com",
+		DeviceName: "laptop",
+	})
+	require.NoError(t, err)
+
+	phone, err := service.LoginV1(fromDevice(t.Context(), "10.0.0.2"), &pb.LoginV1Request{
+		Username:   "sessionuser",
+		Password:   "securePass123!",
+		DeviceName: "phone",
+	})
+	require.NoError(t, err)
+
+	ctx := authorized(t, laptop.GetToken())
+
+	resp, err := service.ListSessionsV1(ctx, &pb.ListSessionsV1Request{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetSessions(), 2)
+
+	devices := map[string]*pb.Session{}
+	for _, session := range resp.GetSessions() {
+		devices[session.GetDeviceName()] = session
+	}
+
+	require.Equal(t, "10.0.0.1", devices["laptop"].GetIpAddress())
+	require.Equal(t, "10.0.0.2", devices["phone"].GetIpAddress())
+	require.NotNil(t, devices["phone"].GetCreatedAt())
+	require.NotNil(t, devices["phone"].GetLastUsedAt())
+
+	// Revoking the phone invalidates both of its tokens at once.
+	_, err = service.RevokeSessionV1(ctx, &pb.RevokeSessionV1Request{SessionId: devices["phone"].GetId()})
+	require.NoError(t, err)
+	require.False(t, accessTokenValid(t, redis, phone.GetToken()))
+	require.True(t, accessTokenValid(t, redis, laptop.GetToken()))
+
+	_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: phone.GetRefreshToken()})
+	require.Error(t, err)
+
+	_, err = service.RevokeSessionV1(ctx, &pb.RevokeSessionV1Request{SessionId: devices["phone"].GetId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	resp, err = service.ListSessionsV1(ctx, &pb.ListSessionsV1Request{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetSessions(), 1)
+	require.Equal(t, "laptop", resp.GetSessions()[0].GetDeviceName())
+}
+
+func TestRefreshToken_KeepsSession(t *testing.T) {
+	t.Parallel()
+
+	service, redis := newSessionService(t)
+
+	login, err := service.RegisterV1(fromDevice(t.Context(), "10.0.0.1"), &pb.RegisterV1Request{
+		Username: "refreshing",
+		Password: "securePass123!",
+		Email:    "refreshing@example.com",
+	})
+	require.NoError(t, err)
+
+	refreshed, err := service.RefreshTokenV1(fromDevice(t.Context(), "10.0.0.9"), &pb.RefreshTokenV1Request{
+		RefreshToken: login.GetRefreshToken(),
+	})
+	require.NoError(t, err)
+
+	// A session holds one access token at a time.
+	require.False(t, accessTokenValid(t, redis, login.GetToken()))
+	require.True(t, accessTokenValid(t, redis, refreshed.GetToken()))
+
+	resp, err := service.ListSessionsV1(authorized(t, refreshed.GetToken()), &pb.ListSessionsV1Request{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetSessions(), 1)
+	require.Equal(t, "10.0.0.9", resp.GetSessions()[0].GetIpAddress())
+}
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+
+	service, redis := newSessionService(t)
+
+	login, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "leaving",
+		Password: "securePass123!",
+		Email:    "leaving@example.com",
+	})
+	require.NoError(t, err)
+
+	other, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "staying",
+		Password: "securePass123!",
+		Email:    "staying@example.com",
+	})
+	require.NoError(t, err)
+
+	ctx := authorized(t, login.GetToken())
+
+	// The refresh token of another user is not found.
+	_, err = service.LogoutV1(ctx, &pb.LogoutV1Request{RefreshToken: other.GetRefreshToken()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = service.LogoutV1(ctx, &pb.LogoutV1Request{RefreshToken: login.GetRefreshToken()})
+	require.NoError(t, err)
+	require.False(t, accessTokenValid(t, redis, login.GetToken()))
+	require.True(t, accessTokenValid(t, redis, other.GetToken()))
+
+	_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: login.GetRefreshToken()})
+	require.Error(t, err)
+
+	_, err = service.LogoutV1(ctx, &pb.LogoutV1Request{})
+	require.ErrorContains(t, err, "error validating input")
+}
+
+func TestLogout_TokenWithoutSession(t *testing.T) {
+	t.Parallel()
+
+	service, _ := newSessionService(t)
+
+	login, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "legacy",
+		Password: "securePass123!",
+		Email:    "legacy@example.com",
+	})
+	require.NoError(t, err)
+
+	userID, err := tokenSubject(login.GetToken())
+	require.NoError(t, err)
+
+	// Refresh tokens issued before sessions existed have none.
+	err = serviceStorage(service).StoreToken(t.Context(), generalutils.GetIDFromString(userID), pgtype.UUID{},
+		utils.HashRefreshToken("legacy-token"), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	_, err = service.LogoutV1(authorized(t, login.GetToken()), &pb.LogoutV1Request{RefreshToken: "legacy-token"})
+	require.NoError(t, err)
+
+	_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: "legacy-token"})
+	require.Error(t, err)
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	t.Parallel()
+
+	service, redis := newSessionService(t)
+
+	first, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "everywhere",
+		Password: "securePass123!",
+		Email:    "everywhere@example.com",
+	})
+	require.NoError(t, err)
+
+	second, err := service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "everywhere", Password: "securePass123!"})
+	require.NoError(t, err)
+
+	ctx := authorized(t, first.GetToken())
+
+	resp, err := service.RevokeAllSessionsV1(ctx, &pb.RevokeAllSessionsV1Request{})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, resp.GetRevoked())
+
+	for _, token := range []string{first.GetToken(), second.GetToken()} {
+		require.False(t, accessTokenValid(t, redis, token))
+	}
+
+	for _, token := range []string{first.GetRefreshToken(), second.GetRefreshToken()} {
+		_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: token})
+		require.Error(t, err)
+	}
+
+	sessions, err := service.ListSessionsV1(ctx, &pb.ListSessionsV1Request{})
+	require.NoError(t, err)
+	require.Empty(t, sessions.GetSessions())
+}
+
+func TestSessions_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t)
+
+	_, err := service.ListSessionsV1(t.Context(), &pb.ListSessionsV1Request{})
+	require.ErrorContains(t, err, "error getting user id")
+
+	_, err = service.RevokeSessionV1(t.Context(), &pb.RevokeSessionV1Request{SessionId: "not-a-uuid"})
+	require.ErrorContains(t, err, "error validating input")
+
+	_, err = service.RevokeAllSessionsV1(t.Context(), &pb.RevokeAllSessionsV1Request{})
+	require.ErrorContains(t, err, "error getting user id")
+
+	_, err = service.LogoutV1(t.Context(), &pb.LogoutV1Request{RefreshToken: "token"})
+	require.ErrorContains(t, err, "error getting user id")
+}
+
+func TestRefreshToken_WithoutSession(t *testing.T) {
+	t.Parallel()
+
+	service, _ := newSessionService(t)
+
+	login, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "upgrading",
+		Password: "securePass123!",
+		Email:    "upgrading@example.com",
+	})
+	require.NoError(t, err)
+
+	userID, err := tokenSubject(login.GetToken())
+	require.NoError(t, err)
+
+	err = serviceStorage(service).StoreToken(t.Context(), generalutils.GetIDFromString(userID), pgtype.UUID{},
+		utils.HashRefreshToken("legacy-token"), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	// Refreshing a token issued before sessions existed starts one.
+	refreshed, err := service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: "legacy-token"})
+	require.NoError(t, err)
+
+	resp, err := service.ListSessionsV1(authorized(t, login.GetToken()), &pb.ListSessionsV1Request{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetSessions(), 2)
+
+	// The old token joined that session, so reusing it signs the session out.
+	_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: "legacy-token"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: refreshed.GetRefreshToken()})
+	require.Error(t, err)
+}
+
+func TestRefreshToken_Reuse(t *testing.T) {
+	t.Parallel()
+
+	service, redis := newSessionService(t)
+
+	stolen, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "replayed",
+		Password: "securePass123!",
+		Email:    "replayed@example.com",
+	})
+	require.NoError(t, err)
+
+	other, err := service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "replayed", Password: "securePass123!"})
+	require.NoError(t, err)
+
+	// The thief refreshes first, then the owner presents the same token.
+	thief, err := service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: stolen.GetRefreshToken()})
+	require.NoError(t, err)
+
+	_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: stolen.GetRefreshToken()})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// The whole family is gone, including what the thief got.
+	require.False(t, accessTokenValid(t, redis, thief.GetToken()))
+
+	_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: thief.GetRefreshToken()})
+	require.Error(t, err)
+
+	// Other sessions are not affected.
+	require.True(t, accessTokenValid(t, redis, other.GetToken()))
+
+	_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: other.GetRefreshToken()})
+	require.NoError(t, err)
+}
+
+func TestRefreshToken_ConcurrentReuse(t *testing.T) {
+	t.Parallel()
+
+	service, _ := newSessionService(t)
+
+	login, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "racing",
+		Password: "securePass123!",
+		Email:    "racing@example.com",
+	})
+	require.NoError(t, err)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		refreshed []*pb.RefreshTokenV1Response
+	)
+
+	for range 2 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			resp, err := service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{
+				RefreshToken: login.GetRefreshToken(),
+			})
+			if err == nil {
+				mu.Lock()
+				refreshed = append(refreshed, resp)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// At most one refresh wins, and the loser revokes what it got.
+	require.LessOrEqual(t, len(refreshed), 1)
+
+	for _, resp := range refreshed {
+		_, err = service.RefreshTokenV1(t.Context(), &pb.RefreshTokenV1Request{RefreshToken: resp.GetRefreshToken()})
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,254 @@
+//nolint:exhaustruct
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+const (
+	// TOTPChallengeExpiration bounds the time between the password and the code of a login.
+	TOTPChallengeExpiration = time.Minute * 5
+
+	totpChallengePrefix = "totp-challenge:"
+	challengeTokenSize  = 32
+)
+
+//nolint:gochecknoglobals
+var errInvalidCode = status.Error(codes.Unauthenticated, "invalid two-factor code")
+
+// EnrollTOTPV1 generates a new TOTP secret for the calling user. It only protects logins once
+// ConfirmTOTPV1 has checked a code from it; enrolling again before that replaces the secret.
+func (as *Service) EnrollTOTPV1(ctx context.Context, req *pb.EnrollTOTPV1Request) (*pb.EnrollTOTPV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	user, err := as.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TotpEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	key, err := utils.GenerateTOTPKey(user.Username)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating totp secret")
+	}
+
+	// The secret is wrapped like user keys, so master key rotations cover it too
+	wrappedSecret, err := as.cfg.Keys().Wrap(ctx, key.Secret())
+	if err != nil {
+		return nil, errors.Wrap(err, "error encrypting totp secret")
+	}
+
+	stored, err := as.Storage.SetTOTPSecret(ctx, user.ID, wrappedSecret)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("failed to store totp secret")
+
+		return nil, errors.Wrap(err, "error storing totp secret")
+	}
+
+	if !stored {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	qrCode, err := utils.TOTPQRCode(key.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "error drawing qr code")
+	}
+
+	return &pb.EnrollTOTPV1Response{OtpauthUri: key.URL(), Secret: key.Secret(), QrCode: qrCode}, nil
+}
+
+// ConfirmTOTPV1 enables two-factor authentication once the code shows the authenticator holds the enrolled
+// secret, and returns the recovery codes. Only their hashes are stored, so they cannot be shown again.
+func (as *Service) ConfirmTOTPV1(ctx context.Context, req *pb.ConfirmTOTPV1Request) (*pb.ConfirmTOTPV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	user, err := as.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TotpEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	if !user.TotpSecret.Valid {
+		return nil, status.Error(codes.FailedPrecondition, "no totp secret enrolled")
+	}
+
+	secret, err := as.cfg.Keys().Unwrap(ctx, user.TotpSecret.String)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decrypting totp secret")
+	}
+
+	step, err := utils.ValidateTOTP(secret, req.GetCode(), time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid two-factor code")
+	}
+
+	recoveryCodes, err := utils.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating recovery codes")
+	}
+
+	hashes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		hashes[i] = utils.HashRecoveryCode(code)
+	}
+
+	enabled, err := as.Storage.EnableTOTP(ctx, user.ID, user.TotpSecret.String, step, hashes)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("failed to enable totp")
+
+		return nil, errors.Wrap(err, "error enabling totp")
+	}
+
+	if !enabled {
+		return nil, status.Error(codes.Aborted, "totp secret changed during confirmation, enroll again")
+	}
+
+	as.logger.Info().Str("user_id", user.ID.String()).Msg("two-factor authentication enabled")
+
+	return &pb.ConfirmTOTPV1Response{RecoveryCodes: recoveryCodes}, nil
+}
+
+// VerifyTOTPV1 completes a login started by LoginV1 for an account with two-factor authentication.
+// A challenge can be answered once, so a wrong code needs a new login.
+func (as *Service) VerifyTOTPV1(ctx context.Context, req *pb.VerifyTOTPV1Request) (*pb.VerifyTOTPV1Response, error) {
+	if err := as.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userID, err := as.memStorage.GetDel(ctx, totpChallengePrefix+req.GetChallengeToken())
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired login challenge")
+	}
+
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	user, err := as.Storage.GetUserByID(ctx, userUUID)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("failed to get user")
+
+		return nil, errors.Wrap(err, "error getting user")
+	}
+
+	if err := as.verifySecondFactor(ctx, user, req.GetCode()); err != nil {
+		as.logger.Warn().Str("user_id", userID).Msg("second login step failed")
+		as.loginFailed(ctx, user.Username)
+
+		return nil, err
+	}
+
+	token, refreshToken, err := as.startSession(ctx, user.ID, req.GetDeviceName())
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error generating token")
+
+		return nil, errors.Wrap(err, "error generating token")
+	}
+
+	as.loginSucceeded(ctx, user.Username)
+
+	return &pb.VerifyTOTPV1Response{Token: token, RefreshToken: refreshToken, VaultKey: vaultKey(user)}, nil
+}
+
+// startTOTPChallenge returns a token standing for a checked password, which VerifyTOTPV1 exchanges for tokens.
+func (as *Service) startTOTPChallenge(ctx context.Context, userID pgtype.UUID) (string, error) {
+	random := make([]byte, challengeTokenSize)
+	if _, err := rand.Read(random); err != nil {
+		return "", errors.Wrap(err, "error generating challenge token")
+	}
+
+	challenge := base64.RawURLEncoding.EncodeToString(random)
+
+	err := as.memStorage.Set(ctx, totpChallengePrefix+challenge, userID.String(), TOTPChallengeExpiration)
+	if err != nil {
+		return "", errors.Wrap(err, "error storing challenge token")
+	}
+
+	return challenge, nil
+}
+
+// verifySecondFactor accepts a TOTP code that was not used before, or an unused recovery code.
+func (as *Service) verifySecondFactor(ctx context.Context, user *db.User, code string) error {
+	if !user.TotpEnabled || !user.TotpSecret.Valid {
+		return status.Error(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	if !utils.IsTOTPCode(code) {
+		used, err := as.Storage.UseRecoveryCode(ctx, user.ID, utils.HashRecoveryCode(code))
+		if err != nil {
+			return errors.Wrap(err, "error using recovery code")
+		}
+
+		if !used {
+			return errInvalidCode
+		}
+
+		as.logger.Info().Str("user_id", user.ID.String()).Msg("recovery code used")
+
+		return nil
+	}
+
+	secret, err := as.cfg.Keys().Unwrap(ctx, user.TotpSecret.String)
+	if err != nil {
+		return errors.Wrap(err, "error decrypting totp secret")
+	}
+
+	step, err := utils.ValidateTOTP(secret, code, time.Now())
+	if err != nil {
+		return errInvalidCode
+	}
+
+	// A code seen once, even by another login, is not accepted again
+	used, err := as.Storage.UseTOTPStep(ctx, user.ID, step)
+	if err != nil {
+		return errors.Wrap(err, "error using totp code")
+	}
+
+	if !used {
+		return errInvalidCode
+	}
+
+	return nil
+}
+
+// currentUser loads the user the request is authenticated as.
+func (as *Service) currentUser(ctx context.Context) (*db.User, error) {
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	user, err := as.Storage.GetUserByID(ctx, userUUID)
+	if err != nil {
+		as.logger.Error().Err(err).Msg("failed to get user")
+
+		return nil, errors.Wrap(err, "error getting user")
+	}
+
+	return user, nil
+}
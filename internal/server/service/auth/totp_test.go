@@ -0,0 +1,207 @@
+//nolint:exhaustruct
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	"github.com/npavlov/go-password-manager/internal/server/service/auth"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+// enrollTOTP registers a user with two-factor authentication and returns its context, secret and recovery codes.
+func enrollTOTP(t *testing.T, service *auth.Service, username string) (context.Context, string, []string) {
+	t.Helper()
+
+	resp, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: username,
+		Password: "securePass123!",
+		Email:    username + "@example.com",
+	})
+	require.NoError(t, err)
+
+	userID, err := tokenSubject(resp.GetToken())
+	require.NoError(t, err)
+
+	ctx := testutils.InjectUserToContext(t.Context(), userID)
+
+	enrollResp, err := service.EnrollTOTPV1(ctx, &pb.EnrollTOTPV1Request{})
+	require.NoError(t, err)
+	require.Contains(t, enrollResp.GetOtpauthUri(), "otpauth://totp/")
+	require.Contains(t, enrollResp.GetOtpauthUri(), enrollResp.GetSecret())
+	require.NotEmpty(t, enrollResp.GetQrCode())
+
+	code, err := totp.GenerateCode(enrollResp.GetSecret(), time.Now())
+	require.NoError(t, err)
+
+	confirmResp, err := service.ConfirmTOTPV1(ctx, &pb.ConfirmTOTPV1Request{Code: code})
+	require.NoError(t, err)
+	require.Len(t, confirmResp.GetRecoveryCodes(), utils.RecoveryCodeCount)
+
+	return ctx, enrollResp.GetSecret(), confirmResp.GetRecoveryCodes()
+}
+
+func loginChallenge(t *testing.T, service *auth.Service, username string) string {
+	t.Helper()
+
+	resp, err := service.LoginV1(t.Context(), &pb.LoginV1Request{Username: username, Password: "securePass123!"})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetToken(), "tokens must wait for the second factor")
+	require.Empty(t, resp.GetRefreshToken())
+	require.NotEmpty(t, resp.GetChallengeToken())
+
+	return resp.GetChallengeToken()
+}
+
+func TestTOTPLogin(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t)
+	_, secret, _ := enrollTOTP(t, service, "totpuser")
+
+	// The code that confirmed the enrollment cannot log in.
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	_, err = service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{
+		ChallengeToken: loginChallenge(t, service, "totpuser"),
+		Code:           code,
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// The next code does, once.
+	code, err = totp.GenerateCode(secret, time.Now().Add(30*time.Second))
+	require.NoError(t, err)
+
+	challenge := loginChallenge(t, service, "totpuser")
+	resp, err := service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{ChallengeToken: challenge, Code: code})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.GetToken())
+	require.NotEmpty(t, resp.GetRefreshToken())
+
+	_, err = service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{ChallengeToken: challenge, Code: code})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{
+		ChallengeToken: loginChallenge(t, service, "totpuser"),
+		Code:           code,
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestTOTPLogin_RecoveryCode(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t)
+	_, _, recoveryCodes := enrollTOTP(t, service, "recovering")
+
+	resp, err := service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{
+		ChallengeToken: loginChallenge(t, service, "recovering"),
+		Code:           recoveryCodes[0],
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.GetToken())
+
+	// Recovery codes are single-use.
+	_, err = service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{
+		ChallengeToken: loginChallenge(t, service, "recovering"),
+		Code:           recoveryCodes[0],
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{
+		ChallengeToken: loginChallenge(t, service, "recovering"),
+		Code:           "not-a-recovery-code",
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestVerifyTOTP_InvalidChallenge(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t)
+	_, secret, _ := enrollTOTP(t, service, "challenged")
+
+	code, err := totp.GenerateCode(secret, time.Now().Add(30*time.Second))
+	require.NoError(t, err)
+
+	_, err = service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{ChallengeToken: "unknown", Code: code})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// A wrong code uses up the challenge, so guessing needs the password every time.
+	challenge := loginChallenge(t, service, "challenged")
+
+	_, err = service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{ChallengeToken: challenge, Code: "000000"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = service.VerifyTOTPV1(t.Context(), &pb.VerifyTOTPV1Request{ChallengeToken: challenge, Code: code})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestEnrollTOTP_Errors(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t)
+
+	_, err := service.EnrollTOTPV1(t.Context(), &pb.EnrollTOTPV1Request{})
+	require.ErrorContains(t, err, "error getting user id")
+
+	resp, err := service.RegisterV1(t.Context(), &pb.RegisterV1Request{
+		Username: "enrolling",
+		Password: "securePass123!",
+		Email:    "enrolling@example.com",
+	})
+	require.NoError(t, err)
+
+	userID, err := tokenSubject(resp.GetToken())
+	require.NoError(t, err)
+
+	ctx := testutils.InjectUserToContext(t.Context(), userID)
+
+	// Confirming needs an enrolled secret and a code from it.
+	_, err = service.ConfirmTOTPV1(ctx, &pb.ConfirmTOTPV1Request{Code: "123456"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	first, err := service.EnrollTOTPV1(ctx, &pb.EnrollTOTPV1Request{})
+	require.NoError(t, err)
+
+	// Enrolling again replaces the unconfirmed secret.
+	second, err := service.EnrollTOTPV1(ctx, &pb.EnrollTOTPV1Request{})
+	require.NoError(t, err)
+	require.NotEqual(t, first.GetSecret(), second.GetSecret())
+
+	code, err := totp.GenerateCode(first.GetSecret(), time.Now())
+	require.NoError(t, err)
+
+	_, err = service.ConfirmTOTPV1(ctx, &pb.ConfirmTOTPV1Request{Code: code})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = service.ConfirmTOTPV1(ctx, &pb.ConfirmTOTPV1Request{Code: "12345"})
+	require.ErrorContains(t, err, "error validating input")
+
+	// Until a code is confirmed, logins need the password alone.
+	loginResp, err := service.LoginV1(t.Context(), &pb.LoginV1Request{Username: "enrolling", Password: "securePass123!"})
+	require.NoError(t, err)
+	require.NotEmpty(t, loginResp.GetToken())
+	require.Empty(t, loginResp.GetChallengeToken())
+
+	code, err = totp.GenerateCode(second.GetSecret(), time.Now())
+	require.NoError(t, err)
+
+	_, err = service.ConfirmTOTPV1(ctx, &pb.ConfirmTOTPV1Request{Code: code})
+	require.NoError(t, err)
+
+	_, err = service.EnrollTOTPV1(ctx, &pb.EnrollTOTPV1Request{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = service.ConfirmTOTPV1(ctx, &pb.ConfirmTOTPV1Request{Code: code})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -5,13 +5,17 @@ import (
 	"context"
 
 	"github.com/bufbuild/protovalidate-go"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/card"
+	"github.com/npavlov/go-password-manager/internal/server/authz"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/db"
 	"github.com/npavlov/go-password-manager/internal/server/service/utils"
@@ -29,10 +33,15 @@ type Service struct {
 type Storage interface {
 	UpdateCard(ctx context.Context, updateCard db.UpdateCardParams) (*db.Card, error)
 	DeleteCard(ctx context.Context, cardID string, userID pgtype.UUID) error
-	GetCards(ctx context.Context, userID string) ([]db.Card, error)
+	GetCards(ctx context.Context, params db.GetCardsByUserIDParams) ([]db.Card, error)
+	CountCards(ctx context.Context, userID pgtype.UUID) (int64, error)
 	GetCard(ctx context.Context, cardID string, userID pgtype.UUID) (*db.Card, error)
-	StoreCard(ctx context.Context, createCard db.StoreCardParams) (*db.Card, error)
+	StoreCard(ctx context.Context, createCard db.StoreCardParams, collectionID pgtype.UUID) (*db.Card, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User, error)
+	GetItemAccess(ctx context.Context, itemID, userID pgtype.UUID) (*db.GetItemAccessRow, error)
+	GetCollectionAccess(ctx context.Context, collectionID, userID pgtype.UUID) (*db.GetCollectionAccessRow, error)
+	GetEmergencyItemAccess(ctx context.Context, itemID, granteeID pgtype.UUID) (pgtype.UUID, error)
+	ItemExists(ctx context.Context, id pgtype.UUID) (bool, error)
 }
 
 func NewCardService(log *zerolog.Logger, storage Storage, cfg *config.Config) *Service {
@@ -58,15 +67,25 @@ func (ns *Service) StoreCardV1(ctx context.Context, req *pb.StoreCardV1Request)
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, decryptedUserKey, err := utils.GetDecryptionKey(ctx, ns.storage, ns.cfg.SecuredMasterKey.Get())
+	userUUID, userKeys, err := authz.StoreVault(ctx, ns.storage, ns.cfg.Keys(), req.GetCollectionId())
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting decrypted user UUID")
 	}
 
-	data := req.GetCard()
+	cardID, err := utils.StoreItemID(ctx, ns.storage, userKeys, req.GetItemId())
+	if err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	data, err := cardPayload(req.GetCard(), req.GetSealedCard(), userKeys.Current)
+	if err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
 
 	encryptedCardNumber, encryptedCVV, encryptedExpiryDate, err := ns.EncryptCard(
-		decryptedUserKey,
+		userKeys.Current,
+		userUUID,
+		cardID,
 		data.GetCardNumber(),
 		data.GetCvv(),
 		data.GetExpiryDate(),
@@ -78,16 +97,18 @@ func (ns *Service) StoreCardV1(ctx context.Context, req *pb.StoreCardV1Request)
 	}
 
 	// Hash card number for uniqueness check
-	hashedCardNumber := utils.HashCardNumber(req.GetCard().GetCardNumber())
+	hashedCardNumber := utils.HashCardNumber(data.GetCardNumber())
 
 	Card, err := ns.storage.StoreCard(ctx, db.StoreCardParams{
+		ID:                  cardID,
 		UserID:              userUUID,
 		EncryptedCardNumber: encryptedCardNumber,
 		HashedCardNumber:    hashedCardNumber,
 		EncryptedCvv:        encryptedCVV,
 		EncryptedExpiryDate: encryptedExpiryDate,
-		CardholderName:      req.GetCard().GetCardholderName(),
-	})
+		CardholderName:      data.GetCardholderName(),
+		KeyVersion:          userKeys.Version,
+	}, gu.GetIDFromString(req.GetCollectionId()))
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("failed to store card")
 
@@ -104,15 +125,22 @@ func (ns *Service) UpdateCardV1(ctx context.Context, req *pb.UpdateCardV1Request
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	data := req.GetData()
-
-	_, decryptedUserKey, err := utils.GetDecryptionKey(ctx, ns.storage, ns.cfg.SecuredMasterKey.Get())
+	userUUID, userKeys, err := authz.ItemKeys(ctx, ns.storage, ns.cfg.Keys(), req.GetCardId(), authz.Write)
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting decrypted user UUID")
 	}
 
+	data, err := cardPayload(req.GetData(), req.GetSealedData(), userKeys.Current)
+	if err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	cardID := gu.GetIDFromString(req.GetCardId())
+
 	encryptedCardNumber, encryptedCVV, encryptedExpiryDate, err := ns.EncryptCard(
-		decryptedUserKey,
+		userKeys.Current,
+		userUUID,
+		cardID,
 		data.GetCardNumber(),
 		data.GetCvv(),
 		data.GetExpiryDate(),
@@ -124,16 +152,22 @@ func (ns *Service) UpdateCardV1(ctx context.Context, req *pb.UpdateCardV1Request
 	}
 
 	// Hash card number for uniqueness check
-	hashedCardNumber := utils.HashCardNumber(req.GetData().GetCardNumber())
+	hashedCardNumber := utils.HashCardNumber(data.GetCardNumber())
 
 	card, err := ns.storage.UpdateCard(ctx, db.UpdateCardParams{
-		ID:                  gu.GetIDFromString(req.GetCardId()),
+		ID:                  cardID,
+		UserID:              userUUID,
 		EncryptedCardNumber: encryptedCardNumber,
 		HashedCardNumber:    hashedCardNumber,
 		EncryptedCvv:        encryptedCVV,
 		EncryptedExpiryDate: encryptedExpiryDate,
-		CardholderName:      req.GetData().GetCardholderName(),
+		CardholderName:      data.GetCardholderName(),
+		KeyVersion:          userKeys.Version,
+		ExpectedVersion:     req.GetExpectedVersion(),
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ns.conflictError(ctx, req.GetCardId(), userUUID, userKeys)
+	}
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("failed to store password")
 
@@ -141,26 +175,86 @@ func (ns *Service) UpdateCardV1(ctx context.Context, req *pb.UpdateCardV1Request
 	}
 
 	return &pb.UpdateCardV1Response{
-		CardId: card.ID.String(),
+		CardId:  card.ID.String(),
+		Version: card.Version,
 	}, nil
 }
 
-func (ns *Service) EncryptCard(decryptedUserKey, cardNum, cvv, expiryDate string) (string, string, string, error) {
-	encryptedCardNumber, err := utils.Encrypt(cardNum, decryptedUserKey)
+// conflictError explains why an update matched no row: either the card does not exist
+// or its version moved on, in which case the current server copy is attached to the status.
+func (ns *Service) conflictError(
+	ctx context.Context,
+	cardID string,
+	userUUID pgtype.UUID,
+	userKeys utils.UserKeys,
+) error {
+	current, err := ns.storage.GetCard(ctx, cardID, userUUID)
+	if err != nil {
+		return status.Error(codes.NotFound, "card not found")
+	}
+
+	cardData, err := ns.decryptCard(current, userKeys)
+	if err != nil {
+		return err
+	}
+
+	st, err := status.New(codes.Aborted, "card was modified concurrently").WithDetails(&pb.CardConflict{
+		Current: &pb.CardEntry{
+			Id:         current.ID.String(),
+			Card:       cardData,
+			LastUpdate: timestamppb.New(current.UpdatedAt.Time),
+			Version:    current.Version,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "error building conflict status")
+	}
+
+	return st.Err()
+}
+
+// cardPayload returns the card fields of a request. Zero-knowledge accounts send them sealed,
+// every other account in plain form.
+func cardPayload(data *pb.CardData, sealed *pb.SealedCardData, userKey string) (*pb.CardData, error) {
+	if sealed == nil {
+		return data, utils.RequireSealed(userKey, data.GetCardholderName())
+	}
+
+	if userKey != "" {
+		return nil, status.Error(codes.InvalidArgument, "sealed card data requires a zero-knowledge account")
+	}
+
+	return &pb.CardData{
+		CardNumber:     sealed.GetCardNumber(),
+		ExpiryDate:     sealed.GetExpiryDate(),
+		Cvv:            sealed.GetCvv(),
+		CardholderName: sealed.GetCardholderName(),
+	}, nil
+}
+
+// EncryptCard seals the sensitive card fields, each bound to its field of the card.
+func (ns *Service) EncryptCard(
+	decryptedUserKey string,
+	userID, cardID pgtype.UUID,
+	cardNum, cvv, expiryDate string,
+) (string, string, string, error) {
+	encryptedCardNumber, err := utils.SealField(cardNum, decryptedUserKey,
+		utils.Bind(userID, cardID, utils.FieldCardNumber))
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("failed to encrypt card number")
 
 		return "", "", "", errors.Wrap(err, "failed to encrypt card number")
 	}
 
-	encryptedCVV, err := utils.Encrypt(cvv, decryptedUserKey)
+	encryptedCVV, err := utils.SealField(cvv, decryptedUserKey, utils.Bind(userID, cardID, utils.FieldCVV))
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("failed to encrypt card cvv")
 
 		return "", "", "", errors.Wrap(err, "failed to encrypt card cvv")
 	}
 
-	encryptedExpiryDate, err := utils.Encrypt(expiryDate, decryptedUserKey)
+	encryptedExpiryDate, err := utils.SealField(expiryDate, decryptedUserKey,
+		utils.Bind(userID, cardID, utils.FieldExpiryDate))
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("failed to encrypt card Expiry Date")
 
@@ -175,7 +269,7 @@ func (ns *Service) GetCardV1(ctx context.Context, req *pb.GetCardV1Request) (*pb
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, decryptedUserKey, err := utils.GetDecryptionKey(ctx, ns.storage, ns.cfg.SecuredMasterKey.Get())
+	userUUID, userKeys, err := authz.ItemKeys(ctx, ns.storage, ns.cfg.Keys(), req.GetCardId(), authz.Read)
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("error getting decrypted user UUID")
 
@@ -189,45 +283,69 @@ func (ns *Service) GetCardV1(ctx context.Context, req *pb.GetCardV1Request) (*pb
 		return nil, errors.Wrap(err, "error getting user id")
 	}
 
-	cardNumber, err := utils.Decrypt(Card.EncryptedCardNumber, decryptedUserKey)
+	cardData, err := ns.decryptCard(Card, userKeys)
 	if err != nil {
-		ns.logger.Error().Err(err).Msg("error decrypting Card Number")
+		return nil, err
+	}
 
-		return nil, errors.Wrap(err, "error decrypting Card Number")
+	return &pb.GetCardV1Response{
+		Card:       cardData,
+		LastUpdate: timestamppb.New(Card.UpdatedAt.Time),
+		Version:    Card.Version,
+	}, nil
+}
+
+func (ns *Service) GetCardsV1(ctx context.Context, req *pb.GetCardsV1Request) (*pb.GetCardsV1Response, error) {
+	if err := ns.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	cardCvv, err := utils.Decrypt(Card.EncryptedCvv, decryptedUserKey)
+	userUUID, userKeys, err := utils.GetDecryptionKey(ctx, ns.storage, ns.cfg.Keys())
 	if err != nil {
-		ns.logger.Error().Err(err).Msg("error decrypting Card CVV")
+		ns.logger.Error().Err(err).Msg("error getting decrypted user UUID")
 
-		return nil, errors.Wrap(err, "error decrypting Card CVV")
+		return nil, errors.Wrap(err, "error getting decrypted user UUID")
 	}
 
-	expiryDate, err := utils.Decrypt(Card.EncryptedExpiryDate, decryptedUserKey)
+	limit, offset := utils.Paginate(req.GetPage(), req.GetPageSize())
+
+	cards, err := ns.storage.GetCards(ctx, db.GetCardsByUserIDParams{
+		UserID: userUUID,
+		Limit:  limit,
+		Offset: offset,
+	})
 	if err != nil {
-		ns.logger.Error().Err(err).Msg("error decrypting Expiry Date")
+		ns.logger.Error().Err(err).Msg("error getting cards")
 
-		return nil, errors.Wrap(err, "error decrypting Expiry Date")
+		return nil, errors.Wrap(err, "error getting cards")
 	}
 
-	return &pb.GetCardV1Response{
-		Card: &pb.CardData{
-			CardNumber:     cardNumber,
-			Cvv:            cardCvv,
-			ExpiryDate:     expiryDate,
-			CardholderName: Card.CardholderName,
-		},
-		LastUpdate: timestamppb.New(Card.UpdatedAt.Time),
-	}, nil
-}
+	totalCount, err := ns.storage.CountCards(ctx, userUUID)
+	if err != nil {
+		ns.logger.Error().Err(err).Msg("error counting cards")
 
-func (ns *Service) GetCardsV1(_ context.Context, req *pb.GetCardsV1Request) (*pb.GetCardsV1Response, error) {
-	if err := ns.validator.Validate(req); err != nil {
-		return nil, errors.Wrap(err, "error validating input")
+		return nil, errors.Wrap(err, "error counting cards")
+	}
+
+	entries := make([]*pb.CardEntry, len(cards))
+	for cursor, card := range cards {
+		cardData, err := ns.decryptCard(&card, userKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		entries[cursor] = &pb.CardEntry{
+			Id:         card.ID.String(),
+			Card:       cardData,
+			LastUpdate: timestamppb.New(card.UpdatedAt.Time),
+			Version:    card.Version,
+		}
 	}
 
 	return &pb.GetCardsV1Response{
-		Cards: make([]*pb.CardData, 0),
+		Cards: entries,
+		//nolint:gosec
+		TotalCount: int32(totalCount),
 	}, nil
 }
 
@@ -236,7 +354,7 @@ func (ns *Service) DeleteCardV1(ctx context.Context, req *pb.DeleteCardV1Request
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, _, err := utils.GetDecryptionKey(ctx, ns.storage, ns.cfg.SecuredMasterKey.Get())
+	userUUID, _, err := authz.ItemKeys(ctx, ns.storage, ns.cfg.Keys(), req.GetCardId(), authz.Write)
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("error getting user id")
 
@@ -254,3 +372,36 @@ func (ns *Service) DeleteCardV1(ctx context.Context, req *pb.DeleteCardV1Request
 		Ok: true,
 	}, nil
 }
+
+// decryptCard decrypts the sensitive card fields with the user key the card was written with.
+func (ns *Service) decryptCard(card *db.Card, userKeys utils.UserKeys) (*pb.CardData, error) {
+	cardNumber, err := userKeys.Open(card.EncryptedCardNumber, card.KeyVersion,
+		utils.Bind(card.UserID, card.ID, utils.FieldCardNumber))
+	if err != nil {
+		ns.logger.Error().Err(err).Msg("error decrypting Card Number")
+
+		return nil, errors.Wrap(err, "error decrypting Card Number")
+	}
+
+	cardCvv, err := userKeys.Open(card.EncryptedCvv, card.KeyVersion, utils.Bind(card.UserID, card.ID, utils.FieldCVV))
+	if err != nil {
+		ns.logger.Error().Err(err).Msg("error decrypting Card CVV")
+
+		return nil, errors.Wrap(err, "error decrypting Card CVV")
+	}
+
+	expiryDate, err := userKeys.Open(card.EncryptedExpiryDate, card.KeyVersion,
+		utils.Bind(card.UserID, card.ID, utils.FieldExpiryDate))
+	if err != nil {
+		ns.logger.Error().Err(err).Msg("error decrypting Expiry Date")
+
+		return nil, errors.Wrap(err, "error decrypting Expiry Date")
+	}
+
+	return &pb.CardData{
+		CardNumber:     cardNumber,
+		Cvv:            cardCvv,
+		ExpiryDate:     expiryDate,
+		CardholderName: card.CardholderName,
+	}, nil
+}
@@ -9,8 +9,11 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/card"
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/db"
 	"github.com/npavlov/go-password-manager/internal/server/service/card"
@@ -169,6 +172,62 @@ func TestUpdateCard_Success(t *testing.T) {
 	require.Equal(t, "Jane Smith", card.GetCard().GetCardholderName())
 }
 
+func TestUpdateCard_VersionConflict(t *testing.T) {
+	t.Parallel()
+
+	svc, _, ctx := setupCardService(t)
+
+	created, err := svc.StoreCardV1(ctx, &pb.StoreCardV1Request{
+		Card: &pb.CardData{
+			CardNumber:     "4111111111111111",
+			Cvv:            "123",
+			ExpiryDate:     "12/30",
+			CardholderName: "John Doe",
+		},
+	})
+	require.NoError(t, err)
+
+	current, err := svc.GetCardV1(ctx, &pb.GetCardV1Request{CardId: created.GetCardId()})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), current.GetVersion())
+
+	resp, err := svc.UpdateCardV1(ctx, &pb.UpdateCardV1Request{
+		CardId: created.GetCardId(),
+		Data: &pb.CardData{
+			CardNumber:     "4111111111111111",
+			Cvv:            "321",
+			ExpiryDate:     "12/30",
+			CardholderName: "Jane",
+		},
+		ExpectedVersion: current.GetVersion(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.GetVersion())
+
+	_, err = svc.UpdateCardV1(ctx, &pb.UpdateCardV1Request{
+		CardId: created.GetCardId(),
+		Data: &pb.CardData{
+			CardNumber:     "4111111111111111",
+			Cvv:            "555",
+			ExpiryDate:     "12/30",
+			CardholderName: "Joe",
+		},
+		ExpectedVersion: current.GetVersion(),
+	})
+	require.Error(t, err)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Aborted, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	conflict, ok := st.Details()[0].(*pb.CardConflict)
+	require.True(t, ok)
+	require.Equal(t, int64(2), conflict.GetCurrent().GetVersion())
+	require.Equal(t, "Jane", conflict.GetCurrent().GetCard().GetCardholderName())
+	require.Equal(t, "321", conflict.GetCurrent().GetCard().GetCvv())
+}
+
 func TestUpdateCard_Invalid(t *testing.T) {
 	t.Parallel()
 
@@ -183,15 +242,33 @@ func TestUpdateCard_Invalid(t *testing.T) {
 	require.Error(t, err)
 }
 
-func TestGetCards_Empty(t *testing.T) {
+func TestGetCards_Success(t *testing.T) {
 	t.Parallel()
 
 	svc, _, ctx := setupCardService(t)
 
-	req := &pb.GetCardsV1Request{}
-	resp, err := svc.GetCardsV1(ctx, req)
+	cards := []*pb.CardData{
+		{CardNumber: "4111111111111111", Cvv: "123", ExpiryDate: "12/30", CardholderName: "John Doe"},
+		{CardNumber: "5555555555554444", Cvv: "456", ExpiryDate: "01/29", CardholderName: "Jane Doe"},
+	}
+	for _, data := range cards {
+		_, err := svc.StoreCardV1(ctx, &pb.StoreCardV1Request{Card: data})
+		require.NoError(t, err)
+	}
+
+	resp, err := svc.GetCardsV1(ctx, &pb.GetCardsV1Request{Page: 1, PageSize: 10})
 	require.NoError(t, err)
-	require.NotNil(t, resp)
+	require.Len(t, resp.GetCards(), 2)
+	require.Equal(t, int32(2), resp.GetTotalCount())
+
+	numbers := make([]string, 0, len(cards))
+	for _, entry := range resp.GetCards() {
+		require.NotEmpty(t, entry.GetId())
+		require.NotNil(t, entry.GetLastUpdate())
+		numbers = append(numbers, entry.GetCard().GetCardNumber())
+	}
+
+	require.ElementsMatch(t, []string{"4111111111111111", "5555555555554444"}, numbers)
 }
 
 func TestStoreCard_MissingContext(t *testing.T) {
@@ -251,6 +328,7 @@ func TestGetCards_ValidEmpty(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 	require.Empty(t, resp.GetCards())
+	require.Zero(t, resp.GetTotalCount())
 }
 
 func TestDeleteCard_InvalidUUID(t *testing.T) {
@@ -274,6 +352,8 @@ func TestEncryptCard_Success(t *testing.T) {
 
 	encryptedCardNumber, encryptedCVV, encryptedExpiryDate, err := svc.EncryptCard(
 		encryptionKey,
+		utils.NewItemID(),
+		utils.NewItemID(),
 		"4111111111111111",
 		"123",
 		"12/30",
@@ -397,6 +477,8 @@ func TestEncryptCard_InvalidKey(t *testing.T) {
 	//nolint:dogsled
 	_, _, _, err := svc.EncryptCard(
 		"invalid-key", // Invalid encryption key
+		utils.NewItemID(),
+		utils.NewItemID(),
 		"4111111111111111",
 		"123",
 		"12/30",
@@ -628,3 +710,51 @@ func TestCardValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreCard_ZeroKnowledge(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, regularCtx := setupCardService(t)
+	ctx := storage.AddZeroKnowledgeUser(t.Context())
+
+	sealed := &pb.SealedCardData{
+		CardNumber:     vault.SealedPrefix + "bnVtYmVy",
+		ExpiryDate:     vault.SealedPrefix + "ZXhwaXJ5",
+		Cvv:            vault.SealedPrefix + "Y3Z2",
+		CardholderName: vault.SealedPrefix + "aG9sZGVy",
+	}
+
+	resp, err := svc.StoreCardV1(ctx, &pb.StoreCardV1Request{SealedCard: sealed})
+	require.NoError(t, err)
+
+	getResp, err := svc.GetCardV1(ctx, &pb.GetCardV1Request{CardId: resp.GetCardId()})
+	require.NoError(t, err)
+	require.Equal(t, sealed.GetCardNumber(), getResp.GetCard().GetCardNumber())
+	require.Equal(t, sealed.GetCardholderName(), getResp.GetCard().GetCardholderName())
+
+	updateResp, err := svc.UpdateCardV1(ctx, &pb.UpdateCardV1Request{
+		CardId:          resp.GetCardId(),
+		SealedData:      sealed,
+		ExpectedVersion: getResp.GetVersion(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, getResp.GetVersion()+1, updateResp.GetVersion())
+
+	// A zero-knowledge account cannot send plain card data...
+	_, err = svc.StoreCardV1(ctx, &pb.StoreCardV1Request{Card: &pb.CardData{
+		CardNumber:     "4111111111111111",
+		Cvv:            "123",
+		ExpiryDate:     "12/30",
+		CardholderName: "John Doe",
+	}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// ...and a regular account cannot send sealed card data.
+	_, err = svc.StoreCardV1(regularCtx, &pb.StoreCardV1Request{SealedCard: sealed})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Sealed card fields must carry the sealed prefix.
+	_, err = svc.StoreCardV1(ctx, &pb.StoreCardV1Request{SealedCard: &pb.SealedCardData{CardNumber: "4111111111111111"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error validating input")
+}
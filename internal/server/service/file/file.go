@@ -16,6 +16,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/file"
+	"github.com/npavlov/go-password-manager/internal/server/authz"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/db"
 	"github.com/npavlov/go-password-manager/internal/server/service/utils"
@@ -27,11 +28,20 @@ const (
 )
 
 type Storage interface {
-	StoreBinary(ctx context.Context, createBinary db.StoreBinaryEntryParams) (*db.BinaryEntry, error)
+	StoreBinary(
+		ctx context.Context,
+		createBinary db.StoreBinaryEntryParams,
+		collectionID pgtype.UUID,
+	) (*db.BinaryEntry, error)
 	DeleteBinary(ctx context.Context, arg db.DeleteBinaryEntryParams) error
-	GetBinaries(ctx context.Context, userID string) ([]db.BinaryEntry, error)
+	GetBinaries(ctx context.Context, params db.GetBinaryEntriesByUserIDParams) ([]db.BinaryEntry, error)
+	CountBinaries(ctx context.Context, userID pgtype.UUID) (int64, error)
 	GetBinary(ctx context.Context, binaryID string, userID pgtype.UUID) (*db.BinaryEntry, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User, error)
+	GetItemAccess(ctx context.Context, itemID, userID pgtype.UUID) (*db.GetItemAccessRow, error)
+	GetCollectionAccess(ctx context.Context, collectionID, userID pgtype.UUID) (*db.GetCollectionAccessRow, error)
+	GetEmergencyItemAccess(ctx context.Context, itemID, granteeID pgtype.UUID) (pgtype.UUID, error)
+	ItemExists(ctx context.Context, id pgtype.UUID) (bool, error)
 }
 
 type S3Storage interface {
@@ -90,15 +100,20 @@ func (fs *Service) UploadFileV1(stream grpc.ClientStreamingServer[pb.UploadFileV
 		return errors.Wrap(err, "failed to validate file metadata")
 	}
 
-	userUUID, decryptedUserKey, err := utils.GetDecryptionKey(ctx, fs.storage, fs.cfg.SecuredMasterKey.Get())
+	userUUID, userKeys, err := authz.StoreVault(ctx, fs.storage, fs.cfg.Keys(), req.GetCollectionId())
 	if err != nil {
 		fs.logger.Error().Err(err).Msg("error getting user id")
 
 		return errors.Wrap(err, "error getting user id")
 	}
 
+	binaryID, err := utils.StoreItemID(ctx, fs.storage, userKeys, req.GetItemId())
+	if err != nil {
+		return errors.Wrap(err, "error validating input")
+	}
+
 	// Prepare MinIO upload
-	objectName := userUUID.String() + "-" + req.GetFilename()
+	objectName := utils.ObjectPrefix(userUUID) + req.GetFilename()
 	pipeReader, pipeWriter := io.Pipe()
 
 	// Upload file asynchronously
@@ -117,8 +132,8 @@ func (fs *Service) UploadFileV1(stream grpc.ClientStreamingServer[pb.UploadFileV
 		fs.logger.Info().Msg("Successfully uploaded file to MinIO")
 	}()
 
-	// Encrypt and write data to the pipe
-	encryptor, err := utils.NewEncryptor(pipeWriter, decryptedUserKey)
+	// Encrypt and write data to the pipe, bound to the entry stored below
+	encryptor, err := userKeys.SealWriter(pipeWriter, utils.Bind(userUUID, binaryID, utils.FieldFile))
 	if err != nil {
 		fs.logger.Error().Err(err).Msg("error creating encryptor")
 
@@ -152,12 +167,21 @@ func (fs *Service) UploadFileV1(stream grpc.ClientStreamingServer[pb.UploadFileV
 		}
 	}
 
+	// Write the final chunk, without which the file does not decrypt
+	if err := encryptor.Close(); err != nil {
+		fs.logger.Error().Err(err).Msg("failed to finish file")
+
+		return errors.Wrap(err, "failed to finish file")
+	}
+
 	binary, err := fs.storage.StoreBinary(ctx, db.StoreBinaryEntryParams{
-		UserID:   userUUID,
-		FileName: req.GetFilename(),
-		FileSize: totalSize,
-		FileUrl:  objectName,
-	})
+		ID:         binaryID,
+		UserID:     userUUID,
+		FileName:   req.GetFilename(),
+		FileSize:   totalSize,
+		FileUrl:    objectName,
+		KeyVersion: userKeys.Version,
+	}, gu.GetIDFromString(req.GetCollectionId()))
 	if err != nil {
 		fs.logger.Error().Err(err).Msg("failed to store binary")
 
@@ -176,7 +200,50 @@ func (fs *Service) GetFilesV1(ctx context.Context, req *pb.GetFilesV1Request) (*
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	return &pb.GetFilesV1Response{}, nil
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		fs.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	limit, offset := utils.Paginate(req.GetPage(), req.GetPageSize())
+
+	binaries, err := fs.storage.GetBinaries(ctx, db.GetBinaryEntriesByUserIDParams{
+		UserID: userUUID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		fs.logger.Error().Err(err).Msg("error getting files")
+
+		return nil, errors.Wrap(err, "error getting files")
+	}
+
+	totalCount, err := fs.storage.CountBinaries(ctx, userUUID)
+	if err != nil {
+		fs.logger.Error().Err(err).Msg("error counting files")
+
+		return nil, errors.Wrap(err, "error counting files")
+	}
+
+	files := make([]*pb.FileMeta, len(binaries))
+	for cursor, file := range binaries {
+		files[cursor] = &pb.FileMeta{
+			Id:         file.ID.String(),
+			FileName:   file.FileName,
+			FileSize:   file.FileSize,
+			FileUrl:    file.FileUrl,
+			LastUpdate: timestamppb.New(file.UpdatedAt.Time),
+			Version:    file.Version,
+		}
+	}
+
+	return &pb.GetFilesV1Response{
+		Files: files,
+		//nolint:gosec
+		TotalCount: int32(totalCount),
+	}, nil
 }
 
 func (fs *Service) DeleteFileV1(ctx context.Context, req *pb.DeleteFileV1Request) (*pb.DeleteFileV1Response, error) {
@@ -184,7 +251,7 @@ func (fs *Service) DeleteFileV1(ctx context.Context, req *pb.DeleteFileV1Request
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, err := utils.GetUserID(ctx)
+	userUUID, err := authz.ItemVault(ctx, fs.storage, req.GetFileId(), authz.Write)
 	if err != nil {
 		fs.logger.Error().Err(err).Msg("error getting user id")
 
@@ -210,7 +277,7 @@ func (fs *Service) DeleteFileV1(ctx context.Context, req *pb.DeleteFileV1Request
 func (fs *Service) DownloadFileV1(req *pb.DownloadFileV1Request, str grpc.ServerStreamingServer[pb.DownloadFileV1Response]) error {
 	ctx := str.Context()
 
-	userUUID, decryptedUserKey, err := utils.GetDecryptionKey(ctx, fs.storage, fs.cfg.SecuredMasterKey.Get())
+	userUUID, userKeys, err := authz.ItemKeys(ctx, fs.storage, fs.cfg.Keys(), req.GetFileId(), authz.Read)
 	if err != nil {
 		fs.logger.Error().Err(err).Msg("error getting user id")
 
@@ -246,8 +313,9 @@ func (fs *Service) DownloadFileV1(req *pb.DownloadFileV1Request, str grpc.Server
 	}
 	defer reader.Close()
 
-	// Create a decryptor to decrypt the data on the fly
-	decryptor, err := utils.NewDecryptor(reader, decryptedUserKey)
+	// Create a decryptor to decrypt the data on the fly, with the user key of the version the entry records
+	decryptor, err := userKeys.OpenReader(reader, fileEntry.KeyVersion,
+		utils.Bind(fileEntry.UserID, fileEntry.ID, utils.FieldFile))
 	if err != nil {
 		fs.logger.Error().Err(err).Msg("error creating decryptor")
 
@@ -275,7 +343,8 @@ func (fs *Service) DownloadFileV1(req *pb.DownloadFileV1Request, str grpc.Server
 			return status.Error(codes.Internal, "error reading and decrypting file")
 		}
 
-		if cursor < len(buffer) || errors.Is(err, io.EOF) {
+		// Decryptors may return short reads before the end, only EOF tells the file is complete
+		if errors.Is(err, io.EOF) {
 			break
 		}
 	}
@@ -290,7 +359,7 @@ func (fs *Service) GetFileV1(ctx context.Context, req *pb.GetFileV1Request) (*pb
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, err := utils.GetUserID(ctx)
+	userUUID, err := authz.ItemVault(ctx, fs.storage, req.GetFileId(), authz.Read)
 	if err != nil {
 		fs.logger.Error().Err(err).Msg("error getting user id")
 
@@ -310,6 +379,7 @@ func (fs *Service) GetFileV1(ctx context.Context, req *pb.GetFileV1Request) (*pb
 			FileName: file.FileName,
 			FileSize: file.FileSize,
 			FileUrl:  file.FileUrl,
+			Version:  file.Version,
 		},
 		LastUpdate: timestamppb.New(file.UpdatedAt.Time),
 	}, nil
@@ -173,8 +173,10 @@ func TestUploadFile_Success(t *testing.T) {
 		},
 	}
 
-	mockS3.PutObjectFunc = func(_ context.Context, _ string, _ string, _ io.Reader, _ int64, _ minio.PutObjectOptions) (minio.UploadInfo, error) {
-		return minio.UploadInfo{}, nil
+	mockS3.PutObjectFunc = func(_ context.Context, _ string, _ string, reader io.Reader, _ int64, _ minio.PutObjectOptions) (minio.UploadInfo, error) {
+		_, err := io.Copy(io.Discard, reader)
+
+		return minio.UploadInfo{}, err
 	}
 
 	err := svc.UploadFileV1(mockStream)
@@ -182,7 +184,10 @@ func TestUploadFile_Success(t *testing.T) {
 
 	// Verify the binary was stored
 	userID := testutils.GetUserIDFromContext(ctx)
-	binaries, err := storage.GetBinaries(t.Context(), userID)
+	binaries, err := storage.GetBinaries(t.Context(), db.GetBinaryEntriesByUserIDParams{
+		UserID: pgtype.UUID{Bytes: uuid.MustParse(userID), Valid: true},
+		Limit:  utils.DefaultPageSize,
+	})
 	require.NoError(t, err)
 	require.Len(t, binaries, 1)
 	require.Equal(t, "test.txt", binaries[0].FileName)
@@ -243,7 +248,7 @@ func TestDownloadFile_Success(t *testing.T) {
 		FileName: "test.txt",
 		FileSize: 123,
 		FileUrl:  userID + "-test.txt",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	user, err := storage.GetUserByID(ctx, pgtype.UUID{Bytes: uuid.MustParse(userID), Valid: true})
@@ -267,7 +272,7 @@ func TestDownloadFile_Success(t *testing.T) {
 
 	// Create an encryptor pipe to encrypt the data exactly like the service would
 	pr, pw := io.Pipe()
-	encryptor, err := utils.NewEncryptor(pw, userKey)
+	encryptor, err := utils.NewBoundEncryptor(pw, userKey, utils.Bind(binary.UserID, binary.ID, utils.FieldFile))
 	require.NoError(t, err)
 
 	// Write data to encryptor in a goroutine
@@ -353,7 +358,7 @@ func TestDeleteFile_Success(t *testing.T) {
 		FileName: "test.txt",
 		FileSize: 123,
 		FileUrl:  userID + "-test.txt",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	mockS3.RemoveObjectFunc = func(_ context.Context, _ string, _ string, _ minio.RemoveObjectOptions) error {
@@ -381,7 +386,7 @@ func TestGetFile_Success(t *testing.T) {
 		FileName: "test.txt",
 		FileSize: 123,
 		FileUrl:  userID + "-test.txt",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	resp, err := svc.GetFileV1(ctx, &pb.GetFileV1Request{FileId: binary.ID.String()})
@@ -404,7 +409,7 @@ func TestGetFiles_Success(t *testing.T) {
 		FileName: "test1.txt",
 		FileSize: 123,
 		FileUrl:  userID + "-test1.txt",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	_, err = storage.StoreBinary(ctx, db.StoreBinaryEntryParams{
@@ -412,11 +417,13 @@ func TestGetFiles_Success(t *testing.T) {
 		FileName: "test2.txt",
 		FileSize: 456,
 		FileUrl:  userID + "-test2.txt",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
-	_, err = svc.GetFilesV1(ctx, &pb.GetFilesV1Request{})
+	resp, err := svc.GetFilesV1(ctx, &pb.GetFilesV1Request{})
 	require.NoError(t, err)
+	require.Len(t, resp.GetFiles(), 2)
+	require.Equal(t, int32(2), resp.GetTotalCount())
 }
 
 func TestUploadFile_EmptyFile(t *testing.T) {
@@ -573,7 +580,7 @@ func TestDownloadFile_DecryptionError(t *testing.T) {
 		FileName: "test.txt",
 		FileSize: 123,
 		FileUrl:  userID + "-test.txt",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	// Generate test data - 3 blocks of 1024 random bytes each
@@ -614,6 +621,73 @@ func TestDownloadFile_DecryptionError(t *testing.T) {
 	require.Equal(t, codes.Internal, status.Code(err))
 }
 
+func TestDownloadFile_TruncatedFile(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, mockS3, ctx, masterKey := setupFileService(t)
+
+	userID := testutils.GetUserIDFromContext(ctx)
+	binary, err := storage.StoreBinary(ctx, db.StoreBinaryEntryParams{
+		UserID:   pgtype.UUID{Bytes: uuid.MustParse(userID), Valid: true},
+		FileName: "test.txt",
+		FileSize: 123,
+		FileUrl:  userID + "-test.txt",
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	user, err := storage.GetUserByID(ctx, pgtype.UUID{Bytes: uuid.MustParse(userID), Valid: true})
+	require.NoError(t, err)
+
+	userKey, err := utils.Decrypt(user.EncryptionKey, masterKey)
+	require.NoError(t, err)
+
+	// Encrypt two full chunks and a short final one, like the service does
+	content := make([]byte, 2*utils.StreamChunkSize+100)
+	_, err = rand.Read(content)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	//nolint:gosec
+	encryptor, err := utils.NewStreamEncryptor(&buf, userKey, uint32(binary.KeyVersion),
+		utils.Bind(binary.UserID, binary.ID, utils.FieldFile))
+	require.NoError(t, err)
+	_, err = encryptor.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, encryptor.Close())
+
+	download := func(encrypted []byte) ([]byte, error) {
+		mockS3.GetObjectFunc = func(_ context.Context, _ string, _ string, _ minio.GetObjectOptions) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(encrypted)), nil
+		}
+
+		var receivedData []byte
+
+		mockStream := &MockDownloadStream{
+			SendFunc: func(resp *pb.DownloadFileV1Response) error {
+				receivedData = append(receivedData, resp.GetData()...)
+
+				return nil
+			},
+			ContextFunc: func() context.Context {
+				return ctx
+			},
+		}
+
+		err := svc.DownloadFileV1(&pb.DownloadFileV1Request{FileId: binary.ID.String()}, mockStream)
+
+		return receivedData, err
+	}
+
+	received, err := download(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, content, received)
+
+	// A file cut off after a chunk boundary is rejected instead of streamed as complete
+	_, err = download(buf.Bytes()[:buf.Len()-100-16])
+	require.Error(t, err)
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
 func TestDownloadFile_UnauthorizedAccess(t *testing.T) {
 	t.Parallel()
 
@@ -626,7 +700,7 @@ func TestDownloadFile_UnauthorizedAccess(t *testing.T) {
 		FileName: "test.txt",
 		FileSize: 123,
 		FileUrl:  otherUserID.String() + "-test.txt",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	mockStream := &MockDownloadStream{
@@ -661,7 +735,7 @@ func TestDeleteFile_Unauthorized(t *testing.T) {
 		FileName: "test.txt",
 		FileSize: 123,
 		FileUrl:  otherUserID.String() + "-test.txt",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	resp, err := svc.DeleteFileV1(ctx, &pb.DeleteFileV1Request{FileId: binary.ID.String()})
@@ -681,7 +755,7 @@ func TestDeleteFile_S3DeleteFailure(t *testing.T) {
 		FileName: "test.txt",
 		FileSize: 123,
 		FileUrl:  userID + "-test.txt",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	storage.CallError = errors.New("error deleting file")
@@ -713,7 +787,7 @@ func TestGetFile_Unauthorized(t *testing.T) {
 		FileName: "test.txt",
 		FileSize: 123,
 		FileUrl:  otherUserID.String() + "-test.txt",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	resp, err := svc.GetFileV1(ctx, &pb.GetFileV1Request{FileId: binary.ID.String()})
@@ -729,6 +803,8 @@ func TestGetFiles_EmptyResult(t *testing.T) {
 	resp, err := svc.GetFilesV1(ctx, &pb.GetFilesV1Request{})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
+	require.Empty(t, resp.GetFiles())
+	require.Zero(t, resp.GetTotalCount())
 }
 
 func TestGetFiles_WithPagination(t *testing.T) {
@@ -744,13 +820,35 @@ func TestGetFiles_WithPagination(t *testing.T) {
 			FileName: "test.txt",
 			FileSize: 123,
 			FileUrl:  userID + "-test.txt",
-		})
+		}, pgtype.UUID{})
 		require.NoError(t, err)
 	}
 
-	resp, err := svc.GetFilesV1(ctx, &pb.GetFilesV1Request{})
+	resp, err := svc.GetFilesV1(ctx, &pb.GetFilesV1Request{Page: 2, PageSize: 4})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
+	require.Len(t, resp.GetFiles(), 4)
+	require.Equal(t, int32(10), resp.GetTotalCount())
+
+	resp, err = svc.GetFilesV1(ctx, &pb.GetFilesV1Request{Page: 3, PageSize: 4})
+	require.NoError(t, err)
+	require.Len(t, resp.GetFiles(), 2)
+
+	for _, meta := range resp.GetFiles() {
+		require.NotEmpty(t, meta.GetId())
+		require.Equal(t, "test.txt", meta.GetFileName())
+		require.NotNil(t, meta.GetLastUpdate())
+	}
+}
+
+func TestGetFiles_InvalidPageSize(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _, ctx, _ := setupFileService(t)
+
+	_, err := svc.GetFilesV1(ctx, &pb.GetFilesV1Request{PageSize: 101})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error validating input")
 }
 
 type MockDownloadStream struct {
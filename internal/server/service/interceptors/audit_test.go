@@ -0,0 +1,162 @@
+//nolint:wrapcheck,exhaustruct
+package interceptors_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb_file "github.com/npavlov/go-password-manager/gen/proto/file"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/server/auditlog"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/interceptors"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+// auditedCall runs a call through AuditInterceptor and TokenInterceptor, like the server chains them.
+func auditedCall(
+	audit, token grpc.UnaryServerInterceptor,
+	ctx context.Context,
+	method string,
+	req any,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	return audit(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return token(ctx, req, info, handler)
+	})
+}
+
+func TestAuditInterceptor(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	vault := newScopedVault(t)
+	keys := testutils.NewTokenKeys(t)
+	userID := vault.userID.String()
+
+	accessToken, err := keys.Sign(userID, time.Now(), time.Hour)
+	require.NoError(t, err)
+
+	memStorage := &MockMemStorage{}
+	memStorage.On("Get", mock.Anything, accessToken).Return(userID, nil)
+
+	audit := interceptors.AuditInterceptor(&logger, auditlog.NewRecorder(vault.storage))
+	token := interceptors.TokenInterceptor(&logger, keys, vault.apiTokens, newClientCerts(t), memStorage)
+
+	ctx := peer.NewContext(t.Context(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 7), Port: 4242}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", accessToken, "user-agent", "cli/1.0"))
+
+	// The item of a request
+	_, err = auditedCall(audit, token, ctx, pb_password.PasswordService_GetPasswordV1_FullMethodName,
+		&pb_password.GetPasswordV1Request{PasswordId: vault.tagged},
+		func(_ context.Context, _ any) (any, error) {
+			return &pb_password.GetPasswordV1Response{}, nil
+		})
+	require.NoError(t, err)
+
+	// The item a call created
+	_, err = auditedCall(audit, token, ctx, pb_password.PasswordService_StorePasswordV1_FullMethodName,
+		&pb_password.StorePasswordV1Request{},
+		func(_ context.Context, _ any) (any, error) {
+			return &pb_password.StorePasswordV1Response{PasswordId: vault.untagged}, nil
+		})
+	require.NoError(t, err)
+
+	// Calls refused by the scope of an API token name their user too
+	readOnly := vault.token(t, db.CreateAPITokenParams{ReadOnly: true})
+
+	_, err = auditedCall(audit, token, withToken(t.Context(), readOnly),
+		pb_password.PasswordService_DeletePasswordV1_FullMethodName,
+		&pb_password.DeletePasswordV1Request{PasswordId: vault.tagged},
+		func(_ context.Context, _ any) (any, error) {
+			t.Error("handler must not run")
+
+			//nolint:nilnil
+			return nil, nil
+		})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	events, err := vault.storage.ListAuditEvents(t.Context(), db.ListAuditEventsParams{
+		UserID: vault.userID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	// Newest first
+	require.Equal(t, pb_password.PasswordService_DeletePasswordV1_FullMethodName, events[0].Action)
+	require.Equal(t, "PermissionDenied", events[0].Result)
+	require.Equal(t, vault.tagged, events[0].ItemID.String())
+
+	require.Equal(t, vault.untagged, events[1].ItemID.String())
+	require.Equal(t, "OK", events[1].Result)
+
+	require.Equal(t, vault.tagged, events[2].ItemID.String())
+	require.Equal(t, "10.0.0.7", events[2].Ip)
+	require.Equal(t, "cli/1.0", events[2].UserAgent)
+
+	// Calls without credentials are recorded without an actor
+	_, err = auditedCall(audit, token, t.Context(), pb_password.PasswordService_GetPasswordsV1_FullMethodName,
+		&pb_password.GetPasswordsV1Request{}, func(_ context.Context, _ any) (any, error) {
+			t.Error("handler must not run")
+
+			//nolint:nilnil
+			return nil, nil
+		})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	last, err := vault.storage.GetLastAuditEvent(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(4), last.Seq)
+	require.False(t, last.ActorID.Valid)
+	require.Equal(t, "Unauthenticated", last.Result)
+}
+
+func TestStreamAuditInterceptor(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	vault := newScopedVault(t)
+	apiToken := vault.token(t, db.CreateAPITokenParams{})
+
+	audit := interceptors.StreamAuditInterceptor(&logger, auditlog.NewRecorder(vault.storage))
+	token := interceptors.StreamTokenInterceptor(&logger, testutils.NewTokenKeys(t), vault.apiTokens,
+		newClientCerts(t), &MockMemStorage{})
+	info := &grpc.StreamServerInfo{FullMethod: pb_file.FileService_DownloadFileV1_FullMethodName}
+
+	handler := func(_ any, stream grpc.ServerStream) error {
+		return stream.RecvMsg(&pb_file.DownloadFileV1Request{})
+	}
+
+	fileID := uuid.NewString()
+	stream := &recordingStream{
+		ctx: withToken(t.Context(), apiToken),
+		req: &pb_file.DownloadFileV1Request{FileId: fileID},
+	}
+
+	err := audit(nil, stream, info, func(srv any, stream grpc.ServerStream) error {
+		return token(srv, stream, info, handler)
+	})
+	require.NoError(t, err)
+
+	last, err := vault.storage.GetLastAuditEvent(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, vault.userID, last.ActorID)
+	require.Equal(t, fileID, last.ItemID.String())
+	require.Equal(t, pb_file.FileService_DownloadFileV1_FullMethodName, last.Action)
+	require.Equal(t, "OK", last.Result)
+}
@@ -11,12 +11,36 @@ import (
 	"google.golang.org/grpc/status"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_send "github.com/npavlov/go-password-manager/gen/proto/send"
+	"github.com/npavlov/go-password-manager/internal/server/apitoken"
+	"github.com/npavlov/go-password-manager/internal/server/clientcert"
 	"github.com/npavlov/go-password-manager/internal/server/redis"
-	"github.com/npavlov/go-password-manager/internal/server/service/utils"
 )
 
-// TokenInterceptor extracts a token from metadata and injects it into the context.
-func TokenInterceptor(log *zerolog.Logger, jwtSecret string, memSt redis.MemStorage) grpc.UnaryServerInterceptor {
+// TokenVerifier checks an access token and returns its user ID, see jwtkeys.Keys.
+type TokenVerifier interface {
+	Verify(token string) (string, error)
+}
+
+// APITokenResolver looks up personal access tokens, see apitoken.Resolver.
+type APITokenResolver interface {
+	Resolve(ctx context.Context, token string) (string, *apitoken.Scope, error)
+}
+
+// ClientCertResolver maps the client certificate of a call to a user, see clientcert.Resolver.
+type ClientCertResolver interface {
+	Resolve(ctx context.Context) (string, error)
+}
+
+// TokenInterceptor extracts a token from metadata and injects it into the context. Calls made with
+// an API token are held to its scope. Calls without a token may authenticate with a client certificate.
+func TokenInterceptor(
+	log *zerolog.Logger,
+	verifier TokenVerifier,
+	apiTokens APITokenResolver,
+	clientCerts ClientCertResolver,
+	memSt redis.MemStorage,
+) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req interface{},
@@ -28,6 +52,9 @@ func TokenInterceptor(log *zerolog.Logger, jwtSecret string, memSt redis.MemStor
 			pb.AuthService_RegisterV1_FullMethodName:     true,
 			pb.AuthService_LoginV1_FullMethodName:        true,
 			pb.AuthService_RefreshTokenV1_FullMethodName: true,
+			pb.AuthService_VerifyTOTPV1_FullMethodName:   true,
+			// Recipients of a send open it with the key of its link, without an account
+			pb_send.SendService_AccessSendV1_FullMethodName: true,
 		}
 
 		// Skip authentication for specified methods
@@ -37,19 +64,39 @@ func TokenInterceptor(log *zerolog.Logger, jwtSecret string, memSt redis.MemStor
 			return handler(ctx, req)
 		}
 
-		userID, err := AuthenticateToken(ctx, jwtSecret, memSt)
+		userID, scope, err := AuthenticateToken(ctx, verifier, apiTokens, clientCerts, memSt)
 		if err != nil {
 			log.Info().Str("method", info.FullMethod).Msg("authentication failed")
 
 			return nil, errors.Wrap(err, "authenticating token")
 		}
 
+		setAuditActor(ctx, userID)
+
 		//nolint:revive,staticcheck
 		ctx = context.WithValue(ctx, "user_id", userID)
 
 		log.Info().Str("method", info.FullMethod).Msg("user_id extracted and added to context")
 
-		return handler(ctx, req)
+		if scope == nil {
+			return handler(ctx, req)
+		}
+
+		rule, err := authorizeScope(info.FullMethod, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := rule.checkRequest(req, scope); err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		if err == nil && rule.filter != nil {
+			rule.filter(resp, scope)
+		}
+
+		return resp, err
 	}
 }
 
@@ -65,12 +112,14 @@ func (w *wrappedStream) Context() context.Context {
 }
 
 func StreamTokenInterceptor(logger *zerolog.Logger,
-	jwtSecret string,
+	verifier TokenVerifier,
+	apiTokens APITokenResolver,
+	clientCerts ClientCertResolver,
 	memStorage redis.MemStorage,
 ) grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		// Skip token authentication if the request is for reflection
-		if info.FullMethod == "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo" {
+		if info.FullMethod == reflectionMethod {
 			return handler(srv, stream)
 		}
 
@@ -78,49 +127,90 @@ func StreamTokenInterceptor(logger *zerolog.Logger,
 		ctx := stream.Context()
 
 		// Authenticate token
-		userID, err := AuthenticateToken(ctx, jwtSecret, memStorage)
+		userID, scope, err := AuthenticateToken(ctx, verifier, apiTokens, clientCerts, memStorage)
 		if err != nil {
 			logger.Error().Err(err).Msg("Unauthorized stream request")
 
 			return status.Error(codes.Unauthenticated, "invalid token")
 		}
 
+		setAuditActor(ctx, userID)
+
 		// Add user ID to context
 		//nolint:revive,staticcheck
 		ctx = context.WithValue(ctx, "user_id", userID)
 
 		// Wrap the original stream with the new context
-		wrapped := &wrappedStream{ServerStream: stream, ctx: ctx}
+		var wrapped grpc.ServerStream = &wrappedStream{ServerStream: stream, ctx: ctx}
+
+		if scope != nil {
+			rule, err := authorizeScope(info.FullMethod, scope)
+			if err != nil {
+				return err
+			}
+
+			wrapped = &scopedStream{ServerStream: wrapped, rule: rule, scope: scope}
+		}
 
 		// Pass the modified stream to the handler
 		return handler(srv, wrapped)
 	}
 }
 
-func AuthenticateToken(ctx context.Context, jwtSecret string, memStorage redis.MemStorage) (string, error) {
+// AuthenticateToken returns the user of the call. Calls made with an API token also get its scope,
+// which is nil for access tokens and client certificates.
+func AuthenticateToken(
+	ctx context.Context,
+	verifier TokenVerifier,
+	apiTokens APITokenResolver,
+	clientCerts ClientCertResolver,
+	memStorage redis.MemStorage,
+) (string, *apitoken.Scope, error) {
 	// Extract token from metadata
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return "", status.Errorf(codes.Unauthenticated, "missing metadata")
+	var tokens []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		tokens = md.Get("authorization")
 	}
 
-	tokens := md.Get("authorization")
-	if len(tokens) == 0 {
-		return "", status.Errorf(codes.Unauthenticated, "missing authorization token")
+	if len(tokens) == 0 || tokens[0] == "" {
+		return authenticateClientCert(ctx, clientCerts)
 	}
 
 	tokenString := tokens[0]
 
-	userID, err := utils.ValidateJWT(tokenString, jwtSecret)
+	if apitoken.IsToken(tokenString) {
+		userID, scope, err := apiTokens.Resolve(ctx, tokenString)
+		if err != nil {
+			return "", nil, status.Errorf(codes.Unauthenticated, "invalid token")
+		}
+
+		return userID, scope, nil
+	}
+
+	userID, err := verifier.Verify(tokenString)
 	if err != nil {
-		return "", status.Errorf(codes.Unauthenticated, "invalid token")
+		return "", nil, status.Errorf(codes.Unauthenticated, "invalid token")
 	}
 
 	// Check if the token exists in Redis and match with User ID
 	result, err := memStorage.Get(ctx, tokenString)
 	if result != userID || err != nil {
-		return "", status.Errorf(codes.Unauthenticated, "invalid token")
+		return "", nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+
+	return userID, nil, nil
+}
+
+// authenticateClientCert returns the user the client certificate of a call without a token is mapped to.
+func authenticateClientCert(ctx context.Context, clientCerts ClientCertResolver) (string, *apitoken.Scope, error) {
+	userID, err := clientCerts.Resolve(ctx)
+	if errors.Is(err, clientcert.ErrNoCertificate) {
+		return "", nil, status.Errorf(codes.Unauthenticated, "missing authorization token")
+	}
+
+	if err != nil {
+		return "", nil, status.Errorf(codes.Unauthenticated, "client certificate is not accepted")
 	}
 
-	return userID, nil
+	return userID, nil, nil
 }
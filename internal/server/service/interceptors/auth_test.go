@@ -16,8 +16,9 @@ import (
 	"google.golang.org/grpc/status"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_send "github.com/npavlov/go-password-manager/gen/proto/send"
 	"github.com/npavlov/go-password-manager/internal/server/service/interceptors"
-	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
 )
 
 type MockMemStorage struct {
@@ -36,13 +37,25 @@ func (m *MockMemStorage) Set(ctx context.Context, key string, value string, expi
 	return args.Error(0)
 }
 
+func (m *MockMemStorage) GetDel(ctx context.Context, key string) (string, error) {
+	args := m.Called(ctx, key)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMemStorage) Del(ctx context.Context, keys ...string) error {
+	args := m.Called(ctx, keys)
+
+	return args.Error(0)
+}
+
 func TestTokenInterceptor(t *testing.T) {
 	t.Parallel()
 
 	logger := zerolog.New(nil)
-	jwtSecret := "test-secret1"
+	keys := testutils.NewTokenKeys(t)
 	validUserID := "user-123"
-	validToken, _ := utils.GenerateJWT("user-123", jwtSecret, time.Now().Add(time.Hour).Unix())
+	validToken, _ := keys.Sign("user-123", time.Now(), time.Hour)
 	invalidToken := "invalid.token"
 
 	tests := []struct {
@@ -69,6 +82,21 @@ func TestTokenInterceptor(t *testing.T) {
 			mockSetup:     func(_ *MockMemStorage) {},
 			expectedError: false,
 		},
+		{
+			name:          "skip send access",
+			method:        pb_send.SendService_AccessSendV1_FullMethodName,
+			token:         "",
+			mockSetup:     func(_ *MockMemStorage) {},
+			expectedError: false,
+		},
+		{
+			name:          "send needs a login",
+			method:        pb_send.SendService_CreateSendV1_FullMethodName,
+			token:         "",
+			mockSetup:     func(_ *MockMemStorage) {},
+			expectedError: true,
+			expectedCode:  codes.Unauthenticated,
+		},
 		{
 			name:          "missing metadata",
 			method:        "/service.privateMethod",
@@ -96,7 +124,8 @@ func TestTokenInterceptor(t *testing.T) {
 			mockStorage := &MockMemStorage{}
 			tt.mockSetup(mockStorage)
 
-			interceptor := interceptors.TokenInterceptor(&logger, jwtSecret, mockStorage)
+			interceptor := interceptors.TokenInterceptor(&logger, keys, newAPITokens(t), newClientCerts(t),
+				mockStorage)
 
 			// Create test context with or without token
 			ctx := t.Context()
@@ -149,8 +178,8 @@ func TestStreamTokenInterceptor(t *testing.T) {
 	t.Parallel()
 
 	logger := zerolog.New(nil)
-	jwtSecret := "test-secret"
-	validToken, _ := utils.GenerateJWT("user-123", jwtSecret, time.Now().Add(time.Hour).Unix())
+	keys := testutils.NewTokenKeys(t)
+	validToken, _ := keys.Sign("user-123", time.Now(), time.Hour)
 	validUserID := "user-123"
 
 	tests := []struct {
@@ -194,7 +223,8 @@ func TestStreamTokenInterceptor(t *testing.T) {
 			mockStorage := &MockMemStorage{}
 			tt.mockSetup(mockStorage)
 
-			interceptor := interceptors.StreamTokenInterceptor(&logger, jwtSecret, mockStorage)
+			interceptor := interceptors.StreamTokenInterceptor(&logger, keys, newAPITokens(t), newClientCerts(t),
+				mockStorage)
 
 			// Create test context with or without token
 			ctx := t.Context()
@@ -258,8 +288,10 @@ func (m *mockServerStream) Context() context.Context {
 func TestAuthenticateToken(t *testing.T) {
 	t.Parallel()
 
-	jwtSecret := "test-secret"
-	validToken, _ := utils.GenerateJWT("user-123", jwtSecret, time.Now().Add(time.Hour).Unix())
+	keys := testutils.NewTokenKeys(t)
+	validToken, _ := keys.Sign("user-123", time.Now(), time.Hour)
+	// Signed by the keys of another deployment
+	foreignToken, _ := testutils.NewTokenKeys(t).Sign("user-123", time.Now(), time.Hour)
 
 	tests := []struct {
 		name          string
@@ -290,6 +322,15 @@ func TestAuthenticateToken(t *testing.T) {
 			expectedError: true,
 			expectedCode:  codes.Unauthenticated,
 		},
+		{
+			name:  "unknown signing key",
+			token: foreignToken,
+			mockSetup: func(m *MockMemStorage) {
+				m.On("Get", mock.Anything, foreignToken).Return("user-123", nil).Maybe()
+			},
+			expectedError: true,
+			expectedCode:  codes.Unauthenticated,
+		},
 		{
 			name:  "token not in storage",
 			token: validToken,
@@ -328,7 +369,8 @@ func TestAuthenticateToken(t *testing.T) {
 			}
 
 			// Call authenticateToken
-			_, err := interceptors.AuthenticateToken(ctx, jwtSecret, mockStorage)
+			_, scope, err := interceptors.AuthenticateToken(ctx, keys, newAPITokens(t), newClientCerts(t),
+				mockStorage)
 
 			if tt.expectedError {
 				require.Error(t, err)
@@ -337,6 +379,7 @@ func TestAuthenticateToken(t *testing.T) {
 				}
 			} else {
 				require.NoError(t, err)
+				require.Nil(t, scope)
 			}
 
 			mockStorage.AssertExpectations(t)
@@ -0,0 +1,92 @@
+//nolint:exhaustruct
+package interceptors_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/server/clientcert"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/interceptors"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+// newClientCerts returns a resolver that maps no client certificates.
+func newClientCerts(t *testing.T) *clientcert.Resolver {
+	t.Helper()
+
+	logger := zerolog.Nop()
+
+	resolver, err := clientcert.NewResolver(testutils.NewMockDBStorage(&logger, ""), clientcert.Options{}, &logger)
+	require.NoError(t, err)
+
+	return resolver
+}
+
+// withClientCert returns a context of a call made by a client with the verified certificate.
+func withClientCert(ctx context.Context, cert *x509.Certificate) context.Context {
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestTokenInterceptor_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	storage := testutils.NewMockDBStorage(&logger, "")
+
+	user, err := storage.RegisterUser(t.Context(), db.CreateUserParams{Username: "ci-bot"})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "identities.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"spiffe://example.org/ci": "ci-bot"}`), 0o600))
+
+	clientCerts, err := clientcert.NewResolver(storage, clientcert.Options{IdentitiesFile: path}, &logger)
+	require.NoError(t, err)
+
+	ca := testutils.NewTestCA(t)
+	mapped := ca.Issue(t, "ci", "spiffe://example.org/ci").Leaf
+	unmapped := ca.Issue(t, "ci", "spiffe://example.org/other").Leaf
+
+	interceptor := interceptors.TokenInterceptor(&logger, testutils.NewTokenKeys(t), newAPITokens(t), clientCerts,
+		&MockMemStorage{})
+	info := &grpc.UnaryServerInfo{FullMethod: pb_password.PasswordService_GetPasswordsV1_FullMethodName}
+
+	handler := func(ctx context.Context, _ any) (any, error) {
+		require.Equal(t, user.ID.String(), ctx.Value("user_id"))
+
+		return "success", nil
+	}
+
+	resp, err := interceptor(withClientCert(t.Context(), mapped), nil, info, handler)
+	require.NoError(t, err)
+	require.Equal(t, "success", resp)
+
+	// An empty authorization header counts as none
+	ctx := metadata.NewIncomingContext(withClientCert(t.Context(), mapped), metadata.Pairs("authorization", ""))
+	_, err = interceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+
+	_, err = interceptor(withClientCert(t.Context(), unmapped), nil, info, handler)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// A token, even an invalid one, wins over the certificate
+	ctx = metadata.NewIncomingContext(withClientCert(t.Context(), mapped), metadata.Pairs("authorization", "bad"))
+	_, err = interceptor(ctx, nil, info, handler)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
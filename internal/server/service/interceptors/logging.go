@@ -21,10 +21,9 @@ func LoggingServerInterceptor(logger *zerolog.Logger) grpc.UnaryServerIntercepto
 		// Start time
 		start := time.Now()
 
-		// Log the request details before handling
+		// Log the request before handling; bodies hold secrets, so only the method is logged
 		logger.Info().
 			Str("method", info.FullMethod).
-			Interface("request", req).
 			Msg("gRPC Request received")
 
 		// Call the actual handler
@@ -0,0 +1,164 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+
+	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
+	pb_file "github.com/npavlov/go-password-manager/gen/proto/file"
+	pb_meta "github.com/npavlov/go-password-manager/gen/proto/metadata"
+	pb_note "github.com/npavlov/go-password-manager/gen/proto/note"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/server/authz"
+	"github.com/npavlov/go-password-manager/internal/server/redis"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+// mutatingMethods lists the RPCs that modify vault items.
+//
+//nolint:gochecknoglobals
+var mutatingMethods = map[string]bool{
+	pb_password.PasswordService_StorePasswordV1_FullMethodName:  true,
+	pb_password.PasswordService_UpdatePasswordV1_FullMethodName: true,
+	pb_password.PasswordService_DeletePasswordV1_FullMethodName: true,
+	pb_note.NoteService_StoreNoteV1_FullMethodName:              true,
+	pb_note.NoteService_DeleteNoteV1_FullMethodName:             true,
+	pb_card.CardService_StoreCardV1_FullMethodName:              true,
+	pb_card.CardService_UpdateCardV1_FullMethodName:             true,
+	pb_card.CardService_DeleteCardV1_FullMethodName:             true,
+	pb_file.FileService_UploadFileV1_FullMethodName:             true,
+	pb_file.FileService_DeleteFileV1_FullMethodName:             true,
+	pb_meta.MetadataService_AddMetaInfoV1_FullMethodName:        true,
+	pb_meta.MetadataService_RemoveMetaInfoV1_FullMethodName:     true,
+}
+
+// ChangeNotifyInterceptor announces successful item mutations on the Redis channel of the vault they
+// changed, so WatchItemsV1 streams on every replica pick them up. The vault is resolved before the call,
+// while a deleted item can still be found.
+func ChangeNotifyInterceptor(
+	log *zerolog.Logger,
+	pubSub redis.PubSub,
+	storage authz.Storage,
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !mutatingMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		vaultUUID, vaultErr := changedVault(ctx, storage, req)
+
+		resp, err := handler(ctx, req)
+		if err == nil {
+			notifyChange(ctx, log, pubSub, vaultUUID, vaultErr)
+		}
+
+		return resp, err
+	}
+}
+
+// StreamChangeNotifyInterceptor is the streaming counterpart of ChangeNotifyInterceptor. The vault is
+// resolved from the first message the client sends.
+func StreamChangeNotifyInterceptor(
+	log *zerolog.Logger,
+	pubSub redis.PubSub,
+	storage authz.Storage,
+) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !mutatingMethods[info.FullMethod] {
+			return handler(srv, stream)
+		}
+
+		notified := &notifiedStream{ServerStream: stream, storage: storage}
+
+		err := handler(srv, notified)
+		if err == nil {
+			if !notified.resolved {
+				notified.vaultUUID, notified.vaultErr = utils.GetUserID(stream.Context())
+			}
+
+			notifyChange(stream.Context(), log, pubSub, notified.vaultUUID, notified.vaultErr)
+		}
+
+		return err
+	}
+}
+
+// notifiedStream resolves the vault a stream changes from its first message.
+type notifiedStream struct {
+	grpc.ServerStream
+	storage   authz.Storage
+	resolved  bool
+	vaultUUID pgtype.UUID
+	vaultErr  error
+}
+
+func (s *notifiedStream) RecvMsg(msg any) error {
+	if err := s.ServerStream.RecvMsg(msg); err != nil {
+		//nolint:wrapcheck // io.EOF ends client streams and must reach the handler as is
+		return err
+	}
+
+	if !s.resolved {
+		s.resolved = true
+		s.vaultUUID, s.vaultErr = changedVault(s.Context(), s.storage, msg)
+	}
+
+	return nil
+}
+
+// changedVault returns the vault a mutating request changes: the organization vault of the collection a
+// new item goes into, the vault authz resolves an existing item to, or the caller's own.
+func changedVault(ctx context.Context, storage authz.Storage, req any) (pgtype.UUID, error) {
+	if collectionID := messageField(req, "collection_id"); collectionID != "" {
+		collection, err := authz.Collection(ctx, storage, collectionID, authz.Write)
+		if err != nil {
+			return pgtype.UUID{}, errors.Wrap(err, "error getting collection")
+		}
+
+		return collection.VaultID, nil
+	}
+
+	if itemID := messageItemID(req); itemID != "" {
+		vaultUUID, err := authz.ItemVault(ctx, storage, itemID, authz.Write)
+		if err != nil {
+			return pgtype.UUID{}, errors.Wrap(err, "error getting item vault")
+		}
+
+		return vaultUUID, nil
+	}
+
+	vaultUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, errors.Wrap(err, "error getting user id")
+	}
+
+	return vaultUUID, nil
+}
+
+// notifyChange never fails the RPC: the change is already committed and watchers resync periodically.
+func notifyChange(
+	ctx context.Context,
+	log *zerolog.Logger,
+	pubSub redis.PubSub,
+	vaultUUID pgtype.UUID,
+	vaultErr error,
+) {
+	if vaultErr != nil {
+		log.Error().Err(vaultErr).Msg("failed to resolve the changed vault")
+
+		return
+	}
+
+	if err := pubSub.Publish(ctx, redis.ItemChangesChannel(vaultUUID.String()), redis.ItemChangedMessage); err != nil {
+		log.Error().Err(err).Str("vault_id", vaultUUID.String()).Msg("failed to publish item change")
+	}
+}
@@ -0,0 +1,189 @@
+//nolint:exhaustruct
+package interceptors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb_file "github.com/npavlov/go-password-manager/gen/proto/file"
+	pb_note "github.com/npavlov/go-password-manager/gen/proto/note"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/redis"
+	"github.com/npavlov/go-password-manager/internal/server/service/interceptors"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+func TestChangeNotifyInterceptor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		method     string
+		handlerErr error
+		notified   bool
+	}{
+		{
+			name:     "successful mutation is announced",
+			method:   pb_password.PasswordService_UpdatePasswordV1_FullMethodName,
+			notified: true,
+		},
+		{
+			name:       "failed mutation is not announced",
+			method:     pb_password.PasswordService_UpdatePasswordV1_FullMethodName,
+			handlerErr: status.Error(codes.Aborted, "conflict"),
+		},
+		{
+			name:   "read is not announced",
+			method: pb_password.PasswordService_GetPasswordV1_FullMethodName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pubSub := testutils.NewMockRedis()
+			userID := uuid.New().String()
+			ctx := testutils.InjectUserToContext(t.Context(), userID)
+
+			messages, err := pubSub.Subscribe(ctx, redis.ItemChangesChannel(userID))
+			require.NoError(t, err)
+
+			storage := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+			interceptor := interceptors.ChangeNotifyInterceptor(testutils.GetTLogger(), pubSub, storage)
+			handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+				return "ok", tt.handlerErr
+			}
+
+			_, err = interceptor(ctx, "request", &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.handlerErr, err)
+			assert.Equal(t, tt.notified, len(messages) == 1)
+		})
+	}
+}
+
+func TestStreamChangeNotifyInterceptor(t *testing.T) {
+	t.Parallel()
+
+	pubSub := testutils.NewMockRedis()
+	userID := uuid.New().String()
+	ctx := testutils.InjectUserToContext(t.Context(), userID)
+
+	messages, err := pubSub.Subscribe(ctx, redis.ItemChangesChannel(userID))
+	require.NoError(t, err)
+
+	storage := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+	interceptor := interceptors.StreamChangeNotifyInterceptor(testutils.GetTLogger(), pubSub, storage)
+	info := &grpc.StreamServerInfo{FullMethod: pb_file.FileService_UploadFileV1_FullMethodName}
+
+	err = interceptor(nil, &mockServerStream{ctx: ctx}, info, func(_ interface{}, _ grpc.ServerStream) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, messages, 1)
+}
+
+// setupOrgVault creates an organization of the user with a collection holding a note, and returns the vault
+// of the organization, the collection and the note.
+func setupOrgVault(t *testing.T, storage *testutils.MockDBStorage, userID string) (string, string, string) {
+	t.Helper()
+
+	org, err := storage.CreateOrganization(t.Context(), db.CreateOrganizationParams{
+		Name:    "Acme",
+		OwnerID: gu.GetIDFromString(userID),
+	})
+	require.NoError(t, err)
+
+	collection, err := storage.CreateCollection(t.Context(), db.CreateCollectionParams{OrgID: org.ID, Name: "Servers"})
+	require.NoError(t, err)
+
+	note, err := storage.StoreNote(t.Context(), db.CreateNoteEntryParams{UserID: org.VaultID}, collection.ID)
+	require.NoError(t, err)
+
+	return org.VaultID.String(), collection.ID.String(), note.ID.String()
+}
+
+func TestChangeNotifyInterceptor_OrganizationVault(t *testing.T) {
+	t.Parallel()
+
+	pubSub := testutils.NewMockRedis()
+	storage := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+	userID := uuid.New().String()
+	ctx := testutils.InjectUserToContext(t.Context(), userID)
+
+	vaultID, collectionID, noteID := setupOrgVault(t, storage, userID)
+
+	own, err := pubSub.Subscribe(ctx, redis.ItemChangesChannel(userID))
+	require.NoError(t, err)
+
+	vault, err := pubSub.Subscribe(ctx, redis.ItemChangesChannel(vaultID))
+	require.NoError(t, err)
+
+	interceptor := interceptors.ChangeNotifyInterceptor(testutils.GetTLogger(), pubSub, storage)
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	// A new item of a collection, and a change of an item kept in the organization vault
+	requests := map[string]interface{}{
+		pb_note.NoteService_StoreNoteV1_FullMethodName:  &pb_note.StoreNoteV1Request{CollectionId: collectionID},
+		pb_note.NoteService_DeleteNoteV1_FullMethodName: &pb_note.DeleteNoteV1Request{NoteId: noteID},
+	}
+
+	for method, req := range requests {
+		_, err = interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, vault, len(requests))
+	assert.Empty(t, own)
+}
+
+func TestStreamChangeNotifyInterceptor_OrganizationVault(t *testing.T) {
+	t.Parallel()
+
+	pubSub := testutils.NewMockRedis()
+	storage := testutils.NewMockDBStorage(testutils.GetTLogger(), "")
+	userID := uuid.New().String()
+	ctx := testutils.InjectUserToContext(t.Context(), userID)
+
+	vaultID, collectionID, _ := setupOrgVault(t, storage, userID)
+
+	vault, err := pubSub.Subscribe(ctx, redis.ItemChangesChannel(vaultID))
+	require.NoError(t, err)
+
+	interceptor := interceptors.StreamChangeNotifyInterceptor(testutils.GetTLogger(), pubSub, storage)
+	info := &grpc.StreamServerInfo{FullMethod: pb_file.FileService_UploadFileV1_FullMethodName}
+	stream := &recvServerStream{
+		mockServerStream: mockServerStream{ctx: ctx},
+		msg:              &pb_file.UploadFileV1Request{CollectionId: collectionID},
+	}
+
+	err = interceptor(nil, stream, info, func(_ interface{}, stream grpc.ServerStream) error {
+		return stream.RecvMsg(&pb_file.UploadFileV1Request{})
+	})
+	require.NoError(t, err)
+	assert.Len(t, vault, 1)
+}
+
+// recvServerStream receives a single message.
+type recvServerStream struct {
+	mockServerStream
+	msg proto.Message
+}
+
+func (s *recvServerStream) RecvMsg(msg any) error {
+	proto.Merge(msg.(proto.Message), s.msg)
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package interceptors
+
+import (
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
+	pb_file "github.com/npavlov/go-password-manager/gen/proto/file"
+	pb_item "github.com/npavlov/go-password-manager/gen/proto/item"
+	pb_meta "github.com/npavlov/go-password-manager/gen/proto/metadata"
+	pb_note "github.com/npavlov/go-password-manager/gen/proto/note"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/server/apitoken"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// scopedMethod tells what an RPC reaches, so that calls made with API tokens can be held to their scope.
+type scopedMethod struct {
+	// write marks RPCs that change the vault.
+	write bool
+	// create marks RPCs that add an item. New items carry no tags, so tokens limited to tags may not call them.
+	create bool
+	// itemType is the type of the items the RPC works on; empty for RPCs over every type.
+	itemType db.ItemType
+	// itemIDs returns the resource IDs of the items the request names.
+	itemIDs func(req any) []string
+	// collectionID returns the collection of an organization the request adds an item to, if any.
+	collectionID func(req any) string
+	// filter drops the items out of scope from a response. It reports false when nothing is left to send.
+	filter func(resp any, scope *apitoken.Scope) bool
+}
+
+// scopedMethods lists the RPCs open to API tokens; the rest, such as managing sessions and the tokens
+// themselves, need a login.
+//
+//nolint:gochecknoglobals
+var scopedMethods = map[string]scopedMethod{
+	pb_auth.AuthService_GetVaultKeyV1_FullMethodName: {},
+
+	pb_password.PasswordService_StorePasswordV1_FullMethodName: {
+		write: true, create: true, itemType: db.ItemTypePassword,
+		collectionID: requestCollection((*pb_password.StorePasswordV1Request).GetCollectionId),
+	},
+	pb_password.PasswordService_GetPasswordV1_FullMethodName: {
+		itemType: db.ItemTypePassword, itemIDs: requestID((*pb_password.GetPasswordV1Request).GetPasswordId),
+	},
+	pb_password.PasswordService_GetPasswordsV1_FullMethodName: {
+		itemType: db.ItemTypePassword,
+		filter: listed((*pb_password.GetPasswordsV1Response).GetPasswords,
+			func(resp *pb_password.GetPasswordsV1Response, entries []*pb_password.PasswordEntry) {
+				resp.Passwords = entries
+			}, (*pb_password.PasswordEntry).GetId),
+	},
+	pb_password.PasswordService_UpdatePasswordV1_FullMethodName: {
+		write: true, itemType: db.ItemTypePassword,
+		itemIDs: requestID((*pb_password.UpdatePasswordV1Request).GetPasswordId),
+	},
+	pb_password.PasswordService_DeletePasswordV1_FullMethodName: {
+		write: true, itemType: db.ItemTypePassword,
+		itemIDs: requestID((*pb_password.DeletePasswordV1Request).GetPasswordId),
+	},
+
+	pb_note.NoteService_StoreNoteV1_FullMethodName: {
+		write: true, create: true, itemType: db.ItemTypeText,
+		collectionID: requestCollection((*pb_note.StoreNoteV1Request).GetCollectionId),
+	},
+	pb_note.NoteService_GetNoteV1_FullMethodName: {
+		itemType: db.ItemTypeText, itemIDs: requestID((*pb_note.GetNoteV1Request).GetNoteId),
+	},
+	pb_note.NoteService_GetNotesV1_FullMethodName: {
+		itemType: db.ItemTypeText,
+		filter: listed((*pb_note.GetNotesV1Response).GetNotes,
+			func(resp *pb_note.GetNotesV1Response, entries []*pb_note.NoteEntry) {
+				resp.Notes = entries
+			}, (*pb_note.NoteEntry).GetId),
+	},
+	pb_note.NoteService_DeleteNoteV1_FullMethodName: {
+		write: true, itemType: db.ItemTypeText, itemIDs: requestID((*pb_note.DeleteNoteV1Request).GetNoteId),
+	},
+
+	pb_card.CardService_StoreCardV1_FullMethodName: {
+		write: true, create: true, itemType: db.ItemTypeCard,
+		collectionID: requestCollection((*pb_card.StoreCardV1Request).GetCollectionId),
+	},
+	pb_card.CardService_GetCardV1_FullMethodName: {
+		itemType: db.ItemTypeCard, itemIDs: requestID((*pb_card.GetCardV1Request).GetCardId),
+	},
+	pb_card.CardService_GetCardsV1_FullMethodName: {
+		itemType: db.ItemTypeCard,
+		filter: listed((*pb_card.GetCardsV1Response).GetCards,
+			func(resp *pb_card.GetCardsV1Response, entries []*pb_card.CardEntry) {
+				resp.Cards = entries
+			}, (*pb_card.CardEntry).GetId),
+	},
+	pb_card.CardService_UpdateCardV1_FullMethodName: {
+		write: true, itemType: db.ItemTypeCard, itemIDs: requestID((*pb_card.UpdateCardV1Request).GetCardId),
+	},
+	pb_card.CardService_DeleteCardV1_FullMethodName: {
+		write: true, itemType: db.ItemTypeCard, itemIDs: requestID((*pb_card.DeleteCardV1Request).GetCardId),
+	},
+
+	pb_file.FileService_UploadFileV1_FullMethodName: {
+		write: true, create: true, itemType: db.ItemTypeBinary,
+		collectionID: requestCollection((*pb_file.UploadFileV1Request).GetCollectionId),
+	},
+	pb_file.FileService_GetFileV1_FullMethodName: {
+		itemType: db.ItemTypeBinary, itemIDs: requestID((*pb_file.GetFileV1Request).GetFileId),
+	},
+	pb_file.FileService_GetFilesV1_FullMethodName: {
+		itemType: db.ItemTypeBinary,
+		filter: listed((*pb_file.GetFilesV1Response).GetFiles,
+			func(resp *pb_file.GetFilesV1Response, entries []*pb_file.FileMeta) {
+				resp.Files = entries
+			}, (*pb_file.FileMeta).GetId),
+	},
+	pb_file.FileService_DownloadFileV1_FullMethodName: {
+		itemType: db.ItemTypeBinary, itemIDs: requestID((*pb_file.DownloadFileV1Request).GetFileId),
+	},
+	pb_file.FileService_DeleteFileV1_FullMethodName: {
+		write: true, itemType: db.ItemTypeBinary, itemIDs: requestID((*pb_file.DeleteFileV1Request).GetFileId),
+	},
+
+	pb_item.ItemService_GetItemsV1_FullMethodName: {
+		filter: listed((*pb_item.GetItemsV1Response).GetItems,
+			func(resp *pb_item.GetItemsV1Response, entries []*pb_item.ItemData) {
+				resp.Items = entries
+			}, (*pb_item.ItemData).GetId),
+	},
+	pb_item.ItemService_HydrateItemsV1_FullMethodName: {
+		itemIDs: requestIDs((*pb_item.HydrateItemsV1Request).GetItemIds),
+		filter: func(resp any, scope *apitoken.Scope) bool {
+			hydrated, ok := resp.(*pb_item.HydrateItemsV1Response)
+
+			return !ok || scope.AllowsItem(hydrated.GetItem().GetId())
+		},
+	},
+	pb_item.ItemService_GetChangesV1_FullMethodName: {
+		filter: listed((*pb_item.GetChangesV1Response).GetChanges,
+			func(resp *pb_item.GetChangesV1Response, entries []*pb_item.ItemChange) {
+				resp.Changes = entries
+			}, (*pb_item.ItemChange).GetItemId),
+	},
+	pb_item.ItemService_WatchItemsV1_FullMethodName: {
+		filter: listed((*pb_item.WatchItemsV1Response).GetChanges,
+			func(resp *pb_item.WatchItemsV1Response, entries []*pb_item.ItemChange) {
+				resp.Changes = entries
+			}, (*pb_item.ItemChange).GetItemId),
+	},
+
+	pb_meta.MetadataService_GetMetaInfoV1_FullMethodName: {
+		itemIDs: requestID((*pb_meta.GetMetaInfoV1Request).GetItemId),
+	},
+	pb_meta.MetadataService_AddMetaInfoV1_FullMethodName: {
+		write: true, itemIDs: requestID((*pb_meta.AddMetaInfoV1Request).GetItemId),
+	},
+	pb_meta.MetadataService_RemoveMetaInfoV1_FullMethodName: {
+		write: true, itemIDs: requestID((*pb_meta.RemoveMetaInfoV1Request).GetItemId),
+	},
+}
+
+// requestID returns the itemIDs of a request naming a single item.
+func requestID[R any](itemID func(R) string) func(req any) []string {
+	return func(req any) []string {
+		if typed, ok := req.(R); ok {
+			return []string{itemID(typed)}
+		}
+
+		return nil
+	}
+}
+
+// requestIDs returns the itemIDs of a request naming several items.
+func requestIDs[R any](itemIDs func(R) []string) func(req any) []string {
+	return func(req any) []string {
+		if typed, ok := req.(R); ok {
+			return itemIDs(typed)
+		}
+
+		return nil
+	}
+}
+
+// requestCollection returns the collectionID of a request that may add an item to a collection.
+func requestCollection[R any](collectionID func(R) string) func(req any) string {
+	return func(req any) string {
+		if typed, ok := req.(R); ok {
+			return collectionID(typed)
+		}
+
+		return ""
+	}
+}
+
+// listed returns the filter of a response listing items.
+func listed[R any, E any](
+	entries func(R) []E,
+	setEntries func(R, []E),
+	itemID func(E) string,
+) func(resp any, scope *apitoken.Scope) bool {
+	return func(resp any, scope *apitoken.Scope) bool {
+		if typed, ok := resp.(R); ok {
+			setEntries(typed, slices.DeleteFunc(entries(typed), func(entry E) bool {
+				return !scope.AllowsItem(itemID(entry))
+			}))
+		}
+
+		return true
+	}
+}
+
+// authorizeScope returns how the RPC is held to the scope of an API token, or why the token may not call it.
+func authorizeScope(method string, scope *apitoken.Scope) (scopedMethod, error) {
+	rule, ok := scopedMethods[method]
+
+	switch {
+	case !ok:
+		return rule, status.Error(codes.PermissionDenied, "method is not available to api tokens")
+	case rule.write && scope.ReadOnly:
+		return rule, status.Error(codes.PermissionDenied, "api token is read-only")
+	case rule.itemType != "" && !scope.AllowsType(rule.itemType):
+		return rule, status.Error(codes.PermissionDenied, "item type is out of the api token scope")
+	case rule.create && len(scope.Tags) > 0:
+		return rule, status.Error(codes.PermissionDenied, "api tokens limited to tags cannot create items")
+	}
+
+	return rule, nil
+}
+
+// checkRequest rejects requests naming items out of scope. Scopes limited to types or tags only reach
+// the items of the user, so such tokens cannot add items to the collections of an organization either.
+func (rule scopedMethod) checkRequest(req any, scope *apitoken.Scope) error {
+	if rule.collectionID != nil && scope.Restricted() && rule.collectionID(req) != "" {
+		return status.Error(codes.PermissionDenied, "collections are out of the api token scope")
+	}
+
+	if rule.itemIDs == nil {
+		return nil
+	}
+
+	for _, itemID := range rule.itemIDs(req) {
+		if !scope.AllowsItem(itemID) {
+			return status.Error(codes.PermissionDenied, "item is out of the api token scope")
+		}
+	}
+
+	return nil
+}
+
+// scopedStream holds a stream called with an API token to its scope.
+type scopedStream struct {
+	grpc.ServerStream
+	rule  scopedMethod
+	scope *apitoken.Scope
+}
+
+func (s *scopedStream) RecvMsg(msg any) error {
+	if err := s.ServerStream.RecvMsg(msg); err != nil {
+		//nolint:wrapcheck // io.EOF ends client streams and must reach the handler as is
+		return err
+	}
+
+	return s.rule.checkRequest(msg, s.scope)
+}
+
+func (s *scopedStream) SendMsg(msg any) error {
+	if s.rule.filter != nil && !s.rule.filter(msg, s.scope) {
+		return nil
+	}
+
+	//nolint:wrapcheck
+	return s.ServerStream.SendMsg(msg)
+}
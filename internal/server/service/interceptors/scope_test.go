@@ -0,0 +1,327 @@
+//nolint:wrapcheck,exhaustruct,forcetypeassert
+package interceptors_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
+	pb_item "github.com/npavlov/go-password-manager/gen/proto/item"
+	pb_note "github.com/npavlov/go-password-manager/gen/proto/note"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/server/apitoken"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/interceptors"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+// newAPITokens returns a resolver that knows no API tokens.
+func newAPITokens(t *testing.T) *apitoken.Resolver {
+	t.Helper()
+
+	logger := zerolog.Nop()
+
+	return apitoken.NewResolver(testutils.NewMockDBStorage(&logger, ""), &logger)
+}
+
+// scopedVault holds a user with a password tagged env=ci, an untagged password and a note tagged env=ci.
+type scopedVault struct {
+	storage   *testutils.MockDBStorage
+	apiTokens *apitoken.Resolver
+	userID    pgtype.UUID
+	tagged    string
+	untagged  string
+	note      string
+}
+
+func newScopedVault(t *testing.T) *scopedVault {
+	t.Helper()
+
+	logger := zerolog.Nop()
+	storage := testutils.NewMockDBStorage(&logger, "")
+	vault := &scopedVault{
+		storage:   storage,
+		apiTokens: apitoken.NewResolver(storage, &logger),
+		userID:    pgtype.UUID{Bytes: uuid.New(), Valid: true},
+	}
+
+	ctx := t.Context()
+
+	for _, target := range []*string{&vault.tagged, &vault.untagged} {
+		password, err := storage.StorePassword(ctx, db.CreatePasswordEntryParams{UserID: vault.userID}, pgtype.UUID{})
+		require.NoError(t, err)
+
+		*target = password.ID.String()
+	}
+
+	note, err := storage.StoreNote(ctx, db.CreateNoteEntryParams{UserID: vault.userID}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	vault.note = note.ID.String()
+
+	for _, itemID := range []string{vault.tagged, vault.note} {
+		_, err := storage.AddMeta(ctx, itemID, "env", "ci")
+		require.NoError(t, err)
+	}
+
+	return vault
+}
+
+// token creates an API token of the user and returns its secret.
+func (v *scopedVault) token(t *testing.T, params db.CreateAPITokenParams) string {
+	t.Helper()
+
+	secret, hash := apitoken.New()
+	params.UserID = v.userID
+	params.TokenHash = hash
+	params.Name = "ci-" + uuid.NewString()
+
+	if !params.ExpiresAt.Valid {
+		params.ExpiresAt = pgtype.Timestamp{Time: time.Now().Add(time.Hour), Valid: true}
+	}
+
+	_, err := v.storage.CreateAPIToken(t.Context(), params)
+	require.NoError(t, err)
+
+	return secret
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", token))
+}
+
+func TestTokenInterceptor_APITokenScope(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	vault := newScopedVault(t)
+	keys := testutils.NewTokenKeys(t)
+
+	readOnly := vault.token(t, db.CreateAPITokenParams{ReadOnly: true, Tags: apitoken.EncodeTags(nil)})
+	passwordsOnly := vault.token(t, db.CreateAPITokenParams{ItemTypes: []string{"password"}, Tags: []byte(`{}`)})
+	taggedOnly := vault.token(t, db.CreateAPITokenParams{Tags: apitoken.EncodeTags(map[string]string{"env": "ci"})})
+	expired := vault.token(t, db.CreateAPITokenParams{
+		Tags:      apitoken.EncodeTags(nil),
+		ExpiresAt: pgtype.Timestamp{Time: time.Now().Add(-time.Minute), Valid: true},
+	})
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		req    any
+		code   codes.Code
+	}{
+		{
+			name:   "read-only token reads",
+			token:  readOnly,
+			method: pb_password.PasswordService_GetPasswordV1_FullMethodName,
+			req:    &pb_password.GetPasswordV1Request{PasswordId: vault.untagged},
+			code:   codes.OK,
+		},
+		{
+			name:   "read-only token writes",
+			token:  readOnly,
+			method: pb_password.PasswordService_DeletePasswordV1_FullMethodName,
+			req:    &pb_password.DeletePasswordV1Request{PasswordId: vault.untagged},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "method closed to api tokens",
+			token:  readOnly,
+			method: pb_auth.AuthService_ListSessionsV1_FullMethodName,
+			req:    &pb_auth.ListSessionsV1Request{},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "item type out of scope",
+			token:  passwordsOnly,
+			method: pb_note.NoteService_GetNoteV1_FullMethodName,
+			req:    &pb_note.GetNoteV1Request{NoteId: vault.note},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "hydrating an item of another type",
+			token:  passwordsOnly,
+			method: pb_item.ItemService_HydrateItemsV1_FullMethodName,
+			req:    &pb_item.HydrateItemsV1Request{ItemIds: []string{vault.tagged, vault.note}},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "tagged item",
+			token:  taggedOnly,
+			method: pb_password.PasswordService_UpdatePasswordV1_FullMethodName,
+			req:    &pb_password.UpdatePasswordV1Request{PasswordId: vault.tagged},
+			code:   codes.OK,
+		},
+		{
+			name:   "untagged item",
+			token:  taggedOnly,
+			method: pb_password.PasswordService_GetPasswordV1_FullMethodName,
+			req:    &pb_password.GetPasswordV1Request{PasswordId: vault.untagged},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "token limited to tags creates an item",
+			token:  taggedOnly,
+			method: pb_password.PasswordService_StorePasswordV1_FullMethodName,
+			req:    &pb_password.StorePasswordV1Request{},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "token limited to types stores into a collection",
+			token:  passwordsOnly,
+			method: pb_password.PasswordService_StorePasswordV1_FullMethodName,
+			req:    &pb_password.StorePasswordV1Request{CollectionId: uuid.NewString()},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "token limited to types stores into the vault of the user",
+			token:  passwordsOnly,
+			method: pb_password.PasswordService_StorePasswordV1_FullMethodName,
+			req:    &pb_password.StorePasswordV1Request{},
+			code:   codes.OK,
+		},
+		{
+			name:   "expired token",
+			token:  expired,
+			method: pb_password.PasswordService_GetPasswordV1_FullMethodName,
+			req:    &pb_password.GetPasswordV1Request{PasswordId: vault.untagged},
+			code:   codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			interceptor := interceptors.TokenInterceptor(&logger, keys, vault.apiTokens, newClientCerts(t),
+				&MockMemStorage{})
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+
+			handler := func(ctx context.Context, _ any) (any, error) {
+				require.Equal(t, vault.userID.String(), ctx.Value("user_id"))
+
+				return "success", nil
+			}
+
+			_, err := interceptor(withToken(t.Context(), tt.token), tt.req, info, handler)
+			require.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestTokenInterceptor_FiltersResponses(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	vault := newScopedVault(t)
+	token := vault.token(t, db.CreateAPITokenParams{
+		ItemTypes: []string{"password"},
+		Tags:      apitoken.EncodeTags(map[string]string{"env": "ci"}),
+	})
+
+	interceptor := interceptors.TokenInterceptor(&logger, testutils.NewTokenKeys(t), vault.apiTokens,
+		newClientCerts(t), &MockMemStorage{})
+	info := &grpc.UnaryServerInfo{FullMethod: pb_item.ItemService_GetItemsV1_FullMethodName}
+
+	handler := func(_ context.Context, _ any) (any, error) {
+		return &pb_item.GetItemsV1Response{Items: []*pb_item.ItemData{
+			{Id: vault.tagged}, {Id: vault.untagged}, {Id: vault.note},
+		}}, nil
+	}
+
+	resp, err := interceptor(withToken(t.Context(), token), &pb_item.GetItemsV1Request{}, info, handler)
+	require.NoError(t, err)
+
+	items := resp.(*pb_item.GetItemsV1Response).GetItems()
+	require.Len(t, items, 1)
+	require.Equal(t, vault.tagged, items[0].GetId())
+
+	// The call is recorded as the last use of the token
+	tokens, err := vault.storage.ListAPITokens(t.Context(), vault.userID)
+	require.NoError(t, err)
+	require.True(t, tokens[0].LastUsedAt.Valid)
+}
+
+// recordingStream collects the messages the handler sends.
+//
+//nolint:containedctx
+type recordingStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	req  any
+	sent []any
+}
+
+func (s *recordingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *recordingStream) RecvMsg(msg any) error {
+	proto.Merge(msg.(proto.Message), s.req.(proto.Message))
+
+	return nil
+}
+
+func (s *recordingStream) SendMsg(msg any) error {
+	s.sent = append(s.sent, msg)
+
+	return nil
+}
+
+func TestStreamTokenInterceptor_APITokenScope(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	vault := newScopedVault(t)
+	token := vault.token(t, db.CreateAPITokenParams{Tags: apitoken.EncodeTags(map[string]string{"env": "ci"})})
+
+	interceptor := interceptors.StreamTokenInterceptor(&logger, testutils.NewTokenKeys(t), vault.apiTokens,
+		newClientCerts(t), &MockMemStorage{})
+	info := &grpc.StreamServerInfo{FullMethod: pb_item.ItemService_HydrateItemsV1_FullMethodName}
+
+	// Hydrates every changed item, like a request by changed_since
+	handler := func(_ any, stream grpc.ServerStream) error {
+		if err := stream.RecvMsg(&pb_item.HydrateItemsV1Request{}); err != nil {
+			return err
+		}
+
+		for _, itemID := range []string{vault.tagged, vault.untagged, vault.note} {
+			if err := stream.SendMsg(&pb_item.HydrateItemsV1Response{Item: &pb_item.ItemData{Id: itemID}}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	stream := &recordingStream{ctx: withToken(t.Context(), token), req: &pb_item.HydrateItemsV1Request{}}
+	require.NoError(t, interceptor(nil, stream, info, handler))
+	require.Len(t, stream.sent, 2)
+
+	// Naming an item out of scope fails the call
+	stream = &recordingStream{
+		ctx: withToken(t.Context(), token),
+		req: &pb_item.HydrateItemsV1Request{ItemIds: []string{vault.untagged}},
+	}
+	err := interceptor(nil, stream, info, handler)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Empty(t, stream.sent)
+
+	// Streams closed to api tokens fail before the handler runs
+	info = &grpc.StreamServerInfo{FullMethod: "/service.StreamMethod"}
+	err = interceptor(nil, &recordingStream{ctx: withToken(t.Context(), token)}, info, handler)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
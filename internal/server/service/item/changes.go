@@ -0,0 +1,84 @@
+//nolint:exhaustruct
+package item
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+// defaultChangesLimit is used when the client does not ask for a specific page size.
+const defaultChangesLimit int32 = 500
+
+// GetChangesV1 returns the latest change of every item modified after the requested cursor,
+// including tombstones of deleted items.
+func (is *Service) GetChangesV1(ctx context.Context, req *pb.GetChangesV1Request) (*pb.GetChangesV1Response, error) {
+	if err := is.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	limit := req.GetLimit()
+	if limit == 0 {
+		limit = defaultChangesLimit
+	}
+
+	// Fetch one extra row to find out whether another page follows.
+	rows, err := is.storage.GetItemChanges(ctx, db.GetItemChangesSinceParams{
+		UserID:     userUUID,
+		SinceSeq:   req.GetSinceCursor(),
+		MaxChanges: limit + 1,
+	})
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting changes")
+
+		return nil, errors.Wrap(err, "error getting changes")
+	}
+
+	hasMore := len(rows) > int(limit)
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	changes, nextCursor := toItemChanges(rows, req.GetSinceCursor())
+
+	return &pb.GetChangesV1Response{
+		Changes:    changes,
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+// toItemChanges converts change log rows and returns the cursor of the last one, or sinceCursor if there are none.
+func toItemChanges(rows []db.GetItemChangesSinceRow, sinceCursor int64) ([]*pb.ItemChange, int64) {
+	nextCursor := sinceCursor
+	changes := make([]*pb.ItemChange, len(rows))
+	for cursor, row := range rows {
+		op := pb.ChangeOp_CHANGE_OP_UPSERT
+		if row.Deleted {
+			op = pb.ChangeOp_CHANGE_OP_DELETE
+		}
+
+		changes[cursor] = &pb.ItemChange{
+			ItemId:    row.ItemID.String(),
+			Type:      utils.ToProtoItemType(row.Type),
+			Op:        op,
+			Cursor:    row.Seq,
+			ChangedAt: timestamppb.New(row.ChangedAt.Time),
+		}
+		nextCursor = row.Seq
+	}
+
+	return changes, nextCursor
+}
@@ -0,0 +1,105 @@
+//nolint:exhaustruct
+package item_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestGetChanges_InsertsUpdatesAndDeletes(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, encryptionKey := setupHydrateService(t)
+	password, note := storeHydrationFixtures(t, storage, ctx, encryptionKey)
+
+	resp, err := svc.GetChangesV1(ctx, &pb.GetChangesV1Request{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetChanges(), 2)
+	assert.False(t, resp.GetHasMore())
+
+	// Both items are reported once, with the metadata update folded into the password change.
+	assert.Equal(t, note.ID.String(), resp.GetChanges()[0].GetItemId())
+	assert.Equal(t, pb.ItemType_ITEM_TYPE_NOTE, resp.GetChanges()[0].GetType())
+	assert.Equal(t, password.ID.String(), resp.GetChanges()[1].GetItemId())
+	assert.Equal(t, pb.ChangeOp_CHANGE_OP_UPSERT, resp.GetChanges()[1].GetOp())
+	assert.Equal(t, resp.GetChanges()[1].GetCursor(), resp.GetNextCursor())
+
+	cursor := resp.GetNextCursor()
+
+	userUUID := pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true}
+	require.NoError(t, storage.DeleteNote(ctx, note.ID.String(), userUUID))
+
+	resp, err = svc.GetChangesV1(ctx, &pb.GetChangesV1Request{SinceCursor: cursor})
+	require.NoError(t, err)
+	require.Len(t, resp.GetChanges(), 1)
+	assert.Equal(t, note.ID.String(), resp.GetChanges()[0].GetItemId())
+	assert.Equal(t, pb.ChangeOp_CHANGE_OP_DELETE, resp.GetChanges()[0].GetOp())
+	assert.Greater(t, resp.GetNextCursor(), cursor)
+
+	// Nothing new after the latest cursor; the cursor stays put.
+	latest := resp.GetNextCursor()
+	resp, err = svc.GetChangesV1(ctx, &pb.GetChangesV1Request{SinceCursor: latest})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetChanges())
+	assert.Equal(t, latest, resp.GetNextCursor())
+}
+
+func TestGetChanges_Paging(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, encryptionKey := setupHydrateService(t)
+	storeHydrationFixtures(t, storage, ctx, encryptionKey)
+
+	resp, err := svc.GetChangesV1(ctx, &pb.GetChangesV1Request{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.GetChanges(), 1)
+	assert.True(t, resp.GetHasMore())
+
+	resp, err = svc.GetChangesV1(ctx, &pb.GetChangesV1Request{SinceCursor: resp.GetNextCursor(), Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.GetChanges(), 1)
+	assert.False(t, resp.GetHasMore())
+}
+
+func TestGetChanges_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	svc, _, ctx, _ := setupHydrateService(t)
+
+	_, err := svc.GetChangesV1(ctx, &pb.GetChangesV1Request{SinceCursor: -1})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error validating input")
+
+	_, err = svc.GetChangesV1(ctx, &pb.GetChangesV1Request{Limit: 501})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error validating input")
+}
+
+func TestGetChanges_StorageError(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, _ := setupHydrateService(t)
+	storage.CallError = errors.New("db down")
+
+	_, err := svc.GetChangesV1(ctx, &pb.GetChangesV1Request{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error getting changes")
+}
+
+func TestGetChanges_NoUserContext(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _, _ := setupHydrateService(t)
+
+	_, err := svc.GetChangesV1(t.Context(), &pb.GetChangesV1Request{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error getting user id")
+}
@@ -0,0 +1,298 @@
+//nolint:exhaustruct
+package item
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
+	pb_file "github.com/npavlov/go-password-manager/gen/proto/file"
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	pb_note "github.com/npavlov/go-password-manager/gen/proto/note"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// hydrateBatchSize limits how many items are loaded from the database per round trip.
+const hydrateBatchSize = 100
+
+// HydrateItemsV1 streams decrypted payloads for the requested items. Items are selected
+// either by explicit resource IDs or by their last update time.
+func (is *Service) HydrateItemsV1(
+	req *pb.HydrateItemsV1Request,
+	stream grpc.ServerStreamingServer[pb.HydrateItemsV1Response],
+) error {
+	ctx := stream.Context()
+
+	if err := is.validator.Validate(req); err != nil {
+		return errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, userKeys, err := utils.GetDecryptionKey(ctx, is.storage, is.cfg.Keys())
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting decrypted user key")
+
+		return errors.Wrap(err, "error getting decrypted user key")
+	}
+
+	rows, err := is.selectHydrationItems(ctx, userUUID, req)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(rows); start += hydrateBatchSize {
+		batch := rows[start:min(start+hydrateBatchSize, len(rows))]
+
+		responses, err := is.hydrateBatch(ctx, userUUID, userKeys, batch)
+		if err != nil {
+			return err
+		}
+
+		for _, resp := range responses {
+			if err := stream.Send(resp); err != nil {
+				is.logger.Error().Err(err).Msg("error sending hydrated item")
+
+				return errors.Wrap(err, "error sending hydrated item")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (is *Service) selectHydrationItems(
+	ctx context.Context,
+	userUUID pgtype.UUID,
+	req *pb.HydrateItemsV1Request,
+) ([]db.GetItemsByResourceIDsRow, error) {
+	if len(req.GetItemIds()) > 0 {
+		ids := make([]pgtype.UUID, len(req.GetItemIds()))
+		for cursor, id := range req.GetItemIds() {
+			ids[cursor] = gu.GetIDFromString(id)
+		}
+
+		rows, err := is.storage.GetItemsByResourceIDs(ctx, db.GetItemsByResourceIDsParams{
+			UserID:      userUUID,
+			ResourceIds: ids,
+		})
+		if err != nil {
+			is.logger.Error().Err(err).Msg("error getting items by ids")
+
+			return nil, errors.Wrap(err, "error getting items by ids")
+		}
+
+		return rows, nil
+	}
+
+	changed, err := is.storage.GetItemsChangedSince(ctx, db.GetItemsChangedSinceParams{
+		UserID:       userUUID,
+		ChangedSince: pgtype.Timestamp{Time: req.GetChangedSince().AsTime(), Valid: true},
+	})
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting changed items")
+
+		return nil, errors.Wrap(err, "error getting changed items")
+	}
+
+	rows := make([]db.GetItemsByResourceIDsRow, len(changed))
+	for cursor, row := range changed {
+		rows[cursor] = db.GetItemsByResourceIDsRow(row)
+	}
+
+	return rows, nil
+}
+
+// hydrateBatch loads payloads and metadata for a batch of items with one query per item type.
+//
+//nolint:cyclop,funlen
+func (is *Service) hydrateBatch(
+	ctx context.Context,
+	userUUID pgtype.UUID,
+	userKeys utils.UserKeys,
+	rows []db.GetItemsByResourceIDsRow,
+) ([]*pb.HydrateItemsV1Response, error) {
+	idsByType := make(map[db.ItemType][]pgtype.UUID)
+	allIDs := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		idsByType[row.Type] = append(idsByType[row.Type], row.IDResource)
+		allIDs = append(allIDs, row.IDResource)
+	}
+
+	payloads := make(map[pgtype.UUID]func(*pb.HydrateItemsV1Response), len(rows))
+
+	if ids := idsByType[db.ItemTypePassword]; len(ids) > 0 {
+		passwords, err := is.storage.GetPasswordsByIDs(ctx, db.GetPasswordEntriesByIDsParams{UserID: userUUID, Ids: ids})
+		if err != nil {
+			is.logger.Error().Err(err).Msg("error getting passwords")
+
+			return nil, errors.Wrap(err, "error getting passwords")
+		}
+
+		for _, password := range passwords {
+			decrypted, err := userKeys.Open(password.Password, password.KeyVersion,
+				utils.Bind(password.UserID, password.ID, utils.FieldPassword))
+			if err != nil {
+				is.logger.Error().Err(err).Msg("error decrypting password")
+
+				return nil, errors.Wrap(err, "error decrypting password")
+			}
+
+			data := &pb_password.PasswordData{Login: password.Login, Password: decrypted}
+			version := password.Version
+			payloads[password.ID] = func(resp *pb.HydrateItemsV1Response) {
+				resp.Item.Version = version
+				resp.Payload = &pb.HydrateItemsV1Response_Password{Password: data}
+			}
+		}
+	}
+
+	if ids := idsByType[db.ItemTypeText]; len(ids) > 0 {
+		notes, err := is.storage.GetNotesByIDs(ctx, db.GetNotesByIDsParams{UserID: userUUID, Ids: ids})
+		if err != nil {
+			is.logger.Error().Err(err).Msg("error getting notes")
+
+			return nil, errors.Wrap(err, "error getting notes")
+		}
+
+		for _, note := range notes {
+			content, err := userKeys.Open(note.EncryptedContent, note.KeyVersion,
+				utils.Bind(note.UserID, note.ID, utils.FieldNote))
+			if err != nil {
+				is.logger.Error().Err(err).Msg("error decrypting note")
+
+				return nil, errors.Wrap(err, "error decrypting note")
+			}
+
+			data := &pb_note.NoteData{Content: content}
+			version := note.Version
+			payloads[note.ID] = func(resp *pb.HydrateItemsV1Response) {
+				resp.Item.Version = version
+				resp.Payload = &pb.HydrateItemsV1Response_Note{Note: data}
+			}
+		}
+	}
+
+	if ids := idsByType[db.ItemTypeCard]; len(ids) > 0 {
+		cards, err := is.storage.GetCardsByIDs(ctx, db.GetCardsByIDsParams{UserID: userUUID, Ids: ids})
+		if err != nil {
+			is.logger.Error().Err(err).Msg("error getting cards")
+
+			return nil, errors.Wrap(err, "error getting cards")
+		}
+
+		for _, card := range cards {
+			data, err := is.decryptCard(&card, userKeys)
+			if err != nil {
+				return nil, err
+			}
+
+			version := card.Version
+			payloads[card.ID] = func(resp *pb.HydrateItemsV1Response) {
+				resp.Item.Version = version
+				resp.Payload = &pb.HydrateItemsV1Response_Card{Card: data}
+			}
+		}
+	}
+
+	if ids := idsByType[db.ItemTypeBinary]; len(ids) > 0 {
+		binaries, err := is.storage.GetBinariesByIDs(ctx, db.GetBinaryEntriesByIDsParams{UserID: userUUID, Ids: ids})
+		if err != nil {
+			is.logger.Error().Err(err).Msg("error getting files")
+
+			return nil, errors.Wrap(err, "error getting files")
+		}
+
+		for _, binary := range binaries {
+			data := &pb_file.FileMeta{
+				Id:         binary.ID.String(),
+				FileName:   binary.FileName,
+				FileSize:   binary.FileSize,
+				FileUrl:    binary.FileUrl,
+				LastUpdate: timestamppb.New(binary.UpdatedAt.Time),
+				Version:    binary.Version,
+			}
+			payloads[binary.ID] = func(resp *pb.HydrateItemsV1Response) {
+				resp.Item.Version = data.GetVersion()
+				resp.Payload = &pb.HydrateItemsV1Response_File{File: data}
+			}
+		}
+	}
+
+	metaRows, err := is.storage.GetMetaInfoByItemIDs(ctx, allIDs)
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting metainfo")
+
+		return nil, errors.Wrap(err, "error getting metainfo")
+	}
+
+	meta := make(map[pgtype.UUID]map[string]string, len(rows))
+	for _, row := range metaRows {
+		if _, ok := meta[row.ItemID]; !ok {
+			meta[row.ItemID] = make(map[string]string)
+		}
+
+		meta[row.ItemID][row.Key] = row.Value
+	}
+
+	responses := make([]*pb.HydrateItemsV1Response, 0, len(rows))
+	for _, row := range rows {
+		setPayload, ok := payloads[row.IDResource]
+		if !ok {
+			// The underlying record is gone; there is nothing to hydrate.
+			continue
+		}
+
+		resp := &pb.HydrateItemsV1Response{
+			Item: &pb.ItemData{
+				Id:        row.IDResource.String(),
+				Type:      utils.ToProtoItemType(row.Type),
+				CreatedAt: timestamppb.New(row.CreatedAt.Time),
+				UpdatedAt: timestamppb.New(row.UpdatedAt.Time),
+			},
+			Metadata: meta[row.IDResource],
+		}
+		setPayload(resp)
+
+		responses = append(responses, resp)
+	}
+
+	return responses, nil
+}
+
+func (is *Service) decryptCard(card *db.Card, userKeys utils.UserKeys) (*pb_card.CardData, error) {
+	cardNumber, err := userKeys.Open(card.EncryptedCardNumber, card.KeyVersion,
+		utils.Bind(card.UserID, card.ID, utils.FieldCardNumber))
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error decrypting Card Number")
+
+		return nil, errors.Wrap(err, "error decrypting Card Number")
+	}
+
+	cardCvv, err := userKeys.Open(card.EncryptedCvv, card.KeyVersion, utils.Bind(card.UserID, card.ID, utils.FieldCVV))
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error decrypting Card CVV")
+
+		return nil, errors.Wrap(err, "error decrypting Card CVV")
+	}
+
+	expiryDate, err := userKeys.Open(card.EncryptedExpiryDate, card.KeyVersion,
+		utils.Bind(card.UserID, card.ID, utils.FieldExpiryDate))
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error decrypting Expiry Date")
+
+		return nil, errors.Wrap(err, "error decrypting Expiry Date")
+	}
+
+	return &pb_card.CardData{
+		CardNumber:     cardNumber,
+		Cvv:            cardCvv,
+		ExpiryDate:     expiryDate,
+		CardholderName: card.CardholderName,
+	}, nil
+}
@@ -0,0 +1,247 @@
+//nolint:exhaustruct
+package item_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/redis"
+	"github.com/npavlov/go-password-manager/internal/server/service/item"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	generalutils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+type MockHydrateStream struct {
+	Sent        []*pb.HydrateItemsV1Response
+	SendFunc    func(*pb.HydrateItemsV1Response) error
+	ContextFunc func() context.Context
+}
+
+func (m *MockHydrateStream) Send(resp *pb.HydrateItemsV1Response) error {
+	if m.SendFunc != nil {
+		return m.SendFunc(resp)
+	}
+
+	m.Sent = append(m.Sent, resp)
+
+	return nil
+}
+
+func (m *MockHydrateStream) Context() context.Context {
+	if m.ContextFunc != nil {
+		return m.ContextFunc()
+	}
+
+	return context.Background()
+}
+
+// The following are required by the gRPC stream interface but not used in tests.
+func (m *MockHydrateStream) SetHeader(_ metadata.MD) error {
+	panic("implement me")
+}
+
+func (m *MockHydrateStream) SendHeader(_ metadata.MD) error {
+	panic("implement me")
+}
+
+func (m *MockHydrateStream) SetTrailer(_ metadata.MD) {
+	panic("implement me")
+}
+
+func (m *MockHydrateStream) SendMsg(_ any) error {
+	panic("implement me")
+}
+
+func (m *MockHydrateStream) RecvMsg(_ any) error {
+	panic("implement me")
+}
+
+func setupHydrateService(t *testing.T) (*item.Service, *testutils.MockDBStorage, context.Context, string) {
+	t.Helper()
+
+	return setupHydrateServiceWithPubSub(t, testutils.NewMockRedis())
+}
+
+func setupHydrateServiceWithPubSub(
+	t *testing.T,
+	pubSub redis.PubSub,
+) (*item.Service, *testutils.MockDBStorage, context.Context, string) {
+	t.Helper()
+
+	logger := zerolog.New(nil)
+	masterKey, _ := utils.GenerateRandomKey()
+	storage := testutils.SetupMockUserStorage(masterKey)
+	cfg := &config.Config{
+		SecuredMasterKey: generalutils.NewString(masterKey),
+	}
+
+	userID := uuid.New()
+	encryptionKey, _ := utils.GenerateRandomKey()
+	encryptedKey, _ := utils.Encrypt(encryptionKey, masterKey)
+
+	storage.AddTestUser(db.User{
+		ID:            pgtype.UUID{Bytes: userID, Valid: true},
+		Username:      "testuser",
+		Password:      "hashed-password",
+		EncryptionKey: encryptedKey,
+	})
+
+	ctx := testutils.InjectUserToContext(t.Context(), userID.String())
+
+	return item.NewItemService(&logger, storage, cfg, pubSub), storage, ctx, encryptionKey
+}
+
+func storeHydrationFixtures(
+	t *testing.T,
+	storage *testutils.MockDBStorage,
+	ctx context.Context,
+	encryptionKey string,
+) (*db.Password, *db.Note) {
+	t.Helper()
+
+	userUUID := pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true}
+
+	passwordID := utils.NewItemID()
+
+	encryptedPassword, err := utils.EncryptBound("secret", encryptionKey,
+		utils.Bind(userUUID, passwordID, utils.FieldPassword))
+	require.NoError(t, err)
+
+	password, err := storage.StorePassword(ctx, db.CreatePasswordEntryParams{
+		ID:       passwordID,
+		UserID:   userUUID,
+		Login:    "user",
+		Password: encryptedPassword,
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	noteID := utils.NewItemID()
+
+	encryptedNote, err := utils.EncryptBound("my note", encryptionKey, utils.Bind(userUUID, noteID, utils.FieldNote))
+	require.NoError(t, err)
+
+	note, err := storage.StoreNote(ctx, db.CreateNoteEntryParams{
+		ID:               noteID,
+		UserID:           userUUID,
+		EncryptedContent: encryptedNote,
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	_, err = storage.AddMeta(ctx, password.ID.String(), "site", "example.com")
+	require.NoError(t, err)
+
+	return password, note
+}
+
+func TestHydrateItems_ChangedSince(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, encryptionKey := setupHydrateService(t)
+	password, note := storeHydrationFixtures(t, storage, ctx, encryptionKey)
+
+	stream := &MockHydrateStream{ContextFunc: func() context.Context { return ctx }}
+	err := svc.HydrateItemsV1(&pb.HydrateItemsV1Request{}, stream)
+	require.NoError(t, err)
+	require.Len(t, stream.Sent, 2)
+
+	byID := make(map[string]*pb.HydrateItemsV1Response)
+	for _, resp := range stream.Sent {
+		byID[resp.GetItem().GetId()] = resp
+	}
+
+	hydratedPassword := byID[password.ID.String()]
+	require.NotNil(t, hydratedPassword)
+	assert.Equal(t, pb.ItemType_ITEM_TYPE_PASSWORD, hydratedPassword.GetItem().GetType())
+	assert.Equal(t, "user", hydratedPassword.GetPassword().GetLogin())
+	assert.Equal(t, "secret", hydratedPassword.GetPassword().GetPassword())
+	assert.Equal(t, "example.com", hydratedPassword.GetMetadata()["site"])
+	assert.Equal(t, int64(1), hydratedPassword.GetItem().GetVersion())
+
+	hydratedNote := byID[note.ID.String()]
+	require.NotNil(t, hydratedNote)
+	assert.Equal(t, pb.ItemType_ITEM_TYPE_NOTE, hydratedNote.GetItem().GetType())
+	assert.Equal(t, "my note", hydratedNote.GetNote().GetContent())
+	assert.Empty(t, hydratedNote.GetMetadata())
+
+	// Nothing has changed after the newest update.
+	stream = &MockHydrateStream{ContextFunc: func() context.Context { return ctx }}
+	err = svc.HydrateItemsV1(&pb.HydrateItemsV1Request{
+		ChangedSince: timestamppb.New(time.Now().Add(time.Minute)),
+	}, stream)
+	require.NoError(t, err)
+	assert.Empty(t, stream.Sent)
+}
+
+func TestHydrateItems_ByIDs(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, encryptionKey := setupHydrateService(t)
+	_, note := storeHydrationFixtures(t, storage, ctx, encryptionKey)
+
+	stream := &MockHydrateStream{ContextFunc: func() context.Context { return ctx }}
+	err := svc.HydrateItemsV1(&pb.HydrateItemsV1Request{
+		ItemIds: []string{note.ID.String(), uuid.NewString()},
+	}, stream)
+	require.NoError(t, err)
+	require.Len(t, stream.Sent, 1)
+	assert.Equal(t, note.ID.String(), stream.Sent[0].GetItem().GetId())
+	assert.Equal(t, "my note", stream.Sent[0].GetNote().GetContent())
+}
+
+func TestHydrateItems_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	svc, _, ctx, _ := setupHydrateService(t)
+
+	stream := &MockHydrateStream{ContextFunc: func() context.Context { return ctx }}
+	err := svc.HydrateItemsV1(&pb.HydrateItemsV1Request{
+		ItemIds:      []string{uuid.NewString()},
+		ChangedSince: timestamppb.Now(),
+	}, stream)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error validating input")
+
+	err = svc.HydrateItemsV1(&pb.HydrateItemsV1Request{ItemIds: []string{"not-a-uuid"}}, stream)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error validating input")
+}
+
+func TestHydrateItems_SendError(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, encryptionKey := setupHydrateService(t)
+	storeHydrationFixtures(t, storage, ctx, encryptionKey)
+
+	stream := &MockHydrateStream{
+		ContextFunc: func() context.Context { return ctx },
+		SendFunc: func(_ *pb.HydrateItemsV1Response) error {
+			return context.Canceled
+		},
+	}
+	err := svc.HydrateItemsV1(&pb.HydrateItemsV1Request{}, stream)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error sending hydrated item")
+}
+
+func TestHydrateItems_NoUserContext(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _, _ := setupHydrateService(t)
+
+	err := svc.HydrateItemsV1(&pb.HydrateItemsV1Request{}, &MockHydrateStream{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error getting decrypted user key")
+}
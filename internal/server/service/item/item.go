@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/bufbuild/protovalidate-go"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
@@ -13,11 +14,35 @@ import (
 	pb "github.com/npavlov/go-password-manager/gen/proto/item"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/redis"
 	"github.com/npavlov/go-password-manager/internal/server/service/utils"
 )
 
 type Storage interface {
 	GetItems(ctx context.Context, getItems db.GetItemsByUserIDParams) ([]db.GetItemsByUserIDRow, error)
+	GetItemsByResourceIDs(ctx context.Context,
+		params db.GetItemsByResourceIDsParams) ([]db.GetItemsByResourceIDsRow, error)
+	GetItemsChangedSince(ctx context.Context,
+		params db.GetItemsChangedSinceParams) ([]db.GetItemsChangedSinceRow, error)
+	GetPasswordsByIDs(ctx context.Context, params db.GetPasswordEntriesByIDsParams) ([]db.Password, error)
+	GetNotesByIDs(ctx context.Context, params db.GetNotesByIDsParams) ([]db.Note, error)
+	GetCardsByIDs(ctx context.Context, params db.GetCardsByIDsParams) ([]db.Card, error)
+	GetBinariesByIDs(ctx context.Context, params db.GetBinaryEntriesByIDsParams) ([]db.BinaryEntry, error)
+	GetMetaInfoByItemIDs(ctx context.Context, itemIDs []pgtype.UUID) ([]db.GetMetaInfoByItemIDsRow, error)
+	GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User, error)
+	GetItemChanges(ctx context.Context,
+		params db.GetItemChangesSinceParams) ([]db.GetItemChangesSinceRow, error)
+	GetUser(ctx context.Context, username string) (*db.User, error)
+	GetShareKey(ctx context.Context, userID pgtype.UUID) (*db.ShareKey, error)
+	CreateShareKey(ctx context.Context, params db.CreateShareKeyParams) error
+	GetItemChangeSeq(ctx context.Context, userID, itemID pgtype.UUID) (int64, error)
+	UpsertShare(ctx context.Context, params db.UpsertShareParams) (*db.Share, error)
+	GetShare(ctx context.Context, itemID, recipientID pgtype.UUID) (*db.Share, error)
+	RefreshShare(ctx context.Context, params db.RefreshShareParams) (bool, error)
+	ListShares(ctx context.Context, params db.ListSharesParams) ([]db.ListSharesRow, error)
+	DeleteShare(ctx context.Context, params db.DeleteShareParams) (bool, error)
+	UpdatePassword(ctx context.Context, params db.UpdatePasswordEntryParams) (*db.Password, error)
+	UpdateCard(ctx context.Context, params db.UpdateCardParams) (*db.Card, error)
 }
 
 type Service struct {
@@ -26,9 +51,10 @@ type Service struct {
 	logger    *zerolog.Logger
 	storage   Storage
 	cfg       *config.Config
+	pubSub    redis.PubSub
 }
 
-func NewItemService(log *zerolog.Logger, storage Storage, cfg *config.Config) *Service {
+func NewItemService(log *zerolog.Logger, storage Storage, cfg *config.Config, pubSub redis.PubSub) *Service {
 	validator, err := protovalidate.New()
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create validator")
@@ -39,6 +65,7 @@ func NewItemService(log *zerolog.Logger, storage Storage, cfg *config.Config) *S
 		validator: validator,
 		storage:   storage,
 		cfg:       cfg,
+		pubSub:    pubSub,
 	}
 }
 
@@ -76,19 +103,11 @@ func (is *Service) GetItemsV1(ctx context.Context, req *pb.GetItemsV1Request) (*
 	for cursor, item := range data {
 		items[cursor] = &pb.ItemData{
 			Id:        item.IDResource.String(),
+			Type:      utils.ToProtoItemType(item.Type),
 			UpdatedAt: timestamppb.New(item.UpdatedAt.Time),
 			CreatedAt: timestamppb.New(item.CreatedAt.Time),
-		}
-
-		switch item.Type {
-		case db.ItemTypeCard:
-			items[cursor].Type = pb.ItemType_ITEM_TYPE_CARD
-		case db.ItemTypeBinary:
-			items[cursor].Type = pb.ItemType_ITEM_TYPE_BINARY
-		case db.ItemTypePassword:
-			items[cursor].Type = pb.ItemType_ITEM_TYPE_PASSWORD
-		case db.ItemTypeText:
-			items[cursor].Type = pb.ItemType_ITEM_TYPE_NOTE
+			Owner:     item.Owner,
+			ReadOnly:  item.ReadOnly,
 		}
 	}
 
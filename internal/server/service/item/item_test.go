@@ -39,7 +39,7 @@ func setupItemService(t *testing.T) (*item.Service, *testutils.MockDBStorage, co
 	// Inject user ID into context
 	ctx := testutils.InjectUserToContext(t.Context(), userID.String())
 
-	return item.NewItemService(&logger, storage, cfg), storage, ctx
+	return item.NewItemService(&logger, storage, cfg, testutils.NewMockRedis()), storage, ctx
 }
 
 func TestGetItems_Success(t *testing.T) {
@@ -0,0 +1,522 @@
+//nolint:exhaustruct
+package item
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/redis"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// ShareItemV1 seals an item with its metadata to the share key of the recipient, creating that key on the
+// first share. Sharing the item with the same recipient again changes the grant.
+//
+//nolint:cyclop,funlen
+func (is *Service) ShareItemV1(ctx context.Context, req *pb.ShareItemV1Request) (*pb.ShareItemV1Response, error) {
+	if err := is.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	ownerUUID, ownerKeys, err := utils.GetDecryptionKey(ctx, is.storage, is.cfg.Keys())
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting decrypted user key")
+
+		return nil, errors.Wrap(err, "error getting decrypted user key")
+	}
+
+	if ownerKeys.Current == "" {
+		return nil, status.Error(codes.FailedPrecondition, "zero-knowledge accounts cannot share items")
+	}
+
+	row, err := is.ownedItem(ctx, ownerUUID, gu.GetIDFromString(req.GetItemId()))
+	if err != nil {
+		return nil, err
+	}
+
+	if row.Type == db.ItemTypeBinary {
+		return nil, status.Error(codes.FailedPrecondition, "files cannot be shared")
+	}
+
+	// Organization vaults share a namespace with users, but only people receive shares
+	recipient, err := is.storage.GetUser(ctx, req.GetRecipient())
+	if err != nil || recipient.OrganizationVault {
+		return nil, status.Error(codes.NotFound, "recipient not found")
+	}
+
+	if recipient.ID == ownerUUID {
+		return nil, status.Error(codes.InvalidArgument, "items cannot be shared with their owner")
+	}
+
+	shareKey, err := is.recipientShareKey(ctx, recipient.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, seq, err := is.sealShare(ctx, ownerUUID, ownerKeys, row, recipient.ID, shareKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := is.storage.UpsertShare(ctx, db.UpsertShareParams{
+		ItemID:      row.IDResource,
+		OwnerID:     ownerUUID,
+		RecipientID: recipient.ID,
+		ReadOnly:    req.GetReadOnly(),
+		Payload:     payload,
+		ItemSeq:     seq,
+	})
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error storing share")
+
+		return nil, errors.Wrap(err, "error storing share")
+	}
+
+	return &pb.ShareItemV1Response{
+		Share: &pb.Share{
+			ItemId:    share.ItemID.String(),
+			Recipient: recipient.Username,
+			ReadOnly:  share.ReadOnly,
+			CreatedAt: timestamppb.New(share.CreatedAt.Time),
+		},
+	}, nil
+}
+
+// RevokeShareV1 removes the share of an item of the caller with the recipient, along with the sealed copy.
+func (is *Service) RevokeShareV1(ctx context.Context, req *pb.RevokeShareV1Request) (*pb.RevokeShareV1Response, error) {
+	if err := is.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	ownerUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	deleted, err := is.storage.DeleteShare(ctx, db.DeleteShareParams{
+		ItemID:    gu.GetIDFromString(req.GetItemId()),
+		OwnerID:   ownerUUID,
+		Recipient: req.GetRecipient(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error revoking share")
+	}
+
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "share not found")
+	}
+
+	return &pb.RevokeShareV1Response{}, nil
+}
+
+// ListSharesV1 lists who the caller's items are shared with.
+func (is *Service) ListSharesV1(ctx context.Context, req *pb.ListSharesV1Request) (*pb.ListSharesV1Response, error) {
+	if err := is.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	ownerUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	rows, err := is.storage.ListShares(ctx, db.ListSharesParams{
+		OwnerID: ownerUUID,
+		ByItem:  req.GetItemId() != "",
+		ItemID:  gu.GetIDFromString(req.GetItemId()),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing shares")
+	}
+
+	shares := make([]*pb.Share, len(rows))
+	for cursor, row := range rows {
+		shares[cursor] = &pb.Share{
+			ItemId:    row.ItemID.String(),
+			Recipient: row.Recipient,
+			ReadOnly:  row.ReadOnly,
+			CreatedAt: timestamppb.New(row.CreatedAt.Time),
+		}
+	}
+
+	return &pb.ListSharesV1Response{Shares: shares}, nil
+}
+
+// GetSharedItemV1 opens the copy of an item sealed to the caller. A copy older than the latest change of the
+// item is sealed again from the owner's vault first.
+//
+//nolint:funlen
+func (is *Service) GetSharedItemV1(
+	ctx context.Context,
+	req *pb.GetSharedItemV1Request,
+) (*pb.GetSharedItemV1Response, error) {
+	if err := is.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	recipientUUID, recipientKeys, err := utils.GetDecryptionKey(ctx, is.storage, is.cfg.Keys())
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting decrypted user key")
+
+		return nil, errors.Wrap(err, "error getting decrypted user key")
+	}
+
+	share, err := is.storage.GetShare(ctx, gu.GetIDFromString(req.GetItemId()), recipientUUID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "shared item not found")
+	}
+
+	shareKey, err := is.storage.GetShareKey(ctx, recipientUUID)
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting share key")
+
+		return nil, errors.Wrap(err, "error getting share key")
+	}
+
+	payload, err := is.currentShare(ctx, share, shareKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := recipientKeys.Open(shareKey.PrivateKey, shareKey.KeyVersion,
+		utils.Bind(recipientUUID, recipientUUID, utils.FieldShareKey))
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error decrypting share key")
+
+		return nil, errors.Wrap(err, "error decrypting share key")
+	}
+
+	plain, err := utils.OpenShare(payload, privateKey, utils.Bind(recipientUUID, share.ItemID, utils.FieldShare))
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error decrypting shared item")
+
+		return nil, errors.Wrap(err, "error decrypting shared item")
+	}
+
+	item := &pb.HydrateItemsV1Response{}
+	if err := proto.Unmarshal(plain, item); err != nil {
+		return nil, errors.Wrap(err, "error decoding shared item")
+	}
+
+	owner, err := is.storage.GetUserByID(ctx, share.OwnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting owner")
+	}
+
+	item.Item.Owner = owner.Username
+	item.Item.ReadOnly = share.ReadOnly
+
+	return &pb.GetSharedItemV1Response{Item: item}, nil
+}
+
+// UpdateSharedItemV1 changes the password or card of an item shared with the caller with write access. The
+// item stays encrypted with the owner's key; every copy shared from it is sealed again when read next.
+//
+//nolint:cyclop,funlen
+func (is *Service) UpdateSharedItemV1(
+	ctx context.Context,
+	req *pb.UpdateSharedItemV1Request,
+) (*pb.UpdateSharedItemV1Response, error) {
+	if err := is.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	recipientUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	share, err := is.storage.GetShare(ctx, gu.GetIDFromString(req.GetItemId()), recipientUUID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "shared item not found")
+	}
+
+	if share.ReadOnly {
+		return nil, status.Error(codes.PermissionDenied, "item is shared read-only")
+	}
+
+	ownerKeys, err := utils.GetUserKey(ctx, is.storage, share.OwnerID, is.cfg.Keys())
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting owner key")
+
+		return nil, errors.Wrap(err, "error getting owner key")
+	}
+
+	row, err := is.ownedItem(ctx, share.OwnerID, share.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	var version int64
+
+	switch {
+	case req.GetPassword() != nil && row.Type == db.ItemTypePassword:
+		version, err = is.updateSharedPassword(ctx, share, ownerKeys, req)
+	case req.GetCard() != nil && row.Type == db.ItemTypeCard:
+		version, err = is.updateSharedCard(ctx, share, ownerKeys, req)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "data does not match the type of the item")
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.Aborted, "item was modified concurrently")
+	}
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error updating shared item")
+
+		return nil, errors.Wrap(err, "error updating shared item")
+	}
+
+	// The owner's watchers learn about the change like about their own; failing to tell them does not
+	// undo it.
+	channel := redis.ItemChangesChannel(share.OwnerID.String())
+	if err := is.pubSub.Publish(ctx, channel, redis.ItemChangedMessage); err != nil {
+		is.logger.Error().Err(err).Msg("failed to publish item change")
+	}
+
+	return &pb.UpdateSharedItemV1Response{Version: version}, nil
+}
+
+func (is *Service) updateSharedPassword(
+	ctx context.Context,
+	share *db.Share,
+	ownerKeys utils.UserKeys,
+	req *pb.UpdateSharedItemV1Request,
+) (int64, error) {
+	data := req.GetPassword()
+
+	encryptedPassword, err := ownerKeys.Seal(data.GetPassword(),
+		utils.Bind(share.OwnerID, share.ItemID, utils.FieldPassword))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to encrypt password")
+	}
+
+	password, err := is.storage.UpdatePassword(ctx, db.UpdatePasswordEntryParams{
+		ID:              share.ItemID,
+		UserID:          share.OwnerID,
+		Login:           data.GetLogin(),
+		Password:        encryptedPassword,
+		KeyVersion:      ownerKeys.Version,
+		ExpectedVersion: req.GetExpectedVersion(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return password.Version, nil
+}
+
+func (is *Service) updateSharedCard(
+	ctx context.Context,
+	share *db.Share,
+	ownerKeys utils.UserKeys,
+	req *pb.UpdateSharedItemV1Request,
+) (int64, error) {
+	data := req.GetCard()
+	fields := []struct{ name, value string }{
+		{utils.FieldCardNumber, data.GetCardNumber()},
+		{utils.FieldExpiryDate, data.GetExpiryDate()},
+		{utils.FieldCVV, data.GetCvv()},
+	}
+
+	sealed := make([]string, len(fields))
+	for i, field := range fields {
+		var err error
+
+		sealed[i], err = ownerKeys.Seal(field.value, utils.Bind(share.OwnerID, share.ItemID, field.name))
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to encrypt card")
+		}
+	}
+
+	card, err := is.storage.UpdateCard(ctx, db.UpdateCardParams{
+		ID:                  share.ItemID,
+		UserID:              share.OwnerID,
+		EncryptedCardNumber: sealed[0],
+		EncryptedExpiryDate: sealed[1],
+		EncryptedCvv:        sealed[2],
+		HashedCardNumber:    utils.HashCardNumber(data.GetCardNumber()),
+		CardholderName:      data.GetCardholderName(),
+		KeyVersion:          ownerKeys.Version,
+		ExpectedVersion:     req.GetExpectedVersion(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return card.Version, nil
+}
+
+// ownedItem returns an item of the user.
+func (is *Service) ownedItem(
+	ctx context.Context,
+	userUUID, itemID pgtype.UUID,
+) (db.GetItemsByResourceIDsRow, error) {
+	rows, err := is.storage.GetItemsByResourceIDs(ctx, db.GetItemsByResourceIDsParams{
+		UserID:      userUUID,
+		ResourceIds: []pgtype.UUID{itemID},
+	})
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting item")
+
+		return db.GetItemsByResourceIDsRow{}, errors.Wrap(err, "error getting item")
+	}
+
+	if len(rows) == 0 {
+		return db.GetItemsByResourceIDsRow{}, status.Error(codes.NotFound, "item not found")
+	}
+
+	return rows[0], nil
+}
+
+// recipientShareKey returns the share key of the recipient, creating it with the recipient's key when missing.
+func (is *Service) recipientShareKey(ctx context.Context, recipientUUID pgtype.UUID) (*db.ShareKey, error) {
+	shareKey, err := is.storage.GetShareKey(ctx, recipientUUID)
+	if err == nil {
+		return shareKey, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		is.logger.Error().Err(err).Msg("error getting share key")
+
+		return nil, errors.Wrap(err, "error getting share key")
+	}
+
+	recipientKeys, err := utils.GetUserKey(ctx, is.storage, recipientUUID, is.cfg.Keys())
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting recipient key")
+
+		return nil, errors.Wrap(err, "error getting recipient key")
+	}
+
+	// The private key is wrapped like the vault, which the server cannot do for zero-knowledge accounts.
+	if recipientKeys.Current == "" {
+		return nil, status.Error(codes.FailedPrecondition, "items cannot be shared with zero-knowledge accounts")
+	}
+
+	publicKey, privateKey, err := utils.GenerateShareKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating share key")
+	}
+
+	wrapped, err := recipientKeys.Seal(privateKey, utils.Bind(recipientUUID, recipientUUID, utils.FieldShareKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "error wrapping share key")
+	}
+
+	if err := is.storage.CreateShareKey(ctx, db.CreateShareKeyParams{
+		UserID:     recipientUUID,
+		PublicKey:  publicKey,
+		PrivateKey: wrapped,
+		KeyVersion: recipientKeys.Version,
+	}); err != nil {
+		return nil, errors.Wrap(err, "error storing share key")
+	}
+
+	// Read it back: a concurrent share may have created the key first.
+	shareKey, err = is.storage.GetShareKey(ctx, recipientUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting share key")
+	}
+
+	return shareKey, nil
+}
+
+// sealShare hydrates an item of the owner and seals it to the public share key of the recipient. It returns
+// the sealed item with the change sequence of the item it reflects.
+func (is *Service) sealShare(
+	ctx context.Context,
+	ownerUUID pgtype.UUID,
+	ownerKeys utils.UserKeys,
+	row db.GetItemsByResourceIDsRow,
+	recipientUUID pgtype.UUID,
+	publicKey string,
+) (string, int64, error) {
+	// Read the sequence first, so a change made while hydrating makes the copy stale rather than lost.
+	seq, err := is.storage.GetItemChangeSeq(ctx, ownerUUID, row.IDResource)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "error getting item change")
+	}
+
+	hydrated, err := is.hydrateBatch(ctx, ownerUUID, ownerKeys, []db.GetItemsByResourceIDsRow{row})
+	if err != nil {
+		return "", 0, err
+	}
+
+	if len(hydrated) == 0 {
+		return "", 0, status.Error(codes.NotFound, "item not found")
+	}
+
+	plain, err := proto.Marshal(hydrated[0])
+	if err != nil {
+		return "", 0, errors.Wrap(err, "error encoding item")
+	}
+
+	sealed, err := utils.SealShare(plain, publicKey, utils.Bind(recipientUUID, row.IDResource, utils.FieldShare))
+	if err != nil {
+		return "", 0, errors.Wrap(err, "error sealing item")
+	}
+
+	return sealed, seq, nil
+}
+
+// currentShare returns the payload of the share, sealing it again when the item changed after it was sealed.
+func (is *Service) currentShare(ctx context.Context, share *db.Share, publicKey string) (string, error) {
+	seq, err := is.storage.GetItemChangeSeq(ctx, share.OwnerID, share.ItemID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The owner deleted the item
+		return "", status.Error(codes.NotFound, "shared item not found")
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "error getting item change")
+	}
+
+	if seq == share.ItemSeq {
+		return share.Payload, nil
+	}
+
+	ownerKeys, err := utils.GetUserKey(ctx, is.storage, share.OwnerID, is.cfg.Keys())
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting owner key")
+
+		return "", errors.Wrap(err, "error getting owner key")
+	}
+
+	row, err := is.ownedItem(ctx, share.OwnerID, share.ItemID)
+	if err != nil {
+		return "", err
+	}
+
+	payload, seq, err := is.sealShare(ctx, share.OwnerID, ownerKeys, row, share.RecipientID, publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	// A concurrent read may have refreshed the copy already; either copy is current.
+	if _, err := is.storage.RefreshShare(ctx, db.RefreshShareParams{
+		Payload:    payload,
+		ItemSeq:    seq,
+		ID:         share.ID,
+		OldItemSeq: share.ItemSeq,
+	}); err != nil {
+		return "", errors.Wrap(err, "error refreshing share")
+	}
+
+	return payload, nil
+}
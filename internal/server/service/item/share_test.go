@@ -0,0 +1,200 @@
+//nolint:exhaustruct
+package item_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/item"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	generalutils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+func userUUIDFromContext(ctx context.Context) pgtype.UUID {
+	return pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true}
+}
+
+// setupShareService stores the hydration fixtures for the owner "testuser" and registers the recipient
+// "bob" with a key of its own. It returns the contexts of both users.
+func setupShareService(t *testing.T) (
+	*item.Service,
+	*testutils.MockDBStorage,
+	context.Context,
+	context.Context,
+	*db.Password,
+) {
+	t.Helper()
+
+	logger := zerolog.New(nil)
+	masterKey, _ := utils.GenerateRandomKey()
+	storage := testutils.SetupMockUserStorage(masterKey)
+	cfg := &config.Config{
+		SecuredMasterKey: generalutils.NewString(masterKey),
+	}
+
+	contexts := make([]context.Context, 0, 2)
+	keys := make([]string, 0, 2)
+
+	for _, username := range []string{"testuser", "bob"} {
+		userID := uuid.New()
+		encryptionKey, _ := utils.GenerateRandomKey()
+		encryptedKey, _ := utils.Encrypt(encryptionKey, masterKey)
+
+		storage.AddTestUser(db.User{
+			ID:            pgtype.UUID{Bytes: userID, Valid: true},
+			Username:      username,
+			Password:      "hashed-password",
+			EncryptionKey: encryptedKey,
+		})
+
+		contexts = append(contexts, testutils.InjectUserToContext(t.Context(), userID.String()))
+		keys = append(keys, encryptionKey)
+	}
+
+	password, _ := storeHydrationFixtures(t, storage, contexts[0], keys[0])
+
+	return item.NewItemService(&logger, storage, cfg, testutils.NewMockRedis()), storage, contexts[0], contexts[1],
+		password
+}
+
+// passwordUpdate is a request to change the shared password to value.
+func passwordUpdate(itemID string, expectedVersion int64, value string) *pb.UpdateSharedItemV1Request {
+	return &pb.UpdateSharedItemV1Request{
+		ItemId:          itemID,
+		ExpectedVersion: expectedVersion,
+		Data: &pb.UpdateSharedItemV1Request_Password{
+			Password: &pb_password.PasswordData{Login: "user", Password: value},
+		},
+	}
+}
+
+func TestShareItem_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, recipientCtx, password := setupShareService(t)
+	itemID := password.ID.String()
+
+	shared, err := svc.ShareItemV1(ctx, &pb.ShareItemV1Request{ItemId: itemID, Recipient: "bob", ReadOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, "bob", shared.GetShare().GetRecipient())
+	assert.True(t, shared.GetShare().GetReadOnly())
+
+	// The copy is sealed to the recipient; the server does not store it in the clear.
+	share, err := storage.GetShare(ctx, password.ID, userUUIDFromContext(recipientCtx))
+	require.NoError(t, err)
+	assert.NotContains(t, share.Payload, "secret")
+
+	resp, err := svc.GetSharedItemV1(recipientCtx, &pb.GetSharedItemV1Request{ItemId: itemID})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", resp.GetItem().GetPassword().GetPassword())
+	assert.Equal(t, "example.com", resp.GetItem().GetMetadata()["site"])
+	assert.Equal(t, "testuser", resp.GetItem().GetItem().GetOwner())
+	assert.True(t, resp.GetItem().GetItem().GetReadOnly())
+
+	// The shared item is listed with the recipient's own items.
+	items, err := svc.GetItemsV1(recipientCtx, &pb.GetItemsV1Request{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, items.GetItems(), 1)
+	assert.Equal(t, "testuser", items.GetItems()[0].GetOwner())
+
+	_, err = svc.UpdateSharedItemV1(recipientCtx, passwordUpdate(itemID, 0, "new"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	list, err := svc.ListSharesV1(ctx, &pb.ListSharesV1Request{ItemId: itemID})
+	require.NoError(t, err)
+	require.Len(t, list.GetShares(), 1)
+	assert.Equal(t, "bob", list.GetShares()[0].GetRecipient())
+
+	_, err = svc.RevokeShareV1(ctx, &pb.RevokeShareV1Request{ItemId: itemID, Recipient: "bob"})
+	require.NoError(t, err)
+
+	_, err = svc.GetSharedItemV1(recipientCtx, &pb.GetSharedItemV1Request{ItemId: itemID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = svc.RevokeShareV1(ctx, &pb.RevokeShareV1Request{ItemId: itemID, Recipient: "bob"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestShareItem_ReadWrite(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, recipientCtx, password := setupShareService(t)
+	itemID := password.ID.String()
+
+	_, err := svc.ShareItemV1(ctx, &pb.ShareItemV1Request{ItemId: itemID, Recipient: "bob"})
+	require.NoError(t, err)
+
+	updated, err := svc.UpdateSharedItemV1(recipientCtx, passwordUpdate(itemID, 1, "new"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated.GetVersion())
+
+	// The item stays in the owner's vault, encrypted with the owner's key.
+	stored, err := storage.GetPassword(ctx, itemID, userUUIDFromContext(ctx))
+	require.NoError(t, err)
+	assert.NotEqual(t, password.Password, stored.Password)
+
+	// The copy of the recipient is sealed again after the change.
+	resp, err := svc.GetSharedItemV1(recipientCtx, &pb.GetSharedItemV1Request{ItemId: itemID})
+	require.NoError(t, err)
+	assert.Equal(t, "new", resp.GetItem().GetPassword().GetPassword())
+	assert.False(t, resp.GetItem().GetItem().GetReadOnly())
+
+	_, err = svc.UpdateSharedItemV1(recipientCtx, passwordUpdate(itemID, 1, "late"))
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = svc.UpdateSharedItemV1(recipientCtx, &pb.UpdateSharedItemV1Request{
+		ItemId: itemID,
+		Data: &pb.UpdateSharedItemV1Request_Card{Card: &pb_card.CardData{
+			CardNumber: "4111111111111111", ExpiryDate: "12/30", Cvv: "123", CardholderName: "Bob",
+		}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestShareItem_Rejected(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, _, password := setupShareService(t)
+
+	_, err := svc.ShareItemV1(ctx, &pb.ShareItemV1Request{ItemId: password.ID.String(), Recipient: "nobody"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = svc.ShareItemV1(ctx, &pb.ShareItemV1Request{ItemId: password.ID.String(), Recipient: "testuser"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = svc.ShareItemV1(ctx, &pb.ShareItemV1Request{ItemId: uuid.NewString(), Recipient: "bob"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	binary, err := storage.StoreBinary(ctx, db.StoreBinaryEntryParams{
+		UserID: userUUIDFromContext(ctx), FileName: "file.txt", FileUrl: "file",
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	_, err = svc.ShareItemV1(ctx, &pb.ShareItemV1Request{ItemId: binary.ID.String(), Recipient: "bob"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Zero-knowledge accounts have no key the server could seal with, to share or to receive.
+	zkCtx := storage.AddZeroKnowledgeUser(t.Context())
+	zkUser, err := storage.GetUserByID(zkCtx, userUUIDFromContext(zkCtx))
+	require.NoError(t, err)
+
+	_, err = svc.ShareItemV1(ctx, &pb.ShareItemV1Request{ItemId: password.ID.String(), Recipient: zkUser.Username})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = svc.ShareItemV1(zkCtx, &pb.ShareItemV1Request{ItemId: password.ID.String(), Recipient: "bob"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
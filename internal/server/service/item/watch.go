@@ -0,0 +1,115 @@
+//nolint:exhaustruct
+package item
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/redis"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+// watchResyncInterval bounds how long a lost notification can delay an update.
+const watchResyncInterval = time.Minute
+
+// WatchItemsV1 streams the changes made after the requested cursor, then keeps the stream open
+// and pushes new changes as other sessions of the user make them.
+func (is *Service) WatchItemsV1(req *pb.WatchItemsV1Request, stream pb.ItemService_WatchItemsV1Server) error {
+	if err := is.validator.Validate(req); err != nil {
+		return errors.Wrap(err, "error validating input")
+	}
+
+	ctx := stream.Context()
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error getting user id")
+
+		return errors.Wrap(err, "error getting user id")
+	}
+
+	// Subscribe before catching up, so a change committed in between is not missed.
+	notifications, err := is.pubSub.Subscribe(ctx, redis.ItemChangesChannel(userUUID.String()))
+	if err != nil {
+		is.logger.Error().Err(err).Msg("error subscribing to item changes")
+
+		return status.Error(codes.Unavailable, "item change notifications are unavailable")
+	}
+
+	// The catch-up batch is sent even when empty, so the client knows it is being watched.
+	cursor, err := is.sendChangesSince(ctx, stream, userUUID, req.GetSinceCursor(), true)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchResyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-notifications:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+
+				return status.Error(codes.Unavailable, "item change notifications closed")
+			}
+		case <-ticker.C:
+		}
+
+		cursor, err = is.sendChangesSince(ctx, stream, userUUID, cursor, false)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// sendChangesSince sends every change after cursor in pages and returns the new cursor.
+// Nothing is sent when there are no changes, unless sendEmpty is set.
+func (is *Service) sendChangesSince(
+	ctx context.Context,
+	stream pb.ItemService_WatchItemsV1Server,
+	userUUID pgtype.UUID,
+	cursor int64,
+	sendEmpty bool,
+) (int64, error) {
+	for {
+		rows, err := is.storage.GetItemChanges(ctx, db.GetItemChangesSinceParams{
+			UserID:     userUUID,
+			SinceSeq:   cursor,
+			MaxChanges: defaultChangesLimit,
+		})
+		if err != nil {
+			is.logger.Error().Err(err).Msg("error getting changes")
+
+			return cursor, errors.Wrap(err, "error getting changes")
+		}
+
+		if len(rows) == 0 && !sendEmpty {
+			return cursor, nil
+		}
+
+		var changes []*pb.ItemChange
+		changes, cursor = toItemChanges(rows, cursor)
+
+		if err := stream.Send(&pb.WatchItemsV1Response{Changes: changes, NextCursor: cursor}); err != nil {
+			is.logger.Error().Err(err).Msg("error sending changes")
+
+			return cursor, errors.Wrap(err, "error sending changes")
+		}
+
+		if len(rows) < int(defaultChangesLimit) {
+			return cursor, nil
+		}
+	}
+}
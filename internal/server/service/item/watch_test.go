@@ -0,0 +1,162 @@
+//nolint:exhaustruct
+package item_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	"github.com/npavlov/go-password-manager/internal/server/redis"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+type MockWatchStream struct {
+	sent    chan *pb.WatchItemsV1Response
+	ctx     context.Context
+	sendErr error
+}
+
+func newMockWatchStream(ctx context.Context) *MockWatchStream {
+	return &MockWatchStream{
+		sent: make(chan *pb.WatchItemsV1Response, 10),
+		ctx:  ctx,
+	}
+}
+
+func (m *MockWatchStream) Send(resp *pb.WatchItemsV1Response) error {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+
+	m.sent <- resp
+
+	return nil
+}
+
+func (m *MockWatchStream) Context() context.Context {
+	return m.ctx
+}
+
+// The following are required by the gRPC stream interface but not used in tests.
+func (m *MockWatchStream) SetHeader(_ metadata.MD) error {
+	panic("implement me")
+}
+
+func (m *MockWatchStream) SendHeader(_ metadata.MD) error {
+	panic("implement me")
+}
+
+func (m *MockWatchStream) SetTrailer(_ metadata.MD) {
+	panic("implement me")
+}
+
+func (m *MockWatchStream) SendMsg(_ any) error {
+	panic("implement me")
+}
+
+func (m *MockWatchStream) RecvMsg(_ any) error {
+	panic("implement me")
+}
+
+func (m *MockWatchStream) next(t *testing.T) *pb.WatchItemsV1Response {
+	t.Helper()
+
+	select {
+	case resp := <-m.sent:
+		return resp
+	case <-time.After(time.Second):
+		require.FailNow(t, "no changes were streamed")
+
+		return nil
+	}
+}
+
+func TestWatchItems_CatchUpAndNotify(t *testing.T) {
+	t.Parallel()
+
+	pubSub := testutils.NewMockRedis()
+	svc, storage, ctx, encryptionKey := setupHydrateServiceWithPubSub(t, pubSub)
+	_, note := storeHydrationFixtures(t, storage, ctx, encryptionKey)
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream := newMockWatchStream(ctx)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- svc.WatchItemsV1(&pb.WatchItemsV1Request{}, stream)
+	}()
+
+	// The changes made before the call are sent right away.
+	resp := stream.next(t)
+	require.Len(t, resp.GetChanges(), 2)
+	cursor := resp.GetNextCursor()
+
+	userID := testutils.GetUserIDFromContext(ctx)
+	channel := redis.ItemChangesChannel(userID)
+	require.Eventually(t, func() bool { return pubSub.Subscribers(channel) == 1 }, time.Second, 10*time.Millisecond)
+
+	userUUID := pgtype.UUID{Bytes: uuid.MustParse(userID), Valid: true}
+	require.NoError(t, storage.DeleteNote(ctx, note.ID.String(), userUUID))
+	require.NoError(t, pubSub.Publish(ctx, channel, "changed"))
+
+	resp = stream.next(t)
+	require.Len(t, resp.GetChanges(), 1)
+	assert.Equal(t, note.ID.String(), resp.GetChanges()[0].GetItemId())
+	assert.Equal(t, pb.ChangeOp_CHANGE_OP_DELETE, resp.GetChanges()[0].GetOp())
+	assert.Greater(t, resp.GetNextCursor(), cursor)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestWatchItems_NothingToCatchUp(t *testing.T) {
+	t.Parallel()
+
+	svc, _, ctx, _ := setupHydrateService(t)
+
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	stream := newMockWatchStream(ctx)
+	err := svc.WatchItemsV1(&pb.WatchItemsV1Request{}, stream)
+	require.NoError(t, err)
+
+	// A single empty batch confirms the subscription.
+	require.Len(t, stream.sent, 1)
+	resp := <-stream.sent
+	assert.Empty(t, resp.GetChanges())
+	assert.Equal(t, int64(0), resp.GetNextCursor())
+}
+
+func TestWatchItems_SendError(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, encryptionKey := setupHydrateService(t)
+	storeHydrationFixtures(t, storage, ctx, encryptionKey)
+
+	stream := newMockWatchStream(ctx)
+	stream.sendErr = status.Error(codes.Canceled, "client gone")
+
+	err := svc.WatchItemsV1(&pb.WatchItemsV1Request{}, stream)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error sending changes")
+}
+
+func TestWatchItems_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	svc, _, ctx, _ := setupHydrateService(t)
+
+	err := svc.WatchItemsV1(&pb.WatchItemsV1Request{SinceCursor: -1}, newMockWatchStream(ctx))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error validating input")
+}
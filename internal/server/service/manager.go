@@ -11,6 +11,8 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/npavlov/go-password-manager/internal/server/authz"
+	"github.com/npavlov/go-password-manager/internal/server/clientcert"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/redis"
 	"github.com/npavlov/go-password-manager/internal/server/service/interceptors"
@@ -22,20 +24,34 @@ type GManager struct {
 	grpcServer *grpc.Server
 }
 
-func NewGRPCManager(cfg *config.Config, logger *zerolog.Logger, memStorage redis.MemStorage) *GManager {
+func NewGRPCManager(
+	cfg *config.Config,
+	logger *zerolog.Logger,
+	memStorage redis.MemStorage,
+	pubSub redis.PubSub,
+	verifier interceptors.TokenVerifier,
+	apiTokens interceptors.APITokenResolver,
+	clientCerts interceptors.ClientCertResolver,
+	auditor interceptors.AuditRecorder,
+	vaults authz.Storage,
+) *GManager {
 	// Create gRPC server
-	creds, err := credentials.NewServerTLSFromFile(cfg.Certificate, cfg.PrivateKey)
+	tlsConfig, err := clientcert.ServerTLS(cfg.Certificate, cfg.PrivateKey, cfg.ClientCertOptions())
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to generate credentials")
 	}
 
 	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
-		interceptors.LoggingServerInterceptor(logger), // Logs all requests/responses
-		interceptors.TokenInterceptor(logger, cfg.JwtSecret, memStorage),
+		interceptors.LoggingServerInterceptor(logger),  // Logs all requests/responses
+		interceptors.AuditInterceptor(logger, auditor), // Records every call, refused ones included
+		interceptors.TokenInterceptor(logger, verifier, apiTokens, clientCerts, memStorage),
+		interceptors.ChangeNotifyInterceptor(logger, pubSub, vaults), // Wakes up WatchItemsV1 streams
 	),
 		grpc.ChainStreamInterceptor(
-			interceptors.StreamTokenInterceptor(logger, cfg.JwtSecret, memStorage),
-		), grpc.Creds(creds))
+			interceptors.StreamAuditInterceptor(logger, auditor),
+			interceptors.StreamTokenInterceptor(logger, verifier, apiTokens, clientCerts, memStorage),
+			interceptors.StreamChangeNotifyInterceptor(logger, pubSub, vaults),
+		), grpc.Creds(credentials.NewTLS(tlsConfig)))
 	reflection.Register(grpcServer)
 
 	return &GManager{
@@ -7,6 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/apitoken"
+	"github.com/npavlov/go-password-manager/internal/server/auditlog"
+	"github.com/npavlov/go-password-manager/internal/server/clientcert"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/service"
 	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
@@ -22,7 +27,6 @@ func TestGManager_StartAndShutdown(t *testing.T) {
 		Address:     "localhost:50051",
 		Certificate: "testdata/cert.pem", // use valid test certs or mock creds
 		PrivateKey:  "testdata/key.pem",
-		JwtSecret:   "testsecret",
 	}
 
 	mockRedis := testutils.NewMockRedis()
@@ -33,7 +37,15 @@ func TestGManager_StartAndShutdown(t *testing.T) {
 
 	ctx, cancel := context.WithCancel(t.Context())
 
-	gm := service.NewGRPCManager(cfg, logger, mockRedis)
+	apiTokens := apitoken.NewResolver(testutils.NewMockDBStorage(logger, ""), logger)
+
+	clientCerts, err := clientcert.NewResolver(testutils.NewMockDBStorage(logger, ""), cfg.ClientCertOptions(), logger)
+	require.NoError(t, err)
+
+	auditor := auditlog.NewRecorder(testutils.NewMockDBStorage(logger, ""))
+
+	gm := service.NewGRPCManager(cfg, logger, mockRedis, mockRedis, testutils.NewTokenKeys(t), apiTokens, clientCerts,
+		auditor, testutils.NewMockDBStorage(logger, ""))
 
 	// Act
 	go gm.Start(ctx, wg)
@@ -12,16 +12,24 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/note"
+	"github.com/npavlov/go-password-manager/internal/server/authz"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/db"
 	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
 )
 
 type Storage interface {
-	StoreNote(ctx context.Context, createNote db.CreateNoteEntryParams) (*db.Note, error)
+	StoreNote(ctx context.Context, createNote db.CreateNoteEntryParams, collectionID pgtype.UUID) (*db.Note, error)
 	GetNote(ctx context.Context, noteID string, userID pgtype.UUID) (*db.Note, error)
+	GetNotes(ctx context.Context, params db.GetNotesByUserIDParams) ([]db.Note, error)
+	CountNotes(ctx context.Context, userID pgtype.UUID) (int64, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User, error)
 	DeleteNote(ctx context.Context, noteID string, userID pgtype.UUID) error
+	GetItemAccess(ctx context.Context, itemID, userID pgtype.UUID) (*db.GetItemAccessRow, error)
+	GetCollectionAccess(ctx context.Context, collectionID, userID pgtype.UUID) (*db.GetCollectionAccessRow, error)
+	GetEmergencyItemAccess(ctx context.Context, itemID, granteeID pgtype.UUID) (pgtype.UUID, error)
+	ItemExists(ctx context.Context, id pgtype.UUID) (bool, error)
 }
 
 type Service struct {
@@ -55,12 +63,17 @@ func (ns *Service) StoreNoteV1(ctx context.Context, req *pb.StoreNoteV1Request)
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, decryptedUserKey, err := utils.GetDecryptionKey(ctx, ns.storage, ns.cfg.SecuredMasterKey.Get())
+	userUUID, userKeys, err := authz.StoreVault(ctx, ns.storage, ns.cfg.Keys(), req.GetCollectionId())
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting decrypted user key")
 	}
 
-	encryptedNote, err := utils.Encrypt(req.GetNote().GetContent(), decryptedUserKey)
+	noteID, err := utils.StoreItemID(ctx, ns.storage, userKeys, req.GetItemId())
+	if err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	encryptedNote, err := userKeys.Seal(req.GetNote().GetContent(), utils.Bind(userUUID, noteID, utils.FieldNote))
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("failed to encrypt password")
 
@@ -68,9 +81,11 @@ func (ns *Service) StoreNoteV1(ctx context.Context, req *pb.StoreNoteV1Request)
 	}
 
 	note, err := ns.storage.StoreNote(ctx, db.CreateNoteEntryParams{
+		ID:               noteID,
 		UserID:           userUUID,
 		EncryptedContent: encryptedNote,
-	})
+		KeyVersion:       userKeys.Version,
+	}, gu.GetIDFromString(req.GetCollectionId()))
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("failed to store password")
 
@@ -87,7 +102,7 @@ func (ns *Service) GetNoteV1(ctx context.Context, req *pb.GetNoteV1Request) (*pb
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, decryptedUserKey, err := utils.GetDecryptionKey(ctx, ns.storage, ns.cfg.SecuredMasterKey.Get())
+	userUUID, userKeys, err := authz.ItemKeys(ctx, ns.storage, ns.cfg.Keys(), req.GetNoteId(), authz.Read)
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting decrypted user key")
 	}
@@ -99,7 +114,8 @@ func (ns *Service) GetNoteV1(ctx context.Context, req *pb.GetNoteV1Request) (*pb
 		return nil, errors.Wrap(err, "error getting user id")
 	}
 
-	content, err := utils.Decrypt(note.EncryptedContent, decryptedUserKey)
+	content, err := userKeys.Open(note.EncryptedContent, note.KeyVersion,
+		utils.Bind(note.UserID, note.ID, utils.FieldNote))
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("error decrypting password")
 
@@ -111,16 +127,64 @@ func (ns *Service) GetNoteV1(ctx context.Context, req *pb.GetNoteV1Request) (*pb
 			Content: content,
 		},
 		LastUpdate: timestamppb.New(note.UpdatedAt.Time),
+		Version:    note.Version,
 	}, nil
 }
 
-func (ns *Service) GetNotesV1(_ context.Context, req *pb.GetNotesV1Request) (*pb.GetNotesV1Response, error) {
+func (ns *Service) GetNotesV1(ctx context.Context, req *pb.GetNotesV1Request) (*pb.GetNotesV1Response, error) {
 	if err := ns.validator.Validate(req); err != nil {
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
+	userUUID, userKeys, err := utils.GetDecryptionKey(ctx, ns.storage, ns.cfg.Keys())
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting decrypted user key")
+	}
+
+	limit, offset := utils.Paginate(req.GetPage(), req.GetPageSize())
+
+	notes, err := ns.storage.GetNotes(ctx, db.GetNotesByUserIDParams{
+		UserID: userUUID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		ns.logger.Error().Err(err).Msg("error getting notes")
+
+		return nil, errors.Wrap(err, "error getting notes")
+	}
+
+	totalCount, err := ns.storage.CountNotes(ctx, userUUID)
+	if err != nil {
+		ns.logger.Error().Err(err).Msg("error counting notes")
+
+		return nil, errors.Wrap(err, "error counting notes")
+	}
+
+	entries := make([]*pb.NoteEntry, len(notes))
+	for cursor, note := range notes {
+		content, err := userKeys.Open(note.EncryptedContent, note.KeyVersion,
+			utils.Bind(note.UserID, note.ID, utils.FieldNote))
+		if err != nil {
+			ns.logger.Error().Err(err).Msg("error decrypting note")
+
+			return nil, errors.Wrap(err, "error decrypting note")
+		}
+
+		entries[cursor] = &pb.NoteEntry{
+			Id: note.ID.String(),
+			Note: &pb.NoteData{
+				Content: content,
+			},
+			LastUpdate: timestamppb.New(note.UpdatedAt.Time),
+			Version:    note.Version,
+		}
+	}
+
 	return &pb.GetNotesV1Response{
-		Notes: make([]*pb.NoteData, 0),
+		Notes: entries,
+		//nolint:gosec
+		TotalCount: int32(totalCount),
 	}, nil
 }
 
@@ -129,7 +193,7 @@ func (ns *Service) DeleteNoteV1(ctx context.Context, req *pb.DeleteNoteV1Request
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, err := utils.GetUserID(ctx)
+	userUUID, err := authz.ItemVault(ctx, ns.storage, req.GetNoteId(), authz.Write)
 	if err != nil {
 		ns.logger.Error().Err(err).Msg("error getting user id")
 
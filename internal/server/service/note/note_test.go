@@ -68,7 +68,10 @@ func TestStoreNote_Success(t *testing.T) {
 	require.NotEmpty(t, resp.GetNoteId())
 
 	// Verify note was stored
-	notes, err := storage.GetNotes(ctx, pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true})
+	notes, err := storage.GetNotes(ctx, db.GetNotesByUserIDParams{
+		UserID: pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
+		Limit:  utils.DefaultPageSize,
+	})
 	require.NoError(t, err)
 	require.Len(t, notes, 1)
 
@@ -99,11 +102,14 @@ func TestGetNote_Success(t *testing.T) {
 
 	// Store a test note first
 	testContent := "Secret note content"
-	encryptedContent, _ := utils.Encrypt(testContent, userKey)
+	userID := pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true}
+	noteID := utils.NewItemID()
+	encryptedContent, _ := utils.EncryptBound(testContent, userKey, utils.Bind(userID, noteID, utils.FieldNote))
 	note, err := storage.StoreNote(ctx, db.CreateNoteEntryParams{
-		UserID:           pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
+		ID:               noteID,
+		UserID:           userID,
 		EncryptedContent: encryptedContent,
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	req := &pb.GetNoteV1Request{
@@ -140,7 +146,7 @@ func TestGetNote_Unauthorized(t *testing.T) {
 	otherNote, err := storage.StoreNote(t.Context(), db.CreateNoteEntryParams{
 		UserID:           pgtype.UUID{Bytes: otherUserID, Valid: true},
 		EncryptedContent: "encrypted-content",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	req := &pb.GetNoteV1Request{
@@ -163,7 +169,7 @@ func TestDeleteNote_Success(t *testing.T) {
 	note, err := storage.StoreNote(ctx, db.CreateNoteEntryParams{
 		UserID:           pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
 		EncryptedContent: encryptedContent,
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	req := &pb.DeleteNoteV1Request{
@@ -179,17 +185,63 @@ func TestDeleteNote_Success(t *testing.T) {
 	require.Error(t, err)
 }
 
-func TestGetNotes_NotImplemented(t *testing.T) {
+func TestGetNotes_Success(t *testing.T) {
 	t.Parallel()
 
 	svc, _, ctx, _ := setupNoteService(t)
 
-	req := &pb.GetNotesV1Request{}
-	resp, err := svc.GetNotesV1(ctx, req)
+	contents := []string{"first note", "second note", "third note"}
+	for _, content := range contents {
+		_, err := svc.StoreNoteV1(ctx, &pb.StoreNoteV1Request{
+			Note: &pb.NoteData{Content: content},
+		})
+		require.NoError(t, err)
+	}
+
+	firstPage, err := svc.GetNotesV1(ctx, &pb.GetNotesV1Request{Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, firstPage.GetNotes(), 2)
+	require.Equal(t, int32(3), firstPage.GetTotalCount())
 
+	secondPage, err := svc.GetNotesV1(ctx, &pb.GetNotesV1Request{Page: 2, PageSize: 2})
 	require.NoError(t, err)
-	require.NotNil(t, resp)
-	// Add more assertions when method is implemented
+	require.Len(t, secondPage.GetNotes(), 1)
+
+	received := make([]string, 0, len(contents))
+	for _, entry := range append(firstPage.GetNotes(), secondPage.GetNotes()...) {
+		require.NotEmpty(t, entry.GetId())
+		require.NotNil(t, entry.GetLastUpdate())
+		received = append(received, entry.GetNote().GetContent())
+	}
+
+	require.ElementsMatch(t, contents, received)
+}
+
+func TestGetNotes_Empty(t *testing.T) {
+	t.Parallel()
+
+	svc, _, ctx, _ := setupNoteService(t)
+
+	resp, err := svc.GetNotesV1(ctx, &pb.GetNotesV1Request{})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetNotes())
+	require.Zero(t, resp.GetTotalCount())
+}
+
+func TestGetNotes_DecryptionFailure(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, _ := setupNoteService(t)
+
+	_, err := storage.StoreNote(ctx, db.CreateNoteEntryParams{
+		UserID:           pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
+		EncryptedContent: "invalid-encrypted-content",
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	_, err = svc.GetNotesV1(ctx, &pb.GetNotesV1Request{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error decrypting note")
 }
 
 func TestStoreNote_EncryptionFailure(t *testing.T) {
@@ -244,7 +296,7 @@ func TestGetNote_DecryptionFailure(t *testing.T) {
 	storeNote, err := storage.StoreNote(ctx, db.CreateNoteEntryParams{
 		UserID:           pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
 		EncryptedContent: "invalid-encrypted-content",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	req := &pb.GetNoteV1Request{
@@ -266,7 +318,7 @@ func TestDeleteNote_DatabaseFailure(t *testing.T) {
 	note, err := storage.StoreNote(ctx, db.CreateNoteEntryParams{
 		UserID:           pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
 		EncryptedContent: encryptedContent,
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	// Make storage return error
@@ -308,7 +360,7 @@ func TestGetNote_MissingUserContext(t *testing.T) {
 	note, err := storage.StoreNote(ctx, db.CreateNoteEntryParams{
 		UserID:           pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
 		EncryptedContent: encryptedContent,
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	// Try to get note without user context
@@ -0,0 +1,266 @@
+//nolint:exhaustruct
+package organization
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb_item "github.com/npavlov/go-password-manager/gen/proto/item"
+	pb "github.com/npavlov/go-password-manager/gen/proto/organization"
+	"github.com/npavlov/go-password-manager/internal/server/authz"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+// CreateCollectionV1 creates a collection in an organization. Owners and admins manage collections.
+func (os *Service) CreateCollectionV1(
+	ctx context.Context,
+	req *pb.CreateCollectionV1Request,
+) (*pb.CreateCollectionV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, membership, err := os.membership(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !authz.Allows(membership.Role, pgtype.Bool{}, authz.Manage) {
+		return nil, status.Error(codes.PermissionDenied, "only owners and admins manage collections")
+	}
+
+	existing, err := os.storage.ListCollections(ctx, db.ListCollectionsParams{
+		UserID:         userUUID,
+		OrgID:          membership.ID,
+		AllCollections: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing collections")
+	}
+
+	for _, collection := range existing {
+		if collection.Name == req.GetName() {
+			return nil, status.Error(codes.AlreadyExists, "a collection with this name already exists")
+		}
+	}
+
+	collection, err := os.storage.CreateCollection(ctx, db.CreateCollectionParams{
+		OrgID: membership.ID,
+		Name:  req.GetName(),
+	})
+	if err != nil {
+		os.logger.Error().Err(err).Msg("error creating collection")
+
+		return nil, errors.Wrap(err, "error creating collection")
+	}
+
+	return &pb.CreateCollectionV1Response{
+		Collection: &pb.Collection{
+			Id:        collection.ID.String(),
+			OrgId:     collection.OrgID.String(),
+			Name:      collection.Name,
+			CreatedAt: timestamppb.New(collection.CreatedAt.Time),
+		},
+	}, nil
+}
+
+// DeleteCollectionV1 deletes an empty collection. The items go with the vault of the organization, so
+// they are deleted one by one first.
+func (os *Service) DeleteCollectionV1(
+	ctx context.Context,
+	req *pb.DeleteCollectionV1Request,
+) (*pb.DeleteCollectionV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	collection, err := authz.Collection(ctx, os.storage, req.GetCollectionId(), authz.Manage)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := os.storage.ListCollectionItems(ctx, collection.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing collection items")
+	}
+
+	if len(items) > 0 {
+		return nil, status.Error(codes.FailedPrecondition, "the collection still has items")
+	}
+
+	deleted, err := os.storage.DeleteCollection(ctx, collection.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error deleting collection")
+	}
+
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+
+	return &pb.DeleteCollectionV1Response{}, nil
+}
+
+// ListCollectionsV1 lists the collections of an organization the caller reaches.
+func (os *Service) ListCollectionsV1(
+	ctx context.Context,
+	req *pb.ListCollectionsV1Request,
+) (*pb.ListCollectionsV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, membership, err := os.membership(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := os.storage.ListCollections(ctx, db.ListCollectionsParams{
+		UserID:         userUUID,
+		OrgID:          membership.ID,
+		AllCollections: authz.Allows(membership.Role, pgtype.Bool{}, authz.Read),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing collections")
+	}
+
+	collections := make([]*pb.Collection, len(rows))
+	for cursor, row := range rows {
+		collections[cursor] = &pb.Collection{
+			Id:        row.ID.String(),
+			OrgId:     row.OrgID.String(),
+			Name:      row.Name,
+			ReadOnly:  !authz.Allows(membership.Role, row.ReadOnly, authz.Write),
+			CreatedAt: timestamppb.New(row.CreatedAt.Time),
+		}
+	}
+
+	return &pb.ListCollectionsV1Response{Collections: collections}, nil
+}
+
+// SetCollectionAccessV1 gives a member of the organization access to a collection, or changes it.
+func (os *Service) SetCollectionAccessV1(
+	ctx context.Context,
+	req *pb.SetCollectionAccessV1Request,
+) (*pb.SetCollectionAccessV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	collection, err := authz.Collection(ctx, os.storage, req.GetCollectionId(), authz.Manage)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := os.member(ctx, collection.OrgID, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := os.storage.UpsertCollectionMember(ctx, db.UpsertCollectionMemberParams{
+		UserID:       target.ID,
+		ReadOnly:     req.GetReadOnly(),
+		CollectionID: collection.ID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error storing collection access")
+	}
+
+	if !stored {
+		return nil, status.Error(codes.NotFound, "member not found")
+	}
+
+	return &pb.SetCollectionAccessV1Response{}, nil
+}
+
+// RevokeCollectionAccessV1 takes the access to a collection away from a member. Owners and admins reach
+// every collection regardless.
+func (os *Service) RevokeCollectionAccessV1(
+	ctx context.Context,
+	req *pb.RevokeCollectionAccessV1Request,
+) (*pb.RevokeCollectionAccessV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	collection, err := authz.Collection(ctx, os.storage, req.GetCollectionId(), authz.Manage)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := os.user(ctx, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := os.storage.DeleteCollectionMember(ctx, collection.ID, target.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error deleting collection access")
+	}
+
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "access not found")
+	}
+
+	return &pb.RevokeCollectionAccessV1Response{}, nil
+}
+
+// ListCollectionItemsV1 lists the items of a collection the caller reaches. The items are read with the
+// RPCs of their type, which resolve the vault of the organization.
+func (os *Service) ListCollectionItemsV1(
+	ctx context.Context,
+	req *pb.ListCollectionItemsV1Request,
+) (*pb.ListCollectionItemsV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	collection, err := authz.Collection(ctx, os.storage, req.GetCollectionId(), authz.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := os.storage.ListCollectionItems(ctx, collection.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing collection items")
+	}
+
+	readOnly := !authz.Allows(collection.Role, collection.ReadOnly, authz.Write)
+
+	items := make([]*pb_item.ItemData, len(rows))
+	for cursor, row := range rows {
+		items[cursor] = &pb_item.ItemData{
+			Id:        row.IDResource.String(),
+			Type:      utils.ToProtoItemType(row.Type),
+			CreatedAt: timestamppb.New(row.CreatedAt.Time),
+			UpdatedAt: timestamppb.New(row.UpdatedAt.Time),
+			ReadOnly:  readOnly,
+		}
+	}
+
+	return &pb.ListCollectionItemsV1Response{Items: items}, nil
+}
+
+// member returns the user with the username, if they are a member of the organization.
+func (os *Service) member(ctx context.Context, orgID pgtype.UUID, username string) (*db.User, error) {
+	target, err := os.user(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = os.storage.GetMembership(ctx, orgID, target.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "member not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting membership")
+	}
+
+	return target, nil
+}
@@ -0,0 +1,419 @@
+//nolint:exhaustruct
+package organization
+
+import (
+	"context"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/organization"
+	"github.com/npavlov/go-password-manager/internal/server/authz"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+type Storage interface {
+	authz.Storage
+	GetUser(ctx context.Context, username string) (*db.User, error)
+	CreateOrganization(ctx context.Context, params db.CreateOrganizationParams) (*db.CreateOrganizationRow, error)
+	ListOrganizations(ctx context.Context, userID pgtype.UUID) ([]db.ListOrganizationsRow, error)
+	GetMembership(ctx context.Context, orgID, userID pgtype.UUID) (*db.GetMembershipRow, error)
+	DeleteOrganization(ctx context.Context, orgID pgtype.UUID) (bool, error)
+	CountCollections(ctx context.Context, orgID pgtype.UUID) (int64, error)
+	UpsertOrgMember(ctx context.Context, params db.UpsertOrgMemberParams) (*db.OrgMember, error)
+	DeleteOrgMember(ctx context.Context, orgID, userID pgtype.UUID) (bool, error)
+	CountOrgOwners(ctx context.Context, orgID pgtype.UUID) (int64, error)
+	ListOrgMembers(ctx context.Context, orgID pgtype.UUID) ([]db.ListOrgMembersRow, error)
+	CreateCollection(ctx context.Context, params db.CreateCollectionParams) (*db.Collection, error)
+	DeleteCollection(ctx context.Context, collectionID pgtype.UUID) (bool, error)
+	ListCollections(ctx context.Context, params db.ListCollectionsParams) ([]db.ListCollectionsRow, error)
+	UpsertCollectionMember(ctx context.Context, params db.UpsertCollectionMemberParams) (bool, error)
+	DeleteCollectionMember(ctx context.Context, collectionID, userID pgtype.UUID) (bool, error)
+	ListCollectionItems(ctx context.Context, collectionID pgtype.UUID) ([]db.ListCollectionItemsRow, error)
+}
+
+type Service struct {
+	pb.UnimplementedOrganizationServiceServer
+	validator protovalidate.Validator
+	logger    *zerolog.Logger
+	storage   Storage
+	cfg       *config.Config
+}
+
+func NewOrganizationService(log *zerolog.Logger, storage Storage, cfg *config.Config) *Service {
+	validator, err := protovalidate.New()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create validator")
+	}
+
+	return &Service{
+		logger:    log,
+		validator: validator,
+		storage:   storage,
+		cfg:       cfg,
+	}
+}
+
+func (os *Service) RegisterService(grpcServer *grpc.Server) {
+	pb.RegisterOrganizationServiceServer(grpcServer, os)
+}
+
+// CreateOrganizationV1 creates an organization with a vault of its own, encrypted with a new key that the
+// server wraps like the keys of users. Zero-knowledge accounts cannot join, as the server would read the
+// items they put into collections.
+func (os *Service) CreateOrganizationV1(
+	ctx context.Context,
+	req *pb.CreateOrganizationV1Request,
+) (*pb.CreateOrganizationV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		os.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	user, err := os.storage.GetUserByID(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting user")
+	}
+
+	if user.WrappedVaultKey.Valid {
+		return nil, status.Error(codes.FailedPrecondition, "zero-knowledge accounts cannot join organizations")
+	}
+
+	vaultKey, err := utils.GenerateRandomKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating random key")
+	}
+
+	wrappedKey, err := os.cfg.Keys().Wrap(ctx, vaultKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "error encrypting organization key")
+	}
+
+	org, err := os.storage.CreateOrganization(ctx, db.CreateOrganizationParams{
+		EncryptionKey: wrappedKey,
+		Name:          req.GetName(),
+		OwnerID:       userUUID,
+	})
+	if err != nil {
+		os.logger.Error().Err(err).Msg("error creating organization")
+
+		return nil, errors.Wrap(err, "error creating organization")
+	}
+
+	return &pb.CreateOrganizationV1Response{
+		Organization: &pb.Organization{
+			Id:        org.ID.String(),
+			Name:      org.Name,
+			Role:      pb.Role_ROLE_OWNER,
+			CreatedAt: timestamppb.New(org.CreatedAt.Time),
+		},
+	}, nil
+}
+
+// ListOrganizationsV1 lists the organizations of the caller with the role of the caller in each.
+func (os *Service) ListOrganizationsV1(
+	ctx context.Context,
+	req *pb.ListOrganizationsV1Request,
+) (*pb.ListOrganizationsV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		os.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	rows, err := os.storage.ListOrganizations(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing organizations")
+	}
+
+	orgs := make([]*pb.Organization, len(rows))
+	for cursor, row := range rows {
+		orgs[cursor] = &pb.Organization{
+			Id:        row.ID.String(),
+			Name:      row.Name,
+			Role:      toProtoRole(row.Role),
+			CreatedAt: timestamppb.New(row.CreatedAt.Time),
+		}
+	}
+
+	return &pb.ListOrganizationsV1Response{Organizations: orgs}, nil
+}
+
+// DeleteOrganizationV1 deletes an organization along with its vault. Owners delete the collections first,
+// so that no items go with it by accident.
+func (os *Service) DeleteOrganizationV1(
+	ctx context.Context,
+	req *pb.DeleteOrganizationV1Request,
+) (*pb.DeleteOrganizationV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	_, membership, err := os.membership(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	if membership.Role != db.OrgRoleOwner {
+		return nil, status.Error(codes.PermissionDenied, "only owners delete organizations")
+	}
+
+	collections, err := os.storage.CountCollections(ctx, membership.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error counting collections")
+	}
+
+	if collections > 0 {
+		return nil, status.Error(codes.FailedPrecondition, "the organization still has collections")
+	}
+
+	deleted, err := os.storage.DeleteOrganization(ctx, membership.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error deleting organization")
+	}
+
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "organization not found")
+	}
+
+	return &pb.DeleteOrganizationV1Response{}, nil
+}
+
+// SetMemberV1 adds a user to an organization or changes the role of a member. Owners and admins manage
+// members, but only owners make or change other owners, and the last owner keeps the role.
+//
+//nolint:cyclop
+func (os *Service) SetMemberV1(ctx context.Context, req *pb.SetMemberV1Request) (*pb.SetMemberV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	_, membership, err := os.membership(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !authz.Allows(membership.Role, pgtype.Bool{}, authz.Manage) {
+		return nil, status.Error(codes.PermissionDenied, "only owners and admins manage members")
+	}
+
+	target, err := os.user(ctx, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	if target.WrappedVaultKey.Valid {
+		return nil, status.Error(codes.FailedPrecondition, "zero-knowledge accounts cannot join organizations")
+	}
+
+	role := fromProtoRole(req.GetRole())
+
+	current, err := os.storage.GetMembership(ctx, membership.ID, target.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.Wrap(err, "error getting membership")
+	}
+
+	wasOwner := err == nil && current.Role == db.OrgRoleOwner
+	if (role == db.OrgRoleOwner || wasOwner) && membership.Role != db.OrgRoleOwner {
+		return nil, status.Error(codes.PermissionDenied, "only owners manage owners")
+	}
+
+	if wasOwner && role != db.OrgRoleOwner {
+		if err := os.keepOwner(ctx, membership.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	member, err := os.storage.UpsertOrgMember(ctx, db.UpsertOrgMemberParams{
+		OrgID:  membership.ID,
+		UserID: target.ID,
+		Role:   role,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error storing member")
+	}
+
+	return &pb.SetMemberV1Response{
+		Member: &pb.Member{
+			Username:  target.Username,
+			Role:      toProtoRole(member.Role),
+			CreatedAt: timestamppb.New(member.CreatedAt.Time),
+		},
+	}, nil
+}
+
+// RemoveMemberV1 removes a member from an organization. Members may leave on their own; removing others
+// takes the rights SetMemberV1 asks for.
+func (os *Service) RemoveMemberV1(
+	ctx context.Context,
+	req *pb.RemoveMemberV1Request,
+) (*pb.RemoveMemberV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, membership, err := os.membership(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := os.user(ctx, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	self := target.ID == userUUID
+	if !self && !authz.Allows(membership.Role, pgtype.Bool{}, authz.Manage) {
+		return nil, status.Error(codes.PermissionDenied, "only owners and admins manage members")
+	}
+
+	current, err := os.storage.GetMembership(ctx, membership.ID, target.ID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "member not found")
+	}
+
+	if current.Role == db.OrgRoleOwner {
+		if !self && membership.Role != db.OrgRoleOwner {
+			return nil, status.Error(codes.PermissionDenied, "only owners manage owners")
+		}
+
+		if err := os.keepOwner(ctx, membership.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	deleted, err := os.storage.DeleteOrgMember(ctx, membership.ID, target.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error removing member")
+	}
+
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "member not found")
+	}
+
+	return &pb.RemoveMemberV1Response{}, nil
+}
+
+// ListMembersV1 lists the members of an organization of the caller.
+func (os *Service) ListMembersV1(ctx context.Context, req *pb.ListMembersV1Request) (*pb.ListMembersV1Response, error) {
+	if err := os.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	_, membership, err := os.membership(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := os.storage.ListOrgMembers(ctx, membership.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing members")
+	}
+
+	members := make([]*pb.Member, len(rows))
+	for cursor, row := range rows {
+		members[cursor] = &pb.Member{
+			Username:  row.Username,
+			Role:      toProtoRole(row.Role),
+			CreatedAt: timestamppb.New(row.CreatedAt.Time),
+		}
+	}
+
+	return &pb.ListMembersV1Response{Members: members}, nil
+}
+
+// membership returns the caller with their membership of the organization. Organizations of others are
+// not found.
+func (os *Service) membership(ctx context.Context, orgID string) (pgtype.UUID, *db.GetMembershipRow, error) {
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		os.logger.Error().Err(err).Msg("error getting user id")
+
+		return pgtype.UUID{}, nil, errors.Wrap(err, "error getting user id")
+	}
+
+	membership, err := os.storage.GetMembership(ctx, gu.GetIDFromString(orgID), userUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, nil, status.Error(codes.NotFound, "organization not found")
+	}
+	if err != nil {
+		return pgtype.UUID{}, nil, errors.Wrap(err, "error getting membership")
+	}
+
+	return userUUID, membership, nil
+}
+
+// user returns the user with the username. Organization vaults are no users to add or remove.
+func (os *Service) user(ctx context.Context, username string) (*db.User, error) {
+	user, err := os.storage.GetUser(ctx, username)
+	if err != nil || user.OrganizationVault {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	return user, nil
+}
+
+// keepOwner fails when the organization is down to its last owner, who is about to lose the role.
+func (os *Service) keepOwner(ctx context.Context, orgID pgtype.UUID) error {
+	owners, err := os.storage.CountOrgOwners(ctx, orgID)
+	if err != nil {
+		return errors.Wrap(err, "error counting owners")
+	}
+
+	if owners <= 1 {
+		return status.Error(codes.FailedPrecondition, "an organization keeps at least one owner")
+	}
+
+	return nil
+}
+
+func toProtoRole(role db.OrgRole) pb.Role {
+	switch role {
+	case db.OrgRoleOwner:
+		return pb.Role_ROLE_OWNER
+	case db.OrgRoleAdmin:
+		return pb.Role_ROLE_ADMIN
+	case db.OrgRoleMember:
+		return pb.Role_ROLE_MEMBER
+	case db.OrgRoleReadOnly:
+		return pb.Role_ROLE_READ_ONLY
+	default:
+		return pb.Role_ROLE_UNSPECIFIED
+	}
+}
+
+func fromProtoRole(role pb.Role) db.OrgRole {
+	switch role {
+	case pb.Role_ROLE_OWNER:
+		return db.OrgRoleOwner
+	case pb.Role_ROLE_ADMIN:
+		return db.OrgRoleAdmin
+	case pb.Role_ROLE_READ_ONLY:
+		return db.OrgRoleReadOnly
+	case pb.Role_ROLE_MEMBER, pb.Role_ROLE_UNSPECIFIED:
+		return db.OrgRoleMember
+	default:
+		return db.OrgRoleMember
+	}
+}
@@ -0,0 +1,407 @@
+//nolint:exhaustruct
+package organization_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/organization"
+	pb_password "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/organization"
+	"github.com/npavlov/go-password-manager/internal/server/service/password"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+type fixture struct {
+	svc       *organization.Service
+	passwords *password.Service
+	storage   *testutils.MockDBStorage
+	contexts  map[string]context.Context
+}
+
+// setupOrganizationService registers alice, bob and carol, and "zk", a zero-knowledge account.
+func setupOrganizationService(t *testing.T) *fixture {
+	t.Helper()
+
+	logger := zerolog.New(nil)
+	masterKey, _ := utils.GenerateRandomKey()
+	storage := testutils.SetupMockUserStorage(masterKey)
+	cfg := &config.Config{
+		SecuredMasterKey: gu.NewString(masterKey),
+	}
+
+	contexts := make(map[string]context.Context)
+
+	for _, username := range []string{"alice", "bob", "carol", "zk"} {
+		userID := uuid.New()
+		encryptionKey, _ := utils.GenerateRandomKey()
+		encryptedKey, _ := utils.Encrypt(encryptionKey, masterKey)
+
+		storage.AddTestUser(db.User{
+			ID:              pgtype.UUID{Bytes: userID, Valid: true},
+			Username:        username,
+			Password:        "hashed-password",
+			EncryptionKey:   encryptedKey,
+			WrappedVaultKey: pgtype.Text{String: "d3JhcHBlZA==", Valid: username == "zk"},
+		})
+
+		contexts[username] = testutils.InjectUserToContext(t.Context(), userID.String())
+	}
+
+	return &fixture{
+		svc:       organization.NewOrganizationService(&logger, storage, cfg),
+		passwords: password.NewPasswordService(&logger, storage, cfg),
+		storage:   storage,
+		contexts:  contexts,
+	}
+}
+
+// organization creates an organization of alice with bob as a member of the role.
+func (f *fixture) organization(t *testing.T, role pb.Role) string {
+	t.Helper()
+
+	org, err := f.svc.CreateOrganizationV1(f.contexts["alice"], &pb.CreateOrganizationV1Request{Name: "Acme"})
+	require.NoError(t, err)
+
+	_, err = f.svc.SetMemberV1(f.contexts["alice"], &pb.SetMemberV1Request{
+		OrgId: org.GetOrganization().GetId(), Username: "bob", Role: role,
+	})
+	require.NoError(t, err)
+
+	return org.GetOrganization().GetId()
+}
+
+func (f *fixture) collection(t *testing.T, orgID, name string) string {
+	t.Helper()
+
+	collection, err := f.svc.CreateCollectionV1(f.contexts["alice"], &pb.CreateCollectionV1Request{
+		OrgId: orgID, Name: name,
+	})
+	require.NoError(t, err)
+
+	return collection.GetCollection().GetId()
+}
+
+func TestCreateOrganization(t *testing.T) {
+	t.Parallel()
+
+	f := setupOrganizationService(t)
+
+	org, err := f.svc.CreateOrganizationV1(f.contexts["alice"], &pb.CreateOrganizationV1Request{Name: "Acme"})
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", org.GetOrganization().GetName())
+	assert.Equal(t, pb.Role_ROLE_OWNER, org.GetOrganization().GetRole())
+
+	list, err := f.svc.ListOrganizationsV1(f.contexts["alice"], &pb.ListOrganizationsV1Request{})
+	require.NoError(t, err)
+	require.Len(t, list.GetOrganizations(), 1)
+
+	// Organizations of others are not found
+	list, err = f.svc.ListOrganizationsV1(f.contexts["bob"], &pb.ListOrganizationsV1Request{})
+	require.NoError(t, err)
+	assert.Empty(t, list.GetOrganizations())
+
+	_, err = f.svc.ListMembersV1(f.contexts["bob"], &pb.ListMembersV1Request{OrgId: org.GetOrganization().GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = f.svc.CreateOrganizationV1(f.contexts["zk"], &pb.CreateOrganizationV1Request{Name: "Secret"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = f.svc.CreateOrganizationV1(f.contexts["alice"], &pb.CreateOrganizationV1Request{})
+	require.ErrorContains(t, err, "error validating input")
+}
+
+func TestSetMember(t *testing.T) {
+	t.Parallel()
+
+	f := setupOrganizationService(t)
+	orgID := f.organization(t, pb.Role_ROLE_ADMIN)
+
+	members, err := f.svc.ListMembersV1(f.contexts["bob"], &pb.ListMembersV1Request{OrgId: orgID})
+	require.NoError(t, err)
+	require.Len(t, members.GetMembers(), 2)
+
+	// Admins manage members, but not owners
+	_, err = f.svc.SetMemberV1(f.contexts["bob"], &pb.SetMemberV1Request{
+		OrgId: orgID, Username: "carol", Role: pb.Role_ROLE_MEMBER,
+	})
+	require.NoError(t, err)
+
+	_, err = f.svc.SetMemberV1(f.contexts["bob"], &pb.SetMemberV1Request{
+		OrgId: orgID, Username: "carol", Role: pb.Role_ROLE_OWNER,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = f.svc.SetMemberV1(f.contexts["bob"], &pb.SetMemberV1Request{
+		OrgId: orgID, Username: "alice", Role: pb.Role_ROLE_MEMBER,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Members do not manage anyone
+	_, err = f.svc.SetMemberV1(f.contexts["carol"], &pb.SetMemberV1Request{
+		OrgId: orgID, Username: "bob", Role: pb.Role_ROLE_READ_ONLY,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = f.svc.SetMemberV1(f.contexts["alice"], &pb.SetMemberV1Request{
+		OrgId: orgID, Username: "zk", Role: pb.Role_ROLE_MEMBER,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = f.svc.SetMemberV1(f.contexts["alice"], &pb.SetMemberV1Request{
+		OrgId: orgID, Username: "nobody", Role: pb.Role_ROLE_MEMBER,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The last owner keeps the role
+	_, err = f.svc.SetMemberV1(f.contexts["alice"], &pb.SetMemberV1Request{
+		OrgId: orgID, Username: "alice", Role: pb.Role_ROLE_ADMIN,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = f.svc.SetMemberV1(f.contexts["alice"], &pb.SetMemberV1Request{
+		OrgId: orgID, Username: "bob", Role: pb.Role_ROLE_OWNER,
+	})
+	require.NoError(t, err)
+
+	resp, err := f.svc.SetMemberV1(f.contexts["bob"], &pb.SetMemberV1Request{
+		OrgId: orgID, Username: "alice", Role: pb.Role_ROLE_ADMIN,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pb.Role_ROLE_ADMIN, resp.GetMember().GetRole())
+}
+
+func TestRemoveMember(t *testing.T) {
+	t.Parallel()
+
+	f := setupOrganizationService(t)
+	orgID := f.organization(t, pb.Role_ROLE_MEMBER)
+
+	_, err := f.svc.RemoveMemberV1(f.contexts["bob"], &pb.RemoveMemberV1Request{OrgId: orgID, Username: "alice"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = f.svc.RemoveMemberV1(f.contexts["alice"], &pb.RemoveMemberV1Request{OrgId: orgID, Username: "alice"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = f.svc.RemoveMemberV1(f.contexts["alice"], &pb.RemoveMemberV1Request{OrgId: orgID, Username: "carol"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Members leave on their own
+	_, err = f.svc.RemoveMemberV1(f.contexts["bob"], &pb.RemoveMemberV1Request{OrgId: orgID, Username: "bob"})
+	require.NoError(t, err)
+
+	_, err = f.svc.ListMembersV1(f.contexts["bob"], &pb.ListMembersV1Request{OrgId: orgID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDeleteOrganization(t *testing.T) {
+	t.Parallel()
+
+	f := setupOrganizationService(t)
+	orgID := f.organization(t, pb.Role_ROLE_ADMIN)
+	collectionID := f.collection(t, orgID, "Servers")
+
+	_, err := f.svc.DeleteOrganizationV1(f.contexts["bob"], &pb.DeleteOrganizationV1Request{OrgId: orgID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = f.svc.DeleteOrganizationV1(f.contexts["alice"], &pb.DeleteOrganizationV1Request{OrgId: orgID})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = f.svc.DeleteCollectionV1(f.contexts["alice"], &pb.DeleteCollectionV1Request{CollectionId: collectionID})
+	require.NoError(t, err)
+
+	_, err = f.svc.DeleteOrganizationV1(f.contexts["alice"], &pb.DeleteOrganizationV1Request{OrgId: orgID})
+	require.NoError(t, err)
+
+	list, err := f.svc.ListOrganizationsV1(f.contexts["bob"], &pb.ListOrganizationsV1Request{})
+	require.NoError(t, err)
+	assert.Empty(t, list.GetOrganizations())
+}
+
+func TestCollections(t *testing.T) {
+	t.Parallel()
+
+	f := setupOrganizationService(t)
+	orgID := f.organization(t, pb.Role_ROLE_MEMBER)
+	servers := f.collection(t, orgID, "Servers")
+	f.collection(t, orgID, "Finance")
+
+	_, err := f.svc.CreateCollectionV1(f.contexts["alice"], &pb.CreateCollectionV1Request{OrgId: orgID, Name: "Servers"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = f.svc.CreateCollectionV1(f.contexts["bob"], &pb.CreateCollectionV1Request{OrgId: orgID, Name: "Bob"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	all, err := f.svc.ListCollectionsV1(f.contexts["alice"], &pb.ListCollectionsV1Request{OrgId: orgID})
+	require.NoError(t, err)
+	assert.Len(t, all.GetCollections(), 2)
+
+	// Members see the collections they were given access to
+	mine, err := f.svc.ListCollectionsV1(f.contexts["bob"], &pb.ListCollectionsV1Request{OrgId: orgID})
+	require.NoError(t, err)
+	assert.Empty(t, mine.GetCollections())
+
+	_, err = f.svc.ListCollectionItemsV1(f.contexts["bob"], &pb.ListCollectionItemsV1Request{CollectionId: servers})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = f.svc.SetCollectionAccessV1(f.contexts["alice"], &pb.SetCollectionAccessV1Request{
+		CollectionId: servers, Username: "carol",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = f.svc.SetCollectionAccessV1(f.contexts["alice"], &pb.SetCollectionAccessV1Request{
+		CollectionId: servers, Username: "bob", ReadOnly: true,
+	})
+	require.NoError(t, err)
+
+	mine, err = f.svc.ListCollectionsV1(f.contexts["bob"], &pb.ListCollectionsV1Request{OrgId: orgID})
+	require.NoError(t, err)
+	require.Len(t, mine.GetCollections(), 1)
+	assert.Equal(t, "Servers", mine.GetCollections()[0].GetName())
+	assert.True(t, mine.GetCollections()[0].GetReadOnly())
+
+	_, err = f.svc.SetCollectionAccessV1(f.contexts["bob"], &pb.SetCollectionAccessV1Request{
+		CollectionId: servers, Username: "bob",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = f.svc.RevokeCollectionAccessV1(f.contexts["alice"], &pb.RevokeCollectionAccessV1Request{
+		CollectionId: servers, Username: "bob",
+	})
+	require.NoError(t, err)
+
+	_, err = f.svc.RevokeCollectionAccessV1(f.contexts["alice"], &pb.RevokeCollectionAccessV1Request{
+		CollectionId: servers, Username: "bob",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCollectionItems(t *testing.T) {
+	t.Parallel()
+
+	f := setupOrganizationService(t)
+	orgID := f.organization(t, pb.Role_ROLE_MEMBER)
+	servers := f.collection(t, orgID, "Servers")
+
+	_, err := f.passwords.StorePasswordV1(f.contexts["bob"], &pb_password.StorePasswordV1Request{
+		Password:     &pb_password.PasswordData{Login: "root", Password: "secret"},
+		CollectionId: servers,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	stored, err := f.passwords.StorePasswordV1(f.contexts["alice"], &pb_password.StorePasswordV1Request{
+		Password:     &pb_password.PasswordData{Login: "root", Password: "secret"},
+		CollectionId: servers,
+	})
+	require.NoError(t, err)
+
+	passwordID := stored.GetPasswordId()
+
+	_, err = f.svc.SetCollectionAccessV1(f.contexts["alice"], &pb.SetCollectionAccessV1Request{
+		CollectionId: servers, Username: "bob", ReadOnly: true,
+	})
+	require.NoError(t, err)
+
+	items, err := f.svc.ListCollectionItemsV1(f.contexts["bob"], &pb.ListCollectionItemsV1Request{CollectionId: servers})
+	require.NoError(t, err)
+	require.Len(t, items.GetItems(), 1)
+	assert.Equal(t, passwordID, items.GetItems()[0].GetId())
+	assert.True(t, items.GetItems()[0].GetReadOnly())
+
+	// The item is read and written in the vault of the organization
+	resp, err := f.passwords.GetPasswordV1(f.contexts["bob"], &pb_password.GetPasswordV1Request{PasswordId: passwordID})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", resp.GetPassword().GetPassword())
+
+	_, err = f.passwords.DeletePasswordV1(f.contexts["bob"], &pb_password.DeletePasswordV1Request{
+		PasswordId: passwordID,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = f.passwords.GetPasswordV1(f.contexts["carol"], &pb_password.GetPasswordV1Request{PasswordId: passwordID})
+	require.Error(t, err)
+
+	_, err = f.svc.DeleteCollectionV1(f.contexts["alice"], &pb.DeleteCollectionV1Request{CollectionId: servers})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = f.svc.SetCollectionAccessV1(f.contexts["alice"], &pb.SetCollectionAccessV1Request{
+		CollectionId: servers, Username: "bob",
+	})
+	require.NoError(t, err)
+
+	_, err = f.passwords.DeletePasswordV1(f.contexts["bob"], &pb_password.DeletePasswordV1Request{
+		PasswordId: passwordID,
+	})
+	require.NoError(t, err)
+
+	_, err = f.svc.DeleteCollectionV1(f.contexts["alice"], &pb.DeleteCollectionV1Request{CollectionId: servers})
+	require.NoError(t, err)
+}
+
+func TestCollectionItems_ForeignItemID(t *testing.T) {
+	t.Parallel()
+
+	f := setupOrganizationService(t)
+	orgID := f.organization(t, pb.Role_ROLE_MEMBER)
+	servers := f.collection(t, orgID, "Servers")
+
+	_, err := f.svc.SetCollectionAccessV1(f.contexts["alice"], &pb.SetCollectionAccessV1Request{
+		CollectionId: servers, Username: "bob",
+	})
+	require.NoError(t, err)
+
+	stored, err := f.passwords.StorePasswordV1(f.contexts["carol"], &pb_password.StorePasswordV1Request{
+		Password: &pb_password.PasswordData{Login: "carol", Password: "secret"},
+	})
+	require.NoError(t, err)
+
+	victimID := stored.GetPasswordId()
+
+	// The organization vault is not zero-knowledge, so the ID a writer of the collection names is ignored
+	own, err := f.passwords.StorePasswordV1(f.contexts["bob"], &pb_password.StorePasswordV1Request{
+		Password:     &pb_password.PasswordData{Login: "bob", Password: "guess"},
+		CollectionId: servers,
+		ItemId:       victimID,
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, victimID, own.GetPasswordId())
+
+	// A zero-knowledge client naming the item of another user stores nothing
+	_, err = f.passwords.StorePasswordV1(f.contexts["zk"], &pb_password.StorePasswordV1Request{
+		Password: &pb_password.PasswordData{Login: vault.SealedPrefix + "zk", Password: vault.SealedPrefix + "guess"},
+		ItemId:   victimID,
+	})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	bobID, err := utils.GetUserID(f.contexts["bob"])
+	require.NoError(t, err)
+
+	_, err = f.storage.GetItemAccess(t.Context(), gu.GetIDFromString(victimID), bobID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = f.passwords.GetPasswordV1(f.contexts["bob"], &pb_password.GetPasswordV1Request{PasswordId: victimID})
+	require.Error(t, err)
+
+	_, err = f.passwords.DeletePasswordV1(f.contexts["bob"], &pb_password.DeletePasswordV1Request{
+		PasswordId: victimID,
+	})
+	require.Error(t, err)
+
+	resp, err := f.passwords.GetPasswordV1(f.contexts["carol"], &pb_password.GetPasswordV1Request{PasswordId: victimID})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", resp.GetPassword().GetPassword())
+}
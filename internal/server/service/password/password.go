@@ -5,13 +5,17 @@ import (
 	"context"
 
 	"github.com/bufbuild/protovalidate-go"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/server/authz"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/db"
 	"github.com/npavlov/go-password-manager/internal/server/service/utils"
@@ -19,11 +23,21 @@ import (
 )
 
 type Storage interface {
-	StorePassword(ctx context.Context, createPassword db.CreatePasswordEntryParams) (*db.Password, error)
+	StorePassword(
+		ctx context.Context,
+		createPassword db.CreatePasswordEntryParams,
+		collectionID pgtype.UUID,
+	) (*db.Password, error)
 	GetPassword(ctx context.Context, passwordID string, userID pgtype.UUID) (*db.Password, error)
+	GetPasswords(ctx context.Context, params db.GetPasswordEntriesByUserIDParams) ([]db.Password, error)
+	CountPasswords(ctx context.Context, userID pgtype.UUID) (int64, error)
 	UpdatePassword(ctx context.Context, updatePassword db.UpdatePasswordEntryParams) (*db.Password, error)
 	DeletePassword(ctx context.Context, passwordID string, userID pgtype.UUID) error
 	GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User, error)
+	GetItemAccess(ctx context.Context, itemID, userID pgtype.UUID) (*db.GetItemAccessRow, error)
+	GetCollectionAccess(ctx context.Context, collectionID, userID pgtype.UUID) (*db.GetCollectionAccessRow, error)
+	GetEmergencyItemAccess(ctx context.Context, itemID, granteeID pgtype.UUID) (pgtype.UUID, error)
+	ItemExists(ctx context.Context, id pgtype.UUID) (bool, error)
 }
 
 type Service struct {
@@ -59,12 +73,22 @@ func (ps *Service) StorePasswordV1(ctx context.Context,
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, decryptedUserKey, err := utils.GetDecryptionKey(ctx, ps.storage, ps.cfg.SecuredMasterKey.Get())
+	userUUID, userKeys, err := authz.StoreVault(ctx, ps.storage, ps.cfg.Keys(), req.GetCollectionId())
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting decrypted user UUID")
 	}
 
-	encryptedPassword, err := utils.Encrypt(req.GetPassword().GetPassword(), decryptedUserKey)
+	passwordID, err := utils.StoreItemID(ctx, ps.storage, userKeys, req.GetItemId())
+	if err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	if err := utils.RequireSealed(userKeys.Current, req.GetPassword().GetLogin()); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	encryptedPassword, err := userKeys.Seal(req.GetPassword().GetPassword(),
+		utils.Bind(userUUID, passwordID, utils.FieldPassword))
 	if err != nil {
 		ps.logger.Error().Err(err).Msg("failed to encrypt password")
 
@@ -72,10 +96,12 @@ func (ps *Service) StorePasswordV1(ctx context.Context,
 	}
 
 	password, err := ps.storage.StorePassword(ctx, db.CreatePasswordEntryParams{
-		UserID:   userUUID,
-		Login:    req.GetPassword().GetLogin(),
-		Password: encryptedPassword,
-	})
+		ID:         passwordID,
+		UserID:     userUUID,
+		Login:      req.GetPassword().GetLogin(),
+		Password:   encryptedPassword,
+		KeyVersion: userKeys.Version,
+	}, gu.GetIDFromString(req.GetCollectionId()))
 	if err != nil {
 		ps.logger.Error().Err(err).Msg("failed to store password")
 
@@ -92,7 +118,7 @@ func (ps *Service) GetPasswordV1(ctx context.Context, req *pb.GetPasswordV1Reque
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, decryptedUserKey, err := utils.GetDecryptionKey(ctx, ps.storage, ps.cfg.SecuredMasterKey.Get())
+	userUUID, userKeys, err := authz.ItemKeys(ctx, ps.storage, ps.cfg.Keys(), req.GetPasswordId(), authz.Read)
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting decrypted user UUID")
 	}
@@ -104,7 +130,8 @@ func (ps *Service) GetPasswordV1(ctx context.Context, req *pb.GetPasswordV1Reque
 		return nil, errors.Wrap(err, "error getting user id")
 	}
 
-	decryptedPassword, err := utils.Decrypt(password.Password, decryptedUserKey)
+	decryptedPassword, err := userKeys.Open(password.Password, password.KeyVersion,
+		utils.Bind(password.UserID, password.ID, utils.FieldPassword))
 	if err != nil {
 		ps.logger.Error().Err(err).Msg("error decrypting password")
 
@@ -117,18 +144,67 @@ func (ps *Service) GetPasswordV1(ctx context.Context, req *pb.GetPasswordV1Reque
 			Password: decryptedPassword,
 		},
 		LastUpdate: timestamppb.New(password.UpdatedAt.Time),
+		Version:    password.Version,
 	}, nil
 }
 
-func (ps *Service) GetPasswordsV1(_ context.Context,
+func (ps *Service) GetPasswordsV1(ctx context.Context,
 	req *pb.GetPasswordsV1Request,
 ) (*pb.GetPasswordsV1Response, error) {
 	if err := ps.validator.Validate(req); err != nil {
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
+	userUUID, userKeys, err := utils.GetDecryptionKey(ctx, ps.storage, ps.cfg.Keys())
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting decrypted user UUID")
+	}
+
+	limit, offset := utils.Paginate(req.GetPage(), req.GetPageSize())
+
+	passwords, err := ps.storage.GetPasswords(ctx, db.GetPasswordEntriesByUserIDParams{
+		UserID: userUUID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		ps.logger.Error().Err(err).Msg("error getting passwords")
+
+		return nil, errors.Wrap(err, "error getting passwords")
+	}
+
+	totalCount, err := ps.storage.CountPasswords(ctx, userUUID)
+	if err != nil {
+		ps.logger.Error().Err(err).Msg("error counting passwords")
+
+		return nil, errors.Wrap(err, "error counting passwords")
+	}
+
+	entries := make([]*pb.PasswordEntry, len(passwords))
+	for cursor, password := range passwords {
+		decryptedPassword, err := userKeys.Open(password.Password, password.KeyVersion,
+			utils.Bind(password.UserID, password.ID, utils.FieldPassword))
+		if err != nil {
+			ps.logger.Error().Err(err).Msg("error decrypting password")
+
+			return nil, errors.Wrap(err, "error decrypting password")
+		}
+
+		entries[cursor] = &pb.PasswordEntry{
+			Id: password.ID.String(),
+			Password: &pb.PasswordData{
+				Login:    password.Login,
+				Password: decryptedPassword,
+			},
+			LastUpdate: timestamppb.New(password.UpdatedAt.Time),
+			Version:    password.Version,
+		}
+	}
+
 	return &pb.GetPasswordsV1Response{
-		Passwords: []*pb.PasswordData{},
+		Passwords: entries,
+		//nolint:gosec
+		TotalCount: int32(totalCount),
 	}, nil
 }
 
@@ -140,12 +216,19 @@ func (ps *Service) UpdatePasswordV1(
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	_, decryptedUserKey, err := utils.GetDecryptionKey(ctx, ps.storage, ps.cfg.SecuredMasterKey.Get())
+	userUUID, userKeys, err := authz.ItemKeys(ctx, ps.storage, ps.cfg.Keys(), req.GetPasswordId(), authz.Write)
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting decrypted user UUID")
 	}
 
-	encryptedPassword, err := utils.Encrypt(req.GetData().GetPassword(), decryptedUserKey)
+	if err := utils.RequireSealed(userKeys.Current, req.GetData().GetLogin()); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	passwordID := gu.GetIDFromString(req.GetPasswordId())
+
+	encryptedPassword, err := userKeys.Seal(req.GetData().GetPassword(),
+		utils.Bind(userUUID, passwordID, utils.FieldPassword))
 	if err != nil {
 		ps.logger.Error().Err(err).Msg("failed to encrypt password")
 
@@ -153,10 +236,16 @@ func (ps *Service) UpdatePasswordV1(
 	}
 
 	password, err := ps.storage.UpdatePassword(ctx, db.UpdatePasswordEntryParams{
-		ID:       gu.GetIDFromString(req.GetPasswordId()),
-		Login:    req.GetData().GetLogin(),
-		Password: encryptedPassword,
+		ID:              passwordID,
+		UserID:          userUUID,
+		Login:           req.GetData().GetLogin(),
+		Password:        encryptedPassword,
+		KeyVersion:      userKeys.Version,
+		ExpectedVersion: req.GetExpectedVersion(),
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ps.conflictError(ctx, req.GetPasswordId(), userUUID, userKeys)
+	}
 	if err != nil {
 		ps.logger.Error().Err(err).Msg("failed to store password")
 
@@ -165,9 +254,49 @@ func (ps *Service) UpdatePasswordV1(
 
 	return &pb.UpdatePasswordV1Response{
 		PasswordId: password.ID.String(),
+		Version:    password.Version,
 	}, nil
 }
 
+// conflictError explains why an update matched no row: either the entry does not exist
+// or its version moved on, in which case the current server copy is attached to the status.
+func (ps *Service) conflictError(
+	ctx context.Context,
+	passwordID string,
+	userUUID pgtype.UUID,
+	userKeys utils.UserKeys,
+) error {
+	current, err := ps.storage.GetPassword(ctx, passwordID, userUUID)
+	if err != nil {
+		return status.Error(codes.NotFound, "password not found")
+	}
+
+	decryptedPassword, err := userKeys.Open(current.Password, current.KeyVersion,
+		utils.Bind(current.UserID, current.ID, utils.FieldPassword))
+	if err != nil {
+		ps.logger.Error().Err(err).Msg("error decrypting password")
+
+		return errors.Wrap(err, "error decrypting password")
+	}
+
+	st, err := status.New(codes.Aborted, "password was modified concurrently").WithDetails(&pb.PasswordConflict{
+		Current: &pb.PasswordEntry{
+			Id: current.ID.String(),
+			Password: &pb.PasswordData{
+				Login:    current.Login,
+				Password: decryptedPassword,
+			},
+			LastUpdate: timestamppb.New(current.UpdatedAt.Time),
+			Version:    current.Version,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "error building conflict status")
+	}
+
+	return st.Err()
+}
+
 func (ps *Service) DeletePasswordV1(
 	ctx context.Context,
 	req *pb.DeletePasswordV1Request,
@@ -176,7 +305,7 @@ func (ps *Service) DeletePasswordV1(
 		return nil, errors.Wrap(err, "error validating input")
 	}
 
-	userUUID, err := utils.GetUserID(ctx)
+	userUUID, err := authz.ItemVault(ctx, ps.storage, req.GetPasswordId(), authz.Write)
 	if err != nil {
 		ps.logger.Error().Err(err).Msg("error getting user id")
 
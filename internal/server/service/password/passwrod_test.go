@@ -10,9 +10,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/npavlov/go-password-manager/gen/proto/password"
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
 	"github.com/npavlov/go-password-manager/internal/server/config"
 	"github.com/npavlov/go-password-manager/internal/server/db"
 	"github.com/npavlov/go-password-manager/internal/server/service/password"
@@ -75,6 +78,22 @@ func TestStorePassword_Success(t *testing.T) {
 	require.Equal(t, testLogin, storedPass.Login)
 }
 
+func TestStorePassword_IgnoresItemID(t *testing.T) {
+	t.Parallel()
+
+	svc, _, ctx, _ := setupPasswordService(t)
+
+	// Only zero-knowledge clients choose the IDs of their items
+	itemID := uuid.NewString()
+
+	resp, err := svc.StorePasswordV1(ctx, &pb.StorePasswordV1Request{
+		Password: &pb.PasswordData{Login: "login", Password: "password"},
+		ItemId:   itemID,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, itemID, resp.GetPasswordId())
+}
+
 func TestStorePassword_ValidationError(t *testing.T) {
 	t.Parallel()
 
@@ -144,12 +163,15 @@ func TestGetPassword_Success(t *testing.T) {
 	// Store a test password first
 	testLogin := "test@example.com"
 	testPassword := "securepassword123"
-	encryptedPassword, _ := utils.Encrypt(testPassword, userKey)
+	userID := pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true}
+	passwordID := utils.NewItemID()
+	encryptedPassword, _ := utils.EncryptBound(testPassword, userKey, utils.Bind(userID, passwordID, utils.FieldPassword))
 	storedPass, err := storage.StorePassword(ctx, db.CreatePasswordEntryParams{
-		UserID:   pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
+		ID:       passwordID,
+		UserID:   userID,
 		Login:    testLogin,
 		Password: encryptedPassword,
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	req := &pb.GetPasswordV1Request{
@@ -163,6 +185,34 @@ func TestGetPassword_Success(t *testing.T) {
 	require.True(t, timestamppb.New(storedPass.UpdatedAt.Time).AsTime().Equal(resp.GetLastUpdate().AsTime()))
 }
 
+func TestGetPassword_CopiedValue(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, _ := setupPasswordService(t)
+
+	resp, err := svc.StorePasswordV1(ctx, &pb.StorePasswordV1Request{
+		Password: &pb.PasswordData{Login: "victim@example.com", Password: "victim-password"},
+	})
+	require.NoError(t, err)
+
+	userID := pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true}
+	victim, err := storage.GetPassword(ctx, resp.GetPasswordId(), userID)
+	require.NoError(t, err)
+
+	// A ciphertext copied into another row does not decrypt there.
+	copied, err := storage.StorePassword(ctx, db.CreatePasswordEntryParams{
+		ID:       utils.NewItemID(),
+		UserID:   userID,
+		Login:    "attacker@example.com",
+		Password: victim.Password,
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	_, err = svc.GetPasswordV1(ctx, &pb.GetPasswordV1Request{PasswordId: copied.ID.String()})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error decrypting password")
+}
+
 func TestGetPassword_NotFound(t *testing.T) {
 	t.Parallel()
 
@@ -187,7 +237,7 @@ func TestGetPassword_DecryptionError(t *testing.T) {
 		UserID:   pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
 		Login:    "test@example.com",
 		Password: "invalid-encrypted-content",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	req := &pb.GetPasswordV1Request{
@@ -210,7 +260,7 @@ func TestGetPassword_Unauthorized(t *testing.T) {
 		UserID:   pgtype.UUID{Bytes: otherUserID, Valid: true},
 		Login:    "other@example.com",
 		Password: "encrypted-password",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	req := &pb.GetPasswordV1Request{
@@ -222,16 +272,54 @@ func TestGetPassword_Unauthorized(t *testing.T) {
 	require.Contains(t, err.Error(), "unauthorized access to password")
 }
 
-func TestGetPasswords_NotImplemented(t *testing.T) {
+func TestGetPasswords_Success(t *testing.T) {
 	t.Parallel()
 
 	svc, _, ctx, _ := setupPasswordService(t)
 
-	req := &pb.GetPasswordsV1Request{}
-	resp, err := svc.GetPasswordsV1(ctx, req)
+	stored := map[string]string{
+		"first@example.com":  "first-secret",
+		"second@example.com": "second-secret",
+	}
+	for login, password := range stored {
+		_, err := svc.StorePasswordV1(ctx, &pb.StorePasswordV1Request{
+			Password: &pb.PasswordData{Login: login, Password: password},
+		})
+		require.NoError(t, err)
+	}
+
+	resp, err := svc.GetPasswordsV1(ctx, &pb.GetPasswordsV1Request{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetPasswords(), len(stored))
+	require.Equal(t, int32(len(stored)), resp.GetTotalCount())
+
+	for _, entry := range resp.GetPasswords() {
+		require.NotEmpty(t, entry.GetId())
+		require.NotNil(t, entry.GetLastUpdate())
+		require.Equal(t, stored[entry.GetPassword().GetLogin()], entry.GetPassword().GetPassword())
+	}
+
+	paged, err := svc.GetPasswordsV1(ctx, &pb.GetPasswordsV1Request{Page: 2, PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, paged.GetPasswords(), 1)
+	require.Equal(t, int32(len(stored)), paged.GetTotalCount())
+}
+
+func TestGetPasswords_DecryptionFailure(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, _ := setupPasswordService(t)
 
+	_, err := storage.StorePassword(ctx, db.CreatePasswordEntryParams{
+		UserID:   pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
+		Login:    "user@example.com",
+		Password: "not-encrypted",
+	}, pgtype.UUID{})
 	require.NoError(t, err)
-	require.NotNil(t, resp)
+
+	_, err = svc.GetPasswordsV1(ctx, &pb.GetPasswordsV1Request{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error decrypting password")
 }
 
 func TestUpdatePassword_Success(t *testing.T) {
@@ -244,7 +332,7 @@ func TestUpdatePassword_Success(t *testing.T) {
 		UserID:   pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
 		Login:    "old@example.com",
 		Password: "initial-encrypted-pass",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	newLogin := "new@example.com"
@@ -268,11 +356,63 @@ func TestUpdatePassword_Success(t *testing.T) {
 	require.Equal(t, newLogin, updatedPass.Login)
 
 	// Verify decryption
-	decrypted, err := utils.Decrypt(updatedPass.Password, userKey)
+	decrypted, err := utils.DecryptBound(updatedPass.Password, userKey,
+		utils.Bind(updatedPass.UserID, updatedPass.ID, utils.FieldPassword))
 	require.NoError(t, err)
 	require.Equal(t, newPassword, decrypted)
 }
 
+func TestUpdatePassword_VersionConflict(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, ctx, userKey := setupPasswordService(t)
+
+	encrypted, err := utils.Encrypt("server-secret", userKey)
+	require.NoError(t, err)
+
+	stored, err := storage.StorePassword(ctx, db.CreatePasswordEntryParams{
+		UserID:   pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
+		Login:    "server@example.com",
+		Password: encrypted,
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	resp, err := svc.UpdatePasswordV1(ctx, &pb.UpdatePasswordV1Request{
+		PasswordId:      stored.ID.String(),
+		Data:            &pb.PasswordData{Login: "first@example.com", Password: "first-pass"},
+		ExpectedVersion: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.GetVersion())
+
+	// A second writer still holding version 1 must be rejected with the current copy.
+	_, err = svc.UpdatePasswordV1(ctx, &pb.UpdatePasswordV1Request{
+		PasswordId:      stored.ID.String(),
+		Data:            &pb.PasswordData{Login: "second@example.com", Password: "second-pass"},
+		ExpectedVersion: 1,
+	})
+	require.Error(t, err)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Aborted, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	conflict, ok := st.Details()[0].(*pb.PasswordConflict)
+	require.True(t, ok)
+	require.Equal(t, int64(2), conflict.GetCurrent().GetVersion())
+	require.Equal(t, "first@example.com", conflict.GetCurrent().GetPassword().GetLogin())
+	require.Equal(t, "first-pass", conflict.GetCurrent().GetPassword().GetPassword())
+
+	// Version 0 keeps the legacy last-write-wins behaviour.
+	resp, err = svc.UpdatePasswordV1(ctx, &pb.UpdatePasswordV1Request{
+		PasswordId: stored.ID.String(),
+		Data:       &pb.PasswordData{Login: "second@example.com", Password: "second-pass"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resp.GetVersion())
+}
+
 func TestUpdatePassword_ValidationError(t *testing.T) {
 	t.Parallel()
 
@@ -316,7 +456,7 @@ func TestDeletePassword_Success(t *testing.T) {
 		UserID:   pgtype.UUID{Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true},
 		Login:    "test@example.com",
 		Password: "encrypted-password",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	req := &pb.DeletePasswordV1Request{
@@ -357,7 +497,7 @@ func TestDeletePassword_Unauthorized(t *testing.T) {
 		UserID:   pgtype.UUID{Bytes: otherUserID, Valid: true},
 		Login:    "other@example.com",
 		Password: "encrypted-password",
-	})
+	}, pgtype.UUID{})
 	require.NoError(t, err)
 
 	req := &pb.DeletePasswordV1Request{
@@ -571,11 +711,65 @@ func TestDeletePassword_Validation(t *testing.T) {
 func TestGetPasswords_Validation(t *testing.T) {
 	t.Parallel()
 
-	// Currently empty since GetPasswordsV1Request has no fields to validate
-	// This test is included for completeness and future-proofing
 	svc, _, ctx, _ := setupPasswordService(t)
 
-	req := &pb.GetPasswordsV1Request{}
-	_, err := svc.GetPasswordsV1(ctx, req)
+	_, err := svc.GetPasswordsV1(ctx, &pb.GetPasswordsV1Request{})
 	require.NoError(t, err)
+
+	_, err = svc.GetPasswordsV1(ctx, &pb.GetPasswordsV1Request{Page: -1})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error validating input")
+
+	_, err = svc.GetPasswordsV1(ctx, &pb.GetPasswordsV1Request{PageSize: 101})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error validating input")
+
+	// Pages whose offset does not fit are refused up front
+	_, err = svc.GetPasswordsV1(ctx, &pb.GetPasswordsV1Request{Page: 30000000, PageSize: 100})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error validating input")
+}
+
+func TestPassword_ZeroKnowledge(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, _, _ := setupPasswordService(t)
+	ctx := storage.AddZeroKnowledgeUser(t.Context())
+
+	sealed := &pb.PasswordData{Login: vault.SealedPrefix + "bG9naW4=", Password: vault.SealedPrefix + "cGFzcw=="}
+
+	// The client chooses the ID its fields are sealed to
+	itemID := uuid.NewString()
+
+	resp, err := svc.StorePasswordV1(ctx, &pb.StorePasswordV1Request{Password: sealed, ItemId: itemID})
+	require.NoError(t, err)
+	require.Equal(t, itemID, resp.GetPasswordId())
+
+	// An ID that is taken is refused
+	_, err = svc.StorePasswordV1(ctx, &pb.StorePasswordV1Request{Password: sealed, ItemId: itemID})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// Sealed values are stored and returned untouched.
+	stored, err := storage.GetPassword(ctx, resp.GetPasswordId(), pgtype.UUID{
+		Bytes: uuid.MustParse(testutils.GetUserIDFromContext(ctx)), Valid: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sealed.GetPassword(), stored.Password)
+
+	getResp, err := svc.GetPasswordV1(ctx, &pb.GetPasswordV1Request{PasswordId: resp.GetPasswordId()})
+	require.NoError(t, err)
+	require.Equal(t, sealed.GetLogin(), getResp.GetPassword().GetLogin())
+	require.Equal(t, sealed.GetPassword(), getResp.GetPassword().GetPassword())
+
+	// Plaintext is refused, both as password and as login.
+	_, err = svc.StorePasswordV1(ctx, &pb.StorePasswordV1Request{
+		Password: &pb.PasswordData{Login: sealed.GetLogin(), Password: "plain-password"},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = svc.UpdatePasswordV1(ctx, &pb.UpdatePasswordV1Request{
+		PasswordId: resp.GetPasswordId(),
+		Data:       &pb.PasswordData{Login: "plain-login", Password: sealed.GetPassword()},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
 }
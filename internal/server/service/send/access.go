@@ -0,0 +1,161 @@
+//nolint:wrapcheck,exhaustruct
+package send
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/send"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+const keySize = 32
+
+var errSendNotFound = status.Error(codes.NotFound, "send not found")
+
+// AccessSendV1 opens a send for anyone with its link; TokenInterceptor lets it through without a login.
+// The key is checked before the password, so guessing passwords takes the key, and the view is only
+// counted once the contents were read.
+//
+//nolint:cyclop,funlen
+func (ss *Service) AccessSendV1(ctx context.Context, req *pb.AccessSendV1Request) (*pb.AccessSendV1Response, error) {
+	if err := ss.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	key, err := decodeKey(req.GetKey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid key")
+	}
+
+	send, err := ss.storage.GetSend(ctx, gu.GetIDFromString(req.GetSendId()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errSendNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting send")
+	}
+
+	if !open(*send, time.Now()) {
+		return nil, errSendNotFound
+	}
+
+	content, err := utils.DecryptBound(send.Content, key, utils.Bind(send.UserID, send.ID, utils.FieldSend))
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, "key does not open the send")
+	}
+
+	if send.Password.Valid {
+		if err := ss.hasher.Verify(req.GetPassword(), send.Password.String); err != nil {
+			return nil, status.Error(codes.PermissionDenied, "wrong password")
+		}
+	}
+
+	resp := &pb.AccessSendV1Response{Type: toProtoSendType(send.SendType)}
+
+	switch send.SendType {
+	case db.SendTypeText:
+		resp.Text = content
+	case db.SendTypeFile:
+		resp.Filename = content
+
+		resp.Data, err = ss.readFile(ctx, send, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	viewed, err := ss.storage.ViewSend(ctx, send.ID, time.Now())
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Another recipient took the last view meanwhile
+		return nil, errSendNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error counting view")
+	}
+
+	ss.logger.Info().Str("send_id", send.ID.String()).Int32("views", viewed.Views).Msg("send viewed")
+
+	if viewed.Views >= viewed.MaxViews {
+		ss.remove(ctx, *viewed)
+	}
+
+	resp.RemainingViews = viewed.MaxViews - viewed.Views
+	resp.ExpiresAt = timestamppb.New(viewed.ExpiresAt.Time)
+
+	return resp, nil
+}
+
+// readFile decrypts the file of a send.
+func (ss *Service) readFile(ctx context.Context, send *db.Send, key string) ([]byte, error) {
+	reader, err := ss.minio.GetObject(ctx, ss.cfg.Bucket, send.ObjectName.String, minio.GetObjectOptions{})
+	if err != nil {
+		ss.logger.Error().Err(err).Msg("failed to fetch file from MinIO")
+
+		return nil, status.Error(codes.Internal, "failed to retrieve file")
+	}
+	defer reader.Close()
+
+	decryptor, _, err := utils.NewFileDecryptor(reader, key, utils.Bind(send.UserID, send.ID, utils.FieldSendFile))
+	if err != nil {
+		ss.logger.Error().Err(err).Msg("error creating decryptor")
+
+		return nil, status.Error(codes.Internal, "error creating decryptor")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(decryptor, MaxFileSize+1))
+	if err != nil {
+		ss.logger.Error().Err(err).Msg("error reading and decrypting file")
+
+		return nil, status.Error(codes.Internal, "error reading and decrypting file")
+	}
+
+	return data, nil
+}
+
+// remove deletes a send that was viewed for the last time, with its file.
+func (ss *Service) remove(ctx context.Context, send db.Send) {
+	if err := ss.storage.RemoveSend(ctx, send.ID); err != nil {
+		// The purge deletes it later
+		return
+	}
+
+	if send.ObjectName.Valid {
+		ss.removeObject(ctx, send.ObjectName.String)
+	}
+}
+
+// encodeKey turns a key into URL-safe base64, so that it fits in the fragment of a link.
+func encodeKey(key string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeKey reads a key from the fragment of a link.
+func decodeKey(linkKey string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(linkKey)
+	if err != nil {
+		return "", err
+	}
+
+	if len(raw) != keySize {
+		return "", errors.New("invalid key size")
+	}
+
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,44 @@
+package send
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Run purges sends every SEND_PURGE until the context is cancelled.
+func (ss *Service) Run(ctx context.Context) {
+	for {
+		if _, err := ss.Purge(ctx, time.Now()); err != nil {
+			ss.logger.Error().Err(err).Msg("error purging sends")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(ss.cfg.SendPurge):
+		}
+	}
+}
+
+// Purge deletes the sends that expired or were used up at now, with their files. Used-up sends are
+// deleted on their last view already; this catches the ones whose removal failed.
+func (ss *Service) Purge(ctx context.Context, now time.Time) (int, error) {
+	sends, err := ss.storage.DeleteExpiredSends(ctx, now)
+	if err != nil {
+		return 0, errors.Wrap(err, "error deleting expired sends")
+	}
+
+	for _, send := range sends {
+		if send.ObjectName.Valid {
+			ss.removeObject(ctx, send.ObjectName.String)
+		}
+	}
+
+	if len(sends) > 0 {
+		ss.logger.Info().Int("sends", len(sends)).Msg("expired sends purged")
+	}
+
+	return len(sends), nil
+}
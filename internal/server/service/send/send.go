@@ -0,0 +1,398 @@
+//nolint:wrapcheck,exhaustruct
+package send
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/send"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/passhash"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	gu "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// MaxFileSize bounds the files of sends. AccessSendV1 returns a file in one message, and gRPC limits
+// messages to 4 MiB by default.
+const MaxFileSize = 3 << 20
+
+var errFileTooLarge = status.Error(codes.InvalidArgument, "file is larger than 3 MiB")
+
+type Storage interface {
+	GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User, error)
+	CreateSend(ctx context.Context, params db.CreateSendParams) (*db.Send, error)
+	ListSends(ctx context.Context, userID pgtype.UUID) ([]db.Send, error)
+	GetSend(ctx context.Context, sendID pgtype.UUID) (*db.Send, error)
+	DeleteSend(ctx context.Context, sendID, userID pgtype.UUID) (*db.Send, error)
+	ViewSend(ctx context.Context, sendID pgtype.UUID, now time.Time) (*db.Send, error)
+	RemoveSend(ctx context.Context, sendID pgtype.UUID) error
+	DeleteExpiredSends(ctx context.Context, now time.Time) ([]db.Send, error)
+}
+
+type S3Storage interface {
+	PutObject(ctx context.Context,
+		bucketName string,
+		objectName string,
+		reader io.Reader,
+		objectSize int64,
+		opts minio.PutObjectOptions,
+	) (info minio.UploadInfo, err error)
+	GetObject(ctx context.Context, bucketName string, objName string, opts minio.GetObjectOptions) (io.ReadCloser, error)
+	RemoveObject(ctx context.Context, bucketName string, objName string, opts minio.RemoveObjectOptions) error
+}
+
+type Service struct {
+	pb.UnimplementedSendServiceServer
+	validator protovalidate.Validator
+	logger    *zerolog.Logger
+	storage   Storage
+	cfg       *config.Config
+	minio     S3Storage
+	hasher    passhash.Hasher
+}
+
+func NewSendService(log *zerolog.Logger, storage Storage, cfg *config.Config, minioClient S3Storage) *Service {
+	validator, err := protovalidate.New()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create validator")
+	}
+
+	return &Service{
+		logger:    log,
+		validator: validator,
+		storage:   storage,
+		cfg:       cfg,
+		minio:     minioClient,
+		hasher:    passhash.NewArgon2id(cfg.PasswordParams()),
+	}
+}
+
+func (ss *Service) RegisterService(grpcServer *grpc.Server) {
+	pb.RegisterSendServiceServer(grpcServer, ss)
+}
+
+// CreateSendV1 seals a text with a new key and returns the key, which the server does not keep.
+func (ss *Service) CreateSendV1(ctx context.Context, req *pb.CreateSendV1Request) (*pb.CreateSendV1Response, error) {
+	if err := ss.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := ss.sender(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sendID := utils.NewItemID()
+
+	key, err := utils.GenerateRandomKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating key")
+	}
+
+	content, err := utils.EncryptBound(req.GetText(), key, utils.Bind(userUUID, sendID, utils.FieldSend))
+	if err != nil {
+		ss.logger.Error().Err(err).Msg("error encrypting text")
+
+		return nil, errors.Wrap(err, "error encrypting text")
+	}
+
+	return ss.createSend(ctx, db.CreateSendParams{
+		ID:       sendID,
+		UserID:   userUUID,
+		SendType: db.SendTypeText,
+		Content:  content,
+	}, req.GetOptions(), key)
+}
+
+// CreateFileSendV1 seals a file with a new key on its way to object storage, like UploadFileV1 does with
+// the user key, and returns the key.
+//
+//nolint:cyclop,funlen
+func (ss *Service) CreateFileSendV1(
+	stream grpc.ClientStreamingServer[pb.CreateFileSendV1Request, pb.CreateSendV1Response],
+) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		ss.logger.Error().Err(err).Msg("failed to receive file metadata")
+
+		return errors.Wrap(err, "failed to receive file metadata")
+	}
+
+	if err := ss.validator.Validate(req); err != nil {
+		return errors.Wrap(err, "failed to validate file metadata")
+	}
+
+	userUUID, err := ss.sender(ctx)
+	if err != nil {
+		return err
+	}
+
+	sendID := utils.NewItemID()
+
+	key, err := utils.GenerateRandomKey()
+	if err != nil {
+		return errors.Wrap(err, "error generating key")
+	}
+
+	fileName, err := utils.EncryptBound(req.GetFilename(), key, utils.Bind(userUUID, sendID, utils.FieldSend))
+	if err != nil {
+		ss.logger.Error().Err(err).Msg("error encrypting file name")
+
+		return errors.Wrap(err, "error encrypting file name")
+	}
+
+	objectName := utils.ObjectPrefix(userUUID) + "send-" + sendID.String()
+	pipeReader, pipeWriter := io.Pipe()
+	uploaded := make(chan error, 1)
+
+	go func() {
+		defer pipeReader.Close()
+
+		_, err := ss.minio.PutObject(context.Background(), ss.cfg.Bucket, objectName, pipeReader, -1,
+			minio.PutObjectOptions{ContentType: "application/octet-stream"})
+		uploaded <- err
+	}()
+
+	// abort stops the upload and removes whatever reached object storage
+	abort := func(err error) error {
+		_ = pipeWriter.CloseWithError(err)
+		<-uploaded
+		ss.removeObject(ctx, objectName)
+
+		return err
+	}
+
+	encryptor, err := utils.NewStreamEncryptor(pipeWriter, key, 0,
+		utils.Bind(userUUID, sendID, utils.FieldSendFile))
+	if err != nil {
+		ss.logger.Error().Err(err).Msg("error creating encryptor")
+
+		return abort(errors.Wrap(err, "error creating encryptor"))
+	}
+
+	var fileSize int64
+
+	for chunk := req; ; {
+		fileSize += int64(len(chunk.GetData()))
+		if fileSize > MaxFileSize {
+			return abort(errFileTooLarge)
+		}
+
+		if _, err := encryptor.Write(chunk.GetData()); err != nil {
+			ss.logger.Error().Err(err).Msg("failed to write chunk")
+
+			return abort(errors.Wrap(err, "failed to write chunk"))
+		}
+
+		chunk, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			ss.logger.Error().Err(err).Msg("failed to receive file data")
+
+			return abort(errors.Wrap(err, "failed to receive file data"))
+		}
+	}
+
+	if err := encryptor.Close(); err != nil {
+		ss.logger.Error().Err(err).Msg("failed to finish file")
+
+		return abort(errors.Wrap(err, "failed to finish file"))
+	}
+
+	_ = pipeWriter.Close()
+
+	if err := <-uploaded; err != nil {
+		ss.logger.Error().Err(err).Msg("failed to upload to MinIO")
+		ss.removeObject(ctx, objectName)
+
+		return errors.Wrap(err, "failed to upload file")
+	}
+
+	resp, err := ss.createSend(ctx, db.CreateSendParams{
+		ID:         sendID,
+		UserID:     userUUID,
+		SendType:   db.SendTypeFile,
+		Content:    fileName,
+		ObjectName: pgtype.Text{String: objectName, Valid: true},
+		FileSize:   fileSize,
+	}, req.GetOptions(), key)
+	if err != nil {
+		ss.removeObject(ctx, objectName)
+
+		return err
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// ListSendsV1 lists the sends of the caller that are still open.
+func (ss *Service) ListSendsV1(ctx context.Context, req *pb.ListSendsV1Request) (*pb.ListSendsV1Response, error) {
+	if err := ss.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		ss.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	sends, err := ss.storage.ListSends(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing sends")
+	}
+
+	resp := &pb.ListSendsV1Response{}
+
+	for _, send := range sends {
+		if !open(send, time.Now()) {
+			continue
+		}
+
+		resp.Sends = append(resp.Sends, &pb.Send{
+			Id:                send.ID.String(),
+			Type:              toProtoSendType(send.SendType),
+			FileSize:          send.FileSize,
+			PasswordProtected: send.Password.Valid,
+			Views:             send.Views,
+			MaxViews:          send.MaxViews,
+			ExpiresAt:         timestamppb.New(send.ExpiresAt.Time),
+			CreatedAt:         timestamppb.New(send.CreatedAt.Time),
+		})
+	}
+
+	return resp, nil
+}
+
+// DeleteSendV1 deletes a send of the caller along with its file.
+func (ss *Service) DeleteSendV1(ctx context.Context, req *pb.DeleteSendV1Request) (*pb.DeleteSendV1Response, error) {
+	if err := ss.validator.Validate(req); err != nil {
+		return nil, errors.Wrap(err, "error validating input")
+	}
+
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		ss.logger.Error().Err(err).Msg("error getting user id")
+
+		return nil, errors.Wrap(err, "error getting user id")
+	}
+
+	send, err := ss.storage.DeleteSend(ctx, gu.GetIDFromString(req.GetSendId()), userUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "send not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error deleting send")
+	}
+
+	if send.ObjectName.Valid {
+		ss.removeObject(ctx, send.ObjectName.String)
+	}
+
+	return &pb.DeleteSendV1Response{}, nil
+}
+
+// sender returns the caller, who may create sends unless their account is zero-knowledge: its clients
+// promise that the server never reads their secrets.
+func (ss *Service) sender(ctx context.Context) (pgtype.UUID, error) {
+	userUUID, err := utils.GetUserID(ctx)
+	if err != nil {
+		ss.logger.Error().Err(err).Msg("error getting user id")
+
+		return pgtype.UUID{}, errors.Wrap(err, "error getting user id")
+	}
+
+	user, err := ss.storage.GetUserByID(ctx, userUUID)
+	if err != nil {
+		return pgtype.UUID{}, errors.Wrap(err, "error getting user")
+	}
+
+	if user.WrappedVaultKey.Valid {
+		return pgtype.UUID{}, status.Error(codes.FailedPrecondition, "zero-knowledge accounts cannot create sends")
+	}
+
+	return userUUID, nil
+}
+
+// createSend stores a send with its limits and returns the key for its link.
+func (ss *Service) createSend(
+	ctx context.Context,
+	params db.CreateSendParams,
+	options *pb.SendOptions,
+	key string,
+) (*pb.CreateSendV1Response, error) {
+	if options.GetPassword() != "" {
+		hash, err := ss.hasher.Hash(options.GetPassword())
+		if err != nil {
+			return nil, errors.Wrap(err, "error hashing password")
+		}
+
+		params.Password = pgtype.Text{String: hash, Valid: true}
+	}
+
+	params.MaxViews = options.GetMaxViews()
+	params.ExpiresAt = pgtype.Timestamp{
+		Time:  time.Now().Add(time.Duration(options.GetExpiresInHours()) * time.Hour),
+		Valid: true,
+	}
+
+	send, err := ss.storage.CreateSend(ctx, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "error storing send")
+	}
+
+	linkKey, err := encodeKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "error encoding key")
+	}
+
+	ss.logger.Info().Str("user_id", send.UserID.String()).Str("send_id", send.ID.String()).Msg("send created")
+
+	return &pb.CreateSendV1Response{
+		SendId:    send.ID.String(),
+		Key:       linkKey,
+		ExpiresAt: timestamppb.New(send.ExpiresAt.Time),
+	}, nil
+}
+
+// removeObject removes the file of a send. Failures are only logged: without the key, the file cannot be read.
+func (ss *Service) removeObject(ctx context.Context, objectName string) {
+	err := ss.minio.RemoveObject(ctx, ss.cfg.Bucket, objectName, minio.RemoveObjectOptions{ForceDelete: true})
+	if err != nil {
+		ss.logger.Error().Err(err).Str("object", objectName).Msg("failed to remove send file")
+	}
+}
+
+// open reports whether a send can still be viewed at now.
+func open(send db.Send, now time.Time) bool {
+	return send.Views < send.MaxViews && send.ExpiresAt.Time.After(now)
+}
+
+func toProtoSendType(sendType db.SendType) pb.SendType {
+	switch sendType {
+	case db.SendTypeText:
+		return pb.SendType_SEND_TYPE_TEXT
+	case db.SendTypeFile:
+		return pb.SendType_SEND_TYPE_FILE
+	default:
+		return pb.SendType_SEND_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,328 @@
+//nolint:wrapcheck,exhaustruct
+package send_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/send"
+	"github.com/npavlov/go-password-manager/internal/server/config"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/send"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	generalutils "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// memObjects keeps objects in memory.
+type memObjects struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *memObjects) PutObject(_ context.Context, _ string, objectName string, reader io.Reader, _ int64,
+	_ minio.PutObjectOptions,
+) (minio.UploadInfo, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.objects[objectName] = data
+
+	return minio.UploadInfo{Size: int64(len(data))}, nil
+}
+
+func (m *memObjects) GetObject(_ context.Context, _ string, objectName string, _ minio.GetObjectOptions,
+) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return io.NopCloser(bytes.NewReader(m.objects[objectName])), nil
+}
+
+func (m *memObjects) RemoveObject(_ context.Context, _ string, objectName string, _ minio.RemoveObjectOptions) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.objects, objectName)
+
+	return nil
+}
+
+func (m *memObjects) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.objects)
+}
+
+// uploadStream feeds chunks to CreateFileSendV1.
+type uploadStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*pb.CreateFileSendV1Request
+	resp   *pb.CreateSendV1Response
+}
+
+func (s *uploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *uploadStream) Recv() (*pb.CreateFileSendV1Request, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+
+	return chunk, nil
+}
+
+func (s *uploadStream) SendAndClose(resp *pb.CreateSendV1Response) error {
+	s.resp = resp
+
+	return nil
+}
+
+func setupSendService(t *testing.T) (*send.Service, *testutils.MockDBStorage, *memObjects, context.Context) {
+	t.Helper()
+
+	masterKey, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		SecuredMasterKey: generalutils.NewString(masterKey),
+		Bucket:           "test-bucket",
+		PasswordMemory:   1024,
+		PasswordTime:     1,
+		PasswordThreads:  1,
+	}
+
+	storage := testutils.SetupMockUserStorage(masterKey)
+	objects := &memObjects{objects: make(map[string][]byte)}
+	svc := send.NewSendService(testutils.GetTLogger(), storage, cfg, objects)
+
+	user := db.User{
+		ID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username: "sender",
+		Email:    "sender@example.com",
+		Password: "hashed-password",
+	}
+	storage.AddTestUser(user)
+
+	return svc, storage, objects, testutils.InjectUserToContext(t.Context(), user.ID.String())
+}
+
+func options(maxViews int32, password string) *pb.SendOptions {
+	return &pb.SendOptions{MaxViews: maxViews, ExpiresInHours: 24, Password: password}
+}
+
+func TestSendText(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, _, ctx := setupSendService(t)
+
+	created, err := svc.CreateSendV1(ctx, &pb.CreateSendV1Request{
+		Text:    "the wifi password",
+		Options: options(2, ""),
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.GetKey())
+	assert.NotContains(t, created.GetKey(), "=", "the key must fit in a URL")
+
+	stored, err := storage.GetSend(ctx, generalutils.GetIDFromString(created.GetSendId()))
+	require.NoError(t, err)
+	assert.NotContains(t, stored.Content, "the wifi password")
+
+	// Recipients need no login
+	access := &pb.AccessSendV1Request{SendId: created.GetSendId(), Key: created.GetKey()}
+
+	opened, err := svc.AccessSendV1(t.Context(), access)
+	require.NoError(t, err)
+	assert.Equal(t, pb.SendType_SEND_TYPE_TEXT, opened.GetType())
+	assert.Equal(t, "the wifi password", opened.GetText())
+	assert.Equal(t, int32(1), opened.GetRemainingViews())
+
+	opened, err = svc.AccessSendV1(t.Context(), access)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), opened.GetRemainingViews())
+
+	// The last view removed it
+	_, err = svc.AccessSendV1(t.Context(), access)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = storage.GetSend(ctx, stored.ID)
+	require.Error(t, err)
+}
+
+func TestSendWrongKey(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _, ctx := setupSendService(t)
+
+	created, err := svc.CreateSendV1(ctx, &pb.CreateSendV1Request{Text: "secret", Options: options(1, "")})
+	require.NoError(t, err)
+
+	other, err := svc.CreateSendV1(ctx, &pb.CreateSendV1Request{Text: "other", Options: options(1, "")})
+	require.NoError(t, err)
+
+	_, err = svc.AccessSendV1(t.Context(), &pb.AccessSendV1Request{SendId: created.GetSendId(), Key: other.GetKey()})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = svc.AccessSendV1(t.Context(), &pb.AccessSendV1Request{SendId: created.GetSendId(), Key: "not-a-key"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = svc.AccessSendV1(t.Context(), &pb.AccessSendV1Request{SendId: uuid.NewString(), Key: created.GetKey()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Failed attempts do not use up views
+	opened, err := svc.AccessSendV1(t.Context(), &pb.AccessSendV1Request{
+		SendId: created.GetSendId(),
+		Key:    created.GetKey(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", opened.GetText())
+}
+
+func TestSendPassword(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _, ctx := setupSendService(t)
+
+	created, err := svc.CreateSendV1(ctx, &pb.CreateSendV1Request{Text: "secret", Options: options(1, "open sesame")})
+	require.NoError(t, err)
+
+	access := &pb.AccessSendV1Request{SendId: created.GetSendId(), Key: created.GetKey(), Password: "guess"}
+
+	_, err = svc.AccessSendV1(t.Context(), access)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	access.Password = "open sesame"
+
+	opened, err := svc.AccessSendV1(t.Context(), access)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", opened.GetText())
+}
+
+func TestSendExpired(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, _, ctx := setupSendService(t)
+
+	created, err := svc.CreateSendV1(ctx, &pb.CreateSendV1Request{Text: "secret", Options: options(1, "")})
+	require.NoError(t, err)
+
+	// An hour after the expiry, the purge deletes it
+	purged, err := svc.Purge(t.Context(), time.Now().Add(25*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	_, err = storage.GetSend(ctx, generalutils.GetIDFromString(created.GetSendId()))
+	require.Error(t, err)
+
+	_, err = svc.AccessSendV1(t.Context(), &pb.AccessSendV1Request{SendId: created.GetSendId(), Key: created.GetKey()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSendZeroKnowledge(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, _, _ := setupSendService(t)
+	zkCtx := storage.AddZeroKnowledgeUser(t.Context())
+
+	_, err := svc.CreateSendV1(zkCtx, &pb.CreateSendV1Request{Text: "secret", Options: options(1, "")})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestListAndDeleteSends(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _, ctx := setupSendService(t)
+
+	created, err := svc.CreateSendV1(ctx, &pb.CreateSendV1Request{Text: "secret", Options: options(3, "pass")})
+	require.NoError(t, err)
+
+	listed, err := svc.ListSendsV1(ctx, &pb.ListSendsV1Request{})
+	require.NoError(t, err)
+	require.Len(t, listed.GetSends(), 1)
+	assert.Equal(t, created.GetSendId(), listed.GetSends()[0].GetId())
+	assert.True(t, listed.GetSends()[0].GetPasswordProtected())
+	assert.Equal(t, int32(3), listed.GetSends()[0].GetMaxViews())
+
+	// Other users neither see nor delete it
+	_, _, _, otherCtx := setupSendService(t)
+
+	_, err = svc.DeleteSendV1(otherCtx, &pb.DeleteSendV1Request{SendId: created.GetSendId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = svc.DeleteSendV1(ctx, &pb.DeleteSendV1Request{SendId: created.GetSendId()})
+	require.NoError(t, err)
+
+	listed, err = svc.ListSendsV1(ctx, &pb.ListSendsV1Request{})
+	require.NoError(t, err)
+	assert.Empty(t, listed.GetSends())
+}
+
+func TestSendFile(t *testing.T) {
+	t.Parallel()
+
+	svc, _, objects, ctx := setupSendService(t)
+
+	stream := &uploadStream{ctx: ctx, chunks: []*pb.CreateFileSendV1Request{
+		{Filename: "keys.txt", Data: []byte("first chunk, "), Options: options(1, "")},
+		{Data: []byte("second chunk")},
+	}}
+
+	require.NoError(t, svc.CreateFileSendV1(stream))
+	require.NotNil(t, stream.resp)
+	assert.Equal(t, 1, objects.count())
+
+	opened, err := svc.AccessSendV1(t.Context(), &pb.AccessSendV1Request{
+		SendId: stream.resp.GetSendId(),
+		Key:    stream.resp.GetKey(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pb.SendType_SEND_TYPE_FILE, opened.GetType())
+	assert.Equal(t, "keys.txt", opened.GetFilename())
+	assert.Equal(t, []byte("first chunk, second chunk"), opened.GetData())
+
+	// The only view removed the file as well
+	assert.Equal(t, 0, objects.count())
+}
+
+func TestSendFileTooLarge(t *testing.T) {
+	t.Parallel()
+
+	svc, storage, objects, ctx := setupSendService(t)
+
+	stream := &uploadStream{ctx: ctx, chunks: []*pb.CreateFileSendV1Request{
+		{Filename: "big.bin", Data: make([]byte, send.MaxFileSize), Options: options(1, "")},
+		{Data: []byte("one byte too many")},
+	}}
+
+	err := svc.CreateFileSendV1(stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 0, objects.count())
+
+	sends, err := storage.ListSends(ctx, generalutils.GetIDFromString(testutils.GetUserIDFromContext(ctx)))
+	require.NoError(t, err)
+	assert.Empty(t, sends)
+}
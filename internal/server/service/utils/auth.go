@@ -2,42 +2,27 @@ package utils
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 
 	"github.com/npavlov/go-password-manager/internal/server/db"
 )
 
-func GenerateJWT(userID, jwtSecret string, expiration int64) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": userID,
-		"exp":     expiration,
-	})
-	result, err := token.SignedString([]byte(jwtSecret))
-	if err != nil {
-		return "", errors.Wrap(err, "Error generating JWT")
-	}
-
-	return result, nil
+// NewRefreshToken returns a random refresh token.
+func NewRefreshToken() string {
+	return rand.Text()
 }
 
-func ValidateJWT(tokenString string, jwtSecret string) (string, error) {
-	claims := jwt.MapClaims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(_ *jwt.Token) (interface{}, error) {
-		return []byte(jwtSecret), nil
-	})
-	if err != nil || !token.Valid {
-		return "", errors.Wrap(err, "Error validating JWT")
-	}
+// HashRefreshToken hashes a refresh token for storage, so a leaked database holds no usable tokens.
+// Refresh tokens are signed and random, so a fast hash is enough.
+func HashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
 
-	userID, ok := claims["user_id"].(string)
-	if !ok {
-		return "", errors.Wrap(err, "Error validating JWT")
-	}
-
-	return userID, nil
+	return hex.EncodeToString(hash[:])
 }
 
 func GetUserID(ctx context.Context) (pgtype.UUID, error) {
@@ -59,16 +44,36 @@ type UserGetter interface {
 	GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User, error)
 }
 
-func GetUserKey(ctx context.Context, storage UserGetter, userUUID pgtype.UUID, masterKey string) (string, error) {
+// KeyUnwrapper decrypts user keys wrapped with the master key, see kms.KeyProvider.
+type KeyUnwrapper interface {
+	Unwrap(ctx context.Context, wrapped string) (string, error)
+}
+
+// GetUserKey returns the data keys of the user, or empty keys for zero-knowledge accounts,
+// whose data the server cannot decrypt. See SealField.
+func GetUserKey(ctx context.Context, storage UserGetter, userUUID pgtype.UUID, keys KeyUnwrapper) (UserKeys, error) {
 	user, err := storage.GetUserByID(ctx, userUUID)
 	if err != nil {
-		return "", errors.Wrap(err, "Error getting user id")
+		return UserKeys{}, errors.Wrap(err, "Error getting user id")
+	}
+
+	userKeys := UserKeys{Version: user.KeyVersion, Legacy: user.LegacyCiphertexts}
+
+	if user.WrappedVaultKey.Valid {
+		return userKeys, nil
 	}
 
-	decryptedUserKey, err := Decrypt(user.EncryptionKey, masterKey)
+	userKeys.Current, err = keys.Unwrap(ctx, user.EncryptionKey)
 	if err != nil {
-		return "", errors.Wrap(err, "Error decrypting user id")
+		return UserKeys{}, errors.Wrap(err, "Error decrypting user id")
+	}
+
+	if user.PreviousEncryptionKey.Valid {
+		userKeys.Previous, err = keys.Unwrap(ctx, user.PreviousEncryptionKey.String)
+		if err != nil {
+			return UserKeys{}, errors.Wrap(err, "Error decrypting previous user key")
+		}
 	}
 
-	return decryptedUserKey, nil
+	return userKeys, nil
 }
@@ -4,7 +4,6 @@ package utils_test
 import (
 	"context"
 	"testing"
-	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
@@ -12,7 +11,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
 	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	secure "github.com/npavlov/go-password-manager/internal/utils"
 )
 
 // ---- MOCK STORAGE ----
@@ -29,41 +30,22 @@ func (m *mockStorage) GetUserByID(ctx context.Context, id pgtype.UUID) (*db.User
 
 // ---- TESTS ----
 
-func TestGenerateAndValidateJWT(t *testing.T) {
+func TestNewRefreshToken(t *testing.T) {
 	t.Parallel()
 
-	userID := "test-user-id"
-	secret := "my-secret"
-	exp := time.Now().Add(time.Hour).Unix()
-
-	token, err := utils.GenerateJWT(userID, secret, exp)
-	require.NoError(t, err)
+	token := utils.NewRefreshToken()
 	assert.NotEmpty(t, token)
-
-	// Validate the token
-	validatedUserID, err := utils.ValidateJWT(token, secret)
-	require.NoError(t, err)
-	assert.Equal(t, userID, validatedUserID)
+	assert.NotEqual(t, token, utils.NewRefreshToken())
 }
 
-func TestValidateJWT_InvalidToken(t *testing.T) {
+func TestHashRefreshToken(t *testing.T) {
 	t.Parallel()
 
-	_, err := utils.ValidateJWT("bad-token", "my-secret")
-	require.Error(t, err)
-}
-
-func TestValidateJWT_InvalidSecret(t *testing.T) {
-	t.Parallel()
-
-	secret := "correct-secret"
-	wrongSecret := "wrong-secret"
-
-	token, err := utils.GenerateJWT("user-id", secret, time.Now().Add(time.Hour).Unix())
-	require.NoError(t, err)
-
-	_, err = utils.ValidateJWT(token, wrongSecret)
-	require.Error(t, err)
+	hash := utils.HashRefreshToken("refresh-token")
+	assert.Len(t, hash, 64)
+	assert.NotContains(t, hash, "refresh-token")
+	assert.Equal(t, hash, utils.HashRefreshToken("refresh-token"))
+	assert.NotEqual(t, hash, utils.HashRefreshToken("refresh-token2"))
 }
 
 func TestGetUserId_Success(t *testing.T) {
@@ -115,11 +97,11 @@ func TestGetUserKey_Success(t *testing.T) {
 	mockedStorage.On("GetUserByID", mock.Anything, userUUID).Return(mockedUser, nil)
 
 	// Act
-	decryptedKey, err := utils.GetUserKey(ctx, mockedStorage, userUUID, masterKey)
+	decryptedKey, err := utils.GetUserKey(ctx, mockedStorage, userUUID, kms.NewLocalProvider(secure.NewString(masterKey)))
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, plainUserKey, decryptedKey)
+	assert.Equal(t, plainUserKey, decryptedKey.Current)
 	mockedStorage.AssertCalled(t, "GetUserByID", ctx, userUUID)
 }
 
@@ -135,6 +117,6 @@ func TestGetUserKey_Failure(t *testing.T) {
 	mockedUser := &db.User{}
 	mockedStorage.On("GetUserByID", mock.Anything, userUUID).Return(mockedUser, assert.AnError)
 
-	_, err = utils.GetUserKey(ctx, mockedStorage, userUUID, "some-master-key")
+	_, err = utils.GetUserKey(ctx, mockedStorage, userUUID, kms.NewLocalProvider(secure.NewString("some-master-key")))
 	require.Error(t, err)
 }
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Fields a ciphertext can be bound to.
+const (
+	FieldPassword   = "password"
+	FieldNote       = "note"
+	FieldCardNumber = "card_number"
+	FieldExpiryDate = "expiry_date"
+	FieldCVV        = "cvv"
+	FieldFile       = "file"
+	// FieldShareKey binds the private share key of a user, with the user ID as item ID.
+	FieldShareKey = "share_key"
+	// FieldShare binds an item sealed to the share key of a recipient, with the recipient as user.
+	FieldShare = "share"
+	// FieldSend binds the text of a send, or the name of its file, with the send ID as item ID.
+	FieldSend = "send"
+	// FieldSendFile binds the file of a send.
+	FieldSendFile = "send_file"
+)
+
+// Binding identifies where a ciphertext belongs. It is authenticated as AEAD additional data, so a value
+// copied to another row, user or column no longer decrypts.
+type Binding struct {
+	UserID pgtype.UUID
+	ItemID pgtype.UUID
+	Field  string
+}
+
+// NewItemID generates the ID of a new item, so its fields can be bound before the row is inserted.
+func NewItemID() pgtype.UUID {
+	return pgtype.UUID{Bytes: uuid.New(), Valid: true}
+}
+
+// ItemChecker looks up whether an item ID is taken.
+type ItemChecker interface {
+	ItemExists(ctx context.Context, id pgtype.UUID) (bool, error)
+}
+
+var (
+	// ErrInvalidItemID is returned when a client chose an item ID that is not a UUID.
+	ErrInvalidItemID = status.Error(codes.InvalidArgument, "invalid item id")
+	// ErrItemExists is returned when a client chose the ID of an existing item.
+	ErrItemExists = status.Error(codes.AlreadyExists, "item already exists")
+)
+
+// StoreItemID returns the ID of a new item in a vault with the given keys. Clients of zero-knowledge vaults
+// (userKeys.Current is empty) may choose it, to bind the fields they seal before the item exists; it must
+// not be taken yet. Every other vault gets a new ID, whatever the client sent.
+func StoreItemID(ctx context.Context, storage ItemChecker, userKeys UserKeys, requested string) (pgtype.UUID, error) {
+	if userKeys.Current != "" || requested == "" {
+		return NewItemID(), nil
+	}
+
+	var itemID pgtype.UUID
+	if err := itemID.Scan(requested); err != nil {
+		return pgtype.UUID{}, ErrInvalidItemID
+	}
+
+	exists, err := storage.ItemExists(ctx, itemID)
+	if err != nil {
+		return pgtype.UUID{}, errors.Wrap(err, "error checking item id")
+	}
+
+	if exists {
+		return pgtype.UUID{}, ErrItemExists
+	}
+
+	return itemID, nil
+}
+
+// Bind creates the binding of a field of an item.
+func Bind(userID, itemID pgtype.UUID, field string) Binding {
+	return Binding{UserID: userID, ItemID: itemID, Field: field}
+}
+
+// AdditionalData encodes the binding; UUIDs have a fixed length, so the encoding is unambiguous.
+func (b Binding) AdditionalData() []byte {
+	return []byte("gpm/v2|" + b.UserID.String() + "|" + b.ItemID.String() + "|" + b.Field)
+}
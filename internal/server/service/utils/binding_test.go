@@ -0,0 +1,141 @@
+package utils_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func newBinding(field string) utils.Binding {
+	return utils.Bind(pgtype.UUID{Bytes: uuid.New(), Valid: true}, utils.NewItemID(), field)
+}
+
+func TestEncryptBound(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	binding := newBinding(utils.FieldCardNumber)
+
+	stored, err := utils.EncryptBound("4111111111111111", key, binding)
+	require.NoError(t, err)
+	assert.False(t, utils.IsLegacyCiphertext(stored))
+
+	decrypted, err := utils.DecryptBound(stored, key, binding)
+	require.NoError(t, err)
+	assert.Equal(t, "4111111111111111", decrypted)
+
+	// A value copied to another user, item or field no longer decrypts.
+	for _, other := range []utils.Binding{
+		utils.Bind(pgtype.UUID{Bytes: uuid.New(), Valid: true}, binding.ItemID, binding.Field),
+		utils.Bind(binding.UserID, utils.NewItemID(), binding.Field),
+		utils.Bind(binding.UserID, binding.ItemID, utils.FieldCVV),
+	} {
+		_, err = utils.DecryptBound(stored, key, other)
+		require.Error(t, err)
+	}
+
+	// Values written before ciphertexts were bound stay readable.
+	legacy, err := utils.Encrypt("123", key)
+	require.NoError(t, err)
+	assert.True(t, utils.IsLegacyCiphertext(legacy))
+
+	decrypted, err = utils.DecryptBound(legacy, key, binding)
+	require.NoError(t, err)
+	assert.Equal(t, "123", decrypted)
+}
+
+func TestBoundEncryptor(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	binding := newBinding(utils.FieldFile)
+	content := bytes.Repeat([]byte("file content "), 200)
+
+	var buf bytes.Buffer
+	encryptor, err := utils.NewBoundEncryptor(&buf, key, binding)
+	require.NoError(t, err)
+	_, err = encryptor.Write(content)
+	require.NoError(t, err)
+
+	stored := buf.Bytes()
+
+	decryptor, err := utils.NewBoundDecryptor(bytes.NewReader(stored), key, binding)
+	require.NoError(t, err)
+	assert.True(t, decryptor.Bound())
+	assert.Equal(t, content, readBlocks(t, decryptor))
+
+	decryptor, err = utils.NewBoundDecryptor(bytes.NewReader(stored), key, newBinding(utils.FieldFile))
+	require.NoError(t, err)
+	_, err = decryptor.Read(make([]byte, 1024))
+	require.Error(t, err)
+
+	// Files written before ciphertexts were bound have no header and stay readable.
+	buf.Reset()
+	legacy, err := utils.NewEncryptor(&buf, key)
+	require.NoError(t, err)
+	_, err = legacy.Write(content)
+	require.NoError(t, err)
+
+	decryptor, err = utils.NewBoundDecryptor(&buf, key, binding)
+	require.NoError(t, err)
+	assert.False(t, decryptor.Bound())
+	assert.Equal(t, content, readBlocks(t, decryptor))
+}
+
+func TestStoreItemID(t *testing.T) {
+	t.Parallel()
+
+	storage := testutils.SetupMockUserStorage("")
+	zeroKnowledge := utils.UserKeys{}
+	serverKeys := utils.UserKeys{Current: "key", Version: 1}
+
+	chosen := uuid.NewString()
+	itemID, err := utils.StoreItemID(t.Context(), storage, zeroKnowledge, chosen)
+	require.NoError(t, err)
+	assert.Equal(t, chosen, itemID.String())
+
+	itemID, err = utils.StoreItemID(t.Context(), storage, serverKeys, chosen)
+	require.NoError(t, err)
+	assert.NotEqual(t, chosen, itemID.String())
+
+	generated, err := utils.StoreItemID(t.Context(), storage, zeroKnowledge, "")
+	require.NoError(t, err)
+	assert.True(t, generated.Valid)
+
+	_, err = utils.StoreItemID(t.Context(), storage, zeroKnowledge, "not-a-uuid")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	note, err := storage.StoreNote(t.Context(), db.CreateNoteEntryParams{
+		UserID: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+	}, pgtype.UUID{})
+	require.NoError(t, err)
+
+	_, err = utils.StoreItemID(t.Context(), storage, zeroKnowledge, note.ID.String())
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+// readBlocks reads the Decryptor block by block, like the file service does.
+func readBlocks(t *testing.T, reader io.Reader) []byte {
+	t.Helper()
+
+	var content bytes.Buffer
+	_, err := io.CopyBuffer(struct{ io.Writer }{&content}, struct{ io.Reader }{reader}, make([]byte, 1024))
+	require.NoError(t, err)
+
+	return content.Bytes()
+}
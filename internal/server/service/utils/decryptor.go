@@ -2,6 +2,7 @@
 package utils
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/base64"
@@ -12,11 +13,35 @@ import (
 
 // Decryptor wraps an io.Reader and decrypts data in blocks.
 type Decryptor struct {
-	reader io.Reader
-	gcm    cipher.AEAD
+	reader         io.Reader
+	gcm            cipher.AEAD
+	additionalData []byte
 }
 
 func NewDecryptor(reader io.Reader, base64Key string) (*Decryptor, error) {
+	return newDecryptor(reader, base64Key, nil)
+}
+
+// NewBoundDecryptor reads files written by NewBoundEncryptor for binding. Files without the header
+// were written by NewEncryptor and are read like NewDecryptor does.
+func NewBoundDecryptor(reader io.Reader, base64Key string, binding Binding) (*Decryptor, error) {
+	header := make([]byte, len(fileHeader))
+
+	n, err := io.ReadFull(reader, header)
+	// An empty file has no blocks to bind.
+	if (err == nil && string(header) == fileHeader) || (n == 0 && errors.Is(err, io.EOF)) {
+		return newDecryptor(reader, base64Key, binding.AdditionalData())
+	}
+
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+
+	// The bytes read belong to the nonce of the first block.
+	return newDecryptor(io.MultiReader(bytes.NewReader(header[:n]), reader), base64Key, nil)
+}
+
+func newDecryptor(reader io.Reader, base64Key string, additionalData []byte) (*Decryptor, error) {
 	key, err := base64.StdEncoding.DecodeString(base64Key)
 	if err != nil {
 		return nil, err
@@ -32,7 +57,12 @@ func NewDecryptor(reader io.Reader, base64Key string) (*Decryptor, error) {
 		return nil, err
 	}
 
-	return &Decryptor{reader: reader, gcm: gcm}, nil
+	return &Decryptor{reader: reader, gcm: gcm, additionalData: additionalData}, nil
+}
+
+// Bound reports whether the file authenticates a binding, false for legacy files.
+func (d *Decryptor) Bound() bool {
+	return d.additionalData != nil
 }
 
 func (d *Decryptor) Read(bytes []byte) (int, error) {
@@ -44,15 +74,18 @@ func (d *Decryptor) Read(bytes []byte) (int, error) {
 		return 0, err
 	}
 
-	// Read the encrypted block
+	// Read the encrypted block; network readers may return it in pieces, and only the last one is short
 	encrypted := make([]byte, len(bytes)+d.gcm.Overhead())
-	cursor, err := d.reader.Read(encrypted)
+	cursor, err := io.ReadFull(d.reader, encrypted)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
 	if err != nil && !errors.Is(err, io.EOF) {
 		return 0, err
 	}
 
 	// Decrypt the data
-	decrypted, err := d.gcm.Open(nil, nonce, encrypted[:cursor], nil)
+	decrypted, err := d.gcm.Open(nil, nonce, encrypted[:cursor], d.additionalData)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to decrypt data")
 	}
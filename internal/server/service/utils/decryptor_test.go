@@ -3,8 +3,11 @@ package utils_test
 import (
 	"bytes"
 	"encoding/base64"
+	"io"
 	"testing"
+	"testing/iotest"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -40,6 +43,39 @@ func TestDecryptor_Read_Success(t *testing.T) {
 	assert.Equal(t, originalText, decrypted[:read])
 }
 
+func TestDecryptor_Read_ShortReads(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	plaintext := bytes.Repeat([]byte("block "), 400)
+
+	var buf bytes.Buffer
+	encryptor, err := utils.NewEncryptor(&buf, key)
+	require.NoError(t, err)
+	_, err = encryptor.Write(plaintext)
+	require.NoError(t, err)
+
+	// Object storage streams arrive in pieces smaller than a block.
+	decryptor, err := utils.NewDecryptor(iotest.OneByteReader(&buf), key)
+	require.NoError(t, err)
+
+	var decrypted bytes.Buffer
+	block := make([]byte, 1024)
+	for {
+		n, err := decryptor.Read(block)
+		decrypted.Write(block[:n])
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, plaintext, decrypted.Bytes())
+}
+
 func TestDecryptor_Read_InvalidKey(t *testing.T) {
 	t.Parallel()
 
@@ -7,11 +7,16 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"io"
+	"strings"
 
 	"github.com/pkg/errors"
 )
 
-const keySize = 32
+const (
+	keySize = 32
+	// boundPrefix marks values written by EncryptBound; older values are plain base64.
+	boundPrefix = "v2:"
+)
 
 // GenerateRandomKey a random encryption key.
 func GenerateRandomKey() (string, error) {
@@ -26,70 +31,109 @@ func GenerateRandomKey() (string, error) {
 
 // Encrypt using AES-GCM.
 func Encrypt(text, base64Key string) (string, error) {
-	key, err := base64.StdEncoding.DecodeString(base64Key)
+	ciphertext, err := seal([]byte(text), base64Key, nil)
 	if err != nil {
 		return "", err
 	}
 
-	block, err := aes.NewCipher(key)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt using AES-GCM.
+func Decrypt(encryptedText, base64Key string) (string, error) {
+	// Decode the base64-encoded ciphertext
+	data, err := base64.StdEncoding.DecodeString(encryptedText)
 	if err != nil {
 		return "", err
 	}
 
-	aesGCM, err := cipher.NewGCM(block)
+	plaintext, err := open(data, base64Key, nil)
 	if err != nil {
 		return "", err
 	}
 
-	nonce := make([]byte, aesGCM.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+	return string(plaintext), nil
+}
+
+// EncryptBound encrypts text like Encrypt and authenticates binding as additional data, so the
+// ciphertext only decrypts for the user, item and field it was written for.
+func EncryptBound(text, base64Key string, binding Binding) (string, error) {
+	ciphertext, err := seal([]byte(text), base64Key, binding.AdditionalData())
+	if err != nil {
 		return "", err
 	}
 
-	ciphertext := aesGCM.Seal(nonce, nonce, []byte(text), nil)
-
-	return base64.StdEncoding.EncodeToString(ciphertext), nil
+	return boundPrefix + base64.StdEncoding.EncodeToString(ciphertext), nil
 }
 
-// Decrypt using AES-GCM.
-func Decrypt(encryptedText, base64Key string) (string, error) {
-	// Decode the base64-encoded key
-	key, err := base64.StdEncoding.DecodeString(base64Key)
+// DecryptBound decrypts a value written by EncryptBound, or by Encrypt before values were bound.
+func DecryptBound(stored, base64Key string, binding Binding) (string, error) {
+	if IsLegacyCiphertext(stored) {
+		return Decrypt(stored, base64Key)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, boundPrefix))
 	if err != nil {
 		return "", err
 	}
 
-	// Decode the base64-encoded ciphertext
-	data, err := base64.StdEncoding.DecodeString(encryptedText)
+	plaintext, err := open(data, base64Key, binding.AdditionalData())
 	if err != nil {
 		return "", err
 	}
 
+	return string(plaintext), nil
+}
+
+// IsLegacyCiphertext reports whether stored was written by Encrypt, without a binding.
+func IsLegacyCiphertext(stored string) bool {
+	return !strings.HasPrefix(stored, boundPrefix)
+}
+
+func newGCM(base64Key string) (cipher.AEAD, error) {
+	// Decode the base64-encoded key
+	key, err := base64.StdEncoding.DecodeString(base64Key)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a new AES cipher block using the decoded key
 	block, err := aes.NewCipher(key)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Create a new GCM mode instance
-	aesGCM, err := cipher.NewGCM(block)
+	return cipher.NewGCM(block)
+}
+
+// seal returns nonce || ciphertext.
+func seal(plaintext []byte, base64Key string, additionalData []byte) ([]byte, error) {
+	aesGCM, err := newGCM(base64Key)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	nonce := make([]byte, aesGCM.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aesGCM.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(data []byte, base64Key string, additionalData []byte) ([]byte, error) {
+	aesGCM, err := newGCM(base64Key)
+	if err != nil {
+		return nil, err
 	}
 
 	// Extract the nonce from the ciphertext
 	nonceSize := aesGCM.NonceSize()
 	if len(data) < nonceSize {
-		return "", errors.New("invalid ciphertext")
+		return nil, errors.New("invalid ciphertext")
 	}
 
 	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
 
-	// Decrypt the ciphertext
-	plaintext, err := aesGCM.Open(nil, nonce, ciphertext, nil)
-	if err != nil {
-		return "", err
-	}
-
-	return string(plaintext), nil
+	return aesGCM.Open(nil, nonce, ciphertext, additionalData)
 }
@@ -9,14 +9,36 @@ import (
 	"io"
 )
 
+// fileHeader starts files written by NewBoundEncryptor. Legacy files start with the nonce of their first block.
+const fileHeader = "GPMF\x02"
+
 // Encryptor wraps an io.Writer and encrypts data in blocks.
 type Encryptor struct {
-	writer io.Writer
-	block  cipher.Block
-	gcm    cipher.AEAD
+	writer         io.Writer
+	block          cipher.Block
+	gcm            cipher.AEAD
+	additionalData []byte
+	// header is written before the first block.
+	header []byte
 }
 
 func NewEncryptor(writer io.Writer, base64Key string) (*Encryptor, error) {
+	return newEncryptor(writer, base64Key, nil)
+}
+
+// NewBoundEncryptor starts the file with a header and authenticates binding with every block.
+func NewBoundEncryptor(writer io.Writer, base64Key string, binding Binding) (*Encryptor, error) {
+	encryptor, err := newEncryptor(writer, base64Key, binding.AdditionalData())
+	if err != nil {
+		return nil, err
+	}
+
+	encryptor.header = []byte(fileHeader)
+
+	return encryptor, nil
+}
+
+func newEncryptor(writer io.Writer, base64Key string, additionalData []byte) (*Encryptor, error) {
 	key, err := base64.StdEncoding.DecodeString(base64Key)
 	if err != nil {
 		return nil, err
@@ -32,7 +54,7 @@ func NewEncryptor(writer io.Writer, base64Key string) (*Encryptor, error) {
 		return nil, err
 	}
 
-	return &Encryptor{writer: writer, block: block, gcm: gcm}, nil
+	return &Encryptor{writer: writer, block: block, gcm: gcm, additionalData: additionalData}, nil
 }
 
 func (e *Encryptor) Write(bytes []byte) (int, error) {
@@ -40,6 +62,14 @@ func (e *Encryptor) Write(bytes []byte) (int, error) {
 	offset := 0
 	totalLength := 0
 
+	if len(e.header) > 0 && len(bytes) > 0 {
+		if _, err := e.writer.Write(e.header); err != nil {
+			return 0, err
+		}
+
+		e.header = nil
+	}
+
 	for offset < len(bytes) {
 		end := offset + blockSize
 		if end > len(bytes) {
@@ -51,7 +81,7 @@ func (e *Encryptor) Write(bytes []byte) (int, error) {
 			return offset, err
 		}
 
-		encrypted := e.gcm.Seal(nil, nonce, bytes[offset:end], nil)
+		encrypted := e.gcm.Seal(nil, nonce, bytes[offset:end], e.additionalData)
 
 		// Write nonce + encrypted block
 		if _, err := e.writer.Write(nonce); err != nil {
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// ToProtoItemType converts a stored item type to its API value.
+func ToProtoItemType(itemType db.ItemType) pb.ItemType {
+	switch itemType {
+	case db.ItemTypeCard:
+		return pb.ItemType_ITEM_TYPE_CARD
+	case db.ItemTypeBinary:
+		return pb.ItemType_ITEM_TYPE_BINARY
+	case db.ItemTypePassword:
+		return pb.ItemType_ITEM_TYPE_PASSWORD
+	case db.ItemTypeText:
+		return pb.ItemType_ITEM_TYPE_NOTE
+	}
+
+	return pb.ItemType_ITEM_TYPE_UNSPECIFIED
+}
+
+// FromProtoItemType converts an API item type to the stored one; it is empty for unspecified types.
+func FromProtoItemType(itemType pb.ItemType) db.ItemType {
+	switch itemType {
+	case pb.ItemType_ITEM_TYPE_CARD:
+		return db.ItemTypeCard
+	case pb.ItemType_ITEM_TYPE_BINARY:
+		return db.ItemTypeBinary
+	case pb.ItemType_ITEM_TYPE_PASSWORD:
+		return db.ItemTypePassword
+	case pb.ItemType_ITEM_TYPE_NOTE:
+		return db.ItemTypeText
+	case pb.ItemType_ITEM_TYPE_UNSPECIFIED:
+	}
+
+	return ""
+}
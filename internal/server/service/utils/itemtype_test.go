@@ -0,0 +1,22 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/npavlov/go-password-manager/gen/proto/item"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+func TestItemTypeConversion(t *testing.T) {
+	t.Parallel()
+
+	for _, itemType := range []db.ItemType{db.ItemTypePassword, db.ItemTypeText, db.ItemTypeCard, db.ItemTypeBinary} {
+		assert.Equal(t, itemType, utils.FromProtoItemType(utils.ToProtoItemType(itemType)))
+	}
+
+	assert.Equal(t, pb.ItemType_ITEM_TYPE_UNSPECIFIED, utils.ToProtoItemType("unknown"))
+	assert.Empty(t, utils.FromProtoItemType(pb.ItemType_ITEM_TYPE_UNSPECIFIED))
+}
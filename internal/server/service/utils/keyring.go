@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// keyIDSeparator separates the master key ID from a user key ciphertext.
+// It is not part of the base64 alphabet, so legacy ciphertexts never contain it.
+const keyIDSeparator = "$"
+
+var (
+	ErrEmptyKeyring    = errors.New("master keyring is empty")
+	ErrUnknownMasterID = errors.New("unknown master key id")
+
+	keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+type masterKey struct {
+	id  string
+	key string
+}
+
+// Keyring holds the master keys that wrap user encryption keys.
+//
+// It is configured as a comma-separated list of "id:base64key" entries, e.g. MASTER_KEY="k2:...,k1:...".
+// The first entry encrypts, every entry decrypts, so new and retired keys can be served side by side
+// while a rotation is running. A bare base64 key is accepted for deployments that predate key IDs;
+// its ciphertexts carry no ID, as they always did.
+type Keyring struct {
+	keys []masterKey
+}
+
+// ParseKeyring parses a keyring specification.
+func ParseKeyring(spec string) (*Keyring, error) {
+	keyring := &Keyring{}
+	seen := make(map[string]bool)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		var key masterKey
+		if id, value, ok := strings.Cut(entry, ":"); ok {
+			if !keyIDPattern.MatchString(id) {
+				return nil, errors.Errorf("invalid master key id %q", id)
+			}
+
+			key = masterKey{id: id, key: value}
+		} else {
+			key = masterKey{id: "", key: entry}
+		}
+
+		if seen[key.id] {
+			return nil, errors.Errorf("duplicate master key id %q", key.id)
+		}
+		seen[key.id] = true
+
+		keyring.keys = append(keyring.keys, key)
+	}
+
+	if len(keyring.keys) == 0 {
+		return nil, ErrEmptyKeyring
+	}
+
+	return keyring, nil
+}
+
+// ActiveID returns the ID of the key new ciphertexts are encrypted with.
+func (k *Keyring) ActiveID() string {
+	return k.keys[0].id
+}
+
+// Encrypt wraps a user key with the active master key.
+func (k *Keyring) Encrypt(userKey string) (string, error) {
+	active := k.keys[0]
+
+	encrypted, err := Encrypt(userKey, active.key)
+	if err != nil {
+		return "", errors.Wrap(err, "error encrypting user key")
+	}
+
+	if active.id == "" {
+		return encrypted, nil
+	}
+
+	return active.id + keyIDSeparator + encrypted, nil
+}
+
+// Decrypt unwraps a user key with whichever master key it was encrypted with.
+func (k *Keyring) Decrypt(encrypted string) (string, error) {
+	userKey, _, err := k.decrypt(encrypted)
+
+	return userKey, err
+}
+
+// Rewrap re-encrypts a user key under the active master key.
+// It reports false, and returns the ciphertext unchanged, when it already is.
+func (k *Keyring) Rewrap(encrypted string) (string, bool, error) {
+	userKey, current, err := k.decrypt(encrypted)
+	if err != nil {
+		return "", false, err
+	}
+
+	if current {
+		return encrypted, false, nil
+	}
+
+	rewrapped, err := k.Encrypt(userKey)
+	if err != nil {
+		return "", false, err
+	}
+
+	return rewrapped, true, nil
+}
+
+// decrypt also reports whether the ciphertext is in the form Encrypt produces today.
+func (k *Keyring) decrypt(encrypted string) (string, bool, error) {
+	if id, ciphertext, ok := strings.Cut(encrypted, keyIDSeparator); ok {
+		for i, key := range k.keys {
+			if key.id == id {
+				userKey, err := Decrypt(ciphertext, key.key)
+
+				return userKey, i == 0, errors.Wrap(err, "error decrypting user key")
+			}
+		}
+
+		return "", false, errors.Wrapf(ErrUnknownMasterID, "master key %q", id)
+	}
+
+	// Ciphertexts without an ID predate the keyring; GCM authentication tells which key fits.
+	var lastErr error
+	for i, key := range k.keys {
+		userKey, err := Decrypt(encrypted, key.key)
+		if err == nil {
+			return userKey, i == 0 && key.id == "", nil
+		}
+
+		lastErr = err
+	}
+
+	return "", false, errors.Wrap(lastErr, "error decrypting user key")
+}
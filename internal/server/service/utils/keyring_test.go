@@ -0,0 +1,103 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+func TestParseKeyring(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	keyring, err := utils.ParseKeyring("k2:" + key + ", k1:" + key)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", keyring.ActiveID())
+
+	keyring, err = utils.ParseKeyring(key)
+	require.NoError(t, err)
+	assert.Empty(t, keyring.ActiveID())
+
+	_, err = utils.ParseKeyring("")
+	require.ErrorIs(t, err, utils.ErrEmptyKeyring)
+
+	_, err = utils.ParseKeyring("k1:" + key + ",k1:" + key)
+	require.ErrorContains(t, err, "duplicate master key id")
+
+	_, err = utils.ParseKeyring("bad id:" + key)
+	require.ErrorContains(t, err, "invalid master key id")
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	oldKey, _ := utils.GenerateRandomKey()
+	newKey, _ := utils.GenerateRandomKey()
+
+	keyring, err := utils.ParseKeyring("k2:" + newKey + ",k1:" + oldKey)
+	require.NoError(t, err)
+
+	encrypted, err := keyring.Encrypt("user-key")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "k2$"))
+
+	decrypted, err := keyring.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "user-key", decrypted)
+
+	// Keys encrypted by the retired key, with or without its ID, still decrypt.
+	legacy, err := utils.Encrypt("legacy-key", oldKey)
+	require.NoError(t, err)
+
+	for _, ciphertext := range []string{legacy, "k1$" + legacy} {
+		decrypted, err = keyring.Decrypt(ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "legacy-key", decrypted)
+	}
+
+	_, err = keyring.Decrypt("k9$" + legacy)
+	require.ErrorIs(t, err, utils.ErrUnknownMasterID)
+
+	retired, err := utils.ParseKeyring("k2:" + newKey)
+	require.NoError(t, err)
+
+	_, err = retired.Decrypt(legacy)
+	require.Error(t, err)
+}
+
+func TestKeyring_Rewrap(t *testing.T) {
+	t.Parallel()
+
+	oldKey, _ := utils.GenerateRandomKey()
+	newKey, _ := utils.GenerateRandomKey()
+
+	keyring, err := utils.ParseKeyring("k2:" + newKey + ",k1:" + oldKey)
+	require.NoError(t, err)
+
+	legacy, err := utils.Encrypt("user-key", oldKey)
+	require.NoError(t, err)
+
+	rewrapped, changed, err := keyring.Rewrap(legacy)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, strings.HasPrefix(rewrapped, "k2$"))
+
+	again, changed, err := keyring.Rewrap(rewrapped)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, rewrapped, again)
+
+	// Bare keys of a keyring without IDs are current.
+	bare, err := utils.ParseKeyring(oldKey)
+	require.NoError(t, err)
+
+	_, changed, err = bare.Rewrap(legacy)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
@@ -0,0 +1,19 @@
+package utils
+
+// DefaultPageSize is used by list endpoints when the client does not request a page size.
+const DefaultPageSize int32 = 50
+
+// Paginate converts a 1-based page number and page size into a SQL limit and offset.
+// Zero values select the first page and the default page size. Requests bound the page so that the
+// offset fits in int32.
+func Paginate(page, pageSize int32) (int32, int32) {
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+
+	return pageSize, (page - 1) * pageSize
+}
@@ -0,0 +1,42 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		page       int32
+		pageSize   int32
+		wantLimit  int32
+		wantOffset int32
+	}{
+		{name: "defaults", page: 0, pageSize: 0, wantLimit: utils.DefaultPageSize, wantOffset: 0},
+		{name: "first page", page: 1, pageSize: 10, wantLimit: 10, wantOffset: 0},
+		{name: "third page", page: 3, pageSize: 10, wantLimit: 10, wantOffset: 20},
+		{
+			name:       "default size on second page",
+			page:       2,
+			pageSize:   0,
+			wantLimit:  utils.DefaultPageSize,
+			wantOffset: utils.DefaultPageSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			limit, offset := utils.Paginate(tt.page, tt.pageSize)
+			assert.Equal(t, tt.wantLimit, limit)
+			assert.Equal(t, tt.wantOffset, offset)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientIP returns the IP address of the calling client, or an empty string when it is unknown.
+func ClientIP(ctx context.Context) string {
+	client, ok := peer.FromContext(ctx)
+	if !ok || client.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(client.Addr.String())
+	if err != nil {
+		return client.Addr.String()
+	}
+
+	return host
+}
+
+// UserAgent returns the user agent the calling client sent, or an empty string.
+func UserAgent(ctx context.Context) string {
+	return firstMetadata(ctx, "user-agent")
+}
+
+// AccessToken returns the access token the calling client sent, or an empty string.
+func AccessToken(ctx context.Context) string {
+	return firstMetadata(ctx, "authorization")
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
@@ -0,0 +1,39 @@
+//nolint:exhaustruct
+package utils_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+func TestClientIP(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, utils.ClientIP(t.Context()))
+
+	ctx := peer.NewContext(t.Context(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	assert.Equal(t, "10.0.0.1", utils.ClientIP(ctx))
+
+	ctx = peer.NewContext(t.Context(), &peer.Peer{Addr: &net.UnixAddr{Name: "/tmp/socket", Net: "unix"}})
+	assert.Equal(t, "/tmp/socket", utils.ClientIP(ctx))
+}
+
+func TestMetadataValues(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, utils.UserAgent(t.Context()))
+	assert.Empty(t, utils.AccessToken(t.Context()))
+
+	ctx := metadata.NewIncomingContext(t.Context(), metadata.Pairs("user-agent", "grpc-go/1.71.0"))
+	assert.Equal(t, "grpc-go/1.71.0", utils.UserAgent(ctx))
+	assert.Empty(t, utils.AccessToken(ctx))
+
+	ctx = metadata.NewIncomingContext(t.Context(), metadata.Pairs("authorization", "token"))
+	assert.Equal(t, "token", utils.AccessToken(ctx))
+}
@@ -0,0 +1,129 @@
+//nolint:wrapcheck
+package utils
+
+import (
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// sharePrefix marks values sealed to a share key.
+	sharePrefix = "x25519:"
+	// shareInfo separates the keys derived for sealing shares from any other use of the shared secret.
+	shareInfo = "gpm/share/v1"
+)
+
+// ErrInvalidShare is returned for values that were not sealed by SealShare.
+var ErrInvalidShare = errors.New("invalid shared value")
+
+// GenerateShareKey creates the X25519 keypair items are shared to, both halves base64 encoded.
+func GenerateShareKey() (string, string, error) {
+	private, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(private.PublicKey().Bytes()),
+		base64.StdEncoding.EncodeToString(private.Bytes()), nil
+}
+
+// SealShare encrypts plaintext so that only the holder of the private half of publicKey can read it:
+// an ephemeral X25519 key agrees on an AES-GCM key with publicKey, and binding is authenticated as
+// additional data. The ephemeral public key is stored in front of the ciphertext.
+func SealShare(plaintext []byte, publicKey string, binding Binding) (string, error) {
+	recipient, err := decodePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := shareKey(ephemeral, recipient, ephemeral.PublicKey())
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(plaintext, key, binding.AdditionalData())
+	if err != nil {
+		return "", err
+	}
+
+	sealed := append(ephemeral.PublicKey().Bytes(), ciphertext...)
+
+	return sharePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// OpenShare decrypts a value sealed by SealShare with the private share key of the recipient.
+func OpenShare(sealed, privateKey string, binding Binding) ([]byte, error) {
+	if !strings.HasPrefix(sealed, sharePrefix) {
+		return nil, ErrInvalidShare
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(sealed, sharePrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	curve := ecdh.X25519()
+
+	rawPrivate, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	private, err := curve.NewPrivateKey(rawPrivate)
+	if err != nil {
+		return nil, err
+	}
+
+	publicSize := len(private.PublicKey().Bytes())
+	if len(data) < publicSize {
+		return nil, ErrInvalidShare
+	}
+
+	ephemeral, err := curve.NewPublicKey(data[:publicSize])
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := shareKey(private, ephemeral, ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(data[publicSize:], key, binding.AdditionalData())
+}
+
+func decodePublicKey(publicKey string) (*ecdh.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return ecdh.X25519().NewPublicKey(raw)
+}
+
+// shareKey derives the base64 AES key of a sealed value from the X25519 agreement of private and peer,
+// salted with the ephemeral public key of the value.
+func shareKey(private *ecdh.PrivateKey, peer, ephemeral *ecdh.PublicKey) (string, error) {
+	secret, err := private.ECDH(peer)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := hkdf.Key(sha256.New, secret, ephemeral.Bytes(), shareInfo, keySize)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
@@ -0,0 +1,52 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+func TestSealShare(t *testing.T) {
+	t.Parallel()
+
+	publicKey, privateKey, err := utils.GenerateShareKey()
+	require.NoError(t, err)
+
+	recipient := utils.NewItemID()
+	itemID := utils.NewItemID()
+	binding := utils.Bind(recipient, itemID, utils.FieldShare)
+
+	sealed, err := utils.SealShare([]byte("secret"), publicKey, binding)
+	require.NoError(t, err)
+	require.NotContains(t, sealed, "secret")
+
+	plain, err := utils.OpenShare(sealed, privateKey, binding)
+	require.NoError(t, err)
+	require.Equal(t, "secret", string(plain))
+
+	// Every value has its own ephemeral key
+	again, err := utils.SealShare([]byte("secret"), publicKey, binding)
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, again)
+
+	// Another recipient or item does not open it
+	_, err = utils.OpenShare(sealed, privateKey, utils.Bind(utils.NewItemID(), itemID, utils.FieldShare))
+	require.Error(t, err)
+
+	_, otherKey, err := utils.GenerateShareKey()
+	require.NoError(t, err)
+
+	_, err = utils.OpenShare(sealed, otherKey, binding)
+	require.Error(t, err)
+
+	_, err = utils.OpenShare("v2:c2VjcmV0", privateKey, binding)
+	require.ErrorIs(t, err, utils.ErrInvalidShare)
+
+	_, err = utils.OpenShare("x25519:AAAA", privateKey, binding)
+	require.ErrorIs(t, err, utils.ErrInvalidShare)
+
+	_, err = utils.SealShare([]byte("secret"), "not a key", binding)
+	require.Error(t, err)
+}
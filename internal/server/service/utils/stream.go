@@ -0,0 +1,304 @@
+//nolint:wrapcheck
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Files written by NewStreamEncryptor follow the STREAM construction. The header is
+//
+//	"GPMF" | version (1) | key ID (4) | chunk size (4) | nonce prefix (7)
+//
+// and is followed by chunks of chunk size plaintext bytes, the last one possibly shorter or empty. Each chunk is
+// sealed with the nonce prefix | chunk counter (4) | final flag (1), and with the header and the binding as
+// additional data, so reordered, dropped or truncated chunks and a changed header fail to decrypt.
+const (
+	streamMagic           = "GPMF\x03"
+	streamNoncePrefixSize = 7
+	streamHeaderSize      = len(streamMagic) + 4 + 4 + streamNoncePrefixSize
+	// StreamChunkSize is the plaintext size of the chunks NewStreamEncryptor writes.
+	StreamChunkSize = 64 * 1024
+	// maxStreamChunkSize bounds the chunk size a header may ask the reader to buffer.
+	maxStreamChunkSize = 1024 * 1024
+)
+
+var (
+	// ErrTruncatedFile is returned when an encrypted file ends before its final chunk.
+	ErrTruncatedFile = errors.New("encrypted file is truncated")
+	// ErrInvalidFileHeader is returned for a stream header that cannot be read.
+	ErrInvalidFileHeader = errors.New("invalid encrypted file header")
+	// ErrTooManyChunks is returned when the chunk counter would wrap around.
+	ErrTooManyChunks = errors.New("encrypted file has too many chunks")
+)
+
+// FileHeader describes an encrypted file. Files written before the stream format have no key ID or
+// chunk size; their Version is 2 for bound files and 0 for legacy ones.
+type FileHeader struct {
+	Version   byte
+	KeyID     uint32
+	ChunkSize uint32
+}
+
+// Stream reports whether the file is in the tamper-evident stream format.
+func (h FileHeader) Stream() bool {
+	return h.Version == streamMagic[len(streamMagic)-1]
+}
+
+// StreamEncryptor encrypts a file in the stream format. Close must be called to write the final chunk;
+// it does not close the underlying writer.
+type StreamEncryptor struct {
+	writer         io.Writer
+	gcm            cipher.AEAD
+	header         []byte
+	additionalData []byte
+	chunkSize      int
+	chunk          []byte
+	counter        uint32
+	started        bool
+	closed         bool
+}
+
+// NewStreamEncryptor encrypts with the key keyID names, see UserKeys.Version, and binds every chunk to binding.
+func NewStreamEncryptor(writer io.Writer, base64Key string, keyID uint32, binding Binding) (*StreamEncryptor, error) {
+	gcm, err := newGCM(base64Key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, streamHeaderSize)
+	copy(header, streamMagic)
+	binary.BigEndian.PutUint32(header[len(streamMagic):], keyID)
+	binary.BigEndian.PutUint32(header[len(streamMagic)+4:], StreamChunkSize)
+
+	if _, err := io.ReadFull(rand.Reader, header[streamHeaderSize-streamNoncePrefixSize:]); err != nil {
+		return nil, err
+	}
+
+	return &StreamEncryptor{
+		writer:         writer,
+		gcm:            gcm,
+		header:         header,
+		additionalData: append(bytes.Clone(header), binding.AdditionalData()...),
+		chunkSize:      StreamChunkSize,
+		chunk:          make([]byte, 0, StreamChunkSize),
+	}, nil
+}
+
+// Write buffers p and writes every chunk that is known not to be the last one. It returns len(p).
+func (e *StreamEncryptor) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	written := 0
+
+	for len(p) > 0 {
+		// A full chunk is only written once more data shows it is not the final one.
+		if len(e.chunk) == e.chunkSize {
+			if err := e.writeChunk(false); err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(e.chunk[len(e.chunk):e.chunkSize], p)
+		e.chunk = e.chunk[:len(e.chunk)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+// Close writes the buffered data as the final chunk. An empty file still gets a header and a final chunk.
+func (e *StreamEncryptor) Close() error {
+	if e.closed {
+		return nil
+	}
+
+	e.closed = true
+
+	return e.writeChunk(true)
+}
+
+func (e *StreamEncryptor) writeChunk(final bool) error {
+	if !e.started {
+		if _, err := e.writer.Write(e.header); err != nil {
+			return err
+		}
+
+		e.started = true
+	}
+
+	if !final && e.counter == math.MaxUint32 {
+		return ErrTooManyChunks
+	}
+
+	sealed := e.gcm.Seal(nil, streamNonce(e.header, e.counter, final), e.chunk, e.additionalData)
+	if _, err := e.writer.Write(sealed); err != nil {
+		return err
+	}
+
+	e.counter++
+	e.chunk = e.chunk[:0]
+
+	return nil
+}
+
+// StreamDecryptor reads a file written by StreamEncryptor. A chunk is only returned once it was authenticated,
+// and reading fails unless the file ends with its final chunk.
+type StreamDecryptor struct {
+	reader         *bufio.Reader
+	gcm            cipher.AEAD
+	header         []byte
+	additionalData []byte
+	sealed         []byte
+	plain          []byte
+	counter        uint32
+	done           bool
+}
+
+// newStreamDecryptor continues after the magic has been read from reader.
+func newStreamDecryptor(reader io.Reader, base64Key string, binding Binding) (*StreamDecryptor, FileHeader, error) {
+	header := make([]byte, streamHeaderSize)
+	copy(header, streamMagic)
+
+	if _, err := io.ReadFull(reader, header[len(streamMagic):]); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, FileHeader{}, ErrTruncatedFile
+		}
+
+		return nil, FileHeader{}, err
+	}
+
+	parsed := FileHeader{
+		Version:   streamMagic[len(streamMagic)-1],
+		KeyID:     binary.BigEndian.Uint32(header[len(streamMagic):]),
+		ChunkSize: binary.BigEndian.Uint32(header[len(streamMagic)+4:]),
+	}
+
+	if parsed.ChunkSize == 0 || parsed.ChunkSize > maxStreamChunkSize {
+		return nil, FileHeader{}, errors.Wrapf(ErrInvalidFileHeader, "chunk size %d", parsed.ChunkSize)
+	}
+
+	gcm, err := newGCM(base64Key)
+	if err != nil {
+		return nil, FileHeader{}, err
+	}
+
+	return &StreamDecryptor{
+		reader:         bufio.NewReader(reader),
+		gcm:            gcm,
+		header:         header,
+		additionalData: append(bytes.Clone(header), binding.AdditionalData()...),
+		sealed:         make([]byte, int(parsed.ChunkSize)+gcm.Overhead()),
+	}, parsed, nil
+}
+
+func (d *StreamDecryptor) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+
+		if err := d.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+
+	return n, nil
+}
+
+func (d *StreamDecryptor) readChunk() error {
+	n, err := io.ReadFull(d.reader, d.sealed)
+
+	var final bool
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return ErrTruncatedFile
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// Only the final chunk is short.
+		final = true
+	case err != nil:
+		return err
+	default:
+		// A full chunk is the final one if nothing follows it.
+		if _, err := d.reader.Peek(1); errors.Is(err, io.EOF) {
+			final = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if !final && d.counter == math.MaxUint32 {
+		return ErrTooManyChunks
+	}
+
+	plain, err := d.gcm.Open(nil, streamNonce(d.header, d.counter, final), d.sealed[:n], d.additionalData)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decrypt chunk %d", d.counter)
+	}
+
+	d.counter++
+	d.plain = plain
+	d.done = final
+
+	return nil
+}
+
+// streamNonce is the nonce prefix of the header, the big-endian chunk counter and the final flag.
+func streamNonce(header []byte, counter uint32, final bool) []byte {
+	nonce := make([]byte, streamNoncePrefixSize+4+1)
+	copy(nonce, header[streamHeaderSize-streamNoncePrefixSize:])
+	binary.BigEndian.PutUint32(nonce[streamNoncePrefixSize:], counter)
+
+	if final {
+		nonce[len(nonce)-1] = 1
+	}
+
+	return nonce
+}
+
+// NewFileDecryptor reads a file in any format the server has written: the stream format, bound blocks or
+// legacy blocks. The returned header tells which one it was.
+//
+//nolint:ireturn
+func NewFileDecryptor(reader io.Reader, base64Key string, binding Binding) (io.Reader, FileHeader, error) {
+	magic := make([]byte, len(streamMagic))
+
+	n, err := io.ReadFull(reader, magic)
+	if err == nil && string(magic) == streamMagic {
+		decryptor, header, err := newStreamDecryptor(reader, base64Key, binding)
+		if err != nil {
+			return nil, FileHeader{}, err
+		}
+
+		return decryptor, header, nil
+	}
+
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, FileHeader{}, err
+	}
+
+	decryptor, err := NewBoundDecryptor(io.MultiReader(bytes.NewReader(magic[:n]), reader), base64Key, binding)
+	if err != nil {
+		return nil, FileHeader{}, err
+	}
+
+	if decryptor.Bound() {
+		return decryptor, FileHeader{Version: fileHeader[len(fileHeader)-1]}, nil
+	}
+
+	return decryptor, FileHeader{}, nil
+}
@@ -0,0 +1,192 @@
+package utils_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+const streamHeaderSize = 20
+
+func encryptStream(t *testing.T, key string, keyID uint32, binding utils.Binding, content []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encryptor, err := utils.NewStreamEncryptor(&buf, key, keyID, binding)
+	require.NoError(t, err)
+
+	// Write in pieces that do not line up with the chunks.
+	for chunk := range slices.Chunk(content, 1000) {
+		_, err = encryptor.Write(chunk)
+		require.NoError(t, err)
+	}
+	require.NoError(t, encryptor.Close())
+
+	return buf.Bytes()
+}
+
+func decryptStream(key string, binding utils.Binding, stored []byte) ([]byte, error) {
+	decryptor, header, err := utils.NewFileDecryptor(bytes.NewReader(stored), key, binding)
+	if err != nil {
+		return nil, err
+	}
+
+	if !header.Stream() {
+		return nil, utils.ErrInvalidFileHeader
+	}
+
+	return io.ReadAll(decryptor)
+}
+
+func TestStreamEncryptor(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	binding := newBinding(utils.FieldFile)
+
+	for _, size := range []int{0, 1, utils.StreamChunkSize - 1, utils.StreamChunkSize, 3*utils.StreamChunkSize + 7} {
+		content := make([]byte, size)
+		_, err = rand.Read(content)
+		require.NoError(t, err)
+
+		stored := encryptStream(t, key, 3, binding, content)
+
+		decryptor, header, err := utils.NewFileDecryptor(bytes.NewReader(stored), key, binding)
+		require.NoError(t, err)
+		assert.True(t, header.Stream())
+		assert.Equal(t, uint32(3), header.KeyID)
+		assert.Equal(t, uint32(utils.StreamChunkSize), header.ChunkSize)
+
+		decrypted, err := io.ReadAll(decryptor)
+		require.NoError(t, err)
+		assert.Equal(t, content, decrypted, "size %d", size)
+	}
+}
+
+func TestStreamDecryptor_Tampering(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	binding := newBinding(utils.FieldFile)
+	content := make([]byte, 2*utils.StreamChunkSize+100)
+	_, err = rand.Read(content)
+	require.NoError(t, err)
+
+	stored := encryptStream(t, key, 1, binding, content)
+	sealedChunk := utils.StreamChunkSize + 16
+	first := stored[streamHeaderSize : streamHeaderSize+sealedChunk]
+	second := stored[streamHeaderSize+sealedChunk : streamHeaderSize+2*sealedChunk]
+
+	// Dropping the final chunk, or cutting the file anywhere, is detected.
+	_, err = decryptStream(key, binding, stored[:streamHeaderSize+2*sealedChunk])
+	require.Error(t, err)
+	_, err = decryptStream(key, binding, stored[:streamHeaderSize+sealedChunk])
+	require.Error(t, err)
+	_, err = decryptStream(key, binding, stored[:streamHeaderSize])
+	require.ErrorIs(t, err, utils.ErrTruncatedFile)
+	_, err = decryptStream(key, binding, stored[:streamHeaderSize-1])
+	require.ErrorIs(t, err, utils.ErrTruncatedFile)
+	_, err = decryptStream(key, binding, stored[:len(stored)-1])
+	require.Error(t, err)
+
+	// Reordered chunks do not decrypt.
+	final := stored[streamHeaderSize+2*sealedChunk:]
+	swapped := bytes.Join([][]byte{stored[:streamHeaderSize], second, first, final}, nil)
+	_, err = decryptStream(key, binding, swapped)
+	require.Error(t, err)
+
+	// A changed header, key ID included, does not decrypt.
+	changed := bytes.Clone(stored)
+	changed[8]++
+	_, err = decryptStream(key, binding, changed)
+	require.Error(t, err)
+
+	// A header asking for huge chunks is refused before anything is buffered.
+	changed = bytes.Clone(stored)
+	changed[9] = 0xff
+	_, err = decryptStream(key, binding, changed)
+	require.ErrorIs(t, err, utils.ErrInvalidFileHeader)
+
+	// The file is bound to its record.
+	_, err = decryptStream(key, newBinding(utils.FieldFile), stored)
+	require.Error(t, err)
+
+	decrypted, err := decryptStream(key, binding, stored)
+	require.NoError(t, err)
+	assert.Equal(t, content, decrypted)
+}
+
+func TestNewFileDecryptor_OlderFormats(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	binding := newBinding(utils.FieldFile)
+	content := bytes.Repeat([]byte("file content "), 200)
+
+	var buf bytes.Buffer
+	bound, err := utils.NewBoundEncryptor(&buf, key, binding)
+	require.NoError(t, err)
+	_, err = bound.Write(content)
+	require.NoError(t, err)
+
+	decryptor, header, err := utils.NewFileDecryptor(&buf, key, binding)
+	require.NoError(t, err)
+	assert.False(t, header.Stream())
+	assert.Equal(t, byte(2), header.Version)
+	assert.Equal(t, content, readBlocks(t, decryptor))
+
+	buf.Reset()
+	legacy, err := utils.NewEncryptor(&buf, key)
+	require.NoError(t, err)
+	_, err = legacy.Write(content)
+	require.NoError(t, err)
+
+	decryptor, header, err = utils.NewFileDecryptor(&buf, key, binding)
+	require.NoError(t, err)
+	assert.Equal(t, utils.FileHeader{}, header)
+	assert.Equal(t, content, readBlocks(t, decryptor))
+}
+
+func TestUserKeys_OpenReaderKeyVersion(t *testing.T) {
+	t.Parallel()
+
+	current, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+	previous, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	binding := newBinding(utils.FieldFile)
+	keys := utils.UserKeys{Current: current, Previous: previous, Version: 2}
+
+	var buf bytes.Buffer
+	writer, err := keys.SealWriter(&buf, binding)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("file content"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	stored := buf.Bytes()
+
+	reader, err := keys.OpenReader(bytes.NewReader(stored), 2, binding)
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "file content", string(content))
+
+	// A file whose entry names another key version than its header is refused.
+	_, err = utils.UserKeys{Current: current, Version: 1}.OpenReader(bytes.NewReader(stored), 1, binding)
+	require.ErrorIs(t, err, utils.ErrUnknownKeyVersion)
+}
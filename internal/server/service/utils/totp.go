@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	// TOTPIssuer names the service in authenticator apps.
+	TOTPIssuer = "Go Password Manager"
+	// RecoveryCodeCount is how many recovery codes enabling two-factor authentication hands out.
+	RecoveryCodeCount = 10
+
+	totpPeriod = 30
+	// totpSkew accepts codes of one step before and after the current one, for clocks that drift.
+	totpSkew = 1
+
+	recoveryCodeAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+	recoveryCodeLength   = 10
+)
+
+// ErrInvalidTOTPCode is returned for a code that does not match the secret.
+var ErrInvalidTOTPCode = errors.New("invalid totp code")
+
+//nolint:gochecknoglobals
+var totpCodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+// GenerateTOTPKey creates a new TOTP secret for the account, with its otpauth:// URI.
+func GenerateTOTPKey(account string) (*otp.Key, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      TOTPIssuer,
+		AccountName: account,
+		Period:      totpPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+
+	return key, errors.Wrap(err, "error generating totp key")
+}
+
+// TOTPQRCode draws the otpauth:// URI as a QR code with block characters, two modules per line.
+func TOTPQRCode(uri string) (string, error) {
+	code, err := qrcode.New(uri, qrcode.Medium)
+	if err != nil {
+		return "", errors.Wrap(err, "error generating qr code")
+	}
+
+	return code.ToSmallString(false), nil
+}
+
+// IsTOTPCode reports whether code has the format of a TOTP code rather than of a recovery code.
+func IsTOTPCode(code string) bool {
+	return totpCodePattern.MatchString(code)
+}
+
+// ValidateTOTP checks a code against the base32 secret at now and returns the time step it belongs to,
+// which callers record so the code cannot be used again.
+func ValidateTOTP(secret, code string, now time.Time) (int64, error) {
+	if !IsTOTPCode(code) {
+		return 0, ErrInvalidTOTPCode
+	}
+
+	current := now.Unix() / totpPeriod
+
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0), totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "error generating totp code")
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, nil
+		}
+	}
+
+	return 0, ErrInvalidTOTPCode
+}
+
+// GenerateRecoveryCodes returns RecoveryCodeCount random codes formatted like "abcde-fghjk".
+// The alphabet leaves out characters that are easily mistaken for each other.
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	random := make([]byte, recoveryCodeLength)
+
+	for i := range codes {
+		if _, err := rand.Read(random); err != nil {
+			return nil, errors.Wrap(err, "error generating recovery code")
+		}
+
+		var code strings.Builder
+
+		for j, b := range random {
+			if j == recoveryCodeLength/2 {
+				code.WriteByte('-')
+			}
+			// The alphabet has 32 characters, so every one is equally likely.
+			code.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+		}
+
+		codes[i] = code.String()
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode hashes a recovery code for storage, ignoring case, dashes and spaces.
+// Recovery codes are random, so a fast hash is enough.
+func HashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	hash := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(hash[:])
+}
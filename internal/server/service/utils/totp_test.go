@@ -0,0 +1,75 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+func TestGenerateTOTPKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateTOTPKey("alice")
+	require.NoError(t, err)
+	assert.Contains(t, key.URL(), "otpauth://totp/")
+	assert.Equal(t, utils.TOTPIssuer, key.Issuer())
+	assert.Equal(t, "alice", key.AccountName())
+	assert.NotEmpty(t, key.Secret())
+
+	qr, err := utils.TOTPQRCode(key.URL())
+	require.NoError(t, err)
+	assert.Greater(t, strings.Count(qr, "\n"), 10)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateTOTPKey("alice")
+	require.NoError(t, err)
+
+	now := time.Unix(1_700_000_010, 0)
+	code, err := totp.GenerateCode(key.Secret(), now)
+	require.NoError(t, err)
+
+	step, err := utils.ValidateTOTP(key.Secret(), code, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Unix()/30, step)
+
+	// A code of the previous step is accepted for clocks that drift, and reports its own step.
+	step, err = utils.ValidateTOTP(key.Secret(), code, now.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, now.Unix()/30, step)
+
+	_, err = utils.ValidateTOTP(key.Secret(), code, now.Add(5*time.Minute))
+	require.ErrorIs(t, err, utils.ErrInvalidTOTPCode)
+
+	_, err = utils.ValidateTOTP(key.Secret(), "abcdef", now)
+	require.ErrorIs(t, err, utils.ErrInvalidTOTPCode)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	codes, err := utils.GenerateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, utils.RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, code)
+		assert.False(t, utils.IsTOTPCode(code))
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	// Codes typed without the dash or in upper case still match.
+	hash := utils.HashRecoveryCode(codes[0])
+	assert.Equal(t, hash, utils.HashRecoveryCode(strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))))
+	assert.NotEqual(t, hash, utils.HashRecoveryCode(codes[1]))
+}
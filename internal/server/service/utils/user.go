@@ -7,16 +7,21 @@ import (
 	"github.com/pkg/errors"
 )
 
-func GetDecryptionKey(ctx context.Context, storage UserGetter, masterKey string) (pgtype.UUID, string, error) {
+func GetDecryptionKey(ctx context.Context, storage UserGetter, keys KeyUnwrapper) (pgtype.UUID, UserKeys, error) {
 	userUUID, err := GetUserID(ctx)
 	if err != nil {
-		return pgtype.UUID{}, "", errors.Wrap(err, "error getting user id")
+		return pgtype.UUID{}, UserKeys{}, errors.Wrap(err, "error getting user id")
 	}
 
-	decryptedUserKey, err := GetUserKey(ctx, storage, userUUID, masterKey)
+	userKeys, err := GetUserKey(ctx, storage, userUUID, keys)
 	if err != nil {
-		return pgtype.UUID{}, "", errors.Wrap(err, "error getting decryption key")
+		return pgtype.UUID{}, UserKeys{}, errors.Wrap(err, "error getting decryption key")
 	}
 
-	return userUUID, decryptedUserKey, nil
+	return userUUID, userKeys, nil
+}
+
+// ObjectPrefix returns the prefix of the names of the objects holding the files of a user.
+func ObjectPrefix(userID pgtype.UUID) string {
+	return userID.String() + "-"
 }
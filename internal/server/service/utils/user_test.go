@@ -8,8 +8,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/npavlov/go-password-manager/internal/server/db"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
 	"github.com/npavlov/go-password-manager/internal/server/service/utils"
 	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	secure "github.com/npavlov/go-password-manager/internal/utils"
 )
 
 func TestGetDecryptionKey_Success(t *testing.T) {
@@ -33,11 +35,12 @@ func TestGetDecryptionKey_Success(t *testing.T) {
 	// Inject user ID and encryption key into context
 	ctx := testutils.InjectUserToContext(t.Context(), testUser.ID.String())
 
-	userUUID, decryptedKey, err := utils.GetDecryptionKey(ctx, mockStorage, masterKey)
+	keys := kms.NewLocalProvider(secure.NewString(masterKey))
+	userUUID, decryptedKey, err := utils.GetDecryptionKey(ctx, mockStorage, keys)
 
 	require.NoError(t, err)
 	require.True(t, userUUID.Valid)
-	require.NotEmpty(t, decryptedKey)
+	require.Equal(t, encryptionKey, decryptedKey.Current)
 }
 
 func TestGetDecryptionKey_MissingUserID(t *testing.T) {
@@ -49,7 +52,7 @@ func TestGetDecryptionKey_MissingUserID(t *testing.T) {
 	// Use empty context – no user injected
 	ctx := t.Context()
 
-	_, _, err := utils.GetDecryptionKey(ctx, mockStorage, masterKey)
+	_, _, err := utils.GetDecryptionKey(ctx, mockStorage, kms.NewLocalProvider(secure.NewString(masterKey)))
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "error getting user id")
 }
@@ -77,7 +80,17 @@ func TestGetDecryptionKey_InvalidMasterKey(t *testing.T) {
 	// Use wrong master key for decryption
 	badMasterKey := "wrong-master-key"
 
-	_, _, err := utils.GetDecryptionKey(ctx, mockStorage, badMasterKey)
+	_, _, err := utils.GetDecryptionKey(ctx, mockStorage, kms.NewLocalProvider(secure.NewString(badMasterKey)))
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "error getting decryption key")
 }
+
+func TestObjectPrefix(t *testing.T) {
+	t.Parallel()
+
+	userID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	other := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	require.Equal(t, userID.String()+"-", utils.ObjectPrefix(userID))
+	require.NotContains(t, utils.ObjectPrefix(other)+"report.pdf", utils.ObjectPrefix(userID))
+}
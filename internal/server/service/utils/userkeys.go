@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrUnknownKeyVersion is returned for rows encrypted with a user key that is no longer kept.
+	ErrUnknownKeyVersion = errors.New("unknown user key version")
+	// ErrLegacyCiphertext is returned for unbound values once all values of a user were bound.
+	ErrLegacyCiphertext = errors.New("value is not bound to its record")
+)
+
+// UserKeys are the data keys of a user. Every row records the version of the key it was written with;
+// while a key rotation re-encrypts the vault, rows of the previous version are read with Previous.
+// Both keys are empty for zero-knowledge accounts.
+type UserKeys struct {
+	Current  string
+	Version  int32
+	Previous string
+	// Legacy allows values written before ciphertexts were bound, until a key rotation rewrote them.
+	Legacy bool
+}
+
+// Key returns the key rows written with version are encrypted with.
+func (k UserKeys) Key(version int32) (string, error) {
+	switch {
+	case version == k.Version:
+		return k.Current, nil
+	case version == k.Version-1 && k.Previous != "":
+		return k.Previous, nil
+	default:
+		return "", errors.Wrapf(ErrUnknownKeyVersion, "%d", version)
+	}
+}
+
+// Seal encrypts a field with the current key, see SealField.
+func (k UserKeys) Seal(value string, binding Binding) (string, error) {
+	return SealField(value, k.Current, binding)
+}
+
+// Open decrypts a field of a row written with the given key version, see OpenField.
+func (k UserKeys) Open(stored string, version int32, binding Binding) (string, error) {
+	key, err := k.Key(version)
+	if err != nil {
+		return "", err
+	}
+
+	if key != "" && !k.Legacy && IsLegacyCiphertext(stored) {
+		return "", ErrLegacyCiphertext
+	}
+
+	return OpenField(stored, key, binding)
+}
+
+// SealWriter encrypts a file with the current key, see NewSealWriter.
+//
+//nolint:ireturn
+func (k UserKeys) SealWriter(writer io.Writer, binding Binding) (io.WriteCloser, error) {
+	return NewSealWriter(writer, k.Current, k.Version, binding)
+}
+
+// OpenReader decrypts a file written with the given key version, see NewOpenReader.
+//
+//nolint:ireturn
+func (k UserKeys) OpenReader(reader io.Reader, version int32, binding Binding) (io.Reader, error) {
+	key, err := k.Key(version)
+	if err != nil || key == "" {
+		return reader, err
+	}
+
+	decryptor, header, err := NewFileDecryptor(reader, key, binding)
+	if err != nil {
+		return nil, err
+	}
+
+	if !k.Legacy && header.Version == 0 {
+		return nil, ErrLegacyCiphertext
+	}
+
+	//nolint:gosec
+	if header.Stream() && header.KeyID != uint32(version) {
+		return nil, errors.Wrapf(ErrUnknownKeyVersion, "file header names key %d, expected %d", header.KeyID, version)
+	}
+
+	return decryptor, nil
+}
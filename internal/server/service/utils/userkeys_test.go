@@ -0,0 +1,92 @@
+package utils_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+)
+
+func TestUserKeys(t *testing.T) {
+	t.Parallel()
+
+	previous, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+	current, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	oldKeys := utils.UserKeys{Current: previous, Version: 1}
+	keys := utils.UserKeys{Current: current, Version: 2, Previous: previous}
+
+	binding := newBinding(utils.FieldNote)
+
+	oldRow, err := oldKeys.Seal("old secret", binding)
+	require.NoError(t, err)
+	newRow, err := keys.Seal("new secret", binding)
+	require.NoError(t, err)
+
+	// During a rotation rows of both versions are readable.
+	opened, err := keys.Open(oldRow, 1, binding)
+	require.NoError(t, err)
+	assert.Equal(t, "old secret", opened)
+
+	opened, err = keys.Open(newRow, 2, binding)
+	require.NoError(t, err)
+	assert.Equal(t, "new secret", opened)
+
+	// Once it completed the previous key is gone.
+	keys.Previous = ""
+	_, err = keys.Open(oldRow, 1, binding)
+	require.ErrorIs(t, err, utils.ErrUnknownKeyVersion)
+
+	_, err = keys.Key(3)
+	require.ErrorIs(t, err, utils.ErrUnknownKeyVersion)
+}
+
+func TestUserKeys_Legacy(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	binding := newBinding(utils.FieldPassword)
+
+	legacyRow, err := utils.Encrypt("legacy secret", key)
+	require.NoError(t, err)
+
+	var legacyFile bytes.Buffer
+	encryptor, err := utils.NewEncryptor(&legacyFile, key)
+	require.NoError(t, err)
+	_, err = encryptor.Write([]byte("legacy file"))
+	require.NoError(t, err)
+
+	// Unbound values are read until a key rotation rewrote them.
+	keys := utils.UserKeys{Current: key, Version: 1, Legacy: true}
+
+	opened, err := keys.Open(legacyRow, 1, binding)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy secret", opened)
+
+	reader, err := keys.OpenReader(bytes.NewReader(legacyFile.Bytes()), 1, binding)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("legacy file"), readBlocks(t, reader))
+
+	// Afterwards they are refused, so an unbound value cannot be planted in a bound vault.
+	keys.Legacy = false
+
+	_, err = keys.Open(legacyRow, 1, binding)
+	require.ErrorIs(t, err, utils.ErrLegacyCiphertext)
+
+	_, err = keys.OpenReader(bytes.NewReader(legacyFile.Bytes()), 1, binding)
+	require.ErrorIs(t, err, utils.ErrLegacyCiphertext)
+
+	boundRow, err := keys.Seal("bound secret", binding)
+	require.NoError(t, err)
+
+	opened, err = keys.Open(boundRow, 1, binding)
+	require.NoError(t, err)
+	assert.Equal(t, "bound secret", opened)
+}
@@ -0,0 +1,89 @@
+//nolint:wrapcheck
+package utils
+
+import (
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
+)
+
+// ErrPlaintextValue is returned when a zero-knowledge account sends a value its client did not seal.
+var ErrPlaintextValue = status.Error(codes.InvalidArgument, "zero-knowledge accounts only accept client-sealed values")
+
+// SealField encrypts a vault field with the user key. Zero-knowledge accounts have no key on the
+// server (userKey is empty): their clients seal fields themselves and those are stored as they are.
+func SealField(value, userKey string, binding Binding) (string, error) {
+	if userKey == "" {
+		if !vault.IsSealed(value) {
+			return "", ErrPlaintextValue
+		}
+
+		return value, nil
+	}
+
+	return EncryptBound(value, userKey, binding)
+}
+
+// OpenField decrypts a field stored by SealField, including legacy unbound values. Fields of
+// zero-knowledge accounts are returned still sealed, for the client to open.
+func OpenField(stored, userKey string, binding Binding) (string, error) {
+	if userKey == "" {
+		return stored, nil
+	}
+
+	return DecryptBound(stored, userKey, binding)
+}
+
+// RequireSealed checks that fields the server stores unencrypted, such as logins, were sealed
+// by the client of a zero-knowledge account.
+func RequireSealed(userKey string, values ...string) error {
+	if userKey != "" {
+		return nil
+	}
+
+	for _, value := range values {
+		if !vault.IsSealed(value) {
+			return ErrPlaintextValue
+		}
+	}
+
+	return nil
+}
+
+// NewSealWriter encrypts file contents written to writer with the user key of the given version, see
+// NewStreamEncryptor; Close writes the end of the file. Files of zero-knowledge accounts arrive encrypted
+// by the client and are written as they are.
+//
+//nolint:ireturn
+func NewSealWriter(writer io.Writer, userKey string, version int32, binding Binding) (io.WriteCloser, error) {
+	if userKey == "" {
+		return nopWriteCloser{writer}, nil
+	}
+
+	//nolint:gosec
+	return NewStreamEncryptor(writer, userKey, uint32(version), binding)
+}
+
+// NewOpenReader decrypts file contents written by NewSealWriter, or in an older format.
+//
+//nolint:ireturn
+func NewOpenReader(reader io.Reader, userKey string, binding Binding) (io.Reader, error) {
+	if userKey == "" {
+		return reader, nil
+	}
+
+	decryptor, _, err := NewFileDecryptor(reader, userKey, binding)
+
+	return decryptor, err
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,100 @@
+package utils_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/pkg/vault"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+	secure "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+func TestSealOpenField(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	binding := newBinding(utils.FieldPassword)
+
+	// Regular accounts: the server encrypts.
+	stored, err := utils.SealField("secret", key, binding)
+	require.NoError(t, err)
+	assert.NotEqual(t, "secret", stored)
+
+	opened, err := utils.OpenField(stored, key, binding)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", opened)
+
+	// Zero-knowledge accounts: sealed values pass through, plaintext is refused.
+	sealed := vault.SealedPrefix + "c2VjcmV0"
+
+	stored, err = utils.SealField(sealed, "", binding)
+	require.NoError(t, err)
+	assert.Equal(t, sealed, stored)
+
+	opened, err = utils.OpenField(stored, "", binding)
+	require.NoError(t, err)
+	assert.Equal(t, sealed, opened)
+
+	_, err = utils.SealField("secret", "", binding)
+	require.ErrorIs(t, err, utils.ErrPlaintextValue)
+}
+
+func TestRequireSealed(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, utils.RequireSealed("server-key", "plain"))
+	require.NoError(t, utils.RequireSealed("", vault.SealedPrefix+"a", vault.SealedPrefix+"b"))
+	require.ErrorIs(t, utils.RequireSealed("", vault.SealedPrefix+"a", "plain"), utils.ErrPlaintextValue)
+}
+
+func TestSealWriterOpenReader(t *testing.T) {
+	t.Parallel()
+
+	key, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	binding := newBinding(utils.FieldFile)
+
+	var buf bytes.Buffer
+	writer, err := utils.NewSealWriter(&buf, key, 1, binding)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("file content"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	assert.NotContains(t, buf.String(), "file content")
+
+	reader, err := utils.NewOpenReader(&buf, key, binding)
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "file content", string(content))
+
+	// Without a server key the content is passed through.
+	writer, err = utils.NewSealWriter(&buf, "", 1, binding)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("sealed"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	assert.Equal(t, "sealed", buf.String())
+}
+
+func TestGetUserKey_ZeroKnowledge(t *testing.T) {
+	t.Parallel()
+
+	masterKey, _ := utils.GenerateRandomKey()
+	storage := testutils.SetupMockUserStorage(masterKey)
+	ctx := storage.AddZeroKnowledgeUser(t.Context())
+
+	_, key, err := utils.GetDecryptionKey(ctx, storage, kms.NewLocalProvider(secure.NewString(masterKey)))
+	require.NoError(t, err)
+	assert.Empty(t, key.Current)
+	assert.Empty(t, key.Previous)
+}
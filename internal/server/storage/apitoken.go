@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// CreateAPIToken records a new API token of the user.
+func (ds *DBStorage) CreateAPIToken(ctx context.Context, params db.CreateAPITokenParams) (*db.ApiToken, error) {
+	token, err := ds.Queries.CreateAPIToken(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to create api token")
+
+		return nil, errors.Wrap(err, "failed to create api token")
+	}
+
+	return &token, nil
+}
+
+// GetAPIToken returns the unexpired API token with the hash.
+func (ds *DBStorage) GetAPIToken(ctx context.Context, tokenHash string) (*db.ApiToken, error) {
+	token, err := ds.Queries.GetAPIToken(ctx, tokenHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get api token")
+	}
+
+	return &token, nil
+}
+
+// ListAPITokens returns the API tokens of the user, expired ones included, the newest first.
+func (ds *DBStorage) ListAPITokens(ctx context.Context, userID pgtype.UUID) ([]db.ApiToken, error) {
+	tokens, err := ds.Queries.ListAPITokens(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list api tokens")
+
+		return nil, errors.Wrap(err, "failed to list api tokens")
+	}
+
+	return tokens, nil
+}
+
+// DeleteAPIToken revokes an API token of the user. It reports false when the user has no such token.
+func (ds *DBStorage) DeleteAPIToken(ctx context.Context, userID, tokenID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.DeleteAPIToken(ctx, db.DeleteAPITokenParams{
+		ID:     tokenID,
+		UserID: userID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete api token")
+
+		return false, errors.Wrap(err, "failed to delete api token")
+	}
+
+	return affected == 1, nil
+}
+
+// TouchAPIToken records that the API token was used.
+func (ds *DBStorage) TouchAPIToken(ctx context.Context, tokenID pgtype.UUID) error {
+	if err := ds.Queries.TouchAPIToken(ctx, tokenID); err != nil {
+		ds.log.Error().Err(err).Msg("failed to touch api token")
+
+		return errors.Wrap(err, "failed to touch api token")
+	}
+
+	return nil
+}
+
+// ListScopedItemIDs returns the resource IDs of the user's items of the given types that carry every tag.
+func (ds *DBStorage) ListScopedItemIDs(
+	ctx context.Context,
+	params db.ListScopedItemIDsParams,
+) ([]pgtype.UUID, error) {
+	itemIDs, err := ds.Queries.ListScopedItemIDs(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list scoped items")
+
+		return nil, errors.Wrap(err, "failed to list scoped items")
+	}
+
+	return itemIDs, nil
+}
@@ -0,0 +1,130 @@
+//nolint:exhaustruct
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+//nolint:gochecknoglobals
+var apiTokenColumns = []string{
+	"id", "user_id", "name", "token_hash", "read_only", "item_types", "tags", "created_at", "expires_at",
+	"last_used_at",
+}
+
+func TestCreateAPIToken(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	params := db.CreateAPITokenParams{
+		UserID:    userUUID,
+		Name:      "ci",
+		TokenHash: "hash",
+		ReadOnly:  true,
+		ItemTypes: []string{"password"},
+		Tags:      []byte(`{"env":"ci"}`),
+		ExpiresAt: pgFixedTime,
+	}
+	args := []any{userUUID, "ci", "hash", true, []string{"password"}, []byte(`{"env":"ci"}`), pgFixedTime}
+
+	mock.ExpectQuery("INSERT INTO api_tokens").
+		WithArgs(args...).
+		WillReturnRows(pgxmock.NewRows(apiTokenColumns).
+			AddRow(tokenUUID, userUUID, "ci", "hash", true, []string{"password"}, []byte(`{"env":"ci"}`),
+				pgFixedTime, pgFixedTime, pgtype.Timestamp{}))
+	mock.ExpectQuery("INSERT INTO api_tokens").
+		WithArgs(args...).
+		WillReturnError(errors.New("db error"))
+
+	token, err := storage.CreateAPIToken(t.Context(), params)
+	require.NoError(t, err)
+	require.Equal(t, tokenUUID, token.ID)
+	require.Equal(t, []string{"password"}, token.ItemTypes)
+
+	_, err = storage.CreateAPIToken(t.Context(), params)
+	require.ErrorContains(t, err, "failed to create api token")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAPIToken(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectQuery("SELECT (.+) FROM api_tokens").
+		WithArgs("hash").
+		WillReturnRows(pgxmock.NewRows(apiTokenColumns).
+			AddRow(tokenUUID, userUUID, "ci", "hash", false, []string{}, []byte(`{}`),
+				pgFixedTime, pgFixedTime, pgFixedTime))
+	mock.ExpectQuery("SELECT (.+) FROM api_tokens").
+		WithArgs("expired").
+		WillReturnError(errors.New("no rows in result set"))
+
+	token, err := storage.GetAPIToken(t.Context(), "hash")
+	require.NoError(t, err)
+	require.Equal(t, userUUID, token.UserID)
+	require.False(t, token.ReadOnly)
+
+	_, err = storage.GetAPIToken(t.Context(), "expired")
+	require.ErrorContains(t, err, "failed to get api token")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAPIToken(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectExec("DELETE FROM api_tokens").
+		WithArgs(tokenUUID, userUUID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec("DELETE FROM api_tokens").
+		WithArgs(tokenUUID, userUUID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	ok, err := storage.DeleteAPIToken(t.Context(), userUUID, tokenUUID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = storage.DeleteAPIToken(t.Context(), userUUID, tokenUUID)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListScopedItemIDs(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	params := db.ListScopedItemIDsParams{
+		UserID:    userUUID,
+		ItemTypes: []string{"password"},
+		Tags:      []byte(`{"env":"ci"}`),
+	}
+
+	mock.ExpectQuery("SELECT i.id_resource FROM items").
+		WithArgs(userUUID, []string{"password"}, []byte(`{"env":"ci"}`)).
+		WillReturnRows(pgxmock.NewRows([]string{"id_resource"}).AddRow(tokenUUID))
+	mock.ExpectQuery("SELECT i.id_resource FROM items").
+		WithArgs(userUUID, []string{"password"}, []byte(`{"env":"ci"}`)).
+		WillReturnError(errors.New("db error"))
+
+	itemIDs, err := storage.ListScopedItemIDs(t.Context(), params)
+	require.NoError(t, err)
+	require.Equal(t, []pgtype.UUID{tokenUUID}, itemIDs)
+
+	_, err = storage.ListScopedItemIDs(t.Context(), params)
+	require.ErrorContains(t, err, "failed to list scoped items")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// AppendAuditEvent inserts the event build makes from the end of the audit chain, nil while the log is
+// empty. Appends hold a transaction lock on the chain, so no other event is inserted between reading its
+// end and inserting after it.
+func (ds *DBStorage) AppendAuditEvent(
+	ctx context.Context,
+	build func(last *db.AuditEvent) db.InsertAuditEventParams,
+) error {
+	tx, err := ds.dbCon.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+
+	// Rolling back after the commit does nothing
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	queries := ds.Queries.WithTx(tx)
+
+	if err := queries.LockAuditChain(ctx); err != nil {
+		return errors.Wrap(err, "failed to lock audit chain")
+	}
+
+	var last *db.AuditEvent
+
+	event, err := queries.GetLastAuditEvent(ctx)
+
+	switch {
+	case err == nil:
+		last = &event
+	case !errors.Is(err, pgx.ErrNoRows):
+		return errors.Wrap(err, "failed to get last audit event")
+	}
+
+	if err := queries.InsertAuditEvent(ctx, build(last)); err != nil {
+		ds.log.Error().Err(err).Msg("failed to insert audit event")
+
+		return errors.Wrap(err, "failed to insert audit event")
+	}
+
+	return errors.Wrap(tx.Commit(ctx), "failed to commit audit event")
+}
+
+// ListAuditEvents returns a page of the events a user made or that name an item of the user, the newest first.
+func (ds *DBStorage) ListAuditEvents(ctx context.Context, params db.ListAuditEventsParams) ([]db.AuditEvent, error) {
+	events, err := ds.Queries.ListAuditEvents(ctx, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list audit events")
+	}
+
+	return events, nil
+}
+
+// ExportAuditEvents returns up to batchSize events of the chain after afterSeq, in order.
+func (ds *DBStorage) ExportAuditEvents(ctx context.Context, afterSeq int64, batchSize int32) ([]db.AuditEvent, error) {
+	events, err := ds.Queries.ExportAuditEvents(ctx, db.ExportAuditEventsParams{
+		AfterSeq:  afterSeq,
+		BatchSize: batchSize,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to export audit events")
+	}
+
+	return events, nil
+}
@@ -0,0 +1,98 @@
+//nolint:exhaustruct
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+var auditColumns = []string{
+	"seq", "actor_id", "item_id", "action", "ip", "user_agent", "result", "occurred_at", "prev_hash", "hash",
+}
+
+func TestAppendAuditEvent(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	params := db.InsertAuditEventParams{
+		Seq: 2, ActorID: userUUID, Action: "/test.Method", Result: "OK", OccurredAt: pgFixedTime,
+		PrevHash: "prev", Hash: "hash",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mock.ExpectQuery("SELECT (.+) FROM audit_events").
+		WillReturnRows(pgxmock.NewRows(auditColumns).AddRow(int64(1), userUUID, pgtype.UUID{}, "/test.Method",
+			"", "", "OK", pgFixedTime, "", "prev"))
+	mock.ExpectExec("INSERT INTO audit_events").
+		WithArgs(int64(2), userUUID, pgtype.UUID{}, "/test.Method", "", "", "OK", pgFixedTime, "prev", "hash").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	err := storage.AppendAuditEvent(t.Context(), func(last *db.AuditEvent) db.InsertAuditEventParams {
+		require.Equal(t, "prev", last.Hash)
+
+		return params
+	})
+	require.NoError(t, err)
+
+	// The first event of the log follows nothing
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mock.ExpectQuery("SELECT (.+) FROM audit_events").WillReturnError(pgx.ErrNoRows)
+	mock.ExpectExec("INSERT INTO audit_events").
+		WithArgs(int64(2), userUUID, pgtype.UUID{}, "/test.Method", "", "", "OK", pgFixedTime, "prev", "hash").
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	err = storage.AppendAuditEvent(t.Context(), func(last *db.AuditEvent) db.InsertAuditEventParams {
+		require.Nil(t, last)
+
+		return params
+	})
+	require.ErrorContains(t, err, "failed to insert audit event")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	err = storage.AppendAuditEvent(t.Context(), func(*db.AuditEvent) db.InsertAuditEventParams {
+		return params
+	})
+	require.ErrorContains(t, err, "failed to lock audit chain")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	params := db.ListAuditEventsParams{UserID: userUUID, Action: "/test.Method", Limit: 10}
+
+	mock.ExpectQuery("SELECT (.+) FROM audit_events").
+		WithArgs(userUUID, pgtype.UUID{}, "/test.Method", pgtype.Timestamp{}, pgtype.Timestamp{}, int32(0),
+			int32(10)).
+		WillReturnRows(pgxmock.NewRows(auditColumns).AddRow(int64(1), userUUID, pgtype.UUID{}, "/test.Method",
+			"", "", "OK", pgFixedTime, "", "hash"))
+	mock.ExpectQuery("SELECT (.+) FROM audit_events").
+		WithArgs(int64(1), int32(500)).
+		WillReturnError(errors.New("db error"))
+
+	events, err := storage.ListAuditEvents(t.Context(), params)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	_, err = storage.ExportAuditEvents(t.Context(), 1, 500)
+	require.ErrorContains(t, err, "failed to export audit events")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
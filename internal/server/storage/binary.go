@@ -10,9 +10,20 @@ import (
 	"github.com/npavlov/go-password-manager/internal/utils"
 )
 
-// StoreBinary creates new binary record.
-func (ds *DBStorage) StoreBinary(ctx context.Context, createBinary db.StoreBinaryEntryParams) (*db.BinaryEntry, error) {
-	binary, err := ds.Queries.StoreBinaryEntry(ctx, createBinary)
+// StoreBinary creates new binary record, in the collection when one is given.
+func (ds *DBStorage) StoreBinary(
+	ctx context.Context,
+	createBinary db.StoreBinaryEntryParams,
+	collectionID pgtype.UUID,
+) (*db.BinaryEntry, error) {
+	var binary db.BinaryEntry
+
+	err := ds.storeItem(ctx, collectionID, func(queries *db.Queries) (pgtype.UUID, error) {
+		var err error
+		binary, err = queries.StoreBinaryEntry(ctx, createBinary)
+
+		return binary.ID, err
+	})
 	if err != nil {
 		ds.log.Error().Err(err).Msg("failed to store binary entry")
 
@@ -39,11 +50,12 @@ func (ds *DBStorage) GetBinary(ctx context.Context, binaryID string, userID pgty
 	return &binary, nil
 }
 
-// GetBinaries retrieves binary record.
-func (ds *DBStorage) GetBinaries(ctx context.Context, userID string) ([]db.BinaryEntry, error) {
-	uuid := utils.GetIDFromString(userID)
-
-	cards, err := ds.Queries.GetBinaryEntriesByUserID(ctx, uuid)
+// GetBinaries retrieves a page of binary records owned by the user.
+func (ds *DBStorage) GetBinaries(
+	ctx context.Context,
+	params db.GetBinaryEntriesByUserIDParams,
+) ([]db.BinaryEntry, error) {
+	cards, err := ds.Queries.GetBinaryEntriesByUserID(ctx, params)
 	if err != nil {
 		ds.log.Error().Err(err).Msg("failed to find cards")
 
@@ -53,6 +65,33 @@ func (ds *DBStorage) GetBinaries(ctx context.Context, userID string) ([]db.Binar
 	return cards, nil
 }
 
+// CountBinaries returns the total number of binary records owned by the user.
+func (ds *DBStorage) CountBinaries(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	count, err := ds.Queries.CountBinaryEntriesByUserID(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to count binaries")
+
+		return 0, errors.Wrap(err, "failed to count binaries")
+	}
+
+	return count, nil
+}
+
+// GetBinariesByIDs retrieves the binary records with the given IDs owned by the user.
+func (ds *DBStorage) GetBinariesByIDs(
+	ctx context.Context,
+	params db.GetBinaryEntriesByIDsParams,
+) ([]db.BinaryEntry, error) {
+	binaries, err := ds.Queries.GetBinaryEntriesByIDs(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to get binaries by ids")
+
+		return nil, errors.Wrap(err, "failed to get binaries by ids")
+	}
+
+	return binaries, nil
+}
+
 // DeleteBinary removes binary record.
 func (ds *DBStorage) DeleteBinary(ctx context.Context, arg db.DeleteBinaryEntryParams) error {
 	err := ds.Queries.DeleteBinaryEntry(ctx, arg)
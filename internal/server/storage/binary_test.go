@@ -27,6 +27,7 @@ func TestStoreBinary(t *testing.T) {
 	now := time.Now()
 
 	params := db.StoreBinaryEntryParams{
+		ID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
 		UserID:   pgtype.UUID{Bytes: userID, Valid: true},
 		FileName: "file.txt",
 		FileSize: 1234,
@@ -34,14 +35,14 @@ func TestStoreBinary(t *testing.T) {
 	}
 
 	rows := pgxmock.NewRows([]string{
-		"id", "user_id", "file_name", "file_size", "file_url", "created_at", "updated_at",
-	}).AddRow(id, userID.String(), params.FileName, params.FileSize, params.FileUrl, now, now)
+		"id", "user_id", "file_name", "file_size", "file_url", "created_at", "updated_at", "version", "key_version",
+	}).AddRow(id, userID.String(), params.FileName, params.FileSize, params.FileUrl, now, now, int64(1), int32(1))
 
 	mock.ExpectQuery(`INSERT INTO binary_entries`).
-		WithArgs(params.UserID, params.FileName, params.FileUrl, params.FileSize).
+		WithArgs(params.ID, params.UserID, params.FileName, params.FileUrl, params.FileSize, params.KeyVersion).
 		WillReturnRows(rows)
 
-	entry, err := dbStorage.StoreBinary(ctx, params)
+	entry, err := dbStorage.StoreBinary(ctx, params, pgtype.UUID{})
 	require.NoError(t, err)
 	require.NotNil(t, entry)
 	assert.Equal(t, params.FileName, entry.FileName)
@@ -66,8 +67,11 @@ func TestGetBinary(t *testing.T) {
 	}
 
 	rows := pgxmock.NewRows([]string{
-		"id", "user_id", "file_name", "file_size", "file_url", "created_at", "updated_at",
-	}).AddRow(binaryID.String(), userID.String(), "img.png", int64(1024), "https://example.com/img.png", now, now)
+		"id", "user_id", "file_name", "file_size", "file_url", "created_at", "updated_at", "version", "key_version",
+	}).AddRow(
+		binaryID.String(), userID.String(), "img.png", int64(1024), "https://example.com/img.png",
+		now, now, int64(1), int32(1),
+	)
 
 	mock.ExpectQuery(`SELECT`).
 		WithArgs(params.ID, params.UserID).
@@ -86,18 +90,23 @@ func TestGetBinaries(t *testing.T) {
 	ctx := t.Context()
 
 	userID := uuid.New()
+	params := db.GetBinaryEntriesByUserIDParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		Limit:  10,
+		Offset: 0,
+	}
 	now := time.Now()
 
 	rows := pgxmock.NewRows([]string{
-		"id", "user_id", "file_name", "file_size", "file_url", "created_at", "updated_at",
-	}).AddRow(uuid.New().String(), userID.String(), "a.txt", int64(200), "url1", now, now).
-		AddRow(uuid.New().String(), userID.String(), "b.txt", int64(300), "url2", now, now)
+		"id", "user_id", "file_name", "file_size", "file_url", "created_at", "updated_at", "version", "key_version",
+	}).AddRow(uuid.New().String(), userID.String(), "a.txt", int64(200), "url1", now, now, int64(1), int32(1)).
+		AddRow(uuid.New().String(), userID.String(), "b.txt", int64(300), "url2", now, now, int64(1), int32(1))
 
 	mock.ExpectQuery(`SELECT`).
-		WithArgs(pgtype.UUID{Bytes: userID, Valid: true}).
+		WithArgs(params.UserID, params.Limit, params.Offset).
 		WillReturnRows(rows)
 
-	entries, err := dbStorage.GetBinaries(ctx, userID.String())
+	entries, err := dbStorage.GetBinaries(ctx, params)
 	require.NoError(t, err)
 	require.Len(t, entries, 2)
 	assert.Equal(t, "a.txt", entries[0].FileName)
@@ -131,6 +140,7 @@ func TestStoreBinary_Error(t *testing.T) {
 
 	userID := uuid.New()
 	params := db.StoreBinaryEntryParams{
+		ID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
 		UserID:   pgtype.UUID{Bytes: userID, Valid: true},
 		FileName: "file.txt",
 		FileSize: 1234,
@@ -139,10 +149,10 @@ func TestStoreBinary_Error(t *testing.T) {
 
 	expectedErr := errors.New("database error")
 	mock.ExpectQuery(`INSERT INTO binary_entries`).
-		WithArgs(params.UserID, params.FileName, params.FileUrl, params.FileSize).
+		WithArgs(params.ID, params.UserID, params.FileName, params.FileUrl, params.FileSize, params.KeyVersion).
 		WillReturnError(expectedErr)
 
-	entry, err := dbStorage.StoreBinary(ctx, params)
+	entry, err := dbStorage.StoreBinary(ctx, params, pgtype.UUID{})
 	require.Error(t, err)
 	require.Nil(t, entry)
 	assert.Contains(t, err.Error(), "failed to store binary entry")
@@ -203,16 +213,21 @@ func TestGetBinaries_EmptyResult(t *testing.T) {
 	ctx := t.Context()
 
 	userID := uuid.New()
+	params := db.GetBinaryEntriesByUserIDParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		Limit:  10,
+		Offset: 0,
+	}
 
 	rows := pgxmock.NewRows([]string{
-		"id", "user_id", "file_name", "file_size", "file_url", "created_at", "updated_at",
+		"id", "user_id", "file_name", "file_size", "file_url", "created_at", "updated_at", "version", "key_version",
 	})
 
 	mock.ExpectQuery(`SELECT`).
-		WithArgs(pgtype.UUID{Bytes: userID, Valid: true}).
+		WithArgs(params.UserID, params.Limit, params.Offset).
 		WillReturnRows(rows)
 
-	entries, err := dbStorage.GetBinaries(ctx, userID.String())
+	entries, err := dbStorage.GetBinaries(ctx, params)
 	require.NoError(t, err)
 	require.Empty(t, entries)
 }
@@ -224,13 +239,18 @@ func TestGetBinaries_Error(t *testing.T) {
 	ctx := t.Context()
 
 	userID := uuid.New()
+	params := db.GetBinaryEntriesByUserIDParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		Limit:  10,
+		Offset: 0,
+	}
 	expectedErr := errors.New("database error")
 
 	mock.ExpectQuery(`SELECT`).
-		WithArgs(pgtype.UUID{Bytes: userID, Valid: true}).
+		WithArgs(params.UserID, params.Limit, params.Offset).
 		WillReturnError(expectedErr)
 
-	entries, err := dbStorage.GetBinaries(ctx, userID.String())
+	entries, err := dbStorage.GetBinaries(ctx, params)
 	require.Error(t, err)
 	require.Nil(t, entries)
 	assert.Contains(t, err.Error(), "failed to find cards")
@@ -276,3 +296,21 @@ func TestDeleteBinary_Error(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to delete binary entry")
 }
+
+func TestCountBinaries_Error(t *testing.T) {
+	t.Parallel()
+
+	dbStorage, mock := testutils.SetupDBStorage(t)
+	ctx := t.Context()
+
+	userID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	mock.ExpectQuery(`SELECT COUNT`).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+
+	count, err := dbStorage.CountBinaries(ctx, userID)
+	require.Error(t, err)
+	assert.Zero(t, count)
+	assert.Contains(t, err.Error(), "failed to count binaries")
+}
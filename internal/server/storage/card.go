@@ -11,9 +11,20 @@ import (
 	"github.com/npavlov/go-password-manager/internal/utils"
 )
 
-// StoreCard creates new card record.
-func (ds *DBStorage) StoreCard(ctx context.Context, createCard db.StoreCardParams) (*db.Card, error) {
-	card, err := ds.Queries.StoreCard(ctx, createCard)
+// StoreCard creates new card record, in the collection when one is given.
+func (ds *DBStorage) StoreCard(
+	ctx context.Context,
+	createCard db.StoreCardParams,
+	collectionID pgtype.UUID,
+) (*db.Card, error) {
+	var card db.Card
+
+	err := ds.storeItem(ctx, collectionID, func(queries *db.Queries) (pgtype.UUID, error) {
+		var err error
+		card, err = queries.StoreCard(ctx, createCard)
+
+		return card.ID, err
+	})
 	if err != nil {
 		ds.log.Error().Err(err).Msg("failed to store card")
 
@@ -40,11 +51,12 @@ func (ds *DBStorage) GetCard(ctx context.Context, cardID string, userID pgtype.U
 	return &card, nil
 }
 
-// GetCards retrieves password record.
-func (ds *DBStorage) GetCards(ctx context.Context, userID string) ([]db.Card, error) {
-	uuid := utils.GetIDFromString(userID)
-
-	cards, err := ds.Queries.GetCardsByUserID(ctx, uuid)
+// GetCards retrieves a page of card records owned by the user.
+func (ds *DBStorage) GetCards(
+	ctx context.Context,
+	params db.GetCardsByUserIDParams,
+) ([]db.Card, error) {
+	cards, err := ds.Queries.GetCardsByUserID(ctx, params)
 	if err != nil {
 		ds.log.Error().Err(err).Msg("failed to find cards")
 
@@ -54,6 +66,33 @@ func (ds *DBStorage) GetCards(ctx context.Context, userID string) ([]db.Card, er
 	return cards, nil
 }
 
+// CountCards returns the total number of card records owned by the user.
+func (ds *DBStorage) CountCards(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	count, err := ds.Queries.CountCardsByUserID(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to count cards")
+
+		return 0, errors.Wrap(err, "failed to count cards")
+	}
+
+	return count, nil
+}
+
+// GetCardsByIDs retrieves the card records with the given IDs owned by the user.
+func (ds *DBStorage) GetCardsByIDs(
+	ctx context.Context,
+	params db.GetCardsByIDsParams,
+) ([]db.Card, error) {
+	cards, err := ds.Queries.GetCardsByIDs(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to get cards by ids")
+
+		return nil, errors.Wrap(err, "failed to get cards by ids")
+	}
+
+	return cards, nil
+}
+
 func (ds *DBStorage) DeleteCard(ctx context.Context, cardID string, userID pgtype.UUID) error {
 	uuid := utils.GetIDFromString(cardID)
 
@@ -25,6 +25,7 @@ func TestStoreCard(t *testing.T) {
 	id := uuid.New().String()
 
 	card := db.StoreCardParams{
+		ID:                  pgtype.UUID{Bytes: uuid.New(), Valid: true},
 		UserID:              pgtype.UUID{Bytes: userID, Valid: true},
 		EncryptedCardNumber: "123456",
 		EncryptedExpiryDate: "12/12",
@@ -40,16 +41,16 @@ func TestStoreCard(t *testing.T) {
 
 	rows := pgxmock.NewRows([]string{
 		"id", "user_id", "encrypted_card_number", "encrypted_expiry_date", "encrypted_cvv",
-		"cardholder_name", "created_at", "updated_at", "hashed_card_number",
+		"cardholder_name", "created_at", "updated_at", "hashed_card_number", "version", "key_version",
 	}).AddRow(id, userID.String(), card.EncryptedCardNumber, card.EncryptedExpiryDate,
-		card.EncryptedCvv, card.CardholderName, now, now, card.HashedCardNumber)
+		card.EncryptedCvv, card.CardholderName, now, now, card.HashedCardNumber, int64(1), int32(1))
 
 	mock.ExpectQuery("INSERT INTO cards").
-		WithArgs(card.UserID, card.HashedCardNumber, card.EncryptedCardNumber,
-			card.EncryptedExpiryDate, card.EncryptedCvv, card.CardholderName).
+		WithArgs(card.ID, card.UserID, card.HashedCardNumber, card.EncryptedCardNumber,
+			card.EncryptedExpiryDate, card.EncryptedCvv, card.CardholderName, card.KeyVersion).
 		WillReturnRows(rows)
 
-	result, err := storage.StoreCard(t.Context(), card)
+	result, err := storage.StoreCard(t.Context(), card, pgtype.UUID{})
 	require.NoError(t, err)
 	require.Equal(t, result.UserID.String(), userID.String())
 	require.Equal(t, result.EncryptedCardNumber, card.EncryptedCardNumber)
@@ -84,12 +85,12 @@ func TestGetCard(t *testing.T) {
 
 	rows := pgxmock.NewRows([]string{
 		"id", "user_id", "encrypted_card_number", "encrypted_expiry_date", "encrypted_cvv",
-		"cardholder_name", "created_at", "updated_at", "hashed_card_number",
+		"cardholder_name", "created_at", "updated_at", "hashed_card_number", "version", "key_version",
 	}).AddRow(
 		expectedCard.ID.String(), expectedCard.UserID.String(), expectedCard.EncryptedCardNumber,
 		expectedCard.EncryptedExpiryDate, expectedCard.EncryptedCvv,
 		expectedCard.CardholderName, expectedCard.CreatedAt, expectedCard.UpdatedAt,
-		expectedCard.HashedCardNumber,
+		expectedCard.HashedCardNumber, int64(1), int32(1),
 	)
 
 	mock.ExpectQuery("SELECT (.+) FROM cards").
@@ -146,21 +147,27 @@ func TestGetCards(t *testing.T) {
 
 	rows := pgxmock.NewRows([]string{
 		"id", "user_id", "encrypted_card_number", "encrypted_expiry_date", "encrypted_cvv",
-		"cardholder_name", "created_at", "updated_at", "hashed_card_number",
+		"cardholder_name", "created_at", "updated_at", "hashed_card_number", "version", "key_version",
 	})
 	for _, card := range expectedCards {
 		rows.AddRow(
 			card.ID, card.UserID, card.EncryptedCardNumber,
 			card.EncryptedExpiryDate, card.EncryptedCvv, card.CardholderName,
-			card.CreatedAt, card.UpdatedAt, card.HashedCardNumber,
+			card.CreatedAt, card.UpdatedAt, card.HashedCardNumber, card.Version, card.KeyVersion,
 		)
 	}
 
+	params := db.GetCardsByUserIDParams{
+		UserID: expectedCards[0].UserID,
+		Limit:  10,
+		Offset: 0,
+	}
+
 	mock.ExpectQuery("SELECT (.+) FROM cards").
-		WithArgs(expectedCards[0].UserID).
+		WithArgs(params.UserID, params.Limit, params.Offset).
 		WillReturnRows(rows)
 
-	result, err := storage.GetCards(t.Context(), userID.String())
+	result, err := storage.GetCards(t.Context(), params)
 	require.NoError(t, err)
 	require.Len(t, result, 2)
 	require.Equal(t, expectedCards[0].ID, result[0].ID)
@@ -203,6 +210,8 @@ func TestUpdateCard(t *testing.T) {
 			String: "123456",
 			Valid:  true,
 		},
+		UserID:          userID,
+		ExpectedVersion: 1,
 	}
 
 	expectedCard := db.Card{
@@ -219,12 +228,12 @@ func TestUpdateCard(t *testing.T) {
 
 	rows := pgxmock.NewRows([]string{
 		"id", "user_id", "encrypted_card_number", "encrypted_expiry_date", "encrypted_cvv",
-		"cardholder_name", "created_at", "updated_at", "hashed_card_number",
+		"cardholder_name", "created_at", "updated_at", "hashed_card_number", "version", "key_version",
 	}).AddRow(
 		expectedCard.ID.String(), expectedCard.UserID.String(), expectedCard.EncryptedCardNumber,
 		expectedCard.EncryptedExpiryDate, expectedCard.EncryptedCvv,
 		expectedCard.CardholderName, expectedCard.CreatedAt, expectedCard.UpdatedAt,
-		expectedCard.HashedCardNumber,
+		expectedCard.HashedCardNumber, int64(2), int32(1),
 	)
 
 	mock.ExpectQuery("UPDATE cards").
@@ -234,7 +243,10 @@ func TestUpdateCard(t *testing.T) {
 			updateParams.EncryptedCvv,
 			updateParams.CardholderName,
 			updateParams.HashedCardNumber,
+			updateParams.KeyVersion,
 			updateParams.ID,
+			updateParams.UserID,
+			updateParams.ExpectedVersion,
 		).
 		WillReturnRows(rows)
 
@@ -246,6 +258,7 @@ func TestUpdateCard(t *testing.T) {
 	require.Equal(t, expectedCard.EncryptedCvv, result.EncryptedCvv)
 	require.Equal(t, expectedCard.CardholderName, result.CardholderName)
 	require.Equal(t, expectedCard.HashedCardNumber, result.HashedCardNumber)
+	require.Equal(t, int64(2), result.Version)
 }
 
 func TestGetCard_NotFound(t *testing.T) {
@@ -287,13 +300,17 @@ func TestGetCards_DBError(t *testing.T) {
 
 	storage, mock := testutils.SetupDBStorage(t)
 
-	userID := uuid.New().String()
+	params := db.GetCardsByUserIDParams{
+		UserID: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Limit:  10,
+		Offset: 0,
+	}
 
 	mock.ExpectQuery("SELECT (.+) FROM cards").
-		WithArgs(pgtype.UUID{Bytes: uuid.MustParse(userID), Valid: true}).
+		WithArgs(params.UserID, params.Limit, params.Offset).
 		WillReturnError(errors.New("query failed"))
 
-	result, err := storage.GetCards(t.Context(), userID)
+	result, err := storage.GetCards(t.Context(), params)
 	require.Error(t, err)
 	require.Nil(t, result)
 	require.Contains(t, err.Error(), "failed to find cards")
@@ -353,3 +370,19 @@ func TestUpdateCard_DBError(t *testing.T) {
 	require.Nil(t, result)
 	require.Contains(t, err.Error(), "failed to update card")
 }
+
+func TestCountCards(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	userID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	mock.ExpectQuery("SELECT COUNT").
+		WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(5)))
+
+	count, err := storage.CountCards(t.Context(), userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+}
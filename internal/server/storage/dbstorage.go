@@ -1,6 +1,10 @@
 package storage
 
 import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 
 	"github.com/npavlov/go-password-manager/internal/server/db"
@@ -21,3 +25,40 @@ func NewDBStorage(dbCon dbmanager.PgxPool, log *zerolog.Logger) *DBStorage {
 		log:     log,
 	}
 }
+
+// storeItem runs insert, which returns the ID of the item it stored, and puts that item into the collection
+// in the same transaction when one is given. Linking only what the insert stored keeps items of other
+// vaults out of collections, and the item never shows up outside of its collection.
+func (ds *DBStorage) storeItem(
+	ctx context.Context,
+	collectionID pgtype.UUID,
+	insert func(queries *db.Queries) (pgtype.UUID, error),
+) error {
+	if !collectionID.Valid {
+		_, err := insert(ds.Queries)
+
+		return err
+	}
+
+	tx, err := ds.dbCon.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+
+	// Rolling back after the commit does nothing
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	queries := ds.Queries.WithTx(tx)
+
+	itemID, err := insert(queries)
+	if err != nil {
+		return err
+	}
+
+	err = queries.AddCollectionItem(ctx, db.AddCollectionItemParams{ItemID: itemID, CollectionID: collectionID})
+	if err != nil {
+		return errors.Wrap(err, "failed to add item to collection")
+	}
+
+	return errors.Wrap(tx.Commit(ctx), "failed to commit item")
+}
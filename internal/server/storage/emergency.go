@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// UpsertEmergencyAccess makes a user a trusted contact of the grantor, or changes the grant of a contact.
+func (ds *DBStorage) UpsertEmergencyAccess(
+	ctx context.Context,
+	params db.UpsertEmergencyAccessParams,
+) (*db.EmergencyAccess, error) {
+	access, err := ds.Queries.UpsertEmergencyAccess(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to store emergency access")
+
+		return nil, errors.Wrap(err, "failed to store emergency access")
+	}
+
+	return &access, nil
+}
+
+// ListEmergencyAccess returns the grants the user gave or received, the newest first.
+func (ds *DBStorage) ListEmergencyAccess(ctx context.Context, userID pgtype.UUID) ([]db.ListEmergencyAccessRow, error) {
+	grants, err := ds.Queries.ListEmergencyAccess(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list emergency access")
+
+		return nil, errors.Wrap(err, "failed to list emergency access")
+	}
+
+	return grants, nil
+}
+
+// GetEmergencyAccess returns a grant the user gave or received.
+func (ds *DBStorage) GetEmergencyAccess(
+	ctx context.Context,
+	accessID, userID pgtype.UUID,
+) (*db.GetEmergencyAccessRow, error) {
+	access, err := ds.Queries.GetEmergencyAccess(ctx, db.GetEmergencyAccessParams{
+		ID:     accessID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get emergency access")
+	}
+
+	return &access, nil
+}
+
+// DeleteEmergencyAccess deletes a grant the user gave or received. It reports false when there is none.
+func (ds *DBStorage) DeleteEmergencyAccess(ctx context.Context, accessID, userID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.DeleteEmergencyAccess(ctx, db.DeleteEmergencyAccessParams{
+		ID:     accessID,
+		UserID: userID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete emergency access")
+
+		return false, errors.Wrap(err, "failed to delete emergency access")
+	}
+
+	return affected == 1, nil
+}
+
+// RequestEmergencyAccess starts the wait of a grant the grantee received. It reports false unless the grant
+// was idle.
+func (ds *DBStorage) RequestEmergencyAccess(ctx context.Context, accessID, granteeID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.RequestEmergencyAccess(ctx, db.RequestEmergencyAccessParams{
+		ID:        accessID,
+		GranteeID: granteeID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to request emergency access")
+
+		return false, errors.Wrap(err, "failed to request emergency access")
+	}
+
+	return affected == 1, nil
+}
+
+// ApproveEmergencyAccess approves a pending request before the wait is over. It reports false unless a
+// request was pending.
+func (ds *DBStorage) ApproveEmergencyAccess(ctx context.Context, accessID, grantorID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.ApproveEmergencyAccess(ctx, db.ApproveEmergencyAccessParams{
+		ID:        accessID,
+		GrantorID: grantorID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to approve emergency access")
+
+		return false, errors.Wrap(err, "failed to approve emergency access")
+	}
+
+	return affected == 1, nil
+}
+
+// RejectEmergencyAccess returns a requested or approved grant to idle. It reports false when it was idle.
+func (ds *DBStorage) RejectEmergencyAccess(ctx context.Context, accessID, grantorID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.RejectEmergencyAccess(ctx, db.RejectEmergencyAccessParams{
+		ID:        accessID,
+		GrantorID: grantorID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to reject emergency access")
+
+		return false, errors.Wrap(err, "failed to reject emergency access")
+	}
+
+	return affected == 1, nil
+}
+
+// PromoteEmergencyAccess approves every request whose wait is over at now. It returns how many it approved.
+func (ds *DBStorage) PromoteEmergencyAccess(ctx context.Context, now time.Time) (int64, error) {
+	affected, err := ds.Queries.PromoteEmergencyAccess(ctx, pgtype.Timestamp{Time: now, Valid: true})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to promote emergency access")
+	}
+
+	return affected, nil
+}
+
+// GetEmergencyItemAccess returns the grantor whose vault holds the item, if the grantee may view it.
+func (ds *DBStorage) GetEmergencyItemAccess(ctx context.Context, itemID, granteeID pgtype.UUID) (pgtype.UUID, error) {
+	grantorID, err := ds.Queries.GetEmergencyItemAccess(ctx, db.GetEmergencyItemAccessParams{
+		ItemID:    itemID,
+		GranteeID: granteeID,
+	})
+	if err != nil {
+		return pgtype.UUID{}, errors.Wrap(err, "failed to get emergency item access")
+	}
+
+	return grantorID, nil
+}
+
+// ListVaultItems returns the items in the vault of a user, the newest first.
+func (ds *DBStorage) ListVaultItems(ctx context.Context, userID pgtype.UUID) ([]db.ListVaultItemsRow, error) {
+	items, err := ds.Queries.ListVaultItems(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list vault items")
+
+		return nil, errors.Wrap(err, "failed to list vault items")
+	}
+
+	return items, nil
+}
+
+// TakeOverAccount replaces the password of the grantor of an approved takeover grant and turns off the
+// second factor. It reports false unless the grant was approved.
+func (ds *DBStorage) TakeOverAccount(ctx context.Context, params db.TakeOverAccountParams) (bool, error) {
+	affected, err := ds.Queries.TakeOverAccount(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to take over account")
+
+		return false, errors.Wrap(err, "failed to take over account")
+	}
+
+	return affected == 1, nil
+}
@@ -0,0 +1,133 @@
+//nolint:exhaustruct
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestUpsertEmergencyAccess(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	accessID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	params := db.UpsertEmergencyAccessParams{
+		GrantorID: userUUID, GranteeID: tokenUUID, AccessType: db.EmergencyAccessTypeView, WaitDays: 3,
+	}
+
+	mock.ExpectQuery("INSERT INTO emergency_access").
+		WithArgs(userUUID, tokenUUID, db.EmergencyAccessTypeView, int32(3)).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "grantor_id", "grantee_id", "access_type", "wait_days", "status",
+			"requested_at", "approved_at", "created_at",
+		}).AddRow(accessID, userUUID, tokenUUID, db.EmergencyAccessTypeView, int32(3), db.EmergencyAccessStatusIdle,
+			pgtype.Timestamp{}, pgtype.Timestamp{}, pgFixedTime))
+	mock.ExpectQuery("INSERT INTO emergency_access").
+		WithArgs(userUUID, tokenUUID, db.EmergencyAccessTypeView, int32(3)).
+		WillReturnError(errors.New("db error"))
+
+	access, err := storage.UpsertEmergencyAccess(t.Context(), params)
+	require.NoError(t, err)
+	require.Equal(t, accessID, access.ID)
+	require.Equal(t, db.EmergencyAccessStatusIdle, access.Status)
+
+	_, err = storage.UpsertEmergencyAccess(t.Context(), params)
+	require.ErrorContains(t, err, "failed to store emergency access")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmergencyAccessTransitions(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	accessID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	mock.ExpectExec("UPDATE emergency_access").
+		WithArgs(accessID, tokenUUID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE emergency_access").
+		WithArgs(accessID, userUUID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE emergency_access").
+		WithArgs(accessID, userUUID).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectExec("UPDATE emergency_access").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	requested, err := storage.RequestEmergencyAccess(t.Context(), accessID, tokenUUID)
+	require.NoError(t, err)
+	require.True(t, requested)
+
+	// Nothing was requested, so there is nothing to approve
+	approved, err := storage.ApproveEmergencyAccess(t.Context(), accessID, userUUID)
+	require.NoError(t, err)
+	require.False(t, approved)
+
+	_, err = storage.RejectEmergencyAccess(t.Context(), accessID, userUUID)
+	require.ErrorContains(t, err, "failed to reject emergency access")
+
+	promoted, err := storage.PromoteEmergencyAccess(t.Context(), fixedTime)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, promoted)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmergencyItemAccess(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	itemID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	mock.ExpectQuery("SELECT (.+) FROM emergency_access").
+		WithArgs(itemID, tokenUUID).
+		WillReturnRows(pgxmock.NewRows([]string{"grantor_id"}).AddRow(userUUID))
+	mock.ExpectQuery("SELECT (.+) FROM emergency_access").
+		WithArgs(itemID, userUUID).
+		WillReturnError(pgx.ErrNoRows)
+
+	grantorID, err := storage.GetEmergencyItemAccess(t.Context(), itemID, tokenUUID)
+	require.NoError(t, err)
+	require.Equal(t, userUUID, grantorID)
+
+	_, err = storage.GetEmergencyItemAccess(t.Context(), itemID, userUUID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTakeOverAccount(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	accessID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	params := db.TakeOverAccountParams{NewPassword: "hashed", ID: accessID, GranteeID: tokenUUID}
+
+	mock.ExpectExec("WITH grant_used AS").
+		WithArgs("hashed", accessID, tokenUUID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("WITH grant_used AS").
+		WithArgs("hashed", accessID, tokenUUID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	taken, err := storage.TakeOverAccount(t.Context(), params)
+	require.NoError(t, err)
+	require.True(t, taken)
+
+	taken, err = storage.TakeOverAccount(t.Context(), params)
+	require.NoError(t, err)
+	require.False(t, taken)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
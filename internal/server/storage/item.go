@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 
 	"github.com/npavlov/go-password-manager/internal/server/db"
@@ -22,3 +23,60 @@ func (ds *DBStorage) GetItems(
 
 	return items, nil
 }
+
+// ItemExists reports whether any user has an item with the given resource ID.
+func (ds *DBStorage) ItemExists(ctx context.Context, id pgtype.UUID) (bool, error) {
+	exists, err := ds.Queries.ItemExists(ctx, id)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to check item")
+
+		return false, errors.Wrap(err, "failed to check item")
+	}
+
+	return exists, nil
+}
+
+// GetItemsByResourceIDs returns items owned by the user for the given resource IDs.
+func (ds *DBStorage) GetItemsByResourceIDs(
+	ctx context.Context,
+	params db.GetItemsByResourceIDsParams,
+) ([]db.GetItemsByResourceIDsRow, error) {
+	items, err := ds.Queries.GetItemsByResourceIDs(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to get items by ids")
+
+		return nil, errors.Wrap(err, "failed to get items by ids")
+	}
+
+	return items, nil
+}
+
+// GetItemsChangedSince returns items owned by the user updated after the given time.
+func (ds *DBStorage) GetItemsChangedSince(
+	ctx context.Context,
+	params db.GetItemsChangedSinceParams,
+) ([]db.GetItemsChangedSinceRow, error) {
+	items, err := ds.Queries.GetItemsChangedSince(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to get changed items")
+
+		return nil, errors.Wrap(err, "failed to get changed items")
+	}
+
+	return items, nil
+}
+
+// GetItemChanges returns the latest change of every item modified after the given sequence number.
+func (ds *DBStorage) GetItemChanges(
+	ctx context.Context,
+	params db.GetItemChangesSinceParams,
+) ([]db.GetItemChangesSinceRow, error) {
+	changes, err := ds.Queries.GetItemChangesSince(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to get item changes")
+
+		return nil, errors.Wrap(err, "failed to get item changes")
+	}
+
+	return changes, nil
+}
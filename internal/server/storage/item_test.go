@@ -36,13 +36,13 @@ func TestGetItems(t *testing.T) {
 				now := pgtype.Timestamp{Time: time.Now(), Valid: true}
 
 				return pgxmock.NewRows([]string{
-					"id", "type", "id_resource", "created_at", "updated_at",
+					"id", "type", "id_resource", "created_at", "updated_at", "owner", "read_only",
 				}).
 					AddRow(
-						uuid.New(), "password", uuid.New(), now, now,
+						uuid.New(), "password", uuid.New(), now, now, "", false,
 					).
 					AddRow(
-						uuid.New(), "card", uuid.New(), now, now,
+						uuid.New(), "card", uuid.New(), now, now, "alice", true,
 					)
 			},
 			want: []db.GetItemsByUserIDRow{
@@ -59,6 +59,8 @@ func TestGetItems(t *testing.T) {
 					IDResource: pgtype.UUID{Bytes: uuid.New(), Valid: true},
 					CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
 					UpdatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+					Owner:      "alice",
+					ReadOnly:   true,
 				},
 			},
 			wantErr: false,
@@ -72,7 +74,7 @@ func TestGetItems(t *testing.T) {
 			},
 			mockRows: func() *pgxmock.Rows {
 				return pgxmock.NewRows([]string{
-					"id", "type", "id_resource", "created_at", "updated_at",
+					"id", "type", "id_resource", "created_at", "updated_at", "owner", "read_only",
 				})
 			},
 			want:    []db.GetItemsByUserIDRow{},
@@ -101,24 +103,24 @@ func TestGetItems(t *testing.T) {
 
 			if tt.wantErr {
 				mock.ExpectQuery("SELECT").
-					WithArgs(tt.params.UserID, tt.params.Limit, tt.params.Offset).
+					WithArgs(tt.params.UserID, tt.params.Offset, tt.params.Limit).
 					WillReturnError(errors.New("database error"))
 			} else {
 				rows := tt.mockRows()
 				if len(tt.want) > 0 {
 					// Update the mock rows with the expected values
 					rows = pgxmock.NewRows([]string{
-						"id", "type", "id_resource", "created_at", "updated_at",
+						"id", "type", "id_resource", "created_at", "updated_at", "owner", "read_only",
 					})
 					for _, item := range tt.want {
 						rows.AddRow(
 							item.ID, item.Type, item.IDResource,
-							item.CreatedAt, item.UpdatedAt,
+							item.CreatedAt, item.UpdatedAt, item.Owner, item.ReadOnly,
 						)
 					}
 				}
 				mock.ExpectQuery("SELECT").
-					WithArgs(tt.params.UserID, tt.params.Limit, tt.params.Offset).
+					WithArgs(tt.params.UserID, tt.params.Offset, tt.params.Limit).
 					WillReturnRows(rows)
 			}
 
@@ -135,6 +137,8 @@ func TestGetItems(t *testing.T) {
 						require.Equal(t, item.ID, got[i].ID)
 						require.Equal(t, item.Type, got[i].Type)
 						require.Equal(t, item.IDResource, got[i].IDResource)
+						require.Equal(t, item.Owner, got[i].Owner)
+						require.Equal(t, item.ReadOnly, got[i].ReadOnly)
 					}
 				} else {
 					require.Empty(t, got)
@@ -160,13 +164,13 @@ func TestGetItemsWithPagination(t *testing.T) {
 
 	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
 	rows := pgxmock.NewRows([]string{
-		"id", "type", "id_resource", "created_at", "updated_at",
+		"id", "type", "id_resource", "created_at", "updated_at", "owner", "read_only",
 	}).
-		AddRow(uuid.New().String(), "password", uuid.New().String(), now, now).
-		AddRow(uuid.New().String(), "card", uuid.New().String(), now, now)
+		AddRow(uuid.New().String(), "password", uuid.New().String(), now, now, "", false).
+		AddRow(uuid.New().String(), "card", uuid.New().String(), now, now, "", false)
 
 	mock.ExpectQuery("SELECT").
-		WithArgs(params.UserID, params.Limit, params.Offset).
+		WithArgs(params.UserID, params.Offset, params.Limit).
 		WillReturnRows(rows)
 
 	result, err := storage.GetItems(t.Context(), params)
@@ -189,11 +193,11 @@ func TestGetItemsEmptyResult(t *testing.T) {
 	}
 
 	rows := pgxmock.NewRows([]string{
-		"id", "type", "id_resource", "created_at", "updated_at",
+		"id", "type", "id_resource", "created_at", "updated_at", "owner", "read_only",
 	})
 
 	mock.ExpectQuery("SELECT").
-		WithArgs(params.UserID, params.Limit, params.Offset).
+		WithArgs(params.UserID, params.Offset, params.Limit).
 		WillReturnRows(rows)
 
 	result, err := storage.GetItems(t.Context(), params)
@@ -214,7 +218,7 @@ func TestGetItemsDatabaseError(t *testing.T) {
 	}
 
 	mock.ExpectQuery("SELECT").
-		WithArgs(params.UserID, params.Limit, params.Offset).
+		WithArgs(params.UserID, params.Offset, params.Limit).
 		WillReturnError(errors.New("database error"))
 
 	result, err := storage.GetItems(t.Context(), params)
@@ -222,3 +226,142 @@ func TestGetItemsDatabaseError(t *testing.T) {
 	require.Nil(t, result)
 	require.Contains(t, err.Error(), "failed to get items")
 }
+
+func TestItemExists(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	id := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(id).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := storage.ItemExists(t.Context(), id)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(id).
+		WillReturnError(errors.New("db error"))
+
+	_, err = storage.ItemExists(t.Context(), id)
+	require.Error(t, err)
+}
+
+func TestGetItemsByResourceIDs(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	resourceID := uuid.New()
+	params := db.GetItemsByResourceIDsParams{
+		UserID:      pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		ResourceIds: []pgtype.UUID{{Bytes: resourceID, Valid: true}},
+	}
+
+	rows := pgxmock.NewRows([]string{
+		"id", "type", "id_resource", "created_at", "updated_at",
+	}).AddRow(pgtype.UUID{Bytes: uuid.New(), Valid: true}, "text", params.ResourceIds[0], now, now)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(params.UserID, params.ResourceIds).
+		WillReturnRows(rows)
+
+	result, err := storage.GetItemsByResourceIDs(t.Context(), params)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, db.ItemTypeText, result[0].Type)
+	require.Equal(t, pgtype.UUID{Bytes: resourceID, Valid: true}, result[0].IDResource)
+}
+
+func TestGetItemsChangedSince(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	params := db.GetItemsChangedSinceParams{
+		UserID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		ChangedSince: pgtype.Timestamp{Time: time.Now().Add(-time.Hour), Valid: true},
+	}
+
+	rows := pgxmock.NewRows([]string{
+		"id", "type", "id_resource", "created_at", "updated_at",
+	}).
+		AddRow(
+			pgtype.UUID{Bytes: uuid.New(), Valid: true}, "password", pgtype.UUID{Bytes: uuid.New(), Valid: true}, now, now,
+		).
+		AddRow(
+			pgtype.UUID{Bytes: uuid.New(), Valid: true}, "binary", pgtype.UUID{Bytes: uuid.New(), Valid: true}, now, now,
+		)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(params.UserID, params.ChangedSince).
+		WillReturnRows(rows)
+
+	result, err := storage.GetItemsChangedSince(t.Context(), params)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	require.Equal(t, db.ItemTypePassword, result[0].Type)
+	require.Equal(t, db.ItemTypeBinary, result[1].Type)
+}
+
+func TestGetItemsChangedSinceDatabaseError(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	params := db.GetItemsChangedSinceParams{
+		UserID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		ChangedSince: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	}
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(params.UserID, params.ChangedSince).
+		WillReturnError(errors.New("database error"))
+
+	result, err := storage.GetItemsChangedSince(t.Context(), params)
+	require.Error(t, err)
+	require.Nil(t, result)
+	require.Contains(t, err.Error(), "failed to get changed items")
+}
+
+func TestGetItemChanges(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	params := db.GetItemChangesSinceParams{
+		UserID:     pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		SinceSeq:   3,
+		MaxChanges: 10,
+	}
+
+	rows := pgxmock.NewRows([]string{"item_id", "type", "seq", "deleted", "changed_at"}).
+		AddRow(pgtype.UUID{Bytes: uuid.New(), Valid: true}, "card", int64(4), false, now).
+		AddRow(pgtype.UUID{Bytes: uuid.New(), Valid: true}, "text", int64(5), true, now)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(params.UserID, params.SinceSeq, params.MaxChanges).
+		WillReturnRows(rows)
+
+	result, err := storage.GetItemChanges(t.Context(), params)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	require.Equal(t, int64(4), result[0].Seq)
+	require.False(t, result[0].Deleted)
+	require.True(t, result[1].Deleted)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(params.UserID, params.SinceSeq, params.MaxChanges).
+		WillReturnError(errors.New("database error"))
+
+	result, err = storage.GetItemChanges(t.Context(), params)
+	require.Error(t, err)
+	require.Nil(t, result)
+	require.Contains(t, err.Error(), "failed to get item changes")
+}
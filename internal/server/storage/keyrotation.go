@@ -0,0 +1,248 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// StartKeyRotation swaps in a new user key and records the rotation. The old key is kept as the previous
+// key until every row is re-encrypted; pgx.ErrNoRows means the user key changed or a rotation is unfinished.
+func (ds *DBStorage) StartKeyRotation(ctx context.Context, params db.StartKeyRotationParams) (*db.KeyRotation, error) {
+	rotation, err := ds.Queries.StartKeyRotation(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to start key rotation")
+
+		return nil, errors.Wrap(err, "failed to start key rotation")
+	}
+
+	return &rotation, nil
+}
+
+// GetLatestKeyRotation returns the most recent key rotation of the user.
+func (ds *DBStorage) GetLatestKeyRotation(ctx context.Context, userID pgtype.UUID) (*db.KeyRotation, error) {
+	rotation, err := ds.Queries.GetLatestKeyRotation(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to get key rotation")
+
+		return nil, errors.Wrap(err, "failed to get key rotation")
+	}
+
+	return &rotation, nil
+}
+
+// ListRunningKeyRotations returns the rotations still re-encrypting, oldest first.
+func (ds *DBStorage) ListRunningKeyRotations(ctx context.Context) ([]db.KeyRotation, error) {
+	rotations, err := ds.Queries.ListRunningKeyRotations(ctx)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list key rotations")
+
+		return nil, errors.Wrap(err, "failed to list key rotations")
+	}
+
+	return rotations, nil
+}
+
+// RetryKeyRotation marks a failed rotation as running again.
+func (ds *DBStorage) RetryKeyRotation(ctx context.Context, rotationID pgtype.UUID) (*db.KeyRotation, error) {
+	rotation, err := ds.Queries.RetryKeyRotation(ctx, rotationID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to retry key rotation")
+
+		return nil, errors.Wrap(err, "failed to retry key rotation")
+	}
+
+	return &rotation, nil
+}
+
+// FailKeyRotation stops a rotation and records why.
+func (ds *DBStorage) FailKeyRotation(ctx context.Context, rotationID pgtype.UUID, reason string) error {
+	err := ds.Queries.FailKeyRotation(ctx, db.FailKeyRotationParams{
+		Error: pgtype.Text{String: reason, Valid: true},
+		ID:    rotationID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to fail key rotation")
+
+		return errors.Wrap(err, "failed to fail key rotation")
+	}
+
+	return nil
+}
+
+// UpdateKeyRotationProgress records how many items are left to re-encrypt.
+func (ds *DBStorage) UpdateKeyRotationProgress(ctx context.Context, rotationID pgtype.UUID, remaining int64) error {
+	err := ds.Queries.UpdateKeyRotationProgress(ctx, db.UpdateKeyRotationProgressParams{
+		Remaining: remaining,
+		ID:        rotationID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to update key rotation")
+
+		return errors.Wrap(err, "failed to update key rotation")
+	}
+
+	return nil
+}
+
+// FinishKeyRotation drops the previous user key and marks the rotation completed.
+func (ds *DBStorage) FinishKeyRotation(ctx context.Context, rotation db.KeyRotation) (*db.KeyRotation, error) {
+	finished, err := ds.Queries.FinishKeyRotation(ctx, db.FinishKeyRotationParams{
+		ID:        rotation.ID,
+		UserID:    rotation.UserID,
+		ToVersion: rotation.ToVersion,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to finish key rotation")
+
+		return nil, errors.Wrap(err, "failed to finish key rotation")
+	}
+
+	return &finished, nil
+}
+
+// CountStaleItems counts the rows and files of the user encrypted with a key older than keyVersion.
+func (ds *DBStorage) CountStaleItems(ctx context.Context, userID pgtype.UUID, keyVersion int32) (int64, error) {
+	count, err := ds.Queries.CountStaleItems(ctx, db.CountStaleItemsParams{
+		UserID:     userID,
+		KeyVersion: keyVersion,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to count stale items")
+
+		return 0, errors.Wrap(err, "failed to count stale items")
+	}
+
+	return count, nil
+}
+
+// ListStalePasswords returns up to batchSize passwords encrypted with a key older than keyVersion.
+func (ds *DBStorage) ListStalePasswords(
+	ctx context.Context,
+	userID pgtype.UUID,
+	keyVersion, batchSize int32,
+) ([]db.Password, error) {
+	passwords, err := ds.Queries.ListStalePasswords(ctx, db.ListStalePasswordsParams{
+		UserID:     userID,
+		KeyVersion: keyVersion,
+		BatchSize:  batchSize,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list stale passwords")
+
+		return nil, errors.Wrap(err, "failed to list stale passwords")
+	}
+
+	return passwords, nil
+}
+
+// ReencryptPassword stores a re-encrypted password unless the row changed since it was read.
+func (ds *DBStorage) ReencryptPassword(ctx context.Context, params db.ReencryptPasswordParams) (bool, error) {
+	affected, err := ds.Queries.ReencryptPassword(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to re-encrypt password")
+
+		return false, errors.Wrap(err, "failed to re-encrypt password")
+	}
+
+	return affected == 1, nil
+}
+
+// ListStaleNotes returns up to batchSize notes encrypted with a key older than keyVersion.
+func (ds *DBStorage) ListStaleNotes(
+	ctx context.Context,
+	userID pgtype.UUID,
+	keyVersion, batchSize int32,
+) ([]db.Note, error) {
+	notes, err := ds.Queries.ListStaleNotes(ctx, db.ListStaleNotesParams{
+		UserID:     userID,
+		KeyVersion: keyVersion,
+		BatchSize:  batchSize,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list stale notes")
+
+		return nil, errors.Wrap(err, "failed to list stale notes")
+	}
+
+	return notes, nil
+}
+
+// ReencryptNote stores a re-encrypted note unless the row changed since it was read.
+func (ds *DBStorage) ReencryptNote(ctx context.Context, params db.ReencryptNoteParams) (bool, error) {
+	affected, err := ds.Queries.ReencryptNote(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to re-encrypt note")
+
+		return false, errors.Wrap(err, "failed to re-encrypt note")
+	}
+
+	return affected == 1, nil
+}
+
+// ListStaleCards returns up to batchSize cards encrypted with a key older than keyVersion.
+func (ds *DBStorage) ListStaleCards(
+	ctx context.Context,
+	userID pgtype.UUID,
+	keyVersion, batchSize int32,
+) ([]db.Card, error) {
+	cards, err := ds.Queries.ListStaleCards(ctx, db.ListStaleCardsParams{
+		UserID:     userID,
+		KeyVersion: keyVersion,
+		BatchSize:  batchSize,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list stale cards")
+
+		return nil, errors.Wrap(err, "failed to list stale cards")
+	}
+
+	return cards, nil
+}
+
+// ReencryptCard stores a re-encrypted card unless the row changed since it was read.
+func (ds *DBStorage) ReencryptCard(ctx context.Context, params db.ReencryptCardParams) (bool, error) {
+	affected, err := ds.Queries.ReencryptCard(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to re-encrypt card")
+
+		return false, errors.Wrap(err, "failed to re-encrypt card")
+	}
+
+	return affected == 1, nil
+}
+
+// ListStaleBinaries returns up to batchSize files encrypted with a key older than keyVersion.
+func (ds *DBStorage) ListStaleBinaries(
+	ctx context.Context,
+	userID pgtype.UUID,
+	keyVersion, batchSize int32,
+) ([]db.BinaryEntry, error) {
+	binaries, err := ds.Queries.ListStaleBinaryEntries(ctx, db.ListStaleBinaryEntriesParams{
+		UserID:     userID,
+		KeyVersion: keyVersion,
+		BatchSize:  batchSize,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list stale files")
+
+		return nil, errors.Wrap(err, "failed to list stale files")
+	}
+
+	return binaries, nil
+}
+
+// ReencryptBinary points a file at its re-encrypted object unless the row changed since it was read.
+func (ds *DBStorage) ReencryptBinary(ctx context.Context, params db.ReencryptBinaryEntryParams) (bool, error) {
+	affected, err := ds.Queries.ReencryptBinaryEntry(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to re-encrypt file")
+
+		return false, errors.Wrap(err, "failed to re-encrypt file")
+	}
+
+	return affected == 1, nil
+}
@@ -0,0 +1,100 @@
+//nolint:exhaustruct
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+var keyRotationColumns = []string{
+	"id", "user_id", "from_version", "to_version", "status",
+	"total_items", "done_items", "error", "started_at", "finished_at", "binds_values",
+}
+
+func TestStartKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	userUUID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	rotationUUID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	params := db.StartKeyRotationParams{NewKey: "wrapped-key", UserID: userUUID, FromVersion: 1}
+
+	mock.ExpectQuery("INSERT INTO key_rotations").
+		WithArgs("wrapped-key", userUUID, int32(1)).
+		WillReturnRows(pgxmock.NewRows(keyRotationColumns).AddRow(
+			rotationUUID, userUUID, int32(1), int32(2), db.KeyRotationStatusRunning,
+			int64(3), int64(0), pgtype.Text{}, pgtype.Timestamp{Time: time.Now(), Valid: true}, pgtype.Timestamp{},
+			true,
+		))
+
+	rotation, err := storage.StartKeyRotation(t.Context(), params)
+	require.NoError(t, err)
+	require.Equal(t, rotationUUID, rotation.ID)
+	require.Equal(t, int32(2), rotation.ToVersion)
+	require.Equal(t, int64(3), rotation.TotalItems)
+
+	mock.ExpectQuery("INSERT INTO key_rotations").
+		WithArgs("wrapped-key", userUUID, int32(1)).
+		WillReturnError(errors.New("db error"))
+
+	_, err = storage.StartKeyRotation(t.Context(), params)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to start key rotation")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountStaleItems(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	userUUID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	mock.ExpectQuery("AS remaining").
+		WithArgs(userUUID, int32(2)).
+		WillReturnRows(pgxmock.NewRows([]string{"remaining"}).AddRow(int64(7)))
+
+	remaining, err := storage.CountStaleItems(t.Context(), userUUID, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), remaining)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReencryptPassword(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	params := db.ReencryptPasswordParams{
+		Password:      "sealed",
+		KeyVersion:    2,
+		ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Version:       4,
+		OldKeyVersion: 1,
+	}
+
+	mock.ExpectExec("UPDATE passwords").
+		WithArgs("sealed", int32(2), params.ID, int64(4), int32(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	ok, err := storage.ReencryptPassword(t.Context(), params)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The password changed since it was read.
+	mock.ExpectExec("UPDATE passwords").
+		WithArgs("sealed", int32(2), params.ID, int64(4), int32(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	ok, err = storage.ReencryptPassword(t.Context(), params)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 
 	"github.com/npavlov/go-password-manager/internal/server/db"
@@ -40,6 +41,21 @@ func (ds *DBStorage) GetMetaInfo(ctx context.Context, recordID string) ([]db.Get
 	return meta, nil
 }
 
+// GetMetaInfoByItemIDs returns the metadata of several items at once.
+func (ds *DBStorage) GetMetaInfoByItemIDs(
+	ctx context.Context,
+	itemIDs []pgtype.UUID,
+) ([]db.GetMetaInfoByItemIDsRow, error) {
+	meta, err := ds.Queries.GetMetaInfoByItemIDs(ctx, itemIDs)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to get items meta")
+
+		return nil, errors.Wrap(err, "failed to get items meta")
+	}
+
+	return meta, nil
+}
+
 func (ds *DBStorage) DeleteMetaInfo(ctx context.Context, key, itemID string) error {
 	uuid := utils.GetIDFromString(itemID)
 
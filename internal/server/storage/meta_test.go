@@ -226,3 +226,32 @@ func TestDeleteMetaInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMetaInfoByItemIDs(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	itemID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	itemIDs := []pgtype.UUID{itemID}
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(itemIDs).
+		WillReturnRows(pgxmock.NewRows([]string{"item_id", "key", "value"}).
+			AddRow(itemID, "site", "example.com"))
+
+	result, err := storage.GetMetaInfoByItemIDs(t.Context(), itemIDs)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, itemID, result[0].ItemID)
+	require.Equal(t, "site", result[0].Key)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(itemIDs).
+		WillReturnError(errors.New("database error"))
+
+	result, err = storage.GetMetaInfoByItemIDs(t.Context(), itemIDs)
+	require.Error(t, err)
+	require.Nil(t, result)
+	require.Contains(t, err.Error(), "failed to get items meta")
+}
@@ -10,9 +10,20 @@ import (
 	"github.com/npavlov/go-password-manager/internal/utils"
 )
 
-// StoreNote creates new note record.
-func (ds *DBStorage) StoreNote(ctx context.Context, createNote db.CreateNoteEntryParams) (*db.Note, error) {
-	note, err := ds.Queries.CreateNoteEntry(ctx, createNote)
+// StoreNote creates new note record, in the collection when one is given.
+func (ds *DBStorage) StoreNote(
+	ctx context.Context,
+	createNote db.CreateNoteEntryParams,
+	collectionID pgtype.UUID,
+) (*db.Note, error) {
+	var note db.Note
+
+	err := ds.storeItem(ctx, collectionID, func(queries *db.Queries) (pgtype.UUID, error) {
+		var err error
+		note, err = queries.CreateNoteEntry(ctx, createNote)
+
+		return note.ID, err
+	})
 	if err != nil {
 		ds.log.Error().Err(err).Msg("failed to store note")
 
@@ -39,11 +50,12 @@ func (ds *DBStorage) GetNote(ctx context.Context, noteID string, userID pgtype.U
 	return &note, nil
 }
 
-// GetNotes retrieves note records.
-func (ds *DBStorage) GetNotes(ctx context.Context, userID string) ([]db.Note, error) {
-	uuid := utils.GetIDFromString(userID)
-
-	notes, err := ds.Queries.GetNotesByUserID(ctx, uuid)
+// GetNotes retrieves a page of note records owned by the user.
+func (ds *DBStorage) GetNotes(
+	ctx context.Context,
+	params db.GetNotesByUserIDParams,
+) ([]db.Note, error) {
+	notes, err := ds.Queries.GetNotesByUserID(ctx, params)
 	if err != nil {
 		ds.log.Error().Err(err).Msg("failed to create note")
 
@@ -53,6 +65,33 @@ func (ds *DBStorage) GetNotes(ctx context.Context, userID string) ([]db.Note, er
 	return notes, nil
 }
 
+// CountNotes returns the total number of note records owned by the user.
+func (ds *DBStorage) CountNotes(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	count, err := ds.Queries.CountNotesByUserID(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to count notes")
+
+		return 0, errors.Wrap(err, "failed to count notes")
+	}
+
+	return count, nil
+}
+
+// GetNotesByIDs retrieves the note records with the given IDs owned by the user.
+func (ds *DBStorage) GetNotesByIDs(
+	ctx context.Context,
+	params db.GetNotesByIDsParams,
+) ([]db.Note, error) {
+	notes, err := ds.Queries.GetNotesByIDs(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to get notes by ids")
+
+		return nil, errors.Wrap(err, "failed to get notes by ids")
+	}
+
+	return notes, nil
+}
+
 func (ds *DBStorage) DeleteNote(ctx context.Context, noteID string, userID pgtype.UUID) error {
 	uuid := utils.GetIDFromString(noteID)
 
@@ -15,9 +15,12 @@ import (
 	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
 )
 
+var noteColumns = []string{"id", "user_id", "encrypted_content", "created_at", "updated_at", "version", "key_version"}
+
 func TestStoreNote(t *testing.T) {
 	t.Parallel()
 	noteID := uuid.New()
+	noteUUID := pgtype.UUID{Bytes: noteID, Valid: true}
 	userID := uuid.New()
 	userUUID := pgtype.UUID{Bytes: userID, Valid: true}
 
@@ -31,15 +34,16 @@ func TestStoreNote(t *testing.T) {
 		{
 			name: "successful note creation",
 			createNote: db.CreateNoteEntryParams{
+				ID:               noteUUID,
 				UserID:           userUUID,
 				EncryptedContent: "encrypted_content",
 			},
 			mock: func(mock pgxmock.PgxPoolIface) {
 				now := time.Now()
-				rows := pgxmock.NewRows([]string{"id", "user_id", "encrypted_content", "created_at", "updated_at"}).
-					AddRow(noteID.String(), userID.String(), "encrypted_content", now, now)
+				rows := pgxmock.NewRows(noteColumns).
+					AddRow(noteID.String(), userID.String(), "encrypted_content", now, now, int64(1), int32(1))
 				mock.ExpectQuery("INSERT INTO notes").
-					WithArgs(userUUID, "encrypted_content").
+					WithArgs(noteUUID, userUUID, "encrypted_content", int32(0)).
 					WillReturnRows(rows)
 			},
 			want: &db.Note{
@@ -50,12 +54,13 @@ func TestStoreNote(t *testing.T) {
 		{
 			name: "database error",
 			createNote: db.CreateNoteEntryParams{
+				ID:               noteUUID,
 				UserID:           userUUID,
 				EncryptedContent: "encrypted_content",
 			},
 			mock: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery("INSERT INTO notes").
-					WithArgs(userUUID, "encrypted_content").
+					WithArgs(noteUUID, userUUID, "encrypted_content", int32(0)).
 					WillReturnError(errors.New("db error"))
 			},
 			want:    nil,
@@ -70,7 +75,7 @@ func TestStoreNote(t *testing.T) {
 			storage, mock := testutils.SetupDBStorage(t)
 			tt.mock(mock)
 
-			result, err := storage.StoreNote(t.Context(), tt.createNote)
+			result, err := storage.StoreNote(t.Context(), tt.createNote, pgtype.UUID{})
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -107,8 +112,8 @@ func TestGetNote(t *testing.T) {
 			userID: userUUID,
 			mock: func(mock pgxmock.PgxPoolIface) {
 				now := time.Now()
-				rows := pgxmock.NewRows([]string{"id", "user_id", "encrypted_content", "created_at", "updated_at"}).
-					AddRow(noteID.String(), userID.String(), "encrypted_content", now, now)
+				rows := pgxmock.NewRows(noteColumns).
+					AddRow(noteID.String(), userID.String(), "encrypted_content", now, now, int64(1), int32(1))
 				mock.ExpectQuery("SELECT").
 					WithArgs(noteUUID, userUUID).
 					WillReturnRows(rows)
@@ -160,23 +165,29 @@ func TestGetNotes(t *testing.T) {
 	userID := uuid.New()
 	userUUID := pgtype.UUID{Bytes: userID, Valid: true}
 
+	params := db.GetNotesByUserIDParams{
+		UserID: userUUID,
+		Limit:  10,
+		Offset: 0,
+	}
+
 	tests := []struct {
 		name    string
-		userID  string
+		params  db.GetNotesByUserIDParams
 		mock    func(mock pgxmock.PgxPoolIface)
 		want    []db.Note
 		wantErr bool
 	}{
 		{
 			name:   "successful notes retrieval",
-			userID: userID.String(),
+			params: params,
 			mock: func(mock pgxmock.PgxPoolIface) {
 				now := time.Now()
-				rows := pgxmock.NewRows([]string{"id", "user_id", "encrypted_content", "created_at", "updated_at"}).
-					AddRow(noteID.String(), userID.String(), "note1", now, now).
-					AddRow(noteID.String(), userID.String(), "note2", now.Add(-time.Hour), now.Add(-time.Hour))
-				mock.ExpectQuery("SELECT id, user_id, encrypted_content, created_at, updated_at FROM notes").
-					WithArgs(userUUID).
+				rows := pgxmock.NewRows(noteColumns).
+					AddRow(noteID.String(), userID.String(), "note1", now, now, int64(1), int32(1)).
+					AddRow(noteID.String(), userID.String(), "note2", now.Add(-time.Hour), now.Add(-time.Hour), int64(1), int32(1))
+				mock.ExpectQuery("SELECT id, user_id, encrypted_content, created_at, updated_at, version, key_version FROM notes").
+					WithArgs(params.UserID, params.Limit, params.Offset).
 					WillReturnRows(rows)
 			},
 			want: []db.Note{
@@ -187,11 +198,11 @@ func TestGetNotes(t *testing.T) {
 		},
 		{
 			name:   "no notes found",
-			userID: userID.String(),
+			params: params,
 			mock: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "user_id", "encrypted_content", "created_at", "updated_at"})
-				mock.ExpectQuery("SELECT id, user_id, encrypted_content, created_at, updated_at FROM notes").
-					WithArgs(userUUID).
+				rows := pgxmock.NewRows(noteColumns)
+				mock.ExpectQuery("SELECT id, user_id, encrypted_content, created_at, updated_at, version, key_version FROM notes").
+					WithArgs(params.UserID, params.Limit, params.Offset).
 					WillReturnRows(rows)
 			},
 			want:    []db.Note{},
@@ -199,10 +210,10 @@ func TestGetNotes(t *testing.T) {
 		},
 		{
 			name:   "database error",
-			userID: userID.String(),
+			params: params,
 			mock: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery("SELECT id, user_id, encrypted_content, created_at, updated_at FROM notes").
-					WithArgs(userUUID).
+				mock.ExpectQuery("SELECT id, user_id, encrypted_content, created_at, updated_at, version, key_version FROM notes").
+					WithArgs(params.UserID, params.Limit, params.Offset).
 					WillReturnError(errors.New("db error"))
 			},
 			want:    nil,
@@ -217,7 +228,7 @@ func TestGetNotes(t *testing.T) {
 			storage, mock := testutils.SetupDBStorage(t)
 			tt.mock(mock)
 
-			result, err := storage.GetNotes(t.Context(), tt.userID)
+			result, err := storage.GetNotes(t.Context(), tt.params)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -294,3 +305,28 @@ func TestDeleteNote(t *testing.T) {
 		})
 	}
 }
+
+func TestCountNotes(t *testing.T) {
+	t.Parallel()
+
+	userUUID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectQuery("SELECT COUNT").
+		WithArgs(userUUID).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(2)))
+
+	count, err := storage.CountNotes(t.Context(), userUUID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	mock.ExpectQuery("SELECT COUNT").
+		WithArgs(userUUID).
+		WillReturnError(errors.New("db error"))
+
+	_, err = storage.CountNotes(t.Context(), userUUID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to count notes")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// CreateOrganization creates an organization, its vault and the owner membership in one statement.
+func (ds *DBStorage) CreateOrganization(
+	ctx context.Context,
+	params db.CreateOrganizationParams,
+) (*db.CreateOrganizationRow, error) {
+	org, err := ds.Queries.CreateOrganization(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to create organization")
+
+		return nil, errors.Wrap(err, "failed to create organization")
+	}
+
+	return &org, nil
+}
+
+// ListOrganizations returns the organizations the user is a member of, with the role of the user.
+func (ds *DBStorage) ListOrganizations(ctx context.Context, userID pgtype.UUID) ([]db.ListOrganizationsRow, error) {
+	orgs, err := ds.Queries.ListOrganizations(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list organizations")
+
+		return nil, errors.Wrap(err, "failed to list organizations")
+	}
+
+	return orgs, nil
+}
+
+// GetMembership returns the organization with the role of the user, if the user is a member.
+func (ds *DBStorage) GetMembership(ctx context.Context, orgID, userID pgtype.UUID) (*db.GetMembershipRow, error) {
+	membership, err := ds.Queries.GetMembership(ctx, db.GetMembershipParams{
+		OrgID:  orgID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get membership")
+	}
+
+	return &membership, nil
+}
+
+// DeleteOrganization deletes an organization along with its vault. It reports false when there is no such
+// organization.
+func (ds *DBStorage) DeleteOrganization(ctx context.Context, orgID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.DeleteOrganization(ctx, orgID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete organization")
+
+		return false, errors.Wrap(err, "failed to delete organization")
+	}
+
+	return affected == 1, nil
+}
+
+// CountCollections returns the number of collections of an organization.
+func (ds *DBStorage) CountCollections(ctx context.Context, orgID pgtype.UUID) (int64, error) {
+	count, err := ds.Queries.CountCollections(ctx, orgID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count collections")
+	}
+
+	return count, nil
+}
+
+// UpsertOrgMember adds a member to an organization, or changes the role of a member.
+func (ds *DBStorage) UpsertOrgMember(ctx context.Context, params db.UpsertOrgMemberParams) (*db.OrgMember, error) {
+	member, err := ds.Queries.UpsertOrgMember(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to store member")
+
+		return nil, errors.Wrap(err, "failed to store member")
+	}
+
+	return &member, nil
+}
+
+// DeleteOrgMember removes a member from an organization. It reports false when the user is no member.
+func (ds *DBStorage) DeleteOrgMember(ctx context.Context, orgID, userID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.DeleteOrgMember(ctx, db.DeleteOrgMemberParams{
+		OrgID:  orgID,
+		UserID: userID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete member")
+
+		return false, errors.Wrap(err, "failed to delete member")
+	}
+
+	return affected == 1, nil
+}
+
+// CountOrgOwners returns the number of owners of an organization.
+func (ds *DBStorage) CountOrgOwners(ctx context.Context, orgID pgtype.UUID) (int64, error) {
+	count, err := ds.Queries.CountOrgOwners(ctx, orgID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count owners")
+	}
+
+	return count, nil
+}
+
+// CountSoleOwnerships returns the number of organizations the user is the only owner of.
+func (ds *DBStorage) CountSoleOwnerships(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	count, err := ds.Queries.CountSoleOwnerships(ctx, userID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count owned organizations")
+	}
+
+	return count, nil
+}
+
+// ListOrgMembers returns the members of an organization by username.
+func (ds *DBStorage) ListOrgMembers(ctx context.Context, orgID pgtype.UUID) ([]db.ListOrgMembersRow, error) {
+	members, err := ds.Queries.ListOrgMembers(ctx, orgID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list members")
+
+		return nil, errors.Wrap(err, "failed to list members")
+	}
+
+	return members, nil
+}
+
+// CreateCollection creates a collection in an organization.
+func (ds *DBStorage) CreateCollection(ctx context.Context, params db.CreateCollectionParams) (*db.Collection, error) {
+	collection, err := ds.Queries.CreateCollection(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to create collection")
+
+		return nil, errors.Wrap(err, "failed to create collection")
+	}
+
+	return &collection, nil
+}
+
+// DeleteCollection deletes a collection. It reports false when there is no such collection.
+func (ds *DBStorage) DeleteCollection(ctx context.Context, collectionID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.DeleteCollection(ctx, collectionID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete collection")
+
+		return false, errors.Wrap(err, "failed to delete collection")
+	}
+
+	return affected == 1, nil
+}
+
+// ListCollections returns the collections of an organization by name, either all of them or only those
+// the user was given access to, with the access of the user.
+func (ds *DBStorage) ListCollections(
+	ctx context.Context,
+	params db.ListCollectionsParams,
+) ([]db.ListCollectionsRow, error) {
+	collections, err := ds.Queries.ListCollections(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list collections")
+
+		return nil, errors.Wrap(err, "failed to list collections")
+	}
+
+	return collections, nil
+}
+
+// UpsertCollectionMember gives a member access to a collection, or changes it. It reports false when
+// there is no such collection.
+func (ds *DBStorage) UpsertCollectionMember(
+	ctx context.Context,
+	params db.UpsertCollectionMemberParams,
+) (bool, error) {
+	affected, err := ds.Queries.UpsertCollectionMember(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to store collection access")
+
+		return false, errors.Wrap(err, "failed to store collection access")
+	}
+
+	return affected == 1, nil
+}
+
+// DeleteCollectionMember takes the access to a collection away from a member. It reports false when
+// the member had none.
+func (ds *DBStorage) DeleteCollectionMember(ctx context.Context, collectionID, userID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.DeleteCollectionMember(ctx, db.DeleteCollectionMemberParams{
+		CollectionID: collectionID,
+		UserID:       userID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete collection access")
+
+		return false, errors.Wrap(err, "failed to delete collection access")
+	}
+
+	return affected == 1, nil
+}
+
+// ListCollectionItems returns the items of a collection, the newest first.
+func (ds *DBStorage) ListCollectionItems(
+	ctx context.Context,
+	collectionID pgtype.UUID,
+) ([]db.ListCollectionItemsRow, error) {
+	items, err := ds.Queries.ListCollectionItems(ctx, collectionID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list collection items")
+
+		return nil, errors.Wrap(err, "failed to list collection items")
+	}
+
+	return items, nil
+}
+
+// GetItemAccess returns the vault and collection of an organization item, with the role and collection
+// access of the user. Items outside of collections have none.
+func (ds *DBStorage) GetItemAccess(ctx context.Context, itemID, userID pgtype.UUID) (*db.GetItemAccessRow, error) {
+	access, err := ds.Queries.GetItemAccess(ctx, db.GetItemAccessParams{
+		UserID: userID,
+		ItemID: itemID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get item access")
+	}
+
+	return &access, nil
+}
+
+// GetCollectionAccess returns the organization and vault of a collection, with the role and collection
+// access of the user, if the user is a member of the organization.
+func (ds *DBStorage) GetCollectionAccess(
+	ctx context.Context,
+	collectionID, userID pgtype.UUID,
+) (*db.GetCollectionAccessRow, error) {
+	access, err := ds.Queries.GetCollectionAccess(ctx, db.GetCollectionAccessParams{
+		UserID:       userID,
+		CollectionID: collectionID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get collection access")
+	}
+
+	return &access, nil
+}
@@ -0,0 +1,161 @@
+//nolint:exhaustruct
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestCreateOrganization(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	orgID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	params := db.CreateOrganizationParams{EncryptionKey: "wrapped", Name: "Acme", OwnerID: userUUID}
+
+	mock.ExpectQuery("INSERT INTO organizations").
+		WithArgs("wrapped", "Acme", userUUID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "vault_id", "created_at"}).
+			AddRow(orgID, "Acme", tokenUUID, pgFixedTime))
+	mock.ExpectQuery("INSERT INTO organizations").
+		WithArgs("wrapped", "Acme", userUUID).
+		WillReturnError(errors.New("db error"))
+
+	org, err := storage.CreateOrganization(t.Context(), params)
+	require.NoError(t, err)
+	require.Equal(t, orgID, org.ID)
+	require.Equal(t, tokenUUID, org.VaultID)
+
+	_, err = storage.CreateOrganization(t.Context(), params)
+	require.ErrorContains(t, err, "failed to create organization")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMembership(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	orgID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	mock.ExpectQuery("SELECT (.+) FROM organizations").
+		WithArgs(orgID, userUUID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "vault_id", "created_at", "role"}).
+			AddRow(orgID, "Acme", tokenUUID, pgFixedTime, db.OrgRoleAdmin))
+	mock.ExpectQuery("SELECT (.+) FROM organizations").
+		WithArgs(orgID, tokenUUID).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectExec("DELETE FROM org_members").
+		WithArgs(orgID, userUUID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec("DELETE FROM users").
+		WithArgs(orgID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	membership, err := storage.GetMembership(t.Context(), orgID, userUUID)
+	require.NoError(t, err)
+	require.Equal(t, db.OrgRoleAdmin, membership.Role)
+
+	_, err = storage.GetMembership(t.Context(), orgID, tokenUUID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	deleted, err := storage.DeleteOrgMember(t.Context(), orgID, userUUID)
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	deleted, err = storage.DeleteOrganization(t.Context(), orgID)
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreCollectionItem(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	collectionID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	params := db.CreatePasswordEntryParams{ID: passwordUUID, UserID: userUUID, Login: "root", Password: "secret"}
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO passwords").
+		WithArgs(passwordUUID, userUUID, "root", "secret", int32(0)).
+		WillReturnRows(pgxmock.NewRows(passwordColumns).
+			AddRow(passwordUUID, userUUID, "root", "secret", now, now, int64(1), int32(1)))
+	mock.ExpectExec("INSERT INTO collection_items").
+		WithArgs(passwordUUID, collectionID).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	// An item that is not stored, like one whose ID is taken, is not put into the collection
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO passwords").
+		WithArgs(passwordUUID, userUUID, "root", "secret", int32(0)).
+		WillReturnError(errors.New("duplicate key value violates unique constraint"))
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO passwords").
+		WithArgs(passwordUUID, userUUID, "root", "secret", int32(0)).
+		WillReturnRows(pgxmock.NewRows(passwordColumns).
+			AddRow(passwordUUID, userUUID, "root", "secret", now, now, int64(1), int32(1)))
+	mock.ExpectExec("INSERT INTO collection_items").
+		WithArgs(passwordUUID, collectionID).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	password, err := storage.StorePassword(t.Context(), params, collectionID)
+	require.NoError(t, err)
+	require.Equal(t, passwordUUID, password.ID)
+
+	_, err = storage.StorePassword(t.Context(), params, collectionID)
+	require.ErrorContains(t, err, "failed to store password")
+
+	_, err = storage.StorePassword(t.Context(), params, collectionID)
+	require.ErrorContains(t, err, "failed to add item to collection")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCollectionAccess(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	itemID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	collectionID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	mock.ExpectQuery("SELECT (.+) FROM items").
+		WithArgs(userUUID, itemID).
+		WillReturnRows(pgxmock.NewRows([]string{"vault_id", "collection_id", "role", "read_only"}).
+			AddRow(tokenUUID, collectionID, db.NullOrgRole{OrgRole: db.OrgRoleMember, Valid: true},
+				pgtype.Bool{Bool: true, Valid: true}))
+	mock.ExpectExec("INSERT INTO collection_members").
+		WithArgs(userUUID, false, collectionID).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+	access, err := storage.GetItemAccess(t.Context(), itemID, userUUID)
+	require.NoError(t, err)
+	require.Equal(t, tokenUUID, access.VaultID)
+	require.Equal(t, db.OrgRoleMember, access.Role.OrgRole)
+	require.True(t, access.ReadOnly.Bool)
+
+	// Nothing is stored for a collection that does not exist
+	stored, err := storage.UpsertCollectionMember(t.Context(), db.UpsertCollectionMemberParams{
+		UserID: userUUID, CollectionID: collectionID,
+	})
+	require.NoError(t, err)
+	require.False(t, stored)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -11,12 +11,20 @@ import (
 	"github.com/npavlov/go-password-manager/internal/utils"
 )
 
-// StorePassword creates new password record.
+// StorePassword creates new password record, in the collection when one is given.
 func (ds *DBStorage) StorePassword(
 	ctx context.Context,
 	createPassword db.CreatePasswordEntryParams,
+	collectionID pgtype.UUID,
 ) (*db.Password, error) {
-	password, err := ds.Queries.CreatePasswordEntry(ctx, createPassword)
+	var password db.Password
+
+	err := ds.storeItem(ctx, collectionID, func(queries *db.Queries) (pgtype.UUID, error) {
+		var err error
+		password, err = queries.CreatePasswordEntry(ctx, createPassword)
+
+		return password.ID, err
+	})
 	if err != nil {
 		ds.log.Error().Err(err).Msg("failed to store password")
 
@@ -43,11 +51,12 @@ func (ds *DBStorage) GetPassword(ctx context.Context, passwordID string, userID
 	return &password, nil
 }
 
-// GetPasswords retrieves password record.
-func (ds *DBStorage) GetPasswords(ctx context.Context, userID string) ([]db.Password, error) {
-	uuid := utils.GetIDFromString(userID)
-
-	passwords, err := ds.Queries.GetPasswordEntriesByUserID(ctx, uuid)
+// GetPasswords retrieves a page of password records owned by the user.
+func (ds *DBStorage) GetPasswords(
+	ctx context.Context,
+	params db.GetPasswordEntriesByUserIDParams,
+) ([]db.Password, error) {
+	passwords, err := ds.Queries.GetPasswordEntriesByUserID(ctx, params)
 	if err != nil {
 		ds.log.Error().Err(err).Msg("failed to create password")
 
@@ -57,6 +66,33 @@ func (ds *DBStorage) GetPasswords(ctx context.Context, userID string) ([]db.Pass
 	return passwords, nil
 }
 
+// CountPasswords returns the total number of password records owned by the user.
+func (ds *DBStorage) CountPasswords(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	count, err := ds.Queries.CountPasswordEntriesByUserID(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to count passwords")
+
+		return 0, errors.Wrap(err, "failed to count passwords")
+	}
+
+	return count, nil
+}
+
+// GetPasswordsByIDs retrieves the password records with the given IDs owned by the user.
+func (ds *DBStorage) GetPasswordsByIDs(
+	ctx context.Context,
+	params db.GetPasswordEntriesByIDsParams,
+) ([]db.Password, error) {
+	passwords, err := ds.Queries.GetPasswordEntriesByIDs(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to get passwords by ids")
+
+		return nil, errors.Wrap(err, "failed to get passwords by ids")
+	}
+
+	return passwords, nil
+}
+
 func (ds *DBStorage) DeletePassword(ctx context.Context, passwordID string, userID pgtype.UUID) error {
 	uuid := utils.GetIDFromString(passwordID)
 
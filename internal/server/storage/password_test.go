@@ -24,6 +24,8 @@ const (
 var (
 	userUUID     = pgtype.UUID{Bytes: uuid.MustParse(testUserID), Valid: true}
 	passwordUUID = pgtype.UUID{Bytes: uuid.MustParse(testPasswordID), Valid: true}
+
+	passwordColumns = []string{"id", "user_id", "login", "password", "created_at", "updated_at", "version", "key_version"}
 )
 
 func TestStorePassword(t *testing.T) {
@@ -40,16 +42,17 @@ func TestStorePassword(t *testing.T) {
 		{
 			name: "successful password creation",
 			createParams: db.CreatePasswordEntryParams{
+				ID:       passwordUUID,
 				UserID:   userUUID,
 				Login:    "test_login",
 				Password: "test_password",
 			},
 			mock: func(mock pgxmock.PgxPoolIface) {
 				now := time.Now()
-				rows := pgxmock.NewRows([]string{"id", "user_id", "login", "password", "created_at", "updated_at"}).
-					AddRow(passwordUUID, userUUID, "test_login", "test_password", now, now)
+				rows := pgxmock.NewRows(passwordColumns).
+					AddRow(passwordUUID, userUUID, "test_login", "test_password", now, now, int64(1), int32(1))
 				mock.ExpectQuery("INSERT INTO passwords").
-					WithArgs(userUUID, "test_login", "test_password").
+					WithArgs(passwordUUID, userUUID, "test_login", "test_password", int32(0)).
 					WillReturnRows(rows)
 			},
 			want: &db.Password{
@@ -63,13 +66,14 @@ func TestStorePassword(t *testing.T) {
 		{
 			name: "database error",
 			createParams: db.CreatePasswordEntryParams{
+				ID:       passwordUUID,
 				UserID:   userUUID,
 				Login:    "test_login",
 				Password: "test_password",
 			},
 			mock: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery("INSERT INTO passwords").
-					WithArgs(userUUID, "test_login", "test_password").
+					WithArgs(passwordUUID, userUUID, "test_login", "test_password", int32(0)).
 					WillReturnError(errors.New("db error"))
 			},
 			want:          nil,
@@ -85,7 +89,7 @@ func TestStorePassword(t *testing.T) {
 			storage, mock := testutils.SetupDBStorage(t)
 			tt.mock(mock)
 
-			result, err := storage.StorePassword(t.Context(), tt.createParams)
+			result, err := storage.StorePassword(t.Context(), tt.createParams, pgtype.UUID{})
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -121,8 +125,8 @@ func TestGetPassword(t *testing.T) {
 			userID:     userUUID,
 			mock: func(mock pgxmock.PgxPoolIface) {
 				now := time.Now()
-				rows := pgxmock.NewRows([]string{"id", "user_id", "login", "password", "created_at", "updated_at"}).
-					AddRow(passwordUUID, userUUID, "test_login", "test_password", now, now)
+				rows := pgxmock.NewRows(passwordColumns).
+					AddRow(passwordUUID, userUUID, "test_login", "test_password", now, now, int64(1), int32(1))
 				mock.ExpectQuery("SELECT passwords.id, passwords.user_id, passwords.login, passwords.password, passwords.created_at, passwords.updated_at").
 					WithArgs(passwordUUID, userUUID).
 					WillReturnRows(rows)
@@ -178,9 +182,15 @@ func TestGetPassword(t *testing.T) {
 func TestGetPasswords(t *testing.T) {
 	t.Parallel()
 
+	params := db.GetPasswordEntriesByUserIDParams{
+		UserID: userUUID,
+		Limit:  10,
+		Offset: 0,
+	}
+
 	tests := []struct {
 		name          string
-		userID        string
+		params        db.GetPasswordEntriesByUserIDParams
 		mock          func(mock pgxmock.PgxPoolIface)
 		want          []db.Password
 		wantErr       bool
@@ -188,14 +198,16 @@ func TestGetPasswords(t *testing.T) {
 	}{
 		{
 			name:   "successful passwords retrieval",
-			userID: testUserID,
+			params: params,
 			mock: func(mock pgxmock.PgxPoolIface) {
 				now := time.Now()
-				rows := pgxmock.NewRows([]string{"id", "user_id", "login", "password", "created_at", "updated_at"}).
-					AddRow(passwordUUID, userUUID, "test_login1", "test_password1", now, now).
-					AddRow(passwordUUID, userUUID, "test_login2", "test_password2", now.Add(-time.Hour), now.Add(-time.Hour))
-				mock.ExpectQuery("SELECT id, user_id, login, password, created_at, updated_at FROM passwords").
-					WithArgs(userUUID).
+				rows := pgxmock.NewRows(passwordColumns).
+					AddRow(passwordUUID, userUUID, "test_login1", "test_password1", now, now, int64(1), int32(1)).
+					AddRow(passwordUUID, userUUID, "test_login2", "test_password2",
+						now.Add(-time.Hour), now.Add(-time.Hour), int64(1), int32(1))
+				mock.ExpectQuery("SELECT id, user_id, login, password, created_at, updated_at, version, key_version "+
+					"FROM passwords").
+					WithArgs(params.UserID, params.Limit, params.Offset).
 					WillReturnRows(rows)
 			},
 			want: []db.Password{
@@ -216,11 +228,12 @@ func TestGetPasswords(t *testing.T) {
 		},
 		{
 			name:   "no passwords found",
-			userID: testUserID,
+			params: params,
 			mock: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "user_id", "login", "password", "created_at", "updated_at"})
-				mock.ExpectQuery("SELECT id, user_id, login, password, created_at, updated_at FROM passwords").
-					WithArgs(userUUID).
+				rows := pgxmock.NewRows(passwordColumns)
+				mock.ExpectQuery("SELECT id, user_id, login, password, created_at, updated_at, version, key_version "+
+					"FROM passwords").
+					WithArgs(params.UserID, params.Limit, params.Offset).
 					WillReturnRows(rows)
 			},
 			want:    []db.Password{},
@@ -228,10 +241,11 @@ func TestGetPasswords(t *testing.T) {
 		},
 		{
 			name:   "database error",
-			userID: testUserID,
+			params: params,
 			mock: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery("SELECT id, user_id, login, password, created_at, updated_at FROM passwords").
-					WithArgs(userUUID).
+				mock.ExpectQuery("SELECT id, user_id, login, password, created_at, updated_at, version, key_version "+
+					"FROM passwords").
+					WithArgs(params.UserID, params.Limit, params.Offset).
 					WillReturnError(errors.New("db error"))
 			},
 			want:          nil,
@@ -247,7 +261,7 @@ func TestGetPasswords(t *testing.T) {
 			storage, mock := testutils.SetupDBStorage(t)
 			tt.mock(mock)
 
-			result, err := storage.GetPasswords(t.Context(), tt.userID)
+			result, err := storage.GetPasswords(t.Context(), tt.params)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -339,16 +353,18 @@ func TestUpdatePassword(t *testing.T) {
 		{
 			name: "successful password update",
 			updateParams: db.UpdatePasswordEntryParams{
-				Login:    "updated_login",
-				Password: "updated_password",
-				ID:       passwordUUID,
+				Login:           "updated_login",
+				Password:        "updated_password",
+				ID:              passwordUUID,
+				UserID:          userUUID,
+				ExpectedVersion: 1,
 			},
 			mock: func(mock pgxmock.PgxPoolIface) {
 				now := time.Now()
-				rows := pgxmock.NewRows([]string{"id", "user_id", "login", "password", "created_at", "updated_at"}).
-					AddRow(passwordUUID, userUUID, "updated_login", "updated_password", now.Add(-time.Hour), now)
+				rows := pgxmock.NewRows(passwordColumns).
+					AddRow(passwordUUID, userUUID, "updated_login", "updated_password", now.Add(-time.Hour), now, int64(2), int32(1))
 				mock.ExpectQuery("UPDATE passwords").
-					WithArgs("updated_login", "updated_password", passwordUUID).
+					WithArgs("updated_login", "updated_password", int32(0), passwordUUID, userUUID, int64(1)).
 					WillReturnRows(rows)
 			},
 			want: &db.Password{
@@ -362,13 +378,15 @@ func TestUpdatePassword(t *testing.T) {
 		{
 			name: "database error",
 			updateParams: db.UpdatePasswordEntryParams{
-				Login:    "updated_login",
-				Password: "updated_password",
-				ID:       passwordUUID,
+				Login:           "updated_login",
+				Password:        "updated_password",
+				ID:              passwordUUID,
+				UserID:          userUUID,
+				ExpectedVersion: 1,
 			},
 			mock: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery("UPDATE passwords").
-					WithArgs("updated_login", "updated_password", passwordUUID).
+					WithArgs("updated_login", "updated_password", int32(0), passwordUUID, userUUID, int64(1)).
 					WillReturnError(errors.New("db error"))
 			},
 			want:          nil,
@@ -401,3 +419,27 @@ func TestUpdatePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestCountPasswords(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectQuery("SELECT COUNT").
+		WithArgs(userUUID).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+	count, err := storage.CountPasswords(t.Context(), userUUID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	mock.ExpectQuery("SELECT COUNT").
+		WithArgs(userUUID).
+		WillReturnError(errors.New("db error"))
+
+	_, err = storage.CountPasswords(t.Context(), userUUID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to count passwords")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// CreateSend stores a one-time send.
+func (ds *DBStorage) CreateSend(ctx context.Context, params db.CreateSendParams) (*db.Send, error) {
+	send, err := ds.Queries.CreateSend(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to create send")
+
+		return nil, errors.Wrap(err, "failed to create send")
+	}
+
+	return &send, nil
+}
+
+// ListSends returns the sends of a user, the newest first.
+func (ds *DBStorage) ListSends(ctx context.Context, userID pgtype.UUID) ([]db.Send, error) {
+	sends, err := ds.Queries.ListSends(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list sends")
+
+		return nil, errors.Wrap(err, "failed to list sends")
+	}
+
+	return sends, nil
+}
+
+// GetSend returns a send, whoever created it.
+func (ds *DBStorage) GetSend(ctx context.Context, sendID pgtype.UUID) (*db.Send, error) {
+	send, err := ds.Queries.GetSend(ctx, sendID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get send")
+	}
+
+	return &send, nil
+}
+
+// DeleteSend deletes a send of the user and returns it, so that its file can be removed too.
+func (ds *DBStorage) DeleteSend(ctx context.Context, sendID, userID pgtype.UUID) (*db.Send, error) {
+	send, err := ds.Queries.DeleteSend(ctx, db.DeleteSendParams{
+		ID:     sendID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to delete send")
+	}
+
+	return &send, nil
+}
+
+// ViewSend counts a view of a send. It returns pgx.ErrNoRows when the send is gone, expired or used up.
+func (ds *DBStorage) ViewSend(ctx context.Context, sendID pgtype.UUID, now time.Time) (*db.Send, error) {
+	send, err := ds.Queries.ViewSend(ctx, db.ViewSendParams{
+		ID:  sendID,
+		Now: pgtype.Timestamp{Time: now, Valid: true},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to view send")
+	}
+
+	return &send, nil
+}
+
+// RemoveSend deletes a send that was viewed for the last time.
+func (ds *DBStorage) RemoveSend(ctx context.Context, sendID pgtype.UUID) error {
+	if err := ds.Queries.RemoveSend(ctx, sendID); err != nil {
+		ds.log.Error().Err(err).Msg("failed to remove send")
+
+		return errors.Wrap(err, "failed to remove send")
+	}
+
+	return nil
+}
+
+// DeleteExpiredSends deletes the sends that expired or were used up at now, and returns them.
+func (ds *DBStorage) DeleteExpiredSends(ctx context.Context, now time.Time) ([]db.Send, error) {
+	sends, err := ds.Queries.DeleteExpiredSends(ctx, pgtype.Timestamp{Time: now, Valid: true})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete expired sends")
+
+		return nil, errors.Wrap(err, "failed to delete expired sends")
+	}
+
+	return sends, nil
+}
@@ -0,0 +1,110 @@
+//nolint:exhaustruct
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+var sendColumns = []string{
+	"id", "user_id", "send_type", "content", "object_name", "file_size", "password", "max_views", "views",
+	"expires_at", "created_at",
+}
+
+func TestCreateSend(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	sendID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	params := db.CreateSendParams{
+		ID: sendID, UserID: userUUID, SendType: db.SendTypeText, Content: "sealed", MaxViews: 1,
+		ExpiresAt: pgFixedTime,
+	}
+
+	mock.ExpectQuery("INSERT INTO sends").
+		WithArgs(sendID, userUUID, db.SendTypeText, "sealed", pgtype.Text{}, int64(0), pgtype.Text{}, int32(1),
+			pgFixedTime).
+		WillReturnRows(pgxmock.NewRows(sendColumns).AddRow(sendID, userUUID, db.SendTypeText, "sealed",
+			pgtype.Text{}, int64(0), pgtype.Text{}, int32(1), int32(0), pgFixedTime, pgFixedTime))
+	mock.ExpectQuery("INSERT INTO sends").
+		WithArgs(sendID, userUUID, db.SendTypeText, "sealed", pgtype.Text{}, int64(0), pgtype.Text{}, int32(1),
+			pgFixedTime).
+		WillReturnError(errors.New("db error"))
+
+	send, err := storage.CreateSend(t.Context(), params)
+	require.NoError(t, err)
+	require.Equal(t, sendID, send.ID)
+	require.Equal(t, int32(1), send.MaxViews)
+
+	_, err = storage.CreateSend(t.Context(), params)
+	require.ErrorContains(t, err, "failed to create send")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestViewSend(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	sendID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	mock.ExpectQuery("UPDATE sends").
+		WithArgs(sendID, pgFixedTime).
+		WillReturnRows(pgxmock.NewRows(sendColumns).AddRow(sendID, userUUID, db.SendTypeText, "sealed",
+			pgtype.Text{}, int64(0), pgtype.Text{}, int32(2), int32(1), pgFixedTime, pgFixedTime))
+	mock.ExpectQuery("UPDATE sends").
+		WithArgs(sendID, pgFixedTime).
+		WillReturnError(pgx.ErrNoRows)
+
+	send, err := storage.ViewSend(t.Context(), sendID, fixedTime)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), send.Views)
+
+	// Used up or expired
+	_, err = storage.ViewSend(t.Context(), sendID, fixedTime)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSends(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	sendID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	objectName := pgtype.Text{String: "object", Valid: true}
+
+	mock.ExpectQuery("DELETE FROM sends").
+		WithArgs(sendID, userUUID).
+		WillReturnRows(pgxmock.NewRows(sendColumns).AddRow(sendID, userUUID, db.SendTypeFile, "sealed",
+			objectName, int64(10), pgtype.Text{}, int32(1), int32(0), pgFixedTime, pgFixedTime))
+	mock.ExpectExec("DELETE FROM sends").
+		WithArgs(sendID).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectQuery("DELETE FROM sends").
+		WithArgs(pgFixedTime).
+		WillReturnRows(pgxmock.NewRows(sendColumns).AddRow(sendID, userUUID, db.SendTypeFile, "sealed",
+			objectName, int64(10), pgtype.Text{}, int32(1), int32(1), pgFixedTime, pgFixedTime))
+
+	send, err := storage.DeleteSend(t.Context(), sendID, userUUID)
+	require.NoError(t, err)
+	require.Equal(t, objectName, send.ObjectName)
+
+	err = storage.RemoveSend(t.Context(), sendID)
+	require.ErrorContains(t, err, "failed to remove send")
+
+	sends, err := storage.DeleteExpiredSends(t.Context(), fixedTime)
+	require.NoError(t, err)
+	require.Len(t, sends, 1)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// CreateSession records a new signed-in device of the user.
+func (ds *DBStorage) CreateSession(ctx context.Context, params db.CreateSessionParams) (*db.Session, error) {
+	session, err := ds.Queries.CreateSession(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to create session")
+
+		return nil, errors.Wrap(err, "failed to create session")
+	}
+
+	return &session, nil
+}
+
+// TouchSession marks a session as used from ipAddress and extends it to expiresAt.
+// It reports false when the session was revoked.
+func (ds *DBStorage) TouchSession(
+	ctx context.Context,
+	sessionID pgtype.UUID,
+	ipAddress string,
+	expiresAt time.Time,
+) (bool, error) {
+	affected, err := ds.Queries.TouchSession(ctx, db.TouchSessionParams{
+		IpAddress: ipAddress,
+		ExpiresAt: pgtype.Timestamp{Time: expiresAt, Valid: true},
+		ID:        sessionID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to touch session")
+
+		return false, errors.Wrap(err, "failed to touch session")
+	}
+
+	return affected == 1, nil
+}
+
+// ListSessions returns the unexpired sessions of the user, most recently used first.
+func (ds *DBStorage) ListSessions(ctx context.Context, userID pgtype.UUID) ([]db.Session, error) {
+	sessions, err := ds.Queries.ListSessions(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list sessions")
+
+		return nil, errors.Wrap(err, "failed to list sessions")
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession revokes a session of the user together with its refresh tokens.
+// It reports false when the user has no such session.
+func (ds *DBStorage) DeleteSession(ctx context.Context, userID, sessionID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.DeleteSession(ctx, db.DeleteSessionParams{
+		ID:     sessionID,
+		UserID: userID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete session")
+
+		return false, errors.Wrap(err, "failed to delete session")
+	}
+
+	return affected == 1, nil
+}
+
+// DeleteUserSessions revokes every session of the user and returns their IDs.
+func (ds *DBStorage) DeleteUserSessions(ctx context.Context, userID pgtype.UUID) ([]pgtype.UUID, error) {
+	sessionIDs, err := ds.Queries.DeleteUserSessions(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete sessions")
+
+		return nil, errors.Wrap(err, "failed to delete sessions")
+	}
+
+	return sessionIDs, nil
+}
@@ -0,0 +1,154 @@
+//nolint:exhaustruct
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+//nolint:gochecknoglobals
+var sessionColumns = []string{
+	"id", "user_id", "device_name", "ip_address", "created_at", "last_used_at", "expires_at",
+}
+
+func TestCreateSession(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	params := db.CreateSessionParams{
+		UserID:     userUUID,
+		DeviceName: "laptop",
+		IpAddress:  "10.0.0.1",
+		ExpiresAt:  pgFixedTime,
+	}
+
+	mock.ExpectQuery("INSERT INTO sessions").
+		WithArgs(userUUID, "laptop", "10.0.0.1", pgFixedTime).
+		WillReturnRows(pgxmock.NewRows(sessionColumns).
+			AddRow(tokenUUID, userUUID, "laptop", "10.0.0.1", pgFixedTime, pgFixedTime, pgFixedTime))
+	mock.ExpectQuery("INSERT INTO sessions").
+		WithArgs(userUUID, "laptop", "10.0.0.1", pgFixedTime).
+		WillReturnError(errors.New("db error"))
+
+	session, err := storage.CreateSession(t.Context(), params)
+	require.NoError(t, err)
+	require.Equal(t, tokenUUID, session.ID)
+	require.Equal(t, "laptop", session.DeviceName)
+
+	_, err = storage.CreateSession(t.Context(), params)
+	require.ErrorContains(t, err, "failed to create session")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTouchSession(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectExec("UPDATE sessions").
+		WithArgs("10.0.0.2", pgFixedTime, tokenUUID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE sessions").
+		WithArgs("10.0.0.2", pgFixedTime, tokenUUID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE sessions").
+		WithArgs("10.0.0.2", pgFixedTime, tokenUUID).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.TouchSession(t.Context(), tokenUUID, "10.0.0.2", fixedTime)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The session was revoked.
+	ok, err = storage.TouchSession(t.Context(), tokenUUID, "10.0.0.2", fixedTime)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.TouchSession(t.Context(), tokenUUID, "10.0.0.2", fixedTime)
+	require.ErrorContains(t, err, "failed to touch session")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListSessions(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectQuery("SELECT (.+) FROM sessions").
+		WithArgs(userUUID).
+		WillReturnRows(pgxmock.NewRows(sessionColumns).
+			AddRow(tokenUUID, userUUID, "laptop", "10.0.0.1", pgFixedTime, pgFixedTime, pgFixedTime))
+	mock.ExpectQuery("SELECT (.+) FROM sessions").
+		WithArgs(userUUID).
+		WillReturnError(errors.New("db error"))
+
+	sessions, err := storage.ListSessions(t.Context(), userUUID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, "10.0.0.1", sessions[0].IpAddress)
+
+	_, err = storage.ListSessions(t.Context(), userUUID)
+	require.ErrorContains(t, err, "failed to list sessions")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSession(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectExec("DELETE FROM sessions").
+		WithArgs(tokenUUID, userUUID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec("DELETE FROM sessions").
+		WithArgs(tokenUUID, userUUID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec("DELETE FROM sessions").
+		WithArgs(tokenUUID, userUUID).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.DeleteSession(t.Context(), userUUID, tokenUUID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = storage.DeleteSession(t.Context(), userUUID, tokenUUID)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.DeleteSession(t.Context(), userUUID, tokenUUID)
+	require.ErrorContains(t, err, "failed to delete session")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteUserSessions(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectQuery("DELETE FROM sessions").
+		WithArgs(userUUID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(tokenUUID))
+	mock.ExpectQuery("DELETE FROM sessions").
+		WithArgs(userUUID).
+		WillReturnError(errors.New("db error"))
+
+	sessionIDs, err := storage.DeleteUserSessions(t.Context(), userUUID)
+	require.NoError(t, err)
+	require.Equal(t, []pgtype.UUID{tokenUUID}, sessionIDs)
+
+	_, err = storage.DeleteUserSessions(t.Context(), userUUID)
+	require.ErrorContains(t, err, "failed to delete sessions")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// GetShareKey returns the share keypair of the user.
+func (ds *DBStorage) GetShareKey(ctx context.Context, userID pgtype.UUID) (*db.ShareKey, error) {
+	key, err := ds.Queries.GetShareKey(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get share key")
+	}
+
+	return &key, nil
+}
+
+// CreateShareKey stores the share keypair of the user unless the user already has one.
+func (ds *DBStorage) CreateShareKey(ctx context.Context, params db.CreateShareKeyParams) error {
+	if err := ds.Queries.CreateShareKey(ctx, params); err != nil {
+		ds.log.Error().Err(err).Msg("failed to create share key")
+
+		return errors.Wrap(err, "failed to create share key")
+	}
+
+	return nil
+}
+
+// ReencryptShareKey rewraps the private share key of a user unless it changed since it was read.
+func (ds *DBStorage) ReencryptShareKey(ctx context.Context, params db.ReencryptShareKeyParams) (bool, error) {
+	affected, err := ds.Queries.ReencryptShareKey(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to re-encrypt share key")
+
+		return false, errors.Wrap(err, "failed to re-encrypt share key")
+	}
+
+	return affected == 1, nil
+}
+
+// GetItemChangeSeq returns the change sequence of an item of the user that was not deleted.
+func (ds *DBStorage) GetItemChangeSeq(ctx context.Context, userID, itemID pgtype.UUID) (int64, error) {
+	seq, err := ds.Queries.GetItemChangeSeq(ctx, db.GetItemChangeSeqParams{
+		UserID: userID,
+		ItemID: itemID,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get item change")
+	}
+
+	return seq, nil
+}
+
+// UpsertShare shares an item with a user, or replaces the grant and payload of an existing share
+// of the same owner.
+func (ds *DBStorage) UpsertShare(ctx context.Context, params db.UpsertShareParams) (*db.Share, error) {
+	share, err := ds.Queries.UpsertShare(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to store share")
+
+		return nil, errors.Wrap(err, "failed to store share")
+	}
+
+	return &share, nil
+}
+
+// GetShare returns the share of an item with the recipient.
+func (ds *DBStorage) GetShare(ctx context.Context, itemID, recipientID pgtype.UUID) (*db.Share, error) {
+	share, err := ds.Queries.GetShare(ctx, db.GetShareParams{
+		ItemID:      itemID,
+		RecipientID: recipientID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get share")
+	}
+
+	return &share, nil
+}
+
+// RefreshShare replaces the payload of a share unless it was refreshed since it was read.
+func (ds *DBStorage) RefreshShare(ctx context.Context, params db.RefreshShareParams) (bool, error) {
+	affected, err := ds.Queries.RefreshShare(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to refresh share")
+
+		return false, errors.Wrap(err, "failed to refresh share")
+	}
+
+	return affected == 1, nil
+}
+
+// ListShares returns the shares of the owner's items, the newest first.
+func (ds *DBStorage) ListShares(ctx context.Context, params db.ListSharesParams) ([]db.ListSharesRow, error) {
+	shares, err := ds.Queries.ListShares(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list shares")
+
+		return nil, errors.Wrap(err, "failed to list shares")
+	}
+
+	return shares, nil
+}
+
+// DeleteShare revokes the share of an item with the recipient. It reports false when there is no such share.
+func (ds *DBStorage) DeleteShare(ctx context.Context, params db.DeleteShareParams) (bool, error) {
+	affected, err := ds.Queries.DeleteShare(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete share")
+
+		return false, errors.Wrap(err, "failed to delete share")
+	}
+
+	return affected == 1, nil
+}
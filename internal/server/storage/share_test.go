@@ -0,0 +1,137 @@
+//nolint:exhaustruct
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+//nolint:gochecknoglobals
+var shareColumns = []string{
+	"id", "item_id", "owner_id", "recipient_id", "read_only", "payload", "item_seq", "created_at",
+}
+
+func TestShareKey(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	params := db.CreateShareKeyParams{UserID: userUUID, PublicKey: "public", PrivateKey: "private", KeyVersion: 1}
+
+	mock.ExpectExec("INSERT INTO share_keys").
+		WithArgs(userUUID, "public", "private", int32(1)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("INSERT INTO share_keys").
+		WithArgs(userUUID, "public", "private", int32(1)).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectQuery("SELECT (.+) FROM share_keys").
+		WithArgs(userUUID).
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "public_key", "private_key", "key_version", "created_at"}).
+			AddRow(userUUID, "public", "private", int32(1), pgFixedTime))
+	mock.ExpectExec("UPDATE share_keys").
+		WithArgs("rewrapped", int32(2), userUUID, int32(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	require.NoError(t, storage.CreateShareKey(t.Context(), params))
+	require.ErrorContains(t, storage.CreateShareKey(t.Context(), params), "failed to create share key")
+
+	key, err := storage.GetShareKey(t.Context(), userUUID)
+	require.NoError(t, err)
+	require.Equal(t, "public", key.PublicKey)
+
+	ok, err := storage.ReencryptShareKey(t.Context(), db.ReencryptShareKeyParams{
+		PrivateKey: "rewrapped", KeyVersion: 2, UserID: userUUID, OldKeyVersion: 1,
+	})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertShare(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	itemID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	params := db.UpsertShareParams{
+		ItemID: itemID, OwnerID: userUUID, RecipientID: tokenUUID, ReadOnly: true, Payload: "sealed", ItemSeq: 3,
+	}
+	args := []any{itemID, userUUID, tokenUUID, true, "sealed", int64(3)}
+
+	mock.ExpectQuery("INSERT INTO shares").
+		WithArgs(args...).
+		WillReturnRows(pgxmock.NewRows(shareColumns).
+			AddRow(tokenUUID, itemID, userUUID, tokenUUID, true, "sealed", int64(3), pgFixedTime))
+	mock.ExpectQuery("INSERT INTO shares").
+		WithArgs(args...).
+		WillReturnError(errors.New("db error"))
+
+	share, err := storage.UpsertShare(t.Context(), params)
+	require.NoError(t, err)
+	require.Equal(t, itemID, share.ItemID)
+	require.True(t, share.ReadOnly)
+
+	_, err = storage.UpsertShare(t.Context(), params)
+	require.ErrorContains(t, err, "failed to store share")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshAndDeleteShare(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	itemID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	mock.ExpectExec("UPDATE shares").
+		WithArgs("sealed", int64(4), tokenUUID, int64(3)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("DELETE FROM shares").
+		WithArgs(itemID, userUUID, "bob").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	ok, err := storage.RefreshShare(t.Context(), db.RefreshShareParams{
+		Payload: "sealed", ItemSeq: 4, ID: tokenUUID, OldItemSeq: 3,
+	})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = storage.DeleteShare(t.Context(), db.DeleteShareParams{ItemID: itemID, OwnerID: userUUID, Recipient: "bob"})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListShares(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	itemID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	params := db.ListSharesParams{OwnerID: userUUID, ByItem: true, ItemID: itemID}
+
+	mock.ExpectQuery("SELECT (.+) FROM shares").
+		WithArgs(userUUID, true, itemID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "item_id", "read_only", "created_at", "recipient"}).
+			AddRow(tokenUUID, itemID, false, pgFixedTime, "bob"))
+	mock.ExpectQuery("SELECT (.+) FROM shares").
+		WithArgs(userUUID, true, itemID).
+		WillReturnError(errors.New("db error"))
+
+	shares, err := storage.ListShares(t.Context(), params)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	require.Equal(t, "bob", shares[0].Recipient)
+
+	_, err = storage.ListShares(t.Context(), params)
+	require.ErrorContains(t, err, "failed to list shares")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// ListSigningKeys returns the token signing keys, the latest to activate first.
+func (ds *DBStorage) ListSigningKeys(ctx context.Context) ([]db.SigningKey, error) {
+	keys, err := ds.Queries.ListSigningKeys(ctx)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list signing keys")
+
+		return nil, errors.Wrap(err, "failed to list signing keys")
+	}
+
+	return keys, nil
+}
+
+// CreateSigningKey adds a signing key unless a key activating after params.Latest exists, which means
+// another replica rotated first. It reports whether the key was added.
+func (ds *DBStorage) CreateSigningKey(ctx context.Context, params db.CreateSigningKeyParams) (bool, error) {
+	affected, err := ds.Queries.CreateSigningKey(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to create signing key")
+
+		return false, errors.Wrap(err, "failed to create signing key")
+	}
+
+	return affected == 1, nil
+}
+
+// DeleteSigningKey deletes a retired signing key.
+func (ds *DBStorage) DeleteSigningKey(ctx context.Context, keyID string) error {
+	if err := ds.Queries.DeleteSigningKey(ctx, keyID); err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete signing key")
+
+		return errors.Wrap(err, "failed to delete signing key")
+	}
+
+	return nil
+}
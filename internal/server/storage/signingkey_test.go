@@ -0,0 +1,95 @@
+//nolint:exhaustruct
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestListSigningKeys(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	columns := []string{"id", "algorithm", "private_key", "public_key", "created_at", "activates_at"}
+
+	mock.ExpectQuery("SELECT (.+) FROM signing_keys").
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow("key-2", "EdDSA", "wrapped-2", "public-2", pgFixedTime, pgFixedTime).
+			AddRow("key-1", "EdDSA", "wrapped-1", "public-1", pgFixedTime, pgFixedTime))
+	mock.ExpectQuery("SELECT (.+) FROM signing_keys").
+		WillReturnError(errors.New("db error"))
+
+	keys, err := storage.ListSigningKeys(t.Context())
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, "key-2", keys[0].ID)
+
+	_, err = storage.ListSigningKeys(t.Context())
+	require.ErrorContains(t, err, "failed to list signing keys")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateSigningKey(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	params := db.CreateSigningKeyParams{
+		ID:          "key-1",
+		Algorithm:   "EdDSA",
+		PrivateKey:  "wrapped",
+		PublicKey:   "public",
+		ActivatesAt: pgFixedTime,
+		Latest:      pgFixedTime,
+	}
+	args := []any{params.ID, params.Algorithm, params.PrivateKey, params.PublicKey, pgFixedTime, pgFixedTime}
+
+	mock.ExpectExec("INSERT INTO signing_keys").
+		WithArgs(args...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("INSERT INTO signing_keys").
+		WithArgs(args...).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	mock.ExpectExec("INSERT INTO signing_keys").
+		WithArgs(args...).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.CreateSigningKey(t.Context(), params)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Another replica added a newer key.
+	ok, err = storage.CreateSigningKey(t.Context(), params)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.CreateSigningKey(t.Context(), params)
+	require.ErrorContains(t, err, "failed to create signing key")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSigningKey(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectExec("DELETE FROM signing_keys").
+		WithArgs("key-1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec("DELETE FROM signing_keys").
+		WithArgs("key-1").
+		WillReturnError(errors.New("db error"))
+
+	require.NoError(t, storage.DeleteSigningKey(t.Context(), "key-1"))
+	require.ErrorContains(t, storage.DeleteSigningKey(t.Context(), "key-1"), "failed to delete signing key")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
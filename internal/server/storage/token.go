@@ -10,10 +10,11 @@ import (
 	"github.com/npavlov/go-password-manager/internal/server/db"
 )
 
-// StoreToken stores refresh token.
+// StoreToken stores the hash of a refresh token of a session.
 func (ds *DBStorage) StoreToken(ctx context.Context,
 	userID pgtype.UUID,
-	refreshToken string,
+	sessionID pgtype.UUID,
+	tokenHash string,
 	expiresAt time.Time,
 ) error {
 	var pgExpiresAt pgtype.Timestamp
@@ -24,16 +25,45 @@ func (ds *DBStorage) StoreToken(ctx context.Context,
 
 	err = ds.Queries.CreateRefreshToken(ctx, db.CreateRefreshTokenParams{
 		UserID:    userID,
-		Token:     refreshToken,
+		TokenHash: tokenHash,
 		ExpiresAt: pgExpiresAt,
+		SessionID: sessionID,
 	})
 
 	return errors.Wrap(err, "error creating refresh token")
 }
 
-// GetToken gets refresh token.
-func (ds *DBStorage) GetToken(ctx context.Context, token string) (db.GetRefreshTokenRow, error) {
-	tokenDB, err := ds.Queries.GetRefreshToken(ctx, token)
+// GetToken gets a refresh token by its hash, used or not.
+func (ds *DBStorage) GetToken(ctx context.Context, tokenHash string) (db.GetRefreshTokenRow, error) {
+	tokenDB, err := ds.Queries.GetRefreshToken(ctx, tokenHash)
 
 	return tokenDB, errors.Wrap(err, "error getting refresh token")
 }
+
+// UseToken marks a refresh token as exchanged and moves it to the session. It reports false
+// when the token was used already, so of two concurrent refreshes only one succeeds.
+func (ds *DBStorage) UseToken(ctx context.Context, tokenHash string, sessionID pgtype.UUID) (bool, error) {
+	rows, err := ds.Queries.UseRefreshToken(ctx, db.UseRefreshTokenParams{
+		SessionID: sessionID,
+		TokenHash: tokenHash,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "error using refresh token")
+	}
+
+	return rows > 0, nil
+}
+
+// DeleteToken deletes a refresh token by its hash.
+func (ds *DBStorage) DeleteToken(ctx context.Context, tokenHash string) error {
+	err := ds.Queries.DeleteRefreshToken(ctx, tokenHash)
+
+	return errors.Wrap(err, "error deleting refresh token")
+}
+
+// DeleteUserTokens deletes every refresh token of a user, including tokens without a session.
+func (ds *DBStorage) DeleteUserTokens(ctx context.Context, userID pgtype.UUID) error {
+	err := ds.Queries.DeleteUserRefreshTokens(ctx, userID)
+
+	return errors.Wrap(err, "error deleting refresh tokens")
+}
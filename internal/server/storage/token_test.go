@@ -17,7 +17,7 @@ import (
 
 // Static IDs and values for testing.
 const (
-	testToken   = "test_refresh_token"
+	testToken   = "test_refresh_token_hash"
 	testTokenID = "223e4567-e89b-12d3-a456-426614174000"
 )
 
@@ -33,32 +33,32 @@ func TestStoreToken(t *testing.T) {
 	tests := []struct {
 		name          string
 		userID        pgtype.UUID
-		refreshToken  string
+		tokenHash     string
 		expiresAt     time.Time
 		mock          func(mock pgxmock.PgxPoolIface)
 		wantErr       bool
 		expectedError string
 	}{
 		{
-			name:         "successful token storage",
-			userID:       userUUID,
-			refreshToken: testToken,
-			expiresAt:    fixedTime,
+			name:      "successful token storage",
+			userID:    userUUID,
+			tokenHash: testToken,
+			expiresAt: fixedTime,
 			mock: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectExec("INSERT INTO refresh_tokens").
-					WithArgs(userUUID, testToken, pgFixedTime).
+					WithArgs(userUUID, testToken, pgFixedTime, tokenUUID).
 					WillReturnResult(pgxmock.NewResult("INSERT", 1))
 			},
 			wantErr: false,
 		},
 		{
-			name:         "database error",
-			userID:       userUUID,
-			refreshToken: testToken,
-			expiresAt:    fixedTime,
+			name:      "database error",
+			userID:    userUUID,
+			tokenHash: testToken,
+			expiresAt: fixedTime,
 			mock: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectExec("INSERT INTO refresh_tokens").
-					WithArgs(userUUID, testToken, pgFixedTime).
+					WithArgs(userUUID, testToken, pgFixedTime, tokenUUID).
 					WillReturnError(errors.New("failed to scan expires at"))
 			},
 			wantErr:       true,
@@ -73,7 +73,7 @@ func TestStoreToken(t *testing.T) {
 			storage, mock := testutils.SetupDBStorage(t)
 			tt.mock(mock)
 
-			err := storage.StoreToken(t.Context(), tt.userID, tt.refreshToken, tt.expiresAt)
+			err := storage.StoreToken(t.Context(), tt.userID, tokenUUID, tt.tokenHash, tt.expiresAt)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -102,17 +102,18 @@ func TestGetToken(t *testing.T) {
 			name:  "successful token retrieval",
 			token: testToken,
 			mock: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "user_id", "token", "expires_at"}).
-					AddRow(tokenUUID, userUUID, testToken, pgFixedTime)
-				mock.ExpectQuery("SELECT id, user_id, token, expires_at FROM refresh_tokens").
+				rows := pgxmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "session_id", "used_at"}).
+					AddRow(tokenUUID, userUUID, testToken, pgFixedTime, tokenUUID, pgtype.Timestamp{})
+				mock.ExpectQuery("SELECT id, user_id, token_hash, expires_at, session_id, used_at FROM refresh_tokens").
 					WithArgs(testToken).
 					WillReturnRows(rows)
 			},
 			want: db.GetRefreshTokenRow{
 				ID:        tokenUUID,
 				UserID:    userUUID,
-				Token:     testToken,
+				TokenHash: testToken,
 				ExpiresAt: pgFixedTime,
+				SessionID: tokenUUID,
 			},
 			wantErr: false,
 		},
@@ -120,7 +121,7 @@ func TestGetToken(t *testing.T) {
 			name:  "token not found",
 			token: testToken,
 			mock: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery("SELECT id, user_id, token, expires_at FROM refresh_tokens").
+				mock.ExpectQuery("SELECT id, user_id, token_hash, expires_at, session_id, used_at FROM refresh_tokens").
 					WithArgs(testToken).
 					WillReturnError(errors.New("db error"))
 			},
@@ -132,7 +133,7 @@ func TestGetToken(t *testing.T) {
 			name:  "database error",
 			token: testToken,
 			mock: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery("SELECT id, user_id, token, expires_at FROM refresh_tokens").
+				mock.ExpectQuery("SELECT id, user_id, token_hash, expires_at, session_id, used_at FROM refresh_tokens").
 					WithArgs(testToken).
 					WillReturnError(errors.New("db error"))
 			},
@@ -157,11 +158,64 @@ func TestGetToken(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, tt.want.ID, result.ID)
 				require.Equal(t, tt.want.UserID, result.UserID)
-				require.Equal(t, tt.want.Token, result.Token)
+				require.Equal(t, tt.want.TokenHash, result.TokenHash)
 				require.Equal(t, tt.want.ExpiresAt, result.ExpiresAt)
+				require.Equal(t, tt.want.SessionID, result.SessionID)
 			}
 
 			require.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
+
+func TestUseToken(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectExec("UPDATE refresh_tokens").
+		WithArgs(tokenUUID, testToken).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE refresh_tokens").
+		WithArgs(tokenUUID, testToken).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE refresh_tokens").
+		WithArgs(tokenUUID, testToken).
+		WillReturnError(errors.New("db error"))
+
+	used, err := storage.UseToken(t.Context(), testToken, tokenUUID)
+	require.NoError(t, err)
+	require.True(t, used)
+
+	// A token can be exchanged once.
+	used, err = storage.UseToken(t.Context(), testToken, tokenUUID)
+	require.NoError(t, err)
+	require.False(t, used)
+
+	_, err = storage.UseToken(t.Context(), testToken, tokenUUID)
+	require.ErrorContains(t, err, "error using refresh token")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTokens(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectExec("DELETE FROM refresh_tokens").
+		WithArgs(testToken).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec("DELETE FROM refresh_tokens").
+		WithArgs(userUUID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	mock.ExpectExec("DELETE FROM refresh_tokens").
+		WithArgs(userUUID).
+		WillReturnError(errors.New("db error"))
+
+	require.NoError(t, storage.DeleteToken(t.Context(), testToken))
+	require.NoError(t, storage.DeleteUserTokens(t.Context(), userUUID))
+	require.ErrorContains(t, storage.DeleteUserTokens(t.Context(), userUUID), "error deleting refresh tokens")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
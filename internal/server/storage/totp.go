@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// SetTOTPSecret stores a new wrapped TOTP secret for the user, replacing an unconfirmed one.
+// It reports false when two-factor authentication is already enabled.
+func (ds *DBStorage) SetTOTPSecret(ctx context.Context, userID pgtype.UUID, secret string) (bool, error) {
+	affected, err := ds.Queries.SetTOTPSecret(ctx, db.SetTOTPSecretParams{
+		Secret: pgtype.Text{String: secret, Valid: true},
+		ID:     userID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to set totp secret")
+
+		return false, errors.Wrap(err, "failed to set totp secret")
+	}
+
+	return affected == 1, nil
+}
+
+// EnableTOTP enables two-factor authentication with the confirmed secret and stores the hashed recovery
+// codes, in one statement. step is the time step of the confirming code, which cannot log in afterwards.
+// It reports false when the secret was replaced or two-factor authentication enabled meanwhile.
+func (ds *DBStorage) EnableTOTP(
+	ctx context.Context,
+	userID pgtype.UUID,
+	secret string,
+	step int64,
+	codeHashes []string,
+) (bool, error) {
+	affected, err := ds.Queries.EnableTOTP(ctx, db.EnableTOTPParams{
+		CodeHashes: codeHashes,
+		Step:       step,
+		UserID:     userID,
+		Secret:     pgtype.Text{String: secret, Valid: true},
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to enable totp")
+
+		return false, errors.Wrap(err, "failed to enable totp")
+	}
+
+	return affected > 0, nil
+}
+
+// UseTOTPStep records that a code of the given time step logged in. It reports false when a code
+// of this or a later step was used already, so every code works once.
+func (ds *DBStorage) UseTOTPStep(ctx context.Context, userID pgtype.UUID, step int64) (bool, error) {
+	affected, err := ds.Queries.UseTOTPStep(ctx, db.UseTOTPStepParams{
+		Step: step,
+		ID:   userID,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to use totp step")
+
+		return false, errors.Wrap(err, "failed to use totp step")
+	}
+
+	return affected == 1, nil
+}
+
+// UseRecoveryCode marks an unused recovery code of the user as used. It reports false when there is none.
+func (ds *DBStorage) UseRecoveryCode(ctx context.Context, userID pgtype.UUID, codeHash string) (bool, error) {
+	affected, err := ds.Queries.UseRecoveryCode(ctx, db.UseRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to use recovery code")
+
+		return false, errors.Wrap(err, "failed to use recovery code")
+	}
+
+	return affected > 0, nil
+}
+
+// ListTOTPSecrets returns up to batchSize wrapped TOTP secrets, ordered by user ID and starting after afterID.
+func (ds *DBStorage) ListTOTPSecrets(
+	ctx context.Context,
+	afterID pgtype.UUID,
+	batchSize int32,
+) ([]db.ListTOTPSecretsAfterRow, error) {
+	rows, err := ds.Queries.ListTOTPSecretsAfter(ctx, db.ListTOTPSecretsAfterParams{
+		AfterID:   afterID,
+		BatchSize: batchSize,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list totp secrets")
+
+		return nil, errors.Wrap(err, "failed to list totp secrets")
+	}
+
+	return rows, nil
+}
+
+// RewrapTOTPSecret replaces the wrapped TOTP secret of a user, unless it changed since oldSecret was read.
+// It reports whether the secret was replaced.
+func (ds *DBStorage) RewrapTOTPSecret(
+	ctx context.Context,
+	userID pgtype.UUID,
+	oldSecret, newSecret string,
+) (bool, error) {
+	affected, err := ds.Queries.RewrapTOTPSecret(ctx, db.RewrapTOTPSecretParams{
+		NewSecret: pgtype.Text{String: newSecret, Valid: true},
+		ID:        userID,
+		OldSecret: pgtype.Text{String: oldSecret, Valid: true},
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to rewrap totp secret")
+
+		return false, errors.Wrap(err, "failed to rewrap totp secret")
+	}
+
+	return affected == 1, nil
+}
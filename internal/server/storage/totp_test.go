@@ -0,0 +1,177 @@
+//nolint:exhaustruct
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	testutils "github.com/npavlov/go-password-manager/internal/test_utils"
+)
+
+func TestSetTOTPSecret(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	secret := pgtype.Text{String: "wrapped-secret", Valid: true}
+
+	mock.ExpectExec("UPDATE users").
+		WithArgs(secret, userUUID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE users").
+		WithArgs(secret, userUUID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE users").
+		WithArgs(secret, userUUID).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.SetTOTPSecret(t.Context(), userUUID, "wrapped-secret")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Two-factor authentication is enabled already.
+	ok, err = storage.SetTOTPSecret(t.Context(), userUUID, "wrapped-secret")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.SetTOTPSecret(t.Context(), userUUID, "wrapped-secret")
+	require.ErrorContains(t, err, "failed to set totp secret")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnableTOTP(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	secret := pgtype.Text{String: "wrapped-secret", Valid: true}
+	hashes := []string{"hash-1", "hash-2"}
+
+	mock.ExpectExec("INSERT INTO recovery_codes").
+		WithArgs(hashes, int64(42), userUUID, secret).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	mock.ExpectExec("INSERT INTO recovery_codes").
+		WithArgs(hashes, int64(42), userUUID, secret).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	mock.ExpectExec("INSERT INTO recovery_codes").
+		WithArgs(hashes, int64(42), userUUID, secret).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.EnableTOTP(t.Context(), userUUID, "wrapped-secret", 42, hashes)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The secret was replaced by another enrollment.
+	ok, err = storage.EnableTOTP(t.Context(), userUUID, "wrapped-secret", 42, hashes)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.EnableTOTP(t.Context(), userUUID, "wrapped-secret", 42, hashes)
+	require.ErrorContains(t, err, "failed to enable totp")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseTOTPStep(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectExec("UPDATE users").
+		WithArgs(int64(42), userUUID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE users").
+		WithArgs(int64(42), userUUID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE users").
+		WithArgs(int64(42), userUUID).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.UseTOTPStep(t.Context(), userUUID, 42)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The code was used already.
+	ok, err = storage.UseTOTPStep(t.Context(), userUUID, 42)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.UseTOTPStep(t.Context(), userUUID, 42)
+	require.ErrorContains(t, err, "failed to use totp step")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectExec("UPDATE recovery_codes").
+		WithArgs(userUUID, "hash").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE recovery_codes").
+		WithArgs(userUUID, "hash").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE recovery_codes").
+		WithArgs(userUUID, "hash").
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.UseRecoveryCode(t.Context(), userUUID, "hash")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = storage.UseRecoveryCode(t.Context(), userUUID, "hash")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.UseRecoveryCode(t.Context(), userUUID, "hash")
+	require.ErrorContains(t, err, "failed to use recovery code")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListTOTPSecrets(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	rows := pgxmock.NewRows([]string{"id", "totp_secret"}).AddRow(userUUID, "wrapped-secret")
+	mock.ExpectQuery("SELECT id, totp_secret").
+		WithArgs(pgtype.UUID{Valid: true}, int32(10)).
+		WillReturnRows(rows)
+
+	secrets, err := storage.ListTOTPSecrets(t.Context(), pgtype.UUID{Valid: true}, 10)
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+	require.Equal(t, "wrapped-secret", secrets[0].TotpSecret)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRewrapTOTPSecret(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+	oldSecret := pgtype.Text{String: "old-secret", Valid: true}
+	newSecret := pgtype.Text{String: "new-secret", Valid: true}
+
+	mock.ExpectExec("UPDATE users").
+		WithArgs(newSecret, userUUID, oldSecret).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE users").
+		WithArgs(newSecret, userUUID, oldSecret).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.RewrapTOTPSecret(t.Context(), userUUID, "old-secret", "new-secret")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = storage.RewrapTOTPSecret(t.Context(), userUUID, "old-secret", "new-secret")
+	require.ErrorContains(t, err, "failed to rewrap totp secret")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -44,3 +44,99 @@ func (ds *DBStorage) GetUserByID(ctx context.Context, userID pgtype.UUID) (*db.U
 
 	return &user, nil
 }
+
+// ListUserKeys returns up to batchSize server-side user keys, ordered by user ID and starting after afterID.
+// Zero-knowledge accounts are left out, as the server holds no key for them.
+func (ds *DBStorage) ListUserKeys(
+	ctx context.Context,
+	afterID pgtype.UUID,
+	batchSize int32,
+) ([]db.ListUserKeysAfterRow, error) {
+	rows, err := ds.Queries.ListUserKeysAfter(ctx, db.ListUserKeysAfterParams{
+		AfterID:   afterID,
+		BatchSize: batchSize,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list user keys")
+
+		return nil, errors.Wrap(err, "failed to list user keys")
+	}
+
+	return rows, nil
+}
+
+// RewrapUserKey replaces the encrypted key of a user, unless it changed since oldKey was read.
+// It reports whether the key was replaced.
+func (ds *DBStorage) RewrapUserKey(ctx context.Context, userID pgtype.UUID, oldKey, newKey string) (bool, error) {
+	affected, err := ds.Queries.RewrapUserKey(ctx, db.RewrapUserKeyParams{
+		NewKey: newKey,
+		ID:     userID,
+		OldKey: oldKey,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to rewrap user key")
+
+		return false, errors.Wrap(err, "failed to rewrap user key")
+	}
+
+	return affected == 1, nil
+}
+
+// ChangePassword replaces the password hash of a user together with the vault key fields, unless the password
+// changed since OldPassword was read. It reports whether the password was replaced.
+func (ds *DBStorage) ChangePassword(ctx context.Context, params db.ChangeUserPasswordParams) (bool, error) {
+	affected, err := ds.Queries.ChangeUserPassword(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to change password")
+
+		return false, errors.Wrap(err, "failed to change password")
+	}
+
+	return affected == 1, nil
+}
+
+// RehashPassword replaces the password hash of a user with a new hash of the same password. It reports
+// false when the password changed meanwhile, so the newer hash stays.
+func (ds *DBStorage) RehashPassword(ctx context.Context, params db.RehashUserPasswordParams) (bool, error) {
+	affected, err := ds.Queries.RehashUserPassword(ctx, params)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to rehash password")
+
+		return false, errors.Wrap(err, "failed to rehash password")
+	}
+
+	return affected == 1, nil
+}
+
+// DeleteUser deletes a user; the vault, sessions and tokens of the user are deleted with it.
+// It reports whether the user existed.
+func (ds *DBStorage) DeleteUser(ctx context.Context, userID pgtype.UUID) (bool, error) {
+	affected, err := ds.Queries.DeleteUser(ctx, userID)
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to delete user")
+
+		return false, errors.Wrap(err, "failed to delete user")
+	}
+
+	return affected == 1, nil
+}
+
+// ListLegacyUsers returns up to batchSize users, ordered by ID and starting after afterID, whose vault may hold
+// values not bound to their records and whose key is not being rotated.
+func (ds *DBStorage) ListLegacyUsers(
+	ctx context.Context,
+	afterID pgtype.UUID,
+	batchSize int32,
+) ([]db.ListLegacyCiphertextUsersRow, error) {
+	rows, err := ds.Queries.ListLegacyCiphertextUsers(ctx, db.ListLegacyCiphertextUsersParams{
+		AfterID:   afterID,
+		BatchSize: batchSize,
+	})
+	if err != nil {
+		ds.log.Error().Err(err).Msg("failed to list users with unbound values")
+
+		return nil, errors.Wrap(err, "failed to list users with unbound values")
+	}
+
+	return rows, nil
+}
@@ -21,6 +21,13 @@ const (
 	testEncKey   = "testenckey"
 )
 
+//nolint:gochecknoglobals
+var userColumns = []string{
+	"id", "username", "email", "password", "encryption_key", "change_seq", "kdf_salt", "wrapped_vault_key",
+	"key_version", "previous_encryption_key", "legacy_ciphertexts", "totp_secret", "totp_enabled", "totp_last_step",
+	"organization_vault",
+}
+
 func TestRegisterUser(t *testing.T) {
 	t.Parallel()
 
@@ -41,10 +48,11 @@ func TestRegisterUser(t *testing.T) {
 				Email:         testEmail,
 			},
 			mock: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "username", "email", "password", "encryption_key"}).
-					AddRow(userUUID, testUsername, testEmail, testPassword, testEncKey)
+				rows := pgxmock.NewRows(userColumns).
+					AddRow(userUUID, testUsername, testEmail, testPassword, testEncKey, int64(0), pgtype.Text{}, pgtype.Text{},
+						int32(1), pgtype.Text{}, false, pgtype.Text{}, false, int64(0), false)
 				mock.ExpectQuery("INSERT INTO users").
-					WithArgs(testUsername, testPassword, testEncKey, testEmail).
+					WithArgs(testUsername, testPassword, testEncKey, testEmail, pgtype.Text{}, pgtype.Text{}).
 					WillReturnRows(rows)
 			},
 			want: &db.User{
@@ -66,7 +74,7 @@ func TestRegisterUser(t *testing.T) {
 			},
 			mock: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery("INSERT INTO users").
-					WithArgs(testUsername, testPassword, testEncKey, testEmail).
+					WithArgs(testUsername, testPassword, testEncKey, testEmail, pgtype.Text{}, pgtype.Text{}).
 					WillReturnError(errors.New("db error"))
 			},
 			want:          nil,
@@ -83,7 +91,7 @@ func TestRegisterUser(t *testing.T) {
 			},
 			mock: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery("INSERT INTO users").
-					WithArgs(testUsername, testPassword, testEncKey, testEmail).
+					WithArgs(testUsername, testPassword, testEncKey, testEmail, pgtype.Text{}, pgtype.Text{}).
 					WillReturnError(errors.New("db error"))
 			},
 			want:          nil,
@@ -133,8 +141,9 @@ func TestGetUser(t *testing.T) {
 			name:     "successful user retrieval by username",
 			username: testUsername,
 			mock: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "username", "email", "password", "encryption_key"}).
-					AddRow(userUUID, testUsername, testEmail, testPassword, testEncKey)
+				rows := pgxmock.NewRows(userColumns).
+					AddRow(userUUID, testUsername, testEmail, testPassword, testEncKey, int64(0), pgtype.Text{}, pgtype.Text{},
+						int32(1), pgtype.Text{}, false, pgtype.Text{}, false, int64(0), false)
 				mock.ExpectQuery("SELECT").
 					WithArgs(testUsername).
 					WillReturnRows(rows)
@@ -215,8 +224,9 @@ func TestGetUserById(t *testing.T) {
 			name:   "successful user retrieval by ID",
 			userID: userUUID,
 			mock: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "username", "email", "password", "encryption_key"}).
-					AddRow(userUUID, testUsername, testEmail, testPassword, testEncKey)
+				rows := pgxmock.NewRows(userColumns).
+					AddRow(userUUID, testUsername, testEmail, testPassword, testEncKey, int64(0), pgtype.Text{}, pgtype.Text{},
+						int32(1), pgtype.Text{}, false, pgtype.Text{}, false, int64(0), false)
 				mock.ExpectQuery("SELECT").
 					WithArgs(userUUID).
 					WillReturnRows(rows)
@@ -281,3 +291,154 @@ func TestGetUserById(t *testing.T) {
 		})
 	}
 }
+
+func TestListUserKeys(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	rows := pgxmock.NewRows([]string{"id", "encryption_key"}).AddRow(userUUID, testEncKey)
+	mock.ExpectQuery("SELECT id, encryption_key FROM users").
+		WithArgs(pgtype.UUID{Valid: true}, int32(100)).
+		WillReturnRows(rows)
+	mock.ExpectQuery("SELECT id, encryption_key FROM users").
+		WithArgs(userUUID, int32(100)).
+		WillReturnError(errors.New("db error"))
+
+	keys, err := storage.ListUserKeys(t.Context(), pgtype.UUID{Valid: true}, 100)
+	require.NoError(t, err)
+	require.Equal(t, []db.ListUserKeysAfterRow{{ID: userUUID, EncryptionKey: testEncKey}}, keys)
+
+	_, err = storage.ListUserKeys(t.Context(), userUUID, 100)
+	require.ErrorContains(t, err, "failed to list user keys")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRewrapUserKey(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectExec("UPDATE users").
+		WithArgs("new-key", userUUID, testEncKey).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE users").
+		WithArgs("new-key", userUUID, testEncKey).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE users").
+		WithArgs("new-key", userUUID, testEncKey).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.RewrapUserKey(t.Context(), userUUID, testEncKey, "new-key")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The key changed since it was read.
+	ok, err = storage.RewrapUserKey(t.Context(), userUUID, testEncKey, "new-key")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.RewrapUserKey(t.Context(), userUUID, testEncKey, "new-key")
+	require.ErrorContains(t, err, "failed to rewrap user key")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	params := db.ChangeUserPasswordParams{
+		NewPassword:     "new-hash",
+		KdfSalt:         pgtype.Text{String: "salt", Valid: true},
+		WrappedVaultKey: pgtype.Text{String: "wrapped", Valid: true},
+		ID:              userUUID,
+		OldPassword:     testPassword,
+	}
+
+	mock.ExpectExec("UPDATE users").
+		WithArgs(params.NewPassword, params.KdfSalt, params.WrappedVaultKey, userUUID, testPassword).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE users").
+		WithArgs(params.NewPassword, params.KdfSalt, params.WrappedVaultKey, userUUID, testPassword).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE users").
+		WithArgs(params.NewPassword, params.KdfSalt, params.WrappedVaultKey, userUUID, testPassword).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.ChangePassword(t.Context(), params)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The password changed since it was read.
+	ok, err = storage.ChangePassword(t.Context(), params)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.ChangePassword(t.Context(), params)
+	require.ErrorContains(t, err, "failed to change password")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRehashPassword(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	params := db.RehashUserPasswordParams{NewPassword: "$argon2id$new", ID: userUUID, OldPassword: testPassword}
+
+	mock.ExpectExec("UPDATE users").
+		WithArgs(params.NewPassword, userUUID, testPassword).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE users").
+		WithArgs(params.NewPassword, userUUID, testPassword).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE users").
+		WithArgs(params.NewPassword, userUUID, testPassword).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.RehashPassword(t.Context(), params)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = storage.RehashPassword(t.Context(), params)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.RehashPassword(t.Context(), params)
+	require.ErrorContains(t, err, "failed to rehash password")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Parallel()
+
+	storage, mock := testutils.SetupDBStorage(t)
+
+	mock.ExpectExec("DELETE FROM users").
+		WithArgs(userUUID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec("DELETE FROM users").
+		WithArgs(userUUID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec("DELETE FROM users").
+		WithArgs(userUUID).
+		WillReturnError(errors.New("db error"))
+
+	ok, err := storage.DeleteUser(t.Context(), userUUID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = storage.DeleteUser(t.Context(), userUUID)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.DeleteUser(t.Context(), userUUID)
+	require.ErrorContains(t, err, "failed to delete user")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
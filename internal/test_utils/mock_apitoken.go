@@ -0,0 +1,156 @@
+package testutils
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/npavlov/go-password-manager/internal/server/apitoken"
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// CreateAPIToken mock implementation.
+func (m *MockDBStorage) CreateAPIToken(_ context.Context, params db.CreateAPITokenParams) (*db.ApiToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	token := db.ApiToken{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		UserID:    params.UserID,
+		Name:      params.Name,
+		TokenHash: params.TokenHash,
+		ReadOnly:  params.ReadOnly,
+		ItemTypes: params.ItemTypes,
+		Tags:      params.Tags,
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		ExpiresAt: params.ExpiresAt,
+	}
+	m.apiTokens[token.ID] = token
+
+	return &token, nil
+}
+
+// GetAPIToken mock implementation.
+func (m *MockDBStorage) GetAPIToken(_ context.Context, tokenHash string) (*db.ApiToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	for _, token := range m.apiTokens {
+		if token.TokenHash == tokenHash && token.ExpiresAt.Time.After(time.Now()) {
+			return &token, nil
+		}
+	}
+
+	return nil, pgx.ErrNoRows
+}
+
+// ListAPITokens mock implementation.
+func (m *MockDBStorage) ListAPITokens(_ context.Context, userID pgtype.UUID) ([]db.ApiToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	var tokens []db.ApiToken
+	for _, token := range m.apiTokens {
+		if token.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+
+	sortByCreation(tokens, func(token db.ApiToken) (pgtype.Timestamp, pgtype.UUID) {
+		return token.CreatedAt, token.ID
+	})
+
+	return tokens, nil
+}
+
+// DeleteAPIToken mock implementation.
+func (m *MockDBStorage) DeleteAPIToken(_ context.Context, userID, tokenID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	token, exists := m.apiTokens[tokenID]
+	if !exists || token.UserID != userID {
+		return false, nil
+	}
+
+	delete(m.apiTokens, tokenID)
+
+	return true, nil
+}
+
+// TouchAPIToken mock implementation.
+func (m *MockDBStorage) TouchAPIToken(_ context.Context, tokenID pgtype.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return m.CallError
+	}
+
+	if token, exists := m.apiTokens[tokenID]; exists {
+		token.LastUsedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+		m.apiTokens[tokenID] = token
+	}
+
+	return nil
+}
+
+// ListScopedItemIDs mock implementation.
+func (m *MockDBStorage) ListScopedItemIDs(
+	_ context.Context,
+	params db.ListScopedItemIDsParams,
+) ([]pgtype.UUID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	tags := apitoken.DecodeTags(params.Tags)
+
+	var itemIDs []pgtype.UUID
+
+	for _, item := range m.items {
+		if item.UserID != params.UserID {
+			continue
+		}
+
+		if len(params.ItemTypes) > 0 && !slices.Contains(params.ItemTypes, string(item.Type)) {
+			continue
+		}
+
+		matches := true
+		for key, value := range tags {
+			if stored, ok := m.metaInfo[item.IDResource.String()][key]; !ok || stored != value {
+				matches = false
+			}
+		}
+
+		if matches {
+			itemIDs = append(itemIDs, item.IDResource)
+		}
+	}
+
+	return itemIDs, nil
+}
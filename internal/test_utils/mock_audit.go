@@ -0,0 +1,106 @@
+package testutils
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// GetLastAuditEvent mock implementation.
+func (m *MockDBStorage) GetLastAuditEvent(_ context.Context) (*db.AuditEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	if len(m.auditEvents) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+
+	event := m.auditEvents[len(m.auditEvents)-1]
+
+	return &event, nil
+}
+
+// AppendAuditEvent mock implementation.
+func (m *MockDBStorage) AppendAuditEvent(
+	_ context.Context,
+	build func(last *db.AuditEvent) db.InsertAuditEventParams,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return m.CallError
+	}
+
+	var last *db.AuditEvent
+	if len(m.auditEvents) > 0 {
+		last = &m.auditEvents[len(m.auditEvents)-1]
+	}
+
+	m.auditEvents = append(m.auditEvents, db.AuditEvent(build(last)))
+
+	return nil
+}
+
+// ListAuditEvents mock implementation.
+func (m *MockDBStorage) ListAuditEvents(_ context.Context, params db.ListAuditEventsParams) ([]db.AuditEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.AuditEvent, 0)
+
+	for i := len(m.auditEvents) - 1; i >= 0; i-- {
+		event := m.auditEvents[i]
+
+		switch {
+		case event.ActorID != params.UserID && m.items[event.ItemID.String()].UserID != params.UserID,
+			params.ItemID.Valid && event.ItemID != params.ItemID,
+			params.Action != "" && event.Action != params.Action,
+			params.Since.Valid && event.OccurredAt.Time.Before(params.Since.Time),
+			params.Until.Valid && !event.OccurredAt.Time.Before(params.Until.Time):
+			continue
+		}
+
+		result = append(result, event)
+	}
+
+	return paginate(result, params.Limit, params.Offset), nil
+}
+
+// ExportAuditEvents mock implementation.
+func (m *MockDBStorage) ExportAuditEvents(_ context.Context, afterSeq int64, batchSize int32) ([]db.AuditEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.AuditEvent, 0)
+
+	for _, event := range m.auditEvents {
+		if event.Seq > afterSeq && len(result) < int(batchSize) {
+			result = append(result, event)
+		}
+	}
+
+	return result, nil
+}
+
+// SetAuditEvent overwrites an event of the audit chain, as someone with access to the database could.
+func (m *MockDBStorage) SetAuditEvent(event db.AuditEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.auditEvents[event.Seq-1] = event
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 
-	pb "github.com/npavlov/go-password-manager/gen/proto/item"
 	"github.com/npavlov/go-password-manager/internal/client/model"
 	"github.com/npavlov/go-password-manager/internal/client/storage"
 )
@@ -15,8 +14,6 @@ import (
 // MockStorageManager is a mock implementation of IStorageManager for testing.
 type MockStorageManager struct {
 	mock.Mock
-	FetchItemsFunc          func(ctx context.Context) ([]*pb.ItemData, error)
-	ProcessItemFunc         func(ctx context.Context, item *pb.ItemData) bool
 	ProcessPasswordFunc     func(ctx context.Context, passwordId string, meta map[string]string) error
 	ProcessNoteFunc         func(ctx context.Context, noteId string, meta map[string]string) error
 	ProcessCardFunc         func(ctx context.Context, cardID string, meta map[string]string) error
@@ -55,22 +52,6 @@ func NewMockStorageManager() *MockStorageManager {
 	}
 }
 
-func (m *MockStorageManager) FetchItems(ctx context.Context) ([]*pb.ItemData, error) {
-	if m.FetchItemsFunc != nil {
-		return m.FetchItemsFunc(ctx)
-	}
-
-	return nil, errors.New("FetchItemsFunc not implemented")
-}
-
-func (m *MockStorageManager) ProcessItem(ctx context.Context, item *pb.ItemData) bool {
-	if m.ProcessItemFunc != nil {
-		return m.ProcessItemFunc(ctx, item)
-	}
-
-	return false
-}
-
 func (m *MockStorageManager) ProcessPassword(ctx context.Context, passwordID string, meta map[string]string) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
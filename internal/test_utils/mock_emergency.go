@@ -0,0 +1,326 @@
+package testutils
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// UpsertEmergencyAccess mock implementation.
+func (m *MockDBStorage) UpsertEmergencyAccess(
+	_ context.Context,
+	params db.UpsertEmergencyAccessParams,
+) (*db.EmergencyAccess, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	for id, access := range m.emergencyAccess {
+		if access.GrantorID != params.GrantorID || access.GranteeID != params.GranteeID {
+			continue
+		}
+
+		access.AccessType = params.AccessType
+		access.WaitDays = params.WaitDays
+		access.Status = db.EmergencyAccessStatusIdle
+		access.RequestedAt = pgtype.Timestamp{}
+		access.ApprovedAt = pgtype.Timestamp{}
+		m.emergencyAccess[id] = access
+
+		return &access, nil
+	}
+
+	access := db.EmergencyAccess{
+		ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		GrantorID:   params.GrantorID,
+		GranteeID:   params.GranteeID,
+		AccessType:  params.AccessType,
+		WaitDays:    params.WaitDays,
+		Status:      db.EmergencyAccessStatusIdle,
+		RequestedAt: pgtype.Timestamp{},
+		ApprovedAt:  pgtype.Timestamp{},
+		CreatedAt:   pgtype.Timestamp{Time: time.Now(), Valid: true},
+	}
+	m.emergencyAccess[access.ID] = access
+
+	return &access, nil
+}
+
+// ListEmergencyAccess mock implementation.
+func (m *MockDBStorage) ListEmergencyAccess(
+	_ context.Context,
+	userID pgtype.UUID,
+) ([]db.ListEmergencyAccessRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.ListEmergencyAccessRow, 0)
+	for _, access := range m.emergencyAccess {
+		if access.GrantorID == userID || access.GranteeID == userID {
+			result = append(result, db.ListEmergencyAccessRow(m.emergencyAccessRow(access)))
+		}
+	}
+
+	sortByCreation(result, func(row db.ListEmergencyAccessRow) (pgtype.Timestamp, pgtype.UUID) {
+		return row.CreatedAt, row.ID
+	})
+
+	return result, nil
+}
+
+// GetEmergencyAccess mock implementation.
+func (m *MockDBStorage) GetEmergencyAccess(
+	_ context.Context,
+	accessID, userID pgtype.UUID,
+) (*db.GetEmergencyAccessRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	access, exists := m.emergencyAccess[accessID]
+	if !exists || (access.GrantorID != userID && access.GranteeID != userID) {
+		return nil, pgx.ErrNoRows
+	}
+
+	row := m.emergencyAccessRow(access)
+
+	return &row, nil
+}
+
+// DeleteEmergencyAccess mock implementation.
+func (m *MockDBStorage) DeleteEmergencyAccess(_ context.Context, accessID, userID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	access, exists := m.emergencyAccess[accessID]
+	if !exists || (access.GrantorID != userID && access.GranteeID != userID) {
+		return false, nil
+	}
+
+	delete(m.emergencyAccess, accessID)
+
+	return true, nil
+}
+
+// RequestEmergencyAccess mock implementation.
+func (m *MockDBStorage) RequestEmergencyAccess(_ context.Context, accessID, granteeID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	access, exists := m.emergencyAccess[accessID]
+	if !exists || access.GranteeID != granteeID || access.Status != db.EmergencyAccessStatusIdle {
+		return false, nil
+	}
+
+	access.Status = db.EmergencyAccessStatusRequested
+	access.RequestedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+	m.emergencyAccess[accessID] = access
+
+	return true, nil
+}
+
+// ApproveEmergencyAccess mock implementation.
+func (m *MockDBStorage) ApproveEmergencyAccess(_ context.Context, accessID, grantorID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	access, exists := m.emergencyAccess[accessID]
+	if !exists || access.GrantorID != grantorID || access.Status != db.EmergencyAccessStatusRequested {
+		return false, nil
+	}
+
+	access.Status = db.EmergencyAccessStatusApproved
+	access.ApprovedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+	m.emergencyAccess[accessID] = access
+
+	return true, nil
+}
+
+// RejectEmergencyAccess mock implementation.
+func (m *MockDBStorage) RejectEmergencyAccess(_ context.Context, accessID, grantorID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	access, exists := m.emergencyAccess[accessID]
+	if !exists || access.GrantorID != grantorID || access.Status == db.EmergencyAccessStatusIdle {
+		return false, nil
+	}
+
+	m.emergencyAccess[accessID] = idleEmergencyAccess(access)
+
+	return true, nil
+}
+
+// PromoteEmergencyAccess mock implementation.
+func (m *MockDBStorage) PromoteEmergencyAccess(_ context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return 0, m.CallError
+	}
+
+	var promoted int64
+
+	for id, access := range m.emergencyAccess {
+		waitOver := access.RequestedAt.Time.AddDate(0, 0, int(access.WaitDays))
+		if access.Status != db.EmergencyAccessStatusRequested || waitOver.After(now) {
+			continue
+		}
+
+		access.Status = db.EmergencyAccessStatusApproved
+		access.ApprovedAt = pgtype.Timestamp{Time: now, Valid: true}
+		m.emergencyAccess[id] = access
+		promoted++
+	}
+
+	return promoted, nil
+}
+
+// GetEmergencyItemAccess mock implementation. Like GetItemAccess it ignores CallError.
+func (m *MockDBStorage) GetEmergencyItemAccess(_ context.Context, itemID, granteeID pgtype.UUID) (pgtype.UUID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	item, exists := m.items[itemID.String()]
+	if !exists {
+		return pgtype.UUID{}, pgx.ErrNoRows
+	}
+
+	for _, access := range m.emergencyAccess {
+		if access.GrantorID == item.UserID && access.GranteeID == granteeID &&
+			access.Status == db.EmergencyAccessStatusApproved && access.AccessType == db.EmergencyAccessTypeView {
+			return access.GrantorID, nil
+		}
+	}
+
+	return pgtype.UUID{}, pgx.ErrNoRows
+}
+
+// ListVaultItems mock implementation; like the query it leaves out items whose record is gone.
+func (m *MockDBStorage) ListVaultItems(_ context.Context, userID pgtype.UUID) ([]db.ListVaultItemsRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.ListVaultItemsRow, 0)
+	for _, item := range m.items {
+		if item.UserID != userID {
+			continue
+		}
+
+		updatedAt := m.itemUpdatedAt(item)
+		if !updatedAt.Valid {
+			continue
+		}
+
+		result = append(result, db.ListVaultItemsRow{
+			ID:         item.ID,
+			Type:       item.Type,
+			IDResource: item.IDResource,
+			CreatedAt:  item.CreatedAt,
+			UpdatedAt:  updatedAt,
+		})
+	}
+
+	sortByCreation(result, func(row db.ListVaultItemsRow) (pgtype.Timestamp, pgtype.UUID) {
+		return row.CreatedAt, row.ID
+	})
+
+	return result, nil
+}
+
+// TakeOverAccount mock implementation.
+func (m *MockDBStorage) TakeOverAccount(_ context.Context, params db.TakeOverAccountParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	access, exists := m.emergencyAccess[params.ID]
+	if !exists || access.GranteeID != params.GranteeID ||
+		access.Status != db.EmergencyAccessStatusApproved || access.AccessType != db.EmergencyAccessTypeTakeover {
+		return false, nil
+	}
+
+	m.emergencyAccess[params.ID] = idleEmergencyAccess(access)
+
+	user := m.UsersByID[access.GrantorID]
+	user.Password = params.NewPassword
+	user.TotpSecret = pgtype.Text{}
+	user.TotpEnabled = false
+	user.TotpLastStep = 0
+	m.saveUser(user)
+	delete(m.recovery, user.ID)
+
+	return true, nil
+}
+
+// emergencyAccessRow adds the usernames of the grantor and the grantee, like the queries join them.
+func (m *MockDBStorage) emergencyAccessRow(access db.EmergencyAccess) db.GetEmergencyAccessRow {
+	return db.GetEmergencyAccessRow{
+		ID:          access.ID,
+		GrantorID:   access.GrantorID,
+		GranteeID:   access.GranteeID,
+		AccessType:  access.AccessType,
+		WaitDays:    access.WaitDays,
+		Status:      access.Status,
+		RequestedAt: access.RequestedAt,
+		ApprovedAt:  access.ApprovedAt,
+		CreatedAt:   access.CreatedAt,
+		Grantor:     m.UsersByID[access.GrantorID].Username,
+		Grantee:     m.UsersByID[access.GranteeID].Username,
+	}
+}
+
+// deleteEmergencyAccess deletes the grants a user gave or received, like the cascades of the schema.
+func (m *MockDBStorage) deleteEmergencyAccess(userID pgtype.UUID) {
+	maps.DeleteFunc(m.emergencyAccess, func(_ pgtype.UUID, access db.EmergencyAccess) bool {
+		return access.GrantorID == userID || access.GranteeID == userID
+	})
+}
+
+func idleEmergencyAccess(access db.EmergencyAccess) db.EmergencyAccess {
+	access.Status = db.EmergencyAccessStatusIdle
+	access.RequestedAt = pgtype.Timestamp{}
+	access.ApprovedAt = pgtype.Timestamp{}
+
+	return access
+}
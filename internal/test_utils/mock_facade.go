@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 
+	pb_auth "github.com/npavlov/go-password-manager/gen/proto/auth"
 	pb_card "github.com/npavlov/go-password-manager/gen/proto/card"
 	pb_file "github.com/npavlov/go-password-manager/gen/proto/file"
 	pb "github.com/npavlov/go-password-manager/gen/proto/item"
@@ -21,21 +22,37 @@ import (
 type MockFacade struct {
 	mock.Mock
 	LoginFunc          func(username, password string) error
+	VerifyTOTPFunc     func(code string) error
+	EnrollTOTPFunc     func(ctx context.Context) (*pb_auth.EnrollTOTPV1Response, error)
+	ConfirmTOTPFunc    func(ctx context.Context, code string) ([]string, error)
+	LogoutFunc         func(ctx context.Context) error
+	ListSessionsFunc   func(ctx context.Context) ([]*pb_auth.Session, error)
+	RevokeSessionFunc  func(ctx context.Context, sessionID string) error
+	RevokeAllFunc      func(ctx context.Context) (int32, error)
+	CreateAPITokenFunc func(ctx context.Context, name string, scope *pb_auth.APITokenScope,
+		expiresAt time.Time) (string, *pb_auth.APIToken, error)
+	ListAPITokensFunc  func(ctx context.Context) ([]*pb_auth.APIToken, error)
+	RevokeAPITokenFunc func(ctx context.Context, tokenID string) error
+	ChangePasswordFunc func(ctx context.Context, currentPassword, newPassword, newMasterKey string) error
+	DeleteAccountFunc  func(ctx context.Context, password string) error
 	RegisterFunc       func(username, password, email string) (string, error)
 	GetItemsFunc       func(ctx context.Context, page, pageSize int32) ([]*pb.ItemData, int32, error)
+	HydrateItemsFunc   func(ctx context.Context, itemIDs []string, since time.Time) ([]*pb.HydrateItemsV1Response, error)
+	GetChangesFunc     func(ctx context.Context, sinceCursor int64, limit int32) (*pb.GetChangesV1Response, error)
+	WatchItemsFunc     func(ctx context.Context, sinceCursor int64, onChange func(*pb.WatchItemsV1Response)) error
 	StorePasswordFunc  func(ctx context.Context, login string, password string) (string, error)
-	GetPasswordFunc    func(ctx context.Context, id string) (*pb_password.PasswordData, time.Time, error)
-	UpdatePasswordFunc func(ctx context.Context, id, login, password string) error
+	GetPasswordFunc    func(ctx context.Context, id string) (*pb_password.PasswordEntry, error)
+	UpdatePasswordFunc func(ctx context.Context, id, login, password string, expectedVersion int64) (int64, error)
 	DeletePasswordFunc func(ctx context.Context, id string) (bool, error)
 	GetMetainfoFunc    func(ctx context.Context, id string) (map[string]string, error)
 	SetMetainfoFunc    func(ctx context.Context, id string, meta map[string]string) (bool, error)
 	DeleteMetainfoFunc func(ctx context.Context, id, key string) (bool, error)
 	StoreNoteFunc      func(ctx context.Context, content string) (string, error)
-	GetNoteFunc        func(ctx context.Context, id string) (*pb_note.NoteData, time.Time, error)
+	GetNoteFunc        func(ctx context.Context, id string) (*pb_note.NoteEntry, error)
 	DeleteNoteFunc     func(ctx context.Context, id string) (bool, error)
 	StoreCardFunc      func(ctx context.Context, cardNum, expDate, Cvv, cardHolder string) (string, error)
-	UpdateCardFunc     func(ctx context.Context, id, cardNum, expDate, Cvv, cardHolder string) error
-	GetCardFunc        func(ctx context.Context, id string) (*pb_card.CardData, time.Time, error)
+	UpdateCardFunc     func(ctx context.Context, id, cardNum, expDate, cvv, holder string, version int64) (int64, error)
+	GetCardFunc        func(ctx context.Context, id string) (*pb_card.CardEntry, error)
 	DeleteCardFunc     func(ctx context.Context, id string) (bool, error)
 	UploadBinaryFunc   func(ctx context.Context, filename string, reader io.Reader) (string, error)
 	DownloadBinaryFunc func(ctx context.Context, fileID string, writer io.Writer) error
@@ -56,6 +73,128 @@ func (m *MockFacade) Login(username, password string) error {
 	return errors.New("LoginFunc not implemented")
 }
 
+func (m *MockFacade) VerifyTOTP(code string) error {
+	if m.VerifyTOTPFunc != nil {
+		m.Called(code)
+
+		return m.VerifyTOTPFunc(code)
+	}
+
+	return errors.New("VerifyTOTPFunc not implemented")
+}
+
+func (m *MockFacade) EnrollTOTP(ctx context.Context) (*pb_auth.EnrollTOTPV1Response, error) {
+	if m.EnrollTOTPFunc != nil {
+		m.Called(ctx)
+
+		return m.EnrollTOTPFunc(ctx)
+	}
+
+	return nil, errors.New("EnrollTOTPFunc not implemented")
+}
+
+func (m *MockFacade) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	if m.ConfirmTOTPFunc != nil {
+		m.Called(ctx, code)
+
+		return m.ConfirmTOTPFunc(ctx, code)
+	}
+
+	return nil, errors.New("ConfirmTOTPFunc not implemented")
+}
+
+func (m *MockFacade) Logout(ctx context.Context) error {
+	if m.LogoutFunc != nil {
+		m.Called(ctx)
+
+		return m.LogoutFunc(ctx)
+	}
+
+	return errors.New("LogoutFunc not implemented")
+}
+
+func (m *MockFacade) ListSessions(ctx context.Context) ([]*pb_auth.Session, error) {
+	if m.ListSessionsFunc != nil {
+		m.Called(ctx)
+
+		return m.ListSessionsFunc(ctx)
+	}
+
+	return nil, errors.New("ListSessionsFunc not implemented")
+}
+
+func (m *MockFacade) RevokeSession(ctx context.Context, sessionID string) error {
+	if m.RevokeSessionFunc != nil {
+		m.Called(ctx, sessionID)
+
+		return m.RevokeSessionFunc(ctx, sessionID)
+	}
+
+	return errors.New("RevokeSessionFunc not implemented")
+}
+
+func (m *MockFacade) RevokeAllSessions(ctx context.Context) (int32, error) {
+	if m.RevokeAllFunc != nil {
+		m.Called(ctx)
+
+		return m.RevokeAllFunc(ctx)
+	}
+
+	return 0, errors.New("RevokeAllFunc not implemented")
+}
+
+func (m *MockFacade) CreateAPIToken(ctx context.Context, name string, scope *pb_auth.APITokenScope,
+	expiresAt time.Time,
+) (string, *pb_auth.APIToken, error) {
+	if m.CreateAPITokenFunc != nil {
+		m.Called(ctx, name, scope, expiresAt)
+
+		return m.CreateAPITokenFunc(ctx, name, scope, expiresAt)
+	}
+
+	return "", nil, errors.New("CreateAPITokenFunc not implemented")
+}
+
+func (m *MockFacade) ListAPITokens(ctx context.Context) ([]*pb_auth.APIToken, error) {
+	if m.ListAPITokensFunc != nil {
+		m.Called(ctx)
+
+		return m.ListAPITokensFunc(ctx)
+	}
+
+	return nil, errors.New("ListAPITokensFunc not implemented")
+}
+
+func (m *MockFacade) RevokeAPIToken(ctx context.Context, tokenID string) error {
+	if m.RevokeAPITokenFunc != nil {
+		m.Called(ctx, tokenID)
+
+		return m.RevokeAPITokenFunc(ctx, tokenID)
+	}
+
+	return errors.New("RevokeAPITokenFunc not implemented")
+}
+
+func (m *MockFacade) ChangePassword(ctx context.Context, currentPassword, newPassword, newMasterKey string) error {
+	if m.ChangePasswordFunc != nil {
+		m.Called(ctx, currentPassword, newPassword, newMasterKey)
+
+		return m.ChangePasswordFunc(ctx, currentPassword, newPassword, newMasterKey)
+	}
+
+	return errors.New("ChangePasswordFunc not implemented")
+}
+
+func (m *MockFacade) DeleteAccount(ctx context.Context, password string) error {
+	if m.DeleteAccountFunc != nil {
+		m.Called(ctx, password)
+
+		return m.DeleteAccountFunc(ctx, password)
+	}
+
+	return errors.New("DeleteAccountFunc not implemented")
+}
+
 func (m *MockFacade) Register(username, password, email string) (string, error) {
 	if m.RegisterFunc != nil {
 		args := m.Called(username, password, email)
@@ -76,6 +215,42 @@ func (m *MockFacade) GetItems(ctx context.Context, page, pageSize int32) ([]*pb.
 	return nil, 0, errors.New("GetItemsFunc not implemented")
 }
 
+func (m *MockFacade) HydrateItems(
+	ctx context.Context,
+	itemIDs []string,
+	changedSince time.Time,
+) ([]*pb.HydrateItemsV1Response, error) {
+	if m.HydrateItemsFunc != nil {
+		m.Called(ctx, itemIDs, changedSince)
+
+		return m.HydrateItemsFunc(ctx, itemIDs, changedSince)
+	}
+
+	return nil, errors.New("HydrateItemsFunc not implemented")
+}
+
+func (m *MockFacade) GetChanges(ctx context.Context, sinceCursor int64, limit int32) (*pb.GetChangesV1Response, error) {
+	if m.GetChangesFunc != nil {
+		m.Called(ctx, sinceCursor, limit)
+
+		return m.GetChangesFunc(ctx, sinceCursor, limit)
+	}
+
+	return nil, errors.New("GetChangesFunc not implemented")
+}
+
+func (m *MockFacade) WatchItems(
+	ctx context.Context,
+	sinceCursor int64,
+	onChange func(*pb.WatchItemsV1Response),
+) error {
+	if m.WatchItemsFunc != nil {
+		return m.WatchItemsFunc(ctx, sinceCursor, onChange)
+	}
+
+	return errors.New("WatchItemsFunc not implemented")
+}
+
 func (m *MockFacade) StorePassword(ctx context.Context, login string, password string) (string, error) {
 	if m.StorePasswordFunc != nil {
 		return m.StorePasswordFunc(ctx, login, password)
@@ -84,22 +259,26 @@ func (m *MockFacade) StorePassword(ctx context.Context, login string, password s
 	return "", errors.New("StorePasswordFunc not implemented")
 }
 
-func (m *MockFacade) GetPassword(ctx context.Context, id string) (*pb_password.PasswordData, time.Time, error) {
+func (m *MockFacade) GetPassword(ctx context.Context, id string) (*pb_password.PasswordEntry, error) {
 	if m.GetPasswordFunc != nil {
 		m.Called(ctx, id)
 
 		return m.GetPasswordFunc(ctx, id)
 	}
 
-	return nil, time.Time{}, errors.New("GetPasswordFunc not implemented")
+	return nil, errors.New("GetPasswordFunc not implemented")
 }
 
-func (m *MockFacade) UpdatePassword(ctx context.Context, id, login, password string) error {
+func (m *MockFacade) UpdatePassword(
+	ctx context.Context,
+	id, login, password string,
+	expectedVersion int64,
+) (int64, error) {
 	if m.UpdatePasswordFunc != nil {
-		return m.UpdatePasswordFunc(ctx, id, login, password)
+		return m.UpdatePasswordFunc(ctx, id, login, password, expectedVersion)
 	}
 
-	return errors.New("UpdatePasswordFunc not implemented")
+	return 0, errors.New("UpdatePasswordFunc not implemented")
 }
 
 func (m *MockFacade) DeletePassword(ctx context.Context, id string) (bool, error) {
@@ -144,14 +323,14 @@ func (m *MockFacade) StoreNote(ctx context.Context, content string) (string, err
 	return "", errors.New("StoreNoteFunc not implemented")
 }
 
-func (m *MockFacade) GetNote(ctx context.Context, id string) (*pb_note.NoteData, time.Time, error) {
+func (m *MockFacade) GetNote(ctx context.Context, id string) (*pb_note.NoteEntry, error) {
 	if m.GetNoteFunc != nil {
 		m.Called(ctx, id)
 
 		return m.GetNoteFunc(ctx, id)
 	}
 
-	return nil, time.Time{}, errors.New("GetNoteFunc not implemented")
+	return nil, errors.New("GetNoteFunc not implemented")
 }
 
 func (m *MockFacade) DeleteNote(ctx context.Context, id string) (bool, error) {
@@ -170,22 +349,26 @@ func (m *MockFacade) StoreCard(ctx context.Context, cardNum, expDate, cvv, cardH
 	return "", errors.New("StoreCardFunc not implemented")
 }
 
-func (m *MockFacade) UpdateCard(ctx context.Context, id, cardNum, expDate, cvv, cardHolder string) error {
+func (m *MockFacade) UpdateCard(
+	ctx context.Context,
+	id, cardNum, expDate, cvv, cardHolder string,
+	expectedVersion int64,
+) (int64, error) {
 	if m.UpdateCardFunc != nil {
-		return m.UpdateCardFunc(ctx, id, cardNum, expDate, cvv, cardHolder)
+		return m.UpdateCardFunc(ctx, id, cardNum, expDate, cvv, cardHolder, expectedVersion)
 	}
 
-	return errors.New("UpdateCardFunc not implemented")
+	return 0, errors.New("UpdateCardFunc not implemented")
 }
 
-func (m *MockFacade) GetCard(ctx context.Context, id string) (*pb_card.CardData, time.Time, error) {
+func (m *MockFacade) GetCard(ctx context.Context, id string) (*pb_card.CardEntry, error) {
 	if m.GetCardFunc != nil {
 		m.Called(ctx, id)
 
 		return m.GetCardFunc(ctx, id)
 	}
 
-	return nil, time.Time{}, errors.New("GetCardFunc not implemented")
+	return nil, errors.New("GetCardFunc not implemented")
 }
 
 func (m *MockFacade) DeleteCard(ctx context.Context, id string) (bool, error) {
@@ -0,0 +1,421 @@
+//nolint:exhaustruct
+package testutils
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// StartKeyRotation mock implementation.
+func (m *MockDBStorage) StartKeyRotation(_ context.Context, params db.StartKeyRotationParams) (*db.KeyRotation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	user, exists := m.UsersByID[params.UserID]
+	if !exists || user.KeyVersion != params.FromVersion || user.PreviousEncryptionKey.Valid ||
+		user.WrappedVaultKey.Valid {
+		return nil, pgx.ErrNoRows
+	}
+
+	user.PreviousEncryptionKey = pgtype.Text{String: user.EncryptionKey, Valid: true}
+	user.EncryptionKey = params.NewKey
+	user.KeyVersion++
+	m.UsersByID[user.ID] = user
+	m.usersByName[user.Username] = user
+
+	rotation := db.KeyRotation{
+		ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		UserID:      user.ID,
+		FromVersion: params.FromVersion,
+		ToVersion:   user.KeyVersion,
+		Status:      db.KeyRotationStatusRunning,
+		TotalItems:  m.countStale(user.ID, user.KeyVersion),
+		StartedAt:   pgtype.Timestamp{Time: time.Now(), Valid: true},
+		BindsValues: true,
+	}
+	m.rotations[rotation.ID.String()] = rotation
+
+	return &rotation, nil
+}
+
+// GetLatestKeyRotation mock implementation.
+func (m *MockDBStorage) GetLatestKeyRotation(_ context.Context, userID pgtype.UUID) (*db.KeyRotation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	var latest *db.KeyRotation
+	for _, rotation := range m.rotations {
+		if rotation.UserID != userID {
+			continue
+		}
+
+		if latest == nil || rotation.StartedAt.Time.After(latest.StartedAt.Time) {
+			latest = &rotation
+		}
+	}
+
+	if latest == nil {
+		return nil, pgx.ErrNoRows
+	}
+
+	return latest, nil
+}
+
+// ListRunningKeyRotations mock implementation.
+func (m *MockDBStorage) ListRunningKeyRotations(_ context.Context) ([]db.KeyRotation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	var rotations []db.KeyRotation
+	for _, rotation := range m.rotations {
+		if rotation.Status == db.KeyRotationStatusRunning {
+			rotations = append(rotations, rotation)
+		}
+	}
+
+	slices.SortFunc(rotations, func(a, b db.KeyRotation) int {
+		return a.StartedAt.Time.Compare(b.StartedAt.Time)
+	})
+
+	return rotations, nil
+}
+
+// RetryKeyRotation mock implementation.
+func (m *MockDBStorage) RetryKeyRotation(_ context.Context, rotationID pgtype.UUID) (*db.KeyRotation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	rotation, exists := m.rotations[rotationID.String()]
+	if !exists || rotation.Status != db.KeyRotationStatusFailed {
+		return nil, pgx.ErrNoRows
+	}
+
+	rotation.Status = db.KeyRotationStatusRunning
+	rotation.Error = pgtype.Text{}
+	rotation.FinishedAt = pgtype.Timestamp{}
+	m.rotations[rotation.ID.String()] = rotation
+
+	return &rotation, nil
+}
+
+// FailKeyRotation mock implementation.
+func (m *MockDBStorage) FailKeyRotation(_ context.Context, rotationID pgtype.UUID, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return m.CallError
+	}
+
+	rotation, exists := m.rotations[rotationID.String()]
+	if !exists || rotation.Status != db.KeyRotationStatusRunning {
+		return nil
+	}
+
+	rotation.Status = db.KeyRotationStatusFailed
+	rotation.Error = pgtype.Text{String: reason, Valid: true}
+	rotation.FinishedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+	m.rotations[rotation.ID.String()] = rotation
+
+	return nil
+}
+
+// UpdateKeyRotationProgress mock implementation.
+func (m *MockDBStorage) UpdateKeyRotationProgress(_ context.Context, rotationID pgtype.UUID, remaining int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return m.CallError
+	}
+
+	rotation, exists := m.rotations[rotationID.String()]
+	if !exists {
+		return nil
+	}
+
+	rotation.DoneItems = max(rotation.TotalItems-remaining, 0)
+	m.rotations[rotation.ID.String()] = rotation
+
+	return nil
+}
+
+// FinishKeyRotation mock implementation.
+func (m *MockDBStorage) FinishKeyRotation(_ context.Context, rotation db.KeyRotation) (*db.KeyRotation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	finished, exists := m.rotations[rotation.ID.String()]
+	user := m.UsersByID[rotation.UserID]
+
+	if !exists || finished.Status != db.KeyRotationStatusRunning || user.KeyVersion != rotation.ToVersion {
+		return nil, pgx.ErrNoRows
+	}
+
+	user.PreviousEncryptionKey = pgtype.Text{}
+	user.LegacyCiphertexts = user.LegacyCiphertexts && !finished.BindsValues
+	m.UsersByID[user.ID] = user
+	m.usersByName[user.Username] = user
+
+	finished.Status = db.KeyRotationStatusCompleted
+	finished.DoneItems = finished.TotalItems
+	finished.FinishedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+	m.rotations[finished.ID.String()] = finished
+
+	return &finished, nil
+}
+
+// CountStaleItems mock implementation.
+func (m *MockDBStorage) CountStaleItems(_ context.Context, userID pgtype.UUID, keyVersion int32) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return 0, m.CallError
+	}
+
+	return m.countStale(userID, keyVersion), nil
+}
+
+func (m *MockDBStorage) countStale(userID pgtype.UUID, keyVersion int32) int64 {
+	var count int64
+
+	for _, password := range m.passwords {
+		if password.UserID == userID && password.KeyVersion < keyVersion {
+			count++
+		}
+	}
+
+	for _, note := range m.notes {
+		if note.UserID == userID && note.KeyVersion < keyVersion {
+			count++
+		}
+	}
+
+	for _, card := range m.cards {
+		if card.UserID == userID && card.KeyVersion < keyVersion {
+			count++
+		}
+	}
+
+	for _, binary := range m.binaries {
+		if binary.UserID == userID && binary.KeyVersion < keyVersion {
+			count++
+		}
+	}
+
+	if key, exists := m.shareKeys[userID]; exists && key.KeyVersion < keyVersion {
+		count++
+	}
+
+	return count
+}
+
+// ListStalePasswords mock implementation.
+func (m *MockDBStorage) ListStalePasswords(
+	_ context.Context,
+	userID pgtype.UUID,
+	keyVersion, batchSize int32,
+) ([]db.Password, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	return listStale(m.passwords, batchSize, func(password db.Password) (pgtype.UUID, bool) {
+		return password.ID, password.UserID == userID && password.KeyVersion < keyVersion
+	}), nil
+}
+
+// ReencryptPassword mock implementation.
+func (m *MockDBStorage) ReencryptPassword(_ context.Context, params db.ReencryptPasswordParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	password, exists := m.passwords[params.ID.String()]
+	if !exists || password.Version != params.Version || password.KeyVersion != params.OldKeyVersion {
+		return false, nil
+	}
+
+	password.Password = params.Password
+	password.KeyVersion = params.KeyVersion
+	m.passwords[password.ID.String()] = password
+
+	return true, nil
+}
+
+// ListStaleNotes mock implementation.
+func (m *MockDBStorage) ListStaleNotes(
+	_ context.Context,
+	userID pgtype.UUID,
+	keyVersion, batchSize int32,
+) ([]db.Note, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	return listStale(m.notes, batchSize, func(note db.Note) (pgtype.UUID, bool) {
+		return note.ID, note.UserID == userID && note.KeyVersion < keyVersion
+	}), nil
+}
+
+// ReencryptNote mock implementation.
+func (m *MockDBStorage) ReencryptNote(_ context.Context, params db.ReencryptNoteParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	note, exists := m.notes[params.ID.String()]
+	if !exists || note.Version != params.Version || note.KeyVersion != params.OldKeyVersion {
+		return false, nil
+	}
+
+	note.EncryptedContent = params.EncryptedContent
+	note.KeyVersion = params.KeyVersion
+	m.notes[note.ID.String()] = note
+
+	return true, nil
+}
+
+// ListStaleCards mock implementation.
+func (m *MockDBStorage) ListStaleCards(
+	_ context.Context,
+	userID pgtype.UUID,
+	keyVersion, batchSize int32,
+) ([]db.Card, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	return listStale(m.cards, batchSize, func(card db.Card) (pgtype.UUID, bool) {
+		return card.ID, card.UserID == userID && card.KeyVersion < keyVersion
+	}), nil
+}
+
+// ReencryptCard mock implementation.
+func (m *MockDBStorage) ReencryptCard(_ context.Context, params db.ReencryptCardParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	card, exists := m.cards[params.ID.String()]
+	if !exists || card.Version != params.Version || card.KeyVersion != params.OldKeyVersion {
+		return false, nil
+	}
+
+	card.EncryptedCardNumber = params.EncryptedCardNumber
+	card.EncryptedExpiryDate = params.EncryptedExpiryDate
+	card.EncryptedCvv = params.EncryptedCvv
+	card.KeyVersion = params.KeyVersion
+	m.cards[card.ID.String()] = card
+
+	return true, nil
+}
+
+// ListStaleBinaries mock implementation.
+func (m *MockDBStorage) ListStaleBinaries(
+	_ context.Context,
+	userID pgtype.UUID,
+	keyVersion, batchSize int32,
+) ([]db.BinaryEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	return listStale(m.binaries, batchSize, func(binary db.BinaryEntry) (pgtype.UUID, bool) {
+		return binary.ID, binary.UserID == userID && binary.KeyVersion < keyVersion
+	}), nil
+}
+
+// ReencryptBinary mock implementation.
+func (m *MockDBStorage) ReencryptBinary(_ context.Context, params db.ReencryptBinaryEntryParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	binary, exists := m.binaries[params.ID.String()]
+	if !exists || binary.Version != params.Version || binary.KeyVersion != params.OldKeyVersion {
+		return false, nil
+	}
+
+	binary.FileUrl = params.FileUrl
+	binary.KeyVersion = params.KeyVersion
+	m.binaries[binary.ID.String()] = binary
+
+	return true, nil
+}
+
+// listStale mirrors the ListStale queries: matching rows ordered by ID, at most batchSize of them.
+func listStale[T any](rows map[string]T, batchSize int32, match func(T) (pgtype.UUID, bool)) []T {
+	var result []T
+	for _, row := range rows {
+		if _, ok := match(row); ok {
+			result = append(result, row)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b T) int {
+		idA, _ := match(a)
+		idB, _ := match(b)
+
+		return cmp.Compare(idA.String(), idB.String())
+	})
+
+	if len(result) > int(batchSize) {
+		result = result[:batchSize]
+	}
+
+	return result
+}
@@ -0,0 +1,580 @@
+package testutils
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// membershipKey identifies a member of an organization, or the access of a member to a collection.
+type membershipKey struct {
+	groupID pgtype.UUID
+	userID  pgtype.UUID
+}
+
+// CreateOrganization mock implementation.
+func (m *MockDBStorage) CreateOrganization(
+	_ context.Context,
+	params db.CreateOrganizationParams,
+) (*db.CreateOrganizationRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	vaultName := "org/" + uuid.NewString()
+	vault := db.User{
+		ID:                pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username:          vaultName,
+		Email:             vaultName,
+		EncryptionKey:     params.EncryptionKey,
+		KeyVersion:        1,
+		OrganizationVault: true,
+	}
+	m.UsersByID[vault.ID] = vault
+	m.usersByName[vault.Username] = vault
+
+	org := db.Organization{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Name:      params.Name,
+		VaultID:   vault.ID,
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	}
+	m.organizations[org.ID] = org
+	m.orgMembers[membershipKey{org.ID, params.OwnerID}] = db.OrgMember{
+		OrgID:     org.ID,
+		UserID:    params.OwnerID,
+		Role:      db.OrgRoleOwner,
+		CreatedAt: org.CreatedAt,
+	}
+
+	return &db.CreateOrganizationRow{ID: org.ID, Name: org.Name, VaultID: org.VaultID, CreatedAt: org.CreatedAt}, nil
+}
+
+// ListOrganizations mock implementation.
+func (m *MockDBStorage) ListOrganizations(_ context.Context, userID pgtype.UUID) ([]db.ListOrganizationsRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.ListOrganizationsRow, 0)
+	for key, member := range m.orgMembers {
+		if key.userID != userID {
+			continue
+		}
+
+		org := m.organizations[key.groupID]
+		result = append(result, db.ListOrganizationsRow{
+			ID: org.ID, Name: org.Name, CreatedAt: org.CreatedAt, Role: member.Role,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b db.ListOrganizationsRow) int { return cmp.Compare(a.Name, b.Name) })
+
+	return result, nil
+}
+
+// GetMembership mock implementation.
+func (m *MockDBStorage) GetMembership(_ context.Context, orgID, userID pgtype.UUID) (*db.GetMembershipRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	member, exists := m.orgMembers[membershipKey{orgID, userID}]
+	if !exists {
+		return nil, pgx.ErrNoRows
+	}
+
+	org := m.organizations[orgID]
+
+	return &db.GetMembershipRow{
+		ID: org.ID, Name: org.Name, VaultID: org.VaultID, CreatedAt: org.CreatedAt, Role: member.Role,
+	}, nil
+}
+
+// DeleteOrganization mock implementation; like the query it deletes the vault, which takes the rest along.
+func (m *MockDBStorage) DeleteOrganization(_ context.Context, orgID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	org, exists := m.organizations[orgID]
+	if !exists {
+		return false, nil
+	}
+
+	vault := m.UsersByID[org.VaultID]
+	delete(m.UsersByID, vault.ID)
+	delete(m.usersByName, vault.Username)
+	m.deleteOrganizations(vault.ID)
+
+	return true, nil
+}
+
+// CountCollections mock implementation.
+func (m *MockDBStorage) CountCollections(_ context.Context, orgID pgtype.UUID) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return 0, m.CallError
+	}
+
+	var count int64
+	for _, collection := range m.collections {
+		if collection.OrgID == orgID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// UpsertOrgMember mock implementation.
+func (m *MockDBStorage) UpsertOrgMember(_ context.Context, params db.UpsertOrgMemberParams) (*db.OrgMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	key := membershipKey{params.OrgID, params.UserID}
+
+	member, exists := m.orgMembers[key]
+	if !exists {
+		member = db.OrgMember{
+			OrgID:     params.OrgID,
+			UserID:    params.UserID,
+			CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		}
+	}
+
+	member.Role = params.Role
+	m.orgMembers[key] = member
+
+	return &member, nil
+}
+
+// DeleteOrgMember mock implementation; like the foreign key it takes the collection access along.
+func (m *MockDBStorage) DeleteOrgMember(_ context.Context, orgID, userID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	key := membershipKey{orgID, userID}
+	if _, exists := m.orgMembers[key]; !exists {
+		return false, nil
+	}
+
+	delete(m.orgMembers, key)
+	maps.DeleteFunc(m.collectionMembers, func(_ membershipKey, row db.CollectionMember) bool {
+		return row.OrgID == orgID && row.UserID == userID
+	})
+
+	return true, nil
+}
+
+// CountOrgOwners mock implementation.
+func (m *MockDBStorage) CountOrgOwners(_ context.Context, orgID pgtype.UUID) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return 0, m.CallError
+	}
+
+	return m.countOwners(orgID), nil
+}
+
+// CountSoleOwnerships mock implementation.
+func (m *MockDBStorage) CountSoleOwnerships(_ context.Context, userID pgtype.UUID) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return 0, m.CallError
+	}
+
+	var count int64
+	for key, member := range m.orgMembers {
+		if key.userID == userID && member.Role == db.OrgRoleOwner && m.countOwners(key.groupID) == 1 {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// ListOrgMembers mock implementation.
+func (m *MockDBStorage) ListOrgMembers(_ context.Context, orgID pgtype.UUID) ([]db.ListOrgMembersRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.ListOrgMembersRow, 0)
+	for key, member := range m.orgMembers {
+		if key.groupID != orgID {
+			continue
+		}
+
+		result = append(result, db.ListOrgMembersRow{
+			Username: m.UsersByID[key.userID].Username, Role: member.Role, CreatedAt: member.CreatedAt,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b db.ListOrgMembersRow) int { return cmp.Compare(a.Username, b.Username) })
+
+	return result, nil
+}
+
+// CreateCollection mock implementation.
+func (m *MockDBStorage) CreateCollection(_ context.Context, params db.CreateCollectionParams) (*db.Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	for _, collection := range m.collections {
+		if collection.OrgID == params.OrgID && collection.Name == params.Name {
+			return nil, errors.New("collection name already exists")
+		}
+	}
+
+	collection := db.Collection{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		OrgID:     params.OrgID,
+		Name:      params.Name,
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	}
+	m.collections[collection.ID] = collection
+
+	return &collection, nil
+}
+
+// DeleteCollection mock implementation.
+func (m *MockDBStorage) DeleteCollection(_ context.Context, collectionID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	if _, exists := m.collections[collectionID]; !exists {
+		return false, nil
+	}
+
+	m.deleteCollections(func(collection db.Collection) bool { return collection.ID == collectionID })
+
+	return true, nil
+}
+
+// ListCollections mock implementation.
+func (m *MockDBStorage) ListCollections(
+	_ context.Context,
+	params db.ListCollectionsParams,
+) ([]db.ListCollectionsRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.ListCollectionsRow, 0)
+	for _, collection := range m.collections {
+		if collection.OrgID != params.OrgID {
+			continue
+		}
+
+		grant := m.collectionGrant(collection.ID, params.UserID)
+		if !params.AllCollections && !grant.Valid {
+			continue
+		}
+
+		result = append(result, db.ListCollectionsRow{
+			ID:        collection.ID,
+			OrgID:     collection.OrgID,
+			Name:      collection.Name,
+			CreatedAt: collection.CreatedAt,
+			ReadOnly:  grant,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b db.ListCollectionsRow) int { return cmp.Compare(a.Name, b.Name) })
+
+	return result, nil
+}
+
+// UpsertCollectionMember mock implementation; like the foreign key it only admits members.
+func (m *MockDBStorage) UpsertCollectionMember(
+	_ context.Context,
+	params db.UpsertCollectionMemberParams,
+) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	collection, exists := m.collections[params.CollectionID]
+	if !exists {
+		return false, nil
+	}
+
+	if _, exists := m.orgMembers[membershipKey{collection.OrgID, params.UserID}]; !exists {
+		return false, errors.New("user is no member of the organization")
+	}
+
+	key := membershipKey{collection.ID, params.UserID}
+
+	grant, exists := m.collectionMembers[key]
+	if !exists {
+		grant = db.CollectionMember{
+			CollectionID: collection.ID,
+			OrgID:        collection.OrgID,
+			UserID:       params.UserID,
+			CreatedAt:    pgtype.Timestamp{Time: time.Now(), Valid: true},
+		}
+	}
+
+	grant.ReadOnly = params.ReadOnly
+	m.collectionMembers[key] = grant
+
+	return true, nil
+}
+
+// DeleteCollectionMember mock implementation.
+func (m *MockDBStorage) DeleteCollectionMember(_ context.Context, collectionID, userID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	key := membershipKey{collectionID, userID}
+	if _, exists := m.collectionMembers[key]; !exists {
+		return false, nil
+	}
+
+	delete(m.collectionMembers, key)
+
+	return true, nil
+}
+
+// checkNewItem mirrors the constraints of storing an item: its resource ID is unique, and the collection
+// it goes into exists.
+func (m *MockDBStorage) checkNewItem(id, collectionID pgtype.UUID) error {
+	if _, exists := m.items[id.String()]; id.Valid && exists {
+		return errors.New("duplicate item")
+	}
+
+	if _, exists := m.collections[collectionID]; collectionID.Valid && !exists {
+		return errors.New("collection not found")
+	}
+
+	return nil
+}
+
+// addCollectionItem puts a stored item into the collection, if one is given.
+func (m *MockDBStorage) addCollectionItem(itemID, collectionID pgtype.UUID) {
+	if !collectionID.Valid {
+		return
+	}
+
+	m.collectionItems[itemID] = db.CollectionItem{
+		ItemID:       itemID,
+		CollectionID: collectionID,
+		CreatedAt:    pgtype.Timestamp{Time: time.Now(), Valid: true},
+	}
+}
+
+// ListCollectionItems mock implementation; like the query it leaves out items whose record is gone.
+func (m *MockDBStorage) ListCollectionItems(
+	_ context.Context,
+	collectionID pgtype.UUID,
+) ([]db.ListCollectionItemsRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.ListCollectionItemsRow, 0)
+	for itemID, link := range m.collectionItems {
+		item, exists := m.items[itemID.String()]
+		if link.CollectionID != collectionID || !exists {
+			continue
+		}
+
+		updatedAt := m.itemUpdatedAt(item)
+		if !updatedAt.Valid {
+			continue
+		}
+
+		result = append(result, db.ListCollectionItemsRow{
+			ID:         item.ID,
+			Type:       item.Type,
+			IDResource: item.IDResource,
+			CreatedAt:  item.CreatedAt,
+			UpdatedAt:  updatedAt,
+		})
+	}
+
+	sortByCreation(result, func(row db.ListCollectionItemsRow) (pgtype.Timestamp, pgtype.UUID) {
+		return row.CreatedAt, row.ID
+	})
+
+	return result, nil
+}
+
+// GetItemAccess mock implementation. Like GetPassword it ignores CallError, so that tests of the
+// storage calls that follow keep failing where they did.
+func (m *MockDBStorage) GetItemAccess(_ context.Context, itemID, userID pgtype.UUID) (*db.GetItemAccessRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	link, linked := m.collectionItems[itemID]
+	item, exists := m.items[itemID.String()]
+	if !linked || !exists {
+		return nil, pgx.ErrNoRows
+	}
+
+	// Only items kept in the vault of the organization resolve to it
+	collection := m.collections[link.CollectionID]
+	if m.organizations[collection.OrgID].VaultID != item.UserID {
+		return nil, pgx.ErrNoRows
+	}
+
+	access := &db.GetItemAccessRow{
+		VaultID:      item.UserID,
+		CollectionID: collection.ID,
+		ReadOnly:     m.collectionGrant(collection.ID, userID),
+	}
+
+	if member, exists := m.orgMembers[membershipKey{collection.OrgID, userID}]; exists {
+		access.Role = db.NullOrgRole{OrgRole: member.Role, Valid: true}
+	}
+
+	return access, nil
+}
+
+// GetCollectionAccess mock implementation.
+func (m *MockDBStorage) GetCollectionAccess(
+	_ context.Context,
+	collectionID, userID pgtype.UUID,
+) (*db.GetCollectionAccessRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	collection, exists := m.collections[collectionID]
+	if !exists {
+		return nil, pgx.ErrNoRows
+	}
+
+	member, exists := m.orgMembers[membershipKey{collection.OrgID, userID}]
+	if !exists {
+		return nil, pgx.ErrNoRows
+	}
+
+	return &db.GetCollectionAccessRow{
+		ID:       collection.ID,
+		OrgID:    collection.OrgID,
+		VaultID:  m.organizations[collection.OrgID].VaultID,
+		Role:     member.Role,
+		ReadOnly: m.collectionGrant(collection.ID, userID),
+	}, nil
+}
+
+// collectionGrant returns the access of the user to the collection: unset without one, true when read-only.
+func (m *MockDBStorage) collectionGrant(collectionID, userID pgtype.UUID) pgtype.Bool {
+	grant, exists := m.collectionMembers[membershipKey{collectionID, userID}]
+	if !exists {
+		return pgtype.Bool{}
+	}
+
+	return pgtype.Bool{Bool: grant.ReadOnly, Valid: true}
+}
+
+func (m *MockDBStorage) countOwners(orgID pgtype.UUID) int64 {
+	var count int64
+	for key, member := range m.orgMembers {
+		if key.groupID == orgID && member.Role == db.OrgRoleOwner {
+			count++
+		}
+	}
+
+	return count
+}
+
+// deleteCollections mirrors the cascades that follow a deleted collection.
+func (m *MockDBStorage) deleteCollections(match func(db.Collection) bool) {
+	for collectionID, collection := range m.collections {
+		if !match(collection) {
+			continue
+		}
+
+		delete(m.collections, collectionID)
+		maps.DeleteFunc(m.collectionMembers, func(_ membershipKey, row db.CollectionMember) bool {
+			return row.CollectionID == collectionID
+		})
+		maps.DeleteFunc(m.collectionItems, func(_ pgtype.UUID, row db.CollectionItem) bool {
+			return row.CollectionID == collectionID
+		})
+	}
+}
+
+// deleteOrganizations mirrors the cascades that follow a deleted user: the organizations the user was the
+// vault of, and the memberships of the user.
+func (m *MockDBStorage) deleteOrganizations(userID pgtype.UUID) {
+	for orgID, org := range m.organizations {
+		if org.VaultID != userID {
+			continue
+		}
+
+		delete(m.organizations, orgID)
+		maps.DeleteFunc(m.orgMembers, func(key membershipKey, _ db.OrgMember) bool { return key.groupID == orgID })
+		m.deleteCollections(func(collection db.Collection) bool { return collection.OrgID == orgID })
+	}
+
+	maps.DeleteFunc(m.orgMembers, func(key membershipKey, _ db.OrgMember) bool { return key.userID == userID })
+	maps.DeleteFunc(m.collectionMembers, func(key membershipKey, _ db.CollectionMember) bool {
+		return key.userID == userID
+	})
+}
@@ -3,16 +3,22 @@ package testutils
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 	"time"
 )
 
 var ErrKeyNotFound = errors.New("key not found")
 
+// subscriberBuffer is how many undelivered messages a mock subscriber may hold.
+const subscriberBuffer = 16
+
 // MockRedis is a mock implementation of RedisInterface for testing.
 type MockRedis struct {
-	data  map[string]mockValue
-	mutex sync.RWMutex
+	data        map[string]mockValue
+	events      map[string][]time.Time
+	subscribers map[string][]chan string
+	mutex       sync.RWMutex
 }
 
 // mockValue holds a value and its expiration time.
@@ -24,8 +30,10 @@ type mockValue struct {
 // NewMockRedis initializes a new MockRedis instance.
 func NewMockRedis() *MockRedis {
 	return &MockRedis{
-		data:  make(map[string]mockValue),
-		mutex: sync.RWMutex{},
+		data:        make(map[string]mockValue),
+		events:      make(map[string][]time.Time),
+		subscribers: make(map[string][]chan string),
+		mutex:       sync.RWMutex{},
 	}
 }
 
@@ -46,6 +54,32 @@ func (m *MockRedis) Get(_ context.Context, key string) (string, error) {
 	return value.value, nil
 }
 
+func (m *MockRedis) GetDel(_ context.Context, key string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	value, exists := m.data[key]
+	delete(m.data, key)
+
+	if !exists || (value.expiration.Before(time.Now()) && !value.expiration.IsZero()) {
+		return "", ErrKeyNotFound
+	}
+
+	return value.value, nil
+}
+
+func (m *MockRedis) Del(_ context.Context, keys ...string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(m.data, key)
+		delete(m.events, key)
+	}
+
+	return nil
+}
+
 func (m *MockRedis) Set(_ context.Context, key string, value string, expiration time.Duration) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -62,3 +96,104 @@ func (m *MockRedis) Set(_ context.Context, key string, value string, expiration
 
 	return nil
 }
+
+// Record adds an event at the current time under key and drops the events older than window.
+func (m *MockRedis) Record(_ context.Context, key string, window time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	m.events[key] = append(recentEvents(m.events[key], now.Add(-window)), now)
+
+	return nil
+}
+
+// Recent returns the times of the events under key within window, oldest first.
+func (m *MockRedis) Recent(_ context.Context, key string, window time.Duration) ([]time.Time, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return recentEvents(m.events[key], time.Now().Add(-window)), nil
+}
+
+// Admit records an event under key unless limit events are already within window, and returns the events
+// within window before it.
+func (m *MockRedis) Admit(_ context.Context, key string, limit int, window time.Duration) ([]time.Time, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	events := recentEvents(m.events[key], now.Add(-window))
+
+	if len(events) < limit {
+		m.events[key] = append(slices.Clone(events), now)
+	}
+
+	return events, nil
+}
+
+// recentEvents returns a copy of the events after since.
+func recentEvents(events []time.Time, since time.Time) []time.Time {
+	recent := make([]time.Time, 0, len(events))
+
+	for _, event := range events {
+		if event.After(since) {
+			recent = append(recent, event)
+		}
+	}
+
+	return recent
+}
+
+// Publish delivers message to every current subscriber of channel.
+func (m *MockRedis) Publish(_ context.Context, channel, message string) error {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, subscriber := range m.subscribers[channel] {
+		select {
+		case subscriber <- message:
+		default:
+			// Drop the message for a slow subscriber, as Redis would for a lagging client.
+		}
+	}
+
+	return nil
+}
+
+// Subscribe registers a buffered subscriber that is removed and closed once ctx is done.
+func (m *MockRedis) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	subscriber := make(chan string, subscriberBuffer)
+
+	m.mutex.Lock()
+	m.subscribers[channel] = append(m.subscribers[channel], subscriber)
+	m.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		subscribers := m.subscribers[channel]
+		for i, candidate := range subscribers {
+			if candidate == subscriber {
+				m.subscribers[channel] = append(subscribers[:i], subscribers[i+1:]...)
+
+				break
+			}
+		}
+
+		close(subscriber)
+	}()
+
+	return subscriber, nil
+}
+
+// Subscribers returns the number of active subscribers of channel.
+func (m *MockRedis) Subscribers(channel string) int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return len(m.subscribers[channel])
+}
@@ -1,6 +1,7 @@
 package testutils_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -40,3 +41,100 @@ func TestMockRedis_GetNonExistentKey(t *testing.T) {
 	_, err := mockRedis.Get(t.Context(), "non-existent-key")
 	require.ErrorIs(t, err, testutils.ErrKeyNotFound, "expected error for non-existent key")
 }
+
+func TestMockRedis_GetDel(t *testing.T) {
+	t.Parallel()
+
+	mockRedis := testutils.NewMockRedis()
+
+	require.NoError(t, mockRedis.Set(t.Context(), "key", "value", time.Minute))
+
+	value, err := mockRedis.GetDel(t.Context(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	_, err = mockRedis.GetDel(t.Context(), "key")
+	require.ErrorIs(t, err, testutils.ErrKeyNotFound, "expected the key to be deleted")
+}
+
+func TestMockRedis_Del(t *testing.T) {
+	t.Parallel()
+
+	mockRedis := testutils.NewMockRedis()
+
+	require.NoError(t, mockRedis.Set(t.Context(), "first", "1", time.Minute))
+	require.NoError(t, mockRedis.Set(t.Context(), "second", "2", time.Minute))
+	require.NoError(t, mockRedis.Del(t.Context(), "first", "missing"))
+
+	_, err := mockRedis.Get(t.Context(), "first")
+	require.ErrorIs(t, err, testutils.ErrKeyNotFound)
+
+	value, err := mockRedis.Get(t.Context(), "second")
+	require.NoError(t, err)
+	assert.Equal(t, "2", value)
+}
+
+func TestMockRedis_Window(t *testing.T) {
+	t.Parallel()
+
+	mockRedis := testutils.NewMockRedis()
+
+	require.NoError(t, mockRedis.Record(t.Context(), "attempts", time.Minute))
+	require.NoError(t, mockRedis.Record(t.Context(), "attempts", time.Minute))
+
+	events, err := mockRedis.Recent(t.Context(), "attempts", time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	events, err = mockRedis.Recent(t.Context(), "attempts", time.Nanosecond)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	require.NoError(t, mockRedis.Del(t.Context(), "attempts"))
+
+	events, err = mockRedis.Recent(t.Context(), "attempts", time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestMockRedis_Admit(t *testing.T) {
+	t.Parallel()
+
+	mockRedis := testutils.NewMockRedis()
+
+	for want := range 2 {
+		events, err := mockRedis.Admit(t.Context(), "attempts", 2, time.Minute)
+		require.NoError(t, err)
+		assert.Len(t, events, want)
+	}
+
+	// The window is full, so the event is not recorded.
+	events, err := mockRedis.Admit(t.Context(), "attempts", 2, time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	events, err = mockRedis.Recent(t.Context(), "attempts", time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestMockRedis_PublishSubscribe(t *testing.T) {
+	t.Parallel()
+
+	mockRedis := testutils.NewMockRedis()
+	ctx, cancel := context.WithCancel(t.Context())
+
+	messages, err := mockRedis.Subscribe(ctx, "channel")
+	require.NoError(t, err)
+	assert.Equal(t, 1, mockRedis.Subscribers("channel"))
+
+	require.NoError(t, mockRedis.Publish(t.Context(), "channel", "hello"))
+	require.NoError(t, mockRedis.Publish(t.Context(), "other", "ignored"))
+	assert.Equal(t, "hello", <-messages)
+
+	cancel()
+
+	_, open := <-messages
+	assert.False(t, open)
+	assert.Equal(t, 0, mockRedis.Subscribers("channel"))
+}
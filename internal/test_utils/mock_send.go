@@ -0,0 +1,154 @@
+package testutils
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// CreateSend mock implementation.
+func (m *MockDBStorage) CreateSend(_ context.Context, params db.CreateSendParams) (*db.Send, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	send := db.Send{
+		ID:         params.ID,
+		UserID:     params.UserID,
+		SendType:   params.SendType,
+		Content:    params.Content,
+		ObjectName: params.ObjectName,
+		FileSize:   params.FileSize,
+		Password:   params.Password,
+		MaxViews:   params.MaxViews,
+		Views:      0,
+		ExpiresAt:  params.ExpiresAt,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+	}
+	m.sends[send.ID] = send
+
+	return &send, nil
+}
+
+// ListSends mock implementation.
+func (m *MockDBStorage) ListSends(_ context.Context, userID pgtype.UUID) ([]db.Send, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.Send, 0)
+	for _, send := range m.sends {
+		if send.UserID == userID {
+			result = append(result, send)
+		}
+	}
+
+	sortByCreation(result, func(send db.Send) (pgtype.Timestamp, pgtype.UUID) {
+		return send.CreatedAt, send.ID
+	})
+
+	return result, nil
+}
+
+// GetSend mock implementation.
+func (m *MockDBStorage) GetSend(_ context.Context, sendID pgtype.UUID) (*db.Send, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	send, exists := m.sends[sendID]
+	if !exists {
+		return nil, pgx.ErrNoRows
+	}
+
+	return &send, nil
+}
+
+// DeleteSend mock implementation.
+func (m *MockDBStorage) DeleteSend(_ context.Context, sendID, userID pgtype.UUID) (*db.Send, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	send, exists := m.sends[sendID]
+	if !exists || send.UserID != userID {
+		return nil, pgx.ErrNoRows
+	}
+
+	delete(m.sends, sendID)
+
+	return &send, nil
+}
+
+// ViewSend mock implementation.
+func (m *MockDBStorage) ViewSend(_ context.Context, sendID pgtype.UUID, now time.Time) (*db.Send, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	send, exists := m.sends[sendID]
+	if !exists || send.Views >= send.MaxViews || !send.ExpiresAt.Time.After(now) {
+		return nil, pgx.ErrNoRows
+	}
+
+	send.Views++
+	m.sends[sendID] = send
+
+	return &send, nil
+}
+
+// RemoveSend mock implementation.
+func (m *MockDBStorage) RemoveSend(_ context.Context, sendID pgtype.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return m.CallError
+	}
+
+	delete(m.sends, sendID)
+
+	return nil
+}
+
+// DeleteExpiredSends mock implementation.
+func (m *MockDBStorage) DeleteExpiredSends(_ context.Context, now time.Time) ([]db.Send, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.Send, 0)
+	maps.DeleteFunc(m.sends, func(_ pgtype.UUID, send db.Send) bool {
+		expired := !send.ExpiresAt.Time.After(now) || send.Views >= send.MaxViews
+		if expired {
+			result = append(result, send)
+		}
+
+		return expired
+	})
+
+	return result, nil
+}
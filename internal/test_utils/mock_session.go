@@ -0,0 +1,171 @@
+package testutils
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// CreateSession mock implementation.
+func (m *MockDBStorage) CreateSession(_ context.Context, params db.CreateSessionParams) (*db.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	session := db.Session{
+		ID:         pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		UserID:     params.UserID,
+		DeviceName: params.DeviceName,
+		IpAddress:  params.IpAddress,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  params.ExpiresAt,
+	}
+	m.sessions[session.ID] = session
+
+	return &session, nil
+}
+
+// TouchSession mock implementation.
+func (m *MockDBStorage) TouchSession(
+	_ context.Context,
+	sessionID pgtype.UUID,
+	ipAddress string,
+	expiresAt time.Time,
+) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	session, exists := m.sessions[sessionID]
+	if !exists {
+		return false, nil
+	}
+
+	session.IpAddress = ipAddress
+	session.LastUsedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+	session.ExpiresAt = pgtype.Timestamp{Time: expiresAt, Valid: true}
+	m.sessions[sessionID] = session
+
+	return true, nil
+}
+
+// ListSessions mock implementation.
+func (m *MockDBStorage) ListSessions(_ context.Context, userID pgtype.UUID) ([]db.Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	var sessions []db.Session
+
+	for _, session := range m.sessions {
+		if session.UserID == userID && session.ExpiresAt.Time.After(time.Now()) {
+			sessions = append(sessions, session)
+		}
+	}
+
+	slices.SortFunc(sessions, func(a, b db.Session) int {
+		return cmp.Compare(b.LastUsedAt.Time.UnixNano(), a.LastUsedAt.Time.UnixNano())
+	})
+
+	return sessions, nil
+}
+
+// DeleteSession mock implementation; like the foreign key, it removes the refresh tokens of the session.
+func (m *MockDBStorage) DeleteSession(_ context.Context, userID, sessionID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	session, exists := m.sessions[sessionID]
+	if !exists || session.UserID != userID {
+		return false, nil
+	}
+
+	m.deleteSession(sessionID)
+
+	return true, nil
+}
+
+// DeleteUserSessions mock implementation.
+func (m *MockDBStorage) DeleteUserSessions(_ context.Context, userID pgtype.UUID) ([]pgtype.UUID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	var sessionIDs []pgtype.UUID
+
+	for sessionID, session := range m.sessions {
+		if session.UserID == userID {
+			m.deleteSession(sessionID)
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+
+	return sessionIDs, nil
+}
+
+// DeleteToken mock implementation.
+func (m *MockDBStorage) DeleteToken(_ context.Context, tokenHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return m.CallError
+	}
+
+	delete(m.tokens, tokenHash)
+
+	return nil
+}
+
+// DeleteUserTokens mock implementation.
+func (m *MockDBStorage) DeleteUserTokens(_ context.Context, userID pgtype.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return m.CallError
+	}
+
+	for token, row := range m.tokens {
+		if row.UserID == userID {
+			delete(m.tokens, token)
+		}
+	}
+
+	return nil
+}
+
+// deleteSession removes a session and its refresh tokens; the caller holds the lock.
+func (m *MockDBStorage) deleteSession(sessionID pgtype.UUID) {
+	delete(m.sessions, sessionID)
+
+	for token, row := range m.tokens {
+		if row.SessionID == sessionID {
+			delete(m.tokens, token)
+		}
+	}
+}
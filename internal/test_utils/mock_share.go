@@ -0,0 +1,227 @@
+package testutils
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// GetShareKey mock implementation.
+func (m *MockDBStorage) GetShareKey(_ context.Context, userID pgtype.UUID) (*db.ShareKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	key, exists := m.shareKeys[userID]
+	if !exists {
+		return nil, pgx.ErrNoRows
+	}
+
+	return &key, nil
+}
+
+// CreateShareKey mock implementation; like the query it keeps an existing key.
+func (m *MockDBStorage) CreateShareKey(_ context.Context, params db.CreateShareKeyParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return m.CallError
+	}
+
+	if _, exists := m.shareKeys[params.UserID]; exists {
+		return nil
+	}
+
+	m.shareKeys[params.UserID] = db.ShareKey{
+		UserID:     params.UserID,
+		PublicKey:  params.PublicKey,
+		PrivateKey: params.PrivateKey,
+		KeyVersion: params.KeyVersion,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+	}
+
+	return nil
+}
+
+// ReencryptShareKey mock implementation.
+func (m *MockDBStorage) ReencryptShareKey(_ context.Context, params db.ReencryptShareKeyParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	key, exists := m.shareKeys[params.UserID]
+	if !exists || key.KeyVersion != params.OldKeyVersion {
+		return false, nil
+	}
+
+	key.PrivateKey = params.PrivateKey
+	key.KeyVersion = params.KeyVersion
+	m.shareKeys[params.UserID] = key
+
+	return true, nil
+}
+
+// GetItemChangeSeq mock implementation.
+func (m *MockDBStorage) GetItemChangeSeq(_ context.Context, userID, itemID pgtype.UUID) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return 0, m.CallError
+	}
+
+	change, exists := m.changes[itemID.String()]
+	if !exists || change.UserID != userID || change.Deleted {
+		return 0, pgx.ErrNoRows
+	}
+
+	return change.Seq, nil
+}
+
+// UpsertShare mock implementation.
+func (m *MockDBStorage) UpsertShare(_ context.Context, params db.UpsertShareParams) (*db.Share, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	share, exists := m.findShare(params.ItemID, params.RecipientID)
+	if exists && share.OwnerID != params.OwnerID {
+		return nil, pgx.ErrNoRows
+	}
+
+	if !exists {
+		share = db.Share{
+			ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			ItemID:      params.ItemID,
+			OwnerID:     params.OwnerID,
+			RecipientID: params.RecipientID,
+			CreatedAt:   pgtype.Timestamp{Time: time.Now(), Valid: true},
+		}
+	}
+
+	share.ReadOnly = params.ReadOnly
+	share.Payload = params.Payload
+	share.ItemSeq = params.ItemSeq
+	m.shares[share.ID] = share
+
+	return &share, nil
+}
+
+// GetShare mock implementation.
+func (m *MockDBStorage) GetShare(_ context.Context, itemID, recipientID pgtype.UUID) (*db.Share, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	share, exists := m.findShare(itemID, recipientID)
+	if !exists {
+		return nil, pgx.ErrNoRows
+	}
+
+	return &share, nil
+}
+
+// RefreshShare mock implementation.
+func (m *MockDBStorage) RefreshShare(_ context.Context, params db.RefreshShareParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	share, exists := m.shares[params.ID]
+	if !exists || share.ItemSeq != params.OldItemSeq {
+		return false, nil
+	}
+
+	share.Payload = params.Payload
+	share.ItemSeq = params.ItemSeq
+	m.shares[share.ID] = share
+
+	return true, nil
+}
+
+// ListShares mock implementation.
+func (m *MockDBStorage) ListShares(_ context.Context, params db.ListSharesParams) ([]db.ListSharesRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.ListSharesRow, 0)
+	for _, share := range m.shares {
+		if share.OwnerID != params.OwnerID || (params.ByItem && share.ItemID != params.ItemID) {
+			continue
+		}
+
+		result = append(result, db.ListSharesRow{
+			ID:        share.ID,
+			ItemID:    share.ItemID,
+			ReadOnly:  share.ReadOnly,
+			CreatedAt: share.CreatedAt,
+			Recipient: m.UsersByID[share.RecipientID].Username,
+		})
+	}
+
+	sortByCreation(result, func(row db.ListSharesRow) (pgtype.Timestamp, pgtype.UUID) {
+		return row.CreatedAt, row.ID
+	})
+
+	return result, nil
+}
+
+// DeleteShare mock implementation.
+func (m *MockDBStorage) DeleteShare(_ context.Context, params db.DeleteShareParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	recipient, exists := m.usersByName[params.Recipient]
+	if !exists {
+		return false, nil
+	}
+
+	share, exists := m.findShare(params.ItemID, recipient.ID)
+	if !exists || share.OwnerID != params.OwnerID {
+		return false, nil
+	}
+
+	delete(m.shares, share.ID)
+
+	return true, nil
+}
+
+// findShare returns the share of an item with the recipient; the pair is unique like in the schema.
+func (m *MockDBStorage) findShare(itemID, recipientID pgtype.UUID) (db.Share, bool) {
+	for _, share := range m.shares {
+		if share.ItemID == itemID && share.RecipientID == recipientID {
+			return share, true
+		}
+	}
+
+	return db.Share{}, false
+}
@@ -2,11 +2,16 @@
 package testutils
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -26,9 +31,43 @@ type MockDBStorage struct {
 	metaInfo    map[string]map[string]string
 	notes       map[string]db.Note
 	passwords   map[string]db.Password
+	changes     map[string]db.ItemChange
+	changeSeq   map[pgtype.UUID]int64
+	rotations   map[string]db.KeyRotation
+	recovery    map[pgtype.UUID]map[string]bool
+	sessions    map[pgtype.UUID]db.Session
+	signingKeys map[string]db.SigningKey
+	apiTokens   map[pgtype.UUID]db.ApiToken
+	shareKeys   map[pgtype.UUID]db.ShareKey
+	shares      map[pgtype.UUID]db.Share
 	log         *zerolog.Logger
 	CallError   error
 	masterKey   string
+
+	// Organizations, see mock_organization.go
+	organizations     map[pgtype.UUID]db.Organization
+	orgMembers        map[membershipKey]db.OrgMember
+	collections       map[pgtype.UUID]db.Collection
+	collectionMembers map[membershipKey]db.CollectionMember
+	collectionItems   map[pgtype.UUID]db.CollectionItem
+
+	// Emergency access, see mock_emergency.go
+	emergencyAccess map[pgtype.UUID]db.EmergencyAccess
+
+	// Sends, see mock_send.go
+	sends map[pgtype.UUID]db.Send
+
+	// Audit chain in order, see mock_audit.go
+	auditEvents []db.AuditEvent
+}
+
+// itemID returns the ID the service generated for a new item, or a random one for tests that leave it out.
+func itemID(id pgtype.UUID) pgtype.UUID {
+	if id.Valid {
+		return id
+	}
+
+	return pgtype.UUID{Bytes: uuid.New(), Valid: true}
 }
 
 func NewMockDBStorage(logger *zerolog.Logger, masterKey string) *MockDBStorage {
@@ -43,8 +82,27 @@ func NewMockDBStorage(logger *zerolog.Logger, masterKey string) *MockDBStorage {
 		metaInfo:    make(map[string]map[string]string),
 		notes:       make(map[string]db.Note),
 		passwords:   make(map[string]db.Password),
+		changes:     make(map[string]db.ItemChange),
+		changeSeq:   make(map[pgtype.UUID]int64),
+		rotations:   make(map[string]db.KeyRotation),
+		recovery:    make(map[pgtype.UUID]map[string]bool),
+		sessions:    make(map[pgtype.UUID]db.Session),
+		signingKeys: make(map[string]db.SigningKey),
+		apiTokens:   make(map[pgtype.UUID]db.ApiToken),
+		shareKeys:   make(map[pgtype.UUID]db.ShareKey),
+		shares:      make(map[pgtype.UUID]db.Share),
 		log:         logger,
 		masterKey:   masterKey,
+
+		organizations:     make(map[pgtype.UUID]db.Organization),
+		orgMembers:        make(map[membershipKey]db.OrgMember),
+		collections:       make(map[pgtype.UUID]db.Collection),
+		collectionMembers: make(map[membershipKey]db.CollectionMember),
+		collectionItems:   make(map[pgtype.UUID]db.CollectionItem),
+
+		emergencyAccess: make(map[pgtype.UUID]db.EmergencyAccess),
+
+		sends: make(map[pgtype.UUID]db.Send),
 	}
 }
 
@@ -57,6 +115,20 @@ func (m *MockDBStorage) AddTestUser(user db.User) {
 	m.usersByName[user.Username] = user
 }
 
+// AddZeroKnowledgeUser adds a zero-knowledge account and returns ctx carrying its ID.
+func (m *MockDBStorage) AddZeroKnowledgeUser(ctx context.Context) context.Context {
+	user := db.User{
+		ID:              pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username:        "zk-" + uuid.NewString(),
+		Password:        "hashed-password",
+		KdfSalt:         pgtype.Text{String: "c2FsdA==", Valid: true},
+		WrappedVaultKey: pgtype.Text{String: "d3JhcHBlZA==", Valid: true},
+	}
+	m.AddTestUser(user)
+
+	return InjectUserToContext(ctx, user.ID.String())
+}
+
 // RegisterUser mock implementation.
 func (m *MockDBStorage) RegisterUser(_ context.Context, createUser db.CreateUserParams) (*db.User, error) {
 	m.mu.Lock()
@@ -72,11 +144,14 @@ func (m *MockDBStorage) RegisterUser(_ context.Context, createUser db.CreateUser
 	}
 
 	user := db.User{
-		ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
-		Username:      createUser.Username,
-		Email:         createUser.Email,
-		Password:      createUser.Password,
-		EncryptionKey: createUser.EncryptionKey,
+		ID:              pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username:        createUser.Username,
+		Email:           createUser.Email,
+		Password:        createUser.Password,
+		EncryptionKey:   createUser.EncryptionKey,
+		KdfSalt:         createUser.KdfSalt,
+		WrappedVaultKey: createUser.WrappedVaultKey,
+		KeyVersion:      1,
 	}
 
 	// Add to both maps
@@ -116,6 +191,182 @@ func (m *MockDBStorage) GetUserByID(_ context.Context, userID pgtype.UUID) (*db.
 	return &user, nil
 }
 
+// ListUserKeys mock implementation.
+func (m *MockDBStorage) ListUserKeys(
+	_ context.Context,
+	afterID pgtype.UUID,
+	batchSize int32,
+) ([]db.ListUserKeysAfterRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	var rows []db.ListUserKeysAfterRow
+	for _, user := range m.UsersByID {
+		if user.WrappedVaultKey.Valid || bytes.Compare(user.ID.Bytes[:], afterID.Bytes[:]) <= 0 {
+			continue
+		}
+
+		rows = append(rows, db.ListUserKeysAfterRow{ID: user.ID, EncryptionKey: user.EncryptionKey})
+	}
+
+	slices.SortFunc(rows, func(a, b db.ListUserKeysAfterRow) int {
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+
+	if len(rows) > int(batchSize) {
+		rows = rows[:batchSize]
+	}
+
+	return rows, nil
+}
+
+// ListLegacyUsers mock implementation.
+func (m *MockDBStorage) ListLegacyUsers(
+	_ context.Context,
+	afterID pgtype.UUID,
+	batchSize int32,
+) ([]db.ListLegacyCiphertextUsersRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	var rows []db.ListLegacyCiphertextUsersRow
+	for _, user := range m.UsersByID {
+		if !user.LegacyCiphertexts || user.WrappedVaultKey.Valid || user.PreviousEncryptionKey.Valid ||
+			bytes.Compare(user.ID.Bytes[:], afterID.Bytes[:]) <= 0 {
+			continue
+		}
+
+		rows = append(rows, db.ListLegacyCiphertextUsersRow{ID: user.ID, KeyVersion: user.KeyVersion})
+	}
+
+	slices.SortFunc(rows, func(a, b db.ListLegacyCiphertextUsersRow) int {
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+
+	if len(rows) > int(batchSize) {
+		rows = rows[:batchSize]
+	}
+
+	return rows, nil
+}
+
+// RewrapUserKey mock implementation.
+func (m *MockDBStorage) RewrapUserKey(_ context.Context, userID pgtype.UUID, oldKey, newKey string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	user, exists := m.UsersByID[userID]
+	if !exists || user.EncryptionKey != oldKey {
+		return false, nil
+	}
+
+	user.EncryptionKey = newKey
+	m.UsersByID[userID] = user
+	m.usersByName[user.Username] = user
+
+	return true, nil
+}
+
+// ChangePassword mock implementation.
+func (m *MockDBStorage) ChangePassword(_ context.Context, params db.ChangeUserPasswordParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	user, exists := m.UsersByID[params.ID]
+	if !exists || user.Password != params.OldPassword {
+		return false, nil
+	}
+
+	user.Password = params.NewPassword
+	user.KdfSalt = params.KdfSalt
+	user.WrappedVaultKey = params.WrappedVaultKey
+	m.UsersByID[params.ID] = user
+	m.usersByName[user.Username] = user
+
+	return true, nil
+}
+
+func (m *MockDBStorage) RehashPassword(_ context.Context, params db.RehashUserPasswordParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	user, exists := m.UsersByID[params.ID]
+	if !exists || user.Password != params.OldPassword {
+		return false, nil
+	}
+
+	user.Password = params.NewPassword
+	m.UsersByID[params.ID] = user
+	m.usersByName[user.Username] = user
+
+	return true, nil
+}
+
+// DeleteUser mock implementation; it deletes the rows of the user like the cascades of the schema.
+func (m *MockDBStorage) DeleteUser(_ context.Context, userID pgtype.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	user, exists := m.UsersByID[userID]
+	if !exists {
+		return false, nil
+	}
+
+	delete(m.UsersByID, userID)
+	delete(m.usersByName, user.Username)
+	delete(m.changeSeq, userID)
+	delete(m.recovery, userID)
+
+	for itemID, item := range m.items {
+		if item.UserID == userID {
+			delete(m.items, itemID)
+			delete(m.metaInfo, itemID)
+		}
+	}
+
+	maps.DeleteFunc(m.tokens, func(_ string, row db.GetRefreshTokenRow) bool { return row.UserID == userID })
+	maps.DeleteFunc(m.sessions, func(_ pgtype.UUID, row db.Session) bool { return row.UserID == userID })
+	maps.DeleteFunc(m.cards, func(_ string, row db.Card) bool { return row.UserID == userID })
+	maps.DeleteFunc(m.binaries, func(_ string, row db.BinaryEntry) bool { return row.UserID == userID })
+	maps.DeleteFunc(m.notes, func(_ string, row db.Note) bool { return row.UserID == userID })
+	maps.DeleteFunc(m.passwords, func(_ string, row db.Password) bool { return row.UserID == userID })
+	maps.DeleteFunc(m.changes, func(_ string, row db.ItemChange) bool { return row.UserID == userID })
+	maps.DeleteFunc(m.rotations, func(_ string, row db.KeyRotation) bool { return row.UserID == userID })
+	maps.DeleteFunc(m.shares, func(_ pgtype.UUID, row db.Share) bool {
+		return row.OwnerID == userID || row.RecipientID == userID
+	})
+	delete(m.shareKeys, userID)
+	m.deleteOrganizations(userID)
+	m.deleteEmergencyAccess(userID)
+	maps.DeleteFunc(m.sends, func(_ pgtype.UUID, send db.Send) bool { return send.UserID == userID })
+
+	return true, nil
+}
+
 // SetupMockUserStorage is a helper function to configure mock storage with test data.
 func SetupMockUserStorage(masterKey string, initialUsers ...db.User) *MockDBStorage {
 	logger := GetTLogger()
@@ -130,7 +381,8 @@ func SetupMockUserStorage(masterKey string, initialUsers ...db.User) *MockDBStor
 
 func (m *MockDBStorage) StoreToken(_ context.Context,
 	userID pgtype.UUID,
-	refreshToken string,
+	sessionID pgtype.UUID,
+	tokenHash string,
 	expiresAt time.Time,
 ) error {
 	m.mu.Lock()
@@ -146,21 +398,22 @@ func (m *MockDBStorage) StoreToken(_ context.Context,
 		return errors.Wrap(err, "failed to scan expires at")
 	}
 
-	m.tokens[refreshToken] = db.GetRefreshTokenRow{
+	m.tokens[tokenHash] = db.GetRefreshTokenRow{
 		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
 		UserID:    userID,
-		Token:     refreshToken,
+		TokenHash: tokenHash,
 		ExpiresAt: pgExpiresAt,
+		SessionID: sessionID,
 	}
 
 	return nil
 }
 
-func (m *MockDBStorage) GetToken(_ context.Context, token string) (db.GetRefreshTokenRow, error) {
+func (m *MockDBStorage) GetToken(_ context.Context, tokenHash string) (db.GetRefreshTokenRow, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	row, exists := m.tokens[token]
+	row, exists := m.tokens[tokenHash]
 	if !exists {
 		return db.GetRefreshTokenRow{}, errors.New("token not found")
 	}
@@ -168,10 +421,38 @@ func (m *MockDBStorage) GetToken(_ context.Context, token string) (db.GetRefresh
 	return row, nil
 }
 
-func (m *MockDBStorage) StoreCard(_ context.Context, createCard db.StoreCardParams) (*db.Card, error) {
+func (m *MockDBStorage) UseToken(_ context.Context, tokenHash string, sessionID pgtype.UUID) (bool, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	row, exists := m.tokens[tokenHash]
+	if !exists || row.UsedAt.Valid {
+		return false, nil
+	}
+
+	row.UsedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+	row.SessionID = sessionID
+	m.tokens[tokenHash] = row
+
+	return true, nil
+}
+
+func (m *MockDBStorage) StoreCard(
+	_ context.Context,
+	createCard db.StoreCardParams,
+	collectionID pgtype.UUID,
+) (*db.Card, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkNewItem(createCard.ID, collectionID); err != nil {
+		return nil, err
+	}
+
 	for _, card := range m.cards {
 		if card.HashedCardNumber == createCard.HashedCardNumber {
 			return nil, errors.New("already encrypted card")
@@ -179,13 +460,15 @@ func (m *MockDBStorage) StoreCard(_ context.Context, createCard db.StoreCardPara
 	}
 
 	card := db.Card{
-		ID:                  pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		ID:                  itemID(createCard.ID),
 		UserID:              createCard.UserID,
 		EncryptedCardNumber: createCard.EncryptedCardNumber,
 		HashedCardNumber:    createCard.HashedCardNumber,
 		EncryptedCvv:        createCard.EncryptedCvv,
 		EncryptedExpiryDate: createCard.EncryptedExpiryDate,
 		CardholderName:      createCard.CardholderName,
+		Version:             1,
+		KeyVersion:          createCard.KeyVersion,
 		CreatedAt:           pgtype.Timestamp{Time: time.Now(), Valid: true},
 		UpdatedAt:           pgtype.Timestamp{Time: time.Now(), Valid: true},
 	}
@@ -195,6 +478,8 @@ func (m *MockDBStorage) StoreCard(_ context.Context, createCard db.StoreCardPara
 		m.cards = make(map[string]db.Card)
 	}
 	m.cards[card.ID.String()] = card
+	m.linkItem(card.ID, card.UserID, db.ItemTypeCard)
+	m.addCollectionItem(card.ID, collectionID)
 
 	return &card, nil
 }
@@ -213,14 +498,23 @@ func (m *MockDBStorage) UpdateCard(_ context.Context, updateCard db.UpdateCardPa
 		return nil, errors.New("card not found")
 	}
 
+	// Mirror the WHERE clause of the real query: a foreign or stale row matches nothing.
+	if card.UserID != updateCard.UserID ||
+		(updateCard.ExpectedVersion != 0 && card.Version != updateCard.ExpectedVersion) {
+		return nil, errors.Wrap(pgx.ErrNoRows, "failed to update card")
+	}
+
 	card.EncryptedCardNumber = updateCard.EncryptedCardNumber
 	card.HashedCardNumber = updateCard.HashedCardNumber
 	card.EncryptedCvv = updateCard.EncryptedCvv
 	card.EncryptedExpiryDate = updateCard.EncryptedExpiryDate
 	card.CardholderName = updateCard.CardholderName
+	card.KeyVersion = updateCard.KeyVersion
+	card.Version++
 	card.UpdatedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
 
 	m.cards[id] = card
+	m.recordChange(card.UserID, card.ID, db.ItemTypeCard, false)
 
 	return &card, nil
 }
@@ -245,29 +539,57 @@ func (m *MockDBStorage) GetCard(_ context.Context, cardID string, userID pgtype.
 	return &card, nil
 }
 
-func (m *MockDBStorage) GetCards(_ context.Context, userID string) ([]db.Card, error) {
+func (m *MockDBStorage) GetCards(_ context.Context, params db.GetCardsByUserIDParams) ([]db.Card, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var result []db.Card
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.Card, 0)
 	for _, card := range m.cards {
-		if card.UserID.Valid && card.UserID.String() == userID {
+		if card.UserID == params.UserID {
 			result = append(result, card)
 		}
 	}
 
-	return result, nil
+	sortByCreation(result, func(card db.Card) (pgtype.Timestamp, pgtype.UUID) {
+		return card.CreatedAt, card.ID
+	})
+
+	return paginate(result, params.Limit, params.Offset), nil
+}
+
+func (m *MockDBStorage) CountCards(_ context.Context, userID pgtype.UUID) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return 0, m.CallError
+	}
+
+	var count int64
+	for _, card := range m.cards {
+		if card.UserID == userID {
+			count++
+		}
+	}
+
+	return count, nil
 }
 
 func (m *MockDBStorage) DeleteCard(_ context.Context, cardID string, _ pgtype.UUID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if _, found := m.cards[cardID]; !found {
+	card, found := m.cards[cardID]
+	if !found {
 		return errors.New("card not found")
 	}
 
 	delete(m.cards, cardID)
+	m.recordChange(card.UserID, card.ID, db.ItemTypeCard, true)
 
 	return nil
 }
@@ -275,6 +597,7 @@ func (m *MockDBStorage) DeleteCard(_ context.Context, cardID string, _ pgtype.UU
 // StoreBinary stores a binary entry in the mock storage.
 func (m *MockDBStorage) StoreBinary(_ context.Context,
 	createBinary db.StoreBinaryEntryParams,
+	collectionID pgtype.UUID,
 ) (*db.BinaryEntry, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -283,12 +606,22 @@ func (m *MockDBStorage) StoreBinary(_ context.Context,
 		return nil, errors.New("invalid user ID")
 	}
 
+	if err := m.checkNewItem(createBinary.ID, collectionID); err != nil {
+		return nil, err
+	}
+
 	binary := db.BinaryEntry{
-		ID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
-		UserID:   createBinary.UserID,
-		FileName: createBinary.FileName,
-		FileSize: createBinary.FileSize,
-		FileUrl:  createBinary.FileUrl,
+		ID:         itemID(createBinary.ID),
+		UserID:     createBinary.UserID,
+		FileName:   createBinary.FileName,
+		FileSize:   createBinary.FileSize,
+		FileUrl:    createBinary.FileUrl,
+		Version:    1,
+		KeyVersion: createBinary.KeyVersion,
+		CreatedAt: pgtype.Timestamp{
+			Time:  time.Now(),
+			Valid: true,
+		},
 		UpdatedAt: pgtype.Timestamp{
 			Time:  time.Now(),
 			Valid: true,
@@ -296,6 +629,8 @@ func (m *MockDBStorage) StoreBinary(_ context.Context,
 	}
 
 	m.binaries[binary.ID.String()] = binary
+	m.linkItem(binary.ID, binary.UserID, db.ItemTypeBinary)
+	m.addCollectionItem(binary.ID, collectionID)
 
 	return &binary, nil
 }
@@ -327,23 +662,53 @@ func (m *MockDBStorage) DeleteBinary(_ context.Context, arg db.DeleteBinaryEntry
 	}
 
 	delete(m.binaries, arg.ID.String())
+	m.recordChange(binary.UserID, binary.ID, db.ItemTypeBinary, true)
 
 	return nil
 }
 
-// GetBinaries returns all binaries for a user.
-func (m *MockDBStorage) GetBinaries(_ context.Context, userID string) ([]db.BinaryEntry, error) {
+// GetBinaries returns a page of binaries for a user.
+func (m *MockDBStorage) GetBinaries(_ context.Context,
+	params db.GetBinaryEntriesByUserIDParams,
+) ([]db.BinaryEntry, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var result []db.BinaryEntry
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.BinaryEntry, 0)
 	for _, binary := range m.binaries {
-		if binary.UserID.Valid && binary.UserID.String() == userID {
+		if binary.UserID == params.UserID {
 			result = append(result, binary)
 		}
 	}
 
-	return result, nil
+	sortByCreation(result, func(binary db.BinaryEntry) (pgtype.Timestamp, pgtype.UUID) {
+		return binary.CreatedAt, binary.ID
+	})
+
+	return paginate(result, params.Limit, params.Offset), nil
+}
+
+// CountBinaries returns the number of binaries for a user.
+func (m *MockDBStorage) CountBinaries(_ context.Context, userID pgtype.UUID) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return 0, m.CallError
+	}
+
+	var count int64
+	for _, binary := range m.binaries {
+		if binary.UserID == userID {
+			count++
+		}
+	}
+
+	return count, nil
 }
 
 // GetBinary retrieves a specific binary entry.
@@ -381,6 +746,22 @@ func (m *MockDBStorage) GetItems(_ context.Context,
 		}
 	}
 
+	// Items shared with the user, marked with their owner
+	for _, share := range m.shares {
+		item, exists := m.items[share.ItemID.String()]
+		if share.RecipientID != params.UserID || !exists {
+			continue
+		}
+
+		result = append(result, db.GetItemsByUserIDRow{
+			IDResource: item.ID,
+			Type:       item.Type,
+			CreatedAt:  item.CreatedAt,
+			Owner:      m.UsersByID[item.UserID].Username,
+			ReadOnly:   share.ReadOnly,
+		})
+	}
+
 	// Apply pagination
 	start := int(params.Offset)
 	end := start + int(params.Limit)
@@ -458,6 +839,7 @@ func (m *MockDBStorage) AddMeta(_ context.Context, itemID string, key string, va
 	}
 
 	m.metaInfo[itemID][key] = value
+	m.touchChange(itemID)
 
 	return &db.Metainfo{
 		ID:     pgtype.UUID{Bytes: uuid.New(), Valid: true},
@@ -484,6 +866,7 @@ func (m *MockDBStorage) DeleteMetaInfo(_ context.Context, key string, itemID str
 	}
 
 	delete(m.metaInfo[itemID], key)
+	m.touchChange(itemID)
 
 	return nil
 }
@@ -513,7 +896,11 @@ func (m *MockDBStorage) GetMetaInfo(_ context.Context, itemID string) ([]db.GetM
 }
 
 // StoreNote Add these new methods to MockDBStorage.
-func (m *MockDBStorage) StoreNote(_ context.Context, params db.CreateNoteEntryParams) (*db.Note, error) {
+func (m *MockDBStorage) StoreNote(
+	_ context.Context,
+	params db.CreateNoteEntryParams,
+	collectionID pgtype.UUID,
+) (*db.Note, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -521,15 +908,23 @@ func (m *MockDBStorage) StoreNote(_ context.Context, params db.CreateNoteEntryPa
 		return nil, m.CallError
 	}
 
+	if err := m.checkNewItem(params.ID, collectionID); err != nil {
+		return nil, err
+	}
+
 	note := db.Note{
-		ID:               pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		ID:               itemID(params.ID),
 		UserID:           params.UserID,
 		EncryptedContent: params.EncryptedContent,
+		Version:          1,
+		KeyVersion:       params.KeyVersion,
 		CreatedAt:        pgtype.Timestamp{Time: time.Now(), Valid: true},
 		UpdatedAt:        pgtype.Timestamp{Time: time.Now(), Valid: true},
 	}
 
 	m.notes[note.ID.String()] = note
+	m.linkItem(note.ID, note.UserID, db.ItemTypeText)
+	m.addCollectionItem(note.ID, collectionID)
 
 	return &note, nil
 }
@@ -550,18 +945,44 @@ func (m *MockDBStorage) GetNote(_ context.Context, noteID string, userID pgtype.
 	return &note, nil
 }
 
-func (m *MockDBStorage) GetNotes(_ context.Context, userID pgtype.UUID) ([]db.Note, error) {
+func (m *MockDBStorage) GetNotes(_ context.Context, params db.GetNotesByUserIDParams) ([]db.Note, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var result []db.Note
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.Note, 0)
 	for _, note := range m.notes {
-		if note.UserID == userID {
+		if note.UserID == params.UserID {
 			result = append(result, note)
 		}
 	}
 
-	return result, nil
+	sortByCreation(result, func(note db.Note) (pgtype.Timestamp, pgtype.UUID) {
+		return note.CreatedAt, note.ID
+	})
+
+	return paginate(result, params.Limit, params.Offset), nil
+}
+
+func (m *MockDBStorage) CountNotes(_ context.Context, userID pgtype.UUID) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return 0, m.CallError
+	}
+
+	var count int64
+	for _, note := range m.notes {
+		if note.UserID == userID {
+			count++
+		}
+	}
+
+	return count, nil
 }
 
 func (m *MockDBStorage) DeleteNote(_ context.Context, noteID string, userID pgtype.UUID) error {
@@ -582,12 +1003,17 @@ func (m *MockDBStorage) DeleteNote(_ context.Context, noteID string, userID pgty
 	}
 
 	delete(m.notes, noteID)
+	m.recordChange(note.UserID, note.ID, db.ItemTypeText, true)
 
 	return nil
 }
 
 // StorePassword Add these new methods to MockDBStorage.
-func (m *MockDBStorage) StorePassword(_ context.Context, params db.CreatePasswordEntryParams) (*db.Password, error) {
+func (m *MockDBStorage) StorePassword(
+	_ context.Context,
+	params db.CreatePasswordEntryParams,
+	collectionID pgtype.UUID,
+) (*db.Password, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -595,16 +1021,24 @@ func (m *MockDBStorage) StorePassword(_ context.Context, params db.CreatePasswor
 		return nil, m.CallError
 	}
 
+	if err := m.checkNewItem(params.ID, collectionID); err != nil {
+		return nil, err
+	}
+
 	password := db.Password{
-		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
-		UserID:    params.UserID,
-		Login:     params.Login,
-		Password:  params.Password,
-		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
-		UpdatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		ID:         itemID(params.ID),
+		UserID:     params.UserID,
+		Login:      params.Login,
+		Password:   params.Password,
+		Version:    1,
+		KeyVersion: params.KeyVersion,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+		UpdatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
 	}
 
 	m.passwords[password.ID.String()] = password
+	m.linkItem(password.ID, password.UserID, db.ItemTypePassword)
+	m.addCollectionItem(password.ID, collectionID)
 
 	return &password, nil
 }
@@ -625,6 +1059,48 @@ func (m *MockDBStorage) GetPassword(_ context.Context, passwordID string, userID
 	return &password, nil
 }
 
+func (m *MockDBStorage) GetPasswords(_ context.Context,
+	params db.GetPasswordEntriesByUserIDParams,
+) ([]db.Password, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.Password, 0)
+	for _, password := range m.passwords {
+		if password.UserID == params.UserID {
+			result = append(result, password)
+		}
+	}
+
+	sortByCreation(result, func(password db.Password) (pgtype.Timestamp, pgtype.UUID) {
+		return password.CreatedAt, password.ID
+	})
+
+	return paginate(result, params.Limit, params.Offset), nil
+}
+
+func (m *MockDBStorage) CountPasswords(_ context.Context, userID pgtype.UUID) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return 0, m.CallError
+	}
+
+	var count int64
+	for _, password := range m.passwords {
+		if password.UserID == userID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
 func (m *MockDBStorage) UpdatePassword(_ context.Context, params db.UpdatePasswordEntryParams) (*db.Password, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -639,11 +1115,20 @@ func (m *MockDBStorage) UpdatePassword(_ context.Context, params db.UpdatePasswo
 		return nil, errors.New("password not found")
 	}
 
+	// Mirror the WHERE clause of the real query: a foreign or stale row matches nothing.
+	if password.UserID != params.UserID ||
+		(params.ExpectedVersion != 0 && password.Version != params.ExpectedVersion) {
+		return nil, errors.Wrap(pgx.ErrNoRows, "failed to update password")
+	}
+
 	password.Login = params.Login
 	password.Password = params.Password
+	password.KeyVersion = params.KeyVersion
+	password.Version++
 	password.UpdatedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
 
 	m.passwords[id] = password
+	m.recordChange(password.UserID, password.ID, db.ItemTypePassword, false)
 
 	return &password, nil
 }
@@ -666,10 +1151,221 @@ func (m *MockDBStorage) DeletePassword(_ context.Context, passwordID string, use
 	}
 
 	delete(m.passwords, passwordID)
+	m.recordChange(password.UserID, password.ID, db.ItemTypePassword, true)
 
 	return nil
 }
 
+// linkItem mirrors the database triggers that register every stored record in the items table.
+func (m *MockDBStorage) linkItem(resourceID, userID pgtype.UUID, itemType db.ItemType) {
+	m.items[resourceID.String()] = db.Item{
+		ID:         resourceID,
+		UserID:     userID,
+		Type:       itemType,
+		IDResource: resourceID,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+	}
+	m.recordChange(userID, resourceID, itemType, false)
+}
+
+// recordChange mirrors the change tracking triggers: it issues the next per-user sequence number
+// and keeps only the latest change of every item.
+func (m *MockDBStorage) recordChange(userID, itemID pgtype.UUID, itemType db.ItemType, deleted bool) {
+	m.changeSeq[userID]++
+
+	m.changes[itemID.String()] = db.ItemChange{
+		UserID:    userID,
+		ItemID:    itemID,
+		Type:      itemType,
+		Seq:       m.changeSeq[userID],
+		Deleted:   deleted,
+		ChangedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	}
+}
+
+// touchChange records a metadata change as an update of the owning item unless it was deleted.
+func (m *MockDBStorage) touchChange(itemID string) {
+	change, exists := m.changes[itemID]
+	if !exists || change.Deleted {
+		return
+	}
+
+	m.recordChange(change.UserID, change.ItemID, change.Type, false)
+}
+
+// itemUpdatedAt resolves the update time of the record behind an item, like the items join does.
+func (m *MockDBStorage) itemUpdatedAt(item db.Item) pgtype.Timestamp {
+	resourceID := item.ID.String()
+
+	switch item.Type {
+	case db.ItemTypePassword:
+		return m.passwords[resourceID].UpdatedAt
+	case db.ItemTypeText:
+		return m.notes[resourceID].UpdatedAt
+	case db.ItemTypeCard:
+		return m.cards[resourceID].UpdatedAt
+	case db.ItemTypeBinary:
+		return m.binaries[resourceID].UpdatedAt
+	}
+
+	return pgtype.Timestamp{}
+}
+
+func (m *MockDBStorage) ItemExists(_ context.Context, id pgtype.UUID) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	_, exists := m.items[id.String()]
+
+	return exists, nil
+}
+
+func (m *MockDBStorage) GetItemsByResourceIDs(_ context.Context,
+	params db.GetItemsByResourceIDsParams,
+) ([]db.GetItemsByResourceIDsRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.GetItemsByResourceIDsRow, 0)
+	for _, id := range params.ResourceIds {
+		item, exists := m.items[id.String()]
+		if !exists || item.UserID != params.UserID {
+			continue
+		}
+
+		result = append(result, db.GetItemsByResourceIDsRow{
+			ID:         item.ID,
+			Type:       item.Type,
+			IDResource: item.ID,
+			CreatedAt:  item.CreatedAt,
+			UpdatedAt:  m.itemUpdatedAt(item),
+		})
+	}
+
+	return result, nil
+}
+
+func (m *MockDBStorage) GetItemsChangedSince(_ context.Context,
+	params db.GetItemsChangedSinceParams,
+) ([]db.GetItemsChangedSinceRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.GetItemsChangedSinceRow, 0)
+	for _, item := range m.items {
+		updatedAt := m.itemUpdatedAt(item)
+		if item.UserID != params.UserID || !updatedAt.Time.After(params.ChangedSince.Time) {
+			continue
+		}
+
+		result = append(result, db.GetItemsChangedSinceRow{
+			ID:         item.ID,
+			Type:       item.Type,
+			IDResource: item.ID,
+			CreatedAt:  item.CreatedAt,
+			UpdatedAt:  updatedAt,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b db.GetItemsChangedSinceRow) int {
+		if c := a.UpdatedAt.Time.Compare(b.UpdatedAt.Time); c != 0 {
+			return c
+		}
+
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+
+	return result, nil
+}
+
+func (m *MockDBStorage) GetPasswordsByIDs(_ context.Context,
+	params db.GetPasswordEntriesByIDsParams,
+) ([]db.Password, error) {
+	return collectByIDs(m, m.passwords, params.UserID, params.Ids, func(p db.Password) pgtype.UUID { return p.UserID })
+}
+
+func (m *MockDBStorage) GetNotesByIDs(_ context.Context, params db.GetNotesByIDsParams) ([]db.Note, error) {
+	return collectByIDs(m, m.notes, params.UserID, params.Ids, func(n db.Note) pgtype.UUID { return n.UserID })
+}
+
+func (m *MockDBStorage) GetCardsByIDs(_ context.Context, params db.GetCardsByIDsParams) ([]db.Card, error) {
+	return collectByIDs(m, m.cards, params.UserID, params.Ids, func(c db.Card) pgtype.UUID { return c.UserID })
+}
+
+func (m *MockDBStorage) GetBinariesByIDs(_ context.Context,
+	params db.GetBinaryEntriesByIDsParams,
+) ([]db.BinaryEntry, error) {
+	return collectByIDs(m, m.binaries, params.UserID, params.Ids, func(b db.BinaryEntry) pgtype.UUID { return b.UserID })
+}
+
+func (m *MockDBStorage) GetMetaInfoByItemIDs(_ context.Context,
+	itemIDs []pgtype.UUID,
+) ([]db.GetMetaInfoByItemIDsRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.GetMetaInfoByItemIDsRow, 0)
+	for _, id := range itemIDs {
+		for key, value := range m.metaInfo[id.String()] {
+			result = append(result, db.GetMetaInfoByItemIDsRow{
+				ItemID: id,
+				Key:    key,
+				Value:  value,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func (m *MockDBStorage) GetItemChanges(_ context.Context,
+	params db.GetItemChangesSinceParams,
+) ([]db.GetItemChangesSinceRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]db.GetItemChangesSinceRow, 0)
+	for _, change := range m.changes {
+		if change.UserID != params.UserID || change.Seq <= params.SinceSeq {
+			continue
+		}
+
+		result = append(result, db.GetItemChangesSinceRow{
+			ItemID:    change.ItemID,
+			Type:      change.Type,
+			Seq:       change.Seq,
+			Deleted:   change.Deleted,
+			ChangedAt: change.ChangedAt,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b db.GetItemChangesSinceRow) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	return paginate(result, params.MaxChanges, 0), nil
+}
+
 func (m *MockDBStorage) ClearTestData() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -683,6 +1379,125 @@ func (m *MockDBStorage) ClearTestData() {
 	m.metaInfo = make(map[string]map[string]string)
 	m.notes = make(map[string]db.Note)
 	m.passwords = make(map[string]db.Password)
+	m.changes = make(map[string]db.ItemChange)
+	m.changeSeq = make(map[pgtype.UUID]int64)
+	m.rotations = make(map[string]db.KeyRotation)
+	m.recovery = make(map[pgtype.UUID]map[string]bool)
+	m.sessions = make(map[pgtype.UUID]db.Session)
+	m.apiTokens = make(map[pgtype.UUID]db.ApiToken)
+	m.shareKeys = make(map[pgtype.UUID]db.ShareKey)
+	m.shares = make(map[pgtype.UUID]db.Share)
+	m.organizations = make(map[pgtype.UUID]db.Organization)
+	m.orgMembers = make(map[membershipKey]db.OrgMember)
+	m.collections = make(map[pgtype.UUID]db.Collection)
+	m.collectionMembers = make(map[membershipKey]db.CollectionMember)
+	m.collectionItems = make(map[pgtype.UUID]db.CollectionItem)
+	m.emergencyAccess = make(map[pgtype.UUID]db.EmergencyAccess)
+	m.sends = make(map[pgtype.UUID]db.Send)
+	m.auditEvents = nil
 
 	m.CallError = nil
 }
+
+// sortByCreation orders rows the same way the list queries do: newest first, then by ID.
+func sortByCreation[T any](rows []T, key func(T) (pgtype.Timestamp, pgtype.UUID)) {
+	slices.SortFunc(rows, func(a, b T) int {
+		aCreated, aID := key(a)
+		bCreated, bID := key(b)
+
+		if c := bCreated.Time.Compare(aCreated.Time); c != 0 {
+			return c
+		}
+
+		return bytes.Compare(aID.Bytes[:], bID.Bytes[:])
+	})
+}
+
+// paginate applies SQL-style LIMIT/OFFSET semantics to rows.
+func paginate[T any](rows []T, limit, offset int32) []T {
+	start := min(int(offset), len(rows))
+	end := min(start+int(limit), len(rows))
+
+	return rows[start:end]
+}
+
+// collectByIDs returns the records with the given IDs that belong to the user.
+func collectByIDs[T any](
+	m *MockDBStorage,
+	records map[string]T,
+	userID pgtype.UUID,
+	ids []pgtype.UUID,
+	owner func(T) pgtype.UUID,
+) ([]T, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	result := make([]T, 0, len(ids))
+	for _, id := range ids {
+		record, exists := records[id.String()]
+		if exists && owner(record) == userID {
+			result = append(result, record)
+		}
+	}
+
+	return result, nil
+}
+
+func (m *MockDBStorage) ListSigningKeys(_ context.Context) ([]db.SigningKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	keys := slices.Collect(maps.Values(m.signingKeys))
+	slices.SortFunc(keys, func(a, b db.SigningKey) int {
+		return b.ActivatesAt.Time.Compare(a.ActivatesAt.Time)
+	})
+
+	return keys, nil
+}
+
+func (m *MockDBStorage) CreateSigningKey(_ context.Context, params db.CreateSigningKeyParams) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	for _, key := range m.signingKeys {
+		if key.ActivatesAt.Time.After(params.Latest.Time) {
+			return false, nil
+		}
+	}
+
+	m.signingKeys[params.ID] = db.SigningKey{
+		ID:          params.ID,
+		Algorithm:   params.Algorithm,
+		PrivateKey:  params.PrivateKey,
+		PublicKey:   params.PublicKey,
+		CreatedAt:   pgtype.Timestamp{Time: time.Now(), Valid: true},
+		ActivatesAt: params.ActivatesAt,
+	}
+
+	return true, nil
+}
+
+func (m *MockDBStorage) DeleteSigningKey(_ context.Context, keyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return m.CallError
+	}
+
+	delete(m.signingKeys, keyID)
+
+	return nil
+}
@@ -40,6 +40,12 @@ func (m *MockTokenManager) IsAuthorized() bool {
 	return m.Authorized
 }
 
+func (m *MockTokenManager) ClearTokens() error {
+	m.Authorized = false
+
+	return m.Called().Error(0)
+}
+
 func (m *MockTokenManager) HandleAuthFailure() {
 	m.Authorized = false
 	m.Called()
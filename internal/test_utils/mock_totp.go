@@ -0,0 +1,165 @@
+package testutils
+
+import (
+	"bytes"
+	"context"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/npavlov/go-password-manager/internal/server/db"
+)
+
+// SetTOTPSecret mock implementation.
+func (m *MockDBStorage) SetTOTPSecret(_ context.Context, userID pgtype.UUID, secret string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	user, exists := m.UsersByID[userID]
+	if !exists || user.TotpEnabled {
+		return false, nil
+	}
+
+	user.TotpSecret = pgtype.Text{String: secret, Valid: true}
+	m.saveUser(user)
+
+	return true, nil
+}
+
+// EnableTOTP mock implementation.
+func (m *MockDBStorage) EnableTOTP(
+	_ context.Context,
+	userID pgtype.UUID,
+	secret string,
+	step int64,
+	codeHashes []string,
+) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	user, exists := m.UsersByID[userID]
+	if !exists || user.TotpEnabled || user.TotpSecret.String != secret {
+		return false, nil
+	}
+
+	user.TotpEnabled = true
+	user.TotpLastStep = step
+	m.saveUser(user)
+
+	m.recovery[userID] = make(map[string]bool)
+	for _, hash := range codeHashes {
+		m.recovery[userID][hash] = false
+	}
+
+	return true, nil
+}
+
+// UseTOTPStep mock implementation.
+func (m *MockDBStorage) UseTOTPStep(_ context.Context, userID pgtype.UUID, step int64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	user, exists := m.UsersByID[userID]
+	if !exists || !user.TotpEnabled || user.TotpLastStep >= step {
+		return false, nil
+	}
+
+	user.TotpLastStep = step
+	m.saveUser(user)
+
+	return true, nil
+}
+
+// UseRecoveryCode mock implementation.
+func (m *MockDBStorage) UseRecoveryCode(_ context.Context, userID pgtype.UUID, codeHash string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	used, exists := m.recovery[userID][codeHash]
+	if !exists || used {
+		return false, nil
+	}
+
+	m.recovery[userID][codeHash] = true
+
+	return true, nil
+}
+
+// ListTOTPSecrets mock implementation.
+func (m *MockDBStorage) ListTOTPSecrets(
+	_ context.Context,
+	afterID pgtype.UUID,
+	batchSize int32,
+) ([]db.ListTOTPSecretsAfterRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.CallError != nil {
+		return nil, m.CallError
+	}
+
+	var rows []db.ListTOTPSecretsAfterRow
+	for _, user := range m.UsersByID {
+		if !user.TotpSecret.Valid || bytes.Compare(user.ID.Bytes[:], afterID.Bytes[:]) <= 0 {
+			continue
+		}
+
+		rows = append(rows, db.ListTOTPSecretsAfterRow{ID: user.ID, TotpSecret: user.TotpSecret.String})
+	}
+
+	slices.SortFunc(rows, func(a, b db.ListTOTPSecretsAfterRow) int {
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+
+	if len(rows) > int(batchSize) {
+		rows = rows[:batchSize]
+	}
+
+	return rows, nil
+}
+
+// RewrapTOTPSecret mock implementation.
+func (m *MockDBStorage) RewrapTOTPSecret(
+	_ context.Context,
+	userID pgtype.UUID,
+	oldSecret, newSecret string,
+) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CallError != nil {
+		return false, m.CallError
+	}
+
+	user, exists := m.UsersByID[userID]
+	if !exists || user.TotpSecret.String != oldSecret {
+		return false, nil
+	}
+
+	user.TotpSecret = pgtype.Text{String: newSecret, Valid: true}
+	m.saveUser(user)
+
+	return true, nil
+}
+
+// saveUser stores a changed user in both indexes; the caller holds the lock.
+func (m *MockDBStorage) saveUser(user db.User) {
+	m.UsersByID[user.ID] = user
+	m.usersByName[user.Username] = user
+}
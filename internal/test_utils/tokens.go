@@ -0,0 +1,35 @@
+package testutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/npavlov/go-password-manager/internal/server/jwtkeys"
+	"github.com/npavlov/go-password-manager/internal/server/kms"
+	"github.com/npavlov/go-password-manager/internal/server/service/utils"
+	secure "github.com/npavlov/go-password-manager/internal/utils"
+)
+
+// NewTokenKeys creates access token signing keys kept in a mock storage, with an EdDSA key signing.
+func NewTokenKeys(t *testing.T) *jwtkeys.Keys {
+	t.Helper()
+
+	masterKey, err := utils.GenerateRandomKey()
+	require.NoError(t, err)
+
+	keys := jwtkeys.New(NewMockDBStorage(GetTLogger(), masterKey), kms.NewLocalProvider(secure.NewString(masterKey)),
+		jwtkeys.Options{
+			Algorithm:    jwtkeys.AlgEdDSA,
+			Issuer:       "test-issuer",
+			Audience:     "test-audience",
+			Rotation:     24 * time.Hour,
+			Refresh:      time.Minute,
+			Retention:    time.Hour,
+			LegacySecret: "",
+		}, GetTLogger())
+	require.NoError(t, keys.Start(t.Context()))
+
+	return keys
+}
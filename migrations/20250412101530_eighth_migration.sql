@@ -0,0 +1,121 @@
+-- +goose Up
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "change_seq" bigint NOT NULL DEFAULT 0;
+-- create "item_changes" table
+CREATE TABLE "item_changes" (
+  "user_id" uuid NOT NULL,
+  "item_id" uuid NOT NULL,
+  "type" "item_type" NOT NULL,
+  "seq" bigint NOT NULL,
+  "deleted" boolean NOT NULL DEFAULT false,
+  "changed_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id", "item_id"),
+  CONSTRAINT "item_changes_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create index "idx_item_changes_user_seq" to table: "item_changes"
+CREATE UNIQUE INDEX "idx_item_changes_user_seq" ON "item_changes" ("user_id", "seq");
+
+-- backfill: every existing item becomes a change in creation order
+INSERT INTO "item_changes" ("user_id", "item_id", "type", "seq")
+SELECT user_id, id_resource, type, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at, id)
+FROM items
+WHERE user_id IS NOT NULL;
+
+UPDATE "users" u
+SET change_seq = COALESCE((SELECT MAX(c.seq) FROM item_changes c WHERE c.user_id = u.id), 0);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_item_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    rec RECORD;
+    next_seq BIGINT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        rec := OLD;
+    ELSE
+        rec := NEW;
+    END IF;
+
+    -- The row lock on users serialises concurrent writers of the same user.
+    UPDATE users SET change_seq = change_seq + 1 WHERE id = rec.user_id RETURNING change_seq INTO next_seq;
+
+    INSERT INTO item_changes (user_id, item_id, type, seq, deleted)
+    VALUES (rec.user_id, rec.id, TG_ARGV[0]::item_type, next_seq, TG_OP = 'DELETE')
+    ON CONFLICT (user_id, item_id) DO UPDATE
+        SET seq = EXCLUDED.seq, deleted = EXCLUDED.deleted, changed_at = CURRENT_TIMESTAMP;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_meta_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    target UUID;
+    owner UUID;
+    next_seq BIGINT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target := OLD.item_id;
+    ELSE
+        target := NEW.item_id;
+    END IF;
+
+    SELECT user_id INTO owner FROM items WHERE id_resource = target;
+    IF owner IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    UPDATE users SET change_seq = change_seq + 1 WHERE id = owner RETURNING change_seq INTO next_seq;
+
+    -- Metadata of deleted items must not resurrect their tombstones.
+    UPDATE item_changes SET seq = next_seq, changed_at = CURRENT_TIMESTAMP
+    WHERE user_id = owner AND item_id = target AND NOT deleted;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER record_password_change
+    AFTER INSERT OR UPDATE OR DELETE ON passwords
+    FOR EACH ROW
+    EXECUTE FUNCTION record_item_change('password');
+
+CREATE TRIGGER record_note_change
+    AFTER INSERT OR UPDATE OR DELETE ON notes
+    FOR EACH ROW
+    EXECUTE FUNCTION record_item_change('text');
+
+CREATE TRIGGER record_card_change
+    AFTER INSERT OR UPDATE OR DELETE ON cards
+    FOR EACH ROW
+    EXECUTE FUNCTION record_item_change('card');
+
+CREATE TRIGGER record_binary_change
+    AFTER INSERT OR UPDATE OR DELETE ON binary_entries
+    FOR EACH ROW
+    EXECUTE FUNCTION record_item_change('binary');
+
+CREATE TRIGGER record_meta_change
+    AFTER INSERT OR UPDATE OR DELETE ON metainfo
+    FOR EACH ROW
+    EXECUTE FUNCTION record_meta_change();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS record_meta_change ON metainfo;
+DROP TRIGGER IF EXISTS record_binary_change ON binary_entries;
+DROP TRIGGER IF EXISTS record_card_change ON cards;
+DROP TRIGGER IF EXISTS record_note_change ON notes;
+DROP TRIGGER IF EXISTS record_password_change ON passwords;
+DROP FUNCTION IF EXISTS record_meta_change();
+DROP FUNCTION IF EXISTS record_item_change();
+-- reverse: create index "idx_item_changes_user_seq" to table: "item_changes"
+DROP INDEX "idx_item_changes_user_seq";
+-- reverse: create "item_changes" table
+DROP TABLE "item_changes";
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "change_seq";
@@ -0,0 +1,19 @@
+-- +goose Up
+-- modify "passwords" table
+ALTER TABLE "passwords" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+-- modify "cards" table
+ALTER TABLE "cards" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+-- modify "notes" table
+ALTER TABLE "notes" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+-- modify "binary_entries" table
+ALTER TABLE "binary_entries" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+-- +goose Down
+-- reverse: modify "binary_entries" table
+ALTER TABLE "binary_entries" DROP COLUMN "version";
+-- reverse: modify "notes" table
+ALTER TABLE "notes" DROP COLUMN "version";
+-- reverse: modify "cards" table
+ALTER TABLE "cards" DROP COLUMN "version";
+-- reverse: modify "passwords" table
+ALTER TABLE "passwords" DROP COLUMN "version";
@@ -0,0 +1,7 @@
+-- +goose Up
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "kdf_salt" text NULL, ADD COLUMN "wrapped_vault_key" text NULL;
+
+-- +goose Down
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "wrapped_vault_key", DROP COLUMN "kdf_salt";
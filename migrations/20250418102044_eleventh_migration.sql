@@ -0,0 +1,48 @@
+-- +goose Up
+-- create enum type "key_rotation_status"
+CREATE TYPE "key_rotation_status" AS ENUM ('running', 'completed', 'failed');
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "key_version" integer NOT NULL DEFAULT 1, ADD COLUMN "previous_encryption_key" text NULL;
+-- modify "passwords" table
+ALTER TABLE "passwords" ADD COLUMN "key_version" integer NOT NULL DEFAULT 1;
+-- modify "cards" table
+ALTER TABLE "cards" ADD COLUMN "key_version" integer NOT NULL DEFAULT 1;
+-- modify "notes" table
+ALTER TABLE "notes" ADD COLUMN "key_version" integer NOT NULL DEFAULT 1;
+-- modify "binary_entries" table
+ALTER TABLE "binary_entries" ADD COLUMN "key_version" integer NOT NULL DEFAULT 1;
+-- create "key_rotations" table
+CREATE TABLE "key_rotations" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL,
+  "from_version" integer NOT NULL,
+  "to_version" integer NOT NULL,
+  "status" "key_rotation_status" NOT NULL DEFAULT 'running',
+  "total_items" bigint NOT NULL DEFAULT 0,
+  "done_items" bigint NOT NULL DEFAULT 0,
+  "error" text NULL,
+  "started_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "finished_at" timestamp NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "key_rotations_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create index "idx_key_rotations_user_started" to table: "key_rotations"
+CREATE INDEX "idx_key_rotations_user_started" ON "key_rotations" ("user_id", "started_at");
+
+-- +goose Down
+-- reverse: create index "idx_key_rotations_user_started" to table: "key_rotations"
+DROP INDEX "idx_key_rotations_user_started";
+-- reverse: create "key_rotations" table
+DROP TABLE "key_rotations";
+-- reverse: modify "binary_entries" table
+ALTER TABLE "binary_entries" DROP COLUMN "key_version";
+-- reverse: modify "notes" table
+ALTER TABLE "notes" DROP COLUMN "key_version";
+-- reverse: modify "cards" table
+ALTER TABLE "cards" DROP COLUMN "key_version";
+-- reverse: modify "passwords" table
+ALTER TABLE "passwords" DROP COLUMN "key_version";
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "previous_encryption_key", DROP COLUMN "key_version";
+-- reverse: create enum type "key_rotation_status"
+DROP TYPE "key_rotation_status";
@@ -0,0 +1,15 @@
+-- +goose Up
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "legacy_ciphertexts" boolean NOT NULL DEFAULT false;
+-- existing accounts may hold values encrypted before ciphertexts were bound to their records
+UPDATE "users" SET "legacy_ciphertexts" = true WHERE "wrapped_vault_key" IS NULL;
+-- modify "key_rotations" table
+ALTER TABLE "key_rotations" ADD COLUMN "binds_values" boolean NOT NULL DEFAULT true;
+-- unfinished rotations may have written unbound values with their new key
+UPDATE "key_rotations" SET "binds_values" = false WHERE "status" <> 'completed';
+
+-- +goose Down
+-- reverse: modify "key_rotations" table
+ALTER TABLE "key_rotations" DROP COLUMN "binds_values";
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "legacy_ciphertexts";
@@ -0,0 +1,23 @@
+-- +goose Up
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "totp_secret" text NULL, ADD COLUMN "totp_enabled" boolean NOT NULL DEFAULT false, ADD COLUMN "totp_last_step" bigint NOT NULL DEFAULT 0;
+-- create "recovery_codes" table
+CREATE TABLE "recovery_codes" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL,
+  "code_hash" text NOT NULL,
+  "used_at" timestamp NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "recovery_codes_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create index "idx_recovery_codes_user_id" to table: "recovery_codes"
+CREATE INDEX "idx_recovery_codes_user_id" ON "recovery_codes" ("user_id");
+
+-- +goose Down
+-- reverse: create index "idx_recovery_codes_user_id" to table: "recovery_codes"
+DROP INDEX "idx_recovery_codes_user_id";
+-- reverse: create "recovery_codes" table
+DROP TABLE "recovery_codes";
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "totp_last_step", DROP COLUMN "totp_enabled", DROP COLUMN "totp_secret";
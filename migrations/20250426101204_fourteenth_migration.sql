@@ -0,0 +1,25 @@
+-- +goose Up
+-- create "sessions" table
+CREATE TABLE "sessions" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL,
+  "device_name" text NOT NULL DEFAULT '',
+  "ip_address" text NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "last_used_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "expires_at" timestamp NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "sessions_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create index "idx_sessions_user_id" to table: "sessions"
+CREATE INDEX "idx_sessions_user_id" ON "sessions" ("user_id");
+-- modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" ADD COLUMN "session_id" uuid NULL, ADD CONSTRAINT "refresh_tokens_session_id_fkey" FOREIGN KEY ("session_id") REFERENCES "sessions" ("id") ON UPDATE NO ACTION ON DELETE CASCADE;
+
+-- +goose Down
+-- reverse: modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" DROP CONSTRAINT "refresh_tokens_session_id_fkey", DROP COLUMN "session_id";
+-- reverse: create index "idx_sessions_user_id" to table: "sessions"
+DROP INDEX "idx_sessions_user_id";
+-- reverse: create "sessions" table
+DROP TABLE "sessions";
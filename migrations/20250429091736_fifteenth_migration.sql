@@ -0,0 +1,13 @@
+-- +goose Up
+-- modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" ADD COLUMN "token_hash" text NULL, ADD COLUMN "used_at" timestamp NULL;
+-- refresh tokens are kept as hashes only, see HashRefreshToken
+UPDATE "refresh_tokens" SET "token_hash" = encode(sha256(convert_to("token", 'UTF8')), 'hex');
+ALTER TABLE "refresh_tokens" ALTER COLUMN "token_hash" SET NOT NULL, DROP COLUMN "token", ADD CONSTRAINT "refresh_tokens_token_hash_key" UNIQUE ("token_hash");
+
+-- +goose Down
+-- the hashed tokens cannot be restored, so their sessions have to log in again
+DELETE FROM "refresh_tokens";
+-- reverse: modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" DROP CONSTRAINT "refresh_tokens_token_hash_key", ADD COLUMN "token" text NOT NULL, ADD CONSTRAINT "refresh_tokens_token_key" UNIQUE ("token");
+ALTER TABLE "refresh_tokens" DROP COLUMN "used_at", DROP COLUMN "token_hash";
@@ -0,0 +1,15 @@
+-- +goose Up
+-- create "signing_keys" table
+CREATE TABLE "signing_keys" (
+  "id" text NOT NULL,
+  "algorithm" text NOT NULL,
+  "private_key" text NOT NULL,
+  "public_key" text NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "activates_at" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+
+-- +goose Down
+-- reverse: create "signing_keys" table
+DROP TABLE "signing_keys";
@@ -0,0 +1,22 @@
+-- +goose Up
+-- create "api_tokens" table
+CREATE TABLE "api_tokens" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL,
+  "name" text NOT NULL,
+  "token_hash" text NOT NULL,
+  "read_only" boolean NOT NULL DEFAULT true,
+  "item_types" text[] NOT NULL DEFAULT '{}',
+  "tags" jsonb NOT NULL DEFAULT '{}',
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "expires_at" timestamp NOT NULL,
+  "last_used_at" timestamp NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "api_tokens_token_hash_key" UNIQUE ("token_hash"),
+  CONSTRAINT "unique_api_token_name" UNIQUE ("user_id", "name"),
+  CONSTRAINT "api_tokens_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+
+-- +goose Down
+-- reverse: create "api_tokens" table
+DROP TABLE "api_tokens";
@@ -0,0 +1,37 @@
+-- +goose Up
+-- create "share_keys" table
+CREATE TABLE "share_keys" (
+  "user_id" uuid NOT NULL,
+  "public_key" text NOT NULL,
+  "private_key" text NOT NULL,
+  "key_version" integer NOT NULL DEFAULT 1,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id"),
+  CONSTRAINT "share_keys_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create "shares" table
+CREATE TABLE "shares" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "item_id" uuid NOT NULL,
+  "owner_id" uuid NOT NULL,
+  "recipient_id" uuid NOT NULL,
+  "read_only" boolean NOT NULL DEFAULT true,
+  "payload" text NOT NULL,
+  "item_seq" bigint NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "unique_share_recipient" UNIQUE ("item_id", "recipient_id"),
+  CONSTRAINT "shares_item_id_fkey" FOREIGN KEY ("item_id") REFERENCES "items" ("id_resource") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "shares_owner_id_fkey" FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "shares_recipient_id_fkey" FOREIGN KEY ("recipient_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create index "idx_shares_recipient_id" to table: "shares"
+CREATE INDEX "idx_shares_recipient_id" ON "shares" ("recipient_id");
+
+-- +goose Down
+-- reverse: create index "idx_shares_recipient_id" to table: "shares"
+DROP INDEX "idx_shares_recipient_id";
+-- reverse: create "shares" table
+DROP TABLE "shares";
+-- reverse: create "share_keys" table
+DROP TABLE "share_keys";
@@ -0,0 +1,78 @@
+-- +goose Up
+-- create enum type "org_role"
+CREATE TYPE "org_role" AS ENUM ('owner', 'admin', 'member', 'read_only');
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "organization_vault" boolean NOT NULL DEFAULT false;
+-- create "organizations" table
+CREATE TABLE "organizations" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "name" character varying(255) NOT NULL,
+  "vault_id" uuid NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "organizations_vault_id_key" UNIQUE ("vault_id"),
+  CONSTRAINT "organizations_vault_id_fkey" FOREIGN KEY ("vault_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create "org_members" table
+CREATE TABLE "org_members" (
+  "org_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "role" "org_role" NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("org_id", "user_id"),
+  CONSTRAINT "org_members_org_id_fkey" FOREIGN KEY ("org_id") REFERENCES "organizations" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "org_members_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create index "idx_org_members_user_id" to table: "org_members"
+CREATE INDEX "idx_org_members_user_id" ON "org_members" ("user_id");
+-- create "collections" table
+CREATE TABLE "collections" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "org_id" uuid NOT NULL,
+  "name" character varying(255) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "unique_collection_name" UNIQUE ("org_id", "name"),
+  CONSTRAINT "collections_org_id_fkey" FOREIGN KEY ("org_id") REFERENCES "organizations" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create "collection_members" table
+CREATE TABLE "collection_members" (
+  "collection_id" uuid NOT NULL,
+  "org_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "read_only" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("collection_id", "user_id"),
+  CONSTRAINT "collection_members_collection_id_fkey" FOREIGN KEY ("collection_id") REFERENCES "collections" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "collection_members_org_id_user_id_fkey" FOREIGN KEY ("org_id", "user_id") REFERENCES "org_members" ("org_id", "user_id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create "collection_items" table
+CREATE TABLE "collection_items" (
+  "item_id" uuid NOT NULL,
+  "collection_id" uuid NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("item_id"),
+  CONSTRAINT "collection_items_collection_id_fkey" FOREIGN KEY ("collection_id") REFERENCES "collections" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create index "idx_collection_items_collection_id" to table: "collection_items"
+CREATE INDEX "idx_collection_items_collection_id" ON "collection_items" ("collection_id");
+
+-- +goose Down
+-- reverse: create index "idx_collection_items_collection_id" to table: "collection_items"
+DROP INDEX "idx_collection_items_collection_id";
+-- reverse: create "collection_items" table
+DROP TABLE "collection_items";
+-- reverse: create "collection_members" table
+DROP TABLE "collection_members";
+-- reverse: create "collections" table
+DROP TABLE "collections";
+-- reverse: create index "idx_org_members_user_id" to table: "org_members"
+DROP INDEX "idx_org_members_user_id";
+-- reverse: create "org_members" table
+DROP TABLE "org_members";
+-- reverse: create "organizations" table
+DROP TABLE "organizations";
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "organization_vault";
+-- reverse: create enum type "org_role"
+DROP TYPE "org_role";
@@ -0,0 +1,37 @@
+-- +goose Up
+-- create enum type "emergency_access_type"
+CREATE TYPE "emergency_access_type" AS ENUM ('view', 'takeover');
+-- create enum type "emergency_access_status"
+CREATE TYPE "emergency_access_status" AS ENUM ('idle', 'requested', 'approved');
+-- create "emergency_access" table
+CREATE TABLE "emergency_access" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "grantor_id" uuid NOT NULL,
+  "grantee_id" uuid NOT NULL,
+  "access_type" "emergency_access_type" NOT NULL,
+  "wait_days" integer NOT NULL,
+  "status" "emergency_access_status" NOT NULL DEFAULT 'idle',
+  "requested_at" timestamp NULL,
+  "approved_at" timestamp NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "unique_emergency_contact" UNIQUE ("grantor_id", "grantee_id"),
+  CONSTRAINT "emergency_access_grantee_id_fkey" FOREIGN KEY ("grantee_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "emergency_access_grantor_id_fkey" FOREIGN KEY ("grantor_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create index "idx_emergency_access_grantee_id" to table: "emergency_access"
+CREATE INDEX "idx_emergency_access_grantee_id" ON "emergency_access" ("grantee_id");
+-- create index "idx_emergency_access_requested_at" to table: "emergency_access"
+CREATE INDEX "idx_emergency_access_requested_at" ON "emergency_access" ("requested_at") WHERE (status = 'requested'::emergency_access_status);
+
+-- +goose Down
+-- reverse: create index "idx_emergency_access_requested_at" to table: "emergency_access"
+DROP INDEX "idx_emergency_access_requested_at";
+-- reverse: create index "idx_emergency_access_grantee_id" to table: "emergency_access"
+DROP INDEX "idx_emergency_access_grantee_id";
+-- reverse: create "emergency_access" table
+DROP TABLE "emergency_access";
+-- reverse: create enum type "emergency_access_status"
+DROP TYPE "emergency_access_status";
+-- reverse: create enum type "emergency_access_type"
+DROP TYPE "emergency_access_type";
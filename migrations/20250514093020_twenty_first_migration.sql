@@ -0,0 +1,33 @@
+-- +goose Up
+-- create enum type "send_type"
+CREATE TYPE "send_type" AS ENUM ('text', 'file');
+-- create "sends" table
+CREATE TABLE "sends" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL,
+  "send_type" "send_type" NOT NULL,
+  "content" text NOT NULL,
+  "object_name" text NULL,
+  "file_size" bigint NOT NULL DEFAULT 0,
+  "password" text NULL,
+  "max_views" integer NOT NULL,
+  "views" integer NOT NULL DEFAULT 0,
+  "expires_at" timestamp NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "sends_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create index "idx_sends_expires_at" to table: "sends"
+CREATE INDEX "idx_sends_expires_at" ON "sends" ("expires_at");
+-- create index "idx_sends_user_id" to table: "sends"
+CREATE INDEX "idx_sends_user_id" ON "sends" ("user_id");
+
+-- +goose Down
+-- reverse: create index "idx_sends_user_id" to table: "sends"
+DROP INDEX "idx_sends_user_id";
+-- reverse: create index "idx_sends_expires_at" to table: "sends"
+DROP INDEX "idx_sends_expires_at";
+-- reverse: create "sends" table
+DROP TABLE "sends";
+-- reverse: create enum type "send_type"
+DROP TYPE "send_type";
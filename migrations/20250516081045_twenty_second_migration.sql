@@ -0,0 +1,23 @@
+-- +goose Up
+-- create "audit_events" table
+CREATE TABLE "audit_events" (
+  "seq" bigint NOT NULL,
+  "actor_id" uuid NULL,
+  "item_id" uuid NULL,
+  "action" text NOT NULL,
+  "ip" text NOT NULL,
+  "user_agent" text NOT NULL,
+  "result" text NOT NULL,
+  "occurred_at" timestamp NOT NULL,
+  "prev_hash" text NOT NULL,
+  "hash" text NOT NULL,
+  PRIMARY KEY ("seq")
+);
+-- create index "idx_audit_events_actor_id" to table: "audit_events"
+CREATE INDEX "idx_audit_events_actor_id" ON "audit_events" ("actor_id", "seq");
+
+-- +goose Down
+-- reverse: create index "idx_audit_events_actor_id" to table: "audit_events"
+DROP INDEX "idx_audit_events_actor_id";
+-- reverse: create "audit_events" table
+DROP TABLE "audit_events";
@@ -0,0 +1,15 @@
+-- +goose Up
+-- links written before a failed store point at no item, or at an item outside the organization
+DELETE FROM "collection_items" ci
+WHERE NOT EXISTS (
+  SELECT 1 FROM "items" i
+  JOIN "collections" c ON c.id = ci.collection_id
+  JOIN "organizations" o ON o.id = c.org_id AND o.vault_id = i.user_id
+  WHERE i.id_resource = ci.item_id
+);
+-- modify "collection_items" table
+ALTER TABLE "collection_items" ADD CONSTRAINT "collection_items_item_id_fkey" FOREIGN KEY ("item_id") REFERENCES "items" ("id_resource") ON UPDATE NO ACTION ON DELETE CASCADE;
+
+-- +goose Down
+-- reverse: modify "collection_items" table
+ALTER TABLE "collection_items" DROP CONSTRAINT "collection_items_item_id_fkey";
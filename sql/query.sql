@@ -1,6 +1,6 @@
 -- name: CreateUser :one
-INSERT INTO users (username, password, encryption_key, email)
-VALUES ($1, $2, $3, $4)
+INSERT INTO users (username, password, encryption_key, email, kdf_salt, wrapped_vault_key)
+VALUES ($1, $2, $3, $4, $5, $6)
     RETURNING *;
 
 -- name: GetUserByID :one
@@ -11,14 +11,103 @@ WHERE id = $1;
 SELECT * FROM users
 WHERE username = $1;
 
+-- name: ListUserKeysAfter :many
+SELECT id, encryption_key FROM users
+WHERE id > @after_id AND wrapped_vault_key IS NULL
+ORDER BY id
+LIMIT @batch_size;
+
+-- name: ListLegacyCiphertextUsers :many
+SELECT id, key_version FROM users
+WHERE id > @after_id AND legacy_ciphertexts AND wrapped_vault_key IS NULL AND previous_encryption_key IS NULL
+ORDER BY id
+LIMIT @batch_size;
+
+-- name: RewrapUserKey :execrows
+UPDATE users
+SET encryption_key = @new_key
+WHERE id = @id AND encryption_key = @old_key;
+
+-- name: ListTOTPSecretsAfter :many
+SELECT id, totp_secret::text AS totp_secret FROM users
+WHERE id > @after_id AND totp_secret IS NOT NULL
+ORDER BY id
+LIMIT @batch_size;
+
+-- name: RewrapTOTPSecret :execrows
+UPDATE users
+SET totp_secret = @new_secret
+WHERE id = @id AND totp_secret = @old_secret;
+
+-- name: SetTOTPSecret :execrows
+UPDATE users
+SET totp_secret = @secret
+WHERE id = @id AND NOT totp_enabled;
+
+-- name: EnableTOTP :execrows
+WITH enabled AS (
+    UPDATE users
+    SET totp_enabled = true, totp_last_step = @step
+    WHERE users.id = @user_id AND NOT users.totp_enabled AND users.totp_secret = @secret
+    RETURNING users.id
+)
+INSERT INTO recovery_codes (user_id, code_hash)
+SELECT enabled.id, code_hash FROM enabled, unnest(@code_hashes::text[]) AS code_hash;
+
+-- name: UseTOTPStep :execrows
+UPDATE users
+SET totp_last_step = @step
+WHERE id = @id AND totp_enabled AND totp_last_step < @step;
+
+-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes
+SET used_at = NOW()
+WHERE user_id = @user_id AND code_hash = @code_hash AND used_at IS NULL;
+
+-- name: ChangeUserPassword :execrows
+UPDATE users
+SET password = @new_password, kdf_salt = @kdf_salt, wrapped_vault_key = @wrapped_vault_key
+WHERE id = @id AND password = @old_password;
+
+-- name: RehashUserPassword :execrows
+UPDATE users
+SET password = @new_password
+WHERE id = @id AND password = @old_password;
+
+-- name: ListSigningKeys :many
+SELECT * FROM signing_keys
+ORDER BY activates_at DESC;
+
+-- name: CreateSigningKey :execrows
+INSERT INTO signing_keys (id, algorithm, private_key, public_key, activates_at)
+SELECT @id::text, @algorithm::text, @private_key::text, @public_key::text, @activates_at::timestamp
+WHERE NOT EXISTS (SELECT 1 FROM signing_keys WHERE activates_at > @latest::timestamp);
+
+-- name: DeleteSigningKey :exec
+DELETE FROM signing_keys
+WHERE id = $1;
+
+-- name: DeleteUser :execrows
+DELETE FROM users
+WHERE id = $1;
+
 -- name: CreatePasswordEntry :one
-INSERT INTO passwords (user_id, login, password)
-VALUES ($1, $2, $3)
+INSERT INTO passwords (id, user_id, login, password, key_version)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: GetPasswordEntriesByUserID :many
 SELECT * FROM passwords
-WHERE user_id = $1;
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+    LIMIT $2 OFFSET $3;
+
+-- name: CountPasswordEntriesByUserID :one
+SELECT COUNT(*) FROM passwords WHERE user_id = $1;
+
+-- name: GetPasswordEntriesByIDs :many
+SELECT * FROM passwords
+WHERE user_id = @user_id AND id = ANY(@ids::uuid[]);
 
 -- name: GetPasswordEntryByID :one
 SELECT passwords.*
@@ -27,8 +116,9 @@ WHERE passwords.id = $1 and passwords.user_id = $2;
 
 -- name: UpdatePasswordEntry :one
 UPDATE passwords
-SET login = $1, password = $2
-WHERE id = $3
+SET login = @login, password = @password, key_version = @key_version, version = version + 1
+WHERE id = @id AND user_id = @user_id
+  AND (@expected_version::bigint = 0 OR version = @expected_version::bigint)
     RETURNING *;
 
 -- name: DeletePasswordEntry :exec
@@ -36,13 +126,22 @@ DELETE FROM passwords
 WHERE id = $1 and user_id = $2;
 
 -- name: CreateNoteEntry :one
-INSERT INTO notes (user_id, encrypted_content)
-VALUES ($1, $2)
+INSERT INTO notes (id, user_id, encrypted_content, key_version)
+VALUES ($1, $2, $3, $4)
     RETURNING *;
 
 -- name: GetNotesByUserID :many
 SELECT * FROM notes
-WHERE user_id = $1;
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+    LIMIT $2 OFFSET $3;
+
+-- name: CountNotesByUserID :one
+SELECT COUNT(*) FROM notes WHERE user_id = $1;
+
+-- name: GetNotesByIDs :many
+SELECT * FROM notes
+WHERE user_id = @user_id AND id = ANY(@ids::uuid[]);
 
 -- name: GetNoteByID :one
 SELECT notes.*
@@ -53,18 +152,31 @@ WHERE notes.id = $1 and notes.user_id = $2;
 DELETE FROM notes WHERE id = $1 and user_id = $2;
 
 -- name: StoreCard :one
-INSERT INTO cards (user_id, hashed_card_number, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO cards (id, user_id, hashed_card_number, encrypted_card_number, encrypted_expiry_date, encrypted_cvv, cardholder_name, key_version)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     RETURNING *;
 
 -- name: UpdateCard :one
 UPDATE cards
-SET encrypted_card_number = $1, encrypted_expiry_date = $2, encrypted_cvv = $3, cardholder_name = $4, hashed_card_number = $5
-WHERE id = $6
+SET encrypted_card_number = @encrypted_card_number, encrypted_expiry_date = @encrypted_expiry_date,
+    encrypted_cvv = @encrypted_cvv, cardholder_name = @cardholder_name, hashed_card_number = @hashed_card_number,
+    key_version = @key_version, version = version + 1
+WHERE id = @id AND user_id = @user_id
+  AND (@expected_version::bigint = 0 OR version = @expected_version::bigint)
     RETURNING *;
 
 -- name: GetCardsByUserID :many
-SELECT * FROM cards WHERE user_id = $1;
+SELECT * FROM cards
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+    LIMIT $2 OFFSET $3;
+
+-- name: CountCardsByUserID :one
+SELECT COUNT(*) FROM cards WHERE user_id = $1;
+
+-- name: GetCardsByIDs :many
+SELECT * FROM cards
+WHERE user_id = @user_id AND id = ANY(@ids::uuid[]);
 
 -- name: GetCardByID :one
 SELECT cards.*
@@ -75,12 +187,22 @@ WHERE cards.id = $1 and cards.user_id = $2;
 DELETE FROM cards WHERE id = $1 and user_id = $2;
 
 -- name: StoreBinaryEntry :one
-INSERT INTO binary_entries (user_id, file_name, file_url, file_size)
-VALUES ($1, $2, $3, $4)
+INSERT INTO binary_entries (id, user_id, file_name, file_url, file_size, key_version)
+VALUES ($1, $2, $3, $4, $5, $6)
     RETURNING *;
 
 -- name: GetBinaryEntriesByUserID :many
-SELECT * FROM binary_entries WHERE user_id = $1;
+SELECT * FROM binary_entries
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+    LIMIT $2 OFFSET $3;
+
+-- name: CountBinaryEntriesByUserID :one
+SELECT COUNT(*) FROM binary_entries WHERE user_id = $1;
+
+-- name: GetBinaryEntriesByIDs :many
+SELECT * FROM binary_entries
+WHERE user_id = @user_id AND id = ANY(@ids::uuid[]);
 
 -- name: GetBinaryEntryByID :one
 SELECT binary_entries.*
@@ -96,19 +218,57 @@ SELECT
     i.type,
     i.id_resource,
     i.created_at,
-    COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) AS updated_at
+    COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) AS updated_at,
+    COALESCE(o.username, '')::text AS owner,
+    COALESCE(s.read_only, FALSE)::boolean AS read_only
 FROM items i
          LEFT JOIN passwords p ON i.type = 'password' AND i.id_resource = p.id
          LEFT JOIN notes n ON i.type = 'text' AND i.id_resource = n.id
          LEFT JOIN cards c ON i.type = 'card' AND i.id_resource = c.id
          LEFT JOIN binary_entries b ON i.type = 'binary' AND i.id_resource = b.id
-WHERE i.user_id = $1
+         LEFT JOIN shares s ON s.item_id = i.id_resource AND s.recipient_id = @user_id
+         LEFT JOIN users o ON s.id IS NOT NULL AND o.id = i.user_id
+WHERE i.user_id = @user_id OR s.id IS NOT NULL
 ORDER BY i.created_at DESC
-    LIMIT $2 OFFSET $3;
+    LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: GetTotalItemCountByUserID :one
 SELECT COUNT(*) FROM items WHERE user_id = $1;
 
+-- name: ItemExists :one
+SELECT EXISTS (SELECT 1 FROM items WHERE id_resource = $1);
+
+-- name: GetItemsByResourceIDs :many
+SELECT
+    i.id,
+    i.type,
+    i.id_resource,
+    i.created_at,
+    COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) AS updated_at
+FROM items i
+         LEFT JOIN passwords p ON i.type = 'password' AND i.id_resource = p.id
+         LEFT JOIN notes n ON i.type = 'text' AND i.id_resource = n.id
+         LEFT JOIN cards c ON i.type = 'card' AND i.id_resource = c.id
+         LEFT JOIN binary_entries b ON i.type = 'binary' AND i.id_resource = b.id
+WHERE i.user_id = @user_id AND i.id_resource = ANY(@resource_ids::uuid[])
+ORDER BY i.created_at, i.id;
+
+-- name: GetItemsChangedSince :many
+SELECT
+    i.id,
+    i.type,
+    i.id_resource,
+    i.created_at,
+    COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) AS updated_at
+FROM items i
+         LEFT JOIN passwords p ON i.type = 'password' AND i.id_resource = p.id
+         LEFT JOIN notes n ON i.type = 'text' AND i.id_resource = n.id
+         LEFT JOIN cards c ON i.type = 'card' AND i.id_resource = c.id
+         LEFT JOIN binary_entries b ON i.type = 'binary' AND i.id_resource = b.id
+WHERE i.user_id = @user_id
+  AND COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) > @changed_since::timestamp
+ORDER BY COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at), i.id;
+
 -- name: AddMetaInfo :one
 INSERT INTO metainfo (item_id, key, value)
 VALUES ($1, $2, $3)
@@ -118,27 +278,545 @@ VALUES ($1, $2, $3)
 -- name: GetMetaInfoByItemID :many
 SELECT key, value FROM metainfo WHERE item_id = $1;
 
+-- name: GetMetaInfoByItemIDs :many
+SELECT item_id, key, value FROM metainfo WHERE item_id = ANY(@item_ids::uuid[]);
+
 -- name: DeleteMetaInfo :exec
 DELETE FROM metainfo
 WHERE item_id = $1 AND key = $2;
 
 -- name: CreateRefreshToken :exec
-INSERT INTO refresh_tokens (user_id, token, expires_at)
-VALUES ($1, $2, $3);
+INSERT INTO refresh_tokens (user_id, token_hash, expires_at, session_id)
+VALUES ($1, $2, $3, $4);
 
 -- name: GetRefreshToken :one
-SELECT id, user_id, token, expires_at
+SELECT id, user_id, token_hash, expires_at, session_id, used_at
 FROM refresh_tokens
-WHERE token = $1;
+WHERE token_hash = $1;
+
+-- name: UseRefreshToken :execrows
+UPDATE refresh_tokens
+SET used_at = NOW(), session_id = @session_id
+WHERE token_hash = @token_hash AND used_at IS NULL;
 
 -- name: DeleteRefreshToken :exec
 DELETE FROM refresh_tokens
-WHERE token = $1;
+WHERE token_hash = $1;
 
 -- name: DeleteUserRefreshTokens :exec
 DELETE FROM refresh_tokens
 WHERE user_id = $1;
 
+-- name: CreateSession :one
+INSERT INTO sessions (user_id, device_name, ip_address, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: TouchSession :execrows
+UPDATE sessions
+SET last_used_at = NOW(), ip_address = @ip_address, expires_at = @expires_at
+WHERE id = @id;
+
+-- name: ListSessions :many
+SELECT *
+FROM sessions
+WHERE user_id = $1 AND expires_at > NOW()
+ORDER BY last_used_at DESC;
+
+-- name: DeleteSession :execrows
+DELETE FROM sessions
+WHERE id = $1 AND user_id = $2;
+
+-- name: DeleteUserSessions :many
+DELETE FROM sessions
+WHERE user_id = $1
+RETURNING id;
+
 -- name: ExpireRefreshTokens :exec
 DELETE FROM refresh_tokens
-WHERE expires_at < NOW();
\ No newline at end of file
+WHERE expires_at < NOW();
+
+-- name: GetItemChangesSince :many
+SELECT item_id, type, seq, deleted, changed_at
+FROM item_changes
+WHERE user_id = @user_id AND seq > @since_seq::bigint
+ORDER BY seq
+    LIMIT @max_changes;
+
+-- name: StartKeyRotation :one
+WITH rotated AS (
+    UPDATE users
+    SET previous_encryption_key = encryption_key, encryption_key = @new_key, key_version = key_version + 1
+    WHERE users.id = @user_id AND users.key_version = @from_version
+      AND users.previous_encryption_key IS NULL AND users.wrapped_vault_key IS NULL
+    RETURNING users.id, users.key_version
+)
+INSERT INTO key_rotations (user_id, from_version, to_version, total_items)
+SELECT rotated.id, rotated.key_version - 1, rotated.key_version,
+       (SELECT COUNT(*) FROM items WHERE items.user_id = rotated.id)
+FROM rotated
+    RETURNING *;
+
+-- name: GetLatestKeyRotation :one
+SELECT * FROM key_rotations
+WHERE user_id = $1
+ORDER BY started_at DESC
+    LIMIT 1;
+
+-- name: ListRunningKeyRotations :many
+SELECT * FROM key_rotations
+WHERE status = 'running'
+ORDER BY started_at;
+
+-- name: RetryKeyRotation :one
+UPDATE key_rotations
+SET status = 'running', error = NULL, finished_at = NULL
+WHERE id = @id AND status = 'failed'
+    RETURNING *;
+
+-- name: FailKeyRotation :exec
+UPDATE key_rotations
+SET status = 'failed', error = @error, finished_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: UpdateKeyRotationProgress :exec
+UPDATE key_rotations
+SET done_items = GREATEST(total_items - @remaining::bigint, 0)
+WHERE id = @id;
+
+-- name: FinishKeyRotation :one
+WITH cleared AS (
+    UPDATE users
+    SET previous_encryption_key = NULL,
+        legacy_ciphertexts = users.legacy_ciphertexts
+            AND NOT (SELECT r.binds_values FROM key_rotations r WHERE r.id = @id)
+    WHERE users.id = @user_id AND users.key_version = @to_version
+    RETURNING users.id
+)
+UPDATE key_rotations
+SET status = 'completed', done_items = total_items, finished_at = CURRENT_TIMESTAMP
+WHERE key_rotations.id = @id AND key_rotations.user_id IN (SELECT cleared.id FROM cleared)
+    RETURNING *;
+
+-- name: CountStaleItems :one
+SELECT ((SELECT COUNT(*) FROM passwords p WHERE p.user_id = @user_id AND p.key_version < @key_version)
+    + (SELECT COUNT(*) FROM notes n WHERE n.user_id = @user_id AND n.key_version < @key_version)
+    + (SELECT COUNT(*) FROM cards c WHERE c.user_id = @user_id AND c.key_version < @key_version)
+    + (SELECT COUNT(*) FROM binary_entries b WHERE b.user_id = @user_id AND b.key_version < @key_version)
+    + (SELECT COUNT(*) FROM share_keys k WHERE k.user_id = @user_id AND k.key_version < @key_version))::bigint AS remaining;
+
+-- name: ListStalePasswords :many
+SELECT * FROM passwords
+WHERE user_id = @user_id AND key_version < @key_version
+ORDER BY id
+    LIMIT @batch_size;
+
+-- name: ReencryptPassword :execrows
+UPDATE passwords
+SET password = @password, key_version = @key_version
+WHERE id = @id AND version = @version AND key_version = @old_key_version;
+
+-- name: ListStaleNotes :many
+SELECT * FROM notes
+WHERE user_id = @user_id AND key_version < @key_version
+ORDER BY id
+    LIMIT @batch_size;
+
+-- name: ReencryptNote :execrows
+UPDATE notes
+SET encrypted_content = @encrypted_content, key_version = @key_version
+WHERE id = @id AND version = @version AND key_version = @old_key_version;
+
+-- name: ListStaleCards :many
+SELECT * FROM cards
+WHERE user_id = @user_id AND key_version < @key_version
+ORDER BY id
+    LIMIT @batch_size;
+
+-- name: ReencryptCard :execrows
+UPDATE cards
+SET encrypted_card_number = @encrypted_card_number, encrypted_expiry_date = @encrypted_expiry_date,
+    encrypted_cvv = @encrypted_cvv, key_version = @key_version
+WHERE id = @id AND version = @version AND key_version = @old_key_version;
+
+-- name: ListStaleBinaryEntries :many
+SELECT * FROM binary_entries
+WHERE user_id = @user_id AND key_version < @key_version
+ORDER BY id
+    LIMIT @batch_size;
+
+-- name: ReencryptBinaryEntry :execrows
+UPDATE binary_entries
+SET file_url = @file_url, key_version = @key_version
+WHERE id = @id AND version = @version AND key_version = @old_key_version;
+
+-- name: ReencryptShareKey :execrows
+UPDATE share_keys
+SET private_key = @private_key, key_version = @key_version
+WHERE user_id = @user_id AND key_version = @old_key_version;
+
+-- name: CreateAPIToken :one
+INSERT INTO api_tokens (user_id, name, token_hash, read_only, item_types, tags, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetAPIToken :one
+SELECT *
+FROM api_tokens
+WHERE token_hash = $1 AND expires_at > NOW();
+
+-- name: ListAPITokens :many
+SELECT *
+FROM api_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: DeleteAPIToken :execrows
+DELETE FROM api_tokens
+WHERE id = $1 AND user_id = $2;
+
+-- Tokens are used in bursts, so the last use is recorded once a minute at most
+-- name: TouchAPIToken :exec
+UPDATE api_tokens
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
+
+-- name: ListScopedItemIDs :many
+SELECT i.id_resource
+FROM items i
+WHERE i.user_id = @user_id
+  AND (cardinality(@item_types::text[]) = 0 OR i.type::text = ANY(@item_types::text[]))
+  AND NOT EXISTS (
+    SELECT 1 FROM jsonb_each_text(@tags::jsonb) AS tag
+    WHERE NOT EXISTS (
+        SELECT 1 FROM metainfo m
+        WHERE m.item_id = i.id_resource AND m.key = tag.key AND m.value = tag.value
+    )
+);
+
+-- name: GetShareKey :one
+SELECT * FROM share_keys
+WHERE user_id = $1;
+
+-- Concurrent shares with a new recipient may both create a key; the first one is kept
+-- name: CreateShareKey :exec
+INSERT INTO share_keys (user_id, public_key, private_key, key_version)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO NOTHING;
+
+-- name: GetItemChangeSeq :one
+SELECT seq FROM item_changes
+WHERE user_id = $1 AND item_id = $2 AND NOT deleted;
+
+-- name: UpsertShare :one
+INSERT INTO shares (item_id, owner_id, recipient_id, read_only, payload, item_seq)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (item_id, recipient_id) DO UPDATE
+    SET read_only = EXCLUDED.read_only, payload = EXCLUDED.payload, item_seq = EXCLUDED.item_seq
+WHERE shares.owner_id = EXCLUDED.owner_id
+RETURNING *;
+
+-- name: GetShare :one
+SELECT * FROM shares
+WHERE item_id = $1 AND recipient_id = $2;
+
+-- name: RefreshShare :execrows
+UPDATE shares
+SET payload = @payload, item_seq = @item_seq
+WHERE id = @id AND item_seq = @old_item_seq;
+
+-- name: ListShares :many
+SELECT s.id, s.item_id, s.read_only, s.created_at, u.username AS recipient
+FROM shares s
+         JOIN users u ON u.id = s.recipient_id
+WHERE s.owner_id = @owner_id AND (NOT @by_item::boolean OR s.item_id = @item_id)
+ORDER BY s.created_at DESC, s.id;
+
+-- name: DeleteShare :execrows
+DELETE FROM shares
+WHERE item_id = @item_id AND owner_id = @owner_id
+  AND recipient_id = (SELECT users.id FROM users WHERE users.username = @recipient);
+
+-- The vault of an organization is a users row without a password, so it cannot sign in
+-- name: CreateOrganization :one
+WITH vault AS (
+    INSERT INTO users (username, email, password, encryption_key, organization_vault)
+    SELECT 'org/' || vault_name, 'org/' || vault_name, '', @encryption_key, true
+    FROM (SELECT gen_random_uuid()::text AS vault_name) AS names
+    RETURNING users.id
+), org AS (
+    INSERT INTO organizations (name, vault_id)
+    SELECT @name, vault.id FROM vault
+    RETURNING *
+), owner AS (
+    INSERT INTO org_members (org_id, user_id, role)
+    SELECT org.id, @owner_id, 'owner' FROM org
+)
+SELECT * FROM org;
+
+-- name: ListOrganizations :many
+SELECT o.id, o.name, o.created_at, m.role
+FROM organizations o
+         JOIN org_members m ON m.org_id = o.id
+WHERE m.user_id = $1
+ORDER BY o.name, o.id;
+
+-- name: GetMembership :one
+SELECT o.id, o.name, o.vault_id, o.created_at, m.role
+FROM organizations o
+         JOIN org_members m ON m.org_id = o.id
+WHERE o.id = @org_id AND m.user_id = @user_id;
+
+-- Deleting the vault deletes the organization with it
+-- name: DeleteOrganization :execrows
+DELETE FROM users
+WHERE organization_vault AND id = (SELECT organizations.vault_id FROM organizations WHERE organizations.id = $1);
+
+-- name: CountCollections :one
+SELECT COUNT(*) FROM collections WHERE org_id = $1;
+
+-- name: UpsertOrgMember :one
+INSERT INTO org_members (org_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (org_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING *;
+
+-- name: DeleteOrgMember :execrows
+DELETE FROM org_members
+WHERE org_id = $1 AND user_id = $2;
+
+-- name: CountOrgOwners :one
+SELECT COUNT(*) FROM org_members
+WHERE org_id = $1 AND role = 'owner';
+
+-- name: CountSoleOwnerships :one
+SELECT COUNT(*) FROM org_members m
+WHERE m.user_id = $1 AND m.role = 'owner'
+  AND NOT EXISTS (
+    SELECT 1 FROM org_members o
+    WHERE o.org_id = m.org_id AND o.role = 'owner' AND o.user_id <> m.user_id
+);
+
+-- name: ListOrgMembers :many
+SELECT u.username, m.role, m.created_at
+FROM org_members m
+         JOIN users u ON u.id = m.user_id
+WHERE m.org_id = $1
+ORDER BY u.username;
+
+-- name: CreateCollection :one
+INSERT INTO collections (org_id, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: DeleteCollection :execrows
+DELETE FROM collections WHERE id = $1;
+
+-- name: ListCollections :many
+SELECT c.id, c.org_id, c.name, c.created_at, g.read_only
+FROM collections c
+         LEFT JOIN collection_members g ON g.collection_id = c.id AND g.user_id = @user_id
+WHERE c.org_id = @org_id AND (@all_collections::boolean OR g.user_id IS NOT NULL)
+ORDER BY c.name;
+
+-- name: UpsertCollectionMember :execrows
+INSERT INTO collection_members (collection_id, org_id, user_id, read_only)
+SELECT c.id, c.org_id, @user_id, @read_only FROM collections c
+WHERE c.id = @collection_id
+ON CONFLICT (collection_id, user_id) DO UPDATE SET read_only = EXCLUDED.read_only;
+
+-- name: DeleteCollectionMember :execrows
+DELETE FROM collection_members
+WHERE collection_id = $1 AND user_id = $2;
+
+-- name: AddCollectionItem :exec
+INSERT INTO collection_items (item_id, collection_id)
+VALUES ($1, $2);
+
+-- Items rows outlive their records, so only the items that still have one are listed
+-- name: ListCollectionItems :many
+SELECT
+    i.id,
+    i.type,
+    i.id_resource,
+    i.created_at,
+    COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) AS updated_at
+FROM collection_items ci
+         JOIN items i ON i.id_resource = ci.item_id
+         LEFT JOIN passwords p ON i.type = 'password' AND i.id_resource = p.id
+         LEFT JOIN notes n ON i.type = 'text' AND i.id_resource = n.id
+         LEFT JOIN cards c ON i.type = 'card' AND i.id_resource = c.id
+         LEFT JOIN binary_entries b ON i.type = 'binary' AND i.id_resource = b.id
+WHERE ci.collection_id = $1 AND COALESCE(p.id, n.id, c.id, b.id) IS NOT NULL
+ORDER BY i.created_at DESC, i.id;
+
+-- Only items kept in the vault of the organization resolve to it
+-- name: GetItemAccess :one
+SELECT i.user_id AS vault_id, ci.collection_id, m.role, g.read_only
+FROM items i
+         JOIN collection_items ci ON ci.item_id = i.id_resource
+         JOIN collections c ON c.id = ci.collection_id
+         JOIN organizations o ON o.id = c.org_id AND o.vault_id = i.user_id
+         LEFT JOIN org_members m ON m.org_id = c.org_id AND m.user_id = @user_id
+         LEFT JOIN collection_members g ON g.collection_id = c.id AND g.user_id = @user_id
+WHERE i.id_resource = @item_id;
+
+-- name: GetCollectionAccess :one
+SELECT c.id, c.org_id, o.vault_id, m.role, g.read_only
+FROM collections c
+         JOIN organizations o ON o.id = c.org_id
+         JOIN org_members m ON m.org_id = c.org_id AND m.user_id = @user_id
+         LEFT JOIN collection_members g ON g.collection_id = c.id AND g.user_id = @user_id
+WHERE c.id = @collection_id;
+
+-- name: UpsertEmergencyAccess :one
+-- Changing the grant of a contact drops a pending or approved request
+INSERT INTO emergency_access (grantor_id, grantee_id, access_type, wait_days)
+VALUES (@grantor_id, @grantee_id, @access_type, @wait_days)
+ON CONFLICT (grantor_id, grantee_id) DO UPDATE
+    SET access_type = EXCLUDED.access_type, wait_days = EXCLUDED.wait_days,
+        status = 'idle', requested_at = NULL, approved_at = NULL
+RETURNING *;
+
+-- name: ListEmergencyAccess :many
+SELECT e.*, g.username AS grantor, t.username AS grantee
+FROM emergency_access e
+         JOIN users g ON g.id = e.grantor_id
+         JOIN users t ON t.id = e.grantee_id
+WHERE e.grantor_id = @user_id OR e.grantee_id = @user_id
+ORDER BY e.created_at DESC, e.id;
+
+-- name: GetEmergencyAccess :one
+SELECT e.*, g.username AS grantor, t.username AS grantee
+FROM emergency_access e
+         JOIN users g ON g.id = e.grantor_id
+         JOIN users t ON t.id = e.grantee_id
+WHERE e.id = @id AND (e.grantor_id = @user_id OR e.grantee_id = @user_id);
+
+-- name: DeleteEmergencyAccess :execrows
+DELETE FROM emergency_access
+WHERE id = @id AND (grantor_id = @user_id OR grantee_id = @user_id);
+
+-- name: RequestEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'requested', requested_at = CURRENT_TIMESTAMP
+WHERE id = @id AND grantee_id = @grantee_id AND status = 'idle';
+
+-- name: ApproveEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'approved', approved_at = CURRENT_TIMESTAMP
+WHERE id = @id AND grantor_id = @grantor_id AND status = 'requested';
+
+-- name: RejectEmergencyAccess :execrows
+-- Rejecting also ends access that was approved already
+UPDATE emergency_access
+SET status = 'idle', requested_at = NULL, approved_at = NULL
+WHERE id = @id AND grantor_id = @grantor_id AND status <> 'idle';
+
+-- name: PromoteEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'approved', approved_at = @now::timestamp
+WHERE status = 'requested' AND requested_at + make_interval(days => wait_days) <= @now::timestamp;
+
+-- name: GetEmergencyItemAccess :one
+SELECT e.grantor_id
+FROM emergency_access e
+         JOIN items i ON i.user_id = e.grantor_id
+WHERE i.id_resource = @item_id AND e.grantee_id = @grantee_id
+  AND e.status = 'approved' AND e.access_type = 'view';
+
+-- name: ListVaultItems :many
+SELECT
+    i.id,
+    i.type,
+    i.id_resource,
+    i.created_at,
+    COALESCE(p.updated_at, n.updated_at, c.updated_at, b.updated_at) AS updated_at
+FROM items i
+         LEFT JOIN passwords p ON i.type = 'password' AND i.id_resource = p.id
+         LEFT JOIN notes n ON i.type = 'text' AND i.id_resource = n.id
+         LEFT JOIN cards c ON i.type = 'card' AND i.id_resource = c.id
+         LEFT JOIN binary_entries b ON i.type = 'binary' AND i.id_resource = b.id
+WHERE i.user_id = $1 AND COALESCE(p.id, n.id, c.id, b.id) IS NOT NULL
+ORDER BY i.created_at DESC, i.id;
+
+-- name: TakeOverAccount :execrows
+-- The grantee sets a new password; the second factor goes too, or the grantee could not sign in with it.
+-- The grant returns to idle, so taking over again takes another request.
+WITH grant_used AS (
+    UPDATE emergency_access
+    SET status = 'idle', requested_at = NULL, approved_at = NULL
+    WHERE emergency_access.id = @id AND grantee_id = @grantee_id
+      AND status = 'approved' AND access_type = 'takeover'
+    RETURNING grantor_id
+), codes AS (
+    DELETE FROM recovery_codes WHERE user_id IN (SELECT grantor_id FROM grant_used)
+)
+UPDATE users
+SET password = @new_password, totp_secret = NULL, totp_enabled = false, totp_last_step = 0
+FROM grant_used
+WHERE users.id = grant_used.grantor_id;
+
+-- name: CreateSend :one
+INSERT INTO sends (id, user_id, send_type, content, object_name, file_size, password, max_views, expires_at)
+VALUES (@id, @user_id, @send_type, @content, @object_name, @file_size, @password, @max_views, @expires_at)
+RETURNING *;
+
+-- name: ListSends :many
+SELECT * FROM sends
+WHERE user_id = @user_id
+ORDER BY created_at DESC, id;
+
+-- name: GetSend :one
+SELECT * FROM sends
+WHERE id = @id;
+
+-- name: DeleteSend :one
+DELETE FROM sends
+WHERE id = @id AND user_id = @user_id
+RETURNING *;
+
+-- name: ViewSend :one
+-- Counting the view and checking the limits in one statement lets every view through once
+UPDATE sends
+SET views = views + 1
+WHERE id = @id AND views < max_views AND expires_at > @now::timestamp
+RETURNING *;
+
+-- name: RemoveSend :exec
+DELETE FROM sends
+WHERE id = @id;
+
+-- name: DeleteExpiredSends :many
+DELETE FROM sends
+WHERE expires_at <= @now::timestamp OR views >= max_views
+RETURNING *;
+
+-- name: LockAuditChain :exec
+-- Held until the transaction ends, so appends read the end of the chain and insert after it one at a time
+SELECT pg_advisory_xact_lock(hashtext('audit_events'));
+
+-- name: GetLastAuditEvent :one
+SELECT * FROM audit_events
+ORDER BY seq DESC
+LIMIT 1;
+
+-- name: InsertAuditEvent :exec
+INSERT INTO audit_events (seq, actor_id, item_id, action, ip, user_agent, result, occurred_at, prev_hash, hash)
+VALUES (@seq, @actor_id, @item_id, @action, @ip, @user_agent, @result, @occurred_at, @prev_hash, @hash);
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_events
+WHERE (actor_id = @user_id OR item_id IN (SELECT id_resource FROM items WHERE user_id = @user_id))
+  AND (sqlc.narg(item_id)::uuid IS NULL OR item_id = sqlc.narg(item_id)::uuid)
+  AND (@action::text = '' OR action = @action::text)
+  AND (sqlc.narg(since)::timestamp IS NULL OR occurred_at >= sqlc.narg(since)::timestamp)
+  AND (sqlc.narg(until)::timestamp IS NULL OR occurred_at < sqlc.narg(until)::timestamp)
+ORDER BY seq DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ExportAuditEvents :many
+SELECT * FROM audit_events
+WHERE seq > @after_seq
+ORDER BY seq
+LIMIT @batch_size;
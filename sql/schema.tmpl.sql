@@ -4,13 +4,36 @@ CREATE EXTENSION IF NOT EXISTS pgcrypto;
 -- Create ENUM type for item categories
 CREATE TYPE item_type AS ENUM ('password', 'binary', 'card', 'text');
 
+-- Create ENUM type for the state of user key rotations
+CREATE TYPE key_rotation_status AS ENUM ('running', 'completed', 'failed');
+
+-- Create ENUM type for the roles of organization members
+CREATE TYPE org_role AS ENUM ('owner', 'admin', 'member', 'read_only');
+
+-- Create ENUM types for emergency access grants and their requests
+CREATE TYPE emergency_access_type AS ENUM ('view', 'takeover');
+CREATE TYPE emergency_access_status AS ENUM ('idle', 'requested', 'approved');
+
+-- Create ENUM type for the contents of one-time sends
+CREATE TYPE send_type AS ENUM ('text', 'file');
+
 -- Create users table
 CREATE TABLE users (
                        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
                        username VARCHAR(255) UNIQUE NOT NULL,
                        email VARCHAR(255) UNIQUE NOT NULL,
                        password TEXT NOT NULL,
-                       encryption_key TEXT NOT NULL
+                       encryption_key TEXT NOT NULL,
+                       change_seq BIGINT NOT NULL DEFAULT 0, -- Last change sequence issued to the user
+                       kdf_salt TEXT, -- Zero-knowledge accounts only: Argon2id salt of the master password
+                       wrapped_vault_key TEXT, -- Zero-knowledge accounts only: vault key wrapped by the client
+                       key_version INT NOT NULL DEFAULT 1, -- Version of encryption_key, bumped by every key rotation
+                       previous_encryption_key TEXT, -- Key being rotated out; set while a rotation re-encrypts the vault
+                       legacy_ciphertexts BOOLEAN NOT NULL DEFAULT FALSE, -- Vault may hold values not bound to their records
+                       totp_secret TEXT, -- TOTP secret wrapped with the master key; set by enrollment
+                       totp_enabled BOOLEAN NOT NULL DEFAULT FALSE, -- Whether logins need a second factor
+                       totp_last_step BIGINT NOT NULL DEFAULT 0, -- Last accepted TOTP time step, so codes are used once
+                       organization_vault BOOLEAN NOT NULL DEFAULT FALSE -- Holds the items of an organization; cannot sign in
 );
 
 -- Create orders table
@@ -19,6 +42,8 @@ CREATE TABLE passwords (
                         user_id UUID REFERENCES users(id) ON DELETE CASCADE,
                         login VARCHAR(255) NOT NULL,
                         password TEXT NOT NULL,
+                        version BIGINT NOT NULL DEFAULT 1,  -- Incremented on every update for optimistic locking
+                        key_version INT NOT NULL DEFAULT 1,  -- Version of the user key the row is encrypted with
                         created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
                         updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
@@ -29,6 +54,8 @@ CREATE TABLE binary_entries (
                         file_name VARCHAR(255) NOT NULL,
                         file_size BIGINT NOT NULL,  -- File size in bytes
                         file_url TEXT NOT NULL,  -- URL to MinIO storage
+                        version BIGINT NOT NULL DEFAULT 1,  -- Incremented on every update for optimistic locking
+                        key_version INT NOT NULL DEFAULT 1,  -- Version of the user key the row is encrypted with
                         created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
                         updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
@@ -41,6 +68,8 @@ CREATE TABLE cards (
                        encrypted_expiry_date TEXT NOT NULL,  -- Encrypted
                        encrypted_cvv TEXT NOT NULL,          -- Encrypted
                        cardholder_name VARCHAR(255) NOT NULL, -- Cardholder name is stored in plaintext
+                       version BIGINT NOT NULL DEFAULT 1,  -- Incremented on every update for optimistic locking
+                       key_version INT NOT NULL DEFAULT 1,  -- Version of the user key the row is encrypted with
                        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
                        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
@@ -49,6 +78,8 @@ CREATE TABLE notes (
                        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
                        user_id UUID REFERENCES users(id) ON DELETE CASCADE,
                        encrypted_content TEXT NOT NULL, -- Encrypted
+                       version BIGINT NOT NULL DEFAULT 1,  -- Incremented on every update for optimistic locking
+                       key_version INT NOT NULL DEFAULT 1,  -- Version of the user key the row is encrypted with
                        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
                        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
@@ -174,10 +205,293 @@ CREATE TABLE metainfo (
           CONSTRAINT unique_item_key UNIQUE (item_id, key)  -- Prevent duplicate keys for the same item
 );
 
+-- A signed-in device; revoking it removes its refresh tokens
+CREATE TABLE sessions (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        device_name TEXT NOT NULL DEFAULT '',
+        ip_address TEXT NOT NULL DEFAULT '',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        last_used_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, -- Last login or token refresh
+        expires_at TIMESTAMP NOT NULL -- Expiration of the latest refresh token
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
+
 CREATE TABLE refresh_tokens (
         id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
         user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- Link to the user
-        token TEXT NOT NULL UNIQUE,  -- Securely store the refresh token
         expires_at TIMESTAMP NOT NULL,  -- Expiration time
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        session_id UUID REFERENCES sessions(id) ON DELETE CASCADE, -- Unset for tokens issued before sessions
+        token_hash TEXT NOT NULL UNIQUE, -- SHA-256 of the refresh token, which is not stored
+        used_at TIMESTAMP -- Set once exchanged; presenting it again revokes the session
+);
+
+-- Latest change of every item; deleted items stay behind as tombstones
+CREATE TABLE item_changes (
+        user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        item_id UUID NOT NULL,  -- Resource ID of the item
+        type item_type NOT NULL,
+        seq BIGINT NOT NULL,  -- Per-user monotonically increasing change sequence
+        deleted BOOLEAN NOT NULL DEFAULT FALSE,
+        changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (user_id, item_id)
+);
+
+CREATE UNIQUE INDEX idx_item_changes_user_seq ON item_changes (user_id, seq);
+
+-- User key rotations and the progress of re-encrypting the vault
+CREATE TABLE key_rotations (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        from_version INT NOT NULL,
+        to_version INT NOT NULL,
+        status key_rotation_status NOT NULL DEFAULT 'running',
+        total_items BIGINT NOT NULL DEFAULT 0,  -- Rows and files to re-encrypt when the rotation started
+        done_items BIGINT NOT NULL DEFAULT 0,
+        error TEXT,  -- Why a failed rotation stopped
+        started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        finished_at TIMESTAMP,
+        binds_values BOOLEAN NOT NULL DEFAULT TRUE  -- Whether every value it leaves behind is bound to its record
+);
+
+CREATE INDEX idx_key_rotations_user_started ON key_rotations (user_id, started_at);
+
+-- Single-use codes replacing a TOTP code, stored hashed
+CREATE TABLE recovery_codes (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        code_hash TEXT NOT NULL,
+        used_at TIMESTAMP,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_recovery_codes_user_id ON recovery_codes (user_id);
+
+-- Keys signing access tokens, shared by every server replica
+CREATE TABLE signing_keys (
+        id TEXT PRIMARY KEY,  -- The kid header of the tokens it signs
+        algorithm TEXT NOT NULL,  -- EdDSA or ES256
+        private_key TEXT NOT NULL,  -- PKCS #8, wrapped by the key provider like user keys
+        public_key TEXT NOT NULL,  -- PKIX, base64
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        activates_at TIMESTAMP NOT NULL  -- Signs from then on; published in the JWKS before
+);
+
+-- Personal access tokens for automation, limited to a scope
+CREATE TABLE api_tokens (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        name TEXT NOT NULL,
+        token_hash TEXT NOT NULL UNIQUE,  -- SHA-256 of the token, which is not stored
+        read_only BOOLEAN NOT NULL DEFAULT TRUE,
+        item_types TEXT[] NOT NULL DEFAULT '{}',  -- Item types the token reaches; every type when empty
+        tags JSONB NOT NULL DEFAULT '{}',  -- Metadata every item the token reaches carries
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        expires_at TIMESTAMP NOT NULL,
+        last_used_at TIMESTAMP,
+        CONSTRAINT unique_api_token_name UNIQUE (user_id, name)
+);
+
+-- X25519 keypairs items are shared to; created the first time an item is shared with the user
+CREATE TABLE share_keys (
+        user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+        public_key TEXT NOT NULL,  -- Raw X25519 public key, base64
+        private_key TEXT NOT NULL,  -- Wrapped by the user key like vault values
+        key_version INT NOT NULL DEFAULT 1,  -- Version of the user key the private key is wrapped with
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Items shared with other users, sealed to the share key of the recipient
+CREATE TABLE shares (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        item_id UUID NOT NULL REFERENCES items(id_resource) ON DELETE CASCADE,
+        owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        recipient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        read_only BOOLEAN NOT NULL DEFAULT TRUE,
+        payload TEXT NOT NULL,  -- The item with its metadata, sealed to the recipient
+        item_seq BIGINT NOT NULL,  -- Change sequence of the item when the payload was sealed
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        CONSTRAINT unique_share_recipient UNIQUE (item_id, recipient_id)
+);
+
+CREATE INDEX idx_shares_recipient_id ON shares (recipient_id);
+
+-- Organizations keep their items in a vault of their own: a users row without a password
+CREATE TABLE organizations (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        name VARCHAR(255) NOT NULL,
+        vault_id UUID UNIQUE NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE org_members (
+        org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+        user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        role org_role NOT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX idx_org_members_user_id ON org_members (user_id);
+
+CREATE TABLE collections (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+        name VARCHAR(255) NOT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        CONSTRAINT unique_collection_name UNIQUE (org_id, name)
+);
+
+-- Access of members to collections; owners and admins reach every collection without one
+CREATE TABLE collection_members (
+        collection_id UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+        org_id UUID NOT NULL,
+        user_id UUID NOT NULL,
+        read_only BOOLEAN NOT NULL DEFAULT TRUE,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (collection_id, user_id),
+        -- Leaving the organization takes the access away
+        FOREIGN KEY (org_id, user_id) REFERENCES org_members (org_id, user_id) ON DELETE CASCADE
+);
+
+-- Collection of every item of an organization vault
+CREATE TABLE collection_items (
+        item_id UUID PRIMARY KEY REFERENCES items(id_resource) ON DELETE CASCADE,  -- Linked when the item is stored
+        collection_id UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
         created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
-);
\ No newline at end of file
+);
+
+CREATE INDEX idx_collection_items_collection_id ON collection_items (collection_id);
+
+-- Trusted contacts of a user, who get access to the vault once a request went unanswered for wait_days
+CREATE TABLE emergency_access (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        grantor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        grantee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        access_type emergency_access_type NOT NULL,
+        wait_days INT NOT NULL,
+        status emergency_access_status NOT NULL DEFAULT 'idle',
+        requested_at TIMESTAMP,  -- Set while a request is pending or approved
+        approved_at TIMESTAMP,  -- Set once the request is approved, by the grantor or after the wait
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        CONSTRAINT unique_emergency_contact UNIQUE (grantor_id, grantee_id)
+);
+
+CREATE INDEX idx_emergency_access_grantee_id ON emergency_access (grantee_id);
+CREATE INDEX idx_emergency_access_requested_at ON emergency_access (requested_at) WHERE status = 'requested';
+
+-- One-time sends, sealed with a key the server hands out once and does not keep
+CREATE TABLE sends (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+        send_type send_type NOT NULL,
+        content TEXT NOT NULL,  -- Sealed text, or the sealed name of the file
+        object_name TEXT,  -- Files only: object holding the sealed contents
+        file_size BIGINT NOT NULL DEFAULT 0,
+        password TEXT,  -- Hash of the password recipients must give, if any
+        max_views INT NOT NULL,
+        views INT NOT NULL DEFAULT 0,
+        expires_at TIMESTAMP NOT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sends_user_id ON sends (user_id);
+CREATE INDEX idx_sends_expires_at ON sends (expires_at);
+
+-- Audit log of every RPC. Each event hashes the one before it, so editing or removing events breaks the
+-- chain. Events keep the IDs of deleted users and items, so they have no foreign keys.
+CREATE TABLE audit_events (
+        seq BIGINT PRIMARY KEY,  -- Position in the chain, starting at 1
+        actor_id UUID,  -- Calling user, unknown for calls without credentials
+        item_id UUID,  -- Vault item the call named, if any
+        action TEXT NOT NULL,  -- Full gRPC method name
+        ip TEXT NOT NULL,
+        user_agent TEXT NOT NULL,
+        result TEXT NOT NULL,  -- gRPC status code
+        occurred_at TIMESTAMP NOT NULL,
+        prev_hash TEXT NOT NULL,  -- Hash of the event before, empty for the first one
+        hash TEXT NOT NULL
+);
+
+CREATE INDEX idx_audit_events_actor_id ON audit_events (actor_id, seq);
+
+DROP FUNCTION IF EXISTS record_item_change();
+DROP FUNCTION IF EXISTS record_meta_change();
+
+-- Function to record inserts, updates and deletes of any item type
+CREATE FUNCTION record_item_change() RETURNS TRIGGER AS $$
+DECLARE
+    rec RECORD;
+    next_seq BIGINT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        rec := OLD;
+    ELSE
+        rec := NEW;
+    END IF;
+
+    UPDATE users SET change_seq = change_seq + 1 WHERE id = rec.user_id RETURNING change_seq INTO next_seq;
+
+    INSERT INTO item_changes (user_id, item_id, type, seq, deleted)
+    VALUES (rec.user_id, rec.id, TG_ARGV[0]::item_type, next_seq, TG_OP = 'DELETE')
+    ON CONFLICT (user_id, item_id) DO UPDATE
+        SET seq = EXCLUDED.seq, deleted = EXCLUDED.deleted, changed_at = CURRENT_TIMESTAMP;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Function to record metadata changes as changes of the owning item
+CREATE FUNCTION record_meta_change() RETURNS TRIGGER AS $$
+DECLARE
+    target UUID;
+    owner UUID;
+    next_seq BIGINT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target := OLD.item_id;
+    ELSE
+        target := NEW.item_id;
+    END IF;
+
+    SELECT user_id INTO owner FROM items WHERE id_resource = target;
+    IF owner IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    UPDATE users SET change_seq = change_seq + 1 WHERE id = owner RETURNING change_seq INTO next_seq;
+
+    UPDATE item_changes SET seq = next_seq, changed_at = CURRENT_TIMESTAMP
+    WHERE user_id = owner AND item_id = target AND NOT deleted;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS record_password_change ON passwords;
+DROP TRIGGER IF EXISTS record_note_change ON notes;
+DROP TRIGGER IF EXISTS record_card_change ON cards;
+DROP TRIGGER IF EXISTS record_binary_change ON binary_entries;
+DROP TRIGGER IF EXISTS record_meta_change ON metainfo;
+
+CREATE TRIGGER record_password_change
+    AFTER INSERT OR UPDATE OR DELETE ON passwords
+    FOR EACH ROW EXECUTE FUNCTION record_item_change('password');
+
+CREATE TRIGGER record_note_change
+    AFTER INSERT OR UPDATE OR DELETE ON notes
+    FOR EACH ROW EXECUTE FUNCTION record_item_change('text');
+
+CREATE TRIGGER record_card_change
+    AFTER INSERT OR UPDATE OR DELETE ON cards
+    FOR EACH ROW EXECUTE FUNCTION record_item_change('card');
+
+CREATE TRIGGER record_binary_change
+    AFTER INSERT OR UPDATE OR DELETE ON binary_entries
+    FOR EACH ROW EXECUTE FUNCTION record_item_change('binary');
+
+CREATE TRIGGER record_meta_change
+    AFTER INSERT OR UPDATE OR DELETE ON metainfo
+    FOR EACH ROW EXECUTE FUNCTION record_meta_change();